@@ -19,10 +19,10 @@ import (
 // usage prints command-line usage. If mf.printHelpAll is false then only basic flags are included, otherwise all flags are included.
 func usage(mf *mainFlags, cfg *mimir.Config) error {
 	fields := map[uintptr]reflect.StructField{}
-	if err := parseStructure(mf, fields); err != nil {
+	if err := parseStructure(mf, "", fields); err != nil {
 		return err
 	}
-	if err := parseStructure(cfg, fields); err != nil {
+	if err := parseStructure(cfg, "", fields); err != nil {
 		return err
 	}
 
@@ -108,8 +108,9 @@ func isZeroValue(fl *flag.Flag, value string) bool {
 	return value == z.Interface().(flag.Value).String()
 }
 
-// parseStructure parses a struct and populates fields.
-func parseStructure(structure interface{}, fields map[uintptr]reflect.StructField) error {
+// parseStructure parses a struct and populates fields. The fields with no category inherit the
+// input category, which is the category of the parent struct field, if any.
+func parseStructure(structure interface{}, category string, fields map[uintptr]reflect.StructField) error {
 	// structure is expected to be a pointer to a struct
 	if reflect.TypeOf(structure).Kind() != reflect.Ptr {
 		t := reflect.TypeOf(structure)
@@ -130,6 +131,10 @@ func parseStructure(structure interface{}, fields map[uintptr]reflect.StructFiel
 
 		fieldValue := v.FieldByIndex(field.Index)
 
+		if category != "" && field.Tag.Get("category") == "" {
+			field.Tag = reflect.StructTag(fmt.Sprintf(`%s category:"%s"`, field.Tag, category))
+		}
+
 		// Take address of field value and map it to field
 		fields[fieldValue.Addr().Pointer()] = field
 
@@ -138,7 +143,7 @@ func parseStructure(structure interface{}, fields map[uintptr]reflect.StructFiel
 			continue
 		}
 
-		if err := parseStructure(fieldValue.Addr().Interface(), fields); err != nil {
+		if err := parseStructure(fieldValue.Addr().Interface(), field.Tag.Get("category"), fields); err != nil {
 			return err
 		}
 	}
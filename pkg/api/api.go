@@ -40,6 +40,7 @@ import (
 	"github.com/grafana/mimir/pkg/scheduler/schedulerpb"
 	"github.com/grafana/mimir/pkg/storegateway"
 	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	util_log "github.com/grafana/mimir/pkg/util/log"
 	"github.com/grafana/mimir/pkg/util/push"
 )
@@ -258,6 +259,8 @@ type Ingester interface {
 	client.IngesterServer
 	FlushHandler(http.ResponseWriter, *http.Request)
 	ShutdownHandler(http.ResponseWriter, *http.Request)
+	PrepareDownscaleHandler(http.ResponseWriter, *http.Request)
+	StartupProgressHandler(http.ResponseWriter, *http.Request)
 	PushWithCleanup(context.Context, *mimirpb.WriteRequest, func()) (*mimirpb.WriteResponse, error)
 }
 
@@ -268,10 +271,13 @@ func (a *API) RegisterIngester(i Ingester, pushConfig distributor.Config) {
 	a.indexPage.AddLinks(dangerousWeight, "Dangerous", []IndexPageLink{
 		{Dangerous: true, Desc: "Trigger a flush of data from ingester to storage", Path: "/ingester/flush"},
 		{Dangerous: true, Desc: "Trigger ingester shutdown", Path: "/ingester/shutdown"},
+		{Dangerous: true, Desc: "Prepare ingester for downscale", Path: "/ingester/prepare-downscale"},
 	})
 
 	a.RegisterRoute("/ingester/flush", http.HandlerFunc(i.FlushHandler), false, true, "GET", "POST")
 	a.RegisterRoute("/ingester/shutdown", http.HandlerFunc(i.ShutdownHandler), false, true, "GET", "POST")
+	a.RegisterRoute("/ingester/prepare-downscale", http.HandlerFunc(i.PrepareDownscaleHandler), false, true, "GET", "POST")
+	a.RegisterRoute("/ingester/startup-progress", http.HandlerFunc(i.StartupProgressHandler), false, true, "GET")
 	a.RegisterRoute("/ingester/push", push.Handler(pushConfig.MaxRecvMsgSize, a.sourceIPs, a.cfg.SkipLabelNameValidationHeader, i.PushWithCleanup), true, false, "POST") // For testing and debugging.
 }
 
@@ -362,6 +368,14 @@ func (a *API) RegisterCompactor(c *compactor.MultitenantCompactor) {
 	a.RegisterRoute("/compactor/ring", http.HandlerFunc(c.RingHandler), false, true, "GET", "POST")
 }
 
+// RegisterUsageMetering registers the HTTP endpoints serving the usage reports.
+func (a *API) RegisterUsageMetering(r *usagemetering.Recorder) {
+	a.indexPage.AddLinks(defaultWeight, "Usage metering", []IndexPageLink{
+		{Desc: "Usage reports", Path: "/usage-metering/reports"},
+	})
+	a.RegisterRoute("/usage-metering/reports", http.HandlerFunc(r.ReportsHandler), false, true, "GET")
+}
+
 type Distributor interface {
 	querier.Distributor
 	UserStatsHandler(w http.ResponseWriter, r *http.Request)
@@ -390,6 +404,9 @@ func (a *API) RegisterQueryAPI(handler http.Handler, buildInfoHandler http.Handl
 	a.RegisterRoute(path.Join(a.cfg.PrometheusHTTPPrefix, "/api/v1/metadata"), handler, true, true, "GET")
 	a.RegisterRoute(path.Join(a.cfg.PrometheusHTTPPrefix, "/api/v1/cardinality/label_names"), handler, true, true, "GET", "POST")
 	a.RegisterRoute(path.Join(a.cfg.PrometheusHTTPPrefix, "/api/v1/cardinality/label_values"), handler, true, true, "GET", "POST")
+	a.RegisterRoute(path.Join(a.cfg.PrometheusHTTPPrefix, "/api/v1/cardinality/active_series"), handler, true, true, "GET", "POST")
+	a.RegisterRoute(path.Join(a.cfg.PrometheusHTTPPrefix, "/api/v1/cardinality/series_churn"), handler, true, true, "GET", "POST")
+	a.RegisterRoute(path.Join(a.cfg.PrometheusHTTPPrefix, "/api/v1/status/tsdb"), handler, true, true, "GET")
 }
 
 // RegisterQueryFrontend registers the Prometheus routes supported by the
@@ -399,6 +416,12 @@ func (a *API) RegisterQueryFrontendHandler(h http.Handler, buildInfoHandler http
 	a.RegisterQueryAPI(h, buildInfoHandler)
 }
 
+// RegisterQueryFrontendResultsCacheInvalidation registers the endpoint used to invalidate
+// the query-frontend results cache of a tenant.
+func (a *API) RegisterQueryFrontendResultsCacheInvalidation(h http.Handler) {
+	a.RegisterRoute("/query-frontend/invalidate_results_cache", h, true, true, "POST")
+}
+
 func (a *API) RegisterQueryFrontend1(f *frontendv1.Frontend) {
 	frontendv1pb.RegisterFrontendServer(a.server.GRPC, f)
 }
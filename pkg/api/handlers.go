@@ -167,6 +167,8 @@ func NewQuerierHandler(
 	exemplarQueryable storage.ExemplarQueryable,
 	engine *promql.Engine,
 	distributor Distributor,
+	blocksCardinality querier.BlocksCardinalityQueryable,
+	blocksMetadata querier.BlocksMetadataQueryable,
 	reg prometheus.Registerer,
 	logger log.Logger,
 	limits *validation.Overrides,
@@ -199,9 +201,14 @@ func NewQuerierHandler(
 		Help:      "Current number of inflight requests to the querier.",
 	}, []string{"method", "route"})
 
+	// Query results can be requested encoded as protobuf by the query-frontend.
+	codec := protobufCodec{}
+	queryEngine := errorTranslateQueryEngine{codecQueryEngine{engine: engine, codec: codec}}
+	translatedQueryable := querier.NewErrorTranslateSampleAndChunkQueryable(queryable) // Translate errors to errors expected by API.
+
 	api := v1.NewAPI(
-		errorTranslateQueryEngine{engine},
-		querier.NewErrorTranslateSampleAndChunkQueryable(queryable), // Translate errors to errors expected by API.
+		queryEngine,
+		translatedQueryable,
 		nil, // No remote write support.
 		exemplarQueryable,
 		func(context.Context) v1.TargetRetriever { return &querier.DummyTargetRetriever{} },
@@ -238,26 +245,34 @@ func NewQuerierHandler(
 	}
 	router.Use(instrumentMiddleware.Wrap)
 
+	// Partial results can be requested per-query.
+	router.Use(querier.PartialResultsMiddleware)
+
 	// Define the prefixes for all routes
 	prefix := path.Join(cfg.ServerPrefix, cfg.PrometheusHTTPPrefix)
 
 	promRouter := route.New().WithPrefix(path.Join(prefix, "/api/v1"))
 	api.Register(promRouter)
 
+	queryHandler := codecMiddleware(codec, promRouter)
+
 	// TODO(gotjosh): This custom handler is temporary until we're able to vendor the changes in:
 	// https://github.com/prometheus/prometheus/pull/7125/files
-	router.Path(path.Join(prefix, "/api/v1/metadata")).Handler(querier.MetadataHandler(distributor))
+	router.Path(path.Join(prefix, "/api/v1/metadata")).Handler(querier.MetadataHandler(distributor, blocksMetadata, logger))
 	router.Path(path.Join(prefix, "/api/v1/read")).Handler(querier.RemoteReadHandler(queryable, logger))
 	router.Path(path.Join(prefix, "/api/v1/read")).Methods("POST").Handler(promRouter)
-	router.Path(path.Join(prefix, "/api/v1/query")).Methods("GET", "POST").Handler(promRouter)
-	router.Path(path.Join(prefix, "/api/v1/query_range")).Methods("GET", "POST").Handler(promRouter)
+	router.Path(path.Join(prefix, "/api/v1/query")).Methods("GET", "POST").Handler(queryHandler)
+	router.Path(path.Join(prefix, "/api/v1/query_range")).Methods("GET", "POST").Handler(queryHandler)
 	router.Path(path.Join(prefix, "/api/v1/query_exemplars")).Methods("GET", "POST").Handler(promRouter)
 	router.Path(path.Join(prefix, "/api/v1/labels")).Methods("GET", "POST").Handler(promRouter)
 	router.Path(path.Join(prefix, "/api/v1/label/{name}/values")).Methods("GET").Handler(promRouter)
 	router.Path(path.Join(prefix, "/api/v1/series")).Methods("GET", "POST", "DELETE").Handler(promRouter)
 	router.Path(path.Join(prefix, "/api/v1/metadata")).Methods("GET").Handler(promRouter)
-	router.Path(path.Join(prefix, "/api/v1/cardinality/label_names")).Methods("GET", "POST").Handler(querier.LabelNamesCardinalityHandler(distributor, limits))
-	router.Path(path.Join(prefix, "/api/v1/cardinality/label_values")).Methods("GET", "POST").Handler(querier.LabelValuesCardinalityHandler(distributor, limits))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/label_names")).Methods("GET", "POST").Handler(querier.LabelNamesCardinalityHandler(distributor, blocksCardinality, limits))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/label_values")).Methods("GET", "POST").Handler(querier.LabelValuesCardinalityHandler(distributor, blocksCardinality, limits))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/active_series")).Methods("GET", "POST").Handler(querier.ActiveSeriesHandler(distributor, limits))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/series_churn")).Methods("GET", "POST").Handler(querier.SeriesChurnHandler(distributor, limits))
+	router.Path(path.Join(prefix, "/api/v1/status/tsdb")).Methods("GET").Handler(querier.TSDBStatusHandler(distributor, limits))
 
 	// Track execution time.
 	return stats.NewWallTimeMiddleware().Wrap(router)
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/storage"
+	v1 "github.com/prometheus/prometheus/web/api/v1"
+
+	"github.com/grafana/mimir/pkg/mimirpb"
+)
+
+// protobufCodec encodes the results of the instant and range queries as a mimirpb.QueryResponse.
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string {
+	return mimirpb.QueryResponseMimeType
+}
+
+// CanEncode returns whether the input request accepts the results encoded by the codec.
+func (c protobufCodec) CanEncode(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err == nil && mediaType == c.ContentType() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (protobufCodec) Encode(res *promql.Result) ([]byte, error) {
+	resp := mimirpb.QueryResponse{ResultType: string(res.Value.Type())}
+
+	switch v := res.Value.(type) {
+	case promql.Matrix:
+		resp.Result = make([]mimirpb.TimeSeries, 0, len(v))
+		for _, series := range v {
+			samples := make([]mimirpb.Sample, 0, len(series.Points))
+			for _, p := range series.Points {
+				samples = append(samples, mimirpb.Sample{TimestampMs: p.T, Value: p.V})
+			}
+			resp.Result = append(resp.Result, mimirpb.TimeSeries{
+				Labels:  mimirpb.FromLabelsToLabelAdapters(series.Metric),
+				Samples: samples,
+			})
+		}
+
+	case promql.Vector:
+		resp.Result = make([]mimirpb.TimeSeries, 0, len(v))
+		for _, sample := range v {
+			resp.Result = append(resp.Result, mimirpb.TimeSeries{
+				Labels:  mimirpb.FromLabelsToLabelAdapters(sample.Metric),
+				Samples: []mimirpb.Sample{{TimestampMs: sample.T, Value: sample.V}},
+			})
+		}
+
+	case promql.Scalar:
+		resp.Result = []mimirpb.TimeSeries{{
+			Samples: []mimirpb.Sample{{TimestampMs: v.T, Value: v.V}},
+		}}
+
+	case promql.String:
+		resp.Result = []mimirpb.TimeSeries{{
+			Labels:  []mimirpb.LabelAdapter{{Name: "value", Value: v.V}},
+			Samples: []mimirpb.Sample{{TimestampMs: v.T}},
+		}}
+
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", res.Value.Type())
+	}
+
+	for _, warning := range res.Warnings {
+		resp.Warnings = append(resp.Warnings, warning.Error())
+	}
+	return resp.Marshal()
+}
+
+type encodedResultContextKey int
+
+const encodedResultKey encodedResultContextKey = 0
+
+// encodedResult holds the query result encoded by the codec while the Prometheus API serves the request.
+type encodedResult struct {
+	body []byte
+}
+
+// codecMiddleware serves the results of the queries encoded by the codec, when the request accepts them.
+// The request is still served by the Prometheus API, so that parameters validation and errors are unchanged:
+// the query engine, wrapped by codecQueryEngine, encodes the result as soon as the query has been executed,
+// and the successful JSON response written by the Prometheus API is then replaced with the encoded one.
+func codecMiddleware(codec protobufCodec, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !codec.CanEncode(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		encoded := &encodedResult{}
+		r = r.WithContext(context.WithValue(r.Context(), encodedResultKey, encoded))
+		next.ServeHTTP(&codecResponseWriter{ResponseWriter: w, codec: codec, encoded: encoded}, r)
+	})
+}
+
+// codecResponseWriter replaces a successful response with the encoded query result, if any.
+type codecResponseWriter struct {
+	http.ResponseWriter
+
+	codec    protobufCodec
+	encoded  *encodedResult
+	replaced bool
+	written  bool
+}
+
+func (w *codecResponseWriter) WriteHeader(statusCode int) {
+	if statusCode == http.StatusOK && w.encoded.body != nil {
+		w.replaced = true
+		w.Header().Set("Content-Type", w.codec.ContentType())
+		w.Header().Set("Content-Length", strconv.Itoa(len(w.encoded.body)))
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *codecResponseWriter) Write(b []byte) (int, error) {
+	if !w.replaced {
+		return w.ResponseWriter.Write(b)
+	}
+
+	// The JSON response is discarded, and the encoded result is written in its place.
+	if !w.written {
+		w.written = true
+		if _, err := w.ResponseWriter.Write(w.encoded.body); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// codecQueryEngine wraps a query engine, encoding the result of each query whose request accepts it.
+type codecQueryEngine struct {
+	engine v1.QueryEngine
+	codec  protobufCodec
+}
+
+func (qe codecQueryEngine) SetQueryLogger(l promql.QueryLogger) {
+	qe.engine.SetQueryLogger(l)
+}
+
+func (qe codecQueryEngine) NewInstantQuery(q storage.Queryable, qs string, ts time.Time) (promql.Query, error) {
+	query, err := qe.engine.NewInstantQuery(q, qs, ts)
+	if err != nil {
+		return nil, err
+	}
+	return codecQuery{Query: query, codec: qe.codec}, nil
+}
+
+func (qe codecQueryEngine) NewRangeQuery(q storage.Queryable, qs string, start, end time.Time, interval time.Duration) (promql.Query, error) {
+	query, err := qe.engine.NewRangeQuery(q, qs, start, end, interval)
+	if err != nil {
+		return nil, err
+	}
+	return codecQuery{Query: query, codec: qe.codec}, nil
+}
+
+type codecQuery struct {
+	promql.Query
+
+	codec protobufCodec
+}
+
+// Exec runs the query and, if the result has been requested encoded, encodes it before
+// the query is closed, because closing the query releases the memory of the result.
+func (q codecQuery) Exec(ctx context.Context) *promql.Result {
+	res := q.Query.Exec(ctx)
+
+	encoded, ok := ctx.Value(encodedResultKey).(*encodedResult)
+	if !ok || res.Err != nil {
+		return res
+	}
+
+	body, err := q.codec.Encode(res)
+	if err != nil {
+		return &promql.Result{Err: err, Warnings: res.Warnings}
+	}
+	encoded.body = body
+
+	// The Prometheus API response will be replaced with the encoded result, so
+	// there's no need to pass the whole result to the Prometheus API.
+	return &promql.Result{Value: emptyValue(res.Value), Warnings: res.Warnings}
+}
+
+func emptyValue(v parser.Value) parser.Value {
+	switch v.(type) {
+	case promql.Matrix:
+		return promql.Matrix{}
+	case promql.Vector:
+		return promql.Vector{}
+	default:
+		return v
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/mimir/pkg/mimirpb"
+)
+
+func TestQuerierHandler_ProtobufCodec(t *testing.T) {
+	const protobufAccept = mimirpb.QueryResponseMimeType + ",application/json"
+
+	seriesLabels := []mimirpb.LabelAdapter{{Name: "__name__", Value: "foo"}, {Name: "bar", Value: "baz"}}
+
+	tests := map[string]struct {
+		url                 string
+		accept              string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedResponse    *mimirpb.QueryResponse
+	}{
+		"should encode the result as JSON if protobuf is not accepted": {
+			url:                 "/api/v1/query?query=foo&time=20",
+			accept:              "application/json",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+		},
+		"should return invalid parameters errors as JSON": {
+			url:                 "/api/v1/query_range?query=foo&start=20&end=10&step=10",
+			accept:              protobufAccept,
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: "application/json",
+		},
+		"should return query parsing errors as JSON": {
+			url:                 "/api/v1/query?query=foo{&time=20",
+			accept:              protobufAccept,
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: "application/json",
+		},
+		"should return execution errors as JSON": {
+			url:                 "/api/v1/query?query=foo&time=20&timeout=0.000000001",
+			accept:              protobufAccept,
+			expectedStatusCode:  http.StatusServiceUnavailable,
+			expectedContentType: "application/json",
+		},
+		"should encode the result of an instant query as protobuf": {
+			url:                 "/api/v1/query?query=foo&time=20",
+			accept:              protobufAccept,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: mimirpb.QueryResponseMimeType,
+			expectedResponse: &mimirpb.QueryResponse{
+				ResultType: model.ValVector.String(),
+				Result: []mimirpb.TimeSeries{
+					{Labels: seriesLabels, Samples: []mimirpb.Sample{{TimestampMs: 20_000, Value: 2}}},
+				},
+			},
+		},
+		"should encode the result of a scalar instant query as protobuf": {
+			url:                 "/api/v1/query?query=1&time=20",
+			accept:              protobufAccept,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: mimirpb.QueryResponseMimeType,
+			expectedResponse: &mimirpb.QueryResponse{
+				ResultType: model.ValScalar.String(),
+				Result: []mimirpb.TimeSeries{
+					{Samples: []mimirpb.Sample{{TimestampMs: 20_000, Value: 1}}},
+				},
+			},
+		},
+		"should encode the result of a range query as protobuf": {
+			url:                 "/api/v1/query_range?query=foo&start=10&end=20&step=10",
+			accept:              protobufAccept,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: mimirpb.QueryResponseMimeType,
+			expectedResponse: &mimirpb.QueryResponse{
+				ResultType: model.ValMatrix.String(),
+				Result: []mimirpb.TimeSeries{
+					{Labels: seriesLabels, Samples: []mimirpb.Sample{{TimestampMs: 10_000, Value: 1}, {TimestampMs: 20_000, Value: 2}}},
+				},
+			},
+		},
+	}
+
+	test, err := promql.NewTest(t, `
+		load 10s
+			foo{bar="baz"} 0 1 2
+	`)
+	require.NoError(t, err)
+	t.Cleanup(test.Close)
+	require.NoError(t, test.Run())
+
+	engine := promql.NewEngine(promql.EngineOpts{
+		Logger:     log.NewNopLogger(),
+		Timeout:    10 * time.Second,
+		MaxSamples: 1e6,
+	})
+
+	handler := NewQuerierHandler(Config{}, test.Storage(), test.ExemplarQueryable(), engine, nil, nil, nil, prometheus.NewPedanticRegistry(), log.NewNopLogger(), nil)
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req := httptest.NewRequest("GET", testData.url, nil)
+			req.Header.Set("Accept", testData.accept)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			res := recorder.Result()
+			defer func() { _ = res.Body.Close() }()
+
+			assert.Equal(t, testData.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, testData.expectedContentType, res.Header.Get("Content-Type"))
+
+			if testData.expectedResponse == nil {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			actual := &mimirpb.QueryResponse{}
+			require.NoError(t, actual.Unmarshal(body))
+			assert.Equal(t, testData.expectedResponse, actual)
+		})
+	}
+}
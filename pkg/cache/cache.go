@@ -20,23 +20,30 @@ type Cache = cache.Cache
 
 const (
 	BackendMemcached = "memcached"
+	BackendRedis     = "redis"
 )
 
 type BackendConfig struct {
 	Backend   string          `yaml:"backend"`
 	Memcached MemcachedConfig `yaml:"memcached"`
+	Redis     RedisConfig     `yaml:"redis"`
 }
 
 // Validate the config.
 func (cfg *BackendConfig) Validate() error {
-	if cfg.Backend != "" && cfg.Backend != BackendMemcached {
-		return fmt.Errorf("unsupported cache backend: %s", cfg.Backend)
-	}
-
-	if cfg.Backend == BackendMemcached {
+	switch cfg.Backend {
+	case "":
+		// No caching.
+	case BackendMemcached:
 		if err := cfg.Memcached.Validate(); err != nil {
 			return err
 		}
+	case BackendRedis:
+		if err := cfg.Redis.Validate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported cache backend: %s", cfg.Backend)
 	}
 
 	return nil
@@ -55,6 +62,13 @@ func CreateClient(cacheName string, cfg BackendConfig, logger log.Logger, reg pr
 		}
 		return cache.NewMemcachedCache(cacheName, logger, client, reg), nil
 
+	case BackendRedis:
+		client, err := NewRedisClient(logger, cacheName, cfg.Redis, reg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create redis client")
+		}
+		return client, nil
+
 	default:
 		return nil, errors.Errorf("unsupported cache type for cache %s: %s", cacheName, cfg.Backend)
 	}
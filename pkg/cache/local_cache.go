@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/thanos-io/thanos/pkg/cacheutil"
+)
+
+var (
+	errInvalidLocalCacheTTL = errors.New("the local cache TTL must be greater than 0")
+)
+
+// LocalCacheConfig is the config of the in-process LRU cache which can be layered in front
+// of a remote cache backend, to avoid fetching the hot keys from the remote cache over and over.
+type LocalCacheConfig struct {
+	MaxSizeBytes uint64        `yaml:"max_size_bytes" category:"experimental"`
+	TTL          time.Duration `yaml:"ttl" category:"experimental"`
+}
+
+func (cfg *LocalCacheConfig) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix string) {
+	f.Uint64Var(&cfg.MaxSizeBytes, prefix+"max-size-bytes", 0, "Maximum size in bytes of the in-process LRU cache layered in front of the remote cache backend. Items are always stored in both caches, and fetched from the remote cache only if missing in the in-process one. 0 to disable the in-process cache.")
+	f.DurationVar(&cfg.TTL, prefix+"ttl", time.Minute, "Maximum time an item is kept in the in-process LRU cache layered in front of the remote cache backend.")
+}
+
+// Enabled returns whether the local cache is enabled.
+func (cfg *LocalCacheConfig) Enabled() bool {
+	return cfg.MaxSizeBytes > 0
+}
+
+// Validate the config.
+func (cfg *LocalCacheConfig) Validate() error {
+	if cfg.Enabled() && cfg.TTL <= 0 {
+		return errInvalidLocalCacheTTL
+	}
+
+	return nil
+}
+
+// WrapWithLocalCache wraps the input remote cache with an in-process LRU cache, if enabled in
+// the config. Otherwise the input cache is returned unchanged.
+func WrapWithLocalCache(c Cache, name string, cfg LocalCacheConfig, reg prometheus.Registerer) (Cache, error) {
+	if !cfg.Enabled() {
+		return c, nil
+	}
+
+	return WrapWithSizeBoundedLRUCache(c, name, reg, cfg.MaxSizeBytes, cfg.TTL)
+}
+
+// WrapRemoteCacheClientWithLocalCache is like WrapWithLocalCache, but wraps a remote cache client.
+func WrapRemoteCacheClientWithLocalCache(client cacheutil.RemoteCacheClient, name string, cfg LocalCacheConfig, reg prometheus.Registerer) (cacheutil.RemoteCacheClient, error) {
+	if !cfg.Enabled() {
+		return client, nil
+	}
+
+	lru, err := WrapWithSizeBoundedLRUCache(&remoteCacheClientAdapter{client: client, name: name}, name, reg, cfg.MaxSizeBytes, cfg.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localCacheRemoteClient{lru: lru, client: client}, nil
+}
+
+// localCacheRemoteClient is a cacheutil.RemoteCacheClient backed by an LRU cache layered in front of another remote cache client.
+type localCacheRemoteClient struct {
+	lru    *LRUCache
+	client cacheutil.RemoteCacheClient
+}
+
+func (c *localCacheRemoteClient) GetMulti(ctx context.Context, keys []string) map[string][]byte {
+	return c.lru.Fetch(ctx, keys)
+}
+
+func (c *localCacheRemoteClient) SetAsync(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	// Store to the remote cache directly, so that we can return the enqueueing error.
+	if err := c.client.SetAsync(ctx, key, value, ttl); err != nil {
+		return err
+	}
+
+	c.lru.storeLocal(map[string][]byte{key: value}, ttl)
+	return nil
+}
+
+func (c *localCacheRemoteClient) Stop() {
+	c.client.Stop()
+}
+
+// remoteCacheClientAdapter adapts a cacheutil.RemoteCacheClient to the Cache interface.
+type remoteCacheClientAdapter struct {
+	client cacheutil.RemoteCacheClient
+	name   string
+}
+
+func (a *remoteCacheClientAdapter) Store(ctx context.Context, data map[string][]byte, ttl time.Duration) {
+	// Not used by localCacheRemoteClient, which stores to the remote cache client directly.
+	for key, value := range data {
+		_ = a.client.SetAsync(ctx, key, value, ttl)
+	}
+}
+
+func (a *remoteCacheClientAdapter) Fetch(ctx context.Context, keys []string) map[string][]byte {
+	return a.client.GetMulti(ctx, keys)
+}
+
+func (a *remoteCacheClientAdapter) Name() string {
+	return a.name
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapWithLocalCache(t *testing.T) {
+	remote := NewInstrumentedMockCache()
+
+	t.Run("should not wrap the cache if the local cache is disabled", func(t *testing.T) {
+		c, err := WrapWithLocalCache(remote, "test", LocalCacheConfig{}, nil)
+		require.NoError(t, err)
+		assert.Same(t, remote, c)
+	})
+
+	t.Run("should fetch the items from the remote cache only once", func(t *testing.T) {
+		reg := prometheus.NewPedanticRegistry()
+		c, err := WrapWithLocalCache(remote, "test", LocalCacheConfig{MaxSizeBytes: 1024, TTL: time.Minute}, reg)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		remote.Store(ctx, map[string][]byte{"key": []byte("value")}, time.Hour)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, map[string][]byte{"key": []byte("value")}, c.Fetch(ctx, []string{"key"}))
+		}
+		assert.Equal(t, 1, remote.CountFetchCalls())
+
+		assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+			# HELP cortex_cache_memory_hits_total Total number of requests to the in-memory cache that were a hit.
+			# TYPE cortex_cache_memory_hits_total counter
+			cortex_cache_memory_hits_total{name="test"} 2
+			# HELP cortex_cache_memory_requests_total Total number of requests to the in-memory cache.
+			# TYPE cortex_cache_memory_requests_total counter
+			cortex_cache_memory_requests_total{name="test"} 3
+		`), "cortex_cache_memory_hits_total", "cortex_cache_memory_requests_total"))
+	})
+}
+
+func TestWrapRemoteCacheClientWithLocalCache(t *testing.T) {
+	remote := newMockRemoteCacheClient()
+
+	client, err := WrapRemoteCacheClientWithLocalCache(remote, "test", LocalCacheConfig{MaxSizeBytes: 1024, TTL: time.Minute}, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, client.SetAsync(ctx, "key-1", []byte("value-1"), time.Hour))
+	require.NoError(t, remote.SetAsync(ctx, "key-2", []byte("value-2"), time.Hour))
+
+	// The item stored through the wrapped client is in both caches.
+	assert.Equal(t, []byte("value-1"), remote.items["key-1"])
+
+	expected := map[string][]byte{"key-1": []byte("value-1"), "key-2": []byte("value-2")}
+	assert.Equal(t, expected, client.GetMulti(ctx, []string{"key-1", "key-2"}))
+	assert.Equal(t, expected, client.GetMulti(ctx, []string{"key-1", "key-2"}))
+
+	// Only key-2 has been fetched from the remote client, and only once.
+	assert.Equal(t, [][]string{{"key-2"}}, remote.getMultiCalls)
+
+	client.Stop()
+	assert.True(t, remote.stopped)
+}
+
+type mockRemoteCacheClient struct {
+	mtx           sync.Mutex
+	items         map[string][]byte
+	getMultiCalls [][]string
+	stopped       bool
+}
+
+func newMockRemoteCacheClient() *mockRemoteCacheClient {
+	return &mockRemoteCacheClient{items: map[string][]byte{}}
+}
+
+func (m *mockRemoteCacheClient) GetMulti(_ context.Context, keys []string) map[string][]byte {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.getMultiCalls = append(m.getMultiCalls, keys)
+
+	found := map[string][]byte{}
+	for _, key := range keys {
+		if value, ok := m.items[key]; ok {
+			found[key] = value
+		}
+	}
+	return found
+}
+
+func (m *mockRemoteCacheClient) SetAsync(_ context.Context, key string, value []byte, _ time.Duration) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.items[key] = value
+	return nil
+}
+
+func (m *mockRemoteCacheClient) Stop() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.stopped = true
+}
@@ -4,6 +4,7 @@ package cache
 
 import (
 	"context"
+	"math"
 	"sync"
 	"time"
 
@@ -20,9 +21,14 @@ type LRUCache struct {
 	mtx sync.Mutex
 	lru *lru.LRU
 
+	// maxSizeBytes is the max total size of the items in the LRU cache, or 0 if unlimited.
+	maxSizeBytes uint64
+	sizeBytes    uint64
+
 	requests prometheus.Counter
 	hits     prometheus.Counter
 	items    prometheus.GaugeFunc
+	size     prometheus.GaugeFunc
 }
 
 type cacheItem struct {
@@ -32,34 +38,46 @@ type cacheItem struct {
 
 // WrapWithLRUCache wraps a given `Cache` c with a LRU cache. The LRU cache will always store items in both caches.
 // However it will only fetch items from the underlying cache if the LRU cache doesn't have the item.
-// Items fetched from the underlying cache will be stored in the LRU cache with a default TTL.
+// Items fetched from the underlying cache will be stored in the LRU cache with a default TTL, which is also the max
+// TTL of the items stored in the LRU cache.
 // The LRU cache will also remove items from the underlying cache if they are expired.
 // The LRU cache is limited in number of items using `lruSize`. This means this cache is not tailored for large items or items that have a big
-// variation in size.
+// variation in size. See WrapWithSizeBoundedLRUCache for such use cases.
 func WrapWithLRUCache(c Cache, name string, reg prometheus.Registerer, lruSize int, defaultTTL time.Duration) (*LRUCache, error) {
-	lru, err := lru.NewLRU(lruSize, nil)
-	if err != nil {
-		return nil, err
-	}
+	return newLRUCache(c, name, reg, lruSize, 0, defaultTTL)
+}
+
+// WrapWithSizeBoundedLRUCache is like WrapWithLRUCache, but the LRU cache is limited by the total size in bytes
+// of the items (keys and values) instead of their number. Items bigger than `maxSizeBytes` are not stored in
+// the LRU cache.
+func WrapWithSizeBoundedLRUCache(c Cache, name string, reg prometheus.Registerer, maxSizeBytes uint64, defaultTTL time.Duration) (*LRUCache, error) {
+	return newLRUCache(c, name, reg, math.MaxInt32, maxSizeBytes, defaultTTL)
+}
 
+func newLRUCache(c Cache, name string, reg prometheus.Registerer, lruSize int, maxSizeBytes uint64, defaultTTL time.Duration) (*LRUCache, error) {
 	cache := &LRUCache{
-		c:          c,
-		lru:        lru,
-		name:       name,
-		defaultTTL: defaultTTL,
-
-		requests: promauto.With(reg).NewCounter(prometheus.CounterOpts{
-			Name:        "cortex_cache_memory_requests_total",
-			Help:        "Total number of requests to the in-memory cache.",
-			ConstLabels: map[string]string{"name": name},
-		}),
-		hits: promauto.With(reg).NewCounter(prometheus.CounterOpts{
-			Name:        "cortex_cache_memory_hits_total",
-			Help:        "Total number of requests to the in-memory cache that were a hit.",
-			ConstLabels: map[string]string{"name": name},
-		}),
+		c:            c,
+		name:         name,
+		defaultTTL:   defaultTTL,
+		maxSizeBytes: maxSizeBytes,
 	}
 
+	var err error
+	cache.lru, err = lru.NewLRU(lruSize, cache.onEvict)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.requests = promauto.With(reg).NewCounter(prometheus.CounterOpts{
+		Name:        "cortex_cache_memory_requests_total",
+		Help:        "Total number of requests to the in-memory cache.",
+		ConstLabels: map[string]string{"name": name},
+	})
+	cache.hits = promauto.With(reg).NewCounter(prometheus.CounterOpts{
+		Name:        "cortex_cache_memory_hits_total",
+		Help:        "Total number of requests to the in-memory cache that were a hit.",
+		ConstLabels: map[string]string{"name": name},
+	})
 	cache.items = promauto.With(reg).NewGaugeFunc(prometheus.GaugeOpts{
 		Name:        "cortex_cache_memory_items_count",
 		Help:        "Total number of items currently in the in-memory cache.",
@@ -70,6 +88,16 @@ func WrapWithLRUCache(c Cache, name string, reg prometheus.Registerer, lruSize i
 
 		return float64(cache.lru.Len())
 	})
+	cache.size = promauto.With(reg).NewGaugeFunc(prometheus.GaugeOpts{
+		Name:        "cortex_cache_memory_size_bytes",
+		Help:        "Total size in bytes of the items currently in the in-memory cache.",
+		ConstLabels: map[string]string{"name": name},
+	}, func() float64 {
+		cache.mtx.Lock()
+		defer cache.mtx.Unlock()
+
+		return float64(cache.sizeBytes)
+	})
 
 	return cache, nil
 }
@@ -78,27 +106,47 @@ func (l *LRUCache) Store(ctx context.Context, data map[string][]byte, ttl time.D
 	// store the data in the shared cache.
 	l.c.Store(ctx, data, ttl)
 
+	l.storeLocal(data, ttl)
+}
+
+// storeLocal stores the data in the LRU cache only. Items with a ttl <= 0 would be already expired,
+// so they're not stored, and any previous version of them is removed from the LRU cache.
+func (l *LRUCache) storeLocal(data map[string][]byte, ttl time.Duration) {
+	if l.defaultTTL > 0 && ttl > l.defaultTTL {
+		ttl = l.defaultTTL
+	}
+
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
+	if ttl <= 0 {
+		for k := range data {
+			l.lru.Remove(k)
+		}
+		return
+	}
+
+	expiresAt := time.Now().Add(ttl)
 	for k, v := range data {
-		l.lru.Add(k, &cacheItem{
+		l.add(k, &cacheItem{
 			data:      v,
-			expiresAt: time.Now().Add(ttl),
+			expiresAt: expiresAt,
 		})
 	}
 }
 
+// Fetch implements Cache. Items missing in the LRU cache are fetched from the underlying cache,
+// without holding the lock, and then stored in the LRU cache.
 func (l *LRUCache) Fetch(ctx context.Context, keys []string) (result map[string][]byte) {
 	l.requests.Add(float64(len(keys)))
-	l.mtx.Lock()
-	defer l.mtx.Unlock()
+
 	var (
 		found = make(map[string][]byte, len(keys))
 		miss  = make([]string, 0, len(keys))
 		now   = time.Now()
 	)
 
+	l.mtx.Lock()
 	for _, k := range keys {
 		val, ok := l.lru.Get(k)
 		if !ok {
@@ -112,20 +160,37 @@ func (l *LRUCache) Fetch(ctx context.Context, keys []string) (result map[string]
 		}
 		l.lru.Remove(k)
 		miss = append(miss, k)
-
 	}
+	l.mtx.Unlock()
+
 	l.hits.Add(float64(len(found)))
 
-	if len(miss) > 0 {
-		result = l.c.Fetch(ctx, miss)
-		for k, v := range result {
-			// we don't know the ttl of the result, so we use the default one.
-			l.lru.Add(k, &cacheItem{
-				data:      v,
-				expiresAt: now.Add(l.defaultTTL),
-			})
-			found[k] = v
+	if len(miss) == 0 {
+		return found
+	}
+
+	result = l.c.Fetch(ctx, miss)
+	if len(result) == 0 {
+		return found
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	for k, v := range result {
+		found[k] = v
+
+		// The item may have been stored while fetching it from the underlying cache,
+		// in which case the stored one is kept because it's the most recent.
+		if _, ok := l.lru.Peek(k); ok {
+			continue
 		}
+
+		// we don't know the ttl of the result, so we use the default one.
+		l.add(k, &cacheItem{
+			data:      v,
+			expiresAt: now.Add(l.defaultTTL),
+		})
 	}
 
 	return found
@@ -134,3 +199,35 @@ func (l *LRUCache) Fetch(ctx context.Context, keys []string) (result map[string]
 func (l *LRUCache) Name() string {
 	return "in-memory-" + l.name
 }
+
+// add adds the item to the LRU cache, evicting the least recently used items if the
+// max size is exceeded. The caller must hold the lock.
+func (l *LRUCache) add(key string, item *cacheItem) {
+	size := cacheItemSize(key, item.data)
+	if l.maxSizeBytes > 0 && size > l.maxSizeBytes {
+		// The item doesn't fit the cache. Make sure we don't keep serving an older version of it.
+		l.lru.Remove(key)
+		return
+	}
+
+	// Replacing an existing item doesn't trigger the eviction callback.
+	if prev, ok := l.lru.Peek(key); ok {
+		l.sizeBytes -= cacheItemSize(key, prev.(*cacheItem).data)
+	}
+
+	l.lru.Add(key, item)
+	l.sizeBytes += size
+
+	for l.maxSizeBytes > 0 && l.sizeBytes > l.maxSizeBytes {
+		l.lru.RemoveOldest()
+	}
+}
+
+// onEvict is called by the LRU whenever an item is removed. The caller must hold the lock.
+func (l *LRUCache) onEvict(key, value interface{}) {
+	l.sizeBytes -= cacheItemSize(key.(string), value.(*cacheItem).data)
+}
+
+func cacheItemSize(key string, data []byte) uint64 {
+	return uint64(len(key) + len(data))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,6 +58,9 @@ func TestLRUCache_StoreFetch(t *testing.T) {
 		# HELP cortex_cache_memory_requests_total Total number of requests to the in-memory cache.
 		# TYPE cortex_cache_memory_requests_total counter
 		cortex_cache_memory_requests_total{name="test"} 4
+		# HELP cortex_cache_memory_size_bytes Total size in bytes of the items currently in the in-memory cache.
+		# TYPE cortex_cache_memory_size_bytes gauge
+		cortex_cache_memory_size_bytes{name="test"} 20
 	`)))
 }
 
@@ -79,3 +83,116 @@ func TestLRUCache_Evictions(t *testing.T) {
 		cortex_cache_memory_items_count{name="test"} 2
 	`), "cortex_cache_memory_items_count"))
 }
+
+func TestSizeBoundedLRUCache_Evictions(t *testing.T) {
+	const maxSizeBytes = 30
+
+	reg := prometheus.NewPedanticRegistry()
+	lru, err := WrapWithSizeBoundedLRUCache(NewMockCache(), "test", reg, maxSizeBytes, 2*time.Hour)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Each item is 12 bytes, so only 2 items fit the cache.
+	lru.Store(ctx, map[string][]byte{"key_1": []byte("value_1")}, time.Minute)
+	lru.Store(ctx, map[string][]byte{"key_2": []byte("value_2")}, time.Minute)
+	lru.Store(ctx, map[string][]byte{"key_3": []byte("value_3")}, time.Minute)
+
+	_, ok := lru.lru.Peek("key_1")
+	assert.False(t, ok)
+
+	// Replacing an item updates the size.
+	lru.Store(ctx, map[string][]byte{"key_3": []byte("value")}, time.Minute)
+
+	// Items bigger than the max size are not stored in the LRU cache, and remove the previous version.
+	lru.Store(ctx, map[string][]byte{"key_2": []byte(strings.Repeat("x", maxSizeBytes))}, time.Minute)
+	_, ok = lru.lru.Peek("key_2")
+	assert.False(t, ok)
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_cache_memory_items_count Total number of items currently in the in-memory cache.
+		# TYPE cortex_cache_memory_items_count gauge
+		cortex_cache_memory_items_count{name="test"} 1
+		# HELP cortex_cache_memory_size_bytes Total size in bytes of the items currently in the in-memory cache.
+		# TYPE cortex_cache_memory_size_bytes gauge
+		cortex_cache_memory_size_bytes{name="test"} 10
+	`), "cortex_cache_memory_items_count", "cortex_cache_memory_size_bytes"))
+
+	// The items evicted from the LRU cache are still in the underlying cache.
+	assert.Equal(t, map[string][]byte{
+		"key_1": []byte("value_1"),
+		"key_2": []byte(strings.Repeat("x", maxSizeBytes)),
+		"key_3": []byte("value"),
+	}, lru.Fetch(ctx, []string{"key_1", "key_2", "key_3"}))
+}
+
+func TestLRUCache_ShouldCapTTLToDefaultTTL(t *testing.T) {
+	lru, err := WrapWithLRUCache(NewMockCache(), "test", nil, 10, time.Minute)
+	require.NoError(t, err)
+
+	lru.Store(context.Background(), map[string][]byte{"key": []byte("value")}, time.Hour)
+
+	item, ok := lru.lru.Get("key")
+	require.True(t, ok)
+	assert.True(t, time.Until(item.(*cacheItem).expiresAt) <= time.Minute)
+}
+
+func TestLRUCache_ShouldNotStoreItemsWithoutTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Minute} {
+		t.Run(ttl.String(), func(t *testing.T) {
+			lru, err := WrapWithLRUCache(NewMockCache(), "test", nil, 10, time.Minute)
+			require.NoError(t, err)
+
+			lru.Store(context.Background(), map[string][]byte{"key": []byte("value"), "other": []byte("value")}, time.Minute)
+			lru.Store(context.Background(), map[string][]byte{"key": []byte("new-value")}, ttl)
+
+			// The previous version of the item must not be served anymore.
+			_, ok := lru.lru.Get("key")
+			assert.False(t, ok)
+			assert.Equal(t, 1, lru.lru.Len())
+			assert.Equal(t, cacheItemSize("other", []byte("value")), lru.sizeBytes)
+		})
+	}
+}
+
+func TestLRUCache_ShouldNotHoldLockWhileFetchingFromUnderlyingCache(t *testing.T) {
+	ctx := context.Background()
+	underlying := &blockingFetchCache{MockCache: NewMockCache(), fetchStarted: make(chan struct{}), unblockFetch: make(chan struct{})}
+	underlying.MockCache.Store(ctx, map[string][]byte{"remote": []byte("remote")}, time.Hour)
+
+	lru, err := WrapWithLRUCache(underlying, "test", nil, 10, time.Hour)
+	require.NoError(t, err)
+	lru.Store(ctx, map[string][]byte{"local": []byte("local")}, time.Hour)
+
+	// Start fetching a key missing in the LRU cache, which blocks in the underlying cache.
+	done := make(chan map[string][]byte)
+	go func() {
+		done <- lru.Fetch(ctx, []string{"remote"})
+	}()
+	<-underlying.fetchStarted
+
+	// Keys stored in the LRU cache can be fetched and stored while the other fetch is in progress.
+	assert.Equal(t, map[string][]byte{"local": []byte("local")}, lru.Fetch(ctx, []string{"local"}))
+	lru.Store(ctx, map[string][]byte{"other": []byte("other")}, time.Hour)
+
+	close(underlying.unblockFetch)
+	assert.Equal(t, map[string][]byte{"remote": []byte("remote")}, <-done)
+
+	// The item fetched from the underlying cache is stored in the LRU cache.
+	item, ok := lru.lru.Get("remote")
+	require.True(t, ok)
+	assert.Equal(t, []byte("remote"), item.(*cacheItem).data)
+}
+
+// blockingFetchCache is a mocked cache whose Fetch blocks until unblockFetch is closed.
+type blockingFetchCache struct {
+	*MockCache
+	fetchStarted chan struct{}
+	unblockFetch chan struct{}
+}
+
+func (c *blockingFetchCache) Fetch(ctx context.Context, keys []string) map[string][]byte {
+	close(c.fetchStarted)
+	<-c.unblockFetch
+	return c.MockCache.Fetch(ctx, keys)
+}
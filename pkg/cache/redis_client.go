@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/grafana/dskit/concurrency"
+)
+
+const (
+	redisOpGetMulti = "getmulti"
+	redisOpSet      = "set"
+
+	redisReasonMaxItemSize = "max-item-size"
+	redisReasonTimeout     = "timeout"
+	redisReasonOther       = "other"
+)
+
+var (
+	errRedisAsyncBufferFull = errors.New("the async buffer is full")
+)
+
+// RedisClient is a cache client backed by Redis (or any Redis protocol compatible server, like Valkey).
+// Depending on the configured mode, it connects to a single Redis node, to a Redis cluster or to a
+// Redis master discovered through Redis sentinels. The client can be used both as a generic Cache
+// and as a remote cache client (e.g. for the store-gateway index cache).
+type RedisClient struct {
+	logger log.Logger
+	name   string
+	config RedisConfig
+	client redis.UniversalClient
+
+	// Channel used to notify internal goroutines when they should quit.
+	stop chan struct{}
+
+	// Channel used to enqueue async operations.
+	asyncQueue chan func()
+
+	// Wait group used to wait all workers on stopping.
+	workers sync.WaitGroup
+
+	requests   prometheus.Counter
+	hits       prometheus.Counter
+	operations *prometheus.CounterVec
+	failures   *prometheus.CounterVec
+	skipped    *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+}
+
+// NewRedisClient makes a new RedisClient.
+func NewRedisClient(logger log.Logger, name string, cfg RedisConfig, reg prometheus.Registerer) (*RedisClient, error) {
+	opts := &redis.UniversalOptions{
+		Addrs:            cfg.GetEndpoints(),
+		MasterName:       cfg.MasterName,
+		Username:         cfg.Username,
+		Password:         cfg.Password.String(),
+		SentinelPassword: cfg.SentinelPassword.String(),
+		DB:               cfg.DB,
+		DialTimeout:      cfg.Timeout,
+		ReadTimeout:      cfg.Timeout,
+		WriteTimeout:     cfg.Timeout,
+		PoolSize:         cfg.PoolSize,
+		IdleTimeout:      cfg.IdleTimeout,
+		MaxConnAge:       cfg.MaxConnectionAge,
+	}
+
+	if cfg.TLSEnabled {
+		tlsConfig, err := cfg.TLS.GetTLSConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create redis TLS config")
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	var client redis.UniversalClient
+	switch cfg.Mode {
+	case RedisModeCluster:
+		client = redis.NewClusterClient(opts.Cluster())
+	case RedisModeSentinel:
+		client = redis.NewFailoverClient(opts.Failover())
+	default:
+		client = redis.NewClient(opts.Simple())
+	}
+
+	return newRedisClient(logger, name, cfg, client, reg), nil
+}
+
+func newRedisClient(logger log.Logger, name string, cfg RedisConfig, client redis.UniversalClient, reg prometheus.Registerer) *RedisClient {
+	labels := map[string]string{"name": name}
+
+	c := &RedisClient{
+		logger:     log.With(logger, "name", name),
+		name:       name,
+		config:     cfg,
+		client:     client,
+		stop:       make(chan struct{}),
+		asyncQueue: make(chan func(), cfg.MaxAsyncBufferSize),
+
+		requests: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name:        "cortex_cache_redis_requests_total",
+			Help:        "Total number of items requested to redis.",
+			ConstLabels: labels,
+		}),
+		hits: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name:        "cortex_cache_redis_hits_total",
+			Help:        "Total number of items requested to redis that were a hit.",
+			ConstLabels: labels,
+		}),
+		operations: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name:        "cortex_cache_redis_operations_total",
+			Help:        "Total number of operations against redis.",
+			ConstLabels: labels,
+		}, []string{"operation"}),
+		failures: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name:        "cortex_cache_redis_operation_failures_total",
+			Help:        "Total number of operations against redis that failed.",
+			ConstLabels: labels,
+		}, []string{"operation", "reason"}),
+		skipped: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name:        "cortex_cache_redis_operation_skipped_total",
+			Help:        "Total number of operations against redis that have been skipped.",
+			ConstLabels: labels,
+		}, []string{"operation", "reason"}),
+		duration: promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "cortex_cache_redis_operation_duration_seconds",
+			Help:        "Duration of operations against redis.",
+			Buckets:     []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.5, 1},
+			ConstLabels: labels,
+		}, []string{"operation"}),
+	}
+
+	// Initialise the metrics, so that they're exported even before the first operation.
+	for _, op := range []string{redisOpGetMulti, redisOpSet} {
+		c.operations.WithLabelValues(op)
+		c.failures.WithLabelValues(op, redisReasonTimeout)
+		c.failures.WithLabelValues(op, redisReasonOther)
+	}
+	c.skipped.WithLabelValues(redisOpSet, redisReasonMaxItemSize)
+
+	// Start a number of goroutines - processing async operations - equal
+	// to the max concurrency we have.
+	c.workers.Add(cfg.MaxAsyncConcurrency)
+	for i := 0; i < cfg.MaxAsyncConcurrency; i++ {
+		go c.asyncQueueProcessLoop()
+	}
+
+	return c
+}
+
+// Name implements Cache.
+func (c *RedisClient) Name() string {
+	return c.name
+}
+
+// Store implements Cache. Items are stored asynchronously.
+func (c *RedisClient) Store(ctx context.Context, data map[string][]byte, ttl time.Duration) {
+	for key, value := range data {
+		if err := c.SetAsync(ctx, key, value, ttl); err != nil {
+			level.Error(c.logger).Log("msg", "failed to store item to redis", "key", key, "err", err)
+		}
+	}
+}
+
+// Fetch implements Cache.
+func (c *RedisClient) Fetch(ctx context.Context, keys []string) map[string][]byte {
+	return c.GetMulti(ctx, keys)
+}
+
+// SetAsync enqueues an asynchronous operation to store a key into redis. Items
+// bigger than the configured max item size are skipped.
+func (c *RedisClient) SetAsync(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	// Skip hitting redis at all if the item is bigger than the max allowed size.
+	if c.config.MaxItemSize > 0 && len(value) > c.config.MaxItemSize {
+		c.skipped.WithLabelValues(redisOpSet, redisReasonMaxItemSize).Inc()
+		return nil
+	}
+
+	return c.enqueueAsync(func() {
+		start := time.Now()
+		c.operations.WithLabelValues(redisOpSet).Inc()
+
+		// The operation is async, so it can't use the caller context.
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		if err := c.client.Set(ctx, key, value, ttl).Err(); err != nil {
+			level.Debug(c.logger).Log("msg", "failed to store item to redis", "key", key, "sizeBytes", len(value), "err", err)
+			c.trackError(redisOpSet, err)
+			return
+		}
+
+		c.duration.WithLabelValues(redisOpSet).Observe(time.Since(start).Seconds())
+	})
+}
+
+// GetMulti fetches multiple keys from redis. Keys are fetched in batches, each one
+// through a pipeline of GET commands, so that keys belonging to different slots are
+// supported in cluster mode too. In case of error, the keys of the failed batch are
+// considered missing and the error is tracked and logged.
+func (c *RedisClient) GetMulti(ctx context.Context, keys []string) map[string][]byte {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	c.requests.Add(float64(len(keys)))
+
+	batches := c.batchKeys(keys)
+	concurrencyLimit := c.config.MaxGetMultiConcurrency
+	if concurrencyLimit <= 0 {
+		concurrencyLimit = len(batches)
+	}
+
+	var (
+		resultsMx sync.Mutex
+		results   = make(map[string][]byte, len(keys))
+	)
+
+	_ = concurrency.ForEachJob(ctx, len(batches), concurrencyLimit, func(ctx context.Context, idx int) error {
+		found, err := c.getMultiBatch(ctx, batches[idx])
+		if err != nil {
+			level.Debug(c.logger).Log("msg", "failed to get multiple items from redis", "err", err)
+			c.trackError(redisOpGetMulti, err)
+			return nil
+		}
+
+		resultsMx.Lock()
+		for key, value := range found {
+			results[key] = value
+		}
+		resultsMx.Unlock()
+		return nil
+	})
+
+	c.hits.Add(float64(len(results)))
+	return results
+}
+
+func (c *RedisClient) getMultiBatch(ctx context.Context, keys []string) (map[string][]byte, error) {
+	start := time.Now()
+	c.operations.WithLabelValues(redisOpGetMulti).Inc()
+
+	cmds := make([]*redis.StringCmd, 0, len(keys))
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			cmds = append(cmds, pipe.Get(ctx, key))
+		}
+		return nil
+	})
+
+	// A missing key is reported as redis.Nil error, which is not a failure.
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	found := make(map[string][]byte, len(keys))
+	for i, cmd := range cmds {
+		value, err := cmd.Bytes()
+		if err != nil {
+			continue
+		}
+		found[keys[i]] = value
+	}
+
+	c.duration.WithLabelValues(redisOpGetMulti).Observe(time.Since(start).Seconds())
+	return found, nil
+}
+
+// batchKeys splits the input keys into batches of at most the configured max batch size.
+func (c *RedisClient) batchKeys(keys []string) [][]string {
+	batchSize := c.config.MaxGetMultiBatchSize
+	if batchSize <= 0 || len(keys) <= batchSize {
+		return [][]string{keys}
+	}
+
+	batches := make([][]string, 0, (len(keys)+batchSize-1)/batchSize)
+	for len(keys) > 0 {
+		size := batchSize
+		if len(keys) < size {
+			size = len(keys)
+		}
+
+		batches = append(batches, keys[:size])
+		keys = keys[size:]
+	}
+
+	return batches
+}
+
+// Stop the client and release the underlying resources.
+func (c *RedisClient) Stop() {
+	close(c.stop)
+
+	// Wait until all workers have terminated.
+	c.workers.Wait()
+
+	if err := c.client.Close(); err != nil {
+		level.Warn(c.logger).Log("msg", "failed to close redis client", "err", err)
+	}
+}
+
+func (c *RedisClient) trackError(op string, err error) {
+	if errors.Is(err, context.DeadlineExceeded) || isRedisTimeout(err) {
+		c.failures.WithLabelValues(op, redisReasonTimeout).Inc()
+		return
+	}
+	c.failures.WithLabelValues(op, redisReasonOther).Inc()
+}
+
+func isRedisTimeout(err error) bool {
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
+
+func (c *RedisClient) enqueueAsync(op func()) error {
+	select {
+	case c.asyncQueue <- op:
+		return nil
+	default:
+		return errRedisAsyncBufferFull
+	}
+}
+
+func (c *RedisClient) asyncQueueProcessLoop() {
+	defer c.workers.Done()
+
+	for {
+		select {
+		case op := <-c.asyncQueue:
+			op()
+		case <-c.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kit/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/dskit/flagext"
+)
+
+func TestRedisClient_StoreFetch(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireUserAuth("user", "secret")
+
+	cfg := defaultRedisConfig(server.Addr())
+	cfg.Username = "user"
+	cfg.Password = flagext.SecretWithValue("secret")
+	cfg.MaxGetMultiBatchSize = 2
+	cfg.MaxItemSize = 10
+
+	reg := prometheus.NewPedanticRegistry()
+	client, err := NewRedisClient(log.NewNopLogger(), "test", cfg, reg)
+	require.NoError(t, err)
+	t.Cleanup(client.Stop)
+
+	ctx := context.Background()
+	client.Store(ctx, map[string][]byte{
+		"key-1": []byte("value-1"),
+		"key-2": []byte("value-2"),
+		"key-3": []byte("value-3"),
+		"large": []byte("larger-than-max-item-size"),
+	}, time.Minute)
+
+	// Items are stored asynchronously.
+	require.Eventually(t, func() bool {
+		return len(server.Keys()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, map[string][]byte{
+		"key-1": []byte("value-1"),
+		"key-2": []byte("value-2"),
+		"key-3": []byte("value-3"),
+	}, client.Fetch(ctx, []string{"key-1", "key-2", "key-3", "missing", "large"}))
+
+	// Items expire after their TTL.
+	server.FastForward(2 * time.Minute)
+	assert.Empty(t, client.Fetch(ctx, []string{"key-1", "key-2", "key-3"}))
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_cache_redis_hits_total Total number of items requested to redis that were a hit.
+		# TYPE cortex_cache_redis_hits_total counter
+		cortex_cache_redis_hits_total{name="test"} 3
+		# HELP cortex_cache_redis_requests_total Total number of items requested to redis.
+		# TYPE cortex_cache_redis_requests_total counter
+		cortex_cache_redis_requests_total{name="test"} 8
+		# HELP cortex_cache_redis_operations_total Total number of operations against redis.
+		# TYPE cortex_cache_redis_operations_total counter
+		cortex_cache_redis_operations_total{name="test",operation="getmulti"} 5
+		cortex_cache_redis_operations_total{name="test",operation="set"} 3
+		# HELP cortex_cache_redis_operation_skipped_total Total number of operations against redis that have been skipped.
+		# TYPE cortex_cache_redis_operation_skipped_total counter
+		cortex_cache_redis_operation_skipped_total{name="test",operation="set",reason="max-item-size"} 1
+	`), "cortex_cache_redis_hits_total", "cortex_cache_redis_requests_total", "cortex_cache_redis_operations_total", "cortex_cache_redis_operation_skipped_total"))
+}
+
+func TestRedisClient_ShouldTrackFailures(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+
+	cfg := defaultRedisConfig(server.Addr())
+	cfg.Password = flagext.SecretWithValue("wrong")
+
+	reg := prometheus.NewPedanticRegistry()
+	client, err := NewRedisClient(log.NewNopLogger(), "test", cfg, reg)
+	require.NoError(t, err)
+	t.Cleanup(client.Stop)
+
+	assert.Empty(t, client.Fetch(context.Background(), []string{"key-1", "key-2"}))
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_cache_redis_operation_failures_total Total number of operations against redis that failed.
+		# TYPE cortex_cache_redis_operation_failures_total counter
+		cortex_cache_redis_operation_failures_total{name="test",operation="getmulti",reason="other"} 1
+		cortex_cache_redis_operation_failures_total{name="test",operation="getmulti",reason="timeout"} 0
+		cortex_cache_redis_operation_failures_total{name="test",operation="set",reason="other"} 0
+		cortex_cache_redis_operation_failures_total{name="test",operation="set",reason="timeout"} 0
+	`), "cortex_cache_redis_operation_failures_total"))
+}
+
+func TestRedisClient_BatchKeys(t *testing.T) {
+	tests := map[string]struct {
+		batchSize int
+		keys      int
+		expected  []int
+	}{
+		"unlimited batch size": {
+			batchSize: 0,
+			keys:      5,
+			expected:  []int{5},
+		},
+		"less keys than the batch size": {
+			batchSize: 10,
+			keys:      5,
+			expected:  []int{5},
+		},
+		"keys multiple of the batch size": {
+			batchSize: 2,
+			keys:      4,
+			expected:  []int{2, 2},
+		},
+		"keys not multiple of the batch size": {
+			batchSize: 2,
+			keys:      5,
+			expected:  []int{2, 2, 1},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			client := &RedisClient{config: RedisConfig{MaxGetMultiBatchSize: testData.batchSize}}
+
+			keys := make([]string, 0, testData.keys)
+			for i := 0; i < testData.keys; i++ {
+				keys = append(keys, fmt.Sprintf("key-%d", i))
+			}
+
+			var actual []int
+			var actualKeys []string
+			for _, batch := range client.batchKeys(keys) {
+				actual = append(actual, len(batch))
+				actualKeys = append(actualKeys, batch...)
+			}
+
+			assert.Equal(t, testData.expected, actual)
+			assert.Equal(t, keys, actualKeys)
+		})
+	}
+}
+
+func TestCreateClient_Redis(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	cfg := BackendConfig{Backend: BackendRedis, Redis: defaultRedisConfig(server.Addr())}
+	require.NoError(t, cfg.Validate())
+
+	client, err := CreateClient("test", cfg, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	t.Cleanup(client.(*RedisClient).Stop)
+
+	ctx := context.Background()
+	client.Store(ctx, map[string][]byte{"key": []byte("value")}, time.Minute)
+	require.Eventually(t, func() bool {
+		return len(client.Fetch(ctx, []string{"key"})) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRedisConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cfg      RedisConfig
+		expected error
+	}{
+		"no endpoints": {
+			cfg:      RedisConfig{Mode: RedisModeSingle},
+			expected: ErrNoRedisEndpoints,
+		},
+		"single mode with one endpoint": {
+			cfg: RedisConfig{Mode: RedisModeSingle, Endpoint: "localhost:6379"},
+		},
+		"single mode with multiple endpoints": {
+			cfg:      RedisConfig{Mode: RedisModeSingle, Endpoint: "localhost:6379,localhost:6380"},
+			expected: ErrRedisSingleMultipleEndpoint,
+		},
+		"single mode with master name": {
+			cfg:      RedisConfig{Mode: RedisModeSingle, Endpoint: "localhost:6379", MasterName: "master"},
+			expected: ErrRedisMasterNameNotSentinel,
+		},
+		"cluster mode with one endpoint": {
+			cfg: RedisConfig{Mode: RedisModeCluster, Endpoint: "localhost:6379"},
+		},
+		"cluster mode with multiple endpoints": {
+			cfg: RedisConfig{Mode: RedisModeCluster, Endpoint: "localhost:6379,localhost:6380"},
+		},
+		"cluster mode with database index": {
+			cfg:      RedisConfig{Mode: RedisModeCluster, Endpoint: "localhost:6379", DB: 1},
+			expected: ErrRedisClusterDB,
+		},
+		"sentinel mode with master name": {
+			cfg: RedisConfig{Mode: RedisModeSentinel, Endpoint: "localhost:26379,localhost:26380", MasterName: "master"},
+		},
+		"sentinel mode without master name": {
+			cfg:      RedisConfig{Mode: RedisModeSentinel, Endpoint: "localhost:26379"},
+			expected: ErrRedisNoMasterName,
+		},
+		"unsupported mode": {
+			cfg:      RedisConfig{Mode: "unknown", Endpoint: "localhost:6379"},
+			expected: errors.New("unsupported redis mode: unknown"),
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testData.expected, (&BackendConfig{Backend: BackendRedis, Redis: testData.cfg}).Validate())
+		})
+	}
+}
+
+func TestNewRedisClient_ShouldCreateClientDependingOnMode(t *testing.T) {
+	tests := map[string]struct {
+		cfg          RedisConfig
+		expectedType interface{}
+	}{
+		"single mode": {
+			cfg:          RedisConfig{Mode: RedisModeSingle, Endpoint: "localhost:6379"},
+			expectedType: &redis.Client{},
+		},
+		"cluster mode with one endpoint": {
+			cfg:          RedisConfig{Mode: RedisModeCluster, Endpoint: "localhost:6379"},
+			expectedType: &redis.ClusterClient{},
+		},
+		"sentinel mode": {
+			cfg:          RedisConfig{Mode: RedisModeSentinel, Endpoint: "localhost:26379,localhost:26380", MasterName: "master", SentinelPassword: flagext.SecretWithValue("secret")},
+			expectedType: &redis.Client{},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			client, err := NewRedisClient(log.NewNopLogger(), "test", testData.cfg, nil)
+			require.NoError(t, err)
+			t.Cleanup(client.Stop)
+
+			assert.IsType(t, testData.expectedType, client.client)
+		})
+	}
+}
+
+func defaultRedisConfig(endpoint string) RedisConfig {
+	return RedisConfig{
+		Mode:                   RedisModeSingle,
+		Endpoint:               endpoint,
+		Timeout:                time.Second,
+		PoolSize:               10,
+		MaxAsyncConcurrency:    2,
+		MaxAsyncBufferSize:     100,
+		MaxGetMultiConcurrency: 2,
+		MaxGetMultiBatchSize:   100,
+		MaxItemSize:            1024 * 1024,
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package cache
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grafana/dskit/crypto/tls"
+	"github.com/grafana/dskit/flagext"
+)
+
+const (
+	RedisModeSingle   = "single"
+	RedisModeCluster  = "cluster"
+	RedisModeSentinel = "sentinel"
+)
+
+var (
+	redisModes = []string{RedisModeSingle, RedisModeCluster, RedisModeSentinel}
+
+	ErrNoRedisEndpoints            = errors.New("no redis endpoints configured")
+	ErrRedisSingleMultipleEndpoint = errors.New("only one redis endpoint can be configured in single mode")
+	ErrRedisNoMasterName           = errors.New("the redis master name is required in sentinel mode")
+	ErrRedisMasterNameNotSentinel  = errors.New("the redis master name can be configured only in sentinel mode")
+	ErrRedisClusterDB              = errors.New("the redis database index can't be configured in cluster mode")
+)
+
+// RedisConfig is the config for a Redis (or Valkey) cache client.
+type RedisConfig struct {
+	Mode                   string         `yaml:"mode" category:"experimental"`
+	Endpoint               string         `yaml:"endpoint" category:"experimental"`
+	MasterName             string         `yaml:"master_name" category:"experimental"`
+	Username               string         `yaml:"username" category:"experimental"`
+	Password               flagext.Secret `yaml:"password" category:"experimental"`
+	SentinelPassword       flagext.Secret `yaml:"sentinel_password" category:"experimental"`
+	DB                     int            `yaml:"db" category:"experimental"`
+	Timeout                time.Duration  `yaml:"timeout" category:"experimental"`
+	PoolSize               int            `yaml:"pool_size" category:"experimental"`
+	IdleTimeout            time.Duration  `yaml:"idle_timeout" category:"experimental"`
+	MaxConnectionAge       time.Duration  `yaml:"max_connection_age" category:"experimental"`
+	MaxAsyncConcurrency    int            `yaml:"max_async_concurrency" category:"experimental"`
+	MaxAsyncBufferSize     int            `yaml:"max_async_buffer_size" category:"experimental"`
+	MaxGetMultiConcurrency int            `yaml:"max_get_multi_concurrency" category:"experimental"`
+	MaxGetMultiBatchSize   int            `yaml:"max_get_multi_batch_size" category:"experimental"`
+	MaxItemSize            int            `yaml:"max_item_size" category:"experimental"`
+
+	TLSEnabled bool             `yaml:"tls_enabled" category:"experimental"`
+	TLS        tls.ClientConfig `yaml:",inline"`
+}
+
+func (cfg *RedisConfig) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix string) {
+	f.StringVar(&cfg.Mode, prefix+"mode", RedisModeSingle, fmt.Sprintf("How the redis endpoints are addressed. Supported values: %s. In single mode, exactly one endpoint must be configured. In cluster mode, the endpoints are the seed nodes of a Redis cluster. In sentinel mode, the endpoints are Redis sentinels, and the master name is required.", strings.Join(redisModes, ", ")))
+	f.StringVar(&cfg.Endpoint, prefix+"endpoint", "", "Comma separated list of redis endpoints, in the form host:port.")
+	f.StringVar(&cfg.MasterName, prefix+"master-name", "", "The name of the Redis master. Required in sentinel mode.")
+	f.StringVar(&cfg.Username, prefix+"username", "", "Username to use when connecting to redis.")
+	f.Var(&cfg.Password, prefix+"password", "Password to use when connecting to redis.")
+	f.Var(&cfg.SentinelPassword, prefix+"sentinel-password", "Password to use when connecting to the Redis sentinels, in sentinel mode. It can differ from the password used to connect to redis.")
+	f.IntVar(&cfg.DB, prefix+"db", 0, "Database index. Not supported in cluster mode.")
+	f.DurationVar(&cfg.Timeout, prefix+"timeout", 200*time.Millisecond, "The socket read/write timeout.")
+	f.IntVar(&cfg.PoolSize, prefix+"pool-size", 100, "The maximum number of connections in the pool, per redis node.")
+	f.DurationVar(&cfg.IdleTimeout, prefix+"idle-timeout", 5*time.Minute, "Close connections after remaining idle for this duration. If set to 0, idle connections are not closed.")
+	f.DurationVar(&cfg.MaxConnectionAge, prefix+"max-connection-age", 0, "Close connections older than this duration. If set to 0, connections are not closed because of their age.")
+	f.IntVar(&cfg.MaxAsyncConcurrency, prefix+"max-async-concurrency", 50, "The maximum number of concurrent asynchronous operations can occur.")
+	f.IntVar(&cfg.MaxAsyncBufferSize, prefix+"max-async-buffer-size", 25000, "The maximum number of enqueued asynchronous operations allowed.")
+	f.IntVar(&cfg.MaxGetMultiConcurrency, prefix+"max-get-multi-concurrency", 100, "The maximum number of concurrent connections running get operations. If set to 0, concurrency is unlimited.")
+	f.IntVar(&cfg.MaxGetMultiBatchSize, prefix+"max-get-multi-batch-size", 100, "The maximum number of keys a single underlying get operation should run. If more keys are specified, internally keys are split into multiple batches and fetched concurrently, honoring the max concurrency. If set to 0, the max batch size is unlimited.")
+	f.IntVar(&cfg.MaxItemSize, prefix+"max-item-size", 1024*1024, "The maximum size of an item stored in redis. Bigger items are not stored. If set to 0, no maximum size is enforced.")
+	f.BoolVar(&cfg.TLSEnabled, prefix+"tls-enabled", false, "Enable connecting to redis with TLS.")
+	cfg.TLS.RegisterFlagsWithPrefix(strings.TrimSuffix(prefix, "."), f)
+}
+
+func (cfg *RedisConfig) GetEndpoints() []string {
+	if cfg.Endpoint == "" {
+		return []string{}
+	}
+
+	return strings.Split(cfg.Endpoint, ",")
+}
+
+// Validate the config.
+func (cfg *RedisConfig) Validate() error {
+	endpoints := cfg.GetEndpoints()
+	if len(endpoints) == 0 {
+		return ErrNoRedisEndpoints
+	}
+
+	switch cfg.Mode {
+	case RedisModeSingle:
+		if len(endpoints) > 1 {
+			return ErrRedisSingleMultipleEndpoint
+		}
+	case RedisModeCluster:
+		if cfg.DB != 0 {
+			return ErrRedisClusterDB
+		}
+	case RedisModeSentinel:
+		if cfg.MasterName == "" {
+			return ErrRedisNoMasterName
+		}
+	default:
+		return fmt.Errorf("unsupported redis mode: %s", cfg.Mode)
+	}
+
+	if cfg.Mode != RedisModeSentinel && cfg.MasterName != "" {
+		return ErrRedisMasterNameNotSentinel
+	}
+
+	return nil
+}
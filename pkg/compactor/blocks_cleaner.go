@@ -26,6 +26,7 @@ import (
 	"github.com/grafana/mimir/pkg/storage/bucket"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storage/tsdb/bucketindex"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	util_log "github.com/grafana/mimir/pkg/util/log"
 )
@@ -40,6 +41,8 @@ type BlocksCleanerConfig struct {
 	CleanupConcurrency      int
 	TenantCleanupDelay      time.Duration // Delay before removing tenant deletion mark and "debug".
 	DeleteBlocksConcurrency int
+	MetricsMetadataMaxAge   time.Duration           // Removes the stored metrics metadata not seen since then, if > 0.
+	UsageRecorder           *usagemetering.Recorder // Tracks the tenants storage size, if usage metering is enabled.
 }
 
 type BlocksCleaner struct {
@@ -67,6 +70,7 @@ type BlocksCleaner struct {
 	tenantMarkedBlocks          *prometheus.GaugeVec
 	tenantPartialBlocks         *prometheus.GaugeVec
 	tenantBucketIndexLastUpdate *prometheus.GaugeVec
+	metadataSnapshotsMerged     prometheus.Counter
 }
 
 func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, ownUser func(userID string) (bool, error), cfgProvider ConfigProvider, logger log.Logger, reg prometheus.Registerer) *BlocksCleaner {
@@ -126,6 +130,10 @@ func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, own
 			Name: "cortex_bucket_index_last_successful_update_timestamp_seconds",
 			Help: "Timestamp of the last successful update of a tenant's bucket index.",
 		}, []string{"user"}),
+		metadataSnapshotsMerged: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_metrics_metadata_snapshots_merged_total",
+			Help: "Total number of metrics metadata snapshots uploaded by the ingesters and merged into the tenant metrics metadata.",
+		}),
 	}
 
 	c.Service = services.NewTimerService(cfg.CleanupInterval, c.starting, c.ticker, nil)
@@ -294,6 +302,12 @@ func (c *BlocksCleaner) deleteUserMarkedForDeletion(ctx context.Context, userID
 		level.Info(userLogger).Log("msg", "deleted files under "+block.DebugMetas+" for tenant marked for deletion", "count", deleted)
 	}
 
+	if deleted, err := bucket.DeletePrefix(ctx, userBucket, mimir_tsdb.MetricsMetadataPathname, userLogger); err != nil {
+		return errors.Wrap(err, "failed to delete "+mimir_tsdb.MetricsMetadataPathname)
+	} else if deleted > 0 {
+		level.Info(userLogger).Log("msg", "deleted files under "+mimir_tsdb.MetricsMetadataPathname+" for tenant marked for deletion", "count", deleted)
+	}
+
 	// Tenant deletion mark file is inside Markers as well.
 	if deleted, err := bucket.DeletePrefix(ctx, userBucket, bucketindex.MarkersPathname, userLogger); err != nil {
 		return errors.Wrap(err, "failed to delete marker files")
@@ -361,10 +375,82 @@ func (c *BlocksCleaner) cleanUser(ctx context.Context, userID string) (returnErr
 	c.tenantMarkedBlocks.WithLabelValues(userID).Set(float64(len(idx.BlockDeletionMarks)))
 	c.tenantPartialBlocks.WithLabelValues(userID).Set(float64(len(partials)))
 	c.tenantBucketIndexLastUpdate.WithLabelValues(userID).SetToCurrentTime()
+	c.cfg.UsageRecorder.SetStorageBytes(userID, idx.SizeBytes())
+
+	// Merging the metrics metadata is a best effort, so we don't return error if it fails.
+	c.mergeUserMetricsMetadata(ctx, userID, userBucket, userLogger)
 
 	return nil
 }
 
+// mergeUserMetricsMetadata merges the metrics metadata snapshots uploaded by the ingesters into the tenant metrics
+// metadata, removing the metadata not seen within the metrics metadata max age or the blocks retention period, whichever
+// is shorter, and then deletes the merged snapshots.
+func (c *BlocksCleaner) mergeUserMetricsMetadata(ctx context.Context, userID string, userBucket objstore.InstrumentedBucket, userLogger log.Logger) {
+	snapshots, err := mimir_tsdb.ListMetricsMetadataSnapshots(ctx, userBucket)
+	if err != nil {
+		level.Warn(userLogger).Log("msg", "failed to list metrics metadata snapshots", "err", err)
+		return
+	}
+	if len(snapshots) == 0 {
+		return
+	}
+
+	var sources []*mimir_tsdb.MetricsMetadata
+
+	merged, err := mimir_tsdb.ReadMetricsMetadata(ctx, userBucket, userLogger)
+	if errors.Is(err, mimir_tsdb.ErrMetricsMetadataCorrupted) {
+		level.Warn(userLogger).Log("msg", "found corrupted metrics metadata, recreating it")
+	} else if err != nil && !errors.Is(err, mimir_tsdb.ErrMetricsMetadataNotFound) {
+		level.Warn(userLogger).Log("msg", "failed to read metrics metadata", "err", err)
+		return
+	} else if merged != nil {
+		sources = append(sources, merged)
+	}
+
+	for _, snapshot := range snapshots {
+		metadata, err := mimir_tsdb.ReadMetricsMetadataObject(ctx, userBucket, snapshot, userLogger)
+		if errors.Is(err, mimir_tsdb.ErrMetricsMetadataNotFound) {
+			continue
+		}
+		if errors.Is(err, mimir_tsdb.ErrMetricsMetadataCorrupted) {
+			// The corrupted snapshot is deleted together with the merged ones.
+			level.Warn(userLogger).Log("msg", "dropping corrupted metrics metadata snapshot", "snapshot", snapshot)
+			continue
+		}
+		if err != nil {
+			level.Warn(userLogger).Log("msg", "failed to read metrics metadata snapshot", "snapshot", snapshot, "err", err)
+			return
+		}
+		sources = append(sources, metadata)
+	}
+
+	merged = mimir_tsdb.MergeMetricsMetadata(sources...)
+	maxAge := c.cfg.MetricsMetadataMaxAge
+	if retention := c.cfgProvider.CompactorBlocksRetentionPeriod(userID); retention > 0 && (maxAge <= 0 || retention < maxAge) {
+		maxAge = retention
+	}
+	if maxAge > 0 {
+		merged.RemoveOlderThan(time.Now().Add(-maxAge))
+	}
+
+	if err := mimir_tsdb.WriteMetricsMetadata(ctx, userBucket, merged); err != nil {
+		level.Warn(userLogger).Log("msg", "failed to upload metrics metadata", "err", err)
+		return
+	}
+
+	for _, snapshot := range snapshots {
+		if err := userBucket.Delete(ctx, snapshot); err != nil && !userBucket.IsObjNotFoundErr(err) {
+			// The snapshot will be merged again at the next run, which is harmless.
+			level.Warn(userLogger).Log("msg", "failed to delete metrics metadata snapshot", "snapshot", snapshot, "err", err)
+			continue
+		}
+		c.metadataSnapshotsMerged.Inc()
+	}
+
+	level.Info(userLogger).Log("msg", "merged metrics metadata snapshots", "snapshots", len(snapshots), "metadata", len(merged.Metadata))
+}
+
 // Concurrently deletes blocks marked for deletion, and removes blocks from index.
 func (c *BlocksCleaner) deleteBlocksMarkedForDeletion(ctx context.Context, idx *bucketindex.Index, userBucket objstore.Bucket, userLogger log.Logger) {
 	blocksToDelete := make([]ulid.ULID, 0, len(idx.BlockDeletionMarks))
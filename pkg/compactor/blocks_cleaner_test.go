@@ -26,6 +26,7 @@ import (
 	"github.com/thanos-io/thanos/pkg/block/metadata"
 	"github.com/thanos-io/thanos/pkg/objstore"
 
+	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storage/tsdb/bucketindex"
 	mimir_testutil "github.com/grafana/mimir/pkg/storage/tsdb/testutil"
@@ -681,6 +682,132 @@ func TestBlocksCleaner_ShouldRemoveBlocksOutsideRetentionPeriod(t *testing.T) {
 	}
 }
 
+func TestBlocksCleaner_ShouldMergeMetricsMetadataSnapshots(t *testing.T) {
+	bucketClient, _ := mimir_testutil.PrepareFilesystemBucket(t)
+	bucketClient = bucketindex.BucketWithGlobalMarkers(bucketClient)
+	createTSDBBlock(t, bucketClient, "user-1", 10, 20, 2, nil)
+
+	cfg := BlocksCleanerConfig{
+		DeletionDelay:           time.Hour,
+		CleanupInterval:         time.Minute,
+		CleanupConcurrency:      1,
+		DeleteBlocksConcurrency: 1,
+	}
+
+	ctx := context.Background()
+	logger := test.NewTestingLogger(t)
+	reg := prometheus.NewPedanticRegistry()
+	cfgProvider := newMockConfigProvider()
+	cfgProvider.userRetentionPeriods["user-1"] = 24 * time.Hour
+
+	now := time.Now()
+	userBucket := bucket.NewUserBucketClient("user-1", bucketClient, cfgProvider)
+
+	// The metadata not seen within the retention period is removed.
+	require.NoError(t, tsdb.WriteMetricsMetadata(ctx, userBucket, &tsdb.MetricsMetadata{Version: tsdb.MetricsMetadataVersion1, Metadata: []tsdb.MetricMetadata{
+		{Metric: "metric_a", Type: "counter", Help: "A", LastSeen: now.Add(-2 * time.Hour).UnixMilli()},
+		{Metric: "metric_old", Type: "gauge", Help: "Old", LastSeen: now.Add(-48 * time.Hour).UnixMilli()},
+	}}))
+	require.NoError(t, tsdb.WriteMetricsMetadataObject(ctx, userBucket, tsdb.MetricsMetadataSnapshotPath("ingester-1", now), &tsdb.MetricsMetadata{Version: tsdb.MetricsMetadataVersion1, Metadata: []tsdb.MetricMetadata{
+		{Metric: "metric_a", Type: "counter", Help: "A", LastSeen: now.UnixMilli()},
+	}}))
+	require.NoError(t, tsdb.WriteMetricsMetadataObject(ctx, userBucket, tsdb.MetricsMetadataSnapshotPath("ingester-2", now), &tsdb.MetricsMetadata{Version: tsdb.MetricsMetadataVersion1, Metadata: []tsdb.MetricMetadata{
+		{Metric: "metric_b", Type: "gauge", Help: "B", LastSeen: now.UnixMilli()},
+	}}))
+
+	cleaner := NewBlocksCleaner(cfg, bucketClient, tsdb.AllUsers, cfgProvider, logger, reg)
+	require.NoError(t, cleaner.cleanUsers(ctx))
+
+	merged, err := tsdb.ReadMetricsMetadata(ctx, userBucket, logger)
+	require.NoError(t, err)
+	assert.Equal(t, []tsdb.MetricMetadata{
+		{Metric: "metric_a", Type: "counter", Help: "A", LastSeen: now.UnixMilli()},
+		{Metric: "metric_b", Type: "gauge", Help: "B", LastSeen: now.UnixMilli()},
+	}, merged.Metadata)
+
+	// The merged snapshots are deleted.
+	snapshots, err := tsdb.ListMetricsMetadataSnapshots(ctx, userBucket)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_compactor_metrics_metadata_snapshots_merged_total Total number of metrics metadata snapshots uploaded by the ingesters and merged into the tenant metrics metadata.
+		# TYPE cortex_compactor_metrics_metadata_snapshots_merged_total counter
+		cortex_compactor_metrics_metadata_snapshots_merged_total 2
+	`), "cortex_compactor_metrics_metadata_snapshots_merged_total"))
+}
+
+func TestBlocksCleaner_ShouldRemoveMetricsMetadataOlderThanMaxAge(t *testing.T) {
+	tests := map[string]struct {
+		maxAge          time.Duration
+		retentionPeriod time.Duration
+		expectedMetrics []string
+	}{
+		"no max age and no retention period": {
+			expectedMetrics: []string{"metric_new", "metric_old"},
+		},
+		"max age and no retention period": {
+			maxAge:          24 * time.Hour,
+			expectedMetrics: []string{"metric_new"},
+		},
+		"max age shorter than the retention period": {
+			maxAge:          24 * time.Hour,
+			retentionPeriod: 72 * time.Hour,
+			expectedMetrics: []string{"metric_new"},
+		},
+		"retention period shorter than the max age": {
+			maxAge:          72 * time.Hour,
+			retentionPeriod: 24 * time.Hour,
+			expectedMetrics: []string{"metric_new"},
+		},
+		"no max age and retention period longer than the metadata age": {
+			retentionPeriod: 72 * time.Hour,
+			expectedMetrics: []string{"metric_new", "metric_old"},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bucketClient, _ := mimir_testutil.PrepareFilesystemBucket(t)
+			bucketClient = bucketindex.BucketWithGlobalMarkers(bucketClient)
+			createTSDBBlock(t, bucketClient, "user-1", 10, 20, 2, nil)
+
+			cfg := BlocksCleanerConfig{
+				DeletionDelay:           time.Hour,
+				CleanupInterval:         time.Minute,
+				CleanupConcurrency:      1,
+				DeleteBlocksConcurrency: 1,
+				MetricsMetadataMaxAge:   testData.maxAge,
+			}
+
+			ctx := context.Background()
+			logger := test.NewTestingLogger(t)
+			cfgProvider := newMockConfigProvider()
+			cfgProvider.userRetentionPeriods["user-1"] = testData.retentionPeriod
+
+			now := time.Now()
+			userBucket := bucket.NewUserBucketClient("user-1", bucketClient, cfgProvider)
+
+			require.NoError(t, tsdb.WriteMetricsMetadataObject(ctx, userBucket, tsdb.MetricsMetadataSnapshotPath("ingester-1", now), &tsdb.MetricsMetadata{Version: tsdb.MetricsMetadataVersion1, Metadata: []tsdb.MetricMetadata{
+				{Metric: "metric_new", Type: "counter", Help: "New", LastSeen: now.UnixMilli()},
+				{Metric: "metric_old", Type: "gauge", Help: "Old", LastSeen: now.Add(-48 * time.Hour).UnixMilli()},
+			}}))
+
+			cleaner := NewBlocksCleaner(cfg, bucketClient, tsdb.AllUsers, cfgProvider, logger, prometheus.NewPedanticRegistry())
+			require.NoError(t, cleaner.cleanUsers(ctx))
+
+			merged, err := tsdb.ReadMetricsMetadata(ctx, userBucket, logger)
+			require.NoError(t, err)
+
+			var actualMetrics []string
+			for _, m := range merged.Metadata {
+				actualMetrics = append(actualMetrics, m.Metric)
+			}
+			assert.Equal(t, testData.expectedMetrics, actualMetrics)
+		})
+	}
+}
+
 type mockBucketFailure struct {
 	objstore.Bucket
 
@@ -699,6 +826,8 @@ type mockConfigProvider struct {
 	splitAndMergeShards  map[string]int
 	instancesShardSize   map[string]int
 	splitGroups          map[string]int
+
+	exemplarsRetentionPeriods map[string]time.Duration
 }
 
 func newMockConfigProvider() *mockConfigProvider {
@@ -737,6 +866,13 @@ func (m *mockConfigProvider) CompactorTenantShardSize(user string) int {
 	return 0
 }
 
+func (m *mockConfigProvider) ExemplarsRetentionPeriod(user string) time.Duration {
+	if result, ok := m.exemplarsRetentionPeriods[user]; ok {
+		return result
+	}
+	return 0
+}
+
 func (m *mockConfigProvider) S3SSEType(user string) string {
 	return ""
 }
@@ -392,6 +392,12 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 	elapsed = time.Since(compactionBegin)
 	level.Info(jobLogger).Log("msg", "compacted blocks", "new", fmt.Sprintf("%v", compIDs), "blocks", fmt.Sprintf("%v", blocksToCompactDirs), "duration", elapsed, "duration_ms", elapsed.Milliseconds())
 
+	// The exemplars stored alongside the source blocks are carried over to the compacted blocks.
+	exemplars, err := c.sourceBlocksExemplars(blocksToCompactDirs, jobLogger)
+	if err != nil {
+		return false, nil, err
+	}
+
 	uploadBegin := time.Now()
 	uploadedBlocks := atomic.NewInt64(0)
 
@@ -430,6 +436,12 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 		}
 
 		begin := time.Now()
+		if exemplars != nil {
+			if err := c.uploadCompactedBlockExemplars(ctx, exemplars, newMeta, blockToUpload.shardIndex, job, jobLogger); err != nil {
+				return err
+			}
+		}
+
 		if err := block.Upload(ctx, jobLogger, c.bkt, bdir, job.hashFunc); err != nil {
 			return errors.Wrapf(err, "upload of %s failed", blockToUpload.ulid)
 		}
@@ -458,6 +470,65 @@ func (c *BucketCompactor) runCompactionJob(ctx context.Context, job *Job) (shoul
 	return true, compIDs, nil
 }
 
+// sourceBlocksExemplars returns the merged exemplars stored alongside the source blocks, or nil if
+// the exemplars are not retained or none of the source blocks has exemplars.
+func (c *BucketCompactor) sourceBlocksExemplars(blockDirs []string, jobLogger log.Logger) (*mimit_tsdb.BlockExemplars, error) {
+	if c.exemplarsRetentionPeriod <= 0 {
+		return nil, nil
+	}
+
+	var sources []*mimit_tsdb.BlockExemplars
+	for _, dir := range blockDirs {
+		exemplars, err := mimit_tsdb.ReadBlockExemplarsFromDir(dir, jobLogger)
+		if errors.Is(err, mimit_tsdb.ErrBlockExemplarsNotFound) {
+			continue
+		}
+		if errors.Is(err, mimit_tsdb.ErrBlockExemplarsCorrupted) {
+			// The exemplars are not essential to the block, so we don't want to halt the compaction.
+			level.Warn(jobLogger).Log("msg", "dropping corrupted block exemplars", "block", dir)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, exemplars)
+	}
+
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	return mimit_tsdb.MergeBlockExemplars(sources...), nil
+}
+
+// uploadCompactedBlockExemplars uploads the exemplars belonging to the compacted block, which are the ones within
+// the block time range, within the retention period and, when splitting, of the series belonging to the block shard.
+func (c *BucketCompactor) uploadCompactedBlockExemplars(ctx context.Context, exemplars *mimit_tsdb.BlockExemplars, meta *metadata.Meta, shardIndex int, job *Job, jobLogger log.Logger) error {
+	minT := meta.MinTime
+	if retentionMinT := time.Now().Add(-c.exemplarsRetentionPeriod).UnixMilli(); minT < retentionMinT {
+		minT = retentionMinT
+	}
+
+	exemplars = exemplars.Filter(func(series labels.Labels, ex mimit_tsdb.BlockExemplar) bool {
+		if ex.Timestamp < minT || ex.Timestamp >= meta.MaxTime {
+			return false
+		}
+
+		// Must be the same sharding used by the compactor to split the series.
+		return !job.UseSplitting() || series.Hash()%uint64(job.SplittingShards()) == uint64(shardIndex)
+	})
+	if len(exemplars.Series) == 0 {
+		return nil
+	}
+
+	if err := mimit_tsdb.WriteBlockExemplars(ctx, c.bkt, meta.ULID, exemplars); err != nil {
+		return errors.Wrapf(err, "upload of %s exemplars failed", meta.ULID)
+	}
+
+	level.Info(jobLogger).Log("msg", "uploaded block exemplars", "result_block", meta.ULID, "exemplars", exemplars.NumExemplars())
+	return nil
+}
+
 // convertCompactionResultToForEachJobs filters out empty ULIDs.
 // When handling result of split compactions, shard index is index in the slice returned by compaction.
 func convertCompactionResultToForEachJobs(compactedBlocks []ulid.ULID, splitJob bool, jobLogger log.Logger) []ulidWithShardIndex {
@@ -660,6 +731,9 @@ type BucketCompactor struct {
 	sortJobs                       JobsOrderFunc
 	blockSyncConcurrency           int
 	metrics                        *BucketCompactorMetrics
+
+	// How long the exemplars stored alongside the blocks are retained. 0 to drop them.
+	exemplarsRetentionPeriod time.Duration
 }
 
 // NewBucketCompactor creates a new bucket compactor.
@@ -677,6 +751,7 @@ func NewBucketCompactor(
 	sortJobs JobsOrderFunc,
 	blockSyncConcurrency int,
 	metrics *BucketCompactorMetrics,
+	exemplarsRetentionPeriod time.Duration,
 ) (*BucketCompactor, error) {
 	if concurrency <= 0 {
 		return nil, errors.Errorf("invalid concurrency level (%d), concurrency level must be > 0", concurrency)
@@ -695,6 +770,7 @@ func NewBucketCompactor(
 		sortJobs:                       sortJobs,
 		blockSyncConcurrency:           blockSyncConcurrency,
 		metrics:                        metrics,
+		exemplarsRetentionPeriod:       exemplarsRetentionPeriod,
 	}, nil
 }
 
@@ -9,11 +9,15 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/tsdb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,6 +27,7 @@ import (
 
 	"github.com/thanos-io/thanos/pkg/block/metadata"
 
+	mimit_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storage/tsdb/bucketindex"
 )
 
@@ -119,7 +124,7 @@ func TestFilterOwnJobs(t *testing.T) {
 	m := NewBucketCompactorMetrics(prometheus.NewCounter(prometheus.CounterOpts{}), prometheus.NewCounter(prometheus.CounterOpts{}), nil)
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
-			bc, err := NewBucketCompactor(log.NewNopLogger(), nil, nil, nil, nil, "", nil, 2, false, testCase.ownJob, nil, 4, m)
+			bc, err := NewBucketCompactor(log.NewNopLogger(), nil, nil, nil, nil, "", nil, 2, false, testCase.ownJob, nil, 4, m, 0)
 			require.NoError(t, err)
 
 			res, err := bc.filterOwnJobs(jobsFn())
@@ -252,3 +257,66 @@ func TestConvertCompactionResultToForEachJobs(t *testing.T) {
 	require.Equal(t, ulidWithShardIndex{ulid: ulid1, shardIndex: 1}, res[0])
 	require.Equal(t, ulidWithShardIndex{ulid: ulid2, shardIndex: 3}, res[1])
 }
+
+func TestBucketCompactor_UploadCompactedBlockExemplars(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	series := []labels.Labels{
+		labels.FromStrings(labels.MetricName, "metric", "pod", "a"),
+		labels.FromStrings(labels.MetricName, "metric", "pod", "b"),
+		labels.FromStrings(labels.MetricName, "metric", "pod", "c"),
+	}
+
+	var results []exemplar.QueryResult
+	for _, s := range series {
+		results = append(results, exemplar.QueryResult{SeriesLabels: s, Exemplars: []exemplar.Exemplar{
+			{Labels: labels.FromStrings("trace_id", "old"), Value: 1, Ts: now.Add(-3 * time.Hour).UnixMilli()},
+			{Labels: labels.FromStrings("trace_id", "new"), Value: 2, Ts: now.Add(-30 * time.Minute).UnixMilli()},
+			{Labels: labels.FromStrings("trace_id", "future"), Value: 3, Ts: now.Add(time.Hour).UnixMilli()},
+		}})
+	}
+	exemplars := mimit_tsdb.NewBlockExemplars(results)
+
+	meta := &metadata.Meta{BlockMeta: tsdb.BlockMeta{ULID: ulid.MustNew(1, nil), MinTime: now.Add(-4 * time.Hour).UnixMilli(), MaxTime: now.UnixMilli()}}
+
+	t.Run("should upload the exemplars within the block time range and the retention period", func(t *testing.T) {
+		bkt := objstore.NewInMemBucket()
+		c := &BucketCompactor{bkt: bkt, exemplarsRetentionPeriod: time.Hour}
+		job := NewJob("user-1", "key", nil, 0, metadata.NoneFunc, false, 0, "")
+
+		require.NoError(t, c.uploadCompactedBlockExemplars(ctx, exemplars, meta, 0, job, log.NewNopLogger()))
+
+		actual, err := mimit_tsdb.ReadBlockExemplars(ctx, objstore.WithNoopInstr(bkt), meta.ULID, log.NewNopLogger())
+		require.NoError(t, err)
+		require.Len(t, actual.Series, len(series))
+		for _, s := range actual.Series {
+			require.Len(t, s.Exemplars, 1)
+			assert.Equal(t, labels.FromStrings("trace_id", "new"), s.Exemplars[0].Labels)
+		}
+	})
+
+	t.Run("should upload only the exemplars of the series belonging to the shard when splitting", func(t *testing.T) {
+		bkt := objstore.NewInMemBucket()
+		c := &BucketCompactor{bkt: bkt, exemplarsRetentionPeriod: 24 * time.Hour}
+		job := NewJob("user-1", "key", nil, 0, metadata.NoneFunc, true, 2, "")
+
+		total := 0
+		for shardIndex := 0; shardIndex < 2; shardIndex++ {
+			meta := &metadata.Meta{BlockMeta: tsdb.BlockMeta{ULID: ulid.MustNew(uint64(shardIndex+1), nil), MinTime: meta.MinTime, MaxTime: meta.MaxTime}}
+			require.NoError(t, c.uploadCompactedBlockExemplars(ctx, exemplars, meta, shardIndex, job, log.NewNopLogger()))
+
+			actual, err := mimit_tsdb.ReadBlockExemplars(ctx, objstore.WithNoopInstr(bkt), meta.ULID, log.NewNopLogger())
+			if errors.Is(err, mimit_tsdb.ErrBlockExemplarsNotFound) {
+				continue
+			}
+			require.NoError(t, err)
+
+			for _, s := range actual.Series {
+				assert.Equal(t, uint64(shardIndex), s.Labels.Hash()%2)
+				assert.Len(t, s.Exemplars, 2)
+			}
+			total += len(actual.Series)
+		}
+		assert.Equal(t, len(series), total)
+	})
+}
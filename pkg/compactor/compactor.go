@@ -33,6 +33,7 @@ import (
 	"github.com/grafana/mimir/pkg/storage/bucket"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storage/tsdb/bucketindex"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	util_log "github.com/grafana/mimir/pkg/util/log"
 )
@@ -40,6 +41,9 @@ import (
 const (
 	// CompactorRingKey is the key under which we store the compactors ring in the KVStore.
 	CompactorRingKey = "compactor"
+
+	// usageReportsRingKey is the key hashed to find the compactor writing the usage reports.
+	usageReportsRingKey = "usage-metering-reports"
 )
 
 const (
@@ -93,6 +97,7 @@ type Config struct {
 	DeletionDelay         time.Duration           `yaml:"deletion_delay" category:"advanced"`
 	TenantCleanupDelay    time.Duration           `yaml:"tenant_cleanup_delay" category:"advanced"`
 	MaxCompactionTime     time.Duration           `yaml:"max_compaction_time" category:"advanced"`
+	MetricsMetadataMaxAge time.Duration           `yaml:"metrics_metadata_max_age" category:"experimental"`
 
 	// Compactor concurrency options
 	MaxOpeningBlocksConcurrency int `yaml:"max_opening_blocks_concurrency" category:"advanced"` // Number of goroutines opening blocks before compaction.
@@ -116,6 +121,9 @@ type Config struct {
 	// Allow downstream projects to customise the blocks compactor.
 	BlocksGrouperFactory   BlocksGrouperFactory   `yaml:"-"`
 	BlocksCompactorFactory BlocksCompactorFactory `yaml:"-"`
+
+	// Tracks the tenants usage and writes the usage reports, if usage metering is enabled.
+	UsageRecorder *usagemetering.Recorder `yaml:"-"`
 }
 
 // RegisterFlags registers the MultitenantCompactor flags.
@@ -141,6 +149,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	f.DurationVar(&cfg.DeletionDelay, "compactor.deletion-delay", 12*time.Hour, "Time before a block marked for deletion is deleted from bucket. "+
 		"If not 0, blocks will be marked for deletion and compactor component will permanently delete blocks marked for deletion from the bucket. "+
 		"If 0, blocks will be deleted straight away. Note that deleting blocks immediately can cause query failures.")
+	f.DurationVar(&cfg.MetricsMetadataMaxAge, "compactor.metrics-metadata-max-age", 0, "The metric metadata stored in the long-term storage which hasn't been seen by the ingesters within this period is removed. If the tenant has a blocks retention period shorter than this, the metadata is removed after the retention period instead. 0 to keep the metadata for the blocks retention period only.")
 	f.DurationVar(&cfg.TenantCleanupDelay, "compactor.tenant-cleanup-delay", 6*time.Hour, "For tenants marked for deletion, this is time between deleting of last block, and doing final cleanup (marker files, debug files) of the tenant.")
 	// compactor concurrency options
 	f.IntVar(&cfg.MaxOpeningBlocksConcurrency, "compactor.max-opening-blocks-concurrency", 1, "Number of goroutines opening blocks before compaction.")
@@ -192,6 +201,9 @@ type ConfigProvider interface {
 
 	// CompactorTenantShardSize returns number of compactors that this user can use. 0 = all compactors.
 	CompactorTenantShardSize(userID string) int
+
+	// ExemplarsRetentionPeriod returns how long exemplars are retained in the long-term storage. 0 when disabled.
+	ExemplarsRetentionPeriod(userID string) time.Duration
 }
 
 // MultitenantCompactor is a multi-tenant TSDB blocks compactor based on Thanos.
@@ -442,6 +454,11 @@ func (c *MultitenantCompactor) starting(ctx context.Context) error {
 	allowedTenants := util.NewAllowedTenants(c.compactorCfg.EnabledTenants, c.compactorCfg.DisabledTenants)
 	c.shardingStrategy = newSplitAndMergeShardingStrategy(allowedTenants, c.ring, c.ringLifecycler, c.cfgProvider)
 
+	// The usage reports are written by a single compactor.
+	c.compactorCfg.UsageRecorder.EnableReports(func() (bool, error) {
+		return instanceOwnsTokenInRing(c.ring, c.ringLifecycler.Addr, usageReportsRingKey)
+	})
+
 	// Create the blocks cleaner (service).
 	c.blocksCleaner = NewBlocksCleaner(BlocksCleanerConfig{
 		DeletionDelay:           c.compactorCfg.DeletionDelay,
@@ -449,6 +466,8 @@ func (c *MultitenantCompactor) starting(ctx context.Context) error {
 		CleanupConcurrency:      c.compactorCfg.CleanupConcurrency,
 		TenantCleanupDelay:      c.compactorCfg.TenantCleanupDelay,
 		DeleteBlocksConcurrency: defaultDeleteBlocksConcurrency,
+		MetricsMetadataMaxAge:   c.compactorCfg.MetricsMetadataMaxAge,
+		UsageRecorder:           c.compactorCfg.UsageRecorder,
 	}, c.bucketClient, c.shardingStrategy.blocksCleanerOwnUser, c.cfgProvider, c.parentLogger, c.registerer)
 
 	// Start blocks cleaner asynchronously, don't wait until initial cleanup is finished.
@@ -689,6 +708,7 @@ func (c *MultitenantCompactor) compactUser(ctx context.Context, userID string) e
 		c.jobsOrder,
 		c.compactorCfg.BlockSyncConcurrency,
 		c.bucketCompactorMetrics,
+		c.cfgProvider.ExemplarsRetentionPeriod(userID),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create bucket compactor")
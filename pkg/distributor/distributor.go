@@ -42,6 +42,7 @@ import (
 	"github.com/grafana/mimir/pkg/distributor/forwarding"
 	ingester_client "github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/mimirpb"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/httpgrpcutil"
 	util_math "github.com/grafana/mimir/pkg/util/math"
@@ -115,6 +116,7 @@ type Distributor struct {
 	sampleDelayHistogram             prometheus.Histogram
 	ingesterAppends                  *prometheus.CounterVec
 	ingesterAppendFailures           *prometheus.CounterVec
+	ingesterAppendsUnavailable       prometheus.Counter
 	ingesterQueries                  *prometheus.CounterVec
 	ingesterQueryFailures            *prometheus.CounterVec
 	replicationFactor                prometheus.Gauge
@@ -146,6 +148,9 @@ type Config struct {
 	// This config is dynamically injected because defined in the querier config.
 	ShuffleShardingLookbackPeriod time.Duration `yaml:"-"`
 
+	// Tracks the tenants usage, if usage metering is enabled.
+	UsageRecorder *usagemetering.Recorder `yaml:"-"`
+
 	// Limits for distributor
 	InstanceLimits InstanceLimits `yaml:"instance_limits"`
 
@@ -322,6 +327,11 @@ func New(cfg Config, clientConfig ingester_client.Config, limits *validation.Ove
 			Name:      "distributor_ingester_append_failures_total",
 			Help:      "The total number of failed batch appends sent to ingesters.",
 		}, []string{"ingester", "type"}),
+		ingesterAppendsUnavailable: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "distributor_ingester_appends_unavailable_total",
+			Help:      "The total number of batch appends rejected by ingesters with a retryable error because temporarily unavailable, eg. when overloaded.",
+		}),
 		ingesterQueries: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
 			Namespace: "cortex",
 			Name:      "distributor_ingester_queries_total",
@@ -626,6 +636,7 @@ func (d *Distributor) PushWithCleanup(ctx context.Context, req *mimirpb.WriteReq
 	d.incomingExemplars.WithLabelValues(userID).Add(float64(numExemplars))
 	// Count the total number of metadata in.
 	d.incomingMetadata.WithLabelValues(userID).Add(float64(len(req.Metadata)))
+	d.cfg.UsageRecorder.AddBytesReceived(userID, req.Size())
 
 	// A WriteRequest can only contain series or metadata but not both. This might change in the future.
 	// For each timeseries or samples, we compute a hash to distribute across ingesters;
@@ -855,6 +866,11 @@ func (d *Distributor) PushWithCleanup(ctx context.Context, req *mimirpb.WriteReq
 	if err != nil {
 		return nil, err
 	}
+
+	// The samples are tracked as ingested only once successfully pushed to the ingesters, given
+	// the client is expected to retry the failed requests.
+	d.cfg.UsageRecorder.AddSamplesIngested(userID, validatedSamples)
+
 	return &mimirpb.WriteResponse{}, firstPartialErr
 }
 
@@ -911,6 +927,17 @@ func (d *Distributor) send(ctx context.Context, ingester ring.InstanceDesc, time
 		}
 	}
 
+	// Ingesters under pressure (eg. with the push circuit breaker open) shed load with a retryable 503 error.
+	// ring.DoBatch() counts it as the failure of a single replica, like any other error, so the request still
+	// succeeds if each series is written to the quorum of ingesters. Otherwise the error is returned to the
+	// client as is, so that it backs off and retries. The rejections are not tracked per-ingester, because
+	// each ingester already tracks the requests it rejects.
+	if err != nil {
+		if resp, ok := httpgrpc.HTTPResponseFromError(err); ok && resp.Code == http.StatusServiceUnavailable {
+			d.ingesterAppendsUnavailable.Inc()
+		}
+	}
+
 	return err
 }
 
@@ -1227,6 +1254,254 @@ func (cm *labelValuesCardinalityConcurrentMap) toLabelValuesCardinalityResponse(
 	}
 }
 
+// ActiveSeries queries the ingesters for the series which are currently active and match the matchers.
+// The series returned by the ingesters are deduplicated, and their order is not guaranteed.
+func (d *Distributor) ActiveSeries(ctx context.Context, matchers []*labels.Matcher) ([]labels.Labels, error) {
+	replicationSet, err := d.GetIngestersForMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchersProto, err := ingester_client.ToLabelMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+	req := &ingester_client.ActiveSeriesRequest{Matchers: matchersProto}
+
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merger := &activeSeriesResponseMerger{
+		result:         map[uint64][]labels.Labels{},
+		sizeLimitBytes: d.limits.ActiveSeriesResultsMaxSizeBytes(userID),
+	}
+	_, err = d.ForReplicationSet(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
+		stream, err := client.ActiveSeries(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.CloseSend() //nolint:errcheck
+		return nil, merger.collectResponses(stream)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merger.toActiveSeries(), nil
+}
+
+// SeriesChurn queries the ingesters for the number of series created and removed for each metric name
+// over the input window. The order of the returned items is not guaranteed.
+func (d *Distributor) SeriesChurn(ctx context.Context, window time.Duration) ([]*ingester_client.MetricSeriesChurn, error) {
+	replicationSet, err := d.GetIngestersForMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure we get a successful response from all of them.
+	replicationSet.MaxErrors = 0
+	replicationSet.MaxUnavailableZones = 0
+
+	req := &ingester_client.SeriesChurnRequest{WindowMs: window.Milliseconds()}
+	resps, err := d.ForReplicationSet(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
+		return client.SeriesChurn(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Each series is created and removed in every ingester it's replicated to,
+	// so the summed counts are divided by the replication factor.
+	merged := map[string]*ingester_client.MetricSeriesChurn{}
+	for _, resp := range resps {
+		for _, item := range resp.(*ingester_client.SeriesChurnResponse).Items {
+			m, ok := merged[item.MetricName]
+			if !ok {
+				m = &ingester_client.MetricSeriesChurn{MetricName: item.MetricName}
+				merged[item.MetricName] = m
+			}
+			m.CreatedSeries += item.CreatedSeries
+			m.RemovedSeries += item.RemovedSeries
+		}
+	}
+
+	replicationFactor := uint64(d.ingestersRing.ReplicationFactor())
+	result := make([]*ingester_client.MetricSeriesChurn, 0, len(merged))
+	for _, m := range merged {
+		m.CreatedSeries /= replicationFactor
+		m.RemovedSeries /= replicationFactor
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// tsdbStatusTopN is the number of items returned by TSDBStatus for each top list, like in Prometheus.
+const tsdbStatusTopN = 10
+
+// TSDBStatus returns the statistics of the tenant's TSDB head, merged across the ingesters.
+//
+// The counts of series and chunks are summed across ingesters and divided by the replication factor.
+// The number of label pairs, values and their size can't be summed, because the same label values are
+// stored in many ingesters, so the highest count across ingesters is returned, which is a lower bound.
+// The top lists are merged from the top lists of each ingester, so they're an approximation.
+func (d *Distributor) TSDBStatus(ctx context.Context) (*ingester_client.TSDBStatusResponse, error) {
+	replicationSet, err := d.GetIngestersForMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure we get a successful response from all of them.
+	replicationSet.MaxErrors = 0
+	replicationSet.MaxUnavailableZones = 0
+
+	req := &ingester_client.TSDBStatusRequest{}
+	resps, err := d.ForReplicationSet(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
+		return client.TSDBStatus(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result                      = &ingester_client.TSDBStatusResponse{}
+		seriesCountByMetricName     = map[string]uint64{}
+		labelValueCountByLabelName  = map[string]uint64{}
+		memoryInBytesByLabelName    = map[string]uint64{}
+		seriesCountByLabelValuePair = map[string]uint64{}
+	)
+
+	for _, resp := range resps {
+		r := resp.(*ingester_client.TSDBStatusResponse)
+		if r.NumSeries == 0 {
+			continue
+		}
+
+		if result.NumSeries == 0 || r.MinTime < result.MinTime {
+			result.MinTime = r.MinTime
+		}
+		if result.NumSeries == 0 || r.MaxTime > result.MaxTime {
+			result.MaxTime = r.MaxTime
+		}
+		result.NumSeries += r.NumSeries
+		result.ChunkCount += r.ChunkCount
+		result.NumLabelPairs = util_math.MaxUint64(result.NumLabelPairs, r.NumLabelPairs)
+
+		for _, item := range r.SeriesCountByMetricName {
+			seriesCountByMetricName[item.Name] += item.Value
+		}
+		for _, item := range r.LabelValueCountByLabelName {
+			labelValueCountByLabelName[item.Name] = util_math.MaxUint64(labelValueCountByLabelName[item.Name], item.Value)
+		}
+		for _, item := range r.MemoryInBytesByLabelName {
+			memoryInBytesByLabelName[item.Name] = util_math.MaxUint64(memoryInBytesByLabelName[item.Name], item.Value)
+		}
+		for _, item := range r.SeriesCountByLabelValuePair {
+			seriesCountByLabelValuePair[item.Name] += item.Value
+		}
+	}
+
+	// Each series is stored in every ingester it's replicated to.
+	replicationFactor := uint64(d.ingestersRing.ReplicationFactor())
+	result.NumSeries /= replicationFactor
+	result.ChunkCount /= replicationFactor
+
+	result.SeriesCountByMetricName = topTSDBStatItems(seriesCountByMetricName, replicationFactor)
+	result.LabelValueCountByLabelName = topTSDBStatItems(labelValueCountByLabelName, 1)
+	result.MemoryInBytesByLabelName = topTSDBStatItems(memoryInBytesByLabelName, 1)
+	result.SeriesCountByLabelValuePair = topTSDBStatItems(seriesCountByLabelValuePair, replicationFactor)
+
+	return result, nil
+}
+
+// topTSDBStatItems returns the tsdbStatusTopN items with the highest value, after dividing the values by the input divisor.
+func topTSDBStatItems(values map[string]uint64, divisor uint64) []ingester_client.TSDBStatItem {
+	items := make([]ingester_client.TSDBStatItem, 0, len(values))
+	for name, value := range values {
+		items = append(items, ingester_client.TSDBStatItem{Name: name, Value: value / divisor})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Value != items[j].Value {
+			return items[i].Value > items[j].Value
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	if len(items) > tsdbStatusTopN {
+		items = items[:tsdbStatusTopN]
+	}
+	return items
+}
+
+type activeSeriesResponseMerger struct {
+	lock             sync.Mutex
+	result           map[uint64][]labels.Labels
+	sizeLimitBytes   int
+	currentSizeBytes int
+}
+
+// collectResponses listens for the stream and once the message is received, puts the series to the map with distinct series.
+func (m *activeSeriesResponseMerger) collectResponses(stream ingester_client.Ingester_ActiveSeriesClient) error {
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := m.putSeriesToMap(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *activeSeriesResponseMerger) putSeriesToMap(message *ingester_client.ActiveSeriesResponse) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, metric := range message.Metric {
+		// The labels are copied because the message buffer could be reused.
+		series := mimirpb.FromLabelAdaptersToLabelsWithCopy(metric.Labels)
+		fp := series.Hash()
+
+		exists := false
+		for _, existing := range m.result[fp] {
+			if labels.Equal(existing, series) {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+
+		for _, l := range series {
+			m.currentSizeBytes += len(l.Name) + len(l.Value)
+		}
+		if m.currentSizeBytes > m.sizeLimitBytes {
+			return fmt.Errorf("size of distinct active series is greater than %v bytes", m.sizeLimitBytes)
+		}
+		m.result[fp] = append(m.result[fp], series)
+	}
+	return nil
+}
+
+// toActiveSeries returns the distinct series collected from the ingesters.
+func (m *activeSeriesResponseMerger) toActiveSeries() []labels.Labels {
+	// we need to acquire the lock to prevent concurrent read/write to the map because it might be a case that some ingesters responses are
+	// still being processed if replicationSet.Do() returned execution to this method when it decided that it got enough responses from the quorum of instances.
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	result := make([]labels.Labels, 0, len(m.result))
+	for _, series := range m.result {
+		result = append(result, series...)
+	}
+	return result
+}
+
 // LabelNames returns all of the label names.
 func (d *Distributor) LabelNames(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) ([]string, error) {
 	replicationSet, err := d.GetIngestersForMetadata(ctx)
@@ -8,6 +8,7 @@ package distributor
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -34,6 +35,7 @@ import (
 	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/mtime"
 	"github.com/weaveworks/common/user"
@@ -49,7 +51,9 @@ import (
 	"github.com/grafana/mimir/pkg/ingester"
 	"github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/mimirpb"
+	"github.com/grafana/mimir/pkg/querier/stats"
 	"github.com/grafana/mimir/pkg/storage/chunk"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/chunkcompat"
 	"github.com/grafana/mimir/pkg/util/limiter"
@@ -952,6 +956,36 @@ func TestDistributor_QueryStream_ShouldReturnErrorIfMaxChunksPerQueryLimitIsReac
 	assert.Contains(t, err.Error(), "the query hit the max number of chunks limit")
 }
 
+func TestDistributor_QueryStream_ShouldTrackIngesterQueryStats(t *testing.T) {
+	const numSeries = 10
+
+	ctx := user.InjectOrgID(context.Background(), "user")
+
+	// Prepare distributors. A single ingester is used to get deterministic statistics.
+	ds, _, _ := prepare(t, prepConfig{
+		numIngesters:      1,
+		happyIngesters:    1,
+		numDistributors:   1,
+		replicationFactor: 1,
+	})
+
+	writeReq := makeWriteRequest(0, numSeries, 0, false)
+	_, err := ds[0].Push(ctx, writeReq)
+	require.NoError(t, err)
+
+	reqStats, ctx := stats.ContextWithEmptyStats(ctx)
+	queryRes, err := ds[0].QueryStream(ctx, math.MinInt32, math.MaxInt32, labels.MustNewMatcher(labels.MatchRegexp, model.MetricNameLabel, ".+"))
+	require.NoError(t, err)
+	require.Len(t, queryRes.Chunkseries, numSeries)
+
+	assert.Equal(t, time.Millisecond, reqStats.LoadIngesterWallTime())
+	assert.Equal(t, uint64(numSeries), reqStats.LoadIngesterSeries())
+	assert.Equal(t, uint64(queryRes.ChunksCount()), reqStats.LoadIngesterChunks())
+	assert.NotZero(t, reqStats.LoadIngesterChunkBytes())
+	assert.Equal(t, uint64(queryRes.ChunksCount()), reqStats.LoadIngesterHeadChunks())
+	assert.Equal(t, uint64(0), reqStats.LoadIngesterBlockChunks())
+}
+
 func TestDistributor_QueryStream_ShouldReturnErrorIfMaxSeriesPerQueryLimitIsReached(t *testing.T) {
 	const maxSeriesLimit = 10
 
@@ -1315,6 +1349,125 @@ func TestDistributor_Push_LabelNameValidation(t *testing.T) {
 	}
 }
 
+func TestDistributor_Push_ShouldHandleIngestersRejectingWithRetryableError(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "user")
+
+	// Push many series, so that they're spread across multiple batches.
+	const numSeries = 100
+
+	tests := map[string]struct {
+		happyIngesters int
+		expectedErr    bool
+	}{
+		"1 out of 3 ingesters has the push circuit breaker open": {
+			happyIngesters: 2,
+		},
+		"2 out of 3 ingesters have the push circuit breaker open": {
+			happyIngesters: 1,
+			expectedErr:    true,
+		},
+	}
+
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ds, ingesters, _ := prepare(t, prepConfig{
+				numIngesters:            3,
+				happyIngesters:          tc.happyIngesters,
+				numDistributors:         1,
+				replicationFactor:       3,
+				unhappyIngestersPushErr: ingester.ErrPushCircuitBreakerOpen,
+			})
+
+			_, err := ds[0].Push(ctx, makeWriteRequest(100000, numSeries, 0, false))
+			if tc.expectedErr {
+				// The retryable error is returned as is, so that the client retries.
+				resp, ok := httpgrpc.HTTPResponseFromError(err)
+				require.True(t, ok)
+				assert.Equal(t, int32(http.StatusServiceUnavailable), resp.Code)
+			} else {
+				require.NoError(t, err)
+
+				// All the series have been written to the ingesters with the push circuit breaker closed.
+				for i := 0; i < tc.happyIngesters; i++ {
+					assert.Len(t, ingesters[i].series(), numSeries)
+				}
+			}
+
+			// The push to the unavailable ingesters may complete after the quorum has been reached.
+			expectedRejections := float64(3 - tc.happyIngesters)
+			test.Poll(t, time.Second, expectedRejections, func() interface{} {
+				return testutil.ToFloat64(ds[0].ingesterAppendsUnavailable)
+			})
+		})
+	}
+}
+
+func TestDistributor_Push_ShouldTrackSamplesIngestedOnlyOnSuccess(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "user")
+
+	tests := map[string]struct {
+		happyIngesters          int
+		expectedSamplesIngested int64
+	}{
+		"the push succeeds": {
+			happyIngesters:          3,
+			expectedSamplesIngested: 10,
+		},
+		"the push fails": {
+			happyIngesters:          1,
+			expectedSamplesIngested: 0,
+		},
+	}
+
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			bkt := objstore.NewInMemBucket()
+			recorder := usagemetering.NewRecorder(usagemetering.Config{Enabled: true, InstanceID: "distributor", FlushInterval: time.Hour, ReportDelay: 2 * time.Hour}, bkt, log.NewNopLogger(), nil)
+			require.NoError(t, services.StartAndAwaitRunning(context.Background(), recorder))
+
+			ds, _, _ := prepare(t, prepConfig{
+				numIngesters:      3,
+				happyIngesters:    tc.happyIngesters,
+				numDistributors:   1,
+				replicationFactor: 3,
+				usageRecorder:     recorder,
+			})
+
+			_, err := ds[0].Push(ctx, makeWriteRequest(100000, 10, 0, false))
+			if tc.expectedSamplesIngested > 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+
+			// The usage tracked in memory is uploaded on shutdown.
+			require.NoError(t, services.StopAndAwaitTerminated(context.Background(), recorder))
+
+			var samplesIngested, bytesReceived int64
+			require.NoError(t, bkt.Iter(context.Background(), "partials/", func(hourDir string) error {
+				return bkt.Iter(context.Background(), hourDir, func(name string) error {
+					reader, err := bkt.Get(context.Background(), name)
+					require.NoError(t, err)
+					defer reader.Close()
+
+					report := usagemetering.Report{}
+					require.NoError(t, json.NewDecoder(reader).Decode(&report))
+					for _, tenant := range report.Tenants {
+						samplesIngested += tenant.SamplesIngested
+						bytesReceived += tenant.BytesReceived
+					}
+					return nil
+				})
+			}))
+
+			assert.Equal(t, tc.expectedSamplesIngested, samplesIngested)
+
+			// The bytes received are tracked regardless of the push outcome.
+			assert.Greater(t, bytesReceived, int64(0))
+		})
+	}
+}
+
 func TestDistributor_Push_ExemplarValidation(t *testing.T) {
 	ctx := user.InjectOrgID(context.Background(), "user")
 	manyLabels := []string{model.MetricNameLabel, "test"}
@@ -2112,6 +2265,171 @@ func TestDistributor_LabelNamesAndValues(t *testing.T) {
 	}
 }
 
+func TestDistributor_ActiveSeries(t *testing.T) {
+	fixtures := []labels.Labels{
+		{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}},
+		{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}},
+		{{Name: labels.MetricName, Value: "metric_1"}, {Name: "status", Value: "200"}},
+	}
+
+	tests := map[string]struct {
+		matchers       []*labels.Matcher
+		sizeLimitBytes int
+		expectedSeries []labels.Labels
+		expectedError  string
+	}{
+		"should return the distinct series matching the matchers": {
+			matchers:       []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "metric_0")},
+			sizeLimitBytes: 1024,
+			expectedSeries: fixtures[:2],
+		},
+		"should return all the distinct series with no matchers": {
+			sizeLimitBytes: 1024,
+			expectedSeries: fixtures,
+		},
+		"should fail if the size limit is reached": {
+			sizeLimitBytes: 40,
+			expectedError:  "size of distinct active series is greater than 40 bytes",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx := user.InjectOrgID(context.Background(), "active-series")
+
+			limits := validation.Limits{}
+			flagext.DefaultValues(&limits)
+			limits.ActiveSeriesResultsMaxSizeBytes = testData.sizeLimitBytes
+			ds, _, _ := prepare(t, prepConfig{
+				numIngesters:      3,
+				happyIngesters:    3,
+				numDistributors:   1,
+				replicationFactor: 3,
+				limits:            &limits,
+			})
+			t.Cleanup(func() {
+				require.NoError(t, services.StopAndAwaitTerminated(ctx, ds[0]))
+			})
+
+			for _, series := range fixtures {
+				_, err := ds[0].Push(ctx, mockWriteRequest(series, 1, 100000))
+				require.NoError(t, err)
+			}
+
+			actual, err := ds[0].ActiveSeries(ctx, testData.matchers)
+			if testData.expectedError != "" {
+				require.EqualError(t, err, testData.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, testData.expectedSeries, actual)
+		})
+	}
+}
+
+func TestDistributor_SeriesChurn(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "series-churn")
+
+	ds, _, _ := prepare(t, prepConfig{
+		numIngesters:      3,
+		happyIngesters:    3,
+		numDistributors:   1,
+		replicationFactor: 3,
+	})
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(ctx, ds[0]))
+	})
+
+	for _, series := range []labels.Labels{
+		{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}},
+		{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}},
+		{{Name: labels.MetricName, Value: "metric_1"}, {Name: "status", Value: "200"}},
+	} {
+		_, err := ds[0].Push(ctx, mockWriteRequest(series, 1, 100000))
+		require.NoError(t, err)
+	}
+
+	// The series are replicated to all the ingesters, so the counts are divided by the replication factor.
+	// Since the Push() response is sent as soon as the quorum is reached, the final ingester may not have
+	// received the series yet, so we retry the assertion until we hit the desired state.
+	test.Poll(t, time.Second, map[string]uint64{"metric_0": 2, "metric_1": 1}, func() interface{} {
+		items, err := ds[0].SeriesChurn(ctx, time.Hour)
+		require.NoError(t, err)
+
+		created := map[string]uint64{}
+		for _, item := range items {
+			created[item.MetricName] = item.CreatedSeries
+		}
+		return created
+	})
+}
+
+func TestDistributor_TSDBStatus(t *testing.T) {
+	ctx := user.InjectOrgID(context.Background(), "tsdb-status")
+
+	ds, _, _ := prepare(t, prepConfig{
+		numIngesters:      3,
+		happyIngesters:    3,
+		numDistributors:   1,
+		replicationFactor: 3,
+	})
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(ctx, ds[0]))
+	})
+
+	for i, series := range []labels.Labels{
+		{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}},
+		{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}},
+		{{Name: labels.MetricName, Value: "metric_1"}, {Name: "status", Value: "200"}},
+	} {
+		_, err := ds[0].Push(ctx, mockWriteRequest(series, 1, int64(100000*(i+1))))
+		require.NoError(t, err)
+	}
+
+	// The series are replicated to all the ingesters, so the series counts are divided by the replication factor.
+	// Since the Push() response is sent as soon as the quorum is reached, the final ingester may not have
+	// received the series yet, so we retry the assertion until we hit the desired state.
+	expected := &client.TSDBStatusResponse{
+		NumSeries:     3,
+		NumLabelPairs: 4,
+		ChunkCount:    3,
+		MinTime:       100000,
+		MaxTime:       300000,
+		SeriesCountByMetricName: []client.TSDBStatItem{
+			{Name: "metric_0", Value: 2},
+			{Name: "metric_1", Value: 1},
+		},
+		LabelValueCountByLabelName: []client.TSDBStatItem{
+			{Name: labels.MetricName, Value: 2},
+			{Name: "status", Value: 2},
+		},
+		MemoryInBytesByLabelName: []client.TSDBStatItem{},
+		SeriesCountByLabelValuePair: []client.TSDBStatItem{
+			{Name: labels.MetricName + "=metric_0", Value: 2},
+			{Name: "status=200", Value: 2},
+			{Name: labels.MetricName + "=metric_1", Value: 1},
+			{Name: "status=500", Value: 1},
+		},
+	}
+	test.Poll(t, time.Second, expected, func() interface{} {
+		res, err := ds[0].TSDBStatus(ctx)
+		require.NoError(t, err)
+		return res
+	})
+}
+
+func TestTopTSDBStatItems(t *testing.T) {
+	values := map[string]uint64{}
+	for i := 0; i < tsdbStatusTopN+5; i++ {
+		values[fmt.Sprintf("item_%02d", i)] = uint64(i * 3)
+	}
+
+	items := topTSDBStatItems(values, 3)
+	require.Len(t, items, tsdbStatusTopN)
+	assert.Equal(t, client.TSDBStatItem{Name: "item_14", Value: 14}, items[0])
+	assert.Equal(t, client.TSDBStatItem{Name: "item_05", Value: 5}, items[tsdbStatusTopN-1])
+}
+
 // This test asserts that distributor waits for all ingester responses to be completed even if ZoneAwareness is enabled.
 // Also, it simulates delay from zone C to verify that there is no race condition. must be run with `-race` flag (race detection).
 func TestDistributor_LabelValuesCardinality_ExpectedAllIngestersResponsesToBeCompleted(t *testing.T) {
@@ -2639,6 +2957,8 @@ type prepConfig struct {
 	ingesterZones                []string
 	zonesResponseDelay           map[string]time.Duration
 	forwarding                   bool
+	unhappyIngestersPushErr      error // Defaults to errFail.
+	usageRecorder                *usagemetering.Recorder
 }
 
 func prepare(t *testing.T, cfg prepConfig) ([]*Distributor, []mockIngester, []*prometheus.Registry) {
@@ -2664,6 +2984,7 @@ func prepare(t *testing.T, cfg prepConfig) ([]*Distributor, []mockIngester, []*p
 		ingesters = append(ingesters, mockIngester{
 			queryDelay:       cfg.queryDelay,
 			seriesCountTotal: cfg.ingestersSeriesCountTotal,
+			pushErr:          cfg.unhappyIngestersPushErr,
 		})
 	}
 
@@ -2741,6 +3062,7 @@ func prepare(t *testing.T, cfg prepConfig) ([]*Distributor, []mockIngester, []*p
 		distributorCfg.InstanceLimits.MaxInflightPushRequests = cfg.maxInflightRequests
 		distributorCfg.InstanceLimits.MaxIngestionRate = cfg.maxIngestionRate
 		distributorCfg.ShuffleShardingLookbackPeriod = time.Hour
+		distributorCfg.UsageRecorder = cfg.usageRecorder
 
 		if cfg.forwarding {
 			distributorCfg.Forwarding.Enabled = true
@@ -2946,6 +3268,7 @@ type mockIngester struct {
 	client.IngesterClient
 	grpc_health_v1.HealthClient
 	happy            bool
+	pushErr          error
 	stats            client.UsersStatsResponse
 	timeseries       map[uint32]*mimirpb.PreallocTimeseries
 	metadata         map[uint32]map[mimirpb.MetricMetadata]struct{}
@@ -2987,6 +3310,9 @@ func (i *mockIngester) Push(ctx context.Context, req *mimirpb.WriteRequest, opts
 	i.trackCall("Push")
 
 	if !i.happy {
+		if i.pushErr != nil {
+			return nil, i.pushErr
+		}
 		return nil, errFail
 	}
 
@@ -3054,6 +3380,7 @@ func (i *mockIngester) QueryStream(ctx context.Context, req *client.QueryRequest
 	}
 
 	results := []*client.QueryStreamResponse{}
+	queryStats := &client.QueryStreamStats{WallTime: time.Millisecond}
 	for _, ts := range i.timeseries {
 		if !match(ts.Labels, matchers) {
 			continue
@@ -3100,7 +3427,19 @@ func (i *mockIngester) QueryStream(ctx context.Context, req *client.QueryRequest
 				},
 			},
 		})
+
+		queryStats.SeriesCount++
+		queryStats.SamplesCount += uint64(len(ts.Samples))
+		queryStats.ChunksCount += uint64(len(wireChunks))
+		queryStats.HeadChunksCount += uint64(len(wireChunks))
+		for _, c := range wireChunks {
+			queryStats.ChunkBytes += uint64(len(c.Data))
+		}
 	}
+
+	// The query statistics are sent in the last message.
+	results = append(results, &client.QueryStreamResponse{Stats: queryStats})
+
 	return &stream{
 		results: results,
 	}, nil
@@ -3227,6 +3566,123 @@ func (s *labelNamesAndValuesMockStream) Recv() (*client.LabelNamesAndValuesRespo
 	return result, nil
 }
 
+func (i *mockIngester) ActiveSeries(ctx context.Context, req *client.ActiveSeriesRequest, opts ...grpc.CallOption) (client.Ingester_ActiveSeriesClient, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("ActiveSeries")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	matchers, err := client.FromLabelMatchers(req.GetMatchers())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &client.ActiveSeriesResponse{}
+	for _, ts := range i.timeseries {
+		if match(ts.Labels, matchers) {
+			resp.Metric = append(resp.Metric, &mimirpb.Metric{Labels: ts.Labels})
+		}
+	}
+	return &activeSeriesMockStream{responses: []*client.ActiveSeriesResponse{resp}}, nil
+}
+
+type activeSeriesMockStream struct {
+	grpc.ClientStream
+	responses []*client.ActiveSeriesResponse
+	i         int
+}
+
+func (*activeSeriesMockStream) CloseSend() error {
+	return nil
+}
+
+func (s *activeSeriesMockStream) Recv() (*client.ActiveSeriesResponse, error) {
+	if s.i >= len(s.responses) {
+		return nil, io.EOF
+	}
+	result := s.responses[s.i]
+	s.i++
+	return result, nil
+}
+
+// SeriesChurn reports all the series in the mock ingester as created.
+func (i *mockIngester) SeriesChurn(ctx context.Context, req *client.SeriesChurnRequest, opts ...grpc.CallOption) (*client.SeriesChurnResponse, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("SeriesChurn")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	created := map[string]uint64{}
+	for _, ts := range i.timeseries {
+		created[mimirpb.FromLabelAdaptersToLabels(ts.Labels).Get(labels.MetricName)]++
+	}
+
+	resp := &client.SeriesChurnResponse{}
+	for name, count := range created {
+		resp.Items = append(resp.Items, &client.MetricSeriesChurn{MetricName: name, CreatedSeries: count})
+	}
+	return resp, nil
+}
+
+func (i *mockIngester) TSDBStatus(ctx context.Context, req *client.TSDBStatusRequest, opts ...grpc.CallOption) (*client.TSDBStatusResponse, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("TSDBStatus")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	resp := &client.TSDBStatusResponse{}
+	seriesCountByMetricName := map[string]uint64{}
+	labelValues := map[string]map[string]struct{}{}
+	seriesCountByLabelValuePair := map[string]uint64{}
+	for _, ts := range i.timeseries {
+		resp.NumSeries++
+		resp.ChunkCount++
+		for _, s := range ts.Samples {
+			if resp.MinTime == 0 || s.TimestampMs < resp.MinTime {
+				resp.MinTime = s.TimestampMs
+			}
+			if s.TimestampMs > resp.MaxTime {
+				resp.MaxTime = s.TimestampMs
+			}
+		}
+
+		for _, l := range ts.Labels {
+			if l.Name == labels.MetricName {
+				seriesCountByMetricName[l.Value]++
+			}
+			if _, ok := labelValues[l.Name]; !ok {
+				labelValues[l.Name] = map[string]struct{}{}
+			}
+			labelValues[l.Name][l.Value] = struct{}{}
+			seriesCountByLabelValuePair[l.Name+"="+l.Value]++
+		}
+	}
+
+	for name, count := range seriesCountByMetricName {
+		resp.SeriesCountByMetricName = append(resp.SeriesCountByMetricName, client.TSDBStatItem{Name: name, Value: count})
+	}
+	for name, values := range labelValues {
+		resp.NumLabelPairs += uint64(len(values))
+		resp.LabelValueCountByLabelName = append(resp.LabelValueCountByLabelName, client.TSDBStatItem{Name: name, Value: uint64(len(values))})
+	}
+	for pair, count := range seriesCountByLabelValuePair {
+		resp.SeriesCountByLabelValuePair = append(resp.SeriesCountByLabelValuePair, client.TSDBStatItem{Name: pair, Value: count})
+	}
+	return resp, nil
+}
+
 func (i *mockIngester) LabelValuesCardinality(ctx context.Context, req *client.LabelValuesCardinalityRequest, opts ...grpc.CallOption) (client.Ingester_LabelValuesCardinalityClient, error) {
 	i.Lock()
 	defer i.Unlock()
@@ -278,6 +278,11 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 				return nil, err
 			}
 
+			// The last message of the stream contains the ingester query statistics.
+			if resp.Stats != nil {
+				addIngesterQueryStreamStats(reqStats, resp.Stats)
+			}
+
 			// Enforce the max chunks limits.
 			if chunkLimitErr := queryLimiter.AddChunks(resp.ChunksCount()); chunkLimitErr != nil {
 				return nil, validation.LimitError(chunkLimitErr.Error())
@@ -335,6 +340,17 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 	return resp, nil
 }
 
+// addIngesterQueryStreamStats adds the query statistics received from an ingester to the input stats.
+func addIngesterQueryStreamStats(reqStats *stats.Stats, ingesterStats *ingester_client.QueryStreamStats) {
+	reqStats.AddIngesterWallTime(ingesterStats.WallTime)
+	reqStats.AddIngesterSelectTime(ingesterStats.SelectTime)
+	reqStats.AddIngesterSeries(ingesterStats.SeriesCount)
+	reqStats.AddIngesterChunks(ingesterStats.ChunksCount)
+	reqStats.AddIngesterChunkBytes(ingesterStats.ChunkBytes)
+	reqStats.AddIngesterHeadChunks(ingesterStats.HeadChunksCount)
+	reqStats.AddIngesterBlockChunks(ingesterStats.BlockChunksCount)
+}
+
 // Merges and dedupes two sorted slices with samples together.
 func mergeSamples(a, b []mimirpb.Sample) []mimirpb.Sample {
 	if sameSamples(a, b) {
@@ -28,6 +28,8 @@ type CombinedFrontendConfig struct {
 
 	QueryMiddleware querymiddleware.Config `yaml:",inline"`
 
+	QueryFrontendRing RingConfig `yaml:"ring" doc:"description=The query-frontends ring is used to share the per-tenant query rate limits across all query-frontend replicas. It is used only when the query rate limits are enabled."`
+
 	DownstreamURL string `yaml:"downstream_url" category:"advanced"`
 }
 
@@ -36,6 +38,7 @@ func (cfg *CombinedFrontendConfig) RegisterFlags(f *flag.FlagSet, logger log.Log
 	cfg.FrontendV1.RegisterFlags(f)
 	cfg.FrontendV2.RegisterFlags(f, logger)
 	cfg.QueryMiddleware.RegisterFlags(f)
+	cfg.QueryFrontendRing.RegisterFlags(f, logger)
 
 	f.StringVar(&cfg.DownstreamURL, "query-frontend.downstream-url", "", "URL of downstream Prometheus.")
 }
@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package astmapper
+
+import (
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// splittableRangeVectorFuncs maps each function whose range vector can be split by time
+// to the function used to compute each sub-range and the aggregation used to recombine them.
+var splittableRangeVectorFuncs = map[string]struct {
+	legFunc string
+	combine parser.ItemType
+}{
+	"sum_over_time":   {legFunc: "sum_over_time", combine: parser.SUM},
+	"count_over_time": {legFunc: "count_over_time", combine: parser.SUM},
+	"max_over_time":   {legFunc: "max_over_time", combine: parser.MAX},
+	"min_over_time":   {legFunc: "min_over_time", combine: parser.MIN},
+	"increase":        {legFunc: "increase", combine: parser.SUM},
+	"rate":            {legFunc: "increase", combine: parser.SUM},
+}
+
+// NewInstantQuerySplitter creates a new mapper which splits the range vector selectors
+// of an instant query into sub-ranges of the given interval.
+func NewInstantQuerySplitter(interval time.Duration, logger log.Logger) ASTMapper {
+	return NewMultiMapper(
+		NewASTNodeMapper(&instantSplitter{
+			interval: interval,
+			logger:   logger,
+		}),
+		newSubtreeFolder(),
+	)
+}
+
+type instantSplitter struct {
+	interval time.Duration
+	logger   log.Logger
+}
+
+// MapNode processes the input node and checks if it can be split by time. If so, it returns
+// a new node which is expected to provide the same output when executed, but running each
+// sub-range as a separate embedded query.
+func (i *instantSplitter) MapNode(node parser.Node, stats *MapperStats) (mapped parser.Node, finished bool, err error) {
+	switch n := node.(type) {
+	case *parser.AggregateExpr:
+		// If the aggregation directly wraps a splittable function and it can be computed
+		// over the partial results of each sub-range, we push it down to each leg to
+		// reduce the number of series returned by each embedded query.
+		call, ok := unwrapParens(n.Expr).(*parser.Call)
+		if !ok || n.Param != nil {
+			return n, false, nil
+		}
+		splittable, ok := splittableRangeVectorFuncs[call.Func.Name]
+		if !ok || splittable.combine != n.Op {
+			return n, false, nil
+		}
+		selector, ok := i.splittableMatrixSelector(call)
+		if !ok {
+			return n, false, nil
+		}
+
+		mapped, err := i.splitAndSquash(call.Func.Name, selector, stats, func(leg parser.Expr) parser.Expr {
+			return &parser.AggregateExpr{
+				Op:       n.Op,
+				Expr:     leg,
+				Grouping: n.Grouping,
+				Without:  n.Without,
+			}
+		}, n.Op, n.Grouping, n.Without)
+		if err != nil {
+			return nil, true, err
+		}
+		return mapped, true, nil
+
+	case *parser.Call:
+		if _, ok := splittableRangeVectorFuncs[n.Func.Name]; !ok {
+			return n, false, nil
+		}
+		selector, ok := i.splittableMatrixSelector(n)
+		if !ok {
+			return n, false, nil
+		}
+
+		// Each leg returns one sample per series, so we recombine them grouping by all labels.
+		combine := splittableRangeVectorFuncs[n.Func.Name].combine
+		mapped, err := i.splitAndSquash(n.Func.Name, selector, stats, nil, combine, nil, true)
+		if err != nil {
+			return nil, true, err
+		}
+		return mapped, true, nil
+
+	case *parser.SubqueryExpr:
+		// Subqueries are evaluated at multiple timestamps, while each embedded query
+		// is executed as an instant query, so we can't split their inner expressions.
+		return n, true, nil
+
+	default:
+		return n, false, nil
+	}
+}
+
+// splittableMatrixSelector returns the matrix selector of the input call if its range
+// is larger than the split interval.
+func (i *instantSplitter) splittableMatrixSelector(call *parser.Call) (*parser.MatrixSelector, bool) {
+	if len(call.Args) != 1 {
+		return nil, false
+	}
+	selector, ok := call.Args[0].(*parser.MatrixSelector)
+	if !ok || selector.Range <= i.interval {
+		return nil, false
+	}
+	if _, ok := selector.VectorSelector.(*parser.VectorSelector); !ok {
+		return nil, false
+	}
+	return selector, true
+}
+
+// splitAndSquash splits the range of the input matrix selector into sub-ranges, each one queried
+// by an embedded query running the leg function of funcName, and recombines their results with
+// the given aggregation. If wrapLeg is not nil, it's applied to each leg before squashing it.
+func (i *instantSplitter) splitAndSquash(funcName string, selector *parser.MatrixSelector, stats *MapperStats, wrapLeg func(parser.Expr) parser.Expr, combine parser.ItemType, grouping []string, without bool) (parser.Expr, error) {
+	/*
+		splitting a range vector function is representable naively as
+
+		sum without() (
+		  sum_over_time(foo[1d]) or
+		  sum_over_time(foo[1d] offset 1d) or
+		  sum_over_time(foo[1d] offset 2d)
+		)
+
+		Each leg but the oldest one is shortened by 1ms, because range vector selectors are
+		closed on both ends and we don't want a sample sitting on a boundary to be counted twice.
+
+		rate() is computed as the sum of the increase() of each sub-range divided by the original
+		range in seconds. increase() extrapolates each sub-range to its boundaries, which covers the
+		gap between the last sample of a sub-range and the first sample of the next one.
+	*/
+
+	legFunc, ok := parser.Functions[splittableRangeVectorFuncs[funcName].legFunc]
+	if !ok {
+		return nil, errors.Errorf("unknown function %s", splittableRangeVectorFuncs[funcName].legFunc)
+	}
+
+	vs := selector.VectorSelector.(*parser.VectorSelector)
+	legs := splitRange(selector.Range, i.interval)
+	children := make([]parser.Node, 0, len(legs))
+
+	for _, leg := range legs {
+		var expr parser.Expr = &parser.Call{
+			Func: legFunc,
+			Args: parser.Expressions{
+				&parser.MatrixSelector{
+					VectorSelector: &parser.VectorSelector{
+						Name:           vs.Name,
+						OriginalOffset: vs.OriginalOffset + leg.offset,
+						Timestamp:      copyTimestamp(vs.Timestamp),
+						StartOrEnd:     vs.StartOrEnd,
+						LabelMatchers:  copyLabelMatchers(vs.LabelMatchers),
+					},
+					Range: leg.rng,
+				},
+			},
+		}
+		if wrapLeg != nil {
+			expr = wrapLeg(expr)
+		}
+		children = append(children, expr)
+	}
+
+	// Update stats.
+	stats.AddSplitQueries(len(children))
+
+	squashed, err := vectorSquasher(children...)
+	if err != nil {
+		return nil, err
+	}
+
+	var combined parser.Expr = &parser.AggregateExpr{
+		Op:       combine,
+		Expr:     squashed,
+		Grouping: grouping,
+		Without:  without,
+	}
+
+	if funcName == "rate" {
+		combined = &parser.ParenExpr{
+			Expr: &parser.BinaryExpr{
+				Op:  parser.DIV,
+				LHS: combined,
+				RHS: &parser.NumberLiteral{Val: selector.Range.Seconds()},
+			},
+		}
+	}
+
+	return combined, nil
+}
+
+type splitLeg struct {
+	rng    time.Duration
+	offset time.Duration
+}
+
+// splitRange splits the input range into legs of the given interval, starting from the most
+// recent one. The oldest leg covers the remainder of the range.
+func splitRange(rng, interval time.Duration) []splitLeg {
+	legs := make([]splitLeg, 0, int((rng+interval-1)/interval))
+
+	for offset := time.Duration(0); offset < rng; offset += interval {
+		if offset+interval >= rng {
+			legs = append(legs, splitLeg{rng: rng - offset, offset: offset})
+			break
+		}
+		legs = append(legs, splitLeg{rng: interval - time.Millisecond, offset: offset})
+	}
+
+	return legs
+}
+
+// unwrapParens returns the innermost expression wrapped in parentheses.
+func unwrapParens(expr parser.Expr) parser.Expr {
+	for {
+		paren, ok := expr.(*parser.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.Expr
+	}
+}
+
+func copyLabelMatchers(matchers []*labels.Matcher) []*labels.Matcher {
+	out := make([]*labels.Matcher, len(matchers))
+	copy(out, matchers)
+	return out
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package astmapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstantSplitter(t *testing.T) {
+	for _, tt := range []struct {
+		in                   string
+		out                  string
+		expectedSplitQueries int
+	}{
+		// Range vector selectors not larger than the split interval are not split.
+		{
+			in:                   `sum_over_time(foo[1h])`,
+			out:                  concat(`sum_over_time(foo[1h])`),
+			expectedSplitQueries: 0,
+		},
+		// Functions which can't be split are not split.
+		{
+			in:                   `avg_over_time(foo[3h])`,
+			out:                  concat(`avg_over_time(foo[3h])`),
+			expectedSplitQueries: 0,
+		},
+		{
+			in: `sum_over_time(foo[3h])`,
+			out: `sum without() (` + concat(
+				`sum_over_time(foo[59m59s999ms])`,
+				`sum_over_time(foo[59m59s999ms] offset 1h)`,
+				`sum_over_time(foo[1h] offset 2h)`,
+			) + `)`,
+			expectedSplitQueries: 3,
+		},
+		{
+			in: `count_over_time(foo{bar="baz"}[2h])`,
+			out: `sum without() (` + concat(
+				`count_over_time(foo{bar="baz"}[59m59s999ms])`,
+				`count_over_time(foo{bar="baz"}[1h] offset 1h)`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		{
+			in: `max_over_time(foo[2h])`,
+			out: `max without() (` + concat(
+				`max_over_time(foo[59m59s999ms])`,
+				`max_over_time(foo[1h] offset 1h)`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		{
+			in: `min_over_time(foo[2h])`,
+			out: `min without() (` + concat(
+				`min_over_time(foo[59m59s999ms])`,
+				`min_over_time(foo[1h] offset 1h)`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		{
+			in: `increase(foo[2h])`,
+			out: `sum without() (` + concat(
+				`increase(foo[59m59s999ms])`,
+				`increase(foo[1h] offset 1h)`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		// rate() is computed from the sum of the increase() of each sub-range.
+		{
+			in: `rate(foo[2h])`,
+			out: `(sum without() (` + concat(
+				`increase(foo[59m59s999ms])`,
+				`increase(foo[1h] offset 1h)`,
+			) + `) / 7200)`,
+			expectedSplitQueries: 2,
+		},
+		// The oldest leg covers the remainder of the range.
+		{
+			in: `sum_over_time(foo[150m])`,
+			out: `sum without() (` + concat(
+				`sum_over_time(foo[59m59s999ms])`,
+				`sum_over_time(foo[59m59s999ms] offset 1h)`,
+				`sum_over_time(foo[30m] offset 2h)`,
+			) + `)`,
+			expectedSplitQueries: 3,
+		},
+		// The original offset and @ modifier are preserved.
+		{
+			in: `sum_over_time(foo[2h] offset 1d)`,
+			out: `sum without() (` + concat(
+				`sum_over_time(foo[59m59s999ms] offset 1d)`,
+				`sum_over_time(foo[1h] offset 1d1h)`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		{
+			in: `sum_over_time(foo[2h] @ 1000)`,
+			out: `sum without() (` + concat(
+				`sum_over_time(foo[59m59s999ms] @ 1000)`,
+				`sum_over_time(foo[1h] @ 1000 offset 1h)`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		// Matching aggregations are pushed down to each leg.
+		{
+			in: `sum by (bar) (rate(foo[2h]))`,
+			out: `(sum by (bar) (` + concat(
+				`sum by (bar) (increase(foo[59m59s999ms]))`,
+				`sum by (bar) (increase(foo[1h] offset 1h))`,
+			) + `) / 7200)`,
+			expectedSplitQueries: 2,
+		},
+		{
+			in: `max without (bar) (max_over_time(foo[2h]))`,
+			out: `max without (bar) (` + concat(
+				`max without (bar) (max_over_time(foo[59m59s999ms]))`,
+				`max without (bar) (max_over_time(foo[1h] offset 1h))`,
+			) + `)`,
+			expectedSplitQueries: 2,
+		},
+		// Non matching aggregations are not pushed down.
+		{
+			in: `max(sum_over_time(foo[2h]))`,
+			out: `max(sum without() (` + concat(
+				`sum_over_time(foo[59m59s999ms])`,
+				`sum_over_time(foo[1h] offset 1h)`,
+			) + `))`,
+			expectedSplitQueries: 2,
+		},
+		{
+			in: `topk(5, increase(foo[2h]))`,
+			out: `topk(5, sum without() (` + concat(
+				`increase(foo[59m59s999ms])`,
+				`increase(foo[1h] offset 1h)`,
+			) + `))`,
+			expectedSplitQueries: 2,
+		},
+		// Parts of the query which can't be split are embedded as they are.
+		{
+			in: `sum(rate(foo[2h])) / sum(rate(bar[5m]))`,
+			out: `(sum(` + concat(
+				`sum(increase(foo[59m59s999ms]))`,
+				`sum(increase(foo[1h] offset 1h))`,
+			) + `) / 7200) / ` + concat(`sum(rate(bar[5m]))`),
+			expectedSplitQueries: 2,
+		},
+		// Subqueries are not split.
+		{
+			in:                   `max_over_time(sum_over_time(foo[2h])[1d:1h])`,
+			out:                  concat(`max_over_time(sum_over_time(foo[2h])[1d:1h])`),
+			expectedSplitQueries: 0,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.in, func(t *testing.T) {
+			mapper := NewInstantQuerySplitter(time.Hour, log.NewNopLogger())
+			expr, err := parser.ParseExpr(tt.in)
+			require.NoError(t, err)
+			out, err := parser.ParseExpr(tt.out)
+			require.NoError(t, err)
+
+			stats := NewMapperStats()
+			mapped, err := mapper.Map(expr, stats)
+			require.NoError(t, err)
+			require.Equal(t, out.String(), mapped.String())
+			assert.Equal(t, tt.expectedSplitQueries, stats.GetSplitQueries())
+		})
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	assert.Equal(t, []splitLeg{
+		{rng: time.Hour, offset: 0},
+	}, splitRange(time.Hour, time.Hour))
+
+	assert.Equal(t, []splitLeg{
+		{rng: time.Hour - time.Millisecond, offset: 0},
+		{rng: time.Hour - time.Millisecond, offset: time.Hour},
+		{rng: time.Minute, offset: 2 * time.Hour},
+	}, splitRange(2*time.Hour+time.Minute, time.Hour))
+}
@@ -14,11 +14,15 @@ import (
 )
 
 var summableAggregates = map[parser.ItemType]struct{}{
-	parser.SUM:   {},
-	parser.MIN:   {},
-	parser.MAX:   {},
-	parser.COUNT: {},
-	parser.AVG:   {},
+	parser.SUM:          {},
+	parser.MIN:          {},
+	parser.MAX:          {},
+	parser.COUNT:        {},
+	parser.AVG:          {},
+	parser.TOPK:         {},
+	parser.BOTTOMK:      {},
+	parser.GROUP:        {},
+	parser.COUNT_VALUES: {},
 }
 
 // NonParallelFuncs is the list of functions that shouldn't be parallelized.
@@ -53,10 +57,7 @@ func CanParallelize(node parser.Node, logger log.Logger) bool {
 			return false
 		}
 
-		// Ensure there are no nested aggregations
-		nestedAggrs, err := anyNode(n.Expr, isAggregateExpr)
-
-		return err == nil && !nestedAggrs && CanParallelize(n.Expr, logger)
+		return canParallelizeAggregation(n, logger)
 
 	case *parser.BinaryExpr:
 		// Binary expressions can be parallelised when one of the sides is a constant scalar value
@@ -112,6 +113,39 @@ func CanParallelize(node parser.Node, logger log.Logger) bool {
 	}
 }
 
+// canParallelizeAggregation returns true if the input and the parameter of the given aggregation
+// allow to run it on each shard, regardless of the aggregation operation.
+func canParallelizeAggregation(n *parser.AggregateExpr, logger log.Logger) bool {
+	// The parameter is evaluated on each shard, so it must be the same for all of them.
+	switch p := n.Param.(type) {
+	case nil, *parser.StringLiteral:
+	default:
+		if !isConstantScalar(p) {
+			return false
+		}
+	}
+
+	// Ensure there are no nested aggregations
+	nestedAggrs, err := anyNode(n.Expr, isAggregateExpr)
+
+	return err == nil && !nestedAggrs && CanParallelize(n.Expr, logger)
+}
+
+// nonParallelizableFuncs returns the names of the functions which can't be parallelized
+// found in the given node.
+func nonParallelizableFuncs(node parser.Node) []string {
+	var names []string
+
+	_, _ = anyNode(node, func(n parser.Node) (bool, error) {
+		if call, ok := n.(*parser.Call); ok && call.Func != nil && !ParallelizableFunc(*call.Func) {
+			names = append(names, call.Func.Name)
+		}
+		return false, nil
+	})
+
+	return names
+}
+
 // containsAggregateExpr returns true if the given node contains an aggregate expression within its children.
 func containsAggregateExpr(n parser.Node) bool {
 	containsAggregate, _ := anyNode(n, isAggregateExpr)
@@ -16,9 +16,10 @@ import (
 	"github.com/grafana/mimir/pkg/storage/sharding"
 )
 
-// NewSharding creates a new query sharding mapper.
-func NewSharding(shards int, logger log.Logger) (ASTMapper, error) {
-	shardSummer, err := newShardSummer(shards, vectorSquasher, logger)
+// NewSharding creates a new query sharding mapper. If approximateQuantile is true, quantile()
+// aggregations are sharded too, but their result is an approximation of the actual quantile.
+func NewSharding(shards int, approximateQuantile bool, logger log.Logger) (ASTMapper, error) {
+	shardSummer, err := newShardSummer(shards, approximateQuantile, vectorSquasher, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -32,23 +33,25 @@ func NewSharding(shards int, logger log.Logger) (ASTMapper, error) {
 type squasher = func(...parser.Node) (parser.Expr, error)
 
 type shardSummer struct {
-	shards       int
-	currentShard *int
-	squash       squasher
-	logger       log.Logger
+	shards              int
+	approximateQuantile bool
+	currentShard        *int
+	squash              squasher
+	logger              log.Logger
 }
 
 // newShardSummer instantiates an ASTMapper which will fan out sum queries by shard
-func newShardSummer(shards int, squasher squasher, logger log.Logger) (ASTMapper, error) {
+func newShardSummer(shards int, approximateQuantile bool, squasher squasher, logger log.Logger) (ASTMapper, error) {
 	if squasher == nil {
 		return nil, errors.Errorf("squasher required and not passed")
 	}
 
 	return NewASTNodeMapper(&shardSummer{
-		shards:       shards,
-		squash:       squasher,
-		currentShard: nil,
-		logger:       logger,
+		shards:              shards,
+		approximateQuantile: approximateQuantile,
+		squash:              squasher,
+		currentShard:        nil,
+		logger:              logger,
 	}), nil
 }
 
@@ -68,9 +71,14 @@ func (summer *shardSummer) MapNode(node parser.Node, stats *MapperStats) (mapped
 		if summer.currentShard != nil {
 			return n, false, nil
 		}
-		if CanParallelize(n, summer.logger) {
+		if CanParallelize(n, summer.logger) || summer.canApproximateQuantile(n) {
 			return summer.shardAggregate(n, stats)
 		}
+		// Keep track of the aggregation if it's the one preventing the sharding.
+		// Otherwise, the blocking nodes are tracked while mapping its children.
+		if _, ok := summableAggregates[n.Op]; !ok {
+			stats.AddBlockingFunction(n.Op.String())
+		}
 		return n, false, nil
 
 	case *parser.VectorSelector:
@@ -97,10 +105,16 @@ func (summer *shardSummer) MapNode(node parser.Node, stats *MapperStats) (mapped
 					return n, true, nil
 				}
 				if !CanParallelize(n, summer.logger) {
+					stats.AddBlockingFunction(nonParallelizableFuncs(n)...)
 					return n, true, nil
 				}
 				return summer.shardAndSquashFuncCall(n, stats)
 			}
+			// Keep track of the function if it's the one preventing the sharding,
+			// because there's nothing shardable in its arguments.
+			if n.Func != nil && !ParallelizableFunc(*n.Func) && !containsAggregateExpr(n) {
+				stats.AddBlockingFunction(n.Func.Name)
+			}
 			return n, false, nil
 		}
 		return n, false, nil
@@ -222,6 +236,24 @@ func (summer *shardSummer) shardAggregate(expr *parser.AggregateExpr, stats *Map
 			return nil, false, err
 		}
 		return mapped, true, nil
+	case parser.TOPK, parser.BOTTOMK, parser.GROUP:
+		mapped, err = summer.shardSelectorAggregate(expr, stats)
+		if err != nil {
+			return nil, false, err
+		}
+		return mapped, true, nil
+	case parser.COUNT_VALUES:
+		mapped, err = summer.shardCountValues(expr, stats)
+		if err != nil {
+			return nil, false, err
+		}
+		return mapped, true, nil
+	case parser.QUANTILE:
+		mapped, err = summer.shardQuantile(expr, stats)
+		if err != nil {
+			return nil, false, err
+		}
+		return mapped, true, nil
 	}
 
 	// If the aggregation operation is not shardable, we have to return the input
@@ -330,10 +362,157 @@ func (summer *shardSummer) shardAvg(expr *parser.AggregateExpr, stats *MapperSta
 	}, nil
 }
 
+// shardSelectorAggregate attempts to shard the given TOPK/BOTTOMK/GROUP aggregation expression.
+func (summer *shardSummer) shardSelectorAggregate(expr *parser.AggregateExpr, stats *MapperStats) (result parser.Node, err error) {
+	/*
+		Each series belongs to a single shard, so the TOPK/BOTTOMK/GROUP aggregation can be
+		parallelized running it on each shard and then again on the per-shard results:
+
+		topk by(foo) (2,
+		  topk by(foo) (2, bar1{__query_shard__="0_of_2"}) or
+		  topk by(foo) (2, bar1{__query_shard__="1_of_2"})
+		)
+	*/
+	sharded, err := summer.shardAndSquashAggregateExpr(expr, expr.Op, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.AggregateExpr{
+		Op:       expr.Op,
+		Expr:     sharded,
+		Param:    expr.Param,
+		Grouping: expr.Grouping,
+		Without:  expr.Without,
+	}, nil
+}
+
+// shardCountValues attempts to shard the given COUNT_VALUES aggregation expression.
+func (summer *shardSummer) shardCountValues(expr *parser.AggregateExpr, stats *MapperStats) (result parser.Node, err error) {
+	/*
+		The COUNT_VALUES aggregation can be parallelized as the SUM of per-shard COUNT_VALUES,
+		grouping by the label holding the counted values too:
+
+		sum by(foo, value) (
+		  count_values by(foo) ("value", bar1{__query_shard__="0_of_2"}) or
+		  count_values by(foo) ("value", bar1{__query_shard__="1_of_2"})
+		)
+	*/
+	valueLabel, ok := expr.Param.(*parser.StringLiteral)
+	if !ok {
+		return nil, errors.Errorf("expected string literal parameter for COUNT_VALUES aggregation while got %T", expr.Param)
+	}
+
+	sharded, err := summer.shardAndSquashAggregateExpr(expr, expr.Op, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	grouping := expr.Grouping
+	if !expr.Without {
+		grouping = append(append(make([]string, 0, len(grouping)+1), grouping...), valueLabel.Val)
+	}
+
+	return &parser.AggregateExpr{
+		Op:       parser.SUM,
+		Expr:     sharded,
+		Grouping: grouping,
+		Without:  expr.Without,
+	}, nil
+}
+
+// canApproximateQuantile returns true if the given expression is a QUANTILE aggregation which
+// can be sharded approximating its result.
+func (summer *shardSummer) canApproximateQuantile(expr *parser.AggregateExpr) bool {
+	return summer.approximateQuantile && expr.Op == parser.QUANTILE && canParallelizeAggregation(expr, summer.logger)
+}
+
+// shardQuantile attempts to shard the given QUANTILE aggregation expression. The result
+// is an approximation of the actual quantile.
+func (summer *shardSummer) shardQuantile(expr *parser.AggregateExpr, stats *MapperStats) (result parser.Node, err error) {
+	/*
+		The QUANTILE aggregation can't be exactly computed from per-shard results, but since series
+		are randomly distributed across shards, it can be approximated as the average of the per-shard
+		QUANTILE weighted by the per-shard number of series:
+
+		sum(
+		  (quantile(0.9, bar1{__query_shard__="0_of_2"}) * count(bar1{__query_shard__="0_of_2"})) or
+		  (quantile(0.9, bar1{__query_shard__="1_of_2"}) * count(bar1{__query_shard__="1_of_2"}))
+		) / sum(
+		  count(bar1{__query_shard__="0_of_2"}) or
+		  count(bar1{__query_shard__="1_of_2"})
+		)
+	*/
+	if !summer.approximateQuantile {
+		return nil, errors.New("tried to shard a quantile aggregation while approximation is disabled")
+	}
+
+	weighted, err := summer.shardAndSquashAggregateExprWith(expr, stats, func(sharded parser.Expr) parser.Expr {
+		return &parser.BinaryExpr{
+			Op: parser.MUL,
+			LHS: &parser.AggregateExpr{
+				Op:       parser.QUANTILE,
+				Expr:     sharded,
+				Param:    expr.Param,
+				Grouping: expr.Grouping,
+				Without:  expr.Without,
+			},
+			RHS: &parser.AggregateExpr{
+				Op:       parser.COUNT,
+				Expr:     sharded,
+				Grouping: expr.Grouping,
+				Without:  expr.Without,
+			},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	countExpr, err := summer.shardCount(expr, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.ParenExpr{
+		Expr: &parser.BinaryExpr{
+			Op: parser.DIV,
+			LHS: &parser.AggregateExpr{
+				Op:       parser.SUM,
+				Expr:     weighted,
+				Grouping: expr.Grouping,
+				Without:  expr.Without,
+			},
+			RHS: countExpr,
+		},
+	}, nil
+}
+
 // shardAndSquashAggregateExpr returns a squashed CONCAT expression including N embedded
 // queries, where N is the number of shards and each sub-query queries a different shard
 // with the given "op" aggregation operation.
 func (summer *shardSummer) shardAndSquashAggregateExpr(expr *parser.AggregateExpr, op parser.ItemType, stats *MapperStats) (parser.Expr, error) {
+	return summer.shardAndSquashAggregateExprWith(expr, stats, func(sharded parser.Expr) parser.Expr {
+		// Create the child expression, which runs the given aggregation operation
+		// on a single shard. We need to preserve the grouping as it was
+		// in the original one.
+		child := &parser.AggregateExpr{
+			Op:       op,
+			Expr:     sharded,
+			Grouping: expr.Grouping,
+			Without:  expr.Without,
+		}
+		if op.IsAggregatorWithParam() {
+			child.Param = expr.Param
+		}
+		return child
+	})
+}
+
+// shardAndSquashAggregateExprWith returns a squashed CONCAT expression including N embedded
+// queries, where N is the number of shards and each sub-query is built by the given function
+// on the input of the aggregation expression, querying a different shard.
+func (summer *shardSummer) shardAndSquashAggregateExprWith(expr *parser.AggregateExpr, stats *MapperStats, child func(sharded parser.Expr) parser.Expr) (parser.Expr, error) {
 	children := make([]parser.Node, 0, summer.shards)
 
 	// Create sub-query for each shard.
@@ -343,15 +522,7 @@ func (summer *shardSummer) shardAndSquashAggregateExpr(expr *parser.AggregateExp
 			return nil, err
 		}
 
-		// Create the child expression, which runs the given aggregation operation
-		// on a single shard. We need to preserve the grouping as it was
-		// in the original one.
-		children = append(children, &parser.AggregateExpr{
-			Op:       op,
-			Expr:     sharded.(parser.Expr),
-			Grouping: expr.Grouping,
-			Without:  expr.Without,
-		})
+		children = append(children, child(sharded.(parser.Expr)))
 	}
 
 	// Update stats.
@@ -446,11 +446,51 @@ func TestShardSummer(t *testing.T) {
 				`)`,
 			expectedShardedQueries: 6,
 		},
+		{
+			in:                     `topk(10, rate(foo[1m]))`,
+			out:                    `topk(10, ` + concatShards(3, `topk(10, rate(foo{__query_shard__="x_of_y"}[1m]))`) + `)`,
+			expectedShardedQueries: 3,
+		},
+		{
+			in:                     `bottomk by (foo) (10, rate(foo[1m]))`,
+			out:                    `bottomk by (foo) (10, ` + concatShards(3, `bottomk by (foo) (10, rate(foo{__query_shard__="x_of_y"}[1m]))`) + `)`,
+			expectedShardedQueries: 3,
+		},
+		{
+			in:                     `topk(scalar(bar), foo)`,
+			out:                    concat(`topk(scalar(bar), foo)`),
+			expectedShardedQueries: 0,
+		},
+		{
+			in:                     `topk(5, sum by (foo) (bar))`,
+			out:                    `topk(5, sum by (foo) (` + concatShards(3, `sum by (foo) (bar{__query_shard__="x_of_y"})`) + `))`,
+			expectedShardedQueries: 3,
+		},
+		{
+			in:                     `group by (foo) (bar)`,
+			out:                    `group by (foo) (` + concatShards(3, `group by (foo) (bar{__query_shard__="x_of_y"})`) + `)`,
+			expectedShardedQueries: 3,
+		},
+		{
+			in:                     `count_values by (foo) ("value", bar)`,
+			out:                    `sum by (foo, value) (` + concatShards(3, `count_values by (foo) ("value", bar{__query_shard__="x_of_y"})`) + `)`,
+			expectedShardedQueries: 3,
+		},
+		{
+			in:                     `count_values without (foo) ("value", bar)`,
+			out:                    `sum without (foo) (` + concatShards(3, `count_values without (foo) ("value", bar{__query_shard__="x_of_y"})`) + `)`,
+			expectedShardedQueries: 3,
+		},
+		{
+			in:                     `count_values("value", bar)`,
+			out:                    `sum by (value) (` + concatShards(3, `count_values("value", bar{__query_shard__="x_of_y"})`) + `)`,
+			expectedShardedQueries: 3,
+		},
 	} {
 		tt := tt
 
 		t.Run(tt.in, func(t *testing.T) {
-			mapper, err := NewSharding(3, log.NewNopLogger())
+			mapper, err := NewSharding(3, false, log.NewNopLogger())
 			require.NoError(t, err)
 			expr, err := parser.ParseExpr(tt.in)
 			require.NoError(t, err)
@@ -491,6 +531,80 @@ func concat(queries ...string) string {
 	return mapped.String()
 }
 
+func TestShardSummerWithApproximateQuantile(t *testing.T) {
+	for _, tt := range []struct {
+		in                     string
+		out                    string
+		expectedShardedQueries int
+	}{
+		{
+			in: `quantile(0.9, rate(foo[1m]))`,
+			out: `(sum(` + concatShards(3, `quantile(0.9, rate(foo{__query_shard__="x_of_y"}[1m])) * count(rate(foo{__query_shard__="x_of_y"}[1m]))`) + `) / ` +
+				`sum(` + concatShards(3, `count(rate(foo{__query_shard__="x_of_y"}[1m]))`) + `))`,
+			expectedShardedQueries: 6,
+		},
+		{
+			in: `quantile by (bar) (0.9, foo)`,
+			out: `(sum by (bar) (` + concatShards(3, `quantile by (bar) (0.9, foo{__query_shard__="x_of_y"}) * count by (bar) (foo{__query_shard__="x_of_y"})`) + `) / ` +
+				`sum by (bar) (` + concatShards(3, `count by (bar) (foo{__query_shard__="x_of_y"})`) + `))`,
+			expectedShardedQueries: 6,
+		},
+		{
+			in:                     `quantile(scalar(bar), foo)`,
+			out:                    concat(`quantile(scalar(bar), foo)`),
+			expectedShardedQueries: 0,
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.in, func(t *testing.T) {
+			mapper, err := NewSharding(3, true, log.NewNopLogger())
+			require.NoError(t, err)
+			expr, err := parser.ParseExpr(tt.in)
+			require.NoError(t, err)
+			out, err := parser.ParseExpr(tt.out)
+			require.NoError(t, err)
+
+			stats := NewMapperStats()
+			mapped, err := mapper.Map(expr, stats)
+			require.NoError(t, err)
+			require.Equal(t, out.String(), mapped.String())
+			assert.Equal(t, tt.expectedShardedQueries, stats.GetShardedQueries())
+		})
+	}
+}
+
+func TestShardSummer_BlockingFunctions(t *testing.T) {
+	for _, tt := range []struct {
+		in       string
+		expected []string
+	}{
+		{in: `sum(rate(foo[1m]))`, expected: []string{}},
+		{in: `quantile(0.9, foo)`, expected: []string{"quantile"}},
+		{in: `stddev(foo) / stdvar(foo)`, expected: []string{"stddev", "stdvar"}},
+		{in: `histogram_quantile(0.9, rate(foo[1m]))`, expected: []string{"histogram_quantile"}},
+		{in: `sum(histogram_quantile(0.9, rate(foo[1m])))`, expected: []string{"histogram_quantile"}},
+		{in: `absent_over_time(rate(foo[5m])[10m:])`, expected: []string{"absent_over_time"}},
+		// Functions wrapping a shardable aggregation don't block sharding.
+		{in: `histogram_quantile(0.9, sum by (le) (rate(foo[1m])))`, expected: []string{}},
+		{in: `stddev(sum by (bar) (foo))`, expected: []string{"stddev"}},
+	} {
+		tt := tt
+
+		t.Run(tt.in, func(t *testing.T) {
+			mapper, err := NewSharding(3, false, log.NewNopLogger())
+			require.NoError(t, err)
+			expr, err := parser.ParseExpr(tt.in)
+			require.NoError(t, err)
+
+			stats := NewMapperStats()
+			_, err = mapper.Map(expr, stats)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stats.GetBlockingFunctions())
+		})
+	}
+}
+
 func TestShardSummerWithEncoding(t *testing.T) {
 	for i, c := range []struct {
 		shards   int
@@ -504,7 +618,7 @@ func TestShardSummerWithEncoding(t *testing.T) {
 		},
 	} {
 		t.Run(fmt.Sprintf("[%d]", i), func(t *testing.T) {
-			summer, err := newShardSummer(c.shards, vectorSquasher, log.NewNopLogger())
+			summer, err := newShardSummer(c.shards, false, vectorSquasher, log.NewNopLogger())
 			require.Nil(t, err)
 			expr, err := parser.ParseExpr(c.input)
 			require.Nil(t, err)
@@ -2,8 +2,14 @@
 
 package astmapper
 
+import (
+	"sort"
+)
+
 type MapperStats struct {
-	shardedQueries int
+	shardedQueries    int
+	splitQueries      int
+	blockingFunctions map[string]struct{}
 }
 
 func NewMapperStats() *MapperStats {
@@ -19,3 +25,38 @@ func (s *MapperStats) AddShardedQueries(num int) {
 func (s *MapperStats) GetShardedQueries() int {
 	return s.shardedQueries
 }
+
+// AddSplitQueries add num split queries to the counter.
+func (s *MapperStats) AddSplitQueries(num int) {
+	s.splitQueries += num
+}
+
+// GetSplitQueries returns the number of split queries.
+func (s *MapperStats) GetSplitQueries() int {
+	return s.splitQueries
+}
+
+// AddBlockingFunction keeps track of the functions or aggregations which prevented
+// a part of the query from being sharded.
+func (s *MapperStats) AddBlockingFunction(names ...string) {
+	if len(names) == 0 {
+		return
+	}
+	if s.blockingFunctions == nil {
+		s.blockingFunctions = map[string]struct{}{}
+	}
+	for _, name := range names {
+		s.blockingFunctions[name] = struct{}{}
+	}
+}
+
+// GetBlockingFunctions returns the sorted names of the functions or aggregations
+// which prevented a part of the query from being sharded.
+func (s *MapperStats) GetBlockingFunctions() []string {
+	names := make([]string, 0, len(s.blockingFunctions))
+	for name := range s.blockingFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
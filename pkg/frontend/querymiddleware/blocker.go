@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/dskit/tenant"
+
+	apierror "github.com/grafana/mimir/pkg/api/error"
+	"github.com/grafana/mimir/pkg/util/spanlogger"
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+type queryBlockerMiddleware struct {
+	next           Handler
+	limits         Limits
+	logger         log.Logger
+	blockedQueries *prometheus.CounterVec
+}
+
+// newQueryBlockerMiddleware creates a middleware that rejects the queries matching any of
+// the tenant's blocked query patterns. Blocked queries are configured through the limits,
+// so they can be changed at runtime through the runtime config.
+func newQueryBlockerMiddleware(limits Limits, logger log.Logger, registerer prometheus.Registerer) Middleware {
+	blockedQueries := promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cortex",
+		Name:      "frontend_blocked_queries_total",
+		Help:      "Total number of queries rejected by the query-frontend because they match a blocked query pattern.",
+	}, []string{"pattern"})
+
+	return MiddlewareFunc(func(next Handler) Handler {
+		return &queryBlockerMiddleware{
+			next:           next,
+			limits:         limits,
+			logger:         logger,
+			blockedQueries: blockedQueries,
+		}
+	})
+}
+
+func (b *queryBlockerMiddleware) Do(ctx context.Context, req Request) (Response, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, apierror.New(apierror.TypeBadData, err.Error())
+	}
+
+	// Normalizing the query requires parsing it, so we skip it if there are no blocked queries.
+	numBlocked := 0
+	for _, tenantID := range tenantIDs {
+		numBlocked += len(b.limits.BlockedQueries(tenantID))
+	}
+	if numBlocked == 0 {
+		return b.next.Do(ctx, req)
+	}
+
+	log, ctx := spanlogger.NewWithLogger(ctx, b.logger, "queryBlockerMiddleware.Do")
+	defer log.Span.Finish()
+
+	query := normalizeQuery(req.GetQuery())
+
+	for _, tenantID := range tenantIDs {
+		for _, blocked := range b.limits.BlockedQueries(tenantID) {
+			if blocked.Pattern == "" {
+				continue
+			}
+
+			matched, err := matchBlockedQuery(blocked, query)
+			if err != nil {
+				level.Warn(log).Log("msg", "failed to compile the blocked query pattern", "user", tenantID, "pattern", blocked.Pattern, "err", err)
+				continue
+			}
+			if !matched {
+				continue
+			}
+
+			level.Info(log).Log("msg", "query blocked", "user", tenantID, "query", req.GetQuery(), "pattern", blocked.Pattern)
+			b.blockedQueries.WithLabelValues(blocked.Pattern).Inc()
+			return nil, apierror.Newf(apierror.TypeBadData, validation.ErrQueryBlocked, blocked.Pattern)
+		}
+	}
+
+	return b.next.Do(ctx, req)
+}
+
+// matchBlockedQuery returns whether the input normalized query matches the blocked query pattern.
+func matchBlockedQuery(blocked validation.BlockedQuery, query string) (bool, error) {
+	if !blocked.Regex {
+		return normalizeQuery(blocked.Pattern) == query, nil
+	}
+
+	re, err := blocked.Regexp()
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(query), nil
+}
+
+// normalizeQuery returns the input PromQL query in its canonical form, so that
+// formatting differences (e.g. whitespaces) don't affect the matching. If the
+// query can't be parsed, it's returned as is without leading and trailing spaces.
+func normalizeQuery(query string) string {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return strings.TrimSpace(query)
+	}
+	return expr.String()
+}
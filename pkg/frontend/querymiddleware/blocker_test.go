@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	apierror "github.com/grafana/mimir/pkg/api/error"
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+func TestQueryBlockerMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		query           string
+		blockedQueries  []validation.BlockedQuery
+		expectedBlocked string
+	}{
+		"should not block any query if there are no blocked queries": {
+			query: "sum(rate(metric[5m]))",
+		},
+		"should not block a query not matching any pattern": {
+			query: "sum(rate(metric[5m]))",
+			blockedQueries: []validation.BlockedQuery{
+				{Pattern: "sum(rate(other[5m]))"},
+				{Pattern: "count\\(.*\\)", Regex: true},
+			},
+		},
+		"should block a query matching an exact pattern": {
+			query: "sum(rate(metric[5m]))",
+			blockedQueries: []validation.BlockedQuery{
+				{Pattern: "sum(rate(metric[5m]))"},
+			},
+			expectedBlocked: "sum(rate(metric[5m]))",
+		},
+		"should block a query matching an exact pattern once both have been normalized": {
+			query: "sum  (rate(metric[5m] ) )",
+			blockedQueries: []validation.BlockedQuery{
+				{Pattern: "sum(rate(metric[300s]))"},
+			},
+			expectedBlocked: "sum(rate(metric[300s]))",
+		},
+		"should block a query matching a regex pattern": {
+			query: `sum by (pod) (rate(metric{namespace="dev"}[5m]))`,
+			blockedQueries: []validation.BlockedQuery{
+				{Pattern: `.*namespace="dev".*`, Regex: true},
+			},
+			expectedBlocked: `.*namespace="dev".*`,
+		},
+		"should match a regex pattern against the whole normalized query": {
+			query: `sum(rate(metric{namespace="dev"}[5m]))`,
+			blockedQueries: []validation.BlockedQuery{
+				{Pattern: `namespace="dev"`, Regex: true},
+			},
+		},
+		"should skip invalid regex patterns": {
+			query: "sum(rate(metric[5m]))",
+			blockedQueries: []validation.BlockedQuery{
+				{Pattern: "sum(", Regex: true},
+				{Pattern: "sum.*", Regex: true},
+			},
+			expectedBlocked: "sum.*",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			reg := prometheus.NewPedanticRegistry()
+			limits := mockLimits{blockedQueries: testData.blockedQueries}
+			expected := &PrometheusResponse{Status: statusSuccess}
+
+			next := HandlerFunc(func(ctx context.Context, req Request) (Response, error) {
+				return expected, nil
+			})
+
+			handler := newQueryBlockerMiddleware(limits, log.NewNopLogger(), reg).Wrap(next)
+			req := &PrometheusInstantQueryRequest{Path: "/query", Time: 0, Query: testData.query}
+			res, err := handler.Do(user.InjectOrgID(context.Background(), "user-1"), req)
+
+			if testData.expectedBlocked == "" {
+				require.NoError(t, err)
+				assert.Equal(t, expected, res)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, apierror.IsAPIError(err))
+			assert.Contains(t, err.Error(), fmt.Sprintf(validation.ErrQueryBlocked, testData.expectedBlocked))
+			assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(fmt.Sprintf(`
+				# HELP cortex_frontend_blocked_queries_total Total number of queries rejected by the query-frontend because they match a blocked query pattern.
+				# TYPE cortex_frontend_blocked_queries_total counter
+				cortex_frontend_blocked_queries_total{pattern=%q} 1
+			`, testData.expectedBlocked))))
+		})
+	}
+}
@@ -38,7 +38,9 @@ var (
 	errStepTooSmall   = apierror.New(apierror.TypeBadData, "exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)")
 
 	// PrometheusCodec is a codec to encode and decode Prometheus query range requests and responses.
-	PrometheusCodec Codec = prometheusCodec{}
+	PrometheusCodec = NewPrometheusCodec(formatJSON)
+
+	allFormats = []string{formatJSON, formatProtobuf}
 )
 
 const (
@@ -48,6 +50,15 @@ const (
 	statusError = "error"
 
 	totalShardsControlHeader = "Sharding-Control"
+
+	// partialResponseParam is the request parameter used to request partial results,
+	// which is forwarded to the queriers.
+	partialResponseParam = "partial_response"
+
+	formatJSON     = "json"
+	formatProtobuf = "protobuf"
+
+	jsonMimeType = "application/json"
 )
 
 // Codec is used to encode/decode query range requests and responses so they can be passed down to middlewares.
@@ -106,9 +117,24 @@ type Response interface {
 	proto.Message
 	// GetHeaders returns the HTTP headers in the response.
 	GetHeaders() []*PrometheusResponseHeader
+	// GetWarnings returns the warnings in the response.
+	GetWarnings() []string
 }
 
-type prometheusCodec struct{}
+type prometheusCodec struct {
+	// preferredQueryResultResponseFormat is the format the query results are requested
+	// to the downstream queriers.
+	preferredQueryResultResponseFormat string
+}
+
+// NewPrometheusCodec returns a Codec which requests query results to the downstream
+// queriers in the given format ("json" or "protobuf"). Responses to clients are always
+// encoded as JSON.
+func NewPrometheusCodec(queryResultResponseFormat string) Codec {
+	return prometheusCodec{
+		preferredQueryResultResponseFormat: queryResultResponseFormat,
+	}
+}
 
 func (prometheusCodec) MergeResponse(responses ...Response) (Response, error) {
 	if len(responses) == 0 {
@@ -139,9 +165,23 @@ func (prometheusCodec) MergeResponse(responses ...Response) (Response, error) {
 			ResultType: model.ValMatrix.String(),
 			Result:     matrixMerge(promResponses),
 		},
+		Warnings: mergeWarnings(promResponses),
 	}, nil
 }
 
+// mergeWarnings returns the unique warnings of the input responses.
+func mergeWarnings(resps []*PrometheusResponse) []string {
+	var warnings []string
+	for _, resp := range resps {
+		for _, w := range resp.Warnings {
+			if !util.StringsContain(warnings, w) {
+				warnings = append(warnings, w)
+			}
+		}
+	}
+	return warnings
+}
+
 func (c prometheusCodec) DecodeRequest(_ context.Context, r *http.Request) (Request, error) {
 	switch {
 	case isRangeQuery(r.URL.Path):
@@ -224,28 +264,41 @@ func decodeOptions(r *http.Request, opts *Options) {
 			opts.ShardingDisabled = true
 		}
 	}
+
+	// Invalid values are ignored, like the queriers do.
+	if partial, err := strconv.ParseBool(r.FormValue(partialResponseParam)); err == nil {
+		opts.PartialResponse = partial
+	}
 }
 
-func (prometheusCodec) EncodeRequest(ctx context.Context, r Request) (*http.Request, error) {
+// encodeOptions adds the options which need to be forwarded to the queriers to the input query parameters.
+func encodeOptions(params url.Values, opts Options) url.Values {
+	if opts.PartialResponse {
+		params.Set(partialResponseParam, "true")
+	}
+	return params
+}
+
+func (c prometheusCodec) EncodeRequest(ctx context.Context, r Request) (*http.Request, error) {
 	var u *url.URL
 	switch r := r.(type) {
 	case *PrometheusRangeQueryRequest:
 		u = &url.URL{
 			Path: r.Path,
-			RawQuery: url.Values{
+			RawQuery: encodeOptions(url.Values{
 				"start": []string{encodeTime(r.Start)},
 				"end":   []string{encodeTime(r.End)},
 				"step":  []string{encodeDurationMs(r.Step)},
 				"query": []string{r.Query},
-			}.Encode(),
+			}, r.Options).Encode(),
 		}
 	case *PrometheusInstantQueryRequest:
 		u = &url.URL{
 			Path: r.Path,
-			RawQuery: url.Values{
+			RawQuery: encodeOptions(url.Values{
 				"time":  []string{encodeTime(r.Time)},
 				"query": []string{r.Query},
-			}.Encode(),
+			}, r.Options).Encode(),
 		}
 	default:
 		return nil, fmt.Errorf("unsupported request type %T", r)
@@ -259,6 +312,13 @@ func (prometheusCodec) EncodeRequest(ctx context.Context, r Request) (*http.Requ
 		Header:     http.Header{},
 	}
 
+	switch c.preferredQueryResultResponseFormat {
+	case formatProtobuf:
+		req.Header.Set("Accept", mimirpb.QueryResponseMimeType+","+jsonMimeType)
+	default:
+		req.Header.Set("Accept", jsonMimeType)
+	}
+
 	return req.WithContext(ctx), nil
 }
 
@@ -282,8 +342,20 @@ func (prometheusCodec) DecodeResponse(ctx context.Context, r *http.Response, _ R
 	}
 	log.LogFields(otlog.Int("bytes", len(buf)))
 
-	if err := json.Unmarshal(buf, &resp); err != nil {
-		return nil, apierror.Newf(apierror.TypeInternal, "error decoding response: %v", err)
+	contentType := r.Header.Get("Content-Type")
+	log.LogFields(otlog.String("content_type", contentType))
+
+	switch contentType {
+	case mimirpb.QueryResponseMimeType:
+		var protoResp mimirpb.QueryResponse
+		if err := protoResp.Unmarshal(buf); err != nil {
+			return nil, apierror.Newf(apierror.TypeInternal, "error decoding protobuf response: %v", err)
+		}
+		resp = prometheusResponseFromProtobuf(protoResp)
+	default:
+		if err := json.Unmarshal(buf, &resp); err != nil {
+			return nil, apierror.Newf(apierror.TypeInternal, "error decoding response: %v", err)
+		}
 	}
 
 	if resp.Status == statusError {
@@ -295,6 +367,25 @@ func (prometheusCodec) DecodeResponse(ctx context.Context, r *http.Response, _ R
 	}
 	return &resp, nil
 }
+
+// prometheusResponseFromProtobuf converts the input query result, encoded as protobuf by a querier,
+// to a successful response.
+func prometheusResponseFromProtobuf(resp mimirpb.QueryResponse) PrometheusResponse {
+	result := make([]SampleStream, 0, len(resp.Result))
+	for _, series := range resp.Result {
+		result = append(result, SampleStream{Labels: series.Labels, Samples: series.Samples})
+	}
+
+	return PrometheusResponse{
+		Status: statusSuccess,
+		Data: &PrometheusData{
+			ResultType: resp.ResultType,
+			Result:     result,
+		},
+		Warnings: resp.Warnings,
+	}
+}
+
 func (prometheusCodec) EncodeResponse(ctx context.Context, res Response) (*http.Response, error) {
 	sp, _ := opentracing.StartSpanFromContext(ctx, "APIResponse.ToHTTPResponse")
 	defer sp.Finish()
@@ -316,7 +407,7 @@ func (prometheusCodec) EncodeResponse(ctx context.Context, res Response) (*http.
 
 	resp := http.Response{
 		Header: http.Header{
-			"Content-Type": []string{"application/json"},
+			"Content-Type": []string{jsonMimeType},
 		},
 		Body:          ioutil.NopCloser(bytes.NewBuffer(b)),
 		StatusCode:    http.StatusOK,
@@ -12,6 +12,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
 	"testing"
 
@@ -55,6 +56,17 @@ func TestRequest(t *testing.T) {
 				Query: "sum(container_memory_rss) by (namespace)",
 			},
 		},
+		{
+			url: "/api/v1/query_range?end=1536716880&partial_response=true&query=up&start=1536673680&step=120",
+			expected: &PrometheusRangeQueryRequest{
+				Path:    "/api/v1/query_range",
+				Start:   1536673680 * 1e3,
+				End:     1536716880 * 1e3,
+				Step:    120 * 1e3,
+				Query:   "up",
+				Options: Options{PartialResponse: true},
+			},
+		},
 		{
 			url:         "api/v1/query_range?start=foo",
 			expectedErr: apierror.New(apierror.TypeBadData, "invalid parameter \"start\": cannot parse \"foo\" to a valid timestamp"),
@@ -101,6 +113,63 @@ func TestRequest(t *testing.T) {
 	}
 }
 
+func TestEncodeRequest_AcceptHeader(t *testing.T) {
+	req := &PrometheusInstantQueryRequest{
+		Path:  "/api/v1/query",
+		Time:  1536716880 * 1e3,
+		Query: "sum(container_memory_rss) by (namespace)",
+	}
+
+	for format, expectedAccept := range map[string]string{
+		formatJSON:     jsonMimeType,
+		formatProtobuf: mimirpb.QueryResponseMimeType + "," + jsonMimeType,
+	} {
+		t.Run(format, func(t *testing.T) {
+			encoded, err := NewPrometheusCodec(format).EncodeRequest(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, expectedAccept, encoded.Header.Get("Accept"))
+		})
+	}
+}
+
+func TestDecodeResponse_Protobuf(t *testing.T) {
+	expected := &PrometheusResponse{
+		Status: statusSuccess,
+		Data: &PrometheusData{
+			ResultType: model.ValMatrix.String(),
+			Result: []SampleStream{
+				{Labels: []mimirpb.LabelAdapter{{Name: "foo", Value: "bar"}}, Samples: []mimirpb.Sample{{TimestampMs: 1_000, Value: 100}, {TimestampMs: 2_000, Value: 200}}},
+			},
+		},
+	}
+
+	body, err := (&mimirpb.QueryResponse{
+		ResultType: model.ValMatrix.String(),
+		Result: []mimirpb.TimeSeries{
+			{Labels: []mimirpb.LabelAdapter{{Name: "foo", Value: "bar"}}, Samples: []mimirpb.Sample{{TimestampMs: 1_000, Value: 100}, {TimestampMs: 2_000, Value: 200}}},
+		},
+	}).Marshal()
+	require.NoError(t, err)
+
+	httpResponse := &http.Response{
+		StatusCode:    200,
+		Header:        http.Header{"Content-Type": []string{mimirpb.QueryResponseMimeType}},
+		Body:          ioutil.NopCloser(bytes.NewBuffer(body)),
+		ContentLength: int64(len(body)),
+	}
+
+	decoded, err := PrometheusCodec.DecodeResponse(context.Background(), httpResponse, nil, log.NewNopLogger())
+	require.NoError(t, err)
+
+	expected.Headers = []*PrometheusResponseHeader{{Name: "Content-Type", Values: []string{mimirpb.QueryResponseMimeType}}}
+	assert.Equal(t, expected, decoded)
+
+	// Responses to clients are always encoded as JSON.
+	encoded, err := NewPrometheusCodec(formatProtobuf).EncodeResponse(context.Background(), decoded)
+	require.NoError(t, err)
+	assert.Equal(t, jsonMimeType, encoded.Header.Get("Content-Type"))
+}
+
 type prometheusAPIResponse struct {
 	Status    string       `json:"status"`
 	Data      interface{}  `json:"data,omitempty"`
@@ -328,6 +397,36 @@ func TestMergeAPIResponses(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Warnings of the merged responses are deduplicated.",
+			input: []Response{
+				&PrometheusResponse{
+					Status: statusSuccess,
+					Data: &PrometheusData{
+						ResultType: matrix,
+						Result:     []SampleStream{},
+					},
+					Warnings: []string{"warning 1"},
+				},
+				&PrometheusResponse{
+					Status: statusSuccess,
+					Data: &PrometheusData{
+						ResultType: matrix,
+						Result:     []SampleStream{},
+					},
+					Warnings: []string{"warning 1", "warning 2"},
+				},
+			},
+			expected: &PrometheusResponse{
+				Status: statusSuccess,
+				Data: &PrometheusData{
+					ResultType: matrix,
+					Result:     []SampleStream{},
+				},
+				Warnings: []string{"warning 1", "warning 2"},
+			},
+		},
+
 		{
 			name: "Multiple empty responses shouldn't panic.",
 			input: []Response{
@@ -701,6 +800,16 @@ func Test_DecodeOptions(t *testing.T) {
 				ShardingDisabled: true,
 			},
 		},
+		{
+			name: "partial response",
+			input: &http.Request{
+				URL:    &url.URL{RawQuery: "partial_response=true"},
+				Header: http.Header{},
+			},
+			expected: &Options{
+				PartialResponse: true,
+			},
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -49,6 +49,17 @@ type Limits interface {
 	// be run for a given received query. 0 to disable limit.
 	QueryShardingMaxShardedQueries(userID string) int
 
+	// QueryShardingApproximateQuantileEnabled returns whether quantile() aggregations can be
+	// sharded for a given tenant, approximating their result.
+	QueryShardingApproximateQuantileEnabled(userID string) bool
+
+	// SplitInstantQueriesByInterval returns the interval used to split the range vector
+	// selectors of instant queries. 0 to disable splitting.
+	SplitInstantQueriesByInterval(userID string) time.Duration
+
+	// BlockedQueries returns the queries blocked for a given tenant.
+	BlockedQueries(userID string) []validation.BlockedQuery
+
 	// CompactorSplitAndMergeShards returns the number of shards to use when splitting blocks
 	// This method is copied from compactor.ConfigProvider.
 	CompactorSplitAndMergeShards(userID string) int
@@ -175,6 +186,12 @@ func (rt limitedParallelismRoundTripper) RoundTrip(r *http.Request) (*http.Respo
 		return nil, err
 	}
 
+	// Mark the query statistics as requested, so that middlewares sharing the response with other
+	// requests know the statistics must be specific to this request.
+	if isQueryStatsRequested(r) {
+		ctx = contextWithQueryStatsRequested(ctx)
+	}
+
 	if span := opentracing.SpanFromContext(ctx); span != nil {
 		request.LogToSpan(span)
 	}
@@ -227,6 +244,11 @@ func (rt limitedParallelismRoundTripper) RoundTrip(r *http.Request) (*http.Respo
 		return nil, err
 	}
 
+	// All the downstream requests have completed, so the query statistics are final.
+	if isQueryStatsRequested(r) {
+		response = withQueryStatistics(ctx, response)
+	}
+
 	return rt.codec.EncodeResponse(ctx, response)
 }
 
@@ -20,6 +20,7 @@ import (
 	"go.uber.org/atomic"
 
 	"github.com/grafana/mimir/pkg/util"
+	"github.com/grafana/mimir/pkg/util/validation"
 )
 
 func TestLimitsMiddleware_MaxQueryLookback(t *testing.T) {
@@ -203,6 +204,9 @@ type mockLimits struct {
 	maxShardedQueries   int
 	totalShards         int
 	compactorShards     int
+	splitInstantQueries time.Duration
+	approximateQuantile bool
+	blockedQueries      []validation.BlockedQuery
 }
 
 func (m mockLimits) MaxQueryLookback(string) time.Duration {
@@ -236,6 +240,18 @@ func (m mockLimits) CompactorSplitAndMergeShards(userID string) int {
 	return m.compactorShards
 }
 
+func (m mockLimits) QueryShardingApproximateQuantileEnabled(string) bool {
+	return m.approximateQuantile
+}
+
+func (m mockLimits) SplitInstantQueriesByInterval(string) time.Duration {
+	return m.splitInstantQueries
+}
+
+func (m mockLimits) BlockedQueries(string) []validation.BlockedQuery {
+	return m.blockedQueries
+}
+
 type mockHandler struct {
 	mock.Mock
 }
@@ -6,6 +6,7 @@
 package querymiddleware
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
@@ -287,6 +288,7 @@ type PrometheusResponse struct {
 	ErrorType string                      `protobuf:"bytes,3,opt,name=ErrorType,proto3" json:"errorType,omitempty"`
 	Error     string                      `protobuf:"bytes,4,opt,name=Error,proto3" json:"error,omitempty"`
 	Headers   []*PrometheusResponseHeader `protobuf:"bytes,5,rep,name=Headers,proto3" json:"-"`
+	Warnings  []string                    `protobuf:"bytes,6,rep,name=Warnings,proto3" json:"warnings,omitempty"`
 }
 
 func (m *PrometheusResponse) Reset()      { *m = PrometheusResponse{} }
@@ -356,9 +358,18 @@ func (m *PrometheusResponse) GetHeaders() []*PrometheusResponseHeader {
 	return nil
 }
 
+func (m *PrometheusResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
 type PrometheusData struct {
 	ResultType string         `protobuf:"bytes,1,opt,name=ResultType,proto3" json:"resultType"`
 	Result     []SampleStream `protobuf:"bytes,2,rep,name=Result,proto3" json:"result"`
+	// Statistics about the query execution, returned only when explicitly requested by the client.
+	Stats *QueryStatistics `protobuf:"bytes,3,opt,name=Stats,proto3" json:"stats,omitempty"`
 }
 
 func (m *PrometheusData) Reset()      { *m = PrometheusData{} }
@@ -407,6 +418,114 @@ func (m *PrometheusData) GetResult() []SampleStream {
 	return nil
 }
 
+func (m *PrometheusData) GetStats() *QueryStatistics {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type QueryStatistics struct {
+	// The sum of the wall time spent by the queriers to run all the split and sharded queries,
+	// which can be greater than the actual query execution time because they run in parallel.
+	CumulativeWallTimeSeconds float64 `protobuf:"fixed64,1,opt,name=CumulativeWallTimeSeconds,proto3" json:"cumulativeWallTimeSeconds"`
+	QueueTimeSeconds          float64 `protobuf:"fixed64,2,opt,name=QueueTimeSeconds,proto3" json:"queueTimeSeconds"`
+	FetchedSeriesCount        uint64  `protobuf:"varint,3,opt,name=FetchedSeriesCount,proto3" json:"fetchedSeriesCount"`
+	FetchedChunksCount        uint64  `protobuf:"varint,4,opt,name=FetchedChunksCount,proto3" json:"fetchedChunksCount"`
+	FetchedChunkBytes         uint64  `protobuf:"varint,5,opt,name=FetchedChunkBytes,proto3" json:"fetchedChunkBytes"`
+	ShardedQueries            uint32  `protobuf:"varint,6,opt,name=ShardedQueries,proto3" json:"shardedQueries"`
+	SplitQueries              uint32  `protobuf:"varint,7,opt,name=SplitQueries,proto3" json:"splitQueries"`
+	ResultsCacheHits          uint32  `protobuf:"varint,8,opt,name=ResultsCacheHits,proto3" json:"resultsCacheHits"`
+}
+
+func (m *QueryStatistics) Reset()      { *m = QueryStatistics{} }
+func (*QueryStatistics) ProtoMessage() {}
+func (*QueryStatistics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4c16552f9fdb66d8, []int{5}
+}
+func (m *QueryStatistics) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryStatistics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryStatistics.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryStatistics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStatistics.Merge(m, src)
+}
+func (m *QueryStatistics) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryStatistics) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStatistics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryStatistics proto.InternalMessageInfo
+
+func (m *QueryStatistics) GetCumulativeWallTimeSeconds() float64 {
+	if m != nil {
+		return m.CumulativeWallTimeSeconds
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetQueueTimeSeconds() float64 {
+	if m != nil {
+		return m.QueueTimeSeconds
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetFetchedSeriesCount() uint64 {
+	if m != nil {
+		return m.FetchedSeriesCount
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetFetchedChunksCount() uint64 {
+	if m != nil {
+		return m.FetchedChunksCount
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetFetchedChunkBytes() uint64 {
+	if m != nil {
+		return m.FetchedChunkBytes
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetShardedQueries() uint32 {
+	if m != nil {
+		return m.ShardedQueries
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetSplitQueries() uint32 {
+	if m != nil {
+		return m.SplitQueries
+	}
+	return 0
+}
+
+func (m *QueryStatistics) GetResultsCacheHits() uint32 {
+	if m != nil {
+		return m.ResultsCacheHits
+	}
+	return 0
+}
+
 type SampleStream struct {
 	Labels  []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"metric"`
 	Samples []mimirpb.Sample                                    `protobuf:"bytes,2,rep,name=samples,proto3" json:"values"`
@@ -415,7 +534,7 @@ type SampleStream struct {
 func (m *SampleStream) Reset()      { *m = SampleStream{} }
 func (*SampleStream) ProtoMessage() {}
 func (*SampleStream) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4c16552f9fdb66d8, []int{5}
+	return fileDescriptor_4c16552f9fdb66d8, []int{6}
 }
 func (m *SampleStream) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -460,7 +579,7 @@ type CachedResponse struct {
 func (m *CachedResponse) Reset()      { *m = CachedResponse{} }
 func (*CachedResponse) ProtoMessage() {}
 func (*CachedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4c16552f9fdb66d8, []int{6}
+	return fileDescriptor_4c16552f9fdb66d8, []int{7}
 }
 func (m *CachedResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -513,7 +632,7 @@ type Extent struct {
 func (m *Extent) Reset()      { *m = Extent{} }
 func (*Extent) ProtoMessage() {}
 func (*Extent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4c16552f9fdb66d8, []int{7}
+	return fileDescriptor_4c16552f9fdb66d8, []int{8}
 }
 func (m *Extent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -574,12 +693,14 @@ type Options struct {
 	CacheDisabled    bool  `protobuf:"varint,1,opt,name=CacheDisabled,proto3" json:"CacheDisabled,omitempty"`
 	ShardingDisabled bool  `protobuf:"varint,2,opt,name=ShardingDisabled,proto3" json:"ShardingDisabled,omitempty"`
 	TotalShards      int32 `protobuf:"varint,3,opt,name=TotalShards,proto3" json:"TotalShards,omitempty"`
+	// Whether partial results have been requested for the query.
+	PartialResponse bool `protobuf:"varint,4,opt,name=PartialResponse,proto3" json:"PartialResponse,omitempty"`
 }
 
 func (m *Options) Reset()      { *m = Options{} }
 func (*Options) ProtoMessage() {}
 func (*Options) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4c16552f9fdb66d8, []int{8}
+	return fileDescriptor_4c16552f9fdb66d8, []int{9}
 }
 func (m *Options) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -629,6 +750,13 @@ func (m *Options) GetTotalShards() int32 {
 	return 0
 }
 
+func (m *Options) GetPartialResponse() bool {
+	if m != nil {
+		return m.PartialResponse
+	}
+	return false
+}
+
 type Hints struct {
 	// Total number of queries that are expected to to be executed to serve the original request.
 	TotalQueries int32 `protobuf:"varint,1,opt,name=TotalQueries,proto3" json:"TotalQueries,omitempty"`
@@ -637,7 +765,7 @@ type Hints struct {
 func (m *Hints) Reset()      { *m = Hints{} }
 func (*Hints) ProtoMessage() {}
 func (*Hints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4c16552f9fdb66d8, []int{9}
+	return fileDescriptor_4c16552f9fdb66d8, []int{10}
 }
 func (m *Hints) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -679,6 +807,7 @@ func init() {
 	proto.RegisterType((*PrometheusResponseHeader)(nil), "queryrange.PrometheusResponseHeader")
 	proto.RegisterType((*PrometheusResponse)(nil), "queryrange.PrometheusResponse")
 	proto.RegisterType((*PrometheusData)(nil), "queryrange.PrometheusData")
+	proto.RegisterType((*QueryStatistics)(nil), "queryrange.QueryStatistics")
 	proto.RegisterType((*SampleStream)(nil), "queryrange.SampleStream")
 	proto.RegisterType((*CachedResponse)(nil), "queryrange.CachedResponse")
 	proto.RegisterType((*Extent)(nil), "queryrange.Extent")
@@ -689,68 +818,84 @@ func init() {
 func init() { proto.RegisterFile("model.proto", fileDescriptor_4c16552f9fdb66d8) }
 
 var fileDescriptor_4c16552f9fdb66d8 = []byte{
-	// 966 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0x23, 0x45,
-	0x17, 0x75, 0xbb, 0xdd, 0xb6, 0x73, 0x9d, 0xcf, 0xc9, 0x57, 0x19, 0x89, 0x4e, 0xd0, 0x74, 0x5b,
-	0xad, 0x59, 0x84, 0x9f, 0x38, 0x90, 0x11, 0x1b, 0x24, 0x10, 0xd3, 0x93, 0x48, 0x33, 0x08, 0xc1,
-	0x50, 0x89, 0x58, 0xb0, 0x41, 0xe5, 0x74, 0x8d, 0xdd, 0x4c, 0xff, 0x4d, 0x75, 0x35, 0x8c, 0x17,
-	0x48, 0x88, 0x27, 0x60, 0xc9, 0x0b, 0x20, 0xb1, 0x60, 0xcd, 0x8a, 0x07, 0x98, 0x65, 0xd8, 0x8d,
-	0x58, 0x34, 0xc4, 0xd9, 0x20, 0xaf, 0xe6, 0x11, 0x50, 0xdd, 0xea, 0x8e, 0x3b, 0x04, 0xc4, 0xb0,
-	0xb1, 0xab, 0xce, 0x3d, 0xf7, 0xd6, 0xb9, 0xa7, 0xab, 0x2e, 0x0c, 0xe2, 0x34, 0xe0, 0xd1, 0x38,
-	0x13, 0xa9, 0x4c, 0x09, 0x3c, 0x2e, 0xb8, 0x98, 0x0b, 0x96, 0x4c, 0xf9, 0xce, 0xde, 0x34, 0x94,
-	0xb3, 0x62, 0x32, 0x3e, 0x4d, 0xe3, 0xfd, 0x69, 0x3a, 0x4d, 0xf7, 0x91, 0x32, 0x29, 0x1e, 0xe2,
-	0x0e, 0x37, 0xb8, 0xd2, 0xa9, 0x3b, 0xce, 0x34, 0x4d, 0xa7, 0x11, 0x5f, 0xb1, 0x82, 0x42, 0x30,
-	0x19, 0xa6, 0x49, 0x15, 0x7f, 0xa3, 0x59, 0x4e, 0xb0, 0x87, 0x2c, 0x61, 0xfb, 0x71, 0x18, 0x87,
-	0x62, 0x3f, 0x7b, 0x34, 0xd5, 0xab, 0x6c, 0xa2, 0xff, 0xab, 0x8c, 0xed, 0xbf, 0x56, 0x64, 0xc9,
-	0x5c, 0x87, 0xbc, 0x9f, 0xda, 0xf0, 0xf2, 0x03, 0x91, 0xc6, 0x5c, 0xce, 0x78, 0x91, 0x53, 0xa5,
-	0xf7, 0x63, 0xa5, 0x9c, 0xf2, 0xc7, 0x05, 0xcf, 0x25, 0x21, 0xd0, 0xc9, 0x98, 0x9c, 0xd9, 0xc6,
-	0xc8, 0xd8, 0x5d, 0xa3, 0xb8, 0x26, 0x37, 0xc0, 0xca, 0x25, 0x13, 0xd2, 0x6e, 0x8f, 0x8c, 0x5d,
-	0x93, 0xea, 0x0d, 0xd9, 0x04, 0x93, 0x27, 0x81, 0x6d, 0x22, 0xa6, 0x96, 0x2a, 0x37, 0x97, 0x3c,
-	0xb3, 0x3b, 0x08, 0xe1, 0x9a, 0xbc, 0x03, 0x3d, 0x19, 0xc6, 0x3c, 0x2d, 0xa4, 0x6d, 0x8d, 0x8c,
-	0xdd, 0xc1, 0xc1, 0xf6, 0x58, 0x8b, 0x1b, 0xd7, 0xe2, 0xc6, 0x87, 0x55, 0xbb, 0x7e, 0xff, 0x69,
-	0xe9, 0xb6, 0xbe, 0xfb, 0xcd, 0x35, 0x68, 0x9d, 0xa3, 0x8e, 0x46, 0x63, 0xed, 0x2e, 0xea, 0xd1,
-	0x1b, 0x72, 0x1b, 0x7a, 0x69, 0xa6, 0x52, 0x72, 0xbb, 0x87, 0x45, 0xb7, 0xc6, 0x2b, 0xfb, 0xc7,
-	0x1f, 0xe9, 0x90, 0xdf, 0x51, 0xe5, 0x68, 0xcd, 0x24, 0x43, 0x68, 0x87, 0x81, 0xdd, 0x47, 0x6d,
-	0xed, 0x30, 0x20, 0x7b, 0x60, 0xcd, 0xc2, 0x44, 0xe6, 0xf6, 0x1a, 0x96, 0xf8, 0x7f, 0xb3, 0xc4,
-	0x3d, 0x15, 0xc0, 0x02, 0x06, 0xd5, 0x2c, 0xef, 0x17, 0x03, 0x6e, 0xae, 0x8c, 0xbb, 0x9f, 0xe4,
-	0x92, 0x25, 0xf2, 0x5f, 0xad, 0x23, 0xd0, 0x51, 0xad, 0x54, 0xce, 0xe1, 0x7a, 0xd5, 0x93, 0xf9,
-	0x0f, 0x3d, 0x75, 0xfe, 0x63, 0x4f, 0xd6, 0xf5, 0x9e, 0xba, 0x2f, 0xd4, 0xd3, 0x09, 0xd8, 0x8d,
-	0xbb, 0xc0, 0xf3, 0x2c, 0x4d, 0x72, 0x7e, 0x8f, 0xb3, 0x80, 0x0b, 0xb2, 0x0d, 0x9d, 0x0f, 0x59,
-	0xcc, 0x75, 0x37, 0xbe, 0xb5, 0x2c, 0x5d, 0x63, 0x8f, 0x22, 0x44, 0x6e, 0x42, 0xf7, 0x13, 0x16,
-	0x15, 0x3c, 0xb7, 0xdb, 0x23, 0x73, 0x15, 0xac, 0x40, 0xef, 0xfb, 0x36, 0x90, 0xeb, 0x65, 0x89,
-	0x07, 0xdd, 0x63, 0xc9, 0x64, 0x91, 0x57, 0x25, 0x61, 0x59, 0xba, 0xdd, 0x1c, 0x11, 0x5a, 0x45,
-	0x88, 0x0f, 0x9d, 0x43, 0x26, 0x19, 0xda, 0x35, 0x38, 0xd8, 0x69, 0xca, 0x5f, 0x55, 0x54, 0x0c,
-	0x9f, 0x2c, 0x4b, 0x77, 0x18, 0x30, 0xc9, 0x5e, 0x4f, 0xe3, 0x50, 0xf2, 0x38, 0x93, 0x73, 0x8a,
-	0xb9, 0xe4, 0x2d, 0x58, 0x3b, 0x12, 0x22, 0x15, 0x27, 0xf3, 0x8c, 0x6b, 0x8b, 0xfd, 0x97, 0x96,
-	0xa5, 0xbb, 0xc5, 0x6b, 0xb0, 0x91, 0xb1, 0x62, 0x92, 0x57, 0xc0, 0xc2, 0x0d, 0xba, 0xbf, 0xe6,
-	0x6f, 0x2d, 0x4b, 0x77, 0x03, 0x53, 0x1a, 0x74, 0xcd, 0x20, 0x47, 0xd0, 0xd3, 0x26, 0xe5, 0xb6,
-	0x35, 0x32, 0x77, 0x07, 0x07, 0xb7, 0xfe, 0x5e, 0xe8, 0x55, 0x47, 0x6b, 0x9b, 0xea, 0x5c, 0xef,
-	0x1b, 0x03, 0x86, 0x57, 0xbb, 0x22, 0x63, 0x00, 0xca, 0xf3, 0x22, 0x92, 0x28, 0x5e, 0xfb, 0x34,
-	0x5c, 0x96, 0x2e, 0x88, 0x4b, 0x94, 0x36, 0x18, 0xe4, 0x3d, 0xe8, 0xea, 0x1d, 0x7e, 0x89, 0xc1,
-	0x81, 0xdd, 0x14, 0x72, 0xcc, 0xe2, 0x2c, 0xe2, 0xc7, 0x52, 0x70, 0x16, 0xfb, 0x43, 0x75, 0x71,
-	0x94, 0xe3, 0xba, 0x12, 0xad, 0xf2, 0xbc, 0x9f, 0x0d, 0x58, 0x6f, 0x12, 0x49, 0x06, 0xdd, 0x88,
-	0x4d, 0x78, 0xa4, 0x3e, 0x93, 0x89, 0xd7, 0xf0, 0x34, 0x15, 0x92, 0x3f, 0xc9, 0x26, 0xe3, 0x0f,
-	0x14, 0xfe, 0x80, 0x85, 0xc2, 0xbf, 0xab, 0xaa, 0xfd, 0x5a, 0xba, 0x6f, 0xbe, 0xc8, 0x68, 0xd2,
-	0x79, 0x77, 0x02, 0x96, 0x49, 0x2e, 0x94, 0x84, 0x98, 0x4b, 0x11, 0x9e, 0xd2, 0xea, 0x1c, 0xf2,
-	0x36, 0xf4, 0x72, 0x54, 0x90, 0x57, 0x5d, 0x6c, 0xae, 0x8e, 0xd4, 0xd2, 0x56, 0xea, 0xbf, 0xc0,
-	0x2b, 0x46, 0xeb, 0x04, 0xef, 0x73, 0x18, 0xde, 0x65, 0xa7, 0x33, 0x1e, 0x5c, 0x5e, 0xb3, 0x6d,
-	0x30, 0x1f, 0xf1, 0x79, 0xe5, 0x5d, 0x6f, 0x59, 0xba, 0x6a, 0x4b, 0xd5, 0x8f, 0x9a, 0x45, 0xfc,
-	0x89, 0xe4, 0xea, 0x7d, 0xe8, 0x83, 0x48, 0xd3, 0xae, 0x23, 0x0c, 0xf9, 0x1b, 0xd5, 0x51, 0x35,
-	0x95, 0xd6, 0x0b, 0xef, 0x47, 0x03, 0xba, 0x9a, 0x44, 0xdc, 0x7a, 0x22, 0xaa, 0x63, 0x4c, 0x7f,
-	0x6d, 0x59, 0xba, 0x1a, 0xa8, 0x87, 0xe3, 0xb6, 0x1e, 0x8e, 0xf8, 0xec, 0xb5, 0x0a, 0x9e, 0x04,
-	0x7a, 0x4a, 0x8e, 0xa0, 0x2f, 0x05, 0x3b, 0xe5, 0x9f, 0x85, 0x41, 0x75, 0xd7, 0xea, 0x8b, 0x81,
-	0xf0, 0xfd, 0x80, 0xbc, 0x0b, 0x7d, 0x51, 0xb5, 0x53, 0x0d, 0xcd, 0x1b, 0xd7, 0x86, 0xe6, 0x9d,
-	0x64, 0xee, 0xaf, 0x2f, 0x4b, 0xf7, 0x92, 0x49, 0x2f, 0x57, 0xef, 0x77, 0xfa, 0xe6, 0x66, 0xc7,
-	0xfb, 0x0a, 0x7a, 0xd5, 0xd4, 0x20, 0xb7, 0xe0, 0x7f, 0xe8, 0xd2, 0x61, 0x98, 0xb3, 0x49, 0xc4,
-	0x03, 0x94, 0xdd, 0xa7, 0x57, 0x41, 0xf2, 0x2a, 0x6c, 0x1e, 0xcf, 0x98, 0x08, 0xc2, 0x64, 0x7a,
-	0x49, 0x6c, 0x23, 0xf1, 0x1a, 0x4e, 0x46, 0x30, 0x38, 0x49, 0x25, 0x8b, 0x30, 0x90, 0xe3, 0x33,
-	0xb3, 0x68, 0x13, 0xf2, 0x5e, 0x03, 0x0b, 0x27, 0x0e, 0xf1, 0x60, 0x1d, 0x71, 0x35, 0x2b, 0x43,
-	0xae, 0x5f, 0xbf, 0x45, 0xaf, 0x60, 0xfe, 0xd1, 0xd9, 0xb9, 0xd3, 0x7a, 0x76, 0xee, 0xb4, 0x9e,
-	0x9f, 0x3b, 0xc6, 0xd7, 0x0b, 0xc7, 0xf8, 0x61, 0xe1, 0x18, 0x4f, 0x17, 0x8e, 0x71, 0xb6, 0x70,
-	0x8c, 0xdf, 0x17, 0x8e, 0xf1, 0xc7, 0xc2, 0x69, 0x3d, 0x5f, 0x38, 0xc6, 0xb7, 0x17, 0x4e, 0xeb,
-	0xec, 0xc2, 0x69, 0x3d, 0xbb, 0x70, 0x5a, 0x9f, 0x6e, 0xe0, 0xd7, 0x8b, 0xc3, 0x20, 0x88, 0xf8,
-	0x97, 0x4c, 0xf0, 0x49, 0x17, 0xed, 0xb9, 0xfd, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0x78, 0x72,
-	0x07, 0xd5, 0x9a, 0x07, 0x00, 0x00,
+	// 1227 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x4d, 0x8f, 0x1b, 0x45,
+	0x13, 0xde, 0xf1, 0xf7, 0x96, 0x37, 0x5e, 0xa7, 0x93, 0xf7, 0x65, 0x9c, 0x28, 0x33, 0x96, 0x95,
+	0xc3, 0xf2, 0x11, 0x2f, 0x6c, 0xe0, 0x12, 0x09, 0x94, 0xcc, 0x66, 0xa3, 0x04, 0x21, 0x48, 0xda,
+	0x2b, 0x22, 0xc1, 0x01, 0xb5, 0x3d, 0xbd, 0xf6, 0x90, 0xf9, 0x4a, 0x77, 0x4f, 0x12, 0xdf, 0xf8,
+	0x09, 0x1c, 0xf9, 0x07, 0x70, 0xe0, 0x8c, 0x84, 0xc4, 0x0f, 0x88, 0x38, 0x85, 0x5b, 0xe0, 0x30,
+	0x10, 0xe7, 0x82, 0xe6, 0x94, 0x9f, 0x80, 0xba, 0x7b, 0x66, 0x3d, 0x6b, 0x27, 0x22, 0x5c, 0xec,
+	0x9e, 0xaa, 0xa7, 0x9e, 0xae, 0x7a, 0xaa, 0xbb, 0x0b, 0xda, 0x41, 0xe4, 0x52, 0x7f, 0x18, 0xb3,
+	0x48, 0x44, 0x08, 0xee, 0x27, 0x94, 0xcd, 0x19, 0x09, 0xa7, 0xf4, 0xdc, 0xa5, 0xa9, 0x27, 0x66,
+	0xc9, 0x78, 0x38, 0x89, 0x82, 0xdd, 0x69, 0x34, 0x8d, 0x76, 0x15, 0x64, 0x9c, 0x1c, 0xa9, 0x2f,
+	0xf5, 0xa1, 0x56, 0x3a, 0xf4, 0x9c, 0x35, 0x8d, 0xa2, 0xa9, 0x4f, 0x97, 0x28, 0x37, 0x61, 0x44,
+	0x78, 0x51, 0x98, 0xfb, 0xdf, 0x2d, 0xd3, 0x31, 0x72, 0x44, 0x42, 0xb2, 0x1b, 0x78, 0x81, 0xc7,
+	0x76, 0xe3, 0x7b, 0x53, 0xbd, 0x8a, 0xc7, 0xfa, 0x3f, 0x8f, 0xe8, 0xad, 0x32, 0x92, 0x70, 0xae,
+	0x5d, 0x83, 0x9f, 0x2a, 0x70, 0xfe, 0x36, 0x8b, 0x02, 0x2a, 0x66, 0x34, 0xe1, 0x58, 0xe6, 0x7b,
+	0x47, 0x66, 0x8e, 0xe9, 0xfd, 0x84, 0x72, 0x81, 0x10, 0xd4, 0x62, 0x22, 0x66, 0xa6, 0xd1, 0x37,
+	0x76, 0x36, 0xb1, 0x5a, 0xa3, 0xb3, 0x50, 0xe7, 0x82, 0x30, 0x61, 0x56, 0xfa, 0xc6, 0x4e, 0x15,
+	0xeb, 0x0f, 0xd4, 0x85, 0x2a, 0x0d, 0x5d, 0xb3, 0xaa, 0x6c, 0x72, 0x29, 0x63, 0xb9, 0xa0, 0xb1,
+	0x59, 0x53, 0x26, 0xb5, 0x46, 0x1f, 0x42, 0x53, 0x78, 0x01, 0x8d, 0x12, 0x61, 0xd6, 0xfb, 0xc6,
+	0x4e, 0x7b, 0xaf, 0x37, 0xd4, 0xc9, 0x0d, 0x8b, 0xe4, 0x86, 0xd7, 0xf3, 0x72, 0x9d, 0xd6, 0xe3,
+	0xd4, 0xde, 0xf8, 0xee, 0x4f, 0xdb, 0xc0, 0x45, 0x8c, 0xdc, 0x5a, 0x09, 0x6b, 0x36, 0x54, 0x3e,
+	0xfa, 0x03, 0x5d, 0x86, 0x66, 0x14, 0xcb, 0x10, 0x6e, 0x36, 0x15, 0xe9, 0x99, 0xe1, 0x52, 0xfe,
+	0xe1, 0x67, 0xda, 0xe5, 0xd4, 0x24, 0x1d, 0x2e, 0x90, 0xa8, 0x03, 0x15, 0xcf, 0x35, 0x5b, 0x2a,
+	0xb7, 0x8a, 0xe7, 0xa2, 0x4b, 0x50, 0x9f, 0x79, 0xa1, 0xe0, 0xe6, 0xa6, 0xa2, 0x38, 0x5d, 0xa6,
+	0xb8, 0x29, 0x1d, 0x8a, 0xc0, 0xc0, 0x1a, 0x35, 0xf8, 0xcd, 0x80, 0x0b, 0x4b, 0xe1, 0x6e, 0x85,
+	0x5c, 0x90, 0x50, 0xfc, 0xab, 0x74, 0x08, 0x6a, 0xb2, 0x94, 0x5c, 0x39, 0xb5, 0x5e, 0xd6, 0x54,
+	0x7d, 0x45, 0x4d, 0xb5, 0xff, 0x58, 0x53, 0x7d, 0xbd, 0xa6, 0xc6, 0x6b, 0xd5, 0x74, 0x08, 0x66,
+	0xe9, 0x2c, 0x50, 0x1e, 0x47, 0x21, 0xa7, 0x37, 0x29, 0x71, 0x29, 0x43, 0x3d, 0xa8, 0x7d, 0x4a,
+	0x02, 0xaa, 0xab, 0x71, 0xea, 0x59, 0x6a, 0x1b, 0x97, 0xb0, 0x32, 0xa1, 0x0b, 0xd0, 0xf8, 0x9c,
+	0xf8, 0x09, 0xe5, 0x66, 0xa5, 0x5f, 0x5d, 0x3a, 0x73, 0xe3, 0xe0, 0xf7, 0x0a, 0xa0, 0x75, 0x5a,
+	0x34, 0x80, 0xc6, 0x48, 0x10, 0x91, 0xf0, 0x9c, 0x12, 0xb2, 0xd4, 0x6e, 0x70, 0x65, 0xc1, 0xb9,
+	0x07, 0x39, 0x50, 0xbb, 0x4e, 0x04, 0x51, 0x72, 0xb5, 0xf7, 0xce, 0x95, 0xd3, 0x5f, 0x32, 0x4a,
+	0x84, 0x83, 0xb2, 0xd4, 0xee, 0xb8, 0x44, 0x90, 0x77, 0xa2, 0xc0, 0x13, 0x34, 0x88, 0xc5, 0x1c,
+	0xab, 0x58, 0xf4, 0x01, 0x6c, 0x1e, 0x30, 0x16, 0xb1, 0xc3, 0x79, 0x4c, 0xb5, 0xc4, 0xce, 0x1b,
+	0x59, 0x6a, 0x9f, 0xa1, 0x85, 0xb1, 0x14, 0xb1, 0x44, 0xa2, 0x37, 0xa1, 0xae, 0x3e, 0x94, 0xfa,
+	0x9b, 0xce, 0x99, 0x2c, 0xb5, 0xb7, 0x55, 0x48, 0x09, 0xae, 0x11, 0xe8, 0x00, 0x9a, 0x5a, 0x24,
+	0x6e, 0xd6, 0xfb, 0xd5, 0x9d, 0xf6, 0xde, 0xc5, 0x97, 0x27, 0x7a, 0x52, 0xd1, 0x42, 0xa6, 0x22,
+	0x16, 0xed, 0x41, 0xeb, 0x2e, 0x61, 0xa1, 0x17, 0x4e, 0x65, 0xbf, 0xa4, 0x90, 0xff, 0xcf, 0x52,
+	0x1b, 0x3d, 0xcc, 0x6d, 0xa5, 0x7d, 0x8f, 0x71, 0x83, 0x5f, 0x0d, 0xe8, 0x9c, 0x54, 0x02, 0x0d,
+	0x01, 0x30, 0xe5, 0x89, 0x2f, 0x54, 0xc1, 0x5a, 0xdb, 0x4e, 0x96, 0xda, 0xc0, 0x8e, 0xad, 0xb8,
+	0x84, 0x40, 0x57, 0xa1, 0xa1, 0xbf, 0x54, 0xf7, 0xda, 0x7b, 0x66, 0x39, 0xf9, 0x11, 0x09, 0x62,
+	0x9f, 0x8e, 0x04, 0xa3, 0x24, 0x70, 0x3a, 0xf2, 0xb0, 0xc9, 0x2e, 0x69, 0x26, 0x9c, 0xc7, 0xa1,
+	0x1b, 0x50, 0x97, 0xfd, 0xe2, 0x4a, 0xdd, 0xf6, 0xde, 0xf9, 0x32, 0x81, 0xba, 0x11, 0xd2, 0xeb,
+	0x71, 0xe1, 0x4d, 0xb8, 0xd6, 0x51, 0x76, 0xb9, 0x5c, 0x8f, 0x0e, 0x1f, 0xfc, 0x5c, 0x83, 0xed,
+	0x15, 0x3c, 0xfa, 0x12, 0x7a, 0xfb, 0x49, 0x90, 0xf8, 0x44, 0x78, 0x0f, 0xe8, 0x5d, 0xe2, 0xfb,
+	0x87, 0x5e, 0x40, 0x47, 0x74, 0x12, 0x85, 0xae, 0x3e, 0x38, 0x86, 0x73, 0x21, 0x4b, 0xed, 0xde,
+	0xe4, 0x55, 0x20, 0xfc, 0xea, 0x78, 0x74, 0x15, 0xba, 0x77, 0x12, 0x9a, 0xd0, 0x32, 0x67, 0x45,
+	0x71, 0x9e, 0xcd, 0x52, 0xbb, 0x7b, 0x7f, 0xc5, 0x87, 0xd7, 0xd0, 0xe8, 0x06, 0xa0, 0x1b, 0x54,
+	0x4c, 0x66, 0xd4, 0x1d, 0x51, 0xe6, 0x51, 0xbe, 0x1f, 0x25, 0xa1, 0x50, 0x3a, 0xd4, 0x74, 0xf7,
+	0x8e, 0xd6, 0xbc, 0xf8, 0x25, 0x11, 0x25, 0x9e, 0xfd, 0x59, 0x12, 0xde, 0xcb, 0x79, 0x6a, 0x6b,
+	0x3c, 0x25, 0x2f, 0x7e, 0x49, 0x04, 0xda, 0x87, 0xd3, 0x65, 0xab, 0x33, 0x17, 0x94, 0xab, 0xf7,
+	0xa0, 0xe6, 0xfc, 0x2f, 0x4b, 0xed, 0xd3, 0x47, 0xab, 0x4e, 0xbc, 0x8e, 0x47, 0x57, 0xa0, 0x33,
+	0x9a, 0x11, 0xe6, 0x52, 0x57, 0x76, 0xc3, 0xa3, 0xfa, 0xf9, 0x38, 0xa5, 0xef, 0x18, 0x3f, 0xe1,
+	0xc1, 0x2b, 0x48, 0xf4, 0x3e, 0x6c, 0x8d, 0x62, 0xdf, 0x13, 0x45, 0x64, 0x53, 0x45, 0x76, 0xb3,
+	0xd4, 0xde, 0xe2, 0x25, 0x3b, 0x3e, 0x81, 0x92, 0x8d, 0xd0, 0x67, 0x89, 0xef, 0x93, 0xc9, 0x8c,
+	0xde, 0xf4, 0x04, 0x57, 0x2f, 0xf3, 0x29, 0xdd, 0x08, 0xb6, 0xe2, 0xc3, 0x6b, 0xe8, 0xc1, 0x2f,
+	0x06, 0x6c, 0x95, 0x0f, 0x2b, 0x8a, 0xa1, 0xe1, 0x93, 0x31, 0xf5, 0xe5, 0x29, 0xa9, 0xaa, 0xe7,
+	0x73, 0x12, 0x31, 0x41, 0x1f, 0xc5, 0xe3, 0xe1, 0x27, 0xd2, 0x7e, 0x9b, 0x78, 0xcc, 0xd9, 0x97,
+	0x27, 0xfa, 0x8f, 0xd4, 0x7e, 0xef, 0x75, 0x46, 0xaa, 0x8e, 0xbb, 0xe6, 0x92, 0x58, 0x50, 0x26,
+	0xaf, 0x41, 0x40, 0x05, 0xf3, 0x26, 0x38, 0xdf, 0x07, 0x5d, 0x81, 0x26, 0x57, 0x19, 0xf0, 0xfc,
+	0x26, 0x75, 0x97, 0x5b, 0xea, 0xd4, 0x96, 0x37, 0xe8, 0x81, 0x7a, 0x1a, 0x71, 0x11, 0x30, 0xf8,
+	0x1a, 0x3a, 0xaa, 0x16, 0xf7, 0xf8, 0x79, 0xec, 0x41, 0xf5, 0x1e, 0x9d, 0xe7, 0xf7, 0xb7, 0x99,
+	0xa5, 0xb6, 0xfc, 0xc4, 0xf2, 0x47, 0xce, 0x50, 0xfa, 0x48, 0xd0, 0x50, 0x14, 0x1b, 0xa1, 0xf2,
+	0x8d, 0x3b, 0x50, 0x2e, 0x67, 0x3b, 0xdf, 0xaa, 0x80, 0xe2, 0x62, 0x31, 0xf8, 0xd1, 0x80, 0x86,
+	0x06, 0x21, 0xbb, 0x98, 0xe4, 0x72, 0x9b, 0xaa, 0xb3, 0x99, 0xa5, 0xb6, 0x36, 0x14, 0x43, 0xbd,
+	0xa7, 0x87, 0xba, 0x1a, 0x57, 0x3a, 0x0b, 0x1a, 0xba, 0x7a, 0xba, 0xf7, 0xa1, 0x25, 0x18, 0x99,
+	0xd0, 0xaf, 0x3c, 0x37, 0x7f, 0x23, 0x8b, 0x07, 0x4d, 0x99, 0x6f, 0xb9, 0xe8, 0x23, 0x68, 0xb1,
+	0xbc, 0x9c, 0x7c, 0xd8, 0x9f, 0x5d, 0x1b, 0xf6, 0xd7, 0xc2, 0xb9, 0xb3, 0x95, 0xa5, 0xf6, 0x31,
+	0x12, 0x1f, 0xaf, 0x3e, 0xae, 0xb5, 0xaa, 0xdd, 0xda, 0xe0, 0x7b, 0x03, 0x9a, 0xf9, 0xb8, 0x43,
+	0x17, 0xe1, 0x94, 0x92, 0xe9, 0xba, 0xc7, 0xc9, 0xd8, 0xa7, 0xae, 0xca, 0xbb, 0x85, 0x4f, 0x1a,
+	0xd1, 0x5b, 0xd0, 0x55, 0xa7, 0xd2, 0x0b, 0xa7, 0xc7, 0xc0, 0x8a, 0x02, 0xae, 0xd9, 0x51, 0x1f,
+	0xda, 0x87, 0x91, 0x20, 0xbe, 0x72, 0xe8, 0x17, 0xac, 0x8e, 0xcb, 0x26, 0xb4, 0x03, 0xdb, 0xb7,
+	0x09, 0x13, 0x1e, 0xf1, 0x8b, 0xde, 0xa8, 0x72, 0x5b, 0x78, 0xd5, 0x3c, 0x78, 0x1b, 0xea, 0x6a,
+	0xa8, 0xa2, 0x01, 0x6c, 0x29, 0x86, 0xe2, 0x12, 0x18, 0x8a, 0xf5, 0x84, 0xcd, 0x39, 0x78, 0xf2,
+	0xcc, 0xda, 0x78, 0xfa, 0xcc, 0xda, 0x78, 0xf1, 0xcc, 0x32, 0xbe, 0x59, 0x58, 0xc6, 0x0f, 0x0b,
+	0xcb, 0x78, 0xbc, 0xb0, 0x8c, 0x27, 0x0b, 0xcb, 0xf8, 0x6b, 0x61, 0x19, 0x7f, 0x2f, 0xac, 0x8d,
+	0x17, 0x0b, 0xcb, 0xf8, 0xf6, 0xb9, 0xb5, 0xf1, 0xe4, 0xb9, 0xb5, 0xf1, 0xf4, 0xb9, 0xb5, 0xf1,
+	0xc5, 0xb6, 0x6a, 0x74, 0xe0, 0xb9, 0xae, 0x4f, 0x1f, 0x12, 0x46, 0xc7, 0x0d, 0xa5, 0xe4, 0xe5,
+	0x7f, 0x06, 0x00, 0xd5, 0xff, 0x43, 0x3d, 0x7d, 0x0a, 0x00, 0x00,
 }
 
 func (this *PrometheusRangeQueryRequest) Equal(that interface{}) bool {
@@ -911,6 +1056,14 @@ func (this *PrometheusResponse) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.Warnings) != len(that1.Warnings) {
+		return false
+	}
+	for i := range this.Warnings {
+		if this.Warnings[i] != that1.Warnings[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *PrometheusData) Equal(that interface{}) bool {
@@ -943,6 +1096,54 @@ func (this *PrometheusData) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if !this.Stats.Equal(that1.Stats) {
+		return false
+	}
+	return true
+}
+func (this *QueryStatistics) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*QueryStatistics)
+	if !ok {
+		that2, ok := that.(QueryStatistics)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.CumulativeWallTimeSeconds != that1.CumulativeWallTimeSeconds {
+		return false
+	}
+	if this.QueueTimeSeconds != that1.QueueTimeSeconds {
+		return false
+	}
+	if this.FetchedSeriesCount != that1.FetchedSeriesCount {
+		return false
+	}
+	if this.FetchedChunksCount != that1.FetchedChunksCount {
+		return false
+	}
+	if this.FetchedChunkBytes != that1.FetchedChunkBytes {
+		return false
+	}
+	if this.ShardedQueries != that1.ShardedQueries {
+		return false
+	}
+	if this.SplitQueries != that1.SplitQueries {
+		return false
+	}
+	if this.ResultsCacheHits != that1.ResultsCacheHits {
+		return false
+	}
 	return true
 }
 func (this *SampleStream) Equal(that interface{}) bool {
@@ -1075,6 +1276,9 @@ func (this *Options) Equal(that interface{}) bool {
 	if this.TotalShards != that1.TotalShards {
 		return false
 	}
+	if this.PartialResponse != that1.PartialResponse {
+		return false
+	}
 	return true
 }
 func (this *Hints) Equal(that interface{}) bool {
@@ -1153,7 +1357,7 @@ func (this *PrometheusResponse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 10)
 	s = append(s, "&querymiddleware.PrometheusResponse{")
 	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
 	if this.Data != nil {
@@ -1164,6 +1368,7 @@ func (this *PrometheusResponse) GoString() string {
 	if this.Headers != nil {
 		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
 	}
+	s = append(s, "Warnings: "+fmt.Sprintf("%#v", this.Warnings)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1171,7 +1376,7 @@ func (this *PrometheusData) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 7)
 	s = append(s, "&querymiddleware.PrometheusData{")
 	s = append(s, "ResultType: "+fmt.Sprintf("%#v", this.ResultType)+",\n")
 	if this.Result != nil {
@@ -1181,6 +1386,26 @@ func (this *PrometheusData) GoString() string {
 		}
 		s = append(s, "Result: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *QueryStatistics) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&querymiddleware.QueryStatistics{")
+	s = append(s, "CumulativeWallTimeSeconds: "+fmt.Sprintf("%#v", this.CumulativeWallTimeSeconds)+",\n")
+	s = append(s, "QueueTimeSeconds: "+fmt.Sprintf("%#v", this.QueueTimeSeconds)+",\n")
+	s = append(s, "FetchedSeriesCount: "+fmt.Sprintf("%#v", this.FetchedSeriesCount)+",\n")
+	s = append(s, "FetchedChunksCount: "+fmt.Sprintf("%#v", this.FetchedChunksCount)+",\n")
+	s = append(s, "FetchedChunkBytes: "+fmt.Sprintf("%#v", this.FetchedChunkBytes)+",\n")
+	s = append(s, "ShardedQueries: "+fmt.Sprintf("%#v", this.ShardedQueries)+",\n")
+	s = append(s, "SplitQueries: "+fmt.Sprintf("%#v", this.SplitQueries)+",\n")
+	s = append(s, "ResultsCacheHits: "+fmt.Sprintf("%#v", this.ResultsCacheHits)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1237,11 +1462,12 @@ func (this *Options) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&querymiddleware.Options{")
 	s = append(s, "CacheDisabled: "+fmt.Sprintf("%#v", this.CacheDisabled)+",\n")
 	s = append(s, "ShardingDisabled: "+fmt.Sprintf("%#v", this.ShardingDisabled)+",\n")
 	s = append(s, "TotalShards: "+fmt.Sprintf("%#v", this.TotalShards)+",\n")
+	s = append(s, "PartialResponse: "+fmt.Sprintf("%#v", this.PartialResponse)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1478,6 +1704,15 @@ func (m *PrometheusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Warnings) > 0 {
+		for iNdEx := len(m.Warnings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Warnings[iNdEx])
+			copy(dAtA[i:], m.Warnings[iNdEx])
+			i = encodeVarintModel(dAtA, i, uint64(len(m.Warnings[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Headers) > 0 {
 		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1548,6 +1783,18 @@ func (m *PrometheusData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintModel(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Result) > 0 {
 		for iNdEx := len(m.Result) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1572,6 +1819,71 @@ func (m *PrometheusData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *QueryStatistics) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStatistics) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStatistics) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ResultsCacheHits != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.ResultsCacheHits))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.SplitQueries != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.SplitQueries))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ShardedQueries != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.ShardedQueries))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.FetchedChunkBytes != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.FetchedChunkBytes))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.FetchedChunksCount != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.FetchedChunksCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.FetchedSeriesCount != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.FetchedSeriesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.QueueTimeSeconds != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.QueueTimeSeconds))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.CumulativeWallTimeSeconds != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CumulativeWallTimeSeconds))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SampleStream) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1739,6 +2051,16 @@ func (m *Options) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.PartialResponse {
+		i--
+		if m.PartialResponse {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.TotalShards != 0 {
 		i = encodeVarintModel(dAtA, i, uint64(m.TotalShards))
 		i--
@@ -1919,6 +2241,12 @@ func (m *PrometheusResponse) Size() (n int) {
 			n += 1 + l + sovModel(uint64(l))
 		}
 	}
+	if len(m.Warnings) > 0 {
+		for _, s := range m.Warnings {
+			l = len(s)
+			n += 1 + l + sovModel(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1938,6 +2266,43 @@ func (m *PrometheusData) Size() (n int) {
 			n += 1 + l + sovModel(uint64(l))
 		}
 	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovModel(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryStatistics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CumulativeWallTimeSeconds != 0 {
+		n += 9
+	}
+	if m.QueueTimeSeconds != 0 {
+		n += 9
+	}
+	if m.FetchedSeriesCount != 0 {
+		n += 1 + sovModel(uint64(m.FetchedSeriesCount))
+	}
+	if m.FetchedChunksCount != 0 {
+		n += 1 + sovModel(uint64(m.FetchedChunksCount))
+	}
+	if m.FetchedChunkBytes != 0 {
+		n += 1 + sovModel(uint64(m.FetchedChunkBytes))
+	}
+	if m.ShardedQueries != 0 {
+		n += 1 + sovModel(uint64(m.ShardedQueries))
+	}
+	if m.SplitQueries != 0 {
+		n += 1 + sovModel(uint64(m.SplitQueries))
+	}
+	if m.ResultsCacheHits != 0 {
+		n += 1 + sovModel(uint64(m.ResultsCacheHits))
+	}
 	return n
 }
 
@@ -2019,6 +2384,9 @@ func (m *Options) Size() (n int) {
 	if m.TotalShards != 0 {
 		n += 1 + sovModel(uint64(m.TotalShards))
 	}
+	if m.PartialResponse {
+		n += 2
+	}
 	return n
 }
 
@@ -2099,6 +2467,7 @@ func (this *PrometheusResponse) String() string {
 		`ErrorType:` + fmt.Sprintf("%v", this.ErrorType) + `,`,
 		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
 		`Headers:` + repeatedStringForHeaders + `,`,
+		`Warnings:` + fmt.Sprintf("%v", this.Warnings) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2115,6 +2484,24 @@ func (this *PrometheusData) String() string {
 	s := strings.Join([]string{`&PrometheusData{`,
 		`ResultType:` + fmt.Sprintf("%v", this.ResultType) + `,`,
 		`Result:` + repeatedStringForResult + `,`,
+		`Stats:` + strings.Replace(this.Stats.String(), "QueryStatistics", "QueryStatistics", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryStatistics) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QueryStatistics{`,
+		`CumulativeWallTimeSeconds:` + fmt.Sprintf("%v", this.CumulativeWallTimeSeconds) + `,`,
+		`QueueTimeSeconds:` + fmt.Sprintf("%v", this.QueueTimeSeconds) + `,`,
+		`FetchedSeriesCount:` + fmt.Sprintf("%v", this.FetchedSeriesCount) + `,`,
+		`FetchedChunksCount:` + fmt.Sprintf("%v", this.FetchedChunksCount) + `,`,
+		`FetchedChunkBytes:` + fmt.Sprintf("%v", this.FetchedChunkBytes) + `,`,
+		`ShardedQueries:` + fmt.Sprintf("%v", this.ShardedQueries) + `,`,
+		`SplitQueries:` + fmt.Sprintf("%v", this.SplitQueries) + `,`,
+		`ResultsCacheHits:` + fmt.Sprintf("%v", this.ResultsCacheHits) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2172,6 +2559,7 @@ func (this *Options) String() string {
 		`CacheDisabled:` + fmt.Sprintf("%v", this.CacheDisabled) + `,`,
 		`ShardingDisabled:` + fmt.Sprintf("%v", this.ShardingDisabled) + `,`,
 		`TotalShards:` + fmt.Sprintf("%v", this.TotalShards) + `,`,
+		`PartialResponse:` + fmt.Sprintf("%v", this.PartialResponse) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -3025,6 +3413,38 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Warnings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Warnings = append(m.Warnings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipModel(dAtA[iNdEx:])
@@ -3144,6 +3564,231 @@ func (m *PrometheusData) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &QueryStatistics{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStatistics) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStatistics: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStatistics: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CumulativeWallTimeSeconds", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CumulativeWallTimeSeconds = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueTimeSeconds", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.QueueTimeSeconds = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedSeriesCount", wireType)
+			}
+			m.FetchedSeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedSeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedChunksCount", wireType)
+			}
+			m.FetchedChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedChunkBytes", wireType)
+			}
+			m.FetchedChunkBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedChunkBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardedQueries", wireType)
+			}
+			m.ShardedQueries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardedQueries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitQueries", wireType)
+			}
+			m.SplitQueries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SplitQueries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultsCacheHits", wireType)
+			}
+			m.ResultsCacheHits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultsCacheHits |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipModel(dAtA[iNdEx:])
@@ -3655,6 +4300,26 @@ func (m *Options) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialResponse", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PartialResponse = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipModel(dAtA[iNdEx:])
@@ -124,6 +124,7 @@ func (d *PrometheusData) UnmarshalJSON(b []byte) error {
 	v := struct {
 		Type   model.ValueType    `json:"resultType"`
 		Result stdjson.RawMessage `json:"result"`
+		Stats  *QueryStatistics   `json:"stats"`
 	}{}
 
 	err := json.Unmarshal(b, &v)
@@ -131,6 +132,7 @@ func (d *PrometheusData) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	d.ResultType = v.Type.String()
+	d.Stats = v.Stats
 	switch v.Type {
 	case model.ValString:
 		var sss stringSampleStreams
@@ -174,27 +176,33 @@ func (d *PrometheusData) MarshalJSON() ([]byte, error) {
 		return json.Marshal(struct {
 			Type   model.ValueType     `json:"resultType"`
 			Result stringSampleStreams `json:"result"`
+			Stats  *QueryStatistics    `json:"stats,omitempty"`
 		}{
 			Type:   model.ValString,
 			Result: d.Result,
+			Stats:  d.Stats,
 		})
 
 	case model.ValScalar.String():
 		return json.Marshal(struct {
 			Type   model.ValueType     `json:"resultType"`
 			Result scalarSampleStreams `json:"result"`
+			Stats  *QueryStatistics    `json:"stats,omitempty"`
 		}{
 			Type:   model.ValScalar,
 			Result: d.Result,
+			Stats:  d.Stats,
 		})
 
 	case model.ValVector.String():
 		return json.Marshal(struct {
 			Type   model.ValueType      `json:"resultType"`
 			Result []vectorSampleStream `json:"result"`
+			Stats  *QueryStatistics     `json:"stats,omitempty"`
 		}{
 			Type:   model.ValVector,
 			Result: asVectorSampleStreams(d.Result),
+			Stats:  d.Stats,
 		})
 
 	case model.ValMatrix.String():
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/grafana/dskit/tenant"
+
+	apierror "github.com/grafana/mimir/pkg/api/error"
+	"github.com/grafana/mimir/pkg/util/spanlogger"
+)
+
+type queryDeduplicationMiddleware struct {
+	next   Handler
+	logger log.Logger
+
+	*inflightQueries
+}
+
+// inflightQueries keeps track of the queries currently executed, by deduplication key.
+// It's shared by all the handlers built by the middleware.
+type inflightQueries struct {
+	mtx     sync.Mutex
+	queries map[string]*inflightQuery
+
+	requests          prometheus.Counter
+	coalescedRequests prometheus.Counter
+}
+
+// inflightQuery is a query currently executed, whose result is shared with the coalesced requests.
+type inflightQuery struct {
+	done chan struct{}
+
+	// The following fields are safe to read only once done has been closed.
+	res      Response
+	err      error
+	canceled bool
+}
+
+// newQueryDeduplicationMiddleware creates a middleware that coalesces concurrent identical requests
+// (same tenants, query, time range, step and options) into a single downstream execution, whose
+// response is shared with all the coalesced requests. Requests asking for the query statistics are
+// never coalesced.
+//
+// The request executing the query is the leader. If the leader gets canceled, its result can't be
+// shared, so the coalesced requests still waiting will execute the query again, and one of them will
+// become the new leader.
+func newQueryDeduplicationMiddleware(logger log.Logger, registerer prometheus.Registerer) Middleware {
+	inflight := &inflightQueries{
+		queries: map[string]*inflightQuery{},
+		requests: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "frontend_query_deduplication_requests_total",
+			Help:      "Total number of requests the query-frontend attempted to deduplicate.",
+		}),
+		coalescedRequests: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "frontend_query_deduplication_coalesced_requests_total",
+			Help:      "Total number of requests coalesced with an identical in-flight request.",
+		}),
+	}
+
+	return MiddlewareFunc(func(next Handler) Handler {
+		return &queryDeduplicationMiddleware{
+			next:            next,
+			logger:          logger,
+			inflightQueries: inflight,
+		}
+	})
+}
+
+func (d *queryDeduplicationMiddleware) Do(ctx context.Context, req Request) (Response, error) {
+	log, ctx := spanlogger.NewWithLogger(ctx, d.logger, "queryDeduplicationMiddleware.Do")
+	defer log.Span.Finish()
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, apierror.New(apierror.TypeBadData, err.Error())
+	}
+
+	// The query statistics are tracked per request, so a request asking for them is never coalesced
+	// with other requests, otherwise they would report the execution of another request.
+	if isQueryStatsRequestedInContext(ctx) {
+		return d.next.Do(ctx, req)
+	}
+
+	d.requests.Inc()
+	key := deduplicationKey(tenant.JoinTenantIDs(tenantIDs), req)
+
+	coalesced := false
+	for {
+		query, leader := d.acquire(key)
+		if leader {
+			res, err := d.next.Do(ctx, req)
+			d.release(key, query, res, err, ctx.Err() != nil)
+			return res, err
+		}
+
+		if !coalesced {
+			coalesced = true
+			d.coalescedRequests.Inc()
+		}
+		level.Debug(log).Log("msg", "request coalesced with an identical in-flight request", "query", req.GetQuery())
+
+		select {
+		case <-query.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// The leader request has been canceled, so we try again: either another coalesced
+		// request has already become the new leader, or this request will become it.
+		if query.canceled {
+			level.Debug(log).Log("msg", "the in-flight request this request was coalesced with has been canceled, retrying", "query", req.GetQuery())
+			continue
+		}
+
+		return query.res, query.err
+	}
+}
+
+// acquire returns the in-flight query for the input key. If there's no in-flight
+// query, it's created and the caller is the leader, in charge of executing it.
+func (q *inflightQueries) acquire(key string) (query *inflightQuery, leader bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if query, ok := q.queries[key]; ok {
+		return query, false
+	}
+
+	query = &inflightQuery{done: make(chan struct{})}
+	q.queries[key] = query
+	return query, true
+}
+
+// release stores the result of the in-flight query and notifies the coalesced requests.
+func (q *inflightQueries) release(key string, query *inflightQuery, res Response, err error, canceled bool) {
+	q.mtx.Lock()
+	delete(q.queries, key)
+	q.mtx.Unlock()
+
+	query.res = res
+	query.err = err
+	query.canceled = canceled
+	close(query.done)
+}
+
+// deduplicationKey returns the key used to identify identical requests issued by the given tenants.
+// The response is shared between identical requests, so the key includes anything affecting it.
+func deduplicationKey(tenantID string, req Request) string {
+	opts := req.GetOptions()
+	return fmt.Sprintf("%s:%T:%d:%d:%d:%t:%t:%d:%t:%s", tenantID, req, req.GetStart(), req.GetEnd(), req.GetStep(), opts.CacheDisabled, opts.ShardingDisabled, opts.TotalShards, opts.PartialResponse, req.GetQuery())
+}
@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/dskit/test"
+)
+
+func TestQueryDeduplicationMiddleware_ShouldCoalesceIdenticalRequests(t *testing.T) {
+	const numRequests = 10
+
+	var (
+		calls    = atomic.NewInt64(0)
+		release  = make(chan struct{})
+		expected = &PrometheusResponse{Status: statusSuccess, Data: &PrometheusData{ResultType: "vector"}}
+	)
+
+	downstream := HandlerFunc(func(ctx context.Context, req Request) (Response, error) {
+		calls.Inc()
+		<-release
+		return expected, nil
+	})
+
+	reg := prometheus.NewPedanticRegistry()
+	handler := newQueryDeduplicationMiddleware(log.NewNopLogger(), reg).Wrap(downstream)
+	req := &PrometheusRangeQueryRequest{Path: "/query_range", Start: 0, End: 3600 * 1000, Step: 60 * 1000, Query: "sum(up)"}
+
+	wg := sync.WaitGroup{}
+	wg.Add(numRequests)
+	for i := 0; i < numRequests; i++ {
+		go func() {
+			defer wg.Done()
+
+			res, err := handler.Do(user.InjectOrgID(context.Background(), "user-1"), req)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		}()
+	}
+
+	// Wait until all requests have been coalesced, then unblock the downstream execution.
+	test.Poll(t, time.Second, float64(numRequests-1), func() interface{} {
+		return testutil.ToFloat64(handler.(*queryDeduplicationMiddleware).coalescedRequests)
+	})
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_frontend_query_deduplication_coalesced_requests_total Total number of requests coalesced with an identical in-flight request.
+		# TYPE cortex_frontend_query_deduplication_coalesced_requests_total counter
+		cortex_frontend_query_deduplication_coalesced_requests_total 9
+		# HELP cortex_frontend_query_deduplication_requests_total Total number of requests the query-frontend attempted to deduplicate.
+		# TYPE cortex_frontend_query_deduplication_requests_total counter
+		cortex_frontend_query_deduplication_requests_total 10
+	`)))
+}
+
+func TestQueryDeduplicationMiddleware_ShouldNotCoalesceDifferentRequests(t *testing.T) {
+	var (
+		calls   = atomic.NewInt64(0)
+		release = make(chan struct{})
+	)
+
+	downstream := HandlerFunc(func(ctx context.Context, req Request) (Response, error) {
+		calls.Inc()
+		<-release
+		return &PrometheusResponse{Status: statusSuccess}, nil
+	})
+
+	handler := newQueryDeduplicationMiddleware(log.NewNopLogger(), nil).Wrap(downstream)
+	req := &PrometheusRangeQueryRequest{Path: "/query_range", Start: 0, End: 3600 * 1000, Step: 60 * 1000, Query: "sum(up)"}
+
+	requests := []struct {
+		tenantID       string
+		req            Request
+		statsRequested bool
+	}{
+		{tenantID: "user-1", req: req},
+		{tenantID: "user-2", req: req},
+		{tenantID: "user-1|user-2", req: req},
+		{tenantID: "user-1", req: req.WithQuery("sum(down)")},
+		{tenantID: "user-1", req: req.WithStartEnd(0, 7200*1000)},
+		{tenantID: "user-1", req: &PrometheusInstantQueryRequest{Path: "/query", Time: 0, Query: "sum(up)"}},
+		// Requests asking for the query statistics are never coalesced, even if identical.
+		{tenantID: "user-3", req: req, statsRequested: true},
+		{tenantID: "user-3", req: req, statsRequested: true},
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(requests))
+	for _, r := range requests {
+		r := r
+
+		go func() {
+			defer wg.Done()
+
+			ctx := user.InjectOrgID(context.Background(), r.tenantID)
+			if r.statsRequested {
+				ctx = contextWithQueryStatsRequested(ctx)
+			}
+
+			_, err := handler.Do(ctx, r.req)
+			require.NoError(t, err)
+		}()
+	}
+
+	test.Poll(t, time.Second, int64(len(requests)), func() interface{} {
+		return calls.Load()
+	})
+	close(release)
+	wg.Wait()
+}
+
+func TestQueryDeduplicationMiddleware_ShouldRetryCoalescedRequestsWhenLeaderIsCanceled(t *testing.T) {
+	var (
+		calls       = atomic.NewInt64(0)
+		leaderReady = make(chan struct{})
+		release     = make(chan struct{})
+		expected    = &PrometheusResponse{Status: statusSuccess}
+	)
+
+	downstream := HandlerFunc(func(ctx context.Context, req Request) (Response, error) {
+		// The first call is the leader, which gets canceled.
+		if calls.Inc() == 1 {
+			close(leaderReady)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		<-release
+		return expected, nil
+	})
+
+	handler := newQueryDeduplicationMiddleware(log.NewNopLogger(), nil).Wrap(downstream)
+	req := &PrometheusInstantQueryRequest{Path: "/query", Time: 0, Query: "sum(up)"}
+
+	leaderCtx, cancelLeader := context.WithCancel(user.InjectOrgID(context.Background(), "user-1"))
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := handler.Do(leaderCtx, req)
+		leaderErr <- err
+	}()
+	<-leaderReady
+
+	followerRes := make(chan Response, 1)
+	go func() {
+		res, err := handler.Do(user.InjectOrgID(context.Background(), "user-1"), req)
+		require.NoError(t, err)
+		followerRes <- res
+	}()
+
+	// Wait until the follower has been coalesced, then cancel the leader.
+	test.Poll(t, time.Second, float64(1), func() interface{} {
+		return testutil.ToFloat64(handler.(*queryDeduplicationMiddleware).coalescedRequests)
+	})
+	cancelLeader()
+	assert.Equal(t, context.Canceled, <-leaderErr)
+
+	// The follower should execute the query on its own.
+	close(release)
+	assert.Equal(t, expected, <-followerRes)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestQueryDeduplicationMiddleware_ShouldNotCancelLeaderWhenCoalescedRequestIsCanceled(t *testing.T) {
+	var (
+		leaderReady = make(chan struct{})
+		release     = make(chan struct{})
+		expected    = &PrometheusResponse{Status: statusSuccess}
+	)
+
+	downstream := HandlerFunc(func(ctx context.Context, req Request) (Response, error) {
+		close(leaderReady)
+		<-release
+		return expected, nil
+	})
+
+	handler := newQueryDeduplicationMiddleware(log.NewNopLogger(), nil).Wrap(downstream)
+	req := &PrometheusInstantQueryRequest{Path: "/query", Time: 0, Query: "sum(up)"}
+
+	leaderRes := make(chan Response, 1)
+	go func() {
+		res, err := handler.Do(user.InjectOrgID(context.Background(), "user-1"), req)
+		require.NoError(t, err)
+		leaderRes <- res
+	}()
+
+	<-leaderReady
+
+	followerCtx, cancelFollower := context.WithCancel(user.InjectOrgID(context.Background(), "user-1"))
+	followerErr := make(chan error, 1)
+	go func() {
+		_, err := handler.Do(followerCtx, req)
+		followerErr <- err
+	}()
+
+	test.Poll(t, time.Second, float64(1), func() interface{} {
+		return testutil.ToFloat64(handler.(*queryDeduplicationMiddleware).coalescedRequests)
+	})
+	cancelFollower()
+	assert.Equal(t, context.Canceled, <-followerErr)
+
+	close(release)
+	assert.Equal(t, expected, <-leaderRes)
+}
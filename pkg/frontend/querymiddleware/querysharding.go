@@ -8,6 +8,7 @@ package querymiddleware
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-kit/log"
@@ -48,6 +49,7 @@ type queryShardingMetrics struct {
 	shardingSuccesses      prometheus.Counter
 	shardedQueries         prometheus.Counter
 	shardedQueriesPerQuery prometheus.Histogram
+	blockingFunctions      *prometheus.CounterVec
 }
 
 // newQueryShardingMiddleware creates a middleware that will split queries by shard.
@@ -84,6 +86,11 @@ func newQueryShardingMiddleware(
 			Help:      "Number of sharded queries a single query has been rewritten to.",
 			Buckets:   prometheus.ExponentialBuckets(2, 2, 10),
 		}),
+		blockingFunctions: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "frontend_query_sharding_blocking_functions_total",
+			Help:      "Total number of queries the query-frontend couldn't fully shard, partitioned by the function or aggregation which prevented a part of the query from being sharded.",
+		}, []string{"function"}),
 	}
 	return MiddlewareFunc(func(next Handler) Handler {
 		return &querySharding{
@@ -111,8 +118,15 @@ func (s *querySharding) Do(ctx context.Context, r Request) (Response, error) {
 		return s.next.Do(ctx, r)
 	}
 
+	approximateQuantile := s.approximateQuantileEnabled(tenantIDs)
+
 	s.shardingAttempts.Inc()
-	shardedQuery, shardingStats, err := s.shardQuery(r.GetQuery(), totalShards)
+	shardedQuery, shardingStats, err := s.shardQuery(r.GetQuery(), totalShards, approximateQuantile)
+	if err == nil {
+		for _, name := range shardingStats.GetBlockingFunctions() {
+			s.blockingFunctions.WithLabelValues(name).Inc()
+		}
+	}
 
 	// If an error occurred while trying to rewrite the query or the query has not been sharded,
 	// then we should fallback to execute it via queriers.
@@ -120,13 +134,13 @@ func (s *querySharding) Do(ctx context.Context, r Request) (Response, error) {
 		if err != nil {
 			level.Warn(log).Log("msg", "failed to rewrite the input query into a shardable query, falling back to try executing without sharding", "query", r.GetQuery(), "err", err)
 		} else {
-			level.Debug(log).Log("msg", "query is not supported for being rewritten into a shardable query", "query", r.GetQuery())
+			level.Debug(log).Log("msg", "query is not supported for being rewritten into a shardable query", "query", r.GetQuery(), "blocking_functions", strings.Join(shardingStats.GetBlockingFunctions(), ","))
 		}
 
 		return s.next.Do(ctx, r)
 	}
 
-	level.Debug(log).Log("msg", "query has been rewritten into a shardable query", "original", r.GetQuery(), "rewritten", shardedQuery, "sharded_queries", shardingStats.GetShardedQueries())
+	level.Debug(log).Log("msg", "query has been rewritten into a shardable query", "original", r.GetQuery(), "rewritten", shardedQuery, "sharded_queries", shardingStats.GetShardedQueries(), "blocking_functions", strings.Join(shardingStats.GetBlockingFunctions(), ","))
 
 	// Update metrics.
 	s.shardingSuccesses.Inc()
@@ -156,7 +170,8 @@ func (s *querySharding) Do(ctx context.Context, r Request) (Response, error) {
 			ResultType: string(res.Value.Type()),
 			Result:     extracted,
 		},
-		Headers: shardedQueryable.getResponseHeaders(),
+		Headers:  shardedQueryable.getResponseHeaders(),
+		Warnings: promqlWarningsToStrings(res.Warnings),
 	}, nil
 }
 
@@ -222,8 +237,8 @@ func mapEngineError(err error) error {
 // shardQuery attempts to rewrite the input query in a shardable way. Returns the rewritten query
 // to be executed by PromQL engine with shardedQueryable or an empty string if the input query
 // can't be sharded.
-func (s *querySharding) shardQuery(query string, totalShards int) (string, *astmapper.MapperStats, error) {
-	mapper, err := astmapper.NewSharding(totalShards, s.logger)
+func (s *querySharding) shardQuery(query string, totalShards int, approximateQuantile bool) (string, *astmapper.MapperStats, error) {
+	mapper, err := astmapper.NewSharding(totalShards, approximateQuantile, s.logger)
 	if err != nil {
 		return "", nil, err
 	}
@@ -279,7 +294,7 @@ func (s *querySharding) getShardsForQuery(tenantIDs []string, r Request, spanLog
 		// - count(metric)
 		//
 		// Calling s.shardQuery() with 1 total shards we can see how many shardable legs the query has.
-		_, shardingStats, err := s.shardQuery(r.GetQuery(), 1)
+		_, shardingStats, err := s.shardQuery(r.GetQuery(), 1, s.approximateQuantileEnabled(tenantIDs))
 		numShardableLegs := 1
 		if err == nil && shardingStats.GetShardedQueries() > 0 {
 			numShardableLegs = shardingStats.GetShardedQueries()
@@ -337,7 +352,29 @@ func (s *querySharding) getShardsForQuery(tenantIDs []string, r Request, spanLog
 	return totalShards
 }
 
+// approximateQuantileEnabled returns whether quantile() aggregations can be sharded approximating
+// their result. It's enabled only if enabled for all the given tenants.
+func (s *querySharding) approximateQuantileEnabled(tenantIDs []string) bool {
+	for _, tenantID := range tenantIDs {
+		if !s.limit.QueryShardingApproximateQuantileEnabled(tenantID) {
+			return false
+		}
+	}
+	return len(tenantIDs) > 0
+}
+
 // promqlResultToSamples transforms a promql query result into a samplestream
+// promqlWarningsToStrings returns the unique messages of the input PromQL engine warnings.
+func promqlWarningsToStrings(warnings storage.Warnings) []string {
+	var out []string
+	for _, w := range warnings {
+		if !util.StringsContain(out, w.Error()) {
+			out = append(out, w.Error())
+		}
+	}
+	return out
+}
+
 func promqlResultToSamples(res *promql.Result) ([]SampleStream, error) {
 	if res.Err != nil {
 		return nil, res.Err
@@ -190,6 +190,30 @@ func TestQueryShardingCorrectness(t *testing.T) {
 			query:                  `max without(unique) (metric_counter{group_1="0"})`,
 			expectedShardedQueries: 1,
 		},
+		"topk()": {
+			query:                  `topk(2, metric_counter{const="fixed"})`,
+			expectedShardedQueries: 1,
+		},
+		"topk() grouping 'by'": {
+			query:                  `topk by(group_1) (2, metric_counter)`,
+			expectedShardedQueries: 1,
+		},
+		"bottomk()": {
+			query:                  `bottomk(2, metric_counter{const="fixed"})`,
+			expectedShardedQueries: 1,
+		},
+		"bottomk() grouping 'without'": {
+			query:                  `bottomk without(unique) (2, metric_counter)`,
+			expectedShardedQueries: 1,
+		},
+		"group() grouping 'by'": {
+			query:                  `group by(group_1) (metric_counter)`,
+			expectedShardedQueries: 1,
+		},
+		"count_values() grouping 'by'": {
+			query:                  `count_values by(group_1) ("value", metric_counter)`,
+			expectedShardedQueries: 1,
+		},
 		"count() no grouping": {
 			query:                  `count(metric_counter)`,
 			expectedShardedQueries: 1,
@@ -403,14 +427,6 @@ func TestQueryShardingCorrectness(t *testing.T) {
 			query:                  `stdvar(metric_counter{const="fixed"})`,
 			expectedShardedQueries: 0,
 		},
-		"topk()": {
-			query:                  `topk(2, metric_counter{const="fixed"})`,
-			expectedShardedQueries: 0,
-		},
-		"bottomk()": {
-			query:                  `bottomk(2, metric_counter{const="fixed"})`,
-			expectedShardedQueries: 0,
-		},
 		"vector()": {
 			query:                  `vector(1)`,
 			expectedShardedQueries: 0,
@@ -890,6 +906,44 @@ func TestQuerySharding_ShouldFallbackToDownstreamHandlerOnMappingFailure(t *test
 	downstream.AssertCalled(t, "Do", mock.Anything, mock.Anything)
 }
 
+func TestQuerySharding_ShouldTrackBlockingFunctions(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	shardingware := newQueryShardingMiddleware(log.NewNopLogger(), newEngine(), mockLimits{totalShards: 16}, reg)
+
+	downstream := &mockHandler{}
+	downstream.On("Do", mock.Anything, mock.Anything).Return(&PrometheusResponse{
+		Status: statusSuccess, Data: &PrometheusData{
+			ResultType: string(parser.ValueTypeVector),
+		},
+	}, nil)
+
+	for _, query := range []string{
+		`quantile(0.9, foo)`,     // Not shardable.
+		`sum(foo) / stddev(foo)`, // Partially shardable.
+		`quantile(0.9, foo) / histogram_quantile(0.9, rate(bar[1m]))`, // Not shardable.
+		`sum by (foo) (rate(bar{}[1m]))`,                              // Shardable.
+	} {
+		req := &PrometheusRangeQueryRequest{
+			Path:  "/query_range",
+			Start: util.TimeToMillis(start),
+			End:   util.TimeToMillis(end),
+			Step:  step.Milliseconds(),
+			Query: query,
+		}
+
+		_, err := shardingware.Wrap(downstream).Do(user.InjectOrgID(context.Background(), "test"), req)
+		require.NoError(t, err)
+	}
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_frontend_query_sharding_blocking_functions_total Total number of queries the query-frontend couldn't fully shard, partitioned by the function or aggregation which prevented a part of the query from being sharded.
+		# TYPE cortex_frontend_query_sharding_blocking_functions_total counter
+		cortex_frontend_query_sharding_blocking_functions_total{function="histogram_quantile"} 1
+		cortex_frontend_query_sharding_blocking_functions_total{function="quantile"} 2
+		cortex_frontend_query_sharding_blocking_functions_total{function="stddev"} 1
+	`), "cortex_frontend_query_sharding_blocking_functions_total"))
+}
+
 func TestQuerySharding_ShouldSkipShardingViaOption(t *testing.T) {
 	req := &PrometheusRangeQueryRequest{
 		Path:  "/query_range",
@@ -39,12 +39,13 @@ const (
 )
 
 var (
-	supportedResultsCacheBackends = []string{cache.BackendMemcached}
+	supportedResultsCacheBackends = []string{cache.BackendMemcached, cache.BackendRedis}
 )
 
 // ResultsCacheConfig is the config for the results cache.
 type ResultsCacheConfig struct {
 	cache.BackendConfig `yaml:",inline"`
+	LocalCache          cache.LocalCacheConfig  `yaml:"local_cache"`
 	Compression         cache.CompressionConfig `yaml:",inline"`
 }
 
@@ -52,6 +53,8 @@ type ResultsCacheConfig struct {
 func (cfg *ResultsCacheConfig) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&cfg.Backend, "query-frontend.results-cache.backend", "", fmt.Sprintf("Backend for query-frontend results cache, if not empty. Supported values: %s.", supportedResultsCacheBackends))
 	cfg.Memcached.RegisterFlagsWithPrefix(f, "query-frontend.results-cache.memcached.")
+	cfg.Redis.RegisterFlagsWithPrefix(f, "query-frontend.results-cache.redis.")
+	cfg.LocalCache.RegisterFlagsWithPrefix(f, "query-frontend.results-cache.local-cache.")
 	cfg.Compression.RegisterFlagsWithPrefix(f, "query-frontend.results-cache.")
 }
 
@@ -60,12 +63,16 @@ func (cfg *ResultsCacheConfig) Validate() error {
 		return errUnsupportedResultsCacheBackend(cfg.Backend)
 	}
 
-	if cfg.Backend == cache.BackendMemcached {
-		if err := cfg.Memcached.Validate(); err != nil {
+	if cfg.Backend != "" {
+		if err := cfg.BackendConfig.Validate(); err != nil {
 			return errors.Wrap(err, "query-frontend results cache")
 		}
 	}
 
+	if err := cfg.LocalCache.Validate(); err != nil {
+		return errors.Wrap(err, "query-frontend results cache")
+	}
+
 	if err := cfg.Compression.Validate(); err != nil {
 		return errors.Wrap(err, "query-frontend results cache")
 	}
@@ -77,8 +84,8 @@ func errUnsupportedResultsCacheBackend(unsupportedBackend string) error {
 	return fmt.Errorf("unsupported cache backend: %q, supported values: %v", unsupportedBackend, supportedResultsCacheBackends)
 }
 
-// newResultsCache creates a new results cache based on the input configuration.
-func newResultsCache(cfg ResultsCacheConfig, logger log.Logger, reg prometheus.Registerer) (cache.Cache, error) {
+// NewResultsCache creates a new results cache based on the input configuration.
+func NewResultsCache(cfg ResultsCacheConfig, logger log.Logger, reg prometheus.Registerer) (cache.Cache, error) {
 	client, err := cache.CreateClient("frontend-cache", cfg.BackendConfig, logger, reg)
 	if err != nil {
 		return nil, err
@@ -86,7 +93,12 @@ func newResultsCache(cfg ResultsCacheConfig, logger log.Logger, reg prometheus.R
 		return nil, errUnsupportedResultsCacheBackend(cfg.Backend)
 	}
 
-	return cache.NewSpanlessTracingCache(client, logger), nil
+	client, err = cache.WrapWithLocalCache(client, "frontend-cache", cfg.LocalCache, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.NewCompression(cfg.Compression, cache.NewSpanlessTracingCache(client, logger), logger), nil
 }
 
 // Extractor is used by the cache to extract a subset of a response from a cache entry.
@@ -192,6 +204,12 @@ func isResponseCachable(r Response, logger log.Logger) bool {
 		}
 	}
 
+	// Responses with warnings may be partial results, which must not be cached.
+	if len(r.GetWarnings()) > 0 {
+		level.Debug(logger).Log("msg", "response has warnings, not caching the response")
+		return false
+	}
+
 	return true
 }
 
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/grafana/dskit/runutil"
+	"github.com/grafana/dskit/tenant"
+
+	"github.com/grafana/mimir/pkg/util"
+	util_log "github.com/grafana/mimir/pkg/util/log"
+)
+
+const (
+	// ResultsCacheGenerationFilename is the name of the per-tenant object storing the results cache generation.
+	ResultsCacheGenerationFilename = "results-cache-generation.json"
+
+	// resultsCacheGenerationsTTL is how long a results cache generation read from the storage is cached
+	// locally. A generation bumped through another query-frontend replica is seen within this period.
+	resultsCacheGenerationsTTL = 10 * time.Second
+)
+
+// ResultsCacheGenerations keeps track of the per-tenant results cache generations. The generation
+// of a tenant is mixed into the cache keys of its cached results, so that bumping it invalidates all
+// the results cached so far for the tenant.
+//
+// Generations are stored in the object storage, so that they're seen by all query-frontend replicas
+// and never evicted like the cached results, and they're cached locally for a short period of time
+// to not read them from the storage on each request.
+type ResultsCacheGenerations struct {
+	bkt objstore.Bucket
+
+	mtx    sync.Mutex
+	cached map[string]cachedResultsCacheGeneration
+}
+
+type cachedResultsCacheGeneration struct {
+	generation int64
+	expiresAt  time.Time
+}
+
+// resultsCacheGeneration is the content of the object storing a tenant's results cache generation.
+type resultsCacheGeneration struct {
+	Generation int64 `json:"generation"`
+}
+
+// NewResultsCacheGenerations makes a new ResultsCacheGenerations storing the generations in the input bucket.
+func NewResultsCacheGenerations(bkt objstore.Bucket) *ResultsCacheGenerations {
+	return &ResultsCacheGenerations{
+		bkt:    bkt,
+		cached: map[string]cachedResultsCacheGeneration{},
+	}
+}
+
+// get returns the results cache generation for the input tenants, or an empty string if the
+// results cache has never been invalidated for any of them. If the generations are not tracked,
+// an empty string is always returned.
+func (g *ResultsCacheGenerations) get(ctx context.Context, tenantIDs []string, now time.Time) (string, error) {
+	if g == nil {
+		return "", nil
+	}
+
+	found := false
+	generations := make([]string, 0, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		generation, err := g.getTenant(ctx, tenantID, now)
+		if err != nil {
+			return "", err
+		}
+
+		if generation == 0 {
+			generations = append(generations, "")
+			continue
+		}
+		generations = append(generations, strconv.FormatInt(generation, 10))
+		found = true
+	}
+
+	if !found {
+		return "", nil
+	}
+	return strings.Join(generations, ","), nil
+}
+
+// getTenant returns the results cache generation of the input tenant, or 0 if the results cache has
+// never been invalidated for it.
+func (g *ResultsCacheGenerations) getTenant(ctx context.Context, tenantID string, now time.Time) (int64, error) {
+	g.mtx.Lock()
+	cached, ok := g.cached[tenantID]
+	g.mtx.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.generation, nil
+	}
+
+	generation, err := g.read(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	g.mtx.Lock()
+	g.cached[tenantID] = cachedResultsCacheGeneration{generation: generation, expiresAt: now.Add(resultsCacheGenerationsTTL)}
+	g.mtx.Unlock()
+
+	return generation, nil
+}
+
+func (g *ResultsCacheGenerations) read(ctx context.Context, tenantID string) (_ int64, returnErr error) {
+	reader, err := g.bkt.Get(ctx, resultsCacheGenerationPath(tenantID))
+	if g.bkt.IsObjNotFoundErr(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "read results cache generation")
+	}
+	defer runutil.CloseWithErrCapture(&returnErr, reader, "close results cache generation reader")
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return 0, errors.Wrap(err, "read results cache generation")
+	}
+
+	generation := resultsCacheGeneration{}
+	if err := json.Unmarshal(content, &generation); err != nil {
+		return 0, errors.Wrap(err, "decode results cache generation")
+	}
+	return generation.Generation, nil
+}
+
+// bump sets a new results cache generation for the input tenant and returns it. The generation
+// is the current time in milliseconds, so that concurrent invalidations issued through different
+// query-frontend replicas don't need any coordination.
+func (g *ResultsCacheGenerations) bump(ctx context.Context, tenantID string, now time.Time) (int64, error) {
+	generation := util.TimeToMillis(now)
+
+	content, err := json.Marshal(resultsCacheGeneration{Generation: generation})
+	if err != nil {
+		return 0, err
+	}
+	if err := g.bkt.Upload(ctx, resultsCacheGenerationPath(tenantID), bytes.NewReader(content)); err != nil {
+		return 0, errors.Wrap(err, "upload results cache generation")
+	}
+
+	// The generation is seen by this replica right away, and by the others once their cached one expires.
+	g.mtx.Lock()
+	g.cached[tenantID] = cachedResultsCacheGeneration{generation: generation, expiresAt: now.Add(resultsCacheGenerationsTTL)}
+	g.mtx.Unlock()
+
+	return generation, nil
+}
+
+func resultsCacheGenerationPath(tenantID string) string {
+	return path.Join(tenantID, ResultsCacheGenerationFilename)
+}
+
+// cacheKeyWithGeneration returns the input cache key mixed with the results cache generation.
+// Keys are left untouched if the results cache has never been invalidated, to keep using the
+// results cached so far.
+func cacheKeyWithGeneration(key, generation string) string {
+	if generation == "" {
+		return key
+	}
+	return generation + ":" + key
+}
+
+type resultsCacheInvalidationHandler struct {
+	generations *ResultsCacheGenerations
+	logger      log.Logger
+}
+
+// NewResultsCacheInvalidationHandler returns an HTTP handler invalidating all the results cached
+// for the authenticated tenant, by bumping its results cache generation.
+func NewResultsCacheInvalidationHandler(generations *ResultsCacheGenerations, logger log.Logger) http.Handler {
+	return &resultsCacheInvalidationHandler{
+		generations: generations,
+		logger:      logger,
+	}
+}
+
+func (h *resultsCacheInvalidationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := util_log.WithContext(r.Context(), h.logger)
+
+	userID, err := tenant.TenantID(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	generation, err := h.generations.bump(r.Context(), userID, time.Now())
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to invalidate tenant results cache", "user", userID, "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	level.Info(logger).Log("msg", "invalidated tenant results cache", "user", userID, "generation", generation)
+
+	util.WriteJSONResponse(w, resultsCacheInvalidationResponse{Generation: generation})
+}
+
+type resultsCacheInvalidationResponse struct {
+	Generation int64 `json:"generation"`
+}
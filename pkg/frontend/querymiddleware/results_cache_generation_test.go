@@ -0,0 +1,319 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/cache"
+)
+
+func TestResultsCacheGenerations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	generations := NewResultsCacheGenerations(objstore.NewInMemBucket())
+
+	getGeneration := func(tenantIDs []string, now time.Time) string {
+		generation, err := generations.get(ctx, tenantIDs, now)
+		require.NoError(t, err)
+		return generation
+	}
+
+	// No generation if the results cache has never been invalidated.
+	assert.Equal(t, "", getGeneration([]string{"user-1"}, now))
+	assert.Equal(t, "", getGeneration([]string{"user-1", "user-2"}, now))
+
+	generation, err := generations.bump(ctx, "user-1", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000000), generation)
+	assert.Equal(t, "1000000", getGeneration([]string{"user-1"}, now))
+	assert.Equal(t, "", getGeneration([]string{"user-2"}, now))
+	assert.Equal(t, "1000000,", getGeneration([]string{"user-1", "user-2"}, now))
+
+	now = now.Add(1000 * time.Second)
+	generation, err = generations.bump(ctx, "user-2", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000000), generation)
+	assert.Equal(t, "1000000,2000000", getGeneration([]string{"user-1", "user-2"}, now))
+
+	// A nil ResultsCacheGenerations never returns a generation.
+	var disabled *ResultsCacheGenerations
+	generationStr, err := disabled.get(ctx, []string{"user-1"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "", generationStr)
+}
+
+func TestResultsCacheGenerations_ShouldSeeGenerationsBumpedByOtherReplicasAfterTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	bkt := objstore.NewInMemBucket()
+
+	first := NewResultsCacheGenerations(bkt)
+	second := NewResultsCacheGenerations(bkt)
+
+	// Cache the (missing) generation in the second replica.
+	generation, err := second.get(ctx, []string{"user-1"}, now)
+	require.NoError(t, err)
+	require.Equal(t, "", generation)
+
+	_, err = first.bump(ctx, "user-1", now)
+	require.NoError(t, err)
+
+	// The second replica sees the new generation only once its locally cached one expires.
+	generation, err = second.get(ctx, []string{"user-1"}, now.Add(resultsCacheGenerationsTTL-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "", generation)
+
+	generation, err = second.get(ctx, []string{"user-1"}, now.Add(resultsCacheGenerationsTTL))
+	require.NoError(t, err)
+	assert.Equal(t, "1000000", generation)
+}
+
+func TestResultsCacheGenerations_ShouldReturnErrorOnStorageFailure(t *testing.T) {
+	bkt := &failingGenerationsBucket{Bucket: objstore.NewInMemBucket(), getErr: errors.New("get failure")}
+	generations := NewResultsCacheGenerations(bkt)
+
+	_, err := generations.get(context.Background(), []string{"user-1"}, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "get failure")
+}
+
+func TestResultsCacheInvalidationHandler(t *testing.T) {
+	bkt := &failingGenerationsBucket{Bucket: objstore.NewInMemBucket()}
+	handler := NewResultsCacheInvalidationHandler(NewResultsCacheGenerations(bkt), log.NewNopLogger())
+
+	t.Run("should fail if the tenant is missing", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest("POST", "/query-frontend/invalidate_results_cache", nil))
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("should bump the tenant's results cache generation", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/query-frontend/invalidate_results_cache", nil)
+		req = req.WithContext(user.InjectOrgID(req.Context(), "user-1"))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		res := resultsCacheInvalidationResponse{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		assert.Greater(t, res.Generation, int64(0))
+
+		// The generation is stored in the bucket, and seen by other replicas.
+		generations := NewResultsCacheGenerations(bkt)
+		generation, err := generations.get(context.Background(), []string{"user-1"}, time.Now())
+		require.NoError(t, err)
+		assert.NotEmpty(t, generation)
+
+		generation, err = generations.get(context.Background(), []string{"user-2"}, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, generation)
+	})
+
+	t.Run("should fail if the generation can't be stored", func(t *testing.T) {
+		bkt.uploadErr = errors.New("upload failure")
+		defer func() { bkt.uploadErr = nil }()
+
+		req := httptest.NewRequest("POST", "/query-frontend/invalidate_results_cache", nil)
+		req = req.WithContext(user.InjectOrgID(req.Context(), "user-1"))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestSplitAndCacheMiddleware_ResultsCache_ShouldNotReuseCachedResultsAfterInvalidation(t *testing.T) {
+	tests := map[string]struct {
+		// evictCache simulates the eviction of all the items stored in the results cache.
+		evictCache bool
+	}{
+		"results cache items not evicted": {
+			evictCache: false,
+		},
+		"results cache items evicted": {
+			evictCache: true,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			cacheBackend := cache.NewMockCache()
+			generations := NewResultsCacheGenerations(objstore.NewInMemBucket())
+
+			mw := newSplitAndCacheMiddleware(
+				true,
+				true,
+				24*time.Hour,
+				false,
+				mockLimits{maxCacheFreshness: 10 * time.Minute},
+				PrometheusCodec,
+				cacheBackend,
+				generations,
+				constSplitter(day),
+				PrometheusResponseExtractor{},
+				resultsCacheAlwaysEnabled,
+				log.NewNopLogger(),
+				prometheus.NewPedanticRegistry(),
+			)
+
+			downstreamReqs := 0
+			rc := mw.Wrap(HandlerFunc(func(_ context.Context, req Request) (Response, error) {
+				downstreamReqs++
+				return &PrometheusResponse{Status: "success", Data: &PrometheusData{ResultType: model.ValMatrix.String()}}, nil
+			}))
+
+			req := Request(&PrometheusRangeQueryRequest{
+				Path:  "/api/v1/query_range",
+				Start: parseTimeRFC3339(t, "2021-10-15T10:00:00Z").Unix() * 1000,
+				End:   parseTimeRFC3339(t, "2021-10-15T12:00:00Z").Unix() * 1000,
+				Step:  120 * 1000,
+				Query: `{__name__=~".+"}`,
+			})
+
+			ctx := user.InjectOrgID(context.Background(), "user-1")
+			otherCtx := user.InjectOrgID(context.Background(), "user-2")
+
+			for _, c := range []context.Context{ctx, otherCtx} {
+				_, err := rc.Do(c, req)
+				require.NoError(t, err)
+			}
+			require.Equal(t, 2, downstreamReqs)
+
+			// The results are cached.
+			_, err := rc.Do(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, 2, downstreamReqs)
+
+			// Invalidate the results cache of user-1.
+			_, err = generations.bump(context.Background(), "user-1", time.Now())
+			require.NoError(t, err)
+
+			// The generation is not stored in the results cache, so evicting all the cached
+			// items doesn't bring back the results cached before the invalidation.
+			expectedDownstreamReqs := 3
+			if testData.evictCache {
+				cacheBackend.Flush()
+				expectedDownstreamReqs = 4
+			}
+
+			_, err = rc.Do(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, 3, downstreamReqs)
+
+			// The results cached for user-2 are still used, unless evicted.
+			_, err = rc.Do(otherCtx, req)
+			require.NoError(t, err)
+			require.Equal(t, expectedDownstreamReqs, downstreamReqs)
+
+			// The results cached with the new generation are used.
+			_, err = rc.Do(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, expectedDownstreamReqs, downstreamReqs)
+
+			// The results cached before the invalidation are still not used once evicted again.
+			if testData.evictCache {
+				cacheBackend.Flush()
+				_, err = rc.Do(ctx, req)
+				require.NoError(t, err)
+				require.Equal(t, expectedDownstreamReqs+1, downstreamReqs)
+			}
+		})
+	}
+}
+
+func TestSplitAndCacheMiddleware_ResultsCache_ShouldSkipCacheIfGenerationCantBeRead(t *testing.T) {
+	cacheBackend := cache.NewMockCache()
+	bkt := &failingGenerationsBucket{Bucket: objstore.NewInMemBucket()}
+	reg := prometheus.NewPedanticRegistry()
+
+	mw := newSplitAndCacheMiddleware(
+		true,
+		true,
+		24*time.Hour,
+		false,
+		mockLimits{maxCacheFreshness: 10 * time.Minute},
+		PrometheusCodec,
+		cacheBackend,
+		NewResultsCacheGenerations(bkt),
+		constSplitter(day),
+		PrometheusResponseExtractor{},
+		resultsCacheAlwaysEnabled,
+		log.NewNopLogger(),
+		reg,
+	)
+
+	downstreamReqs := 0
+	rc := mw.Wrap(HandlerFunc(func(_ context.Context, req Request) (Response, error) {
+		downstreamReqs++
+		return &PrometheusResponse{Status: "success", Data: &PrometheusData{ResultType: model.ValMatrix.String()}}, nil
+	}))
+
+	req := Request(&PrometheusRangeQueryRequest{
+		Path:  "/api/v1/query_range",
+		Start: parseTimeRFC3339(t, "2021-10-15T10:00:00Z").Unix() * 1000,
+		End:   parseTimeRFC3339(t, "2021-10-15T12:00:00Z").Unix() * 1000,
+		Step:  120 * 1000,
+		Query: `{__name__=~".+"}`,
+	})
+
+	// The generation can't be read, so the results cache is skipped but the query still succeeds.
+	bkt.getErr = errors.New("get failure")
+	ctx := user.InjectOrgID(context.Background(), "user-1")
+	for i := 0; i < 2; i++ {
+		_, err := rc.Do(ctx, req)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, downstreamReqs)
+
+	// The failures are tracked.
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_frontend_results_cache_generation_read_failures_total Total number of failures reading the results cache generation, causing the results cache to be skipped.
+		# TYPE cortex_frontend_results_cache_generation_read_failures_total counter
+		cortex_frontend_results_cache_generation_read_failures_total 2
+	`), "cortex_frontend_results_cache_generation_read_failures_total"))
+
+	// Nothing has been cached meanwhile, and the results cache is used again once the generation can be read.
+	bkt.getErr = nil
+	for i := 0; i < 2; i++ {
+		_, err := rc.Do(ctx, req)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, downstreamReqs)
+}
+
+// failingGenerationsBucket is a bucket whose Get and Upload operations can be configured to fail.
+type failingGenerationsBucket struct {
+	objstore.Bucket
+	getErr    error
+	uploadErr error
+}
+
+func (b *failingGenerationsBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if b.getErr != nil {
+		return nil, b.getErr
+	}
+	return b.Bucket.Get(ctx, name)
+}
+
+func (b *failingGenerationsBucket) Upload(ctx context.Context, name string, r io.Reader) error {
+	if b.uploadErr != nil {
+		return b.uploadErr
+	}
+	return b.Bucket.Upload(ctx, name, r)
+}
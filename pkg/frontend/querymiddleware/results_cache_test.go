@@ -292,6 +292,13 @@ func TestIsResponseCachable(t *testing.T) {
 			}),
 			expected: false,
 		},
+		{
+			name: "has warnings",
+			response: Response(&PrometheusResponse{
+				Warnings: []string{"partial results: some blocks could not be queried"},
+			}),
+			expected: false,
+		},
 		{
 			name:     "broken response",
 			response: Response(&PrometheusResponse{}),
@@ -8,6 +8,7 @@ package querymiddleware
 import (
 	"context"
 	"flag"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -23,6 +24,7 @@ import (
 	"github.com/grafana/dskit/tenant"
 
 	"github.com/grafana/mimir/pkg/cache"
+	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/util"
 )
 
@@ -41,6 +43,12 @@ type Config struct {
 	MaxRetries             int  `yaml:"max_retries" category:"advanced"`
 	ShardedQueries         bool `yaml:"parallelize_shardable_queries"`
 	CacheUnalignedRequests bool `yaml:"cache_unaligned_requests" category:"advanced"`
+
+	QueryResultResponseFormat  string `yaml:"query_result_response_format" category:"experimental"`
+	DeduplicateInflightQueries bool   `yaml:"deduplicate_inflight_queries" category:"experimental"`
+
+	ResultsCacheInvalidationEnabled bool          `yaml:"results_cache_invalidation_enabled" category:"experimental"`
+	ResultsCacheGenerationsStorage  bucket.Config `yaml:"results_cache_generations_storage" category:"experimental"`
 }
 
 // RegisterFlags adds the flags required to config this to the given FlagSet.
@@ -51,7 +59,11 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.BoolVar(&cfg.CacheResults, "query-frontend.cache-results", false, "Cache query results.")
 	f.BoolVar(&cfg.ShardedQueries, "query-frontend.parallelize-shardable-queries", false, "True to enable query sharding.")
 	f.BoolVar(&cfg.CacheUnalignedRequests, "query-frontend.cache-unaligned-requests", false, "Cache requests that are not step-aligned.")
+	f.StringVar(&cfg.QueryResultResponseFormat, "query-frontend.query-result-response-format", formatJSON, fmt.Sprintf("Format to use when retrieving query results from queriers. Supported values: %s. Responses to clients are always encoded as JSON.", strings.Join(allFormats, ", ")))
+	f.BoolVar(&cfg.DeduplicateInflightQueries, "query-frontend.deduplicate-inflight-queries", false, "True to coalesce concurrent identical queries, issued by the same tenants, into a single execution whose response is shared by all of them.")
+	f.BoolVar(&cfg.ResultsCacheInvalidationEnabled, "query-frontend.results-cache-invalidation-enabled", false, "True to enable the endpoint invalidating the results cache of a tenant. The results cache generations are stored in the results cache generations storage, which must be shared by all query-frontends. Requires the results cache to be enabled.")
 	cfg.ResultsCacheConfig.RegisterFlags(f)
+	cfg.ResultsCacheGenerationsStorage.RegisterFlagsWithPrefixAndDefaultDirectory("query-frontend.results-cache-generations-storage.", "results-cache-generations", f)
 }
 
 // Validate validates the config.
@@ -64,6 +76,20 @@ func (cfg *Config) Validate() error {
 			return errors.Wrap(err, "invalid ResultsCache config")
 		}
 	}
+
+	if cfg.ResultsCacheInvalidationEnabled {
+		if !cfg.CacheResults {
+			return errors.New("-query-frontend.results-cache-invalidation-enabled may only be enabled in conjunction with -query-frontend.cache-results. Please set the latter")
+		}
+		if err := cfg.ResultsCacheGenerationsStorage.Validate(); err != nil {
+			return errors.Wrap(err, "invalid results cache generations storage config")
+		}
+	}
+
+	if !util.StringsContain(allFormats, cfg.QueryResultResponseFormat) {
+		return errors.Errorf("unknown query result response format '%s'. Supported values: %s", cfg.QueryResultResponseFormat, strings.Join(allFormats, ", "))
+	}
+
 	return nil
 }
 
@@ -127,16 +153,20 @@ func (f RoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
 }
 
 // NewTripperware returns a Tripperware configured with middlewares to limit, align, split, retry and cache requests.
+// The results cache is required if results caching is enabled, and it's ignored otherwise. The results
+// cache generations are optional: if nil, the results cache invalidation is disabled.
 func NewTripperware(
 	cfg Config,
 	log log.Logger,
 	limits Limits,
 	codec Codec,
+	resultsCache cache.Cache,
+	generations *ResultsCacheGenerations,
 	cacheExtractor Extractor,
 	engineOpts promql.EngineOpts,
 	registerer prometheus.Registerer,
 ) (Tripperware, error) {
-	queryRangeTripperware, err := newQueryTripperware(cfg, log, limits, codec, cacheExtractor, engineOpts, registerer)
+	queryRangeTripperware, err := newQueryTripperware(cfg, log, limits, codec, resultsCache, generations, cacheExtractor, engineOpts, registerer)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +181,8 @@ func newQueryTripperware(
 	log log.Logger,
 	limits Limits,
 	codec Codec,
+	resultsCache cache.Cache,
+	generations *ResultsCacheGenerations,
 	cacheExtractor Extractor,
 	engineOpts promql.EngineOpts,
 	registerer prometheus.Registerer,
@@ -158,11 +190,25 @@ func newQueryTripperware(
 	// Metric used to keep track of each middleware execution duration.
 	metrics := newInstrumentMiddlewareMetrics(registerer)
 
+	// Reject blocked queries before enforcing any other limit, so that they never get queued.
+	queryBlockerMiddleware := newQueryBlockerMiddleware(limits, log, registerer)
+
 	queryRangeMiddleware := []Middleware{
 		// Track query range statistics. Added first before any subsequent middleware modifies the request.
 		newQueryStatsMiddleware(registerer),
+		queryBlockerMiddleware,
 		newLimitsMiddleware(limits, log),
 	}
+	queryInstantMiddleware := []Middleware{queryBlockerMiddleware, newLimitsMiddleware(limits, log)}
+
+	// Inject the middleware to deduplicate in-flight queries. It's added right after the limits
+	// middleware, so that the whole execution of the query is shared by the coalesced requests.
+	if cfg.DeduplicateInflightQueries {
+		queryDeduplicationMiddleware := newQueryDeduplicationMiddleware(log, registerer)
+		queryRangeMiddleware = append(queryRangeMiddleware, newInstrumentMiddleware("query_deduplication", metrics, log), queryDeduplicationMiddleware)
+		queryInstantMiddleware = append(queryInstantMiddleware, newInstrumentMiddleware("query_deduplication", metrics, log), queryDeduplicationMiddleware)
+	}
+
 	if cfg.AlignQueriesWithStep {
 		queryRangeMiddleware = append(queryRangeMiddleware, newInstrumentMiddleware("step_align", metrics, log), newStepAlignMiddleware())
 	}
@@ -171,15 +217,11 @@ func newQueryTripperware(
 	if cfg.SplitQueriesByInterval > 0 || cfg.CacheResults {
 		var c cache.Cache
 
-		// Init the cache client.
 		if cfg.CacheResults {
-			var err error
-
-			c, err = newResultsCache(cfg.ResultsCacheConfig, log, registerer)
-			if err != nil {
-				return nil, err
+			if resultsCache == nil {
+				return nil, errors.New("the results cache is required when results caching is enabled")
 			}
-			c = cache.NewCompression(cfg.ResultsCacheConfig.Compression, c, log)
+			c = resultsCache
 		}
 
 		shouldCache := func(r Request) bool {
@@ -194,6 +236,7 @@ func newQueryTripperware(
 			limits,
 			codec,
 			c,
+			generations,
 			constSplitter(cfg.SplitQueriesByInterval),
 			cacheExtractor,
 			shouldCache,
@@ -201,14 +244,23 @@ func newQueryTripperware(
 			registerer,
 		))
 	}
-	queryInstantMiddleware := []Middleware{newLimitsMiddleware(limits, log)}
+
+	// Disable concurrency limits for sharded and split queries.
+	engineOpts.ActiveQueryTracker = nil
+	engine := promql.NewEngine(engineOpts)
+
+	// Inject the middleware to split instant queries by interval. It's added before query sharding,
+	// so that each split partial query can be sharded too. Splitting is enabled per-tenant.
+	queryInstantMiddleware = append(
+		queryInstantMiddleware,
+		newInstrumentMiddleware("split_instant_query_by_interval", metrics, log),
+		newSplitInstantQueryByIntervalMiddleware(limits, log, engine, registerer),
+	)
 
 	if cfg.ShardedQueries {
-		// Disable concurrency limits for sharded queries.
-		engineOpts.ActiveQueryTracker = nil
 		queryshardingMiddleware := newQueryShardingMiddleware(
 			log,
-			promql.NewEngine(engineOpts),
+			engine,
 			limits,
 			registerer,
 		)
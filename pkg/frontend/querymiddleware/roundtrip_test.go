@@ -68,6 +68,8 @@ func TestRangeTripperware(t *testing.T) {
 		mockLimits{},
 		PrometheusCodec,
 		nil,
+		nil,
+		nil,
 		promql.EngineOpts{
 			Logger:     log.NewNopLogger(),
 			Reg:        nil,
@@ -118,6 +120,8 @@ func TestInstantTripperware(t *testing.T) {
 		mockLimits{totalShards: totalShards},
 		PrometheusCodec,
 		nil,
+		nil,
+		nil,
 		promql.EngineOpts{
 			Logger:     log.NewNopLogger(),
 			Reg:        nil,
@@ -223,6 +227,8 @@ func TestTripperware_Metrics(t *testing.T) {
 				mockLimits{},
 				PrometheusCodec,
 				nil,
+				nil,
+				nil,
 				promql.EngineOpts{
 					Logger:     log.NewNopLogger(),
 					Reg:        nil,
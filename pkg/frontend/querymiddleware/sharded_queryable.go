@@ -107,6 +107,7 @@ func (q *shardedQuerier) Select(_ bool, hints *storage.SelectHints, matchers ...
 // The returned storage.SeriesSet contains sorted series.
 func (q *shardedQuerier) handleEmbeddedQueries(queries []string, hints *storage.SelectHints) storage.SeriesSet {
 	streams := make([][]SampleStream, len(queries))
+	warnings := make([][]string, len(queries))
 
 	// Concurrently run each query. It breaks and cancels each worker context on first error.
 	err := concurrency.ForEachJob(q.ctx, len(queries), len(queries), func(ctx context.Context, idx int) error {
@@ -120,6 +121,7 @@ func (q *shardedQuerier) handleEmbeddedQueries(queries []string, hints *storage.
 			return err
 		}
 		streams[idx] = resStreams // No mutex is needed since each job writes its own index. This is like writing separate variables.
+		warnings[idx] = resp.GetWarnings()
 
 		q.responseHeaders.mergeHeaders(resp.(*PrometheusResponse).Headers)
 		return nil
@@ -129,7 +131,20 @@ func (q *shardedQuerier) handleEmbeddedQueries(queries []string, hints *storage.
 		return storage.ErrSeriesSet(err)
 	}
 
-	return newSeriesSetFromEmbeddedQueriesResults(streams, hints)
+	set := newSeriesSetFromEmbeddedQueriesResults(streams, hints)
+
+	// Propagate the warnings (eg. partial results) received by the embedded queries.
+	var setWarnings storage.Warnings
+	for _, queryWarnings := range warnings {
+		for _, w := range queryWarnings {
+			setWarnings = append(setWarnings, errors.New(w))
+		}
+	}
+	if len(setWarnings) > 0 {
+		return series.NewSeriesSetWithWarnings(set, setWarnings)
+	}
+
+	return set
 }
 
 // LabelValues implements storage.LabelQuerier.
@@ -247,6 +247,35 @@ func TestShardedQuerier_Select_ShouldConcurrentlyRunEmbeddedQueries(t *testing.T
 	require.Equal(t, len(embeddedQueries), actualSeries)
 }
 
+func TestShardedQuerier_Select_ShouldPropagateWarnings(t *testing.T) {
+	querier := mkShardedQuerier(HandlerFunc(func(_ context.Context, req Request) (Response, error) {
+		return &PrometheusResponse{
+			Status: statusSuccess,
+			Data: &PrometheusData{
+				ResultType: string(parser.ValueTypeMatrix),
+				Result:     []SampleStream{},
+			},
+			Warnings: []string{"warning from " + req.GetQuery()},
+		}, nil
+	}))
+
+	encoded, err := astmapper.JSONCodec.Encode([]string{"query-1", "query-2"})
+	require.NoError(t, err)
+
+	set := querier.Select(false, nil,
+		labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, astmapper.EmbeddedQueriesMetricName),
+		labels.MustNewMatcher(labels.MatchEqual, astmapper.EmbeddedQueriesLabelName, encoded),
+	)
+	require.NoError(t, set.Err())
+	assert.False(t, set.Next())
+
+	var warnings []string
+	for _, w := range set.Warnings() {
+		warnings = append(warnings, w.Error())
+	}
+	assert.ElementsMatch(t, []string{"warning from query-1", "warning from query-2"}, warnings)
+}
+
 func TestShardedQueryable_GetResponseHeaders(t *testing.T) {
 	queryable := newShardedQueryable(&PrometheusRangeQueryRequest{}, nil)
 	assert.Empty(t, queryable.getResponseHeaders())
@@ -28,6 +28,8 @@ import (
 
 	apierror "github.com/grafana/mimir/pkg/api/error"
 	"github.com/grafana/mimir/pkg/cache"
+	"github.com/grafana/mimir/pkg/querier/stats"
+	util_log "github.com/grafana/mimir/pkg/util/log"
 	"github.com/grafana/mimir/pkg/util/spanlogger"
 	"github.com/grafana/mimir/pkg/util/validation"
 )
@@ -44,7 +46,8 @@ var (
 )
 
 type splitAndCacheMiddlewareMetrics struct {
-	splitQueriesCount prometheus.Counter
+	splitQueriesCount      prometheus.Counter
+	generationReadFailures prometheus.Counter
 }
 
 func newSplitAndCacheMiddlewareMetrics(reg prometheus.Registerer) *splitAndCacheMiddlewareMetrics {
@@ -53,6 +56,10 @@ func newSplitAndCacheMiddlewareMetrics(reg prometheus.Registerer) *splitAndCache
 			Name: "cortex_frontend_split_queries_total",
 			Help: "Total number of underlying query requests after the split by interval is applied",
 		}),
+		generationReadFailures: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_frontend_results_cache_generation_read_failures_total",
+			Help: "Total number of failures reading the results cache generation, causing the results cache to be skipped.",
+		}),
 	}
 }
 
@@ -76,6 +83,7 @@ type splitAndCacheMiddleware struct {
 	splitter               CacheSplitter
 	extractor              Extractor
 	shouldCacheReq         shouldCacheFn
+	generations            *ResultsCacheGenerations
 }
 
 // newSplitAndCacheMiddleware makes a new splitAndCacheMiddleware.
@@ -87,6 +95,7 @@ func newSplitAndCacheMiddleware(
 	limits Limits,
 	merger Merger,
 	cache cache.Cache,
+	generations *ResultsCacheGenerations,
 	splitter CacheSplitter,
 	extractor Extractor,
 	shouldCacheReq shouldCacheFn,
@@ -108,6 +117,7 @@ func newSplitAndCacheMiddleware(
 			splitter:               splitter,
 			extractor:              extractor,
 			shouldCacheReq:         shouldCacheReq,
+			generations:            generations,
 			logger:                 logger,
 		}
 	})
@@ -121,7 +131,7 @@ func (s *splitAndCacheMiddleware) Do(ctx context.Context, req Request) (Response
 
 	// Split the input requests by the configured interval (eg. day).
 	// Returns the input request if splitting is disabled.
-	splitReqs, err := s.splitRequestByInterval(req)
+	splitReqs, err := s.splitRequestByInterval(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -130,6 +140,18 @@ func (s *splitAndCacheMiddleware) Do(ctx context.Context, req Request) (Response
 	maxCacheFreshness := validation.MaxDurationPerTenant(tenantIDs, s.limits.MaxCacheFreshness)
 	maxCacheTime := int64(model.Now().Add(-maxCacheFreshness))
 
+	// The results cache generation is mixed into the cache keys, so that the tenant's results
+	// cache can be invalidated by bumping it. If it can't be read, the results cached so far may
+	// be stale, so the results cache is not used for this request.
+	generation := ""
+	if isCacheEnabled {
+		if generation, err = s.generations.get(ctx, tenantIDs, time.Now()); err != nil {
+			level.Warn(util_log.WithContext(ctx, s.logger)).Log("msg", "failed to read the results cache generation, skipping the results cache", "err", err)
+			s.metrics.generationReadFailures.Inc()
+			isCacheEnabled = false
+		}
+	}
+
 	// Lookup the results cache.
 	if isCacheEnabled {
 		// Build the cache keys for all requests to try to fetch from cache.
@@ -143,13 +165,14 @@ func (s *splitAndCacheMiddleware) Do(ctx context.Context, req Request) (Response
 				continue
 			}
 
-			splitReq.cacheKey = s.splitter.GenerateCacheKey(tenant.JoinTenantIDs(tenantIDs), splitReq.orig)
+			splitReq.cacheKey = cacheKeyWithGeneration(s.splitter.GenerateCacheKey(tenant.JoinTenantIDs(tenantIDs), splitReq.orig), generation)
 			lookupKeys = append(lookupKeys, splitReq.cacheKey)
 			lookupReqs = append(lookupReqs, splitReq)
 		}
 
 		// Lookup all keys from cache.
 		fetchedExtents := s.fetchCacheExtents(ctx, lookupKeys)
+		queryStats := stats.FromContext(ctx)
 
 		for lookupIdx, extents := range fetchedExtents {
 			if len(extents) == 0 {
@@ -158,6 +181,8 @@ func (s *splitAndCacheMiddleware) Do(ctx context.Context, req Request) (Response
 				continue
 			}
 
+			queryStats.AddResultsCacheHits(1)
+
 			// We have some extents. This means some parts of the response has been cached and we need
 			// to generate the queries for the missing parts.
 			requests, responses, err := partitionCacheExtents(lookupReqs[lookupIdx].orig, extents, defaultMinCacheExtent, s.extractor)
@@ -266,7 +291,7 @@ func (s *splitAndCacheMiddleware) Do(ctx context.Context, req Request) (Response
 }
 
 // splitRequestByInterval splits the given Request by configured interval. Returns the input request if splitting is disabled.
-func (s *splitAndCacheMiddleware) splitRequestByInterval(req Request) (splitRequests, error) {
+func (s *splitAndCacheMiddleware) splitRequestByInterval(ctx context.Context, req Request) (splitRequests, error) {
 	if !s.splitEnabled {
 		return splitRequests{{orig: req}}, nil
 	}
@@ -277,6 +302,7 @@ func (s *splitAndCacheMiddleware) splitRequestByInterval(req Request) (splitRequ
 	}
 
 	s.metrics.splitQueriesCount.Add(float64(len(splitReqs)))
+	stats.FromContext(ctx).AddSplitQueries(uint32(len(splitReqs)))
 
 	// Wrap the split requests into our internal data structure.
 	out := make(splitRequests, 0, len(splitReqs))
@@ -93,6 +93,7 @@ func TestSplitAndCacheMiddleware_SplitByInterval(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		log.NewNopLogger(),
 		reg,
 	)
@@ -127,7 +128,7 @@ func TestSplitAndCacheMiddleware_SplitByInterval(t *testing.T) {
 		# HELP cortex_frontend_split_queries_total Total number of underlying query requests after the split by interval is applied
 		# TYPE cortex_frontend_split_queries_total counter
 		cortex_frontend_split_queries_total 2
-	`)))
+	`), "cortex_frontend_split_queries_total"))
 }
 
 func TestSplitAndCacheMiddleware_ResultsCache(t *testing.T) {
@@ -141,6 +142,7 @@ func TestSplitAndCacheMiddleware_ResultsCache(t *testing.T) {
 		mockLimits{maxCacheFreshness: 10 * time.Minute},
 		PrometheusCodec,
 		cacheBackend,
+		nil,
 		constSplitter(day),
 		PrometheusResponseExtractor{},
 		resultsCacheAlwaysEnabled,
@@ -214,6 +216,7 @@ func TestSplitAndCacheMiddleware_ResultsCache_ShouldNotLookupCacheIfStepIsNotAli
 		mockLimits{maxCacheFreshness: 10 * time.Minute},
 		PrometheusCodec,
 		cacheBackend,
+		nil,
 		constSplitter(day),
 		PrometheusResponseExtractor{},
 		resultsCacheAlwaysEnabled,
@@ -275,6 +278,7 @@ func TestSplitAndCacheMiddleware_ResultsCache_EnabledCachingOfStepUnalignedReque
 		mockLimits{maxCacheFreshness: 10 * time.Minute},
 		PrometheusCodec,
 		cacheBackend,
+		nil,
 		constSplitter(day),
 		PrometheusResponseExtractor{},
 		resultsCacheAlwaysEnabled,
@@ -405,6 +409,7 @@ func TestSplitAndCacheMiddleware_ResultsCache_ShouldNotCacheRequestEarlierThanMa
 				mockLimits{maxCacheFreshness: maxCacheFreshness},
 				PrometheusCodec,
 				cacheBackend,
+				nil,
 				cacheSplitter,
 				PrometheusResponseExtractor{},
 				resultsCacheAlwaysEnabled,
@@ -613,6 +618,7 @@ func TestSplitAndCacheMiddleware_ResultsCacheFuzzy(t *testing.T) {
 					},
 					PrometheusCodec,
 					cache.NewMockCache(),
+					nil,
 					constSplitter(day),
 					PrometheusResponseExtractor{},
 					resultsCacheAlwaysEnabled,
@@ -849,6 +855,7 @@ func TestSplitAndCacheMiddleware_ResultsCache_ExtentsEdgeCases(t *testing.T) {
 				mockLimits{},
 				PrometheusCodec,
 				cacheBackend,
+				nil,
 				cacheSplitter,
 				PrometheusResponseExtractor{},
 				resultsCacheAlwaysEnabled,
@@ -894,6 +901,7 @@ func TestSplitAndCacheMiddleware_StoreAndFetchCacheExtents(t *testing.T) {
 		mockLimits{},
 		PrometheusCodec,
 		cacheBackend,
+		nil,
 		constSplitter(day),
 		PrometheusResponseExtractor{},
 		resultsCacheAlwaysEnabled,
@@ -941,6 +949,7 @@ func TestSplitAndCacheMiddleware_WrapMultipleTimes(t *testing.T) {
 		mockLimits{},
 		PrometheusCodec,
 		cache.NewMockCache(),
+		nil,
 		constSplitter(day),
 		PrometheusResponseExtractor{},
 		resultsCacheAlwaysEnabled,
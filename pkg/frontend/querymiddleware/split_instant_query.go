@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/dskit/tenant"
+
+	apierror "github.com/grafana/mimir/pkg/api/error"
+	"github.com/grafana/mimir/pkg/frontend/querymiddleware/astmapper"
+	"github.com/grafana/mimir/pkg/querier/stats"
+	"github.com/grafana/mimir/pkg/storage/lazyquery"
+	"github.com/grafana/mimir/pkg/util/spanlogger"
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+type splitInstantQueryByIntervalMiddleware struct {
+	limits Limits
+
+	engine *promql.Engine
+	next   Handler
+	logger log.Logger
+
+	splitInstantQueryMetrics
+}
+
+type splitInstantQueryMetrics struct {
+	splittingAttempts    prometheus.Counter
+	splittingSuccesses   prometheus.Counter
+	splitQueries         prometheus.Counter
+	splitQueriesPerQuery prometheus.Histogram
+}
+
+// newSplitInstantQueryByIntervalMiddleware creates a middleware that splits the range vector
+// selectors of instant queries into sub-ranges of the configured interval. The sub-ranges are
+// embedded into the rewritten query and executed in parallel through the downstream handler
+// by the shardedQueryable, while the PromQL engine recombines their results.
+func newSplitInstantQueryByIntervalMiddleware(
+	limits Limits,
+	logger log.Logger,
+	engine *promql.Engine,
+	registerer prometheus.Registerer,
+) Middleware {
+	metrics := splitInstantQueryMetrics{
+		splittingAttempts: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "frontend_instant_query_splitting_rewrites_attempted_total",
+			Help:      "Total number of instant queries the query-frontend attempted to split by interval.",
+		}),
+		splittingSuccesses: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "frontend_instant_query_splitting_rewrites_succeeded_total",
+			Help:      "Total number of instant queries the query-frontend successfully rewritten in a splittable way.",
+		}),
+		splitQueries: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "frontend_instant_query_split_queries_total",
+			Help:      "Total number of split partial queries.",
+		}),
+		splitQueriesPerQuery: promauto.With(registerer).NewHistogram(prometheus.HistogramOpts{
+			Namespace: "cortex",
+			Name:      "frontend_instant_query_split_queries_per_query",
+			Help:      "Number of partial queries a single instant query has been split into.",
+			Buckets:   prometheus.ExponentialBuckets(2, 2, 10),
+		}),
+	}
+
+	return MiddlewareFunc(func(next Handler) Handler {
+		return &splitInstantQueryByIntervalMiddleware{
+			limits:                   limits,
+			engine:                   engine,
+			next:                     next,
+			logger:                   logger,
+			splitInstantQueryMetrics: metrics,
+		}
+	})
+}
+
+func (s *splitInstantQueryByIntervalMiddleware) Do(ctx context.Context, req Request) (Response, error) {
+	log, ctx := spanlogger.NewWithLogger(ctx, s.logger, "splitInstantQueryByIntervalMiddleware.Do")
+	defer log.Span.Finish()
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, apierror.New(apierror.TypeBadData, err.Error())
+	}
+
+	splitInterval := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, s.limits.SplitInstantQueriesByInterval)
+	if splitInterval <= 0 {
+		level.Debug(log).Log("msg", "query splitting is disabled for this query or tenant")
+		return s.next.Do(ctx, req)
+	}
+
+	s.splittingAttempts.Inc()
+	splitQuery, splitStats, err := s.splitQuery(req.GetQuery(), splitInterval)
+
+	// If an error occurred while trying to rewrite the query or the query has not been split,
+	// then we should fallback to execute it via queriers.
+	if err != nil || splitStats.GetSplitQueries() == 0 {
+		if err != nil {
+			level.Warn(log).Log("msg", "failed to rewrite the input query into a splittable query, falling back to try executing without splitting", "query", req.GetQuery(), "err", err)
+		} else {
+			level.Debug(log).Log("msg", "query is not supported for being rewritten into a splittable query", "query", req.GetQuery())
+		}
+
+		return s.next.Do(ctx, req)
+	}
+
+	level.Debug(log).Log("msg", "instant query has been split by interval", "original", req.GetQuery(), "rewritten", splitQuery, "split_interval", splitInterval, "split_queries", splitStats.GetSplitQueries())
+
+	// Update metrics.
+	s.splittingSuccesses.Inc()
+	s.splitQueries.Add(float64(splitStats.GetSplitQueries()))
+	s.splitQueriesPerQuery.Observe(float64(splitStats.GetSplitQueries()))
+
+	// Update query stats.
+	stats.FromContext(ctx).AddSplitQueries(uint32(splitStats.GetSplitQueries()))
+
+	req = req.WithQuery(splitQuery)
+	shardedQueryable := newShardedQueryable(req, s.next)
+
+	qry, err := newQuery(req, s.engine, lazyquery.NewLazyQueryable(shardedQueryable))
+	if err != nil {
+		return nil, apierror.New(apierror.TypeBadData, err.Error())
+	}
+
+	res := qry.Exec(ctx)
+	extracted, err := promqlResultToSamples(res)
+	if err != nil {
+		return nil, mapEngineError(err)
+	}
+	return &PrometheusResponse{
+		Status: statusSuccess,
+		Data: &PrometheusData{
+			ResultType: string(res.Value.Type()),
+			Result:     extracted,
+		},
+		Headers:  shardedQueryable.getResponseHeaders(),
+		Warnings: promqlWarningsToStrings(res.Warnings),
+	}, nil
+}
+
+// splitQuery attempts to rewrite the input query splitting its range vector selectors by
+// the given interval. Returns the rewritten query to be executed by PromQL engine with
+// shardedQueryable.
+func (s *splitInstantQueryByIntervalMiddleware) splitQuery(query string, interval time.Duration) (string, *astmapper.MapperStats, error) {
+	mapper := astmapper.NewInstantQuerySplitter(interval, s.logger)
+
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return "", nil, apierror.New(apierror.TypeBadData, err.Error())
+	}
+
+	stats := astmapper.NewMapperStats()
+	splitQuery, err := mapper.Map(expr, stats)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return splitQuery.String(), stats, nil
+}
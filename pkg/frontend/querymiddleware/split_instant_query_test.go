@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+func TestSplitInstantQueryByIntervalMiddleware_Correctness(t *testing.T) {
+	const numSeries = 100
+
+	var (
+		queryTime     = start.Add(6 * time.Hour)
+		splitInterval = time.Hour
+	)
+
+	tests := map[string]struct {
+		query                string
+		expectedSplitQueries int
+
+		// Extrapolation of rate() and increase() is applied to each sub-range, so the results
+		// are expected to slightly differ from the ones of the original query.
+		approximate bool
+	}{
+		"sum_over_time()": {
+			query:                `sum_over_time(metric_counter[3h])`,
+			expectedSplitQueries: 3,
+		},
+		"count_over_time()": {
+			query:                `count_over_time(metric_counter[3h])`,
+			expectedSplitQueries: 3,
+		},
+		"max_over_time()": {
+			query:                `max_over_time(metric_counter[3h])`,
+			expectedSplitQueries: 3,
+		},
+		"min_over_time()": {
+			query:                `min_over_time(metric_counter[3h])`,
+			expectedSplitQueries: 3,
+		},
+		"sum_over_time() with range not multiple of the split interval": {
+			query:                `sum_over_time(metric_counter[150m])`,
+			expectedSplitQueries: 3,
+		},
+		"sum_over_time() with offset": {
+			query:                `sum_over_time(metric_counter[3h] offset 30m)`,
+			expectedSplitQueries: 3,
+		},
+		"sum by() (sum_over_time())": {
+			query:                `sum by(group_1) (sum_over_time(metric_counter[3h]))`,
+			expectedSplitQueries: 3,
+		},
+		"max(max_over_time())": {
+			query:                `max(max_over_time(metric_counter[3h]))`,
+			expectedSplitQueries: 3,
+		},
+		"count_over_time() / sum_over_time()": {
+			query:                `count_over_time(metric_counter[3h]) / sum_over_time(metric_counter[3h])`,
+			expectedSplitQueries: 6,
+		},
+		"rate()": {
+			query:                `rate(metric_counter[3h])`,
+			expectedSplitQueries: 3,
+			approximate:          true,
+		},
+		"increase()": {
+			query:                `increase(metric_counter[3h])`,
+			expectedSplitQueries: 3,
+			approximate:          true,
+		},
+		"sum(rate())": {
+			query:                `sum(rate(metric_counter[3h]))`,
+			expectedSplitQueries: 3,
+			approximate:          true,
+		},
+		"not splittable function": {
+			query:                `avg_over_time(metric_counter[3h])`,
+			expectedSplitQueries: 0,
+		},
+		"range not larger than the split interval": {
+			query:                `sum_over_time(metric_counter[1h])`,
+			expectedSplitQueries: 0,
+		},
+	}
+
+	series := make([]*promql.StorageSeries, 0, numSeries+1)
+	for i := 0; i < numSeries; i++ {
+		series = append(series, newSeries(newTestCounterLabels(i), start, queryTime, step, factor(float64(i)*0.1+1)))
+	}
+
+	// Add a special series whose data points start later than the start of the queried time range.
+	series = append(series, newSeries(newTestCounterLabels(numSeries), queryTime.Add(-90*time.Minute), queryTime, step, factor(2)))
+
+	queryable := storageSeriesQueryable(series)
+
+	for testName, testData := range tests {
+		testData := testData
+
+		t.Run(testName, func(t *testing.T) {
+			t.Parallel()
+
+			req := &PrometheusInstantQueryRequest{
+				Path:  "/query",
+				Time:  util.TimeToMillis(queryTime),
+				Query: testData.query,
+			}
+
+			engine := newEngine()
+			downstream := &downstreamHandler{
+				engine:    engine,
+				queryable: queryable,
+			}
+
+			// Run the query without splitting.
+			expectedRes, err := downstream.Do(context.Background(), req)
+			require.Nil(t, err)
+			expectedPrometheusRes := expectedRes.(*PrometheusResponse)
+			sort.Sort(byLabels(expectedPrometheusRes.Data.Result))
+
+			// Ensure the query produces some results.
+			require.NotEmpty(t, expectedPrometheusRes.Data.Result)
+			requireValidSamples(t, expectedPrometheusRes.Data.Result)
+
+			reg := prometheus.NewPedanticRegistry()
+			splittingware := newSplitInstantQueryByIntervalMiddleware(mockLimits{splitInstantQueries: splitInterval}, log.NewNopLogger(), engine, reg)
+
+			// Run the query with splitting.
+			splitRes, err := splittingware.Wrap(downstream).Do(user.InjectOrgID(context.Background(), "test"), req)
+			require.Nil(t, err)
+
+			splitPrometheusRes := splitRes.(*PrometheusResponse)
+			sort.Sort(byLabels(splitPrometheusRes.Data.Result))
+
+			if testData.approximate {
+				requireApproximatelyEqualSamples(t, expectedPrometheusRes.Data.Result, splitPrometheusRes.Data.Result, 1e-6)
+			} else {
+				approximatelyEquals(t, expectedPrometheusRes, splitPrometheusRes)
+			}
+
+			expectedSucceeded := 0
+			if testData.expectedSplitQueries > 0 {
+				expectedSucceeded = 1
+			}
+
+			assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(fmt.Sprintf(`
+				# HELP cortex_frontend_instant_query_splitting_rewrites_attempted_total Total number of instant queries the query-frontend attempted to split by interval.
+				# TYPE cortex_frontend_instant_query_splitting_rewrites_attempted_total counter
+				cortex_frontend_instant_query_splitting_rewrites_attempted_total 1
+				# HELP cortex_frontend_instant_query_splitting_rewrites_succeeded_total Total number of instant queries the query-frontend successfully rewritten in a splittable way.
+				# TYPE cortex_frontend_instant_query_splitting_rewrites_succeeded_total counter
+				cortex_frontend_instant_query_splitting_rewrites_succeeded_total %d
+				# HELP cortex_frontend_instant_query_split_queries_total Total number of split partial queries.
+				# TYPE cortex_frontend_instant_query_split_queries_total counter
+				cortex_frontend_instant_query_split_queries_total %d
+			`, expectedSucceeded, testData.expectedSplitQueries)),
+				"cortex_frontend_instant_query_splitting_rewrites_attempted_total",
+				"cortex_frontend_instant_query_splitting_rewrites_succeeded_total",
+				"cortex_frontend_instant_query_split_queries_total"))
+		})
+	}
+}
+
+func TestSplitInstantQueryByIntervalMiddleware_ShouldNotSplitWhenDisabled(t *testing.T) {
+	req := &PrometheusInstantQueryRequest{
+		Path:  "/query",
+		Time:  util.TimeToMillis(end),
+		Query: `sum_over_time(metric_counter[3h])`,
+	}
+
+	downstream := &mockHandler{}
+	downstream.On("Do", mock.Anything, req).Return(&PrometheusResponse{Status: statusSuccess}, nil).Once()
+
+	splittingware := newSplitInstantQueryByIntervalMiddleware(mockLimits{}, log.NewNopLogger(), newEngine(), nil)
+	_, err := splittingware.Wrap(downstream).Do(user.InjectOrgID(context.Background(), "test"), req)
+	require.NoError(t, err)
+	downstream.AssertExpectations(t)
+}
+
+// requireApproximatelyEqualSamples ensures the two input sample streams have the same series and
+// timestamps, and that their values don't differ more than the given relative error.
+func requireApproximatelyEqualSamples(t *testing.T, expected, actual []SampleStream, epsilon float64) {
+	t.Helper()
+
+	require.Equal(t, len(expected), len(actual), "expected same number of series")
+	for i := range expected {
+		require.Equal(t, expected[i].Labels, actual[i].Labels)
+		require.Equal(t, len(expected[i].Samples), len(actual[i].Samples), "expected same number of samples for series %s", expected[i].Labels)
+
+		for j := range expected[i].Samples {
+			require.Equal(t, expected[i].Samples[j].TimestampMs, actual[i].Samples[j].TimestampMs)
+			require.InEpsilonf(t, expected[i].Samples[j].Value, actual[i].Samples[j].Value, epsilon, "sample value at position %d for series %s", j, expected[i].Labels)
+		}
+	}
+}
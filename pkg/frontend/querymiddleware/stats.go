@@ -4,9 +4,19 @@ package querymiddleware
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/grafana/mimir/pkg/querier/stats"
+)
+
+const (
+	// statsParam is the query parameter used by the client to request the query statistics
+	// in the response, like Prometheus does.
+	statsParam    = "stats"
+	statsParamAll = "all"
 )
 
 type queryStatsMiddleware struct {
@@ -35,3 +45,58 @@ func (s queryStatsMiddleware) Do(ctx context.Context, req Request) (Response, er
 
 	return s.next.Do(ctx, req)
 }
+
+type queryStatsContextKey int
+
+const queryStatsRequestedKey queryStatsContextKey = 0
+
+// contextWithQueryStatsRequested returns a new context marking the query statistics as requested
+// by the client for the query run with it.
+func contextWithQueryStatsRequested(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryStatsRequestedKey, true)
+}
+
+// isQueryStatsRequestedInContext returns whether the query statistics have been requested by the client
+// for the query run with the input context.
+func isQueryStatsRequestedInContext(ctx context.Context) bool {
+	requested, _ := ctx.Value(queryStatsRequestedKey).(bool)
+	return requested
+}
+
+// isQueryStatsRequested returns whether the client requested the query statistics in the response.
+func isQueryStatsRequested(r *http.Request) bool {
+	return r.FormValue(statsParam) == statsParamAll
+}
+
+// withQueryStatistics returns a copy of the input response with the query statistics tracked in the
+// context attached. The input response is returned unchanged if query statistics are not tracked.
+func withQueryStatistics(ctx context.Context, res Response) Response {
+	queryStats := stats.FromContext(ctx)
+	if queryStats == nil {
+		return res
+	}
+
+	promRes, ok := res.(*PrometheusResponse)
+	if !ok || promRes.Data == nil {
+		return res
+	}
+
+	// The response may be shared with other requests (eg. when it's been read from the results cache),
+	// so we never modify it in place. Queries requesting the statistics are never deduplicated, so the
+	// statistics tracked in the context only account for this query.
+	data := *promRes.Data
+	data.Stats = &QueryStatistics{
+		CumulativeWallTimeSeconds: queryStats.LoadWallTime().Seconds(),
+		QueueTimeSeconds:          queryStats.LoadQueueTime().Seconds(),
+		FetchedSeriesCount:        queryStats.LoadFetchedSeries(),
+		FetchedChunksCount:        queryStats.LoadFetchedChunks(),
+		FetchedChunkBytes:         queryStats.LoadFetchedChunkBytes(),
+		ShardedQueries:            queryStats.LoadShardedQueries(),
+		SplitQueries:              queryStats.LoadSplitQueries(),
+		ResultsCacheHits:          queryStats.LoadResultsCacheHits(),
+	}
+
+	out := *promRes
+	out.Data = &data
+	return &out
+}
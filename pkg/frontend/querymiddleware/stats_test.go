@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querymiddleware
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/querier/stats"
+)
+
+func TestTripperware_QueryStatistics(t *testing.T) {
+	const (
+		query        = "/api/v1/query_range?end=1536716880&query=sum%28container_memory_rss%29+by+%28namespace%29&start=1536673680&step=120"
+		responseBody = `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+	)
+
+	tw, err := NewTripperware(
+		Config{SplitQueriesByInterval: 24 * time.Hour},
+		log.NewNopLogger(),
+		mockLimits{},
+		PrometheusCodec,
+		nil,
+		nil,
+		nil,
+		promql.EngineOpts{
+			Logger:     log.NewNopLogger(),
+			MaxSamples: 1000,
+			Timeout:    time.Minute,
+		},
+		nil,
+	)
+	require.NoError(t, err)
+
+	downstream := RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		// Simulate the stats merged from the querier response.
+		stats.FromContext(r.Context()).AddFetchedSeries(10)
+		stats.FromContext(r.Context()).AddQueueTime(time.Second)
+		stats.FromContext(r.Context()).AddWallTime(3 * time.Second)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{jsonMimeType}},
+			Body:       ioutil.NopCloser(strings.NewReader(responseBody)),
+		}, nil
+	})
+
+	tests := map[string]struct {
+		statsEnabled  bool
+		queryParams   string
+		expectedStats string
+	}{
+		"should not return the statistics if not requested": {
+			statsEnabled:  true,
+			expectedStats: "",
+		},
+		"should not return the statistics if requested but query stats are disabled": {
+			statsEnabled:  false,
+			queryParams:   "&stats=all",
+			expectedStats: "",
+		},
+		"should return the statistics, with the wall time summed across split queries, if requested": {
+			statsEnabled:  true,
+			queryParams:   "&stats=all",
+			expectedStats: `"stats":{"cumulativeWallTimeSeconds":6,"queueTimeSeconds":2,"fetchedSeriesCount":20,"fetchedChunksCount":0,"fetchedChunkBytes":0,"shardedQueries":0,"splitQueries":2,"resultsCacheHits":0}`,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx := user.InjectOrgID(context.Background(), "user-1")
+			if testData.statsEnabled {
+				_, ctx = stats.ContextWithEmptyStats(ctx)
+			}
+
+			req, err := http.NewRequest("GET", query+testData.queryParams, http.NoBody)
+			require.NoError(t, err)
+			req = req.WithContext(ctx)
+			require.NoError(t, user.InjectOrgIDIntoHTTPRequest(ctx, req))
+
+			resp, err := tw(downstream).RoundTrip(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			if testData.expectedStats == "" {
+				assert.NotContains(t, string(body), `"stats"`)
+			} else {
+				assert.Contains(t, string(body), testData.expectedStats)
+			}
+		})
+	}
+}
+
+func TestWithQueryStatistics(t *testing.T) {
+	res := &PrometheusResponse{
+		Status: statusSuccess,
+		Data:   &PrometheusData{ResultType: "matrix"},
+	}
+
+	t.Run("should return the input response if query statistics are not tracked", func(t *testing.T) {
+		assert.Same(t, res, withQueryStatistics(context.Background(), res))
+	})
+
+	t.Run("should attach the query statistics without modifying the input response", func(t *testing.T) {
+		queryStats, ctx := stats.ContextWithEmptyStats(context.Background())
+		queryStats.AddWallTime(2 * time.Second)
+		queryStats.AddFetchedChunks(5)
+		queryStats.AddShardedQueries(16)
+		queryStats.AddResultsCacheHits(3)
+
+		out := withQueryStatistics(ctx, res).(*PrometheusResponse)
+		assert.Equal(t, &QueryStatistics{
+			CumulativeWallTimeSeconds: 2,
+			FetchedChunksCount:        5,
+			ShardedQueries:            16,
+			ResultsCacheHits:          3,
+		}, out.Data.Stats)
+		assert.Nil(t, res.Data.Stats)
+	})
+}
@@ -43,6 +43,7 @@ var (
 	errCanceled              = httpgrpc.Errorf(StatusClientClosedRequest, context.Canceled.Error())
 	errDeadlineExceeded      = httpgrpc.Errorf(http.StatusGatewayTimeout, context.DeadlineExceeded.Error())
 	errRequestEntityTooLarge = httpgrpc.Errorf(http.StatusRequestEntityTooLarge, "http: request body too large")
+	errTooManyRequests       = httpgrpc.Errorf(http.StatusTooManyRequests, "the query rate limit has been exceeded, please retry later")
 )
 
 // Config for a Handler.
@@ -50,12 +51,23 @@ type HandlerConfig struct {
 	LogQueriesLongerThan time.Duration `yaml:"log_queries_longer_than"`
 	MaxBodySize          int64         `yaml:"max_body_size" category:"advanced"`
 	QueryStatsEnabled    bool          `yaml:"query_stats_enabled" category:"advanced"`
+
+	QueryRateLimitsEnabled bool `yaml:"query_rate_limits_enabled" category:"experimental"`
+
+	QueryLog QueryLogConfig `yaml:"query_log"`
 }
 
 func (cfg *HandlerConfig) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.LogQueriesLongerThan, "query-frontend.log-queries-longer-than", 0, "Log queries that are slower than the specified duration. Set to 0 to disable. Set to < 0 to enable on all queries.")
 	f.Int64Var(&cfg.MaxBodySize, "query-frontend.max-body-size", 10*1024*1024, "Max body size for downstream prometheus.")
-	f.BoolVar(&cfg.QueryStatsEnabled, "query-frontend.query-stats-enabled", true, "False to disable query statistics tracking. When enabled, a message with some statistics is logged for every query.")
+	f.BoolVar(&cfg.QueryStatsEnabled, "query-frontend.query-stats-enabled", true, "False to disable query statistics tracking. When enabled, a message with some statistics is logged for every query, and the statistics are returned in the query response if the request has the stats=all parameter.")
+	f.BoolVar(&cfg.QueryRateLimitsEnabled, "query-frontend.query-rate-limits-enabled", false, "True to enforce the per-tenant query rate limits. When enabled, the query-frontends join the query-frontends ring to share the limits across all replicas.")
+
+	cfg.QueryLog.RegisterFlags(f)
+}
+
+func (cfg *HandlerConfig) Validate() error {
+	return cfg.QueryLog.Validate()
 }
 
 // Handler accepts queries and forwards them to RoundTripper. It can log slow queries,
@@ -64,6 +76,8 @@ type Handler struct {
 	cfg          HandlerConfig
 	log          log.Logger
 	roundTripper http.RoundTripper
+	rateLimiter  *QueryRateLimiter
+	queryLogger  *QueryLogger
 
 	// Metrics.
 	querySeconds *prometheus.CounterVec
@@ -73,12 +87,15 @@ type Handler struct {
 	activeUsers  *util.ActiveUsersCleanupService
 }
 
-// NewHandler creates a new frontend handler.
-func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, log log.Logger, reg prometheus.Registerer) http.Handler {
+// NewHandler creates a new frontend handler. The rate limiter is optional: if nil, queries are not rate limited.
+// The query logger is optional too: if nil, queries are not written to the query log.
+func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, rateLimiter *QueryRateLimiter, queryLogger *QueryLogger, log log.Logger, reg prometheus.Registerer) http.Handler {
 	h := &Handler{
 		cfg:          cfg,
 		log:          log,
 		roundTripper: roundTripper,
+		rateLimiter:  rateLimiter,
+		queryLogger:  queryLogger,
 	}
 
 	if cfg.QueryStatsEnabled {
@@ -134,6 +151,15 @@ func (f *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		_ = r.Body.Close()
 	}()
 
+	// Enforce the per-tenant query rate limits before the query gets enqueued.
+	if f.rateLimiter != nil {
+		if allowed, retryAfter := f.rateLimiter.Allow(r, time.Now()); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			writeError(w, errTooManyRequests)
+			return
+		}
+	}
+
 	// Buffer the body for later use to track slow queries.
 	var buf bytes.Buffer
 	r.Body = http.MaxBytesReader(w, r.Body, f.cfg.MaxBodySize)
@@ -144,7 +170,11 @@ func (f *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	queryResponseTime := time.Since(startTime)
 
 	if err != nil {
-		writeError(w, err)
+		statusCode := writeError(w, err)
+
+		if f.queryLogger != nil {
+			f.queryLogger.Log(r, f.parseRequestQueryString(r, buf), statusCode, queryResponseTime, stats)
+		}
 		return
 	}
 
@@ -163,7 +193,7 @@ func (f *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Check whether we should parse the query string.
 	shouldReportSlowQuery := f.cfg.LogQueriesLongerThan > 0 && queryResponseTime > f.cfg.LogQueriesLongerThan
-	if shouldReportSlowQuery || f.cfg.QueryStatsEnabled {
+	if shouldReportSlowQuery || f.cfg.QueryStatsEnabled || f.queryLogger != nil {
 		queryString = f.parseRequestQueryString(r, buf)
 	}
 
@@ -173,6 +203,9 @@ func (f *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if f.cfg.QueryStatsEnabled {
 		f.reportQueryStats(r, queryString, queryResponseTime, stats)
 	}
+	if f.queryLogger != nil {
+		f.queryLogger.Log(r, queryString, resp.StatusCode, queryResponseTime, stats)
+	}
 }
 
 // reportSlowQuery reports slow queries.
@@ -219,6 +252,16 @@ func (f *Handler) reportQueryStats(r *http.Request, queryString url.Values, quer
 		"fetched_chunk_bytes", numBytes,
 		"fetched_chunks_count", numChunks,
 		"sharded_queries", stats.LoadShardedQueries(),
+		"split_queries", stats.LoadSplitQueries(),
+		"queue_time_seconds", stats.LoadQueueTime().Seconds(),
+		"results_cache_hits", stats.LoadResultsCacheHits(),
+		"ingester_wall_time_seconds", stats.LoadIngesterWallTime().Seconds(),
+		"ingester_select_time_seconds", stats.LoadIngesterSelectTime().Seconds(),
+		"ingester_series_count", stats.LoadIngesterSeries(),
+		"ingester_chunks_count", stats.LoadIngesterChunks(),
+		"ingester_chunk_bytes", stats.LoadIngesterChunkBytes(),
+		"ingester_head_chunks_count", stats.LoadIngesterHeadChunks(),
+		"ingester_block_chunks_count", stats.LoadIngesterBlockChunks(),
 	}, formatQueryString(queryString)...)
 
 	level.Info(util_log.WithContext(r.Context(), f.log)).Log(logMessage...)
@@ -245,7 +288,8 @@ func formatQueryString(queryString url.Values) (fields []interface{}) {
 	return fields
 }
 
-func writeError(w http.ResponseWriter, err error) {
+// writeError writes the input error to the response, and returns the HTTP status code written.
+func writeError(w http.ResponseWriter, err error) int {
 	switch err {
 	case context.Canceled:
 		err = errCanceled
@@ -260,10 +304,14 @@ func writeError(w http.ResponseWriter, err error) {
 	// if the error error is an APIError, ensure it gets written as a JSON response
 	if resp, ok := apierror.HTTPResponseFromError(err); ok {
 		_ = server.WriteResponse(w, resp)
-		return
+		return int(resp.Code)
 	}
 
 	server.WriteError(w, err)
+	if resp, ok := httpgrpc.HTTPResponseFromError(err); ok {
+		return int(resp.Code)
+	}
+	return http.StatusInternalServerError
 }
 
 func writeServiceTimingHeader(queryResponseTime time.Duration, headers http.Header, stats *querier_stats.Stats) {
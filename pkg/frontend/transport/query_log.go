@@ -0,0 +1,531 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/grafana/dskit/services"
+	"github.com/grafana/dskit/tenant"
+
+	querier_stats "github.com/grafana/mimir/pkg/querier/stats"
+	"github.com/grafana/mimir/pkg/util"
+)
+
+const (
+	// QueryLogBackendFile writes the query log to a local file, rotated by size.
+	QueryLogBackendFile = "file"
+
+	// QueryLogBackendObjectStorage periodically uploads the query log to the blocks storage bucket,
+	// under the <tenant>/query-log/ prefix.
+	QueryLogBackendObjectStorage = "object-storage"
+
+	// QueryLogObjectStoragePrefix is the per-tenant prefix of the query log objects in the bucket.
+	QueryLogObjectStoragePrefix = "query-log"
+)
+
+var (
+	queryLogBackends = []string{QueryLogBackendFile, QueryLogBackendObjectStorage}
+
+	errUnsupportedQueryLogBackend   = fmt.Errorf("unsupported query log backend, supported values are: %s", strings.Join(queryLogBackends, ", "))
+	errMissingQueryLogFilePath      = errors.New("the query log file path is required when the query log file backend is used")
+	errInvalidQueryLogMaxSize       = errors.New("the query log file max size must be greater than 0")
+	errInvalidQueryLogFlushInterval = errors.New("the query log flush interval must be greater than 0")
+	errInvalidQueryLogMaxBufferSize = errors.New("the query log object storage max buffer size must be greater than 0")
+
+	// errQueryLogBufferFull is returned when a line can't be written because the tenant's buffer is full.
+	errQueryLogBufferFull = errors.New("the query log buffer is full")
+)
+
+// QueryLogConfig configures the query log, which is a structured log of all the queries
+// received by the query-frontend.
+type QueryLogConfig struct {
+	Backend       string                      `yaml:"backend" category:"experimental"`
+	File          QueryLogFileConfig          `yaml:"file"`
+	ObjectStorage QueryLogObjectStorageConfig `yaml:"object_storage"`
+	FlushInterval time.Duration               `yaml:"flush_interval" category:"experimental"`
+}
+
+// QueryLogFileConfig configures the file backend of the query log.
+type QueryLogFileConfig struct {
+	Path         string `yaml:"path" category:"experimental"`
+	MaxSizeBytes int64  `yaml:"max_size_bytes" category:"experimental"`
+	MaxFiles     int    `yaml:"max_files" category:"experimental"`
+}
+
+// QueryLogObjectStorageConfig configures the object storage backend of the query log.
+type QueryLogObjectStorageConfig struct {
+	MaxBufferSizeBytes int `yaml:"max_buffer_size_bytes" category:"experimental"`
+}
+
+func (cfg *QueryLogConfig) RegisterFlags(f *flag.FlagSet) {
+	f.StringVar(&cfg.Backend, "query-frontend.query-log.backend", "", fmt.Sprintf("Backend where the query log is written to. The query log is a JSON lines log of the queries received by the query-frontend, sampled per-tenant. Supported values are: %s. The %s backend uploads the query log to the blocks storage bucket. Empty to disable the query log.", strings.Join(queryLogBackends, ", "), QueryLogBackendObjectStorage))
+	f.StringVar(&cfg.File.Path, "query-frontend.query-log.file.path", "", "Path of the query log file. Rotated files are suffixed with an incremental number.")
+	f.Int64Var(&cfg.File.MaxSizeBytes, "query-frontend.query-log.file.max-size-bytes", 100*1024*1024, "Maximum size of the query log file before it gets rotated.")
+	f.IntVar(&cfg.File.MaxFiles, "query-frontend.query-log.file.max-files", 5, "Maximum number of rotated query log files to keep. 0 to keep only the current file.")
+	f.IntVar(&cfg.ObjectStorage.MaxBufferSizeBytes, "query-frontend.query-log.object-storage.max-buffer-size-bytes", 10*1024*1024, "Maximum size of the query log buffered in memory for each tenant, between two uploads to the bucket. Entries received when a tenant's buffer is full are dropped.")
+	f.DurationVar(&cfg.FlushInterval, "query-frontend.query-log.flush-interval", time.Minute, "How frequently the query log is flushed to the backend.")
+}
+
+// Enabled returns whether the query log is enabled.
+func (cfg *QueryLogConfig) Enabled() bool {
+	return cfg.Backend != ""
+}
+
+func (cfg *QueryLogConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if !util.StringsContain(queryLogBackends, cfg.Backend) {
+		return errUnsupportedQueryLogBackend
+	}
+	if cfg.Backend == QueryLogBackendFile {
+		if cfg.File.Path == "" {
+			return errMissingQueryLogFilePath
+		}
+		if cfg.File.MaxSizeBytes <= 0 {
+			return errInvalidQueryLogMaxSize
+		}
+	}
+	if cfg.Backend == QueryLogBackendObjectStorage && cfg.ObjectStorage.MaxBufferSizeBytes <= 0 {
+		return errInvalidQueryLogMaxBufferSize
+	}
+	if cfg.FlushInterval <= 0 {
+		return errInvalidQueryLogFlushInterval
+	}
+	return nil
+}
+
+// QueryLogLimits are the per-tenant limits used by the query log.
+type QueryLogLimits interface {
+	QueryLogSamplingRatio(userID string) float64
+}
+
+// QueryLogEntry is a single entry of the query log.
+type QueryLogEntry struct {
+	Timestamp           time.Time `json:"ts"`
+	Tenant              string    `json:"tenant"`
+	Method              string    `json:"method"`
+	Path                string    `json:"path"`
+	Query               string    `json:"query,omitempty"`
+	Start               string    `json:"start,omitempty"`
+	End                 string    `json:"end,omitempty"`
+	Step                string    `json:"step,omitempty"`
+	Time                string    `json:"time,omitempty"`
+	StatusCode          int       `json:"status_code"`
+	ResponseTimeSeconds float64   `json:"response_time_seconds"`
+	UserAgent           string    `json:"user_agent,omitempty"`
+
+	// Query statistics, only set when the query statistics are enabled.
+	Stats *QueryLogStats `json:"stats,omitempty"`
+}
+
+// QueryLogStats are the query statistics tracked in a QueryLogEntry.
+type QueryLogStats struct {
+	CumulativeWallTimeSeconds float64 `json:"cumulative_wall_time_seconds"`
+	QueueTimeSeconds          float64 `json:"queue_time_seconds"`
+	FetchedSeriesCount        uint64  `json:"fetched_series_count"`
+	FetchedChunksCount        uint64  `json:"fetched_chunks_count"`
+	FetchedChunkBytes         uint64  `json:"fetched_chunk_bytes"`
+	ShardedQueries            uint32  `json:"sharded_queries"`
+	SplitQueries              uint32  `json:"split_queries"`
+	ResultsCacheHits          uint32  `json:"results_cache_hits"`
+
+	IngesterWallTimeSeconds   float64 `json:"ingester_wall_time_seconds"`
+	IngesterSelectTimeSeconds float64 `json:"ingester_select_time_seconds"`
+	IngesterSeriesCount       uint64  `json:"ingester_series_count"`
+	IngesterChunksCount       uint64  `json:"ingester_chunks_count"`
+	IngesterChunkBytes        uint64  `json:"ingester_chunk_bytes"`
+	IngesterHeadChunksCount   uint64  `json:"ingester_head_chunks_count"`
+	IngesterBlockChunksCount  uint64  `json:"ingester_block_chunks_count"`
+}
+
+// queryLogWriter writes the query log entries to a backend.
+type queryLogWriter interface {
+	// write buffers the input line, terminated by a new line, for the given tenant.
+	write(tenantID string, line []byte) error
+
+	// flush writes the buffered lines to the backend.
+	flush(ctx context.Context) error
+
+	// close flushes and releases the resources.
+	close(ctx context.Context) error
+}
+
+// QueryLogger writes the queries received by the query-frontend to the query log.
+type QueryLogger struct {
+	services.Service
+
+	cfg    QueryLogConfig
+	limits QueryLogLimits
+	writer queryLogWriter
+	logger log.Logger
+
+	// Used to sample the queries. Not concurrency safe, so it's protected by a mutex.
+	randMtx sync.Mutex
+	rand    *mathrand.Rand
+
+	entriesWritten prometheus.Counter
+	entriesSkipped prometheus.Counter
+	entriesDropped prometheus.Counter
+	writeFailures  prometheus.Counter
+}
+
+// NewQueryLogger makes a new QueryLogger. The bucket client is required only if the
+// object storage backend is configured.
+func NewQueryLogger(cfg QueryLogConfig, limits QueryLogLimits, bkt objstore.Bucket, logger log.Logger, reg prometheus.Registerer) (*QueryLogger, error) {
+	var (
+		writer queryLogWriter
+		err    error
+	)
+
+	switch cfg.Backend {
+	case QueryLogBackendFile:
+		writer, err = newQueryLogFileWriter(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+	case QueryLogBackendObjectStorage:
+		if bkt == nil {
+			return nil, errors.New("the bucket client is required by the query log object storage backend")
+		}
+		writer = newQueryLogBucketWriter(bkt, cfg.ObjectStorage.MaxBufferSizeBytes)
+	default:
+		return nil, errUnsupportedQueryLogBackend
+	}
+
+	return newQueryLogger(cfg, limits, writer, logger, reg), nil
+}
+
+func newQueryLogger(cfg QueryLogConfig, limits QueryLogLimits, writer queryLogWriter, logger log.Logger, reg prometheus.Registerer) *QueryLogger {
+	l := &QueryLogger{
+		cfg:    cfg,
+		limits: limits,
+		writer: writer,
+		logger: logger,
+		rand:   mathrand.New(mathrand.NewSource(time.Now().UnixNano())),
+		entriesWritten: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_query_frontend_query_log_entries_written_total",
+			Help: "Total number of entries written to the query log.",
+		}),
+		entriesSkipped: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_query_frontend_query_log_entries_skipped_total",
+			Help: "Total number of queries not written to the query log because of the per-tenant sampling.",
+		}),
+		entriesDropped: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_query_frontend_query_log_entries_dropped_total",
+			Help: "Total number of entries dropped because the tenant's query log buffer was full.",
+		}),
+		writeFailures: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_query_frontend_query_log_write_failures_total",
+			Help: "Total number of failures while writing to the query log.",
+		}),
+	}
+
+	l.Service = services.NewTimerService(cfg.FlushInterval, nil, l.iteration, l.stopping)
+	return l
+}
+
+func (l *QueryLogger) iteration(ctx context.Context) error {
+	if err := l.writer.flush(ctx); err != nil {
+		l.writeFailures.Inc()
+		level.Warn(l.logger).Log("msg", "failed to flush the query log", "err", err)
+	}
+
+	// Never return an error, otherwise the service would stop.
+	return nil
+}
+
+func (l *QueryLogger) stopping(_ error) error {
+	// Use a fresh context, because the service context has already been canceled.
+	if err := l.writer.close(context.Background()); err != nil {
+		l.writeFailures.Inc()
+		level.Warn(l.logger).Log("msg", "failed to flush the query log on shutdown", "err", err)
+	}
+	return nil
+}
+
+// Log writes the input request to the query log, if sampled for the tenant. The query
+// statistics are optional.
+func (l *QueryLogger) Log(r *http.Request, queryString url.Values, statusCode int, responseTime time.Duration, stats *querier_stats.Stats) {
+	tenantIDs, err := tenant.TenantIDs(r.Context())
+	if err != nil {
+		return
+	}
+
+	if !l.sample(tenantIDs) {
+		l.entriesSkipped.Inc()
+		return
+	}
+
+	userID := tenant.JoinTenantIDs(tenantIDs)
+	entry := QueryLogEntry{
+		Timestamp:           time.Now().UTC(),
+		Tenant:              userID,
+		Method:              r.Method,
+		Path:                r.URL.Path,
+		Query:               queryString.Get("query"),
+		Start:               queryString.Get("start"),
+		End:                 queryString.Get("end"),
+		Step:                queryString.Get("step"),
+		Time:                queryString.Get("time"),
+		StatusCode:          statusCode,
+		ResponseTimeSeconds: responseTime.Seconds(),
+		UserAgent:           r.UserAgent(),
+	}
+
+	if stats != nil {
+		entry.Stats = &QueryLogStats{
+			CumulativeWallTimeSeconds: stats.LoadWallTime().Seconds(),
+			QueueTimeSeconds:          stats.LoadQueueTime().Seconds(),
+			FetchedSeriesCount:        stats.LoadFetchedSeries(),
+			FetchedChunksCount:        stats.LoadFetchedChunks(),
+			FetchedChunkBytes:         stats.LoadFetchedChunkBytes(),
+			ShardedQueries:            stats.LoadShardedQueries(),
+			SplitQueries:              stats.LoadSplitQueries(),
+			ResultsCacheHits:          stats.LoadResultsCacheHits(),
+
+			IngesterWallTimeSeconds:   stats.LoadIngesterWallTime().Seconds(),
+			IngesterSelectTimeSeconds: stats.LoadIngesterSelectTime().Seconds(),
+			IngesterSeriesCount:       stats.LoadIngesterSeries(),
+			IngesterChunksCount:       stats.LoadIngesterChunks(),
+			IngesterChunkBytes:        stats.LoadIngesterChunkBytes(),
+			IngesterHeadChunksCount:   stats.LoadIngesterHeadChunks(),
+			IngesterBlockChunksCount:  stats.LoadIngesterBlockChunks(),
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		l.writeFailures.Inc()
+		return
+	}
+
+	if err := l.writer.write(userID, line); errors.Is(err, errQueryLogBufferFull) {
+		l.entriesDropped.Inc()
+		return
+	} else if err != nil {
+		l.writeFailures.Inc()
+		level.Warn(l.logger).Log("msg", "failed to write to the query log", "err", err)
+		return
+	}
+
+	l.entriesWritten.Inc()
+}
+
+// sample returns whether a query issued by the input tenants should be logged. When the query
+// is issued by multiple tenants, the smallest sampling ratio is used.
+func (l *QueryLogger) sample(tenantIDs []string) bool {
+	ratio := math.Inf(1)
+	for _, tenantID := range tenantIDs {
+		ratio = math.Min(ratio, l.limits.QueryLogSamplingRatio(tenantID))
+	}
+
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+
+	l.randMtx.Lock()
+	defer l.randMtx.Unlock()
+	return l.rand.Float64() < ratio
+}
+
+// queryLogFileWriter writes the query log to a local file, rotated once it reaches the max size.
+type queryLogFileWriter struct {
+	cfg QueryLogFileConfig
+
+	mtx  sync.Mutex
+	file *os.File
+	size int64
+}
+
+func newQueryLogFileWriter(cfg QueryLogFileConfig) (*queryLogFileWriter, error) {
+	w := &queryLogFileWriter{cfg: cfg}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "create query log directory")
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *queryLogFileWriter) open() error {
+	file, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "open query log file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "stat query log file")
+	}
+
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+func (w *queryLogFileWriter) write(_ string, line []byte) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.file == nil {
+		return errors.New("query log file is closed")
+	}
+
+	if w.size > 0 && w.size+int64(len(line))+1 > w.cfg.MaxSizeBytes {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.file.Write(append(line, '\n'))
+	w.size += int64(n)
+	return err
+}
+
+// rotate shifts the rotated files by one, dropping the oldest one, and opens a new file.
+// Must be called with the lock held.
+func (w *queryLogFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return errors.Wrap(err, "close query log file")
+	}
+	w.file = nil
+
+	if w.cfg.MaxFiles <= 0 {
+		if err := os.Remove(w.cfg.Path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "remove query log file")
+		}
+		return w.open()
+	}
+
+	for i := w.cfg.MaxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedQueryLogFilePath(w.cfg.Path, i), rotatedQueryLogFilePath(w.cfg.Path, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "rotate query log file")
+		}
+	}
+	if err := os.Rename(w.cfg.Path, rotatedQueryLogFilePath(w.cfg.Path, 1)); err != nil {
+		return errors.Wrap(err, "rotate query log file")
+	}
+
+	return w.open()
+}
+
+func (w *queryLogFileWriter) flush(_ context.Context) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+func (w *queryLogFileWriter) close(_ context.Context) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func rotatedQueryLogFilePath(path string, idx int) string {
+	return fmt.Sprintf("%s.%d", path, idx)
+}
+
+// queryLogBucketWriter buffers the query log in memory, and periodically uploads a new object
+// per tenant to the bucket. Each tenant's buffer is bounded by maxBufferSize: lines which don't
+// fit are dropped until the next flush.
+type queryLogBucketWriter struct {
+	bkt           objstore.Bucket
+	maxBufferSize int
+
+	mtx     sync.Mutex
+	buffers map[string]*bytes.Buffer
+}
+
+func newQueryLogBucketWriter(bkt objstore.Bucket, maxBufferSize int) *queryLogBucketWriter {
+	return &queryLogBucketWriter{
+		bkt:           bkt,
+		maxBufferSize: maxBufferSize,
+		buffers:       map[string]*bytes.Buffer{},
+	}
+}
+
+func (w *queryLogBucketWriter) write(tenantID string, line []byte) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	buf, ok := w.buffers[tenantID]
+	if !ok {
+		buf = &bytes.Buffer{}
+		w.buffers[tenantID] = buf
+	}
+
+	if buf.Len()+len(line)+1 > w.maxBufferSize {
+		return errQueryLogBufferFull
+	}
+
+	buf.Write(line)
+	buf.WriteByte('\n')
+	return nil
+}
+
+func (w *queryLogBucketWriter) flush(ctx context.Context) error {
+	w.mtx.Lock()
+	buffers := w.buffers
+	w.buffers = map[string]*bytes.Buffer{}
+	w.mtx.Unlock()
+
+	var firstErr error
+	for tenantID, buf := range buffers {
+		if err := w.bkt.Upload(ctx, queryLogObjectPath(tenantID, time.Now()), buf); err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "upload query log for tenant %s", tenantID)
+		}
+	}
+	return firstErr
+}
+
+func (w *queryLogBucketWriter) close(ctx context.Context) error {
+	return w.flush(ctx)
+}
+
+// queryLogObjectPath returns the path of a new query log object for the given tenant. Objects
+// are named after a ULID, so that they're sorted by time and never conflict across replicas.
+func queryLogObjectPath(tenantID string, now time.Time) string {
+	id := ulid.MustNew(ulid.Timestamp(now), rand.Reader)
+	return path.Join(tenantID, QueryLogObjectStoragePrefix, id.String()+".jsonl")
+}
@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/dskit/services"
+
+	querier_stats "github.com/grafana/mimir/pkg/querier/stats"
+)
+
+func TestQueryLogConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cfg      QueryLogConfig
+		expected error
+	}{
+		"should pass if the query log is disabled": {
+			cfg: QueryLogConfig{},
+		},
+		"should fail on unsupported backend": {
+			cfg:      QueryLogConfig{Backend: "unknown", FlushInterval: time.Minute},
+			expected: errUnsupportedQueryLogBackend,
+		},
+		"should fail if the file backend has no path": {
+			cfg:      QueryLogConfig{Backend: QueryLogBackendFile, File: QueryLogFileConfig{MaxSizeBytes: 1024}, FlushInterval: time.Minute},
+			expected: errMissingQueryLogFilePath,
+		},
+		"should fail if the file backend has an invalid max size": {
+			cfg:      QueryLogConfig{Backend: QueryLogBackendFile, File: QueryLogFileConfig{Path: "query.log"}, FlushInterval: time.Minute},
+			expected: errInvalidQueryLogMaxSize,
+		},
+		"should fail on invalid flush interval": {
+			cfg:      QueryLogConfig{Backend: QueryLogBackendObjectStorage, ObjectStorage: QueryLogObjectStorageConfig{MaxBufferSizeBytes: 1024}},
+			expected: errInvalidQueryLogFlushInterval,
+		},
+		"should fail if the object storage backend has an invalid max buffer size": {
+			cfg:      QueryLogConfig{Backend: QueryLogBackendObjectStorage, FlushInterval: time.Minute},
+			expected: errInvalidQueryLogMaxBufferSize,
+		},
+		"should pass on valid object storage backend config": {
+			cfg: QueryLogConfig{Backend: QueryLogBackendObjectStorage, ObjectStorage: QueryLogObjectStorageConfig{MaxBufferSizeBytes: 1024}, FlushInterval: time.Minute},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testData.expected, testData.cfg.Validate())
+		})
+	}
+}
+
+func TestHandler_QueryLog(t *testing.T) {
+	roundTripper := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("query") == "fail" {
+			return nil, httpgrpc.Errorf(http.StatusBadRequest, "bad query")
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})
+
+	writer := &queryLogWriterMock{}
+	queryLogger := newQueryLogger(QueryLogConfig{FlushInterval: time.Minute}, queryLogLimitsMock{"user-1": 1}, writer, log.NewNopLogger(), nil)
+	handler := NewHandler(HandlerConfig{QueryStatsEnabled: true, MaxBodySize: 1024}, roundTripper, nil, queryLogger, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+
+	for _, query := range []string{"up", "fail"} {
+		req := httptest.NewRequest("GET", "/api/v1/query_range?query="+query+"&start=10&end=20&step=5", nil)
+		req.Header.Set("User-Agent", "test-agent")
+		req = req.WithContext(user.InjectOrgID(context.Background(), "user-1"))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	require.Len(t, writer.lines, 2)
+
+	var entry QueryLogEntry
+	require.NoError(t, json.Unmarshal(writer.lines[0], &entry))
+	assert.Equal(t, "user-1", entry.Tenant)
+	assert.Equal(t, "/api/v1/query_range", entry.Path)
+	assert.Equal(t, "up", entry.Query)
+	assert.Equal(t, "10", entry.Start)
+	assert.Equal(t, "20", entry.End)
+	assert.Equal(t, "5", entry.Step)
+	assert.Equal(t, http.StatusOK, entry.StatusCode)
+	assert.Equal(t, "test-agent", entry.UserAgent)
+	assert.NotNil(t, entry.Stats)
+	assert.Contains(t, string(writer.lines[0]), `"cumulative_wall_time_seconds":`)
+
+	require.NoError(t, json.Unmarshal(writer.lines[1], &entry))
+	assert.Equal(t, "fail", entry.Query)
+	assert.Equal(t, http.StatusBadRequest, entry.StatusCode)
+}
+
+func TestQueryLogger_Sampling(t *testing.T) {
+	writer := &queryLogWriterMock{}
+	limits := queryLogLimitsMock{"all": 1, "none": 0, "half": 0.5}
+	queryLogger := newQueryLogger(QueryLogConfig{FlushInterval: time.Minute}, limits, writer, log.NewNopLogger(), nil)
+
+	logQueries := func(tenantID string, count int) int {
+		writer.lines = nil
+		for i := 0; i < count; i++ {
+			req := httptest.NewRequest("GET", "/api/v1/query", nil)
+			req = req.WithContext(user.InjectOrgID(context.Background(), tenantID))
+			queryLogger.Log(req, nil, http.StatusOK, time.Second, nil)
+		}
+		return len(writer.lines)
+	}
+
+	assert.Equal(t, 100, logQueries("all", 100))
+	assert.Equal(t, 0, logQueries("none", 100))
+	assert.InDelta(t, 500, logQueries("half", 1000), 100)
+
+	// When the query is issued by multiple tenants, the smallest ratio is used.
+	assert.Equal(t, 0, logQueries("all|none", 100))
+}
+
+func TestQueryLogFileWriter(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "logs", "query.log")
+
+	w, err := newQueryLogFileWriter(QueryLogFileConfig{Path: logPath, MaxSizeBytes: 10, MaxFiles: 2})
+	require.NoError(t, err)
+
+	// Each line is 5 bytes, including the new line, so the file is rotated every 2 lines.
+	for _, line := range []string{"aaaa", "bbbb", "cccc", "dddd", "eeee", "ffff", "gggg"} {
+		require.NoError(t, w.write("user-1", []byte(line)))
+	}
+	require.NoError(t, w.flush(context.Background()))
+	require.NoError(t, w.close(context.Background()))
+
+	readFile := func(path string) string {
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	assert.Equal(t, "gggg\n", readFile(logPath))
+	assert.Equal(t, "eeee\nffff\n", readFile(rotatedQueryLogFilePath(logPath, 1)))
+	assert.Equal(t, "cccc\ndddd\n", readFile(rotatedQueryLogFilePath(logPath, 2)))
+
+	_, err = os.Stat(rotatedQueryLogFilePath(logPath, 3))
+	assert.True(t, os.IsNotExist(err))
+
+	// Writing after close should fail.
+	assert.Error(t, w.write("user-1", []byte("hhhh")))
+}
+
+func TestQueryLogger_ObjectStorageBackend(t *testing.T) {
+	bkt := objstore.NewInMemBucket()
+
+	queryLogger, err := NewQueryLogger(QueryLogConfig{Backend: QueryLogBackendObjectStorage, ObjectStorage: QueryLogObjectStorageConfig{MaxBufferSizeBytes: 1024 * 1024}, FlushInterval: time.Hour}, queryLogLimitsMock{"user-1": 1, "user-2": 1}, bkt, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), queryLogger))
+
+	for _, tenantID := range []string{"user-1", "user-2", "user-1"} {
+		req := httptest.NewRequest("GET", "/api/v1/query?query=up", nil)
+		req = req.WithContext(user.InjectOrgID(context.Background(), tenantID))
+		queryLogger.Log(req, req.URL.Query(), http.StatusOK, time.Second, querier_stats.FromContext(req.Context()))
+	}
+
+	// Nothing is uploaded until the query log is flushed.
+	assert.Empty(t, bkt.Objects())
+
+	// Stopping the service flushes the buffered entries.
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), queryLogger))
+
+	linesPerTenant := map[string]int{}
+	for name, content := range bkt.Objects() {
+		parts := strings.Split(name, "/")
+		require.Len(t, parts, 3)
+		assert.Equal(t, QueryLogObjectStoragePrefix, parts[1])
+		assert.True(t, strings.HasSuffix(parts[2], ".jsonl"))
+
+		linesPerTenant[parts[0]] += bytes.Count(content, []byte("\n"))
+	}
+
+	assert.Equal(t, map[string]int{"user-1": 2, "user-2": 1}, linesPerTenant)
+}
+
+func TestQueryLogBucketWriter_ShouldDropLinesWhenBufferIsFull(t *testing.T) {
+	bkt := objstore.NewInMemBucket()
+	w := newQueryLogBucketWriter(bkt, 10)
+
+	// Each line takes 5 bytes, including the new line.
+	require.NoError(t, w.write("user-1", []byte("aaaa")))
+	require.NoError(t, w.write("user-1", []byte("bbbb")))
+	assert.Equal(t, errQueryLogBufferFull, w.write("user-1", []byte("cccc")))
+
+	// The buffer is per-tenant, so other tenants are not affected.
+	require.NoError(t, w.write("user-2", []byte("dddd")))
+
+	// Once flushed, the buffer accepts new lines.
+	require.NoError(t, w.flush(context.Background()))
+	require.NoError(t, w.write("user-1", []byte("eeee")))
+	require.NoError(t, w.flush(context.Background()))
+
+	contentPerTenant := map[string]string{}
+	for name, content := range bkt.Objects() {
+		contentPerTenant[strings.Split(name, "/")[0]] += string(content)
+	}
+	assert.Equal(t, map[string]string{"user-1": "aaaa\nbbbb\neeee\n", "user-2": "dddd\n"}, contentPerTenant)
+}
+
+func TestQueryLogger_ShouldTrackDroppedEntries(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	writer := newQueryLogBucketWriter(objstore.NewInMemBucket(), 1)
+	queryLogger := newQueryLogger(QueryLogConfig{FlushInterval: time.Hour}, queryLogLimitsMock{"user-1": 1}, writer, log.NewNopLogger(), reg)
+
+	req := httptest.NewRequest("GET", "/api/v1/query?query=up", nil)
+	req = req.WithContext(user.InjectOrgID(context.Background(), "user-1"))
+	queryLogger.Log(req, req.URL.Query(), http.StatusOK, time.Second, nil)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_query_frontend_query_log_entries_dropped_total Total number of entries dropped because the tenant's query log buffer was full.
+		# TYPE cortex_query_frontend_query_log_entries_dropped_total counter
+		cortex_query_frontend_query_log_entries_dropped_total 1
+		# HELP cortex_query_frontend_query_log_entries_written_total Total number of entries written to the query log.
+		# TYPE cortex_query_frontend_query_log_entries_written_total counter
+		cortex_query_frontend_query_log_entries_written_total 0
+		# HELP cortex_query_frontend_query_log_write_failures_total Total number of failures while writing to the query log.
+		# TYPE cortex_query_frontend_query_log_write_failures_total counter
+		cortex_query_frontend_query_log_write_failures_total 0
+	`), "cortex_query_frontend_query_log_entries_dropped_total", "cortex_query_frontend_query_log_entries_written_total", "cortex_query_frontend_query_log_write_failures_total"))
+}
+
+type queryLogLimitsMock map[string]float64
+
+func (m queryLogLimitsMock) QueryLogSamplingRatio(userID string) float64 {
+	return m[userID]
+}
+
+type queryLogWriterMock struct {
+	lines [][]byte
+}
+
+func (m *queryLogWriterMock) write(_ string, line []byte) error {
+	m.lines = append(m.lines, line)
+	return nil
+}
+
+func (m *queryLogWriterMock) flush(context.Context) error { return nil }
+
+func (m *queryLogWriterMock) close(context.Context) error { return nil }
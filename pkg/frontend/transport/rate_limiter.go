@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package transport
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+
+	"github.com/grafana/dskit/limiter"
+	"github.com/grafana/dskit/tenant"
+)
+
+const (
+	rangeQueryType    = "range"
+	instantQueryType  = "instant"
+	metadataQueryType = "metadata"
+
+	// rateLimitsRecheckPeriod is how frequently the per-tenant rate limits and the number
+	// of healthy query-frontends in the ring are rechecked.
+	rateLimitsRecheckPeriod = 10 * time.Second
+)
+
+// Limits are the per-tenant query rate limits enforced by the query-frontend.
+type Limits interface {
+	RangeQueryRateLimit(userID string) float64
+	RangeQueryBurstSize(userID string) int
+	InstantQueryRateLimit(userID string) float64
+	InstantQueryBurstSize(userID string) int
+	MetadataQueryRateLimit(userID string) float64
+	MetadataQueryBurstSize(userID string) int
+}
+
+// ReadLifecycler represents the read interface to the query-frontends lifecycler.
+type ReadLifecycler interface {
+	HealthyInstancesCount() int
+}
+
+// QueryRateLimiter enforces the per-tenant query rate limits, with a separate bucket for
+// range, instant and metadata queries. The limits are global: each query-frontend enforces
+// the configured limit divided by the number of healthy query-frontends in the ring.
+type QueryRateLimiter struct {
+	limiters map[string]*tenantRateLimiter
+
+	rateLimitedQueries *prometheus.CounterVec
+}
+
+// NewQueryRateLimiter makes a new QueryRateLimiter. The ring is optional: if nil, each
+// query-frontend enforces the whole configured limit.
+func NewQueryRateLimiter(limits Limits, ring ReadLifecycler, reg prometheus.Registerer) *QueryRateLimiter {
+	return &QueryRateLimiter{
+		limiters: map[string]*tenantRateLimiter{
+			rangeQueryType:    newTenantRateLimiter(newGlobalQueryRateStrategy(limits.RangeQueryRateLimit, limits.RangeQueryBurstSize, ring), rateLimitsRecheckPeriod),
+			instantQueryType:  newTenantRateLimiter(newGlobalQueryRateStrategy(limits.InstantQueryRateLimit, limits.InstantQueryBurstSize, ring), rateLimitsRecheckPeriod),
+			metadataQueryType: newTenantRateLimiter(newGlobalQueryRateStrategy(limits.MetadataQueryRateLimit, limits.MetadataQueryBurstSize, ring), rateLimitsRecheckPeriod),
+		},
+		rateLimitedQueries: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_frontend_rate_limited_queries_total",
+			Help: "Total number of queries rejected by the query-frontend because of the per-tenant query rate limits.",
+		}, []string{"query_type"}),
+	}
+}
+
+// Allow returns whether the input request is allowed by the rate limits of all the tenants
+// issuing it. If the request is not allowed, the returned duration is the time the client
+// is recommended to wait before retrying it. A request is charged to the tenants only if
+// it's allowed by all of them.
+func (l *QueryRateLimiter) Allow(r *http.Request, now time.Time) (bool, time.Duration) {
+	queryType := queryTypeFromPath(r.URL.Path)
+	if queryType == "" {
+		return true, 0
+	}
+
+	tenantIDs, err := tenant.TenantIDs(r.Context())
+	if err != nil {
+		// The request will be rejected later on, so there's nothing to rate limit.
+		return true, 0
+	}
+
+	// Reserve a token for each tenant first, and return the tokens reserved so far if the
+	// request is rejected by any of them, so that a rejected request doesn't consume the
+	// rate limit of the other tenants.
+	rl := l.limiters[queryType]
+	reservations := make([]*rate.Reservation, 0, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		r := rl.ReserveN(now, tenantID, 1)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, reserved := range reservations {
+				reserved.CancelAt(now)
+			}
+
+			l.rateLimitedQueries.WithLabelValues(queryType).Inc()
+			return false, retryAfter(rl.Limit(now, tenantID))
+		}
+		reservations = append(reservations, r)
+	}
+
+	return true, 0
+}
+
+// retryAfter returns the time it takes for the bucket of the input rate limit to refill one token.
+func retryAfter(limit float64) time.Duration {
+	if limit <= 0 || limit == float64(rate.Inf) {
+		return time.Second
+	}
+	return time.Duration(math.Max(1, math.Ceil(1/limit))) * time.Second
+}
+
+// queryTypeFromPath returns the type of query served by the input API path, or an
+// empty string if the API is not rate limited.
+func queryTypeFromPath(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/query_range"):
+		return rangeQueryType
+	case strings.HasSuffix(path, "/query"):
+		return instantQueryType
+	case strings.HasSuffix(path, "/series"),
+		strings.HasSuffix(path, "/labels"),
+		strings.HasSuffix(path, "/values") && strings.Contains(path, "/label/"),
+		strings.HasSuffix(path, "/metadata"):
+		return metadataQueryType
+	default:
+		return ""
+	}
+}
+
+type globalQueryRateStrategy struct {
+	limit func(userID string) float64
+	burst func(userID string) int
+	ring  ReadLifecycler
+}
+
+func newGlobalQueryRateStrategy(limit func(string) float64, burst func(string) int, ring ReadLifecycler) limiter.RateLimiterStrategy {
+	return &globalQueryRateStrategy{
+		limit: limit,
+		burst: burst,
+		ring:  ring,
+	}
+}
+
+func (s *globalQueryRateStrategy) Limit(tenantID string) float64 {
+	limit := s.limit(tenantID)
+	if limit <= 0 {
+		return float64(rate.Inf)
+	}
+
+	if s.ring == nil {
+		return limit
+	}
+
+	numFrontends := s.ring.HealthyInstancesCount()
+	if numFrontends == 0 {
+		return limit
+	}
+
+	return limit / float64(numFrontends)
+}
+
+func (s *globalQueryRateStrategy) Burst(tenantID string) int {
+	// The meaning of burst doesn't change for the global strategy, in order
+	// to keep it easier to understand for users / operators.
+	if burst := s.burst(tenantID); burst > 0 {
+		return burst
+	}
+
+	// Burst is ignored when the limit is disabled.
+	return int(math.Max(1, math.Ceil(s.limit(tenantID))))
+}
+
+// tenantRateLimiter is a multi-tenant local rate limiter, like the dskit one, whose tokens
+// can be reserved and returned if the request is eventually rejected.
+type tenantRateLimiter struct {
+	strategy      limiter.RateLimiterStrategy
+	recheckPeriod time.Duration
+
+	mtx     sync.Mutex
+	tenants map[string]*tenantRateLimiterEntry
+}
+
+type tenantRateLimiterEntry struct {
+	limiter   *rate.Limiter
+	recheckAt time.Time
+}
+
+func newTenantRateLimiter(strategy limiter.RateLimiterStrategy, recheckPeriod time.Duration) *tenantRateLimiter {
+	return &tenantRateLimiter{
+		strategy:      strategy,
+		recheckPeriod: recheckPeriod,
+		tenants:       map[string]*tenantRateLimiterEntry{},
+	}
+}
+
+// ReserveN reserves n tokens of the input tenant at time now.
+func (l *tenantRateLimiter) ReserveN(now time.Time, tenantID string, n int) *rate.Reservation {
+	return l.getTenantLimiter(now, tenantID).ReserveN(now, n)
+}
+
+// Limit returns the currently configured maximum overall tokens rate of the input tenant.
+func (l *tenantRateLimiter) Limit(now time.Time, tenantID string) float64 {
+	return float64(l.getTenantLimiter(now, tenantID).Limit())
+}
+
+// getTenantLimiter returns the limiter of the input tenant, creating it if it doesn't exist
+// and reconfiguring it with the current limit and burst once the recheck period has elapsed.
+func (l *tenantRateLimiter) getTenantLimiter(now time.Time, tenantID string) *rate.Limiter {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	entry, ok := l.tenants[tenantID]
+	if !ok {
+		entry = &tenantRateLimiterEntry{
+			limiter:   rate.NewLimiter(rate.Limit(l.strategy.Limit(tenantID)), l.strategy.Burst(tenantID)),
+			recheckAt: now.Add(l.recheckPeriod),
+		}
+		l.tenants[tenantID] = entry
+		return entry.limiter
+	}
+
+	if !now.Before(entry.recheckAt) {
+		if limit := rate.Limit(l.strategy.Limit(tenantID)); entry.limiter.Limit() != limit {
+			entry.limiter.SetLimitAt(now, limit)
+		}
+		if burst := l.strategy.Burst(tenantID); entry.limiter.Burst() != burst {
+			entry.limiter.SetBurstAt(now, burst)
+		}
+		entry.recheckAt = now.Add(l.recheckPeriod)
+	}
+
+	return entry.limiter
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+	"golang.org/x/time/rate"
+
+	"github.com/grafana/dskit/tenant"
+)
+
+func TestGlobalQueryRateStrategy(t *testing.T) {
+	tests := map[string]struct {
+		limit         float64
+		burst         int
+		ring          ReadLifecycler
+		expectedLimit float64
+		expectedBurst int
+	}{
+		"should return an infinite limit if the limit is disabled": {
+			limit:         0,
+			burst:         0,
+			ring:          newReadLifecyclerMock(2),
+			expectedLimit: float64(rate.Inf),
+			expectedBurst: 1,
+		},
+		"should share the limit across the healthy query-frontends": {
+			limit:         10,
+			burst:         20,
+			ring:          newReadLifecyclerMock(2),
+			expectedLimit: 5,
+			expectedBurst: 20,
+		},
+		"should not share the limit if there are no healthy query-frontends": {
+			limit:         10,
+			burst:         20,
+			ring:          newReadLifecyclerMock(0),
+			expectedLimit: 10,
+			expectedBurst: 20,
+		},
+		"should not share the limit if the ring is not configured": {
+			limit:         10,
+			burst:         20,
+			expectedLimit: 10,
+			expectedBurst: 20,
+		},
+		"should use the rounded up limit as burst if the burst is not configured": {
+			limit:         2.5,
+			burst:         0,
+			ring:          newReadLifecyclerMock(1),
+			expectedLimit: 2.5,
+			expectedBurst: 3,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			strategy := newGlobalQueryRateStrategy(
+				func(string) float64 { return testData.limit },
+				func(string) int { return testData.burst },
+				testData.ring,
+			)
+
+			assert.Equal(t, testData.expectedLimit, strategy.Limit("user-1"))
+			assert.Equal(t, testData.expectedBurst, strategy.Burst("user-1"))
+		})
+	}
+}
+
+func TestQueryTypeFromPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/prometheus/api/v1/query_range":            rangeQueryType,
+		"/prometheus/api/v1/query":                  instantQueryType,
+		"/prometheus/api/v1/series":                 metadataQueryType,
+		"/prometheus/api/v1/labels":                 metadataQueryType,
+		"/prometheus/api/v1/label/job/values":       metadataQueryType,
+		"/prometheus/api/v1/metadata":               metadataQueryType,
+		"/prometheus/api/v1/query_exemplars":        "",
+		"/prometheus/api/v1/cardinality/label_name": "",
+	} {
+		assert.Equal(t, expected, queryTypeFromPath(path), path)
+	}
+}
+
+func TestHandler_ServeHTTP_ShouldEnforceQueryRateLimits(t *testing.T) {
+	roundTripper := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})
+
+	limits := &mockRateLimits{instantLimit: 0.5, instantBurst: 1}
+	reg := prometheus.NewPedanticRegistry()
+	handler := NewHandler(HandlerConfig{}, roundTripper, NewQueryRateLimiter(limits, newReadLifecyclerMock(1), reg), nil, log.NewNopLogger(), nil)
+
+	serve := func(path, tenantID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req = req.WithContext(user.InjectOrgID(context.Background(), tenantID))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// The first instant query is allowed by the burst, while the next one is rate limited.
+	require.Equal(t, http.StatusOK, serve("/api/v1/query", "user-1").Code)
+
+	resp := serve("/api/v1/query", "user-1")
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("Retry-After"))
+
+	// Other tenants and query types have their own buckets.
+	require.Equal(t, http.StatusOK, serve("/api/v1/query", "user-2").Code)
+	require.Equal(t, http.StatusOK, serve("/api/v1/query_range", "user-1").Code)
+	require.Equal(t, http.StatusOK, serve("/api/v1/series", "user-1").Code)
+
+	assert.NoError(t, promtest.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_query_frontend_rate_limited_queries_total Total number of queries rejected by the query-frontend because of the per-tenant query rate limits.
+		# TYPE cortex_query_frontend_rate_limited_queries_total counter
+		cortex_query_frontend_rate_limited_queries_total{query_type="instant"} 1
+	`)))
+}
+
+func TestQueryRateLimiter_ShouldRefillBucketsOverTime(t *testing.T) {
+	limits := &mockRateLimits{rangeLimit: 1, rangeBurst: 1}
+	limiter := NewQueryRateLimiter(limits, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/query_range", nil)
+	req = req.WithContext(user.InjectOrgID(context.Background(), "user-1"))
+
+	now := time.Now()
+	allowed, _ := limiter.Allow(req, now)
+	assert.True(t, allowed)
+
+	allowed, retryAfter := limiter.Allow(req, now)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	allowed, _ = limiter.Allow(req, now.Add(time.Second))
+	assert.True(t, allowed)
+}
+
+func TestQueryRateLimiter_ShouldNotChargeTenantsWhenMultiTenantRequestIsRejected(t *testing.T) {
+	tenant.WithDefaultResolver(tenant.NewMultiResolver())
+	defer tenant.WithDefaultResolver(tenant.NewSingleResolver())
+
+	limits := &mockRateLimits{rangeLimit: 1, rangeBurst: 2}
+	limiter := NewQueryRateLimiter(limits, nil, nil)
+
+	allow := func(tenantID string, now time.Time) bool {
+		req := httptest.NewRequest("GET", "/api/v1/query_range", nil)
+		req = req.WithContext(user.InjectOrgID(context.Background(), tenantID))
+		allowed, _ := limiter.Allow(req, now)
+		return allowed
+	}
+
+	// user-1 has a token left, while user-2 has none.
+	now := time.Now()
+	require.True(t, allow("user-1", now))
+	require.True(t, allow("user-2", now))
+	require.True(t, allow("user-2", now))
+
+	// The request is rejected by user-2, so the token reserved for user-1 is returned.
+	assert.False(t, allow("user-1|user-2", now))
+	assert.True(t, allow("user-1", now))
+	assert.False(t, allow("user-1", now))
+}
+
+type readLifecyclerMock struct {
+	healthyInstancesCount int
+}
+
+func newReadLifecyclerMock(healthyInstancesCount int) *readLifecyclerMock {
+	return &readLifecyclerMock{healthyInstancesCount: healthyInstancesCount}
+}
+
+func (m *readLifecyclerMock) HealthyInstancesCount() int {
+	return m.healthyInstancesCount
+}
+
+type mockRateLimits struct {
+	rangeLimit    float64
+	rangeBurst    int
+	instantLimit  float64
+	instantBurst  int
+	metadataLimit float64
+	metadataBurst int
+}
+
+func (m *mockRateLimits) RangeQueryRateLimit(string) float64    { return m.rangeLimit }
+func (m *mockRateLimits) RangeQueryBurstSize(string) int        { return m.rangeBurst }
+func (m *mockRateLimits) InstantQueryRateLimit(string) float64  { return m.instantLimit }
+func (m *mockRateLimits) InstantQueryBurstSize(string) int      { return m.instantBurst }
+func (m *mockRateLimits) MetadataQueryRateLimit(string) float64 { return m.metadataLimit }
+func (m *mockRateLimits) MetadataQueryBurstSize(string) int     { return m.metadataBurst }
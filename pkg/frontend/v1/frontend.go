@@ -215,7 +215,8 @@ func (f *Frontend) Process(server frontendv1pb.Frontend_ProcessServer) error {
 
 		req := reqWrapper.(*request)
 
-		f.queueDuration.Observe(time.Since(req.enqueueTime).Seconds())
+		queueTime := time.Since(req.enqueueTime)
+		f.queueDuration.Observe(queueTime.Seconds())
 		req.queueSpan.Finish()
 
 		/*
@@ -276,6 +277,7 @@ func (f *Frontend) Process(server frontendv1pb.Frontend_ProcessServer) error {
 			if stats.ShouldTrackHTTPGRPCResponse(resp.HttpResponse) {
 				stats := stats.FromContext(req.originalCtx)
 				stats.Merge(resp.Stats) // Safe if stats is nil.
+				stats.AddQueueTime(queueTime)
 			}
 
 			req.response <- resp.HttpResponse
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/grafana/mimir/pkg/ingester/activeseries"
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/mimirpb"
+)
+
+// activeSeries streams the messages with the series which are active at the input time and match the `matchers` param.
+// Messages are immediately sent as soon they reach message size threshold defined in `messageSizeThreshold` param.
+func activeSeries(
+	series *activeseries.ActiveSeries,
+	now time.Time,
+	matchers []*labels.Matcher,
+	messageSizeThreshold int,
+	server client.Ingester_ActiveSeriesServer,
+) error {
+	ctx := server.Context()
+
+	response := client.ActiveSeriesResponse{}
+	responseSizeBytes := 0
+	count := 0
+
+	err := series.ForEachActiveSeries(now, matchers, func(lbls labels.Labels) error {
+		count++
+		if count%checkContextErrorSeriesCount == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		response.Metric = append(response.Metric, &mimirpb.Metric{Labels: mimirpb.FromLabelsToLabelAdapters(lbls)})
+		for _, l := range lbls {
+			responseSizeBytes += len(l.Name) + len(l.Value)
+		}
+		if responseSizeBytes < messageSizeThreshold {
+			return nil
+		}
+
+		// Flush the response when reached message threshold.
+		if err := client.SendActiveSeriesResponse(server, &response); err != nil {
+			return err
+		}
+		response.Metric = response.Metric[:0]
+		responseSizeBytes = 0
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Send response in case there are any pending items.
+	if len(response.Metric) > 0 {
+		return client.SendActiveSeriesResponse(server, &response)
+	}
+	return nil
+}
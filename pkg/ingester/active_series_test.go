@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/mimir/pkg/ingester/activeseries"
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/mimirpb"
+)
+
+func TestActiveSeries(t *testing.T) {
+	now := time.Now()
+	series := activeseries.NewActiveSeries(&activeseries.Matchers{}, time.Minute)
+	for _, lbls := range []labels.Labels{
+		labels.FromStrings("__name__", "metric", "job", "aaaa"),
+		labels.FromStrings("__name__", "metric", "job", "bbbb"),
+		labels.FromStrings("__name__", "metric", "job", "cccc"),
+		labels.FromStrings("__name__", "other", "job", "aaaa"),
+	} {
+		series.UpdateSeries(lbls, now, func(l labels.Labels) labels.Labels { return l })
+	}
+
+	tests := map[string]struct {
+		matchers             []*labels.Matcher
+		messageSizeThreshold int
+		expectedMessages     int
+		expectedSeries       int
+	}{
+		"should send all series in a single message if the threshold is not reached": {
+			messageSizeThreshold: 1024,
+			expectedMessages:     1,
+			expectedSeries:       4,
+		},
+		"should send a message each time the threshold is reached": {
+			// Each series is 21 or 20 bytes.
+			messageSizeThreshold: 40,
+			expectedMessages:     2,
+			expectedSeries:       4,
+		},
+		"should only send the series matching the matchers": {
+			matchers:             []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "__name__", "metric")},
+			messageSizeThreshold: 1024,
+			expectedMessages:     1,
+			expectedSeries:       3,
+		},
+		"should send no message if no series match": {
+			matchers:             []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "__name__", "unknown")},
+			messageSizeThreshold: 1024,
+			expectedMessages:     0,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			server := &mockActiveSeriesServer{context: context.Background()}
+			require.NoError(t, activeSeries(series, now, testData.matchers, testData.messageSizeThreshold, server))
+
+			assert.Len(t, server.SentResponses, testData.expectedMessages)
+
+			actualSeries := 0
+			for _, resp := range server.SentResponses {
+				actualSeries += len(resp.Metric)
+			}
+			assert.Equal(t, testData.expectedSeries, actualSeries)
+		})
+	}
+}
+
+type mockActiveSeriesServer struct {
+	client.Ingester_ActiveSeriesServer
+	SentResponses []client.ActiveSeriesResponse
+	context       context.Context
+}
+
+func (m *mockActiveSeriesServer) Send(resp *client.ActiveSeriesResponse) error {
+	metrics := make([]*mimirpb.Metric, len(resp.Metric))
+	copy(metrics, resp.Metric)
+	m.SentResponses = append(m.SentResponses, client.ActiveSeriesResponse{Metric: metrics})
+	return nil
+}
+
+func (m *mockActiveSeriesServer) Context() context.Context {
+	return m.context
+}
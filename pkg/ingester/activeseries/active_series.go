@@ -124,6 +124,25 @@ func (c *ActiveSeries) Active(now time.Time) (int, []int, bool) {
 	return total, totalMatching, true
 }
 
+// ForEachActiveSeries calls fn for each series which is active at the input time and matches all
+// the input matchers. The iteration stops if fn returns an error.
+func (c *ActiveSeries) ForEachActiveSeries(now time.Time, matchers []*labels.Matcher, fn func(series labels.Labels) error) error {
+	keepUntilNanos := now.Add(-c.timeout).UnixNano()
+
+	var matching []labels.Labels
+	for s := 0; s < numStripes; s++ {
+		// fn is called without holding the stripe lock, so that it doesn't block the ingestion.
+		matching = c.stripes[s].appendActiveSeries(matching[:0], keepUntilNanos, matchers)
+		for _, series := range matching {
+			if err := fn(series); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // getTotalAndUpdateMatching will return the total active series in the stripe and also update the slice provided
 // with each matcher's total.
 func (s *seriesStripe) getTotalAndUpdateMatching(matching []int) int {
@@ -138,6 +157,31 @@ func (s *seriesStripe) getTotalAndUpdateMatching(matching []int) int {
 	return s.active
 }
 
+// appendActiveSeries appends to dst the series in the stripe which are active and match all the matchers.
+func (s *seriesStripe) appendActiveSeries(dst []labels.Labels, keepUntilNanos int64, matchers []*labels.Matcher) []labels.Labels {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, entries := range s.refs {
+		for _, entry := range entries {
+			if entry.nanos.Load() >= keepUntilNanos && matchesAll(entry.lbs, matchers) {
+				dst = append(dst, entry.lbs)
+			}
+		}
+	}
+
+	return dst
+}
+
+func matchesAll(series labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(series.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *seriesStripe) updateSeriesTimestamp(now time.Time, series labels.Labels, fingerprint uint64, labelsCopy func(labels.Labels) labels.Labels) {
 	nowNanos := now.UnixNano()
 
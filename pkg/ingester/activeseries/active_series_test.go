@@ -88,6 +88,43 @@ func TestActiveSeries_UpdateSeries_WithMatchers(t *testing.T) {
 	assert.True(t, valid)
 }
 
+func TestActiveSeries_ForEachActiveSeries(t *testing.T) {
+	ls1 := labels.FromStrings("a", "1", "b", "1")
+	ls2 := labels.FromStrings("a", "1", "b", "2")
+	ls3 := labels.FromStrings("a", "2", "b", "1")
+
+	now := time.Now()
+	c := NewActiveSeries(&Matchers{}, DefaultTimeout)
+	c.UpdateSeries(ls1, now, copyFn)
+	c.UpdateSeries(ls2, now, copyFn)
+	c.UpdateSeries(ls3, now.Add(-DefaultTimeout-time.Second), copyFn)
+
+	collect := func(matchers ...*labels.Matcher) []labels.Labels {
+		var actual []labels.Labels
+		require.NoError(t, c.ForEachActiveSeries(now, matchers, func(series labels.Labels) error {
+			actual = append(actual, series)
+			return nil
+		}))
+		return actual
+	}
+
+	// The inactive series is never returned.
+	assert.ElementsMatch(t, []labels.Labels{ls1, ls2}, collect())
+	assert.ElementsMatch(t, []labels.Labels{ls1, ls2}, collect(labels.MustNewMatcher(labels.MatchRegexp, "a", ".+")))
+	assert.ElementsMatch(t, []labels.Labels{ls2}, collect(labels.MustNewMatcher(labels.MatchEqual, "b", "2")))
+	assert.Empty(t, collect(labels.MustNewMatcher(labels.MatchEqual, "a", "2")))
+
+	// The iteration stops on error.
+	expectedErr := fmt.Errorf("stop")
+	calls := 0
+	err := c.ForEachActiveSeries(now, nil, func(labels.Labels) error {
+		calls++
+		return expectedErr
+	})
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 1, calls)
+}
+
 func TestActiveSeries_ShouldCorrectlyHandleFingerprintCollisions(t *testing.T) {
 	metric := labels.NewBuilder(labels.FromStrings("__name__", "logs"))
 	ls1 := metric.Set("_", "ypfajYg2lsv").Labels()
@@ -13,6 +13,8 @@ import (
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
 	github_com_grafana_mimir_pkg_mimirpb "github.com/grafana/mimir/pkg/mimirpb"
 	mimirpb "github.com/grafana/mimir/pkg/mimirpb"
 	grpc "google.golang.org/grpc"
@@ -24,12 +26,14 @@ import (
 	reflect "reflect"
 	strconv "strconv"
 	strings "strings"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -346,21 +350,21 @@ func (m *LabelValueSeriesCount) GetLabelValueSeries() map[string]uint64 {
 	return nil
 }
 
-type ReadRequest struct {
-	Queries []*QueryRequest `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+type ActiveSeriesRequest struct {
+	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *ReadRequest) Reset()      { *m = ReadRequest{} }
-func (*ReadRequest) ProtoMessage() {}
-func (*ReadRequest) Descriptor() ([]byte, []int) {
+func (m *ActiveSeriesRequest) Reset()      { *m = ActiveSeriesRequest{} }
+func (*ActiveSeriesRequest) ProtoMessage() {}
+func (*ActiveSeriesRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{6}
 }
-func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
+func (m *ActiveSeriesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ActiveSeriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ActiveSeriesRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -370,40 +374,40 @@ func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ReadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadRequest.Merge(m, src)
+func (m *ActiveSeriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveSeriesRequest.Merge(m, src)
 }
-func (m *ReadRequest) XXX_Size() int {
+func (m *ActiveSeriesRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ReadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
+func (m *ActiveSeriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveSeriesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
+var xxx_messageInfo_ActiveSeriesRequest proto.InternalMessageInfo
 
-func (m *ReadRequest) GetQueries() []*QueryRequest {
+func (m *ActiveSeriesRequest) GetMatchers() []*LabelMatcher {
 	if m != nil {
-		return m.Queries
+		return m.Matchers
 	}
 	return nil
 }
 
-type ReadResponse struct {
-	Results []*QueryResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+type ActiveSeriesResponse struct {
+	Metric []*mimirpb.Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
 }
 
-func (m *ReadResponse) Reset()      { *m = ReadResponse{} }
-func (*ReadResponse) ProtoMessage() {}
-func (*ReadResponse) Descriptor() ([]byte, []int) {
+func (m *ActiveSeriesResponse) Reset()      { *m = ActiveSeriesResponse{} }
+func (*ActiveSeriesResponse) ProtoMessage() {}
+func (*ActiveSeriesResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{7}
 }
-func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
+func (m *ActiveSeriesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ActiveSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ActiveSeriesResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -413,42 +417,40 @@ func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ReadResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadResponse.Merge(m, src)
+func (m *ActiveSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveSeriesResponse.Merge(m, src)
 }
-func (m *ReadResponse) XXX_Size() int {
+func (m *ActiveSeriesResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *ReadResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
+func (m *ActiveSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveSeriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
+var xxx_messageInfo_ActiveSeriesResponse proto.InternalMessageInfo
 
-func (m *ReadResponse) GetResults() []*QueryResponse {
+func (m *ActiveSeriesResponse) GetMetric() []*mimirpb.Metric {
 	if m != nil {
-		return m.Results
+		return m.Metric
 	}
 	return nil
 }
 
-type QueryRequest struct {
-	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+type SeriesChurnRequest struct {
+	WindowMs int64 `protobuf:"varint,1,opt,name=window_ms,json=windowMs,proto3" json:"window_ms,omitempty"`
 }
 
-func (m *QueryRequest) Reset()      { *m = QueryRequest{} }
-func (*QueryRequest) ProtoMessage() {}
-func (*QueryRequest) Descriptor() ([]byte, []int) {
+func (m *SeriesChurnRequest) Reset()      { *m = SeriesChurnRequest{} }
+func (*SeriesChurnRequest) ProtoMessage() {}
+func (*SeriesChurnRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{8}
 }
-func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
+func (m *SeriesChurnRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SeriesChurnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SeriesChurnRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -458,56 +460,40 @@ func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *QueryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryRequest.Merge(m, src)
+func (m *SeriesChurnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeriesChurnRequest.Merge(m, src)
 }
-func (m *QueryRequest) XXX_Size() int {
+func (m *SeriesChurnRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
+func (m *SeriesChurnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeriesChurnRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
-
-func (m *QueryRequest) GetStartTimestampMs() int64 {
-	if m != nil {
-		return m.StartTimestampMs
-	}
-	return 0
-}
+var xxx_messageInfo_SeriesChurnRequest proto.InternalMessageInfo
 
-func (m *QueryRequest) GetEndTimestampMs() int64 {
+func (m *SeriesChurnRequest) GetWindowMs() int64 {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.WindowMs
 	}
 	return 0
 }
 
-func (m *QueryRequest) GetMatchers() []*LabelMatcher {
-	if m != nil {
-		return m.Matchers
-	}
-	return nil
-}
-
-type ExemplarQueryRequest struct {
-	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+type SeriesChurnResponse struct {
+	Items []*MetricSeriesChurn `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (m *ExemplarQueryRequest) Reset()      { *m = ExemplarQueryRequest{} }
-func (*ExemplarQueryRequest) ProtoMessage() {}
-func (*ExemplarQueryRequest) Descriptor() ([]byte, []int) {
+func (m *SeriesChurnResponse) Reset()      { *m = SeriesChurnResponse{} }
+func (*SeriesChurnResponse) ProtoMessage() {}
+func (*SeriesChurnResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{9}
 }
-func (m *ExemplarQueryRequest) XXX_Unmarshal(b []byte) error {
+func (m *SeriesChurnResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SeriesChurnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExemplarQueryRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SeriesChurnResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -517,54 +503,42 @@ func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *ExemplarQueryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExemplarQueryRequest.Merge(m, src)
+func (m *SeriesChurnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeriesChurnResponse.Merge(m, src)
 }
-func (m *ExemplarQueryRequest) XXX_Size() int {
+func (m *SeriesChurnResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExemplarQueryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExemplarQueryRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ExemplarQueryRequest proto.InternalMessageInfo
-
-func (m *ExemplarQueryRequest) GetStartTimestampMs() int64 {
-	if m != nil {
-		return m.StartTimestampMs
-	}
-	return 0
+func (m *SeriesChurnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeriesChurnResponse.DiscardUnknown(m)
 }
 
-func (m *ExemplarQueryRequest) GetEndTimestampMs() int64 {
-	if m != nil {
-		return m.EndTimestampMs
-	}
-	return 0
-}
+var xxx_messageInfo_SeriesChurnResponse proto.InternalMessageInfo
 
-func (m *ExemplarQueryRequest) GetMatchers() []*LabelMatchers {
+func (m *SeriesChurnResponse) GetItems() []*MetricSeriesChurn {
 	if m != nil {
-		return m.Matchers
+		return m.Items
 	}
 	return nil
 }
 
-type QueryResponse struct {
-	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+type MetricSeriesChurn struct {
+	MetricName    string `protobuf:"bytes,1,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	CreatedSeries uint64 `protobuf:"varint,2,opt,name=created_series,json=createdSeries,proto3" json:"created_series,omitempty"`
+	RemovedSeries uint64 `protobuf:"varint,3,opt,name=removed_series,json=removedSeries,proto3" json:"removed_series,omitempty"`
 }
 
-func (m *QueryResponse) Reset()      { *m = QueryResponse{} }
-func (*QueryResponse) ProtoMessage() {}
-func (*QueryResponse) Descriptor() ([]byte, []int) {
+func (m *MetricSeriesChurn) Reset()      { *m = MetricSeriesChurn{} }
+func (*MetricSeriesChurn) ProtoMessage() {}
+func (*MetricSeriesChurn) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{10}
 }
-func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
+func (m *MetricSeriesChurn) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetricSeriesChurn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetricSeriesChurn.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -574,42 +548,53 @@ func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *QueryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryResponse.Merge(m, src)
+func (m *MetricSeriesChurn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricSeriesChurn.Merge(m, src)
 }
-func (m *QueryResponse) XXX_Size() int {
+func (m *MetricSeriesChurn) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
+func (m *MetricSeriesChurn) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricSeriesChurn.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
+var xxx_messageInfo_MetricSeriesChurn proto.InternalMessageInfo
 
-func (m *QueryResponse) GetTimeseries() []mimirpb.TimeSeries {
+func (m *MetricSeriesChurn) GetMetricName() string {
 	if m != nil {
-		return m.Timeseries
+		return m.MetricName
 	}
-	return nil
+	return ""
 }
 
-// QueryStreamResponse contains a batch of timeseries chunks or timeseries. Only one of these series will be populated.
-type QueryStreamResponse struct {
-	Chunkseries []TimeSeriesChunk    `protobuf:"bytes,1,rep,name=chunkseries,proto3" json:"chunkseries"`
-	Timeseries  []mimirpb.TimeSeries `protobuf:"bytes,2,rep,name=timeseries,proto3" json:"timeseries"`
+func (m *MetricSeriesChurn) GetCreatedSeries() uint64 {
+	if m != nil {
+		return m.CreatedSeries
+	}
+	return 0
 }
 
-func (m *QueryStreamResponse) Reset()      { *m = QueryStreamResponse{} }
-func (*QueryStreamResponse) ProtoMessage() {}
-func (*QueryStreamResponse) Descriptor() ([]byte, []int) {
+func (m *MetricSeriesChurn) GetRemovedSeries() uint64 {
+	if m != nil {
+		return m.RemovedSeries
+	}
+	return 0
+}
+
+type TSDBStatusRequest struct {
+}
+
+func (m *TSDBStatusRequest) Reset()      { *m = TSDBStatusRequest{} }
+func (*TSDBStatusRequest) ProtoMessage() {}
+func (*TSDBStatusRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{11}
 }
-func (m *QueryStreamResponse) XXX_Unmarshal(b []byte) error {
+func (m *TSDBStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TSDBStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryStreamResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TSDBStatusRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -619,47 +604,42 @@ func (m *QueryStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *QueryStreamResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryStreamResponse.Merge(m, src)
+func (m *TSDBStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TSDBStatusRequest.Merge(m, src)
 }
-func (m *QueryStreamResponse) XXX_Size() int {
+func (m *TSDBStatusRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryStreamResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryStreamResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_QueryStreamResponse proto.InternalMessageInfo
-
-func (m *QueryStreamResponse) GetChunkseries() []TimeSeriesChunk {
-	if m != nil {
-		return m.Chunkseries
-	}
-	return nil
+func (m *TSDBStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TSDBStatusRequest.DiscardUnknown(m)
 }
 
-func (m *QueryStreamResponse) GetTimeseries() []mimirpb.TimeSeries {
-	if m != nil {
-		return m.Timeseries
-	}
-	return nil
-}
+var xxx_messageInfo_TSDBStatusRequest proto.InternalMessageInfo
 
-type ExemplarQueryResponse struct {
-	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+type TSDBStatusResponse struct {
+	NumSeries     uint64 `protobuf:"varint,1,opt,name=num_series,json=numSeries,proto3" json:"num_series,omitempty"`
+	NumLabelPairs uint64 `protobuf:"varint,2,opt,name=num_label_pairs,json=numLabelPairs,proto3" json:"num_label_pairs,omitempty"`
+	ChunkCount    uint64 `protobuf:"varint,3,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	// Min and max time of the samples in the head, only meaningful if num_series > 0.
+	MinTime                     int64          `protobuf:"varint,4,opt,name=min_time,json=minTime,proto3" json:"min_time,omitempty"`
+	MaxTime                     int64          `protobuf:"varint,5,opt,name=max_time,json=maxTime,proto3" json:"max_time,omitempty"`
+	SeriesCountByMetricName     []TSDBStatItem `protobuf:"bytes,6,rep,name=series_count_by_metric_name,json=seriesCountByMetricName,proto3" json:"series_count_by_metric_name"`
+	LabelValueCountByLabelName  []TSDBStatItem `protobuf:"bytes,7,rep,name=label_value_count_by_label_name,json=labelValueCountByLabelName,proto3" json:"label_value_count_by_label_name"`
+	MemoryInBytesByLabelName    []TSDBStatItem `protobuf:"bytes,8,rep,name=memory_in_bytes_by_label_name,json=memoryInBytesByLabelName,proto3" json:"memory_in_bytes_by_label_name"`
+	SeriesCountByLabelValuePair []TSDBStatItem `protobuf:"bytes,9,rep,name=series_count_by_label_value_pair,json=seriesCountByLabelValuePair,proto3" json:"series_count_by_label_value_pair"`
 }
 
-func (m *ExemplarQueryResponse) Reset()      { *m = ExemplarQueryResponse{} }
-func (*ExemplarQueryResponse) ProtoMessage() {}
-func (*ExemplarQueryResponse) Descriptor() ([]byte, []int) {
+func (m *TSDBStatusResponse) Reset()      { *m = TSDBStatusResponse{} }
+func (*TSDBStatusResponse) ProtoMessage() {}
+func (*TSDBStatusResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_60f6df4f3586b478, []int{12}
 }
-func (m *ExemplarQueryResponse) XXX_Unmarshal(b []byte) error {
+func (m *TSDBStatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TSDBStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExemplarQueryResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TSDBStatusResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -669,107 +649,97 @@ func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *ExemplarQueryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExemplarQueryResponse.Merge(m, src)
+func (m *TSDBStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TSDBStatusResponse.Merge(m, src)
 }
-func (m *ExemplarQueryResponse) XXX_Size() int {
+func (m *TSDBStatusResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExemplarQueryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExemplarQueryResponse.DiscardUnknown(m)
+func (m *TSDBStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TSDBStatusResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExemplarQueryResponse proto.InternalMessageInfo
+var xxx_messageInfo_TSDBStatusResponse proto.InternalMessageInfo
 
-func (m *ExemplarQueryResponse) GetTimeseries() []mimirpb.TimeSeries {
+func (m *TSDBStatusResponse) GetNumSeries() uint64 {
 	if m != nil {
-		return m.Timeseries
+		return m.NumSeries
 	}
-	return nil
+	return 0
 }
 
-type LabelValuesRequest struct {
-	LabelName        string         `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
-	StartTimestampMs int64          `protobuf:"varint,2,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64          `protobuf:"varint,3,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         *LabelMatchers `protobuf:"bytes,4,opt,name=matchers,proto3" json:"matchers,omitempty"`
+func (m *TSDBStatusResponse) GetNumLabelPairs() uint64 {
+	if m != nil {
+		return m.NumLabelPairs
+	}
+	return 0
 }
 
-func (m *LabelValuesRequest) Reset()      { *m = LabelValuesRequest{} }
-func (*LabelValuesRequest) ProtoMessage() {}
-func (*LabelValuesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{13}
-}
-func (m *LabelValuesRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *LabelValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_LabelValuesRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *TSDBStatusResponse) GetChunkCount() uint64 {
+	if m != nil {
+		return m.ChunkCount
 	}
+	return 0
 }
-func (m *LabelValuesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelValuesRequest.Merge(m, src)
-}
-func (m *LabelValuesRequest) XXX_Size() int {
-	return m.Size()
-}
-func (m *LabelValuesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelValuesRequest.DiscardUnknown(m)
+
+func (m *TSDBStatusResponse) GetMinTime() int64 {
+	if m != nil {
+		return m.MinTime
+	}
+	return 0
 }
 
-var xxx_messageInfo_LabelValuesRequest proto.InternalMessageInfo
+func (m *TSDBStatusResponse) GetMaxTime() int64 {
+	if m != nil {
+		return m.MaxTime
+	}
+	return 0
+}
 
-func (m *LabelValuesRequest) GetLabelName() string {
+func (m *TSDBStatusResponse) GetSeriesCountByMetricName() []TSDBStatItem {
 	if m != nil {
-		return m.LabelName
+		return m.SeriesCountByMetricName
 	}
-	return ""
+	return nil
 }
 
-func (m *LabelValuesRequest) GetStartTimestampMs() int64 {
+func (m *TSDBStatusResponse) GetLabelValueCountByLabelName() []TSDBStatItem {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.LabelValueCountByLabelName
 	}
-	return 0
+	return nil
 }
 
-func (m *LabelValuesRequest) GetEndTimestampMs() int64 {
+func (m *TSDBStatusResponse) GetMemoryInBytesByLabelName() []TSDBStatItem {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.MemoryInBytesByLabelName
 	}
-	return 0
+	return nil
 }
 
-func (m *LabelValuesRequest) GetMatchers() *LabelMatchers {
+func (m *TSDBStatusResponse) GetSeriesCountByLabelValuePair() []TSDBStatItem {
 	if m != nil {
-		return m.Matchers
+		return m.SeriesCountByLabelValuePair
 	}
 	return nil
 }
 
-type LabelValuesResponse struct {
-	LabelValues []string `protobuf:"bytes,1,rep,name=label_values,json=labelValues,proto3" json:"label_values,omitempty"`
+type TSDBStatItem struct {
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value uint64 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (m *LabelValuesResponse) Reset()      { *m = LabelValuesResponse{} }
-func (*LabelValuesResponse) ProtoMessage() {}
-func (*LabelValuesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{14}
+func (m *TSDBStatItem) Reset()      { *m = TSDBStatItem{} }
+func (*TSDBStatItem) ProtoMessage() {}
+func (*TSDBStatItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{13}
 }
-func (m *LabelValuesResponse) XXX_Unmarshal(b []byte) error {
+func (m *TSDBStatItem) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *TSDBStatItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelValuesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_TSDBStatItem.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -779,42 +749,47 @@ func (m *LabelValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LabelValuesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelValuesResponse.Merge(m, src)
+func (m *TSDBStatItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TSDBStatItem.Merge(m, src)
 }
-func (m *LabelValuesResponse) XXX_Size() int {
+func (m *TSDBStatItem) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelValuesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelValuesResponse.DiscardUnknown(m)
+func (m *TSDBStatItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_TSDBStatItem.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelValuesResponse proto.InternalMessageInfo
+var xxx_messageInfo_TSDBStatItem proto.InternalMessageInfo
 
-func (m *LabelValuesResponse) GetLabelValues() []string {
+func (m *TSDBStatItem) GetName() string {
 	if m != nil {
-		return m.LabelValues
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-type LabelNamesRequest struct {
-	StartTimestampMs int64          `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64          `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         *LabelMatchers `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
+func (m *TSDBStatItem) GetValue() uint64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
 }
 
-func (m *LabelNamesRequest) Reset()      { *m = LabelNamesRequest{} }
-func (*LabelNamesRequest) ProtoMessage() {}
-func (*LabelNamesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{15}
+type ReadRequest struct {
+	Queries []*QueryRequest `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
 }
-func (m *LabelNamesRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *ReadRequest) Reset()      { *m = ReadRequest{} }
+func (*ReadRequest) ProtoMessage() {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{14}
+}
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelNamesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -824,54 +799,40 @@ func (m *LabelNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *LabelNamesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelNamesRequest.Merge(m, src)
+func (m *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(m, src)
 }
-func (m *LabelNamesRequest) XXX_Size() int {
+func (m *ReadRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelNamesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelNamesRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_LabelNamesRequest proto.InternalMessageInfo
-
-func (m *LabelNamesRequest) GetStartTimestampMs() int64 {
-	if m != nil {
-		return m.StartTimestampMs
-	}
-	return 0
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
 }
 
-func (m *LabelNamesRequest) GetEndTimestampMs() int64 {
-	if m != nil {
-		return m.EndTimestampMs
-	}
-	return 0
-}
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
-func (m *LabelNamesRequest) GetMatchers() *LabelMatchers {
+func (m *ReadRequest) GetQueries() []*QueryRequest {
 	if m != nil {
-		return m.Matchers
+		return m.Queries
 	}
 	return nil
 }
 
-type LabelNamesResponse struct {
-	LabelNames []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
+type ReadResponse struct {
+	Results []*QueryResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (m *LabelNamesResponse) Reset()      { *m = LabelNamesResponse{} }
-func (*LabelNamesResponse) ProtoMessage() {}
-func (*LabelNamesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{16}
+func (m *ReadResponse) Reset()      { *m = ReadResponse{} }
+func (*ReadResponse) ProtoMessage() {}
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{15}
 }
-func (m *LabelNamesResponse) XXX_Unmarshal(b []byte) error {
+func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelNamesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -881,39 +842,42 @@ func (m *LabelNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LabelNamesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelNamesResponse.Merge(m, src)
+func (m *ReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadResponse.Merge(m, src)
 }
-func (m *LabelNamesResponse) XXX_Size() int {
+func (m *ReadResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelNamesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelNamesResponse.DiscardUnknown(m)
+func (m *ReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelNamesResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
 
-func (m *LabelNamesResponse) GetLabelNames() []string {
+func (m *ReadResponse) GetResults() []*QueryResponse {
 	if m != nil {
-		return m.LabelNames
+		return m.Results
 	}
 	return nil
 }
 
-type UserStatsRequest struct {
+type QueryRequest struct {
+	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *UserStatsRequest) Reset()      { *m = UserStatsRequest{} }
-func (*UserStatsRequest) ProtoMessage() {}
-func (*UserStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{17}
+func (m *QueryRequest) Reset()      { *m = QueryRequest{} }
+func (*QueryRequest) ProtoMessage() {}
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{16}
 }
-func (m *UserStatsRequest) XXX_Unmarshal(b []byte) error {
+func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UserStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UserStatsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -923,36 +887,56 @@ func (m *UserStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *UserStatsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserStatsRequest.Merge(m, src)
+func (m *QueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRequest.Merge(m, src)
 }
-func (m *UserStatsRequest) XXX_Size() int {
+func (m *QueryRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *UserStatsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserStatsRequest.DiscardUnknown(m)
+func (m *QueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserStatsRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
 
-type UserStatsResponse struct {
-	IngestionRate     float64 `protobuf:"fixed64,1,opt,name=ingestion_rate,json=ingestionRate,proto3" json:"ingestion_rate,omitempty"`
-	NumSeries         uint64  `protobuf:"varint,2,opt,name=num_series,json=numSeries,proto3" json:"num_series,omitempty"`
-	ApiIngestionRate  float64 `protobuf:"fixed64,3,opt,name=api_ingestion_rate,json=apiIngestionRate,proto3" json:"api_ingestion_rate,omitempty"`
-	RuleIngestionRate float64 `protobuf:"fixed64,4,opt,name=rule_ingestion_rate,json=ruleIngestionRate,proto3" json:"rule_ingestion_rate,omitempty"`
+func (m *QueryRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
 }
 
-func (m *UserStatsResponse) Reset()      { *m = UserStatsResponse{} }
-func (*UserStatsResponse) ProtoMessage() {}
-func (*UserStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{18}
+func (m *QueryRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
 }
-func (m *UserStatsResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *QueryRequest) GetMatchers() []*LabelMatcher {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+type ExemplarQueryRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *ExemplarQueryRequest) Reset()      { *m = ExemplarQueryRequest{} }
+func (*ExemplarQueryRequest) ProtoMessage() {}
+func (*ExemplarQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{17}
+}
+func (m *ExemplarQueryRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UserStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UserStatsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ExemplarQueryRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -962,62 +946,54 @@ func (m *UserStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *UserStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserStatsResponse.Merge(m, src)
+func (m *ExemplarQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryRequest.Merge(m, src)
 }
-func (m *UserStatsResponse) XXX_Size() int {
+func (m *ExemplarQueryRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *UserStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserStatsResponse.DiscardUnknown(m)
+func (m *ExemplarQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserStatsResponse proto.InternalMessageInfo
-
-func (m *UserStatsResponse) GetIngestionRate() float64 {
-	if m != nil {
-		return m.IngestionRate
-	}
-	return 0
-}
+var xxx_messageInfo_ExemplarQueryRequest proto.InternalMessageInfo
 
-func (m *UserStatsResponse) GetNumSeries() uint64 {
+func (m *ExemplarQueryRequest) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.NumSeries
+		return m.StartTimestampMs
 	}
 	return 0
 }
 
-func (m *UserStatsResponse) GetApiIngestionRate() float64 {
+func (m *ExemplarQueryRequest) GetEndTimestampMs() int64 {
 	if m != nil {
-		return m.ApiIngestionRate
+		return m.EndTimestampMs
 	}
 	return 0
 }
 
-func (m *UserStatsResponse) GetRuleIngestionRate() float64 {
+func (m *ExemplarQueryRequest) GetMatchers() []*LabelMatchers {
 	if m != nil {
-		return m.RuleIngestionRate
+		return m.Matchers
 	}
-	return 0
+	return nil
 }
 
-type UserIDStatsResponse struct {
-	UserId string             `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Data   *UserStatsResponse `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+type QueryResponse struct {
+	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
 }
 
-func (m *UserIDStatsResponse) Reset()      { *m = UserIDStatsResponse{} }
-func (*UserIDStatsResponse) ProtoMessage() {}
-func (*UserIDStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{19}
+func (m *QueryResponse) Reset()      { *m = QueryResponse{} }
+func (*QueryResponse) ProtoMessage() {}
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{18}
 }
-func (m *UserIDStatsResponse) XXX_Unmarshal(b []byte) error {
+func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UserIDStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UserIDStatsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1027,47 +1003,44 @@ func (m *UserIDStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *UserIDStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserIDStatsResponse.Merge(m, src)
+func (m *QueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryResponse.Merge(m, src)
 }
-func (m *UserIDStatsResponse) XXX_Size() int {
+func (m *QueryResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *UserIDStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserIDStatsResponse.DiscardUnknown(m)
+func (m *QueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserIDStatsResponse proto.InternalMessageInfo
-
-func (m *UserIDStatsResponse) GetUserId() string {
-	if m != nil {
-		return m.UserId
-	}
-	return ""
-}
+var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
 
-func (m *UserIDStatsResponse) GetData() *UserStatsResponse {
+func (m *QueryResponse) GetTimeseries() []mimirpb.TimeSeries {
 	if m != nil {
-		return m.Data
+		return m.Timeseries
 	}
 	return nil
 }
 
-type UsersStatsResponse struct {
-	Stats []*UserIDStatsResponse `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+// QueryStreamResponse contains a batch of timeseries chunks or timeseries. Only one of these series will be populated.
+// The last message of the stream contains the query statistics instead.
+type QueryStreamResponse struct {
+	Chunkseries []TimeSeriesChunk    `protobuf:"bytes,1,rep,name=chunkseries,proto3" json:"chunkseries"`
+	Timeseries  []mimirpb.TimeSeries `protobuf:"bytes,2,rep,name=timeseries,proto3" json:"timeseries"`
+	Stats       *QueryStreamStats    `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
 }
 
-func (m *UsersStatsResponse) Reset()      { *m = UsersStatsResponse{} }
-func (*UsersStatsResponse) ProtoMessage() {}
-func (*UsersStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{20}
+func (m *QueryStreamResponse) Reset()      { *m = QueryStreamResponse{} }
+func (*QueryStreamResponse) ProtoMessage() {}
+func (*QueryStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{19}
 }
-func (m *UsersStatsResponse) XXX_Unmarshal(b []byte) error {
+func (m *QueryStreamResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UsersStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UsersStatsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryStreamResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1077,42 +1050,70 @@ func (m *UsersStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *UsersStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UsersStatsResponse.Merge(m, src)
+func (m *QueryStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStreamResponse.Merge(m, src)
 }
-func (m *UsersStatsResponse) XXX_Size() int {
+func (m *QueryStreamResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *UsersStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UsersStatsResponse.DiscardUnknown(m)
+func (m *QueryStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStreamResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UsersStatsResponse proto.InternalMessageInfo
+var xxx_messageInfo_QueryStreamResponse proto.InternalMessageInfo
 
-func (m *UsersStatsResponse) GetStats() []*UserIDStatsResponse {
+func (m *QueryStreamResponse) GetChunkseries() []TimeSeriesChunk {
 	if m != nil {
-		return m.Stats
+		return m.Chunkseries
 	}
 	return nil
 }
 
-type MetricsForLabelMatchersRequest struct {
-	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	MatchersSet      []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers_set,json=matchersSet,proto3" json:"matchers_set,omitempty"`
+func (m *QueryStreamResponse) GetTimeseries() []mimirpb.TimeSeries {
+	if m != nil {
+		return m.Timeseries
+	}
+	return nil
 }
 
-func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
-func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
-func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{21}
+func (m *QueryStreamResponse) GetStats() *QueryStreamStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
+
+// QueryStreamStats contains the statistics about the work done by the ingester to execute a QueryStream request.
+type QueryStreamStats struct {
+	// The time spent by the ingester to execute the request.
+	WallTime time.Duration `protobuf:"bytes,1,opt,name=wall_time,json=wallTime,proto3,stdduration" json:"wall_time"`
+	// The time spent selecting the series matching the request matchers, which includes looking up their postings.
+	SelectTime time.Duration `protobuf:"bytes,2,opt,name=select_time,json=selectTime,proto3,stdduration" json:"select_time"`
+	// The number of series touched by the request.
+	SeriesCount uint64 `protobuf:"varint,3,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
+	// The number of samples touched by the request.
+	SamplesCount uint64 `protobuf:"varint,4,opt,name=samples_count,json=samplesCount,proto3" json:"samples_count,omitempty"`
+	// The number of chunks returned, only set when streaming chunks.
+	ChunksCount uint64 `protobuf:"varint,5,opt,name=chunks_count,json=chunksCount,proto3" json:"chunks_count,omitempty"`
+	// The number of bytes of the chunks returned, only set when streaming chunks.
+	ChunkBytes uint64 `protobuf:"varint,6,opt,name=chunk_bytes,json=chunkBytes,proto3" json:"chunk_bytes,omitempty"`
+	// The number of chunks returned from the TSDB head, only set when streaming chunks.
+	HeadChunksCount uint64 `protobuf:"varint,7,opt,name=head_chunks_count,json=headChunksCount,proto3" json:"head_chunks_count,omitempty"`
+	// The number of chunks returned from the TSDB blocks on the ingester disk, only set when streaming chunks.
+	BlockChunksCount uint64 `protobuf:"varint,8,opt,name=block_chunks_count,json=blockChunksCount,proto3" json:"block_chunks_count,omitempty"`
+}
+
+func (m *QueryStreamStats) Reset()      { *m = QueryStreamStats{} }
+func (*QueryStreamStats) ProtoMessage() {}
+func (*QueryStreamStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{20}
+}
+func (m *QueryStreamStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryStreamStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsForLabelMatchersRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryStreamStats.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1122,54 +1123,89 @@ func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic boo
 		return b[:n], nil
 	}
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsForLabelMatchersRequest.Merge(m, src)
+func (m *QueryStreamStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStreamStats.Merge(m, src)
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Size() int {
+func (m *QueryStreamStats) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsForLabelMatchersRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsForLabelMatchersRequest.DiscardUnknown(m)
+func (m *QueryStreamStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStreamStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsForLabelMatchersRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryStreamStats proto.InternalMessageInfo
 
-func (m *MetricsForLabelMatchersRequest) GetStartTimestampMs() int64 {
+func (m *QueryStreamStats) GetWallTime() time.Duration {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.WallTime
 	}
 	return 0
 }
 
-func (m *MetricsForLabelMatchersRequest) GetEndTimestampMs() int64 {
+func (m *QueryStreamStats) GetSelectTime() time.Duration {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.SelectTime
 	}
 	return 0
 }
 
-func (m *MetricsForLabelMatchersRequest) GetMatchersSet() []*LabelMatchers {
+func (m *QueryStreamStats) GetSeriesCount() uint64 {
 	if m != nil {
-		return m.MatchersSet
+		return m.SeriesCount
 	}
-	return nil
+	return 0
 }
 
-type MetricsForLabelMatchersResponse struct {
-	Metric []*mimirpb.Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
+func (m *QueryStreamStats) GetSamplesCount() uint64 {
+	if m != nil {
+		return m.SamplesCount
+	}
+	return 0
 }
 
-func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
-func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
-func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{22}
+func (m *QueryStreamStats) GetChunksCount() uint64 {
+	if m != nil {
+		return m.ChunksCount
+	}
+	return 0
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *QueryStreamStats) GetChunkBytes() uint64 {
+	if m != nil {
+		return m.ChunkBytes
+	}
+	return 0
+}
+
+func (m *QueryStreamStats) GetHeadChunksCount() uint64 {
+	if m != nil {
+		return m.HeadChunksCount
+	}
+	return 0
+}
+
+func (m *QueryStreamStats) GetBlockChunksCount() uint64 {
+	if m != nil {
+		return m.BlockChunksCount
+	}
+	return 0
+}
+
+type ExemplarQueryResponse struct {
+	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+}
+
+func (m *ExemplarQueryResponse) Reset()      { *m = ExemplarQueryResponse{} }
+func (*ExemplarQueryResponse) ProtoMessage() {}
+func (*ExemplarQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{21}
+}
+func (m *ExemplarQueryResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsForLabelMatchersResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ExemplarQueryResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1179,39 +1215,43 @@ func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bo
 		return b[:n], nil
 	}
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsForLabelMatchersResponse.Merge(m, src)
+func (m *ExemplarQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryResponse.Merge(m, src)
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Size() int {
+func (m *ExemplarQueryResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsForLabelMatchersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsForLabelMatchersResponse.DiscardUnknown(m)
+func (m *ExemplarQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsForLabelMatchersResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExemplarQueryResponse proto.InternalMessageInfo
 
-func (m *MetricsForLabelMatchersResponse) GetMetric() []*mimirpb.Metric {
+func (m *ExemplarQueryResponse) GetTimeseries() []mimirpb.TimeSeries {
 	if m != nil {
-		return m.Metric
+		return m.Timeseries
 	}
 	return nil
 }
 
-type MetricsMetadataRequest struct {
+type LabelValuesRequest struct {
+	LabelName        string         `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	StartTimestampMs int64          `protobuf:"varint,2,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64          `protobuf:"varint,3,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         *LabelMatchers `protobuf:"bytes,4,opt,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
-func (*MetricsMetadataRequest) ProtoMessage() {}
-func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{23}
+func (m *LabelValuesRequest) Reset()      { *m = LabelValuesRequest{} }
+func (*LabelValuesRequest) ProtoMessage() {}
+func (*LabelValuesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{22}
 }
-func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
+func (m *LabelValuesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsMetadataRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1221,33 +1261,61 @@ func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *MetricsMetadataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsMetadataRequest.Merge(m, src)
+func (m *LabelValuesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesRequest.Merge(m, src)
 }
-func (m *MetricsMetadataRequest) XXX_Size() int {
+func (m *LabelValuesRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsMetadataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsMetadataRequest.DiscardUnknown(m)
+func (m *LabelValuesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsMetadataRequest proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesRequest proto.InternalMessageInfo
 
-type MetricsMetadataResponse struct {
-	Metadata []*mimirpb.MetricMetadata `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+func (m *LabelValuesRequest) GetLabelName() string {
+	if m != nil {
+		return m.LabelName
+	}
+	return ""
 }
 
-func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
-func (*MetricsMetadataResponse) ProtoMessage() {}
-func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{24}
+func (m *LabelValuesRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
 }
-func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelValuesRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
+}
+
+func (m *LabelValuesRequest) GetMatchers() *LabelMatchers {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+type LabelValuesResponse struct {
+	LabelValues []string `protobuf:"bytes,1,rep,name=label_values,json=labelValues,proto3" json:"label_values,omitempty"`
+}
+
+func (m *LabelValuesResponse) Reset()      { *m = LabelValuesResponse{} }
+func (*LabelValuesResponse) ProtoMessage() {}
+func (*LabelValuesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{23}
+}
+func (m *LabelValuesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsMetadataResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1257,43 +1325,42 @@ func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *MetricsMetadataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsMetadataResponse.Merge(m, src)
+func (m *LabelValuesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesResponse.Merge(m, src)
 }
-func (m *MetricsMetadataResponse) XXX_Size() int {
+func (m *LabelValuesResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsMetadataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsMetadataResponse.DiscardUnknown(m)
+func (m *LabelValuesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsMetadataResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesResponse proto.InternalMessageInfo
 
-func (m *MetricsMetadataResponse) GetMetadata() []*mimirpb.MetricMetadata {
+func (m *LabelValuesResponse) GetLabelValues() []string {
 	if m != nil {
-		return m.Metadata
+		return m.LabelValues
 	}
 	return nil
 }
 
-type TimeSeriesChunk struct {
-	FromIngesterId string                                              `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
-	UserId         string                                              `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Labels         []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,3,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"labels"`
-	Chunks         []Chunk                                             `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks"`
+type LabelNamesRequest struct {
+	StartTimestampMs int64          `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64          `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         *LabelMatchers `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
-func (*TimeSeriesChunk) ProtoMessage() {}
-func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{25}
+func (m *LabelNamesRequest) Reset()      { *m = LabelNamesRequest{} }
+func (*LabelNamesRequest) ProtoMessage() {}
+func (*LabelNamesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{24}
 }
-func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
+func (m *LabelNamesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeriesChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelNamesRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1303,57 +1370,54 @@ func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *TimeSeriesChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeriesChunk.Merge(m, src)
+func (m *LabelNamesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNamesRequest.Merge(m, src)
 }
-func (m *TimeSeriesChunk) XXX_Size() int {
+func (m *LabelNamesRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeriesChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeriesChunk.DiscardUnknown(m)
+func (m *LabelNamesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNamesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TimeSeriesChunk proto.InternalMessageInfo
+var xxx_messageInfo_LabelNamesRequest proto.InternalMessageInfo
 
-func (m *TimeSeriesChunk) GetFromIngesterId() string {
+func (m *LabelNamesRequest) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.FromIngesterId
+		return m.StartTimestampMs
 	}
-	return ""
+	return 0
 }
 
-func (m *TimeSeriesChunk) GetUserId() string {
+func (m *LabelNamesRequest) GetEndTimestampMs() int64 {
 	if m != nil {
-		return m.UserId
+		return m.EndTimestampMs
 	}
-	return ""
+	return 0
 }
 
-func (m *TimeSeriesChunk) GetChunks() []Chunk {
+func (m *LabelNamesRequest) GetMatchers() *LabelMatchers {
 	if m != nil {
-		return m.Chunks
+		return m.Matchers
 	}
 	return nil
 }
 
-type Chunk struct {
-	StartTimestampMs int64  `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64  `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Encoding         int32  `protobuf:"varint,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
-	Data             []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+type LabelNamesResponse struct {
+	LabelNames []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
 }
 
-func (m *Chunk) Reset()      { *m = Chunk{} }
-func (*Chunk) ProtoMessage() {}
-func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{26}
+func (m *LabelNamesResponse) Reset()      { *m = LabelNamesResponse{} }
+func (*LabelNamesResponse) ProtoMessage() {}
+func (*LabelNamesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{25}
 }
-func (m *Chunk) XXX_Unmarshal(b []byte) error {
+func (m *LabelNamesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelNamesResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1363,61 +1427,39 @@ func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Chunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Chunk.Merge(m, src)
+func (m *LabelNamesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNamesResponse.Merge(m, src)
 }
-func (m *Chunk) XXX_Size() int {
+func (m *LabelNamesResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *Chunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_Chunk.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Chunk proto.InternalMessageInfo
-
-func (m *Chunk) GetStartTimestampMs() int64 {
-	if m != nil {
-		return m.StartTimestampMs
-	}
-	return 0
-}
-
-func (m *Chunk) GetEndTimestampMs() int64 {
-	if m != nil {
-		return m.EndTimestampMs
-	}
-	return 0
+func (m *LabelNamesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNamesResponse.DiscardUnknown(m)
 }
 
-func (m *Chunk) GetEncoding() int32 {
-	if m != nil {
-		return m.Encoding
-	}
-	return 0
-}
+var xxx_messageInfo_LabelNamesResponse proto.InternalMessageInfo
 
-func (m *Chunk) GetData() []byte {
+func (m *LabelNamesResponse) GetLabelNames() []string {
 	if m != nil {
-		return m.Data
+		return m.LabelNames
 	}
 	return nil
 }
 
-type LabelMatchers struct {
-	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
+type UserStatsRequest struct {
 }
 
-func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
-func (*LabelMatchers) ProtoMessage() {}
-func (*LabelMatchers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{27}
+func (m *UserStatsRequest) Reset()      { *m = UserStatsRequest{} }
+func (*UserStatsRequest) ProtoMessage() {}
+func (*UserStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{26}
 }
-func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
+func (m *UserStatsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UserStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelMatchers.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UserStatsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1427,42 +1469,36 @@ func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *LabelMatchers) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelMatchers.Merge(m, src)
+func (m *UserStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserStatsRequest.Merge(m, src)
 }
-func (m *LabelMatchers) XXX_Size() int {
+func (m *UserStatsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelMatchers) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelMatchers.DiscardUnknown(m)
+func (m *UserStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserStatsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelMatchers proto.InternalMessageInfo
-
-func (m *LabelMatchers) GetMatchers() []*LabelMatcher {
-	if m != nil {
-		return m.Matchers
-	}
-	return nil
-}
+var xxx_messageInfo_UserStatsRequest proto.InternalMessageInfo
 
-type LabelMatcher struct {
-	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MatchType" json:"type,omitempty"`
-	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+type UserStatsResponse struct {
+	IngestionRate     float64 `protobuf:"fixed64,1,opt,name=ingestion_rate,json=ingestionRate,proto3" json:"ingestion_rate,omitempty"`
+	NumSeries         uint64  `protobuf:"varint,2,opt,name=num_series,json=numSeries,proto3" json:"num_series,omitempty"`
+	ApiIngestionRate  float64 `protobuf:"fixed64,3,opt,name=api_ingestion_rate,json=apiIngestionRate,proto3" json:"api_ingestion_rate,omitempty"`
+	RuleIngestionRate float64 `protobuf:"fixed64,4,opt,name=rule_ingestion_rate,json=ruleIngestionRate,proto3" json:"rule_ingestion_rate,omitempty"`
 }
 
-func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
-func (*LabelMatcher) ProtoMessage() {}
-func (*LabelMatcher) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{28}
+func (m *UserStatsResponse) Reset()      { *m = UserStatsResponse{} }
+func (*UserStatsResponse) ProtoMessage() {}
+func (*UserStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{27}
 }
-func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
+func (m *UserStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UserStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelMatcher.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UserStatsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1472,57 +1508,62 @@ func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *LabelMatcher) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelMatcher.Merge(m, src)
+func (m *UserStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserStatsResponse.Merge(m, src)
 }
-func (m *LabelMatcher) XXX_Size() int {
+func (m *UserStatsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelMatcher) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelMatcher.DiscardUnknown(m)
+func (m *UserStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserStatsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelMatcher proto.InternalMessageInfo
+var xxx_messageInfo_UserStatsResponse proto.InternalMessageInfo
 
-func (m *LabelMatcher) GetType() MatchType {
+func (m *UserStatsResponse) GetIngestionRate() float64 {
 	if m != nil {
-		return m.Type
+		return m.IngestionRate
 	}
-	return EQUAL
+	return 0
 }
 
-func (m *LabelMatcher) GetName() string {
+func (m *UserStatsResponse) GetNumSeries() uint64 {
 	if m != nil {
-		return m.Name
+		return m.NumSeries
 	}
-	return ""
+	return 0
 }
 
-func (m *LabelMatcher) GetValue() string {
+func (m *UserStatsResponse) GetApiIngestionRate() float64 {
 	if m != nil {
-		return m.Value
+		return m.ApiIngestionRate
 	}
-	return ""
+	return 0
 }
 
-type TimeSeriesFile struct {
-	FromIngesterId string `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
-	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Filename       string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	Data           []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+func (m *UserStatsResponse) GetRuleIngestionRate() float64 {
+	if m != nil {
+		return m.RuleIngestionRate
+	}
+	return 0
 }
 
-func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
-func (*TimeSeriesFile) ProtoMessage() {}
-func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{29}
+type UserIDStatsResponse struct {
+	UserId string             `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Data   *UserStatsResponse `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
-func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
+
+func (m *UserIDStatsResponse) Reset()      { *m = UserIDStatsResponse{} }
+func (*UserIDStatsResponse) ProtoMessage() {}
+func (*UserIDStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{28}
+}
+func (m *UserIDStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UserIDStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeriesFile.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UserIDStatsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1532,472 +1573,738 @@ func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *TimeSeriesFile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeriesFile.Merge(m, src)
+func (m *UserIDStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserIDStatsResponse.Merge(m, src)
 }
-func (m *TimeSeriesFile) XXX_Size() int {
+func (m *UserIDStatsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeriesFile) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeriesFile.DiscardUnknown(m)
+func (m *UserIDStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserIDStatsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TimeSeriesFile proto.InternalMessageInfo
+var xxx_messageInfo_UserIDStatsResponse proto.InternalMessageInfo
 
-func (m *TimeSeriesFile) GetFromIngesterId() string {
+func (m *UserIDStatsResponse) GetUserId() string {
 	if m != nil {
-		return m.FromIngesterId
+		return m.UserId
 	}
 	return ""
 }
 
-func (m *TimeSeriesFile) GetUserId() string {
+func (m *UserIDStatsResponse) GetData() *UserStatsResponse {
 	if m != nil {
-		return m.UserId
+		return m.Data
 	}
-	return ""
+	return nil
 }
 
-func (m *TimeSeriesFile) GetFilename() string {
-	if m != nil {
-		return m.Filename
+type UsersStatsResponse struct {
+	Stats []*UserIDStatsResponse `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (m *UsersStatsResponse) Reset()      { *m = UsersStatsResponse{} }
+func (*UsersStatsResponse) ProtoMessage() {}
+func (*UsersStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{29}
+}
+func (m *UsersStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UsersStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UsersStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (m *UsersStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsersStatsResponse.Merge(m, src)
+}
+func (m *UsersStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UsersStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsersStatsResponse.DiscardUnknown(m)
 }
 
-func (m *TimeSeriesFile) GetData() []byte {
+var xxx_messageInfo_UsersStatsResponse proto.InternalMessageInfo
+
+func (m *UsersStatsResponse) GetStats() []*UserIDStatsResponse {
 	if m != nil {
-		return m.Data
+		return m.Stats
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("cortex.MatchType", MatchType_name, MatchType_value)
-	proto.RegisterType((*LabelNamesAndValuesRequest)(nil), "cortex.LabelNamesAndValuesRequest")
-	proto.RegisterType((*LabelNamesAndValuesResponse)(nil), "cortex.LabelNamesAndValuesResponse")
-	proto.RegisterType((*LabelValues)(nil), "cortex.LabelValues")
-	proto.RegisterType((*LabelValuesCardinalityRequest)(nil), "cortex.LabelValuesCardinalityRequest")
-	proto.RegisterType((*LabelValuesCardinalityResponse)(nil), "cortex.LabelValuesCardinalityResponse")
-	proto.RegisterType((*LabelValueSeriesCount)(nil), "cortex.LabelValueSeriesCount")
-	proto.RegisterMapType((map[string]uint64)(nil), "cortex.LabelValueSeriesCount.LabelValueSeriesEntry")
-	proto.RegisterType((*ReadRequest)(nil), "cortex.ReadRequest")
-	proto.RegisterType((*ReadResponse)(nil), "cortex.ReadResponse")
-	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
-	proto.RegisterType((*ExemplarQueryRequest)(nil), "cortex.ExemplarQueryRequest")
-	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
-	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
-	proto.RegisterType((*ExemplarQueryResponse)(nil), "cortex.ExemplarQueryResponse")
-	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
-	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
-	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
-	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
-	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
-	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
-	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
-	proto.RegisterType((*UsersStatsResponse)(nil), "cortex.UsersStatsResponse")
-	proto.RegisterType((*MetricsForLabelMatchersRequest)(nil), "cortex.MetricsForLabelMatchersRequest")
-	proto.RegisterType((*MetricsForLabelMatchersResponse)(nil), "cortex.MetricsForLabelMatchersResponse")
-	proto.RegisterType((*MetricsMetadataRequest)(nil), "cortex.MetricsMetadataRequest")
-	proto.RegisterType((*MetricsMetadataResponse)(nil), "cortex.MetricsMetadataResponse")
-	proto.RegisterType((*TimeSeriesChunk)(nil), "cortex.TimeSeriesChunk")
-	proto.RegisterType((*Chunk)(nil), "cortex.Chunk")
-	proto.RegisterType((*LabelMatchers)(nil), "cortex.LabelMatchers")
-	proto.RegisterType((*LabelMatcher)(nil), "cortex.LabelMatcher")
-	proto.RegisterType((*TimeSeriesFile)(nil), "cortex.TimeSeriesFile")
+type MetricsForLabelMatchersRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	MatchersSet      []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers_set,json=matchersSet,proto3" json:"matchers_set,omitempty"`
 }
 
-func init() { proto.RegisterFile("ingester.proto", fileDescriptor_60f6df4f3586b478) }
-
-var fileDescriptor_60f6df4f3586b478 = []byte{
-	// 1416 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0xdb, 0xc6,
-	0x12, 0xd7, 0x4a, 0xb2, 0x6c, 0x8d, 0x64, 0x45, 0x5e, 0xc7, 0xb6, 0xc2, 0x3c, 0xd3, 0x7e, 0x7c,
-	0x48, 0x9e, 0xfa, 0x11, 0x39, 0x71, 0x5a, 0x20, 0x09, 0x5a, 0x04, 0xb6, 0xe3, 0x24, 0x6e, 0x62,
-	0x3b, 0xa1, 0x9d, 0xb6, 0x28, 0x50, 0x08, 0x2b, 0x69, 0x6d, 0x13, 0x26, 0x29, 0x85, 0x5c, 0x16,
-	0xf1, 0xad, 0x40, 0xef, 0x6d, 0xd1, 0x53, 0x4f, 0x05, 0x7a, 0xeb, 0xb9, 0x97, 0xde, 0x7a, 0xce,
-	0x31, 0xc7, 0xa0, 0x87, 0xa0, 0x51, 0x2e, 0xed, 0x2d, 0x7f, 0x42, 0xc1, 0xdd, 0x25, 0x45, 0x52,
-	0xf4, 0x47, 0x8a, 0x24, 0x27, 0x6b, 0x67, 0x7e, 0x33, 0xfb, 0xdb, 0x99, 0xd9, 0x99, 0xa5, 0xa1,
-	0x62, 0xd8, 0xbb, 0xd4, 0x65, 0xd4, 0x69, 0xf4, 0x9c, 0x2e, 0xeb, 0xe2, 0x42, 0xbb, 0xeb, 0x30,
-	0xfa, 0x48, 0xb9, 0xb0, 0x6b, 0xb0, 0x3d, 0xaf, 0xd5, 0x68, 0x77, 0xad, 0x85, 0xdd, 0xee, 0x6e,
-	0x77, 0x81, 0xab, 0x5b, 0xde, 0x0e, 0x5f, 0xf1, 0x05, 0xff, 0x25, 0xcc, 0x94, 0x8b, 0x51, 0xb8,
-	0x43, 0x76, 0x88, 0x4d, 0x16, 0x2c, 0xc3, 0x32, 0x9c, 0x85, 0xde, 0xfe, 0xae, 0xf8, 0xd5, 0x6b,
-	0x89, 0xbf, 0xc2, 0x42, 0xdb, 0x00, 0xe5, 0x2e, 0x69, 0x51, 0x73, 0x83, 0x58, 0xd4, 0x5d, 0xb2,
-	0x3b, 0x9f, 0x12, 0xd3, 0xa3, 0xae, 0x4e, 0x1f, 0x7a, 0xd4, 0x65, 0xf8, 0x22, 0x8c, 0x59, 0x84,
-	0xb5, 0xf7, 0xa8, 0xe3, 0xd6, 0xd0, 0x7c, 0xae, 0x5e, 0x5a, 0x3c, 0xdd, 0x10, 0xcc, 0x1a, 0xdc,
-	0x6a, 0x5d, 0x28, 0xf5, 0x10, 0xa5, 0xdd, 0x86, 0xb3, 0xa9, 0xfe, 0xdc, 0x5e, 0xd7, 0x76, 0x29,
-	0x7e, 0x07, 0x46, 0x0c, 0x46, 0xad, 0xc0, 0xdb, 0x64, 0xcc, 0x9b, 0xc4, 0x0a, 0x84, 0x76, 0x03,
-	0x4a, 0x11, 0x29, 0x9e, 0x05, 0x30, 0xfd, 0x65, 0xd3, 0x26, 0x16, 0xad, 0xa1, 0x79, 0x54, 0x2f,
-	0xea, 0x45, 0x33, 0xd8, 0x0a, 0x4f, 0x43, 0xe1, 0x2b, 0x0e, 0xac, 0x65, 0xe7, 0x73, 0xf5, 0xa2,
-	0x2e, 0x57, 0x9a, 0x03, 0xb3, 0x11, 0x2f, 0x2b, 0xc4, 0xe9, 0x18, 0x36, 0x31, 0x0d, 0x76, 0x10,
-	0x1c, 0x71, 0x0e, 0x4a, 0x03, 0xbf, 0x82, 0x57, 0x51, 0x87, 0xd0, 0xb1, 0x1b, 0x8b, 0x41, 0xf6,
-	0x44, 0x31, 0x78, 0x00, 0xea, 0x61, 0x7b, 0xca, 0x30, 0x5c, 0x8e, 0x87, 0x61, 0x76, 0x38, 0x0c,
-	0x5b, 0xd4, 0x31, 0xa8, 0xbb, 0xd2, 0xf5, 0x6c, 0x16, 0x04, 0xe4, 0x19, 0x82, 0xa9, 0x54, 0xc0,
-	0x71, 0xb1, 0x21, 0x80, 0x85, 0x9a, 0xc7, 0xa4, 0xe9, 0x72, 0x4b, 0x79, 0x96, 0xcb, 0x47, 0x6e,
-	0x3d, 0x24, 0x5d, 0xb5, 0x99, 0x73, 0xa0, 0x57, 0xcd, 0x84, 0x58, 0x59, 0x19, 0xa6, 0xc6, 0xa1,
-	0xb8, 0x0a, 0xb9, 0x7d, 0x7a, 0x20, 0x39, 0xf9, 0x3f, 0xf1, 0x69, 0x18, 0xe1, 0x3c, 0x6a, 0xd9,
-	0x79, 0x54, 0xcf, 0xeb, 0x62, 0x71, 0x2d, 0x7b, 0x05, 0x69, 0x1f, 0x43, 0x49, 0xa7, 0xa4, 0x13,
-	0x64, 0xa6, 0x01, 0xa3, 0x0f, 0x3d, 0xc1, 0x35, 0x51, 0x7b, 0xf7, 0x3d, 0xea, 0x04, 0x09, 0xd4,
-	0x03, 0x90, 0x76, 0x1d, 0xca, 0xc2, 0x5c, 0x06, 0x79, 0x01, 0x46, 0x1d, 0xea, 0x7a, 0x26, 0x0b,
-	0xec, 0xa7, 0x12, 0xf6, 0x02, 0xa7, 0x07, 0x28, 0xed, 0x47, 0x04, 0xe5, 0xa8, 0x6b, 0xfc, 0x3e,
-	0x60, 0x97, 0x11, 0x87, 0x35, 0x99, 0x61, 0x51, 0x97, 0x11, 0xab, 0xd7, 0xe4, 0x39, 0x43, 0xf5,
-	0x9c, 0x5e, 0xe5, 0x9a, 0xed, 0x40, 0xb1, 0xee, 0xe2, 0x3a, 0x54, 0xa9, 0xdd, 0x89, 0x63, 0xb3,
-	0x1c, 0x5b, 0xa1, 0x76, 0x27, 0x8a, 0x8c, 0x96, 0x54, 0xee, 0x44, 0x25, 0xf5, 0x33, 0x82, 0xd3,
-	0xab, 0x8f, 0xa8, 0xd5, 0x33, 0x89, 0xf3, 0x56, 0x28, 0x5e, 0x1a, 0xa2, 0x38, 0x95, 0x46, 0xd1,
-	0x8d, 0x70, 0xbc, 0x03, 0xe3, 0xb1, 0xc0, 0xe2, 0x6b, 0x00, 0x7c, 0xa7, 0xb4, 0x1c, 0xf6, 0x5a,
-	0x0d, 0x7f, 0x3b, 0x51, 0x2a, 0xcb, 0xf9, 0xc7, 0xcf, 0xe6, 0x32, 0x7a, 0x04, 0xad, 0xfd, 0x80,
-	0x60, 0x92, 0x7b, 0xdb, 0x62, 0x0e, 0x25, 0x56, 0xe8, 0xf3, 0x3a, 0x94, 0xda, 0x7b, 0x9e, 0xbd,
-	0x1f, 0x73, 0x3a, 0x13, 0x50, 0x1b, 0xb8, 0x5c, 0xf1, 0x41, 0xd2, 0x6f, 0xd4, 0x22, 0x41, 0x2a,
-	0xfb, 0x4a, 0xa4, 0xb6, 0x60, 0x2a, 0x91, 0x84, 0xd7, 0x70, 0xd2, 0xdf, 0x11, 0xe0, 0x68, 0xfb,
-	0x93, 0x89, 0x3d, 0xe6, 0x4e, 0xa7, 0xe7, 0x3d, 0xfb, 0x0a, 0x79, 0xcf, 0x1d, 0x9b, 0xf7, 0xfc,
-	0x3c, 0x3a, 0x49, 0xde, 0xaf, 0xc0, 0x64, 0x8c, 0xbf, 0x8c, 0xc9, 0x7f, 0xa1, 0x1c, 0xe9, 0x3a,
-	0x41, 0x67, 0x2d, 0x0d, 0x5a, 0x87, 0xab, 0xfd, 0x84, 0x60, 0x62, 0x30, 0x2d, 0xde, 0x6e, 0x49,
-	0x9f, 0xe8, 0x68, 0x1f, 0xca, 0xd4, 0x48, 0x7e, 0xf2, 0x64, 0xc7, 0x8d, 0x0c, 0x0d, 0x43, 0xf5,
-	0x81, 0x4b, 0x9d, 0x2d, 0x46, 0x58, 0x70, 0x2a, 0xed, 0x37, 0x04, 0x13, 0x11, 0xa1, 0x74, 0x75,
-	0x2e, 0x98, 0xfc, 0x46, 0xd7, 0x6e, 0x3a, 0x84, 0x89, 0x4c, 0x23, 0x7d, 0x3c, 0x94, 0xea, 0x84,
-	0x51, 0xbf, 0x18, 0x6c, 0xcf, 0x1a, 0x74, 0x6e, 0xbf, 0x71, 0x16, 0x6d, 0xcf, 0x12, 0x45, 0xe5,
-	0x47, 0x8c, 0xf4, 0x8c, 0x66, 0xc2, 0x53, 0x8e, 0x7b, 0xaa, 0x92, 0x9e, 0xb1, 0x16, 0x73, 0xd6,
-	0x80, 0x49, 0xc7, 0x33, 0x69, 0x12, 0x9e, 0xe7, 0xf0, 0x09, 0x5f, 0x15, 0xc3, 0x6b, 0x5f, 0xc2,
-	0xa4, 0x4f, 0x7c, 0xed, 0x46, 0x9c, 0xfa, 0x0c, 0x8c, 0x7a, 0x2e, 0x75, 0x9a, 0x46, 0x47, 0x56,
-	0x67, 0xc1, 0x5f, 0xae, 0x75, 0xf0, 0x05, 0xc8, 0x77, 0x08, 0x23, 0x9c, 0x66, 0x69, 0xf1, 0x4c,
-	0x10, 0xe3, 0xa1, 0xc3, 0xeb, 0x1c, 0xa6, 0xdd, 0x02, 0xec, 0xab, 0xdc, 0xb8, 0xf7, 0x4b, 0x30,
-	0xe2, 0xfa, 0x02, 0x79, 0x99, 0xce, 0x46, 0xbd, 0x24, 0x98, 0xe8, 0x02, 0xa9, 0xfd, 0x8a, 0x40,
-	0x5d, 0xa7, 0xcc, 0x31, 0xda, 0xee, 0xcd, 0xae, 0x13, 0x4f, 0xe9, 0x1b, 0x2e, 0xad, 0x2b, 0x50,
-	0x0e, 0x6a, 0xa6, 0xe9, 0x52, 0x76, 0x74, 0xc7, 0x2c, 0x05, 0xd0, 0x2d, 0xca, 0xb4, 0x3b, 0x30,
-	0x77, 0x28, 0x67, 0x19, 0x8a, 0x3a, 0x14, 0x2c, 0x0e, 0x91, 0xb1, 0xa8, 0x0e, 0x1a, 0x8b, 0x30,
-	0xd5, 0xa5, 0x5e, 0xab, 0xc1, 0xb4, 0x74, 0xb6, 0x4e, 0x19, 0xf1, 0xa3, 0x1b, 0x54, 0xdf, 0x26,
-	0xcc, 0x0c, 0x69, 0xa4, 0xfb, 0x0f, 0x60, 0xcc, 0x92, 0x32, 0xb9, 0x41, 0x2d, 0xb9, 0x41, 0x68,
-	0x13, 0x22, 0xb5, 0xbf, 0x11, 0x9c, 0x4a, 0x74, 0x5b, 0x3f, 0x5e, 0x3b, 0x4e, 0xd7, 0x6a, 0x06,
-	0x6f, 0xd9, 0x41, 0x69, 0x54, 0x7c, 0xf9, 0x9a, 0x14, 0xaf, 0x75, 0xa2, 0xb5, 0x93, 0x8d, 0xd5,
-	0xce, 0x0e, 0x14, 0xf8, 0x3d, 0x0a, 0x86, 0xce, 0xe4, 0x80, 0x0a, 0x0f, 0xce, 0x3d, 0x62, 0x38,
-	0xcb, 0x57, 0xfd, 0x1e, 0xfa, 0xc7, 0xb3, 0xb9, 0x4b, 0x27, 0x79, 0xed, 0x0a, 0xbb, 0xa5, 0x0e,
-	0xe9, 0x31, 0xea, 0xe8, 0xd2, 0x3b, 0x7e, 0x0f, 0x0a, 0x62, 0x28, 0xd4, 0xf2, 0x7c, 0x9f, 0xf1,
-	0x20, 0x55, 0xd1, 0xb9, 0x21, 0x21, 0xda, 0x77, 0x08, 0x46, 0xc4, 0x09, 0xdf, 0x54, 0xfd, 0x28,
-	0x30, 0x46, 0xed, 0x76, 0xb7, 0x63, 0xd8, 0xbb, 0xfc, 0xda, 0x8e, 0xe8, 0xe1, 0x1a, 0x63, 0x79,
-	0x9d, 0xfc, 0xfb, 0x59, 0x96, 0x77, 0x66, 0x09, 0xc6, 0x63, 0xb5, 0xf2, 0x2f, 0x1e, 0xea, 0x4d,
-	0x28, 0x47, 0x35, 0xf8, 0x1c, 0xe4, 0xd9, 0x41, 0x4f, 0xf4, 0x9f, 0xca, 0xe2, 0x44, 0x60, 0xcd,
-	0xd5, 0xdb, 0x07, 0x3d, 0xaa, 0x73, 0xb5, 0xcf, 0x86, 0x0f, 0x24, 0x91, 0x36, 0xfe, 0x7b, 0xf0,
-	0xa2, 0xcb, 0x71, 0xa1, 0x58, 0x68, 0xdf, 0x20, 0xa8, 0x0c, 0x2a, 0xe4, 0xa6, 0x61, 0xd2, 0xd7,
-	0x51, 0x20, 0x0a, 0x8c, 0xed, 0x18, 0x26, 0xe5, 0x1c, 0xc4, 0x76, 0xe1, 0x3a, 0x2d, 0x52, 0xef,
-	0x7e, 0x02, 0xc5, 0xf0, 0x08, 0xb8, 0x08, 0x23, 0xab, 0xf7, 0x1f, 0x2c, 0xdd, 0xad, 0x66, 0xf0,
-	0x38, 0x14, 0x37, 0x36, 0xb7, 0x9b, 0x62, 0x89, 0xf0, 0x29, 0x28, 0xe9, 0xab, 0xb7, 0x56, 0x3f,
-	0x6f, 0xae, 0x2f, 0x6d, 0xaf, 0xdc, 0xae, 0x66, 0x31, 0x86, 0x8a, 0x10, 0x6c, 0x6c, 0x4a, 0x59,
-	0x6e, 0xf1, 0xdb, 0x51, 0x18, 0x0b, 0x38, 0xe2, 0xab, 0x90, 0xbf, 0xe7, 0xb9, 0x7b, 0x78, 0x7a,
-	0x50, 0xa1, 0x9f, 0x39, 0x06, 0xa3, 0xf2, 0xc6, 0x29, 0x33, 0x43, 0x72, 0x71, 0xdf, 0xb4, 0x0c,
-	0xbe, 0x01, 0xa5, 0xc8, 0xd3, 0x06, 0xa7, 0x3e, 0x6b, 0x95, 0xb3, 0x31, 0x69, 0xfc, 0x15, 0xa4,
-	0x65, 0x2e, 0x22, 0xbc, 0x09, 0x15, 0xae, 0x0a, 0x5e, 0x24, 0x2e, 0xfe, 0x4f, 0x60, 0x92, 0xf6,
-	0x52, 0x54, 0x66, 0x0f, 0xd1, 0x86, 0xb4, 0x6e, 0xc7, 0x3f, 0xb8, 0x94, 0xb4, 0x6f, 0xb3, 0x24,
-	0xb9, 0x94, 0xc1, 0xaf, 0x65, 0xf0, 0x2a, 0xc0, 0x60, 0x6c, 0xe2, 0x33, 0x31, 0x70, 0x74, 0xd4,
-	0x2b, 0x4a, 0x9a, 0x2a, 0x74, 0xb3, 0x0c, 0xc5, 0x70, 0x68, 0xe0, 0x5a, 0xca, 0x1c, 0x11, 0x4e,
-	0x0e, 0x9f, 0x30, 0x5a, 0x06, 0xdf, 0x84, 0xf2, 0x92, 0x69, 0x9e, 0xc4, 0x8d, 0x12, 0xd5, 0xb8,
-	0x49, 0x3f, 0x66, 0xd8, 0x40, 0x93, 0x7d, 0x1a, 0x9f, 0x0f, 0xef, 0xca, 0x91, 0xc3, 0x47, 0xf9,
-	0xff, 0xb1, 0xb8, 0x70, 0xb7, 0x6d, 0x38, 0x95, 0x68, 0xd7, 0x58, 0x4d, 0x58, 0x27, 0x3a, 0xbc,
-	0x32, 0x77, 0xa8, 0x3e, 0xf4, 0xda, 0x92, 0x0f, 0xb5, 0xf8, 0xb7, 0x39, 0xd6, 0x86, 0x93, 0x90,
-	0xfc, 0x47, 0x80, 0xf2, 0xbf, 0x23, 0x31, 0x91, 0xaa, 0xdc, 0x87, 0xe9, 0xf4, 0x6f, 0x5f, 0x7c,
-	0x2e, 0xa5, 0x66, 0x86, 0xbf, 0xc7, 0x95, 0xf3, 0xc7, 0xc1, 0x06, 0x9b, 0x2d, 0x7f, 0xf4, 0xe4,
-	0xb9, 0x9a, 0x79, 0xfa, 0x5c, 0xcd, 0xbc, 0x7c, 0xae, 0xa2, 0xaf, 0xfb, 0x2a, 0xfa, 0xa5, 0xaf,
-	0xa2, 0xc7, 0x7d, 0x15, 0x3d, 0xe9, 0xab, 0xe8, 0xcf, 0xbe, 0x8a, 0xfe, 0xea, 0xab, 0x99, 0x97,
-	0x7d, 0x15, 0x7d, 0xff, 0x42, 0xcd, 0x3c, 0x79, 0xa1, 0x66, 0x9e, 0xbe, 0x50, 0x33, 0x5f, 0x14,
-	0xda, 0xa6, 0x41, 0x6d, 0xd6, 0x2a, 0xf0, 0xff, 0x80, 0x5c, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff,
-	0x5c, 0xeb, 0x47, 0xa9, 0x7c, 0x11, 0x00, 0x00,
+func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
+func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
+func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{30}
 }
-
-func (x MatchType) String() string {
-	s, ok := MatchType_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (this *LabelNamesAndValuesRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*LabelNamesAndValuesRequest)
-	if !ok {
-		that2, ok := that.(LabelNamesAndValuesRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
-	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
+func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsForLabelMatchersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *LabelNamesAndValuesResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *MetricsForLabelMatchersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsForLabelMatchersRequest.Merge(m, src)
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsForLabelMatchersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsForLabelMatchersRequest.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*LabelNamesAndValuesResponse)
-	if !ok {
-		that2, ok := that.(LabelNamesAndValuesResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Items) != len(that1.Items) {
-		return false
-	}
-	for i := range this.Items {
-		if !this.Items[i].Equal(that1.Items[i]) {
-			return false
-		}
+var xxx_messageInfo_MetricsForLabelMatchersRequest proto.InternalMessageInfo
+
+func (m *MetricsForLabelMatchersRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
 	}
-	return true
+	return 0
 }
-func (this *LabelValues) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValues)
-	if !ok {
-		that2, ok := that.(LabelValues)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if len(this.Values) != len(that1.Values) {
-		return false
-	}
-	for i := range this.Values {
-		if this.Values[i] != that1.Values[i] {
-			return false
-		}
+func (m *MetricsForLabelMatchersRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
 	}
-	return true
+	return 0
 }
-func (this *LabelValuesCardinalityRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValuesCardinalityRequest)
-	if !ok {
-		that2, ok := that.(LabelValuesCardinalityRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.LabelNames) != len(that1.LabelNames) {
-		return false
-	}
-	for i := range this.LabelNames {
-		if this.LabelNames[i] != that1.LabelNames[i] {
-			return false
-		}
-	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
-	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
-		}
+func (m *MetricsForLabelMatchersRequest) GetMatchersSet() []*LabelMatchers {
+	if m != nil {
+		return m.MatchersSet
 	}
-	return true
+	return nil
 }
-func (this *LabelValuesCardinalityResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValuesCardinalityResponse)
-	if !ok {
-		that2, ok := that.(LabelValuesCardinalityResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Items) != len(that1.Items) {
-		return false
-	}
-	for i := range this.Items {
-		if !this.Items[i].Equal(that1.Items[i]) {
-			return false
+type MetricsForLabelMatchersResponse struct {
+	Metric []*mimirpb.Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
+}
+
+func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
+func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
+func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{31}
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsForLabelMatchersResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *LabelValueSeriesCount) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *MetricsForLabelMatchersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsForLabelMatchersResponse.Merge(m, src)
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsForLabelMatchersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsForLabelMatchersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsForLabelMatchersResponse proto.InternalMessageInfo
+
+func (m *MetricsForLabelMatchersResponse) GetMetric() []*mimirpb.Metric {
+	if m != nil {
+		return m.Metric
 	}
+	return nil
+}
 
-	that1, ok := that.(*LabelValueSeriesCount)
-	if !ok {
-		that2, ok := that.(LabelValueSeriesCount)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type MetricsMetadataRequest struct {
+}
+
+func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
+func (*MetricsMetadataRequest) ProtoMessage() {}
+func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{32}
+}
+func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsMetadataRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if len(this.LabelValueSeries) != len(that1.LabelValueSeries) {
-		return false
-	}
-	for i := range this.LabelValueSeries {
-		if this.LabelValueSeries[i] != that1.LabelValueSeries[i] {
-			return false
+}
+func (m *MetricsMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsMetadataRequest.Merge(m, src)
+}
+func (m *MetricsMetadataRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsMetadataRequest proto.InternalMessageInfo
+
+type MetricsMetadataResponse struct {
+	Metadata []*mimirpb.MetricMetadata `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
+func (*MetricsMetadataResponse) ProtoMessage() {}
+func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{33}
+}
+func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsMetadataResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ReadRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *MetricsMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsMetadataResponse.Merge(m, src)
+}
+func (m *MetricsMetadataResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsMetadataResponse proto.InternalMessageInfo
+
+func (m *MetricsMetadataResponse) GetMetadata() []*mimirpb.MetricMetadata {
+	if m != nil {
+		return m.Metadata
 	}
+	return nil
+}
 
-	that1, ok := that.(*ReadRequest)
-	if !ok {
-		that2, ok := that.(ReadRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type TimeSeriesChunk struct {
+	FromIngesterId string                                              `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
+	UserId         string                                              `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Labels         []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,3,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"labels"`
+	Chunks         []Chunk                                             `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks"`
+}
+
+func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
+func (*TimeSeriesChunk) ProtoMessage() {}
+func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{34}
+}
+func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeSeriesChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Queries) != len(that1.Queries) {
-		return false
+}
+func (m *TimeSeriesChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeriesChunk.Merge(m, src)
+}
+func (m *TimeSeriesChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeSeriesChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeriesChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeSeriesChunk proto.InternalMessageInfo
+
+func (m *TimeSeriesChunk) GetFromIngesterId() string {
+	if m != nil {
+		return m.FromIngesterId
 	}
-	for i := range this.Queries {
-		if !this.Queries[i].Equal(that1.Queries[i]) {
-			return false
-		}
+	return ""
+}
+
+func (m *TimeSeriesChunk) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return true
+	return ""
 }
-func (this *ReadResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *TimeSeriesChunk) GetChunks() []Chunk {
+	if m != nil {
+		return m.Chunks
 	}
+	return nil
+}
 
-	that1, ok := that.(*ReadResponse)
-	if !ok {
-		that2, ok := that.(ReadResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type Chunk struct {
+	StartTimestampMs int64  `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64  `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Encoding         int32  `protobuf:"varint,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	Data             []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *Chunk) Reset()      { *m = Chunk{} }
+func (*Chunk) ProtoMessage() {}
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{35}
+}
+func (m *Chunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Results) != len(that1.Results) {
-		return false
-	}
-	for i := range this.Results {
-		if !this.Results[i].Equal(that1.Results[i]) {
-			return false
-		}
+}
+func (m *Chunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Chunk.Merge(m, src)
+}
+func (m *Chunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *Chunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Chunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Chunk proto.InternalMessageInfo
+
+func (m *Chunk) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
 	}
-	return true
+	return 0
 }
-func (this *QueryRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Chunk) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
 	}
+	return 0
+}
 
-	that1, ok := that.(*QueryRequest)
-	if !ok {
-		that2, ok := that.(QueryRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *Chunk) GetEncoding() int32 {
+	if m != nil {
+		return m.Encoding
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return 0
+}
+
+func (m *Chunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
+	return nil
+}
+
+type LabelMatchers struct {
+	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
+func (*LabelMatchers) ProtoMessage() {}
+func (*LabelMatchers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{36}
+}
+func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelMatchers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
+}
+func (m *LabelMatchers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelMatchers.Merge(m, src)
+}
+func (m *LabelMatchers) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelMatchers) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelMatchers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelMatchers proto.InternalMessageInfo
+
+func (m *LabelMatchers) GetMatchers() []*LabelMatcher {
+	if m != nil {
+		return m.Matchers
 	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
+	return nil
+}
+
+type LabelMatcher struct {
+	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MatchType" json:"type,omitempty"`
+	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
+func (*LabelMatcher) ProtoMessage() {}
+func (*LabelMatcher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{37}
+}
+func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelMatcher.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
+}
+func (m *LabelMatcher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelMatcher.Merge(m, src)
+}
+func (m *LabelMatcher) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelMatcher) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelMatcher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelMatcher proto.InternalMessageInfo
+
+func (m *LabelMatcher) GetType() MatchType {
+	if m != nil {
+		return m.Type
+	}
+	return EQUAL
+}
+
+func (m *LabelMatcher) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LabelMatcher) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type TimeSeriesFile struct {
+	FromIngesterId string `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
+	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filename       string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Data           []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
+func (*TimeSeriesFile) ProtoMessage() {}
+func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{38}
+}
+func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeSeriesFile.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
+func (m *TimeSeriesFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeriesFile.Merge(m, src)
+}
+func (m *TimeSeriesFile) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeSeriesFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeriesFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeSeriesFile proto.InternalMessageInfo
+
+func (m *TimeSeriesFile) GetFromIngesterId() string {
+	if m != nil {
+		return m.FromIngesterId
+	}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("cortex.MatchType", MatchType_name, MatchType_value)
+	proto.RegisterType((*LabelNamesAndValuesRequest)(nil), "cortex.LabelNamesAndValuesRequest")
+	proto.RegisterType((*LabelNamesAndValuesResponse)(nil), "cortex.LabelNamesAndValuesResponse")
+	proto.RegisterType((*LabelValues)(nil), "cortex.LabelValues")
+	proto.RegisterType((*LabelValuesCardinalityRequest)(nil), "cortex.LabelValuesCardinalityRequest")
+	proto.RegisterType((*LabelValuesCardinalityResponse)(nil), "cortex.LabelValuesCardinalityResponse")
+	proto.RegisterType((*LabelValueSeriesCount)(nil), "cortex.LabelValueSeriesCount")
+	proto.RegisterMapType((map[string]uint64)(nil), "cortex.LabelValueSeriesCount.LabelValueSeriesEntry")
+	proto.RegisterType((*ActiveSeriesRequest)(nil), "cortex.ActiveSeriesRequest")
+	proto.RegisterType((*ActiveSeriesResponse)(nil), "cortex.ActiveSeriesResponse")
+	proto.RegisterType((*SeriesChurnRequest)(nil), "cortex.SeriesChurnRequest")
+	proto.RegisterType((*SeriesChurnResponse)(nil), "cortex.SeriesChurnResponse")
+	proto.RegisterType((*MetricSeriesChurn)(nil), "cortex.MetricSeriesChurn")
+	proto.RegisterType((*TSDBStatusRequest)(nil), "cortex.TSDBStatusRequest")
+	proto.RegisterType((*TSDBStatusResponse)(nil), "cortex.TSDBStatusResponse")
+	proto.RegisterType((*TSDBStatItem)(nil), "cortex.TSDBStatItem")
+	proto.RegisterType((*ReadRequest)(nil), "cortex.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "cortex.ReadResponse")
+	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
+	proto.RegisterType((*ExemplarQueryRequest)(nil), "cortex.ExemplarQueryRequest")
+	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
+	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
+	proto.RegisterType((*QueryStreamStats)(nil), "cortex.QueryStreamStats")
+	proto.RegisterType((*ExemplarQueryResponse)(nil), "cortex.ExemplarQueryResponse")
+	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
+	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
+	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
+	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
+	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
+	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
+	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
+	proto.RegisterType((*UsersStatsResponse)(nil), "cortex.UsersStatsResponse")
+	proto.RegisterType((*MetricsForLabelMatchersRequest)(nil), "cortex.MetricsForLabelMatchersRequest")
+	proto.RegisterType((*MetricsForLabelMatchersResponse)(nil), "cortex.MetricsForLabelMatchersResponse")
+	proto.RegisterType((*MetricsMetadataRequest)(nil), "cortex.MetricsMetadataRequest")
+	proto.RegisterType((*MetricsMetadataResponse)(nil), "cortex.MetricsMetadataResponse")
+	proto.RegisterType((*TimeSeriesChunk)(nil), "cortex.TimeSeriesChunk")
+	proto.RegisterType((*Chunk)(nil), "cortex.Chunk")
+	proto.RegisterType((*LabelMatchers)(nil), "cortex.LabelMatchers")
+	proto.RegisterType((*LabelMatcher)(nil), "cortex.LabelMatcher")
+	proto.RegisterType((*TimeSeriesFile)(nil), "cortex.TimeSeriesFile")
+}
+
+func init() { proto.RegisterFile("ingester.proto", fileDescriptor_60f6df4f3586b478) }
+
+var fileDescriptor_60f6df4f3586b478 = []byte{
+	// 1943 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x73, 0xdb, 0xc6,
+	0x15, 0x27, 0x48, 0x8a, 0x22, 0x1f, 0x29, 0x89, 0x5a, 0x5a, 0x16, 0x0d, 0x45, 0x94, 0x8c, 0x8c,
+	0x5d, 0x35, 0x75, 0x28, 0x7f, 0xb4, 0x33, 0x4e, 0xa6, 0x9d, 0x44, 0x5f, 0xb6, 0xd5, 0x44, 0x76,
+	0x02, 0xc9, 0x6d, 0xa6, 0x9d, 0x0e, 0x66, 0x49, 0xae, 0x24, 0x8c, 0xf0, 0xc1, 0x00, 0x0b, 0x5b,
+	0xbc, 0x65, 0xda, 0x3f, 0xa0, 0x3d, 0xe6, 0xd4, 0x99, 0xde, 0x7a, 0xe9, 0xa5, 0x97, 0xde, 0x7a,
+	0xe8, 0x29, 0x47, 0x1f, 0x33, 0x3d, 0xb8, 0xb5, 0x7c, 0x69, 0x2f, 0x9d, 0xfc, 0x09, 0x1d, 0xec,
+	0x2e, 0x80, 0x05, 0x08, 0x99, 0x72, 0x26, 0xf1, 0x89, 0xdc, 0xf7, 0x7e, 0xfb, 0xdb, 0xb7, 0x6f,
+	0xdf, 0x7b, 0xfb, 0xb0, 0x30, 0x6b, 0x3a, 0x47, 0xc4, 0xa7, 0xc4, 0xeb, 0x0e, 0x3d, 0x97, 0xba,
+	0xa8, 0xd2, 0x77, 0x3d, 0x4a, 0x4e, 0xd5, 0x77, 0x8f, 0x4c, 0x7a, 0x1c, 0xf4, 0xba, 0x7d, 0xd7,
+	0x5e, 0x3f, 0x72, 0x8f, 0xdc, 0x75, 0xa6, 0xee, 0x05, 0x87, 0x6c, 0xc4, 0x06, 0xec, 0x1f, 0x9f,
+	0xa6, 0xde, 0x94, 0xe1, 0x1e, 0x3e, 0xc4, 0x0e, 0x5e, 0xb7, 0x4d, 0xdb, 0xf4, 0xd6, 0x87, 0x27,
+	0x47, 0xfc, 0xdf, 0xb0, 0xc7, 0x7f, 0xc5, 0x8c, 0xce, 0x91, 0xeb, 0x1e, 0x59, 0x24, 0xe1, 0x1d,
+	0x04, 0x1e, 0xa6, 0xa6, 0xeb, 0x70, 0xbd, 0xf6, 0x10, 0xd4, 0x8f, 0x71, 0x8f, 0x58, 0x0f, 0xb1,
+	0x4d, 0xfc, 0x0d, 0x67, 0xf0, 0x0b, 0x6c, 0x05, 0xc4, 0xd7, 0xc9, 0xe7, 0x01, 0xf1, 0x29, 0xba,
+	0x09, 0x55, 0x1b, 0xd3, 0xfe, 0x31, 0xf1, 0xfc, 0xb6, 0xb2, 0x5a, 0x5a, 0xab, 0xdf, 0xbe, 0xd4,
+	0xe5, 0x96, 0x77, 0xd9, 0xac, 0x3d, 0xae, 0xd4, 0x63, 0x94, 0xf6, 0x00, 0x96, 0x72, 0xf9, 0xfc,
+	0xa1, 0xeb, 0xf8, 0x04, 0xfd, 0x10, 0xa6, 0x4c, 0x4a, 0xec, 0x88, 0xad, 0x95, 0x62, 0x13, 0x58,
+	0x8e, 0xd0, 0xb6, 0xa1, 0x2e, 0x49, 0xd1, 0x32, 0x80, 0x15, 0x0e, 0x0d, 0x07, 0xdb, 0xa4, 0xad,
+	0xac, 0x2a, 0x6b, 0x35, 0xbd, 0x66, 0x45, 0x4b, 0xa1, 0xcb, 0x50, 0x79, 0xc2, 0x80, 0xed, 0xe2,
+	0x6a, 0x69, 0xad, 0xa6, 0x8b, 0x91, 0xe6, 0xc1, 0xb2, 0xc4, 0xb2, 0x85, 0xbd, 0x81, 0xe9, 0x60,
+	0xcb, 0xa4, 0xa3, 0x68, 0x8b, 0x2b, 0x50, 0x4f, 0x78, 0xb9, 0x5d, 0x35, 0x1d, 0x62, 0x62, 0x3f,
+	0xe5, 0x83, 0xe2, 0x85, 0x7c, 0xf0, 0x18, 0x3a, 0xe7, 0xad, 0x29, 0xdc, 0x70, 0x27, 0xed, 0x86,
+	0xe5, 0x71, 0x37, 0xec, 0x13, 0xcf, 0x24, 0xfe, 0x96, 0x1b, 0x38, 0x34, 0x72, 0xc8, 0x73, 0x05,
+	0x16, 0x72, 0x01, 0x93, 0x7c, 0x83, 0x01, 0x71, 0x35, 0xf3, 0x89, 0xe1, 0xb3, 0x99, 0x62, 0x2f,
+	0x77, 0x5e, 0xb9, 0xf4, 0x98, 0x74, 0xc7, 0xa1, 0xde, 0x48, 0x6f, 0x5a, 0x19, 0xb1, 0xba, 0x05,
+	0x0b, 0xb9, 0x50, 0xd4, 0x84, 0xd2, 0x09, 0x19, 0x09, 0x9b, 0xc2, 0xbf, 0xe8, 0x12, 0x4c, 0x31,
+	0x3b, 0xda, 0xc5, 0x55, 0x65, 0xad, 0xac, 0xf3, 0xc1, 0xfb, 0xc5, 0xbb, 0x8a, 0x76, 0x1f, 0x5a,
+	0x1b, 0x7d, 0x6a, 0x3e, 0x11, 0x04, 0xdf, 0x3e, 0x08, 0x3f, 0x84, 0x4b, 0x69, 0x22, 0xe1, 0xf6,
+	0x35, 0xa8, 0xd8, 0x84, 0x7a, 0x66, 0x5f, 0xf0, 0x34, 0x05, 0xcf, 0xb0, 0xd7, 0xdd, 0x63, 0x72,
+	0x5d, 0xe8, 0xb5, 0x5b, 0x80, 0x84, 0x1b, 0x8e, 0x03, 0xcf, 0x89, 0x2c, 0x59, 0x82, 0xda, 0x53,
+	0xd3, 0x19, 0xb8, 0x4f, 0x0d, 0x76, 0x74, 0xca, 0x5a, 0x49, 0xaf, 0x72, 0xc1, 0x9e, 0xaf, 0xdd,
+	0x83, 0x56, 0x6a, 0x8a, 0x58, 0x73, 0x3d, 0x7d, 0xd4, 0x57, 0x22, 0xd3, 0xf9, 0x82, 0xf2, 0x0c,
+	0x71, 0xcc, 0xbf, 0x55, 0x60, 0x7e, 0x4c, 0x19, 0x86, 0x29, 0x37, 0x4d, 0x3e, 0x63, 0xe0, 0x22,
+	0x76, 0xc8, 0xd7, 0x60, 0xb6, 0xef, 0x11, 0x4c, 0xc9, 0x20, 0x39, 0xe0, 0xd0, 0xbf, 0x33, 0x42,
+	0xca, 0xc9, 0x42, 0x98, 0x47, 0x6c, 0xf7, 0x49, 0x02, 0x2b, 0x71, 0x98, 0x90, 0x72, 0x98, 0xd6,
+	0x82, 0xf9, 0x83, 0xfd, 0xed, 0xcd, 0x7d, 0x8a, 0x69, 0x10, 0x1d, 0x84, 0xf6, 0x97, 0x32, 0x20,
+	0x59, 0x2a, 0x76, 0xb8, 0x0c, 0xe0, 0x04, 0x76, 0x44, 0xa7, 0x30, 0xba, 0x9a, 0x13, 0xd8, 0x62,
+	0xc5, 0xeb, 0x30, 0x17, 0xaa, 0x79, 0x04, 0x0e, 0xb1, 0xe9, 0xc5, 0x96, 0x39, 0x81, 0xcd, 0x0e,
+	0xf0, 0x93, 0x50, 0x18, 0xee, 0xb0, 0x7f, 0x1c, 0x38, 0x27, 0x46, 0x3f, 0x8c, 0x3c, 0x61, 0x16,
+	0x30, 0x11, 0x8f, 0xf2, 0x2b, 0x50, 0xb5, 0x4d, 0xc7, 0xa0, 0xa6, 0x4d, 0xda, 0x65, 0xe6, 0xfc,
+	0x69, 0xdb, 0x74, 0x0e, 0x4c, 0x9b, 0x30, 0x15, 0x3e, 0xe5, 0xaa, 0x29, 0xa1, 0xc2, 0xa7, 0x4c,
+	0xf5, 0x19, 0x2c, 0x71, 0xcb, 0x38, 0xaf, 0xd1, 0x1b, 0x19, 0xb2, 0x23, 0x2b, 0xe9, 0x80, 0x8a,
+	0xb6, 0xb7, 0x4b, 0x89, 0xbd, 0x59, 0xfe, 0xea, 0xf9, 0x4a, 0x41, 0x5f, 0xf4, 0x93, 0x7c, 0xd8,
+	0x1c, 0xed, 0x25, 0x1e, 0x37, 0x60, 0x45, 0x4e, 0xab, 0x98, 0x5e, 0x4a, 0xc5, 0xe9, 0x89, 0xec,
+	0x6a, 0x92, 0x4a, 0x62, 0x85, 0xb8, 0x7c, 0xa2, 0x5f, 0xc3, 0xb2, 0x4d, 0x6c, 0xd7, 0x1b, 0x19,
+	0xa6, 0x63, 0xf4, 0x46, 0x94, 0xf8, 0x19, 0xfa, 0xea, 0x44, 0xfa, 0x36, 0x27, 0xd8, 0x75, 0x36,
+	0xc3, 0xe9, 0x32, 0x39, 0x86, 0xd5, 0xac, 0x5f, 0xe4, 0xdd, 0x84, 0x07, 0xd5, 0xae, 0x4d, 0xe4,
+	0x5f, 0x4a, 0x39, 0x27, 0x29, 0x01, 0xe1, 0x91, 0x6a, 0x77, 0xa1, 0x21, 0x4f, 0x41, 0x08, 0xca,
+	0x52, 0xf0, 0xb2, 0xff, 0xf9, 0xd5, 0x40, 0xfb, 0x19, 0xd4, 0x75, 0x82, 0x07, 0x51, 0xde, 0x75,
+	0x61, 0xfa, 0xf3, 0x20, 0x0a, 0xaf, 0x94, 0x49, 0x9f, 0x06, 0xc4, 0x8b, 0x4a, 0xb9, 0x1e, 0x81,
+	0xb4, 0x0f, 0xa0, 0xc1, 0xa7, 0xc7, 0x39, 0x38, 0xed, 0x11, 0x3f, 0xb0, 0x68, 0x34, 0x7f, 0x21,
+	0x33, 0x9f, 0xe3, 0xf4, 0x08, 0xa5, 0x7d, 0xa9, 0x40, 0x43, 0xa6, 0x46, 0x37, 0x00, 0xf9, 0x14,
+	0x7b, 0x94, 0x85, 0x98, 0x4f, 0xb1, 0x3d, 0x4c, 0x4a, 0x40, 0x93, 0x69, 0x0e, 0x22, 0xc5, 0x9e,
+	0x8f, 0xd6, 0xa0, 0x49, 0x9c, 0x41, 0x1a, 0x5b, 0x64, 0xd8, 0x59, 0xe2, 0x0c, 0x64, 0xa4, 0x5c,
+	0xdb, 0x4a, 0x17, 0xaa, 0x6d, 0x7f, 0x52, 0xe0, 0xd2, 0xce, 0x29, 0xb1, 0x87, 0x16, 0xf6, 0xde,
+	0x88, 0x89, 0xb7, 0xc6, 0x4c, 0x5c, 0xc8, 0x33, 0xd1, 0x97, 0x6c, 0xfc, 0x08, 0x66, 0x52, 0x8e,
+	0x45, 0xef, 0x03, 0xb0, 0x95, 0xf2, 0xce, 0x70, 0xd8, 0xeb, 0x86, 0xcb, 0xf1, 0x6a, 0x21, 0xc2,
+	0x4a, 0x42, 0x6b, 0xff, 0x50, 0xa0, 0xc5, 0xd8, 0xf6, 0xa9, 0x47, 0xb0, 0x1d, 0x73, 0x7e, 0x20,
+	0xea, 0x45, 0x8a, 0x74, 0x31, 0x8e, 0xd5, 0x98, 0x72, 0x2b, 0x04, 0x09, 0x5e, 0x79, 0x46, 0xc6,
+	0xa8, 0xe2, 0xeb, 0x18, 0x85, 0xba, 0x30, 0xe5, 0x53, 0x4c, 0x79, 0xf5, 0xac, 0xdf, 0x6e, 0xa7,
+	0xe2, 0x89, 0x1b, 0x1a, 0x86, 0xbd, 0xaf, 0x73, 0x98, 0xf6, 0x45, 0x09, 0x9a, 0x59, 0x1d, 0xfa,
+	0x10, 0x6a, 0x4f, 0xb1, 0x65, 0xf1, 0xb2, 0xa5, 0x30, 0xa2, 0x2b, 0x5d, 0xde, 0xaf, 0x75, 0xa3,
+	0x7e, 0xad, 0xbb, 0x2d, 0xfa, 0xb5, 0xcd, 0x6a, 0x68, 0xc4, 0x97, 0xff, 0x5a, 0x51, 0xf4, 0x6a,
+	0x38, 0x8b, 0x15, 0xb7, 0x6d, 0xa8, 0xfb, 0xc4, 0x22, 0x7d, 0x7e, 0xe8, 0xed, 0xe2, 0xc5, 0x39,
+	0x80, 0xcf, 0x63, 0x2c, 0x57, 0xa1, 0x21, 0x97, 0x02, 0x51, 0x7a, 0xeb, 0x52, 0x6a, 0xa3, 0xb7,
+	0x61, 0xc6, 0xc7, 0xf6, 0xd0, 0x8a, 0x31, 0x65, 0x86, 0x69, 0x08, 0x21, 0x07, 0x5d, 0x85, 0x06,
+	0xf7, 0xaf, 0xc0, 0x4c, 0x71, 0x1e, 0x2e, 0xe3, 0x90, 0xb8, 0xc8, 0xb3, 0x72, 0xd6, 0xae, 0x48,
+	0x45, 0x9e, 0x55, 0x28, 0xf4, 0x0e, 0xcc, 0x1f, 0x13, 0x3c, 0x30, 0x52, 0x44, 0xd3, 0x0c, 0x36,
+	0x17, 0x2a, 0xb6, 0x24, 0xb2, 0x1b, 0x80, 0x7a, 0x96, 0xdb, 0x3f, 0x49, 0x83, 0xab, 0x0c, 0xdc,
+	0x64, 0x1a, 0x09, 0xad, 0xed, 0xc3, 0x42, 0x26, 0x6f, 0xbe, 0x83, 0xe0, 0xfc, 0xbb, 0x02, 0x48,
+	0xee, 0x5d, 0x45, 0x2e, 0x4e, 0x68, 0xc8, 0xf2, 0x53, 0xb5, 0xf8, 0x1a, 0xa9, 0x5a, 0x9a, 0x98,
+	0xaa, 0xe5, 0x55, 0xe5, 0x22, 0xa9, 0x7a, 0x17, 0x5a, 0x29, 0xfb, 0x85, 0x4f, 0xae, 0x42, 0x43,
+	0xba, 0x0d, 0xa2, 0xb6, 0xb8, 0x9e, 0x5c, 0x56, 0xbe, 0xf6, 0x47, 0x05, 0xe6, 0x93, 0x56, 0xff,
+	0xcd, 0x56, 0xa1, 0x0b, 0x6d, 0xed, 0x27, 0x80, 0x64, 0xfb, 0xc4, 0xce, 0x26, 0xf5, 0xfb, 0x1a,
+	0x82, 0xe6, 0x63, 0x9f, 0x78, 0x3c, 0x7d, 0x45, 0xe7, 0xf3, 0x37, 0x05, 0xe6, 0x25, 0xa1, 0xa0,
+	0xba, 0x16, 0x7d, 0xd6, 0x99, 0xae, 0x63, 0x78, 0x98, 0xf2, 0x93, 0x56, 0xf4, 0x99, 0x58, 0xaa,
+	0x63, 0x9a, 0xed, 0x8f, 0x8a, 0xd9, 0xfe, 0xe8, 0x06, 0x20, 0x3c, 0x34, 0x8d, 0x0c, 0x53, 0x89,
+	0x31, 0x35, 0xf1, 0xd0, 0xdc, 0x4d, 0x91, 0x75, 0xa1, 0xe5, 0x05, 0x16, 0xc9, 0xc2, 0xcb, 0x0c,
+	0x3e, 0x1f, 0xaa, 0x52, 0x78, 0xed, 0x37, 0xd0, 0x0a, 0x0d, 0xdf, 0xdd, 0x4e, 0x9b, 0xbe, 0x08,
+	0xd3, 0x81, 0x4f, 0x3c, 0xc3, 0x1c, 0x88, 0xe8, 0xac, 0x84, 0xc3, 0xdd, 0x01, 0x7a, 0x17, 0xca,
+	0x03, 0x4c, 0x71, 0x5c, 0x4a, 0x84, 0x8f, 0xc7, 0x36, 0xaf, 0x33, 0x98, 0x76, 0x1f, 0x50, 0xa8,
+	0xf2, 0xd3, 0xec, 0xb7, 0xa2, 0xea, 0xc8, 0x93, 0x69, 0x49, 0x66, 0xc9, 0x58, 0x12, 0x15, 0xc8,
+	0xbf, 0x2a, 0xd0, 0xe1, 0xbd, 0x95, 0x7f, 0xcf, 0xf5, 0xd2, 0x47, 0xfa, 0x3d, 0x87, 0xd6, 0x5d,
+	0x68, 0x44, 0x31, 0x63, 0xf8, 0x84, 0xbe, 0xfa, 0x92, 0xab, 0x47, 0xd0, 0x7d, 0x42, 0xb5, 0x8f,
+	0x60, 0xe5, 0x5c, 0x9b, 0x5f, 0xfb, 0x93, 0xa3, 0x0d, 0x97, 0x05, 0xd9, 0x1e, 0xa1, 0x38, 0xf4,
+	0x6e, 0x14, 0x7d, 0x8f, 0x60, 0x71, 0x4c, 0x23, 0xe8, 0x7f, 0x0c, 0x55, 0x5b, 0xc8, 0xc4, 0x02,
+	0xed, 0xec, 0x02, 0xf1, 0x9c, 0x18, 0xa9, 0xfd, 0x57, 0x81, 0xb9, 0xcc, 0x05, 0x19, 0xfa, 0xeb,
+	0xd0, 0x73, 0x6d, 0x23, 0x7a, 0xa8, 0x48, 0x42, 0x63, 0x36, 0x94, 0xef, 0x0a, 0xf1, 0xee, 0x40,
+	0x8e, 0x9d, 0x62, 0x2a, 0x76, 0x0e, 0xa1, 0xc2, 0xf2, 0x28, 0xea, 0x13, 0x5a, 0x89, 0x29, 0x71,
+	0x9f, 0xbf, 0xf9, 0x5e, 0x58, 0x43, 0xff, 0xf9, 0x7c, 0xe5, 0xd6, 0x45, 0x9e, 0x32, 0xf8, 0xbc,
+	0x8d, 0x01, 0x1e, 0x52, 0xe2, 0xe9, 0x82, 0x1d, 0xfd, 0x08, 0x2a, 0xbc, 0xe2, 0xb7, 0xcb, 0x6c,
+	0x9d, 0x99, 0xe8, 0xa8, 0xe4, 0xab, 0x5e, 0x40, 0xb4, 0xdf, 0x2b, 0x30, 0xc5, 0x77, 0xf8, 0x7d,
+	0xc5, 0x8f, 0x0a, 0x55, 0xe2, 0xf4, 0xdd, 0x81, 0xe9, 0x1c, 0xb1, 0xb4, 0x9d, 0xd2, 0xe3, 0x71,
+	0xd8, 0xf2, 0xb2, 0xb3, 0x09, 0xf3, 0xb3, 0x21, 0x72, 0x66, 0x03, 0x66, 0x52, 0xb1, 0xf2, 0x2d,
+	0x3e, 0x70, 0x0d, 0x68, 0xc8, 0x1a, 0x74, 0x0d, 0xca, 0x74, 0x34, 0xe4, 0xf5, 0x67, 0xf6, 0xf6,
+	0x7c, 0xfc, 0x8d, 0x19, 0xaa, 0x0f, 0x46, 0x43, 0xa2, 0x33, 0x75, 0xdc, 0x80, 0x17, 0xf3, 0x1a,
+	0xf0, 0x12, 0x13, 0xf2, 0x81, 0xf6, 0x3b, 0x05, 0x66, 0x93, 0x08, 0xb9, 0x67, 0x5a, 0xe4, 0xbb,
+	0x08, 0x10, 0x15, 0xaa, 0x87, 0xa6, 0x45, 0x98, 0x0d, 0x7c, 0xb9, 0x78, 0x9c, 0xe7, 0xa9, 0x77,
+	0x7e, 0x0e, 0xb5, 0x78, 0x0b, 0xa8, 0x06, 0x53, 0x3b, 0x9f, 0x3e, 0xde, 0xf8, 0xb8, 0x59, 0x40,
+	0x33, 0x50, 0x7b, 0xf8, 0xe8, 0xc0, 0xe0, 0x43, 0x05, 0xcd, 0x41, 0x5d, 0xdf, 0xb9, 0xbf, 0xf3,
+	0x99, 0xb1, 0xb7, 0x71, 0xb0, 0xf5, 0xa0, 0x59, 0x44, 0x08, 0x66, 0xb9, 0xe0, 0xe1, 0x23, 0x21,
+	0x2b, 0xdd, 0xfe, 0x5f, 0x15, 0xaa, 0x91, 0x8d, 0xe8, 0x3d, 0x28, 0x7f, 0x12, 0xf8, 0xc7, 0xe8,
+	0x72, 0x12, 0xa1, 0xbf, 0xf4, 0x4c, 0x4a, 0x44, 0xc6, 0xa9, 0x8b, 0x63, 0x72, 0x9e, 0x6f, 0x5a,
+	0x21, 0x6c, 0xb9, 0xa4, 0x46, 0x0e, 0xe5, 0x7e, 0x89, 0xa8, 0x4b, 0x39, 0xfd, 0x60, 0xc2, 0x71,
+	0x53, 0x41, 0x8f, 0x60, 0x96, 0xa9, 0xa2, 0x8e, 0xc4, 0x47, 0x6f, 0x45, 0x53, 0xf2, 0x9a, 0x7b,
+	0x75, 0xf9, 0x1c, 0x6d, 0x6c, 0xd6, 0x83, 0xf4, 0x6b, 0x99, 0x9a, 0xf7, 0xb0, 0x96, 0x35, 0x2e,
+	0xe7, 0xe2, 0xd7, 0x0a, 0x68, 0x07, 0x20, 0xb9, 0x36, 0xd1, 0x95, 0x14, 0x58, 0xbe, 0xea, 0x55,
+	0x35, 0x4f, 0x15, 0xd3, 0x6c, 0x42, 0x2d, 0xbe, 0x34, 0x50, 0x3b, 0xe7, 0x1e, 0xe1, 0x24, 0xe7,
+	0xdf, 0x30, 0x5a, 0x01, 0xdd, 0x83, 0xc6, 0x86, 0x65, 0x5d, 0x84, 0x46, 0x95, 0x35, 0x7e, 0x96,
+	0xc7, 0x82, 0xc5, 0x73, 0xea, 0x34, 0xba, 0x9e, 0x7e, 0x8f, 0x39, 0xef, 0xf2, 0x51, 0x7f, 0x30,
+	0x11, 0x17, 0xaf, 0x76, 0x00, 0x73, 0x99, 0x72, 0x8d, 0x3a, 0x99, 0xd9, 0x99, 0x0a, 0xaf, 0xae,
+	0x9c, 0xab, 0x8f, 0x59, 0x7b, 0xd0, 0x4a, 0xfc, 0x1c, 0x3f, 0xac, 0x22, 0x6d, 0xfc, 0x10, 0xb2,
+	0xaf, 0xb8, 0xea, 0xdb, 0xaf, 0xc4, 0x48, 0x51, 0x79, 0x02, 0x97, 0xf3, 0x1f, 0x2e, 0xd1, 0xb5,
+	0x9c, 0x98, 0x19, 0x7f, 0x4c, 0x55, 0xaf, 0x4f, 0x82, 0x49, 0x8b, 0xed, 0x41, 0x43, 0x7e, 0xa4,
+	0x43, 0x71, 0x58, 0xe6, 0xbc, 0x01, 0xaa, 0x6f, 0xe5, 0x2b, 0x25, 0xba, 0x07, 0x50, 0x97, 0xdf,
+	0xcb, 0xe2, 0x80, 0x18, 0x7f, 0xc6, 0x53, 0x97, 0x72, 0x75, 0x72, 0x02, 0x24, 0xaf, 0x5c, 0x49,
+	0x02, 0x8c, 0xbd, 0x87, 0xa9, 0x6a, 0x9e, 0x2a, 0xa2, 0xd9, 0xfc, 0xe9, 0xb3, 0x17, 0x9d, 0xc2,
+	0xd7, 0x2f, 0x3a, 0x85, 0x6f, 0x5e, 0x74, 0x94, 0x2f, 0xce, 0x3a, 0xca, 0x9f, 0xcf, 0x3a, 0xca,
+	0x57, 0x67, 0x1d, 0xe5, 0xd9, 0x59, 0x47, 0xf9, 0xf7, 0x59, 0x47, 0xf9, 0xcf, 0x59, 0xa7, 0xf0,
+	0xcd, 0x59, 0x47, 0xf9, 0xc3, 0xcb, 0x4e, 0xe1, 0xd9, 0xcb, 0x4e, 0xe1, 0xeb, 0x97, 0x9d, 0xc2,
+	0xaf, 0x2a, 0x7d, 0xcb, 0x24, 0x0e, 0xed, 0x55, 0xd8, 0xc7, 0xdb, 0x9d, 0xff, 0x0f, 0x00, 0x22,
+	0x78, 0x4c, 0x15, 0x39, 0x18, 0x00, 0x00,
+}
+
+func (x MatchType) String() string {
+	s, ok := MatchType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *LabelNamesAndValuesRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ExemplarQueryRequest)
+	that1, ok := that.(*LabelNamesAndValuesRequest)
 	if !ok {
-		that2, ok := that.(ExemplarQueryRequest)
+		that2, ok := that.(LabelNamesAndValuesRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2009,12 +2316,6 @@ func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
-	}
 	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
@@ -2025,14 +2326,14 @@ func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *QueryResponse) Equal(that interface{}) bool {
+func (this *LabelNamesAndValuesResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*QueryResponse)
+	that1, ok := that.(*LabelNamesAndValuesResponse)
 	if !ok {
-		that2, ok := that.(QueryResponse)
+		that2, ok := that.(LabelNamesAndValuesResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2044,24 +2345,24 @@ func (this *QueryResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
+	if len(this.Items) != len(that1.Items) {
 		return false
 	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *QueryStreamResponse) Equal(that interface{}) bool {
+func (this *LabelValues) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*QueryStreamResponse)
+	that1, ok := that.(*LabelValues)
 	if !ok {
-		that2, ok := that.(QueryStreamResponse)
+		that2, ok := that.(LabelValues)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2073,32 +2374,27 @@ func (this *QueryStreamResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Chunkseries) != len(that1.Chunkseries) {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	for i := range this.Chunkseries {
-		if !this.Chunkseries[i].Equal(&that1.Chunkseries[i]) {
-			return false
-		}
-	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
+	if len(this.Values) != len(that1.Values) {
 		return false
 	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+	for i := range this.Values {
+		if this.Values[i] != that1.Values[i] {
 			return false
 		}
 	}
 	return true
 }
-func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ExemplarQueryResponse)
+	that1, ok := that.(*LabelValuesCardinalityRequest)
 	if !ok {
-		that2, ok := that.(ExemplarQueryResponse)
+		that2, ok := that.(LabelValuesCardinalityRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2110,24 +2406,32 @@ func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
+	if len(this.LabelNames) != len(that1.LabelNames) {
 		return false
 	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
+			return false
+		}
+	}
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
+	}
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *LabelValuesRequest) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelValuesRequest)
+	that1, ok := that.(*LabelValuesCardinalityResponse)
 	if !ok {
-		that2, ok := that.(LabelValuesRequest)
+		that2, ok := that.(LabelValuesCardinalityResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2139,28 +2443,24 @@ func (this *LabelValuesRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
+	if len(this.Items) != len(that1.Items) {
 		return false
 	}
-	if !this.Matchers.Equal(that1.Matchers) {
-		return false
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *LabelValuesResponse) Equal(that interface{}) bool {
+func (this *LabelValueSeriesCount) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelValuesResponse)
+	that1, ok := that.(*LabelValueSeriesCount)
 	if !ok {
-		that2, ok := that.(LabelValuesResponse)
+		that2, ok := that.(LabelValueSeriesCount)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2172,24 +2472,27 @@ func (this *LabelValuesResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.LabelValues) != len(that1.LabelValues) {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	for i := range this.LabelValues {
-		if this.LabelValues[i] != that1.LabelValues[i] {
+	if len(this.LabelValueSeries) != len(that1.LabelValueSeries) {
+		return false
+	}
+	for i := range this.LabelValueSeries {
+		if this.LabelValueSeries[i] != that1.LabelValueSeries[i] {
 			return false
 		}
 	}
 	return true
 }
-func (this *LabelNamesRequest) Equal(that interface{}) bool {
+func (this *ActiveSeriesRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelNamesRequest)
+	that1, ok := that.(*ActiveSeriesRequest)
 	if !ok {
-		that2, ok := that.(LabelNamesRequest)
+		that2, ok := that.(ActiveSeriesRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2201,25 +2504,24 @@ func (this *LabelNamesRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	if !this.Matchers.Equal(that1.Matchers) {
-		return false
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *LabelNamesResponse) Equal(that interface{}) bool {
+func (this *ActiveSeriesResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelNamesResponse)
+	that1, ok := that.(*ActiveSeriesResponse)
 	if !ok {
-		that2, ok := that.(LabelNamesResponse)
+		that2, ok := that.(ActiveSeriesResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2231,24 +2533,24 @@ func (this *LabelNamesResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.LabelNames) != len(that1.LabelNames) {
+	if len(this.Metric) != len(that1.Metric) {
 		return false
 	}
-	for i := range this.LabelNames {
-		if this.LabelNames[i] != that1.LabelNames[i] {
+	for i := range this.Metric {
+		if !this.Metric[i].Equal(that1.Metric[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *UserStatsRequest) Equal(that interface{}) bool {
+func (this *SeriesChurnRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserStatsRequest)
+	that1, ok := that.(*SeriesChurnRequest)
 	if !ok {
-		that2, ok := that.(UserStatsRequest)
+		that2, ok := that.(SeriesChurnRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2260,16 +2562,19 @@ func (this *UserStatsRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.WindowMs != that1.WindowMs {
+		return false
+	}
 	return true
 }
-func (this *UserStatsResponse) Equal(that interface{}) bool {
+func (this *SeriesChurnResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserStatsResponse)
+	that1, ok := that.(*SeriesChurnResponse)
 	if !ok {
-		that2, ok := that.(UserStatsResponse)
+		that2, ok := that.(SeriesChurnResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2281,28 +2586,24 @@ func (this *UserStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.IngestionRate != that1.IngestionRate {
-		return false
-	}
-	if this.NumSeries != that1.NumSeries {
-		return false
-	}
-	if this.ApiIngestionRate != that1.ApiIngestionRate {
+	if len(this.Items) != len(that1.Items) {
 		return false
 	}
-	if this.RuleIngestionRate != that1.RuleIngestionRate {
-		return false
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *UserIDStatsResponse) Equal(that interface{}) bool {
+func (this *MetricSeriesChurn) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserIDStatsResponse)
+	that1, ok := that.(*MetricSeriesChurn)
 	if !ok {
-		that2, ok := that.(UserIDStatsResponse)
+		that2, ok := that.(MetricSeriesChurn)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2314,22 +2615,25 @@ func (this *UserIDStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.UserId != that1.UserId {
+	if this.MetricName != that1.MetricName {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.CreatedSeries != that1.CreatedSeries {
+		return false
+	}
+	if this.RemovedSeries != that1.RemovedSeries {
 		return false
 	}
 	return true
 }
-func (this *UsersStatsResponse) Equal(that interface{}) bool {
+func (this *TSDBStatusRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UsersStatsResponse)
+	that1, ok := that.(*TSDBStatusRequest)
 	if !ok {
-		that2, ok := that.(UsersStatsResponse)
+		that2, ok := that.(TSDBStatusRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2341,24 +2645,16 @@ func (this *UsersStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Stats) != len(that1.Stats) {
-		return false
-	}
-	for i := range this.Stats {
-		if !this.Stats[i].Equal(that1.Stats[i]) {
-			return false
-		}
-	}
 	return true
 }
-func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
+func (this *TSDBStatusResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsForLabelMatchersRequest)
+	that1, ok := that.(*TSDBStatusResponse)
 	if !ok {
-		that2, ok := that.(MetricsForLabelMatchersRequest)
+		that2, ok := that.(TSDBStatusResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2370,30 +2666,63 @@ func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
+	if this.NumSeries != that1.NumSeries {
 		return false
 	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
+	if this.NumLabelPairs != that1.NumLabelPairs {
 		return false
 	}
-	if len(this.MatchersSet) != len(that1.MatchersSet) {
+	if this.ChunkCount != that1.ChunkCount {
 		return false
 	}
-	for i := range this.MatchersSet {
-		if !this.MatchersSet[i].Equal(that1.MatchersSet[i]) {
+	if this.MinTime != that1.MinTime {
+		return false
+	}
+	if this.MaxTime != that1.MaxTime {
+		return false
+	}
+	if len(this.SeriesCountByMetricName) != len(that1.SeriesCountByMetricName) {
+		return false
+	}
+	for i := range this.SeriesCountByMetricName {
+		if !this.SeriesCountByMetricName[i].Equal(&that1.SeriesCountByMetricName[i]) {
+			return false
+		}
+	}
+	if len(this.LabelValueCountByLabelName) != len(that1.LabelValueCountByLabelName) {
+		return false
+	}
+	for i := range this.LabelValueCountByLabelName {
+		if !this.LabelValueCountByLabelName[i].Equal(&that1.LabelValueCountByLabelName[i]) {
+			return false
+		}
+	}
+	if len(this.MemoryInBytesByLabelName) != len(that1.MemoryInBytesByLabelName) {
+		return false
+	}
+	for i := range this.MemoryInBytesByLabelName {
+		if !this.MemoryInBytesByLabelName[i].Equal(&that1.MemoryInBytesByLabelName[i]) {
+			return false
+		}
+	}
+	if len(this.SeriesCountByLabelValuePair) != len(that1.SeriesCountByLabelValuePair) {
+		return false
+	}
+	for i := range this.SeriesCountByLabelValuePair {
+		if !this.SeriesCountByLabelValuePair[i].Equal(&that1.SeriesCountByLabelValuePair[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
+func (this *TSDBStatItem) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsForLabelMatchersResponse)
+	that1, ok := that.(*TSDBStatItem)
 	if !ok {
-		that2, ok := that.(MetricsForLabelMatchersResponse)
+		that2, ok := that.(TSDBStatItem)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2405,24 +2734,22 @@ func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Metric) != len(that1.Metric) {
+	if this.Name != that1.Name {
 		return false
 	}
-	for i := range this.Metric {
-		if !this.Metric[i].Equal(that1.Metric[i]) {
-			return false
-		}
+	if this.Value != that1.Value {
+		return false
 	}
 	return true
 }
-func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
+func (this *ReadRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsMetadataRequest)
+	that1, ok := that.(*ReadRequest)
 	if !ok {
-		that2, ok := that.(MetricsMetadataRequest)
+		that2, ok := that.(ReadRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2434,16 +2761,24 @@ func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if len(this.Queries) != len(that1.Queries) {
+		return false
+	}
+	for i := range this.Queries {
+		if !this.Queries[i].Equal(that1.Queries[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
+func (this *ReadResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsMetadataResponse)
+	that1, ok := that.(*ReadResponse)
 	if !ok {
-		that2, ok := that.(MetricsMetadataResponse)
+		that2, ok := that.(ReadResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2455,24 +2790,24 @@ func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Metadata) != len(that1.Metadata) {
+	if len(this.Results) != len(that1.Results) {
 		return false
 	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *TimeSeriesChunk) Equal(that interface{}) bool {
+func (this *QueryRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeriesChunk)
+	that1, ok := that.(*QueryRequest)
 	if !ok {
-		that2, ok := that.(TimeSeriesChunk)
+		that2, ok := that.(QueryRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2484,38 +2819,30 @@ func (this *TimeSeriesChunk) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.FromIngesterId != that1.FromIngesterId {
-		return false
-	}
-	if this.UserId != that1.UserId {
+	if this.StartTimestampMs != that1.StartTimestampMs {
 		return false
 	}
-	if len(this.Labels) != len(that1.Labels) {
+	if this.EndTimestampMs != that1.EndTimestampMs {
 		return false
 	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
-			return false
-		}
-	}
-	if len(this.Chunks) != len(that1.Chunks) {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	for i := range this.Chunks {
-		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *Chunk) Equal(that interface{}) bool {
+func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Chunk)
+	that1, ok := that.(*ExemplarQueryRequest)
 	if !ok {
-		that2, ok := that.(Chunk)
+		that2, ok := that.(ExemplarQueryRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2533,22 +2860,24 @@ func (this *Chunk) Equal(that interface{}) bool {
 	if this.EndTimestampMs != that1.EndTimestampMs {
 		return false
 	}
-	if this.Encoding != that1.Encoding {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
 	}
 	return true
 }
-func (this *LabelMatchers) Equal(that interface{}) bool {
+func (this *QueryResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelMatchers)
+	that1, ok := that.(*QueryResponse)
 	if !ok {
-		that2, ok := that.(LabelMatchers)
+		that2, ok := that.(QueryResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2560,24 +2889,24 @@ func (this *LabelMatchers) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Matchers) != len(that1.Matchers) {
+	if len(this.Timeseries) != len(that1.Timeseries) {
 		return false
 	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
 			return false
 		}
 	}
 	return true
 }
-func (this *LabelMatcher) Equal(that interface{}) bool {
+func (this *QueryStreamResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelMatcher)
+	that1, ok := that.(*QueryStreamResponse)
 	if !ok {
-		that2, ok := that.(LabelMatcher)
+		that2, ok := that.(QueryStreamResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2589,25 +2918,35 @@ func (this *LabelMatcher) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if len(this.Chunkseries) != len(that1.Chunkseries) {
 		return false
 	}
-	if this.Name != that1.Name {
-		return false
+	for i := range this.Chunkseries {
+		if !this.Chunkseries[i].Equal(&that1.Chunkseries[i]) {
+			return false
+		}
 	}
-	if this.Value != that1.Value {
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
+	}
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
+	}
+	if !this.Stats.Equal(that1.Stats) {
 		return false
 	}
 	return true
 }
-func (this *TimeSeriesFile) Equal(that interface{}) bool {
+func (this *QueryStreamStats) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeriesFile)
+	that1, ok := that.(*QueryStreamStats)
 	if !ok {
-		that2, ok := that.(TimeSeriesFile)
+		that2, ok := that.(QueryStreamStats)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2619,2063 +2958,1803 @@ func (this *TimeSeriesFile) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.FromIngesterId != that1.FromIngesterId {
+	if this.WallTime != that1.WallTime {
 		return false
 	}
-	if this.UserId != that1.UserId {
+	if this.SelectTime != that1.SelectTime {
 		return false
 	}
-	if this.Filename != that1.Filename {
+	if this.SeriesCount != that1.SeriesCount {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	if this.SamplesCount != that1.SamplesCount {
 		return false
 	}
-	return true
-}
-func (this *LabelNamesAndValuesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.ChunksCount != that1.ChunksCount {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesAndValuesRequest{")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if this.ChunkBytes != that1.ChunkBytes {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesAndValuesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.HeadChunksCount != that1.HeadChunksCount {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesAndValuesResponse{")
-	if this.Items != nil {
-		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	if this.BlockChunksCount != that1.BlockChunksCount {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelValues) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelValues{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesCardinalityRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ExemplarQueryResponse)
+	if !ok {
+		that2, ok := that.(ExemplarQueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelValuesCardinalityRequest{")
-	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesCardinalityResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelValuesCardinalityResponse{")
-	if this.Items != nil {
-		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelValueSeriesCount) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelValueSeriesCount{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
-	for k, _ := range this.LabelValueSeries {
-		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
+func (this *LabelValuesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
-	mapStringForLabelValueSeries := "map[string]uint64{"
-	for _, k := range keysForLabelValueSeries {
-		mapStringForLabelValueSeries += fmt.Sprintf("%#v: %#v,", k, this.LabelValueSeries[k])
+
+	that1, ok := that.(*LabelValuesRequest)
+	if !ok {
+		that2, ok := that.(LabelValuesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	mapStringForLabelValueSeries += "}"
-	if this.LabelValueSeries != nil {
-		s = append(s, "LabelValueSeries: "+mapStringForLabelValueSeries+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ReadRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.LabelName != that1.LabelName {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ReadRequest{")
-	if this.Queries != nil {
-		s = append(s, "Queries: "+fmt.Sprintf("%#v", this.Queries)+",\n")
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ReadResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ReadResponse{")
-	if this.Results != nil {
-		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *QueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.QueryRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+func (this *LabelValuesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ExemplarQueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LabelValuesResponse)
+	if !ok {
+		that2, ok := that.(LabelValuesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.ExemplarQueryRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *QueryResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.LabelValues) != len(that1.LabelValues) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.QueryResponse{")
-	if this.Timeseries != nil {
-		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
+	for i := range this.LabelValues {
+		if this.LabelValues[i] != that1.LabelValues[i] {
+			return false
 		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *QueryStreamResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.QueryStreamResponse{")
-	if this.Chunkseries != nil {
-		vs := make([]*TimeSeriesChunk, len(this.Chunkseries))
-		for i := range vs {
-			vs[i] = &this.Chunkseries[i]
-		}
-		s = append(s, "Chunkseries: "+fmt.Sprintf("%#v", vs)+",\n")
+func (this *LabelNamesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if this.Timeseries != nil {
-		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
+
+	that1, ok := that.(*LabelNamesRequest)
+	if !ok {
+		that2, ok := that.(LabelNamesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ExemplarQueryResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ExemplarQueryResponse{")
-	if this.Timeseries != nil {
-		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
-		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.LabelValuesRequest{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelValuesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LabelNamesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelValuesResponse{")
-	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LabelNamesResponse)
+	if !ok {
+		that2, ok := that.(LabelNamesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.LabelNamesRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.LabelNames) != len(that1.LabelNames) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesResponse{")
-	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UserStatsRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.UserStatsRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *UserStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *UserStatsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.UserStatsResponse{")
-	s = append(s, "IngestionRate: "+fmt.Sprintf("%#v", this.IngestionRate)+",\n")
-	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
-	s = append(s, "ApiIngestionRate: "+fmt.Sprintf("%#v", this.ApiIngestionRate)+",\n")
-	s = append(s, "RuleIngestionRate: "+fmt.Sprintf("%#v", this.RuleIngestionRate)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UserIDStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*UserStatsRequest)
+	if !ok {
+		that2, ok := that.(UserStatsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.UserIDStatsResponse{")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	if this.Data != nil {
-		s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *UsersStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *UserStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.UsersStatsResponse{")
-	if this.Stats != nil {
-		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+
+	that1, ok := that.(*UserStatsResponse)
+	if !ok {
+		that2, ok := that.(UserStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsForLabelMatchersRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.MetricsForLabelMatchersRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.MatchersSet != nil {
-		s = append(s, "MatchersSet: "+fmt.Sprintf("%#v", this.MatchersSet)+",\n")
+	if this.IngestionRate != that1.IngestionRate {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsForLabelMatchersResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.NumSeries != that1.NumSeries {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.MetricsForLabelMatchersResponse{")
-	if this.Metric != nil {
-		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+	if this.ApiIngestionRate != that1.ApiIngestionRate {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsMetadataRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.RuleIngestionRate != that1.RuleIngestionRate {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.MetricsMetadataRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *MetricsMetadataResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *UserIDStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.MetricsMetadataResponse{")
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+
+	that1, ok := that.(*UserIDStatsResponse)
+	if !ok {
+		that2, ok := that.(UserIDStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *TimeSeriesChunk) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.TimeSeriesChunk{")
-	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	if this.Chunks != nil {
-		vs := make([]*Chunk, len(this.Chunks))
-		for i := range vs {
-			vs[i] = &this.Chunks[i]
-		}
-		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
+	if this.UserId != that1.UserId {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *Chunk) GoString() string {
-	if this == nil {
-		return "nil"
+	if !this.Data.Equal(that1.Data) {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.Chunk{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	s = append(s, "Encoding: "+fmt.Sprintf("%#v", this.Encoding)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelMatchers) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelMatchers{")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+func (this *UsersStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelMatcher) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*UsersStatsResponse)
+	if !ok {
+		that2, ok := that.(UsersStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.LabelMatcher{")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *TimeSeriesFile) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.TimeSeriesFile{")
-	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	s = append(s, "Filename: "+fmt.Sprintf("%#v", this.Filename)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringIngester(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if len(this.Stats) != len(that1.Stats) {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// IngesterClient is the client API for Ingester service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IngesterClient interface {
-	Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error)
-	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
-	QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error)
-	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
-	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
-	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
-	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
-	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
-	MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error)
-	// LabelNamesAndValues provides all values for each label that matches the matchers.
-	// The order of the labels and values is not guaranteed.
-	LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error)
-	// LabelValuesCardinality returns all values and series total count for label_names labels
-	// that match the matchers.
-	// The listing order of the labels is not guaranteed.
-	LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error)
-}
-
-type ingesterClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewIngesterClient(cc *grpc.ClientConn) IngesterClient {
-	return &ingesterClient{cc}
-}
-
-func (c *ingesterClient) Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error) {
-	out := new(mimirpb.WriteResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/Push", in, out, opts...)
-	if err != nil {
-		return nil, err
+	for i := range this.Stats {
+		if !this.Stats[i].Equal(that1.Stats[i]) {
+			return false
+		}
 	}
-	return out, nil
+	return true
 }
+func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *ingesterClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[0], "/cortex.Ingester/QueryStream", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*MetricsForLabelMatchersRequest)
+	if !ok {
+		that2, ok := that.(MetricsForLabelMatchersRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &ingesterQueryStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	return x, nil
-}
-
-type Ingester_QueryStreamClient interface {
-	Recv() (*QueryStreamResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterQueryStreamClient struct {
-	grpc.ClientStream
-}
-
-func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
-	m := new(QueryStreamResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	return m, nil
-}
-
-func (c *ingesterClient) QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error) {
-	out := new(ExemplarQueryResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/QueryExemplars", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if len(this.MatchersSet) != len(that1.MatchersSet) {
+		return false
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
-	out := new(LabelValuesResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
-	if err != nil {
-		return nil, err
+	for i := range this.MatchersSet {
+		if !this.MatchersSet[i].Equal(that1.MatchersSet[i]) {
+			return false
+		}
 	}
-	return out, nil
+	return true
 }
-
-func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error) {
-	out := new(LabelNamesResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNames", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
-	out := new(UserStatsResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*MetricsForLabelMatchersResponse)
+	if !ok {
+		that2, ok := that.(MetricsForLabelMatchersResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error) {
-	out := new(UsersStatsResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/AllUserStats", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error) {
-	out := new(MetricsForLabelMatchersResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsForLabelMatchers", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if len(this.Metric) != len(that1.Metric) {
+		return false
 	}
-	return out, nil
+	for i := range this.Metric {
+		if !this.Metric[i].Equal(that1.Metric[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *ingesterClient) MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error) {
-	out := new(MetricsMetadataResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsMetadata", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*MetricsMetadataRequest)
+	if !ok {
+		that2, ok := that.(MetricsMetadataRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
+func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *ingesterClient) LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[1], "/cortex.Ingester/LabelNamesAndValues", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*MetricsMetadataResponse)
+	if !ok {
+		that2, ok := that.(MetricsMetadataResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &ingesterLabelNamesAndValuesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
 	}
-	return x, nil
-}
-
-type Ingester_LabelNamesAndValuesClient interface {
-	Recv() (*LabelNamesAndValuesResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterLabelNamesAndValuesClient struct {
-	grpc.ClientStream
-}
-
-func (x *ingesterLabelNamesAndValuesClient) Recv() (*LabelNamesAndValuesResponse, error) {
-	m := new(LabelNamesAndValuesResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
+		}
 	}
-	return m, nil
+	return true
 }
+func (this *TimeSeriesChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *ingesterClient) LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[2], "/cortex.Ingester/LabelValuesCardinality", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*TimeSeriesChunk)
+	if !ok {
+		that2, ok := that.(TimeSeriesChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &ingesterLabelValuesCardinalityClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if this.FromIngesterId != that1.FromIngesterId {
+		return false
 	}
-	return x, nil
-}
-
-type Ingester_LabelValuesCardinalityClient interface {
-	Recv() (*LabelValuesCardinalityResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterLabelValuesCardinalityClient struct {
-	grpc.ClientStream
-}
-
-func (x *ingesterLabelValuesCardinalityClient) Recv() (*LabelValuesCardinalityResponse, error) {
-	m := new(LabelValuesCardinalityResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if this.UserId != that1.UserId {
+		return false
 	}
-	return m, nil
-}
-
-// IngesterServer is the server API for Ingester service.
-type IngesterServer interface {
-	Push(context.Context, *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error)
-	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
-	QueryExemplars(context.Context, *ExemplarQueryRequest) (*ExemplarQueryResponse, error)
-	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
-	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
-	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
-	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
-	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
-	MetricsMetadata(context.Context, *MetricsMetadataRequest) (*MetricsMetadataResponse, error)
-	// LabelNamesAndValues provides all values for each label that matches the matchers.
-	// The order of the labels and values is not guaranteed.
-	LabelNamesAndValues(*LabelNamesAndValuesRequest, Ingester_LabelNamesAndValuesServer) error
-	// LabelValuesCardinality returns all values and series total count for label_names labels
-	// that match the matchers.
-	// The listing order of the labels is not guaranteed.
-	LabelValuesCardinality(*LabelValuesCardinalityRequest, Ingester_LabelValuesCardinalityServer) error
-}
-
-// UnimplementedIngesterServer can be embedded to have forward compatible implementations.
-type UnimplementedIngesterServer struct {
-}
-
-func (*UnimplementedIngesterServer) Push(ctx context.Context, req *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
-}
-func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
-}
-func (*UnimplementedIngesterServer) QueryExemplars(ctx context.Context, req *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method QueryExemplars not implemented")
-}
-func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
-}
-func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
-}
-func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
-}
-func (*UnimplementedIngesterServer) AllUserStats(ctx context.Context, req *UserStatsRequest) (*UsersStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AllUserStats not implemented")
-}
-func (*UnimplementedIngesterServer) MetricsForLabelMatchers(ctx context.Context, req *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsForLabelMatchers not implemented")
-}
-func (*UnimplementedIngesterServer) MetricsMetadata(ctx context.Context, req *MetricsMetadataRequest) (*MetricsMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsMetadata not implemented")
-}
-func (*UnimplementedIngesterServer) LabelNamesAndValues(req *LabelNamesAndValuesRequest, srv Ingester_LabelNamesAndValuesServer) error {
-	return status.Errorf(codes.Unimplemented, "method LabelNamesAndValues not implemented")
-}
-func (*UnimplementedIngesterServer) LabelValuesCardinality(req *LabelValuesCardinalityRequest, srv Ingester_LabelValuesCardinalityServer) error {
-	return status.Errorf(codes.Unimplemented, "method LabelValuesCardinality not implemented")
-}
-
-func RegisterIngesterServer(s *grpc.Server, srv IngesterServer) {
-	s.RegisterService(&_Ingester_serviceDesc, srv)
-}
-
-func _Ingester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(mimirpb.WriteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if len(this.Labels) != len(that1.Labels) {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).Push(ctx, in)
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/Push",
+	if len(this.Chunks) != len(that1.Chunks) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).Push(ctx, req.(*mimirpb.WriteRequest))
+	for i := range this.Chunks {
+		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _Ingester_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(QueryRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (this *Chunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return srv.(IngesterServer).QueryStream(m, &ingesterQueryStreamServer{stream})
-}
-
-type Ingester_QueryStreamServer interface {
-	Send(*QueryStreamResponse) error
-	grpc.ServerStream
-}
-
-type ingesterQueryStreamServer struct {
-	grpc.ServerStream
-}
-
-func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
 
-func _Ingester_QueryExemplars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExemplarQueryRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IngesterServer).QueryExemplars(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/QueryExemplars",
+	that1, ok := that.(*Chunk)
+	if !ok {
+		that2, ok := that.(Chunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).QueryExemplars(ctx, req.(*ExemplarQueryRequest))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabelValuesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).LabelValues(ctx, in)
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/LabelValues",
+	if this.Encoding != that1.Encoding {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).LabelValues(ctx, req.(*LabelValuesRequest))
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *LabelMatchers) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabelNamesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*LabelMatchers)
+	if !ok {
+		that2, ok := that.(LabelMatchers)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).LabelNames(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/LabelNames",
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).LabelNames(ctx, req.(*LabelNamesRequest))
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *LabelMatcher) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*LabelMatcher)
+	if !ok {
+		that2, ok := that.(LabelMatcher)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).UserStats(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/UserStats",
+	if this.Type != that1.Type {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).UserStats(ctx, req.(*UserStatsRequest))
+	if this.Name != that1.Name {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.Value != that1.Value {
+		return false
+	}
+	return true
 }
+func (this *TimeSeriesFile) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_AllUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*TimeSeriesFile)
+	if !ok {
+		that2, ok := that.(TimeSeriesFile)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).AllUserStats(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/AllUserStats",
+	if this.FromIngesterId != that1.FromIngesterId {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).AllUserStats(ctx, req.(*UserStatsRequest))
+	if this.UserId != that1.UserId {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.Filename != that1.Filename {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
 }
-
-func _Ingester_MetricsForLabelMatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsForLabelMatchersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *LabelNamesAndValuesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, in)
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelNamesAndValuesRequest{")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/MetricsForLabelMatchers",
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelNamesAndValuesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, req.(*MetricsForLabelMatchersRequest))
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelNamesAndValuesResponse{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
 	}
-	return interceptor(ctx, in, info, handler)
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_MetricsMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsMetadataRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *LabelValues) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).MetricsMetadata(ctx, in)
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValues{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelValuesCardinalityRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/MetricsMetadata",
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValuesCardinalityRequest{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).MetricsMetadata(ctx, req.(*MetricsMetadataRequest))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelValuesCardinalityResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelValuesCardinalityResponse{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_LabelNamesAndValues_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(LabelNamesAndValuesRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (this *LabelValueSeriesCount) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return srv.(IngesterServer).LabelNamesAndValues(m, &ingesterLabelNamesAndValuesServer{stream})
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValueSeriesCount{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
+	for k, _ := range this.LabelValueSeries {
+		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
+	mapStringForLabelValueSeries := "map[string]uint64{"
+	for _, k := range keysForLabelValueSeries {
+		mapStringForLabelValueSeries += fmt.Sprintf("%#v: %#v,", k, this.LabelValueSeries[k])
+	}
+	mapStringForLabelValueSeries += "}"
+	if this.LabelValueSeries != nil {
+		s = append(s, "LabelValueSeries: "+mapStringForLabelValueSeries+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-type Ingester_LabelNamesAndValuesServer interface {
-	Send(*LabelNamesAndValuesResponse) error
-	grpc.ServerStream
+func (this *ActiveSeriesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ActiveSeriesRequest{")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-type ingesterLabelNamesAndValuesServer struct {
-	grpc.ServerStream
+func (this *ActiveSeriesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ActiveSeriesResponse{")
+	if this.Metric != nil {
+		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (x *ingesterLabelNamesAndValuesServer) Send(m *LabelNamesAndValuesResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (this *SeriesChurnRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.SeriesChurnRequest{")
+	s = append(s, "WindowMs: "+fmt.Sprintf("%#v", this.WindowMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func _Ingester_LabelValuesCardinality_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(LabelValuesCardinalityRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (this *SeriesChurnResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return srv.(IngesterServer).LabelValuesCardinality(m, &ingesterLabelValuesCardinalityServer{stream})
+	s := make([]string, 0, 5)
+	s = append(s, "&client.SeriesChurnResponse{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-type Ingester_LabelValuesCardinalityServer interface {
-	Send(*LabelValuesCardinalityResponse) error
-	grpc.ServerStream
-}
-
-type ingesterLabelValuesCardinalityServer struct {
-	grpc.ServerStream
-}
-
-func (x *ingesterLabelValuesCardinalityServer) Send(m *LabelValuesCardinalityResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-var _Ingester_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "cortex.Ingester",
-	HandlerType: (*IngesterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Push",
-			Handler:    _Ingester_Push_Handler,
-		},
-		{
-			MethodName: "QueryExemplars",
-			Handler:    _Ingester_QueryExemplars_Handler,
-		},
-		{
-			MethodName: "LabelValues",
-			Handler:    _Ingester_LabelValues_Handler,
-		},
-		{
-			MethodName: "LabelNames",
-			Handler:    _Ingester_LabelNames_Handler,
-		},
-		{
-			MethodName: "UserStats",
-			Handler:    _Ingester_UserStats_Handler,
-		},
-		{
-			MethodName: "AllUserStats",
-			Handler:    _Ingester_AllUserStats_Handler,
-		},
-		{
-			MethodName: "MetricsForLabelMatchers",
-			Handler:    _Ingester_MetricsForLabelMatchers_Handler,
-		},
-		{
-			MethodName: "MetricsMetadata",
-			Handler:    _Ingester_MetricsMetadata_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "QueryStream",
-			Handler:       _Ingester_QueryStream_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "LabelNamesAndValues",
-			Handler:       _Ingester_LabelNamesAndValues_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "LabelValuesCardinality",
-			Handler:       _Ingester_LabelValuesCardinality_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "ingester.proto",
-}
-
-func (m *LabelNamesAndValuesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *MetricSeriesChurn) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.MetricSeriesChurn{")
+	s = append(s, "MetricName: "+fmt.Sprintf("%#v", this.MetricName)+",\n")
+	s = append(s, "CreatedSeries: "+fmt.Sprintf("%#v", this.CreatedSeries)+",\n")
+	s = append(s, "RemovedSeries: "+fmt.Sprintf("%#v", this.RemovedSeries)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelNamesAndValuesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *TSDBStatusRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&client.TSDBStatusRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelNamesAndValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (this *TSDBStatusResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&client.TSDBStatusResponse{")
+	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
+	s = append(s, "NumLabelPairs: "+fmt.Sprintf("%#v", this.NumLabelPairs)+",\n")
+	s = append(s, "ChunkCount: "+fmt.Sprintf("%#v", this.ChunkCount)+",\n")
+	s = append(s, "MinTime: "+fmt.Sprintf("%#v", this.MinTime)+",\n")
+	s = append(s, "MaxTime: "+fmt.Sprintf("%#v", this.MaxTime)+",\n")
+	if this.SeriesCountByMetricName != nil {
+		vs := make([]*TSDBStatItem, len(this.SeriesCountByMetricName))
+		for i := range vs {
+			vs[i] = &this.SeriesCountByMetricName[i]
 		}
+		s = append(s, "SeriesCountByMetricName: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *LabelNamesAndValuesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.LabelValueCountByLabelName != nil {
+		vs := make([]*TSDBStatItem, len(this.LabelValueCountByLabelName))
+		for i := range vs {
+			vs[i] = &this.LabelValueCountByLabelName[i]
+		}
+		s = append(s, "LabelValueCountByLabelName: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelNamesAndValuesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LabelNamesAndValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if this.MemoryInBytesByLabelName != nil {
+		vs := make([]*TSDBStatItem, len(this.MemoryInBytesByLabelName))
+		for i := range vs {
+			vs[i] = &this.MemoryInBytesByLabelName[i]
 		}
+		s = append(s, "MemoryInBytesByLabelName: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return len(dAtA) - i, nil
+	if this.SeriesCountByLabelValuePair != nil {
+		vs := make([]*TSDBStatItem, len(this.SeriesCountByLabelValuePair))
+		for i := range vs {
+			vs[i] = &this.SeriesCountByLabelValuePair[i]
+		}
+		s = append(s, "SeriesCountByLabelValuePair: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValues) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *TSDBStatItem) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 6)
+	s = append(s, "&client.TSDBStatItem{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValues) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *ReadRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ReadRequest{")
+	if this.Queries != nil {
+		s = append(s, "Queries: "+fmt.Sprintf("%#v", this.Queries)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Values) > 0 {
-		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Values[iNdEx])
-			copy(dAtA[i:], m.Values[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.Values[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
+func (this *ReadResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.LabelName) > 0 {
-		i -= len(m.LabelName)
-		copy(dAtA[i:], m.LabelName)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ReadResponse{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesCardinalityRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *QueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.QueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *ExemplarQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&client.ExemplarQueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+func (this *QueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.LabelNames) > 0 {
-		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LabelNames[iNdEx])
-			copy(dAtA[i:], m.LabelNames[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+	s := make([]string, 0, 5)
+	s = append(s, "&client.QueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
 		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesCardinalityResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *QueryStreamResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.QueryStreamResponse{")
+	if this.Chunkseries != nil {
+		vs := make([]*TimeSeriesChunk, len(this.Chunkseries))
+		for i := range vs {
+			vs[i] = &this.Chunkseries[i]
+		}
+		s = append(s, "Chunkseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Timeseries != nil {
+		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *QueryStreamStats) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&client.QueryStreamStats{")
+	s = append(s, "WallTime: "+fmt.Sprintf("%#v", this.WallTime)+",\n")
+	s = append(s, "SelectTime: "+fmt.Sprintf("%#v", this.SelectTime)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "SamplesCount: "+fmt.Sprintf("%#v", this.SamplesCount)+",\n")
+	s = append(s, "ChunksCount: "+fmt.Sprintf("%#v", this.ChunksCount)+",\n")
+	s = append(s, "ChunkBytes: "+fmt.Sprintf("%#v", this.ChunkBytes)+",\n")
+	s = append(s, "HeadChunksCount: "+fmt.Sprintf("%#v", this.HeadChunksCount)+",\n")
+	s = append(s, "BlockChunksCount: "+fmt.Sprintf("%#v", this.BlockChunksCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (this *ExemplarQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ExemplarQueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
 		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValueSeriesCount) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelValuesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 8)
+	s = append(s, "&client.LabelValuesRequest{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValueSeriesCount) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *LabelValuesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelValuesResponse{")
+	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValueSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelValueSeries) > 0 {
-		for k := range m.LabelValueSeries {
-			v := m.LabelValueSeries[k]
-			baseI := i
-			i = encodeVarintIngester(dAtA, i, uint64(v))
-			i--
-			dAtA[i] = 0x10
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x12
-		}
+func (this *LabelNamesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.LabelName) > 0 {
-		i -= len(m.LabelName)
-		copy(dAtA[i:], m.LabelName)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelNamesRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelNamesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelNamesResponse{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Queries) > 0 {
-		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *UserStatsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 4)
+	s = append(s, "&client.UserStatsRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ReadResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *UserStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 8)
+	s = append(s, "&client.UserStatsResponse{")
+	s = append(s, "IngestionRate: "+fmt.Sprintf("%#v", this.IngestionRate)+",\n")
+	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
+	s = append(s, "ApiIngestionRate: "+fmt.Sprintf("%#v", this.ApiIngestionRate)+",\n")
+	s = append(s, "RuleIngestionRate: "+fmt.Sprintf("%#v", this.RuleIngestionRate)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *UserIDStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.UserIDStatsResponse{")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	if this.Data != nil {
+		s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *UsersStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.UsersStatsResponse{")
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *MetricsForLabelMatchersRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.MetricsForLabelMatchersRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.MatchersSet != nil {
+		s = append(s, "MatchersSet: "+fmt.Sprintf("%#v", this.MatchersSet)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *MetricsForLabelMatchersResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricsForLabelMatchersResponse{")
+	if this.Metric != nil {
+		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func (this *MetricsMetadataRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 4)
+	s = append(s, "&client.MetricsMetadataRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MetricsMetadataResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricsMetadataResponse{")
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *TimeSeriesChunk) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 8)
+	s = append(s, "&client.TimeSeriesChunk{")
+	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Chunks != nil {
+		vs := make([]*Chunk, len(this.Chunks))
+		for i := range vs {
+			vs[i] = &this.Chunks[i]
+		}
+		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *Chunk) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&client.Chunk{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	s = append(s, "Encoding: "+fmt.Sprintf("%#v", this.Encoding)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func (this *LabelMatchers) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelMatchers{")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelMatcher) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelMatcher{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *TimeSeriesFile) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 8)
+	s = append(s, "&client.TimeSeriesFile{")
+	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	s = append(s, "Filename: "+fmt.Sprintf("%#v", this.Filename)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func valueToGoStringIngester(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
 
-func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
-	return len(dAtA) - i, nil
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IngesterClient is the client API for Ingester service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IngesterClient interface {
+	Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error)
+	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
+	QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error)
+	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
+	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
+	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
+	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
+	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
+	MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error)
+	// LabelNamesAndValues provides all values for each label that matches the matchers.
+	// The order of the labels and values is not guaranteed.
+	LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error)
+	// LabelValuesCardinality returns all values and series total count for label_names labels
+	// that match the matchers.
+	// The listing order of the labels is not guaranteed.
+	LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error)
+	// ActiveSeries returns the series which are currently active and match the matchers.
+	// The listing order of the series is not guaranteed.
+	ActiveSeries(ctx context.Context, in *ActiveSeriesRequest, opts ...grpc.CallOption) (Ingester_ActiveSeriesClient, error)
+	// SeriesChurn returns the number of series created and removed for each metric name
+	// over the requested window.
+	SeriesChurn(ctx context.Context, in *SeriesChurnRequest, opts ...grpc.CallOption) (*SeriesChurnResponse, error)
+	// TSDBStatus returns the statistics of the tenant's TSDB head.
+	TSDBStatus(ctx context.Context, in *TSDBStatusRequest, opts ...grpc.CallOption) (*TSDBStatusResponse, error)
 }
 
-func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ingesterClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *QueryStreamResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func NewIngesterClient(cc *grpc.ClientConn) IngesterClient {
+	return &ingesterClient{cc}
 }
 
-func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.Chunkseries) > 0 {
-		for iNdEx := len(m.Chunkseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Chunkseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (c *ingesterClient) Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error) {
+	out := new(mimirpb.WriteResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *ExemplarQueryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[0], "/cortex.Ingester/QueryStream", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &ingesterQueryStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *ExemplarQueryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_QueryStreamClient interface {
+	Recv() (*QueryStreamResponse, error)
+	grpc.ClientStream
 }
 
-func (m *ExemplarQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
-	return len(dAtA) - i, nil
+type ingesterQueryStreamClient struct {
+	grpc.ClientStream
 }
 
-func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
+	m := new(QueryStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *LabelValuesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error) {
+	out := new(ExemplarQueryResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/QueryExemplars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *LabelValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Matchers != nil {
-		{
-			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIngester(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.LabelName) > 0 {
-		i -= len(m.LabelName)
-		copy(dAtA[i:], m.LabelName)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
-		i--
-		dAtA[i] = 0xa
+func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
+	out := new(LabelValuesResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *LabelValuesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error) {
+	out := new(LabelNamesResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNames", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *LabelValuesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
+	out := new(UserStatsResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *LabelValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelValues) > 0 {
-		for iNdEx := len(m.LabelValues) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LabelValues[iNdEx])
-			copy(dAtA[i:], m.LabelValues[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelValues[iNdEx])))
-			i--
-			dAtA[i] = 0xa
-		}
+func (c *ingesterClient) AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error) {
+	out := new(UsersStatsResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/AllUserStats", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *LabelNamesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error) {
+	out := new(MetricsForLabelMatchersResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsForLabelMatchers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *LabelNamesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error) {
+	out := new(MetricsMetadataResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *LabelNamesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Matchers != nil {
-		{
-			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIngester(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+func (c *ingesterClient) LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[1], "/cortex.Ingester/LabelNamesAndValues", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	x := &ingesterLabelNamesAndValuesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return x, nil
 }
 
-func (m *LabelNamesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type Ingester_LabelNamesAndValuesClient interface {
+	Recv() (*LabelNamesAndValuesResponse, error)
+	grpc.ClientStream
 }
 
-func (m *LabelNamesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ingesterLabelNamesAndValuesClient struct {
+	grpc.ClientStream
 }
 
-func (m *LabelNamesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelNames) > 0 {
-		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LabelNames[iNdEx])
-			copy(dAtA[i:], m.LabelNames[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
-			i--
-			dAtA[i] = 0xa
-		}
+func (x *ingesterLabelNamesAndValuesClient) Recv() (*LabelNamesAndValuesResponse, error) {
+	m := new(LabelNamesAndValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *UserStatsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[2], "/cortex.Ingester/LabelValuesCardinality", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &ingesterLabelValuesCardinalityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *UserStatsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_LabelValuesCardinalityClient interface {
+	Recv() (*LabelValuesCardinalityResponse, error)
+	grpc.ClientStream
 }
 
-func (m *UserStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+type ingesterLabelValuesCardinalityClient struct {
+	grpc.ClientStream
 }
 
-func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *ingesterLabelValuesCardinalityClient) Recv() (*LabelValuesCardinalityResponse, error) {
+	m := new(LabelValuesCardinalityResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UserStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return m, nil
 }
 
-func (m *UserStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.RuleIngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RuleIngestionRate))))
-		i--
-		dAtA[i] = 0x21
-	}
-	if m.ApiIngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ApiIngestionRate))))
-		i--
-		dAtA[i] = 0x19
-	}
-	if m.NumSeries != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
-		i--
-		dAtA[i] = 0x10
+func (c *ingesterClient) ActiveSeries(ctx context.Context, in *ActiveSeriesRequest, opts ...grpc.CallOption) (Ingester_ActiveSeriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[3], "/cortex.Ingester/ActiveSeries", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.IngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.IngestionRate))))
-		i--
-		dAtA[i] = 0x9
+	x := &ingesterActiveSeriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *UserIDStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return x, nil
 }
 
-func (m *UserIDStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_ActiveSeriesClient interface {
+	Recv() (*ActiveSeriesResponse, error)
+	grpc.ClientStream
 }
 
-func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Data != nil {
-		{
-			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIngester(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+type ingesterActiveSeriesClient struct {
+	grpc.ClientStream
 }
 
-func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *ingesterActiveSeriesClient) Recv() (*ActiveSeriesResponse, error) {
+	m := new(ActiveSeriesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UsersStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return m, nil
 }
 
-func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Stats) > 0 {
-		for iNdEx := len(m.Stats) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Stats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (c *ingesterClient) SeriesChurn(ctx context.Context, in *SeriesChurnRequest, opts ...grpc.CallOption) (*SeriesChurnResponse, error) {
+	out := new(SeriesChurnResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/SeriesChurn", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) TSDBStatus(ctx context.Context, in *TSDBStatusRequest, opts ...grpc.CallOption) (*TSDBStatusResponse, error) {
+	out := new(TSDBStatusResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/TSDBStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// IngesterServer is the server API for Ingester service.
+type IngesterServer interface {
+	Push(context.Context, *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error)
+	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
+	QueryExemplars(context.Context, *ExemplarQueryRequest) (*ExemplarQueryResponse, error)
+	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
+	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
+	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
+	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
+	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
+	MetricsMetadata(context.Context, *MetricsMetadataRequest) (*MetricsMetadataResponse, error)
+	// LabelNamesAndValues provides all values for each label that matches the matchers.
+	// The order of the labels and values is not guaranteed.
+	LabelNamesAndValues(*LabelNamesAndValuesRequest, Ingester_LabelNamesAndValuesServer) error
+	// LabelValuesCardinality returns all values and series total count for label_names labels
+	// that match the matchers.
+	// The listing order of the labels is not guaranteed.
+	LabelValuesCardinality(*LabelValuesCardinalityRequest, Ingester_LabelValuesCardinalityServer) error
+	// ActiveSeries returns the series which are currently active and match the matchers.
+	// The listing order of the series is not guaranteed.
+	ActiveSeries(*ActiveSeriesRequest, Ingester_ActiveSeriesServer) error
+	// SeriesChurn returns the number of series created and removed for each metric name
+	// over the requested window.
+	SeriesChurn(context.Context, *SeriesChurnRequest) (*SeriesChurnResponse, error)
+	// TSDBStatus returns the statistics of the tenant's TSDB head.
+	TSDBStatus(context.Context, *TSDBStatusRequest) (*TSDBStatusResponse, error)
 }
 
-func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.MatchersSet) > 0 {
-		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+// UnimplementedIngesterServer can be embedded to have forward compatible implementations.
+type UnimplementedIngesterServer struct {
 }
 
-func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIngesterServer) Push(ctx context.Context, req *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
 }
-
-func (m *MetricsForLabelMatchersResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
 }
-
-func (m *MetricsForLabelMatchersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metric) > 0 {
-		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
-	return len(dAtA) - i, nil
+func (*UnimplementedIngesterServer) QueryExemplars(ctx context.Context, req *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryExemplars not implemented")
 }
-
-func (m *MetricsMetadataRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
 }
-
-func (m *MetricsMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
+}
+func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
+}
+func (*UnimplementedIngesterServer) AllUserStats(ctx context.Context, req *UserStatsRequest) (*UsersStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllUserStats not implemented")
+}
+func (*UnimplementedIngesterServer) MetricsForLabelMatchers(ctx context.Context, req *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsForLabelMatchers not implemented")
+}
+func (*UnimplementedIngesterServer) MetricsMetadata(ctx context.Context, req *MetricsMetadataRequest) (*MetricsMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsMetadata not implemented")
+}
+func (*UnimplementedIngesterServer) LabelNamesAndValues(req *LabelNamesAndValuesRequest, srv Ingester_LabelNamesAndValuesServer) error {
+	return status.Errorf(codes.Unimplemented, "method LabelNamesAndValues not implemented")
+}
+func (*UnimplementedIngesterServer) LabelValuesCardinality(req *LabelValuesCardinalityRequest, srv Ingester_LabelValuesCardinalityServer) error {
+	return status.Errorf(codes.Unimplemented, "method LabelValuesCardinality not implemented")
+}
+func (*UnimplementedIngesterServer) ActiveSeries(req *ActiveSeriesRequest, srv Ingester_ActiveSeriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ActiveSeries not implemented")
+}
+func (*UnimplementedIngesterServer) SeriesChurn(ctx context.Context, req *SeriesChurnRequest) (*SeriesChurnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeriesChurn not implemented")
+}
+func (*UnimplementedIngesterServer) TSDBStatus(ctx context.Context, req *TSDBStatusRequest) (*TSDBStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TSDBStatus not implemented")
 }
 
-func (m *MetricsMetadataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func RegisterIngesterServer(s *grpc.Server, srv IngesterServer) {
+	s.RegisterService(&_Ingester_serviceDesc, srv)
 }
 
-func (m *MetricsMetadataResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Ingester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(mimirpb.WriteRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(IngesterServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/Push",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).Push(ctx, req.(*mimirpb.WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *MetricsMetadataResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _Ingester_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IngesterServer).QueryStream(m, &ingesterQueryStreamServer{stream})
 }
 
-func (m *MetricsMetadataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
-	}
-	return len(dAtA) - i, nil
+type Ingester_QueryStreamServer interface {
+	Send(*QueryStreamResponse) error
+	grpc.ServerStream
 }
 
-func (m *TimeSeriesChunk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type ingesterQueryStreamServer struct {
+	grpc.ServerStream
 }
 
-func (m *TimeSeriesChunk) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *TimeSeriesChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Chunks) > 0 {
-		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Chunks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
+func _Ingester_QueryExemplars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemplarQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Labels) > 0 {
-		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size := m.Labels[iNdEx].Size()
-				i -= size
-				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
-					return 0, err
-				}
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+	if interceptor == nil {
+		return srv.(IngesterServer).QueryExemplars(ctx, in)
 	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0x12
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/QueryExemplars",
 	}
-	if len(m.FromIngesterId) > 0 {
-		i -= len(m.FromIngesterId)
-		copy(dAtA[i:], m.FromIngesterId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
-		i--
-		dAtA[i] = 0xa
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).QueryExemplars(ctx, req.(*ExemplarQueryRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Chunk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelValuesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Chunk) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelValues(ctx, req.(*LabelValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x22
+func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Encoding != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.Encoding))
-		i--
-		dAtA[i] = 0x18
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelNames(ctx, in)
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelNames",
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelNames(ctx, req.(*LabelNamesRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
+func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).UserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/UserStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).UserStats(ctx, req.(*UserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ingester_AllUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).AllUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/AllUserStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).AllUserStats(ctx, req.(*UserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ingester_MetricsForLabelMatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsForLabelMatchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/MetricsForLabelMatchers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, req.(*MetricsForLabelMatchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ingester_MetricsMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).MetricsMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/MetricsMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).MetricsMetadata(ctx, req.(*MetricsMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ingester_LabelNamesAndValues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LabelNamesAndValuesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IngesterServer).LabelNamesAndValues(m, &ingesterLabelNamesAndValuesServer{stream})
+}
+
+type Ingester_LabelNamesAndValuesServer interface {
+	Send(*LabelNamesAndValuesResponse) error
+	grpc.ServerStream
+}
+
+type ingesterLabelNamesAndValuesServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingesterLabelNamesAndValuesServer) Send(m *LabelNamesAndValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ingester_LabelValuesCardinality_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LabelValuesCardinalityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IngesterServer).LabelValuesCardinality(m, &ingesterLabelValuesCardinalityServer{stream})
+}
+
+type Ingester_LabelValuesCardinalityServer interface {
+	Send(*LabelValuesCardinalityResponse) error
+	grpc.ServerStream
+}
+
+type ingesterLabelValuesCardinalityServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingesterLabelValuesCardinalityServer) Send(m *LabelValuesCardinalityResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ingester_ActiveSeries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ActiveSeriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IngesterServer).ActiveSeries(m, &ingesterActiveSeriesServer{stream})
+}
+
+type Ingester_ActiveSeriesServer interface {
+	Send(*ActiveSeriesResponse) error
+	grpc.ServerStream
+}
+
+type ingesterActiveSeriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingesterActiveSeriesServer) Send(m *ActiveSeriesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ingester_SeriesChurn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeriesChurnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).SeriesChurn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/SeriesChurn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).SeriesChurn(ctx, req.(*SeriesChurnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ingester_TSDBStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSDBStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).TSDBStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/TSDBStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).TSDBStatus(ctx, req.(*TSDBStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Ingester_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cortex.Ingester",
+	HandlerType: (*IngesterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Push",
+			Handler:    _Ingester_Push_Handler,
+		},
+		{
+			MethodName: "QueryExemplars",
+			Handler:    _Ingester_QueryExemplars_Handler,
+		},
+		{
+			MethodName: "LabelValues",
+			Handler:    _Ingester_LabelValues_Handler,
+		},
+		{
+			MethodName: "LabelNames",
+			Handler:    _Ingester_LabelNames_Handler,
+		},
+		{
+			MethodName: "UserStats",
+			Handler:    _Ingester_UserStats_Handler,
+		},
+		{
+			MethodName: "AllUserStats",
+			Handler:    _Ingester_AllUserStats_Handler,
+		},
+		{
+			MethodName: "MetricsForLabelMatchers",
+			Handler:    _Ingester_MetricsForLabelMatchers_Handler,
+		},
+		{
+			MethodName: "MetricsMetadata",
+			Handler:    _Ingester_MetricsMetadata_Handler,
+		},
+		{
+			MethodName: "SeriesChurn",
+			Handler:    _Ingester_SeriesChurn_Handler,
+		},
+		{
+			MethodName: "TSDBStatus",
+			Handler:    _Ingester_TSDBStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueryStream",
+			Handler:       _Ingester_QueryStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LabelNamesAndValues",
+			Handler:       _Ingester_LabelNamesAndValues_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LabelValuesCardinality",
+			Handler:       _Ingester_LabelValuesCardinality_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ActiveSeries",
+			Handler:       _Ingester_ActiveSeries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ingester.proto",
+}
+
+func (m *LabelNamesAndValuesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4685,12 +4764,12 @@ func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LabelMatchers) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelNamesAndValuesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LabelMatchers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelNamesAndValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4712,7 +4791,7 @@ func (m *LabelMatchers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
+func (m *LabelNamesAndValuesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4722,39 +4801,34 @@ func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelNamesAndValuesResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *LabelMatcher) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelNamesAndValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *TimeSeriesFile) Marshal() (dAtA []byte, err error) {
+func (m *LabelValues) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4764,1035 +4838,3757 @@ func (m *TimeSeriesFile) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TimeSeriesFile) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelValues) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TimeSeriesFile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Filename) > 0 {
-		i -= len(m.Filename)
-		copy(dAtA[i:], m.Filename)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Filename)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0x12
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	if len(m.FromIngesterId) > 0 {
-		i -= len(m.FromIngesterId)
-		copy(dAtA[i:], m.FromIngesterId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintIngester(dAtA []byte, offset int, v uint64) int {
-	offset -= sovIngester(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *LabelValuesCardinalityRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *LabelNamesAndValuesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *LabelValuesCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	return n
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelNames[iNdEx])
+			copy(dAtA[i:], m.LabelNames[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelNamesAndValuesResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LabelValuesCardinalityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelValues) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	if len(m.Values) > 0 {
-		for _, s := range m.Values {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (m *LabelValueSeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *LabelValuesCardinalityRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *LabelValueSeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValueSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.LabelNames) > 0 {
-		for _, s := range m.LabelNames {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.LabelValueSeries) > 0 {
+		for k := range m.LabelValueSeries {
+			v := m.LabelValueSeries[k]
+			baseI := i
+			i = encodeVarintIngester(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
 		}
 	}
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelValuesCardinalityResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (m *ActiveSeriesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *LabelValueSeriesCount) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	if len(m.LabelValueSeries) > 0 {
-		for k, v := range m.LabelValueSeries {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + sovIngester(uint64(v))
-			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
-		}
-	}
-	return n
+func (m *ActiveSeriesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ReadRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ActiveSeriesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Queries) > 0 {
-		for _, e := range m.Queries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ReadResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for _, e := range m.Results {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (m *ActiveSeriesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *QueryRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+func (m *ActiveSeriesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ExemplarQueryRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ActiveSeriesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.Metric) > 0 {
+		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *QueryResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for _, e := range m.Timeseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (m *SeriesChurnRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *QueryStreamResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SeriesChurnRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SeriesChurnRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Chunkseries) > 0 {
-		for _, e := range m.Chunkseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	if len(m.Timeseries) > 0 {
-		for _, e := range m.Timeseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	if m.WindowMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.WindowMs))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ExemplarQueryResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SeriesChurnResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesChurnResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SeriesChurnResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Timeseries) > 0 {
-		for _, e := range m.Timeseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelValuesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *MetricSeriesChurn) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricSeriesChurn) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricSeriesChurn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	if m.RemovedSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.RemovedSeries))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	if m.CreatedSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.CreatedSeries))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.Matchers != nil {
-		l = m.Matchers.Size()
-		n += 1 + l + sovIngester(uint64(l))
+	if len(m.MetricName) > 0 {
+		i -= len(m.MetricName)
+		copy(dAtA[i:], m.MetricName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.MetricName)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelValuesResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TSDBStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TSDBStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TSDBStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.LabelValues) > 0 {
-		for _, s := range m.LabelValues {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelNamesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TSDBStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if m.Matchers != nil {
-		l = m.Matchers.Size()
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *LabelNamesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.LabelNames) > 0 {
-		for _, s := range m.LabelNames {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+func (m *TSDBStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UserStatsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *TSDBStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	return n
-}
-
-func (m *UserStatsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.SeriesCountByLabelValuePair) > 0 {
+		for iNdEx := len(m.SeriesCountByLabelValuePair) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SeriesCountByLabelValuePair[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
 	}
-	var l int
-	_ = l
-	if m.IngestionRate != 0 {
-		n += 9
+	if len(m.MemoryInBytesByLabelName) > 0 {
+		for iNdEx := len(m.MemoryInBytesByLabelName) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MemoryInBytesByLabelName[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
 	}
-	if m.NumSeries != 0 {
-		n += 1 + sovIngester(uint64(m.NumSeries))
+	if len(m.LabelValueCountByLabelName) > 0 {
+		for iNdEx := len(m.LabelValueCountByLabelName) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LabelValueCountByLabelName[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
 	}
-	if m.ApiIngestionRate != 0 {
-		n += 9
+	if len(m.SeriesCountByMetricName) > 0 {
+		for iNdEx := len(m.SeriesCountByMetricName) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SeriesCountByMetricName[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
 	}
-	if m.RuleIngestionRate != 0 {
-		n += 9
+	if m.MaxTime != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MaxTime))
+		i--
+		dAtA[i] = 0x28
 	}
-	return n
-}
-
-func (m *UserIDStatsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.MinTime != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MinTime))
+		i--
+		dAtA[i] = 0x20
 	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ChunkCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ChunkCount))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Data != nil {
-		l = m.Data.Size()
-		n += 1 + l + sovIngester(uint64(l))
+	if m.NumLabelPairs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.NumLabelPairs))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if m.NumSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *UsersStatsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Stats) > 0 {
-		for _, e := range m.Stats {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (m *TSDBStatItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *MetricsForLabelMatchersRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *TSDBStatItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TSDBStatItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	if m.Value != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Value))
+		i--
+		dAtA[i] = 0x10
 	}
-	if len(m.MatchersSet) > 0 {
-		for _, e := range m.MatchersSet {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *MetricsForLabelMatchersResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Metric) > 0 {
-		for _, e := range m.Metric {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.Queries) > 0 {
+		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *MetricsMetadataRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReadResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *MetricsMetadataResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Metadata) > 0 {
-		for _, e := range m.Metadata {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *TimeSeriesChunk) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.FromIngesterId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	if len(m.Labels) > 0 {
-		for _, e := range m.Labels {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
 		}
 	}
-	if len(m.Chunks) > 0 {
-		for _, e := range m.Chunks {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Chunk) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ExemplarQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
 	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if m.Encoding != 0 {
-		n += 1 + sovIngester(uint64(m.Encoding))
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
 	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelMatchers) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LabelMatcher) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Type != 0 {
-		n += 1 + sovIngester(uint64(m.Type))
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if len(m.Chunkseries) > 0 {
+		for iNdEx := len(m.Chunkseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chunkseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *TimeSeriesFile) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryStreamStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStreamStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStreamStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.FromIngesterId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.BlockChunksCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.BlockChunksCount))
+		i--
+		dAtA[i] = 0x40
 	}
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.HeadChunksCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.HeadChunksCount))
+		i--
+		dAtA[i] = 0x38
 	}
-	l = len(m.Filename)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ChunkBytes != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ChunkBytes))
+		i--
+		dAtA[i] = 0x30
 	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ChunksCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ChunksCount))
+		i--
+		dAtA[i] = 0x28
 	}
-	return n
+	if m.SamplesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SamplesCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.SelectTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.SelectTime):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintIngester(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x12
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.WallTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintIngester(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func sovIngester(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *ExemplarQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozIngester(x uint64) (n int) {
-	return sovIngester(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *ExemplarQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *LabelNamesAndValuesRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+
+func (m *ExemplarQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&LabelNamesAndValuesRequest{`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *LabelNamesAndValuesResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForItems := "[]*LabelValues{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(f.String(), "LabelValues", "LabelValues", 1) + ","
+
+func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&LabelNamesAndValuesResponse{`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *LabelValues) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LabelValues{`,
-		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
-		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *LabelValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *LabelValuesCardinalityRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Matchers != nil {
+		{
+			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
 	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x18
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&LabelValuesCardinalityRequest{`,
-		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelValuesCardinalityResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForItems := "[]*LabelValueSeriesCount{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(f.String(), "LabelValueSeriesCount", "LabelValueSeriesCount", 1) + ","
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&LabelValuesCardinalityResponse{`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *LabelValueSeriesCount) String() string {
-	if this == nil {
-		return "nil"
-	}
-	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
-	for k, _ := range this.LabelValueSeries {
-		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
-	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
-	mapStringForLabelValueSeries := "map[string]uint64{"
-	for _, k := range keysForLabelValueSeries {
-		mapStringForLabelValueSeries += fmt.Sprintf("%v: %v,", k, this.LabelValueSeries[k])
+
+func (m *LabelValuesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	mapStringForLabelValueSeries += "}"
-	s := strings.Join([]string{`&LabelValueSeriesCount{`,
-		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
-		`LabelValueSeries:` + mapStringForLabelValueSeries + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *ReadRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelValuesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LabelValues) > 0 {
+		for iNdEx := len(m.LabelValues) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelValues[iNdEx])
+			copy(dAtA[i:], m.LabelValues[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelValues[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	repeatedStringForQueries := "[]*QueryRequest{"
-	for _, f := range this.Queries {
-		repeatedStringForQueries += strings.Replace(f.String(), "QueryRequest", "QueryRequest", 1) + ","
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelNamesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForQueries += "}"
-	s := strings.Join([]string{`&ReadRequest{`,
-		`Queries:` + repeatedStringForQueries + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *ReadResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelNamesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelNamesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Matchers != nil {
+		{
+			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	repeatedStringForResults := "[]*QueryResponse{"
-	for _, f := range this.Results {
-		repeatedStringForResults += strings.Replace(f.String(), "QueryResponse", "QueryResponse", 1) + ","
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForResults += "}"
-	s := strings.Join([]string{`&ReadResponse{`,
-		`Results:` + repeatedStringForResults + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
-func (this *QueryRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelNamesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
-	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&QueryRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *ExemplarQueryRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMatchers := "[]*LabelMatchers{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
-	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&ExemplarQueryRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *LabelNamesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *QueryResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelNamesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelNames[iNdEx])
+			copy(dAtA[i:], m.LabelNames[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	repeatedStringForTimeseries := "[]TimeSeries{"
-	for _, f := range this.Timeseries {
-		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	return len(dAtA) - i, nil
+}
+
+func (m *UserStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForTimeseries += "}"
-	s := strings.Join([]string{`&QueryResponse{`,
-		`Timeseries:` + repeatedStringForTimeseries + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *QueryStreamResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *UserStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForChunkseries := "[]TimeSeriesChunk{"
-	for _, f := range this.Chunkseries {
-		repeatedStringForChunkseries += strings.Replace(strings.Replace(f.String(), "TimeSeriesChunk", "TimeSeriesChunk", 1), `&`, ``, 1) + ","
+	return dAtA[:n], nil
+}
+
+func (m *UserStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RuleIngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RuleIngestionRate))))
+		i--
+		dAtA[i] = 0x21
 	}
-	repeatedStringForChunkseries += "}"
-	repeatedStringForTimeseries := "[]TimeSeries{"
-	for _, f := range this.Timeseries {
-		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	if m.ApiIngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ApiIngestionRate))))
+		i--
+		dAtA[i] = 0x19
 	}
-	repeatedStringForTimeseries += "}"
-	s := strings.Join([]string{`&QueryStreamResponse{`,
-		`Chunkseries:` + repeatedStringForChunkseries + `,`,
-		`Timeseries:` + repeatedStringForTimeseries + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ExemplarQueryResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.NumSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForTimeseries := "[]TimeSeries{"
-	for _, f := range this.Timeseries {
-		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	if m.IngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.IngestionRate))))
+		i--
+		dAtA[i] = 0x9
 	}
-	repeatedStringForTimeseries += "}"
-	s := strings.Join([]string{`&ExemplarQueryResponse{`,
-		`Timeseries:` + repeatedStringForTimeseries + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *LabelValuesRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *UserIDStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&LabelValuesRequest{`,
-		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *LabelValuesResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LabelValuesResponse{`,
-		`LabelValues:` + fmt.Sprintf("%v", this.LabelValues) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *UserIDStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *LabelNamesRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Data != nil {
+		{
+			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	s := strings.Join([]string{`&LabelNamesRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelNamesResponse) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	s := strings.Join([]string{`&LabelNamesResponse{`,
-		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *UserStatsRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&UserStatsRequest{`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *UserStatsResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&UserStatsResponse{`,
-		`IngestionRate:` + fmt.Sprintf("%v", this.IngestionRate) + `,`,
-		`NumSeries:` + fmt.Sprintf("%v", this.NumSeries) + `,`,
-		`ApiIngestionRate:` + fmt.Sprintf("%v", this.ApiIngestionRate) + `,`,
-		`RuleIngestionRate:` + fmt.Sprintf("%v", this.RuleIngestionRate) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *UsersStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *UserIDStatsResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Stats) > 0 {
+		for iNdEx := len(m.Stats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Stats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	s := strings.Join([]string{`&UserIDStatsResponse{`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Data:` + strings.Replace(this.Data.String(), "UserStatsResponse", "UserStatsResponse", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *UsersStatsResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForStats := "[]*UserIDStatsResponse{"
-	for _, f := range this.Stats {
-		repeatedStringForStats += strings.Replace(f.String(), "UserIDStatsResponse", "UserIDStatsResponse", 1) + ","
+
+func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForStats += "}"
-	s := strings.Join([]string{`&UsersStatsResponse{`,
-		`Stats:` + repeatedStringForStats + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *MetricsForLabelMatchersRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMatchersSet := "[]*LabelMatchers{"
-	for _, f := range this.MatchersSet {
-		repeatedStringForMatchersSet += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
-	}
-	repeatedStringForMatchersSet += "}"
-	s := strings.Join([]string{`&MetricsForLabelMatchersRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`MatchersSet:` + repeatedStringForMatchersSet + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *MetricsForLabelMatchersResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MatchersSet) > 0 {
+		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	repeatedStringForMetric := "[]*Metric{"
-	for _, f := range this.Metric {
-		repeatedStringForMetric += strings.Replace(fmt.Sprintf("%v", f), "Metric", "mimirpb.Metric", 1) + ","
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForMetric += "}"
-	s := strings.Join([]string{`&MetricsForLabelMatchersResponse{`,
-		`Metric:` + repeatedStringForMetric + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MetricsMetadataRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
 	}
-	s := strings.Join([]string{`&MetricsMetadataRequest{`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *MetricsMetadataResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMetadata := "[]*MetricMetadata{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "MetricMetadata", "mimirpb.MetricMetadata", 1) + ","
+
+func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&MetricsMetadataResponse{`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *TimeSeriesChunk) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForChunks := "[]Chunk{"
-	for _, f := range this.Chunks {
-		repeatedStringForChunks += strings.Replace(strings.Replace(f.String(), "Chunk", "Chunk", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForChunks += "}"
-	s := strings.Join([]string{`&TimeSeriesChunk{`,
-		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
-		`Chunks:` + repeatedStringForChunks + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *MetricsForLabelMatchersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *Chunk) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *MetricsForLabelMatchersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metric) > 0 {
+		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	s := strings.Join([]string{`&Chunk{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Encoding:` + fmt.Sprintf("%v", this.Encoding) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *LabelMatchers) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+
+func (m *MetricsMetadataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&LabelMatchers{`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *LabelMatcher) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LabelMatcher{`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *MetricsMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *TimeSeriesFile) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *MetricsMetadataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsMetadataResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&TimeSeriesFile{`,
-		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Filename:` + fmt.Sprintf("%v", this.Filename) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func valueToStringIngester(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
+
+func (m *MetricsMetadataResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsMetadataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimeSeriesChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeSeriesChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeSeriesChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chunks) > 0 {
+		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chunks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.FromIngesterId) > 0 {
+		i -= len(m.FromIngesterId)
+		copy(dAtA[i:], m.FromIngesterId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Chunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Chunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Encoding != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Encoding))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelMatchers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelMatchers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelMatcher) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimeSeriesFile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeSeriesFile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeSeriesFile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Filename) > 0 {
+		i -= len(m.Filename)
+		copy(dAtA[i:], m.Filename)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Filename)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.FromIngesterId) > 0 {
+		i -= len(m.FromIngesterId)
+		copy(dAtA[i:], m.FromIngesterId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIngester(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIngester(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *LabelNamesAndValuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelNamesAndValuesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValues) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, s := range m.LabelNames {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValueSeriesCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.LabelValueSeries) > 0 {
+		for k, v := range m.LabelValueSeries {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + sovIngester(uint64(v))
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ActiveSeriesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ActiveSeriesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Metric) > 0 {
+		for _, e := range m.Metric {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SeriesChurnRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.WindowMs != 0 {
+		n += 1 + sovIngester(uint64(m.WindowMs))
+	}
+	return n
+}
+
+func (m *SeriesChurnResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricSeriesChurn) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MetricName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.CreatedSeries != 0 {
+		n += 1 + sovIngester(uint64(m.CreatedSeries))
+	}
+	if m.RemovedSeries != 0 {
+		n += 1 + sovIngester(uint64(m.RemovedSeries))
+	}
+	return n
+}
+
+func (m *TSDBStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TSDBStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NumSeries != 0 {
+		n += 1 + sovIngester(uint64(m.NumSeries))
+	}
+	if m.NumLabelPairs != 0 {
+		n += 1 + sovIngester(uint64(m.NumLabelPairs))
+	}
+	if m.ChunkCount != 0 {
+		n += 1 + sovIngester(uint64(m.ChunkCount))
+	}
+	if m.MinTime != 0 {
+		n += 1 + sovIngester(uint64(m.MinTime))
+	}
+	if m.MaxTime != 0 {
+		n += 1 + sovIngester(uint64(m.MaxTime))
+	}
+	if len(m.SeriesCountByMetricName) > 0 {
+		for _, e := range m.SeriesCountByMetricName {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.LabelValueCountByLabelName) > 0 {
+		for _, e := range m.LabelValueCountByLabelName {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.MemoryInBytesByLabelName) > 0 {
+		for _, e := range m.MemoryInBytesByLabelName {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.SeriesCountByLabelValuePair) > 0 {
+		for _, e := range m.SeriesCountByLabelValuePair {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TSDBStatItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.Value != 0 {
+		n += 1 + sovIngester(uint64(m.Value))
+	}
+	return n
+}
+
+func (m *ReadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Queries) > 0 {
+		for _, e := range m.Queries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReadResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExemplarQueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryStreamResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Chunkseries) > 0 {
+		for _, e := range m.Chunkseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryStreamStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime)
+	n += 1 + l + sovIngester(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.SelectTime)
+	n += 1 + l + sovIngester(uint64(l))
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	if m.SamplesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SamplesCount))
+	}
+	if m.ChunksCount != 0 {
+		n += 1 + sovIngester(uint64(m.ChunksCount))
+	}
+	if m.ChunkBytes != 0 {
+		n += 1 + sovIngester(uint64(m.ChunkBytes))
+	}
+	if m.HeadChunksCount != 0 {
+		n += 1 + sovIngester(uint64(m.HeadChunksCount))
+	}
+	if m.BlockChunksCount != 0 {
+		n += 1 + sovIngester(uint64(m.BlockChunksCount))
+	}
+	return n
+}
+
+func (m *ExemplarQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelValuesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelValues) > 0 {
+		for _, s := range m.LabelValues {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelNamesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelNamesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, s := range m.LabelNames {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *UserStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *UserStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IngestionRate != 0 {
+		n += 9
+	}
+	if m.NumSeries != 0 {
+		n += 1 + sovIngester(uint64(m.NumSeries))
+	}
+	if m.ApiIngestionRate != 0 {
+		n += 9
+	}
+	if m.RuleIngestionRate != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *UserIDStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.Data != nil {
+		l = m.Data.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *UsersStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Stats) > 0 {
+		for _, e := range m.Stats {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricsForLabelMatchersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if len(m.MatchersSet) > 0 {
+		for _, e := range m.MatchersSet {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricsForLabelMatchersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Metric) > 0 {
+		for _, e := range m.Metric {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricsMetadataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *MetricsMetadataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TimeSeriesChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FromIngesterId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Chunks) > 0 {
+		for _, e := range m.Chunks {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Chunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Encoding != 0 {
+		n += 1 + sovIngester(uint64(m.Encoding))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelMatchers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelMatcher) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovIngester(uint64(m.Type))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *TimeSeriesFile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FromIngesterId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.Filename)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func sovIngester(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIngester(x uint64) (n int) {
+	return sovIngester(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *LabelNamesAndValuesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&LabelNamesAndValuesRequest{`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNamesAndValuesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*LabelValues{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "LabelValues", "LabelValues", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&LabelNamesAndValuesResponse{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValues) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValues{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&LabelValuesCardinalityRequest{`,
+		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*LabelValueSeriesCount{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "LabelValueSeriesCount", "LabelValueSeriesCount", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&LabelValuesCardinalityResponse{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValueSeriesCount) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
+	for k, _ := range this.LabelValueSeries {
+		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
+	mapStringForLabelValueSeries := "map[string]uint64{"
+	for _, k := range keysForLabelValueSeries {
+		mapStringForLabelValueSeries += fmt.Sprintf("%v: %v,", k, this.LabelValueSeries[k])
+	}
+	mapStringForLabelValueSeries += "}"
+	s := strings.Join([]string{`&LabelValueSeriesCount{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`LabelValueSeries:` + mapStringForLabelValueSeries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActiveSeriesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&ActiveSeriesRequest{`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActiveSeriesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetric := "[]*Metric{"
+	for _, f := range this.Metric {
+		repeatedStringForMetric += strings.Replace(fmt.Sprintf("%v", f), "Metric", "mimirpb.Metric", 1) + ","
+	}
+	repeatedStringForMetric += "}"
+	s := strings.Join([]string{`&ActiveSeriesResponse{`,
+		`Metric:` + repeatedStringForMetric + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SeriesChurnRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SeriesChurnRequest{`,
+		`WindowMs:` + fmt.Sprintf("%v", this.WindowMs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SeriesChurnResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*MetricSeriesChurn{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "MetricSeriesChurn", "MetricSeriesChurn", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&SeriesChurnResponse{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricSeriesChurn) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MetricSeriesChurn{`,
+		`MetricName:` + fmt.Sprintf("%v", this.MetricName) + `,`,
+		`CreatedSeries:` + fmt.Sprintf("%v", this.CreatedSeries) + `,`,
+		`RemovedSeries:` + fmt.Sprintf("%v", this.RemovedSeries) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TSDBStatusRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TSDBStatusRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TSDBStatusResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSeriesCountByMetricName := "[]TSDBStatItem{"
+	for _, f := range this.SeriesCountByMetricName {
+		repeatedStringForSeriesCountByMetricName += strings.Replace(strings.Replace(f.String(), "TSDBStatItem", "TSDBStatItem", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForSeriesCountByMetricName += "}"
+	repeatedStringForLabelValueCountByLabelName := "[]TSDBStatItem{"
+	for _, f := range this.LabelValueCountByLabelName {
+		repeatedStringForLabelValueCountByLabelName += strings.Replace(strings.Replace(f.String(), "TSDBStatItem", "TSDBStatItem", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForLabelValueCountByLabelName += "}"
+	repeatedStringForMemoryInBytesByLabelName := "[]TSDBStatItem{"
+	for _, f := range this.MemoryInBytesByLabelName {
+		repeatedStringForMemoryInBytesByLabelName += strings.Replace(strings.Replace(f.String(), "TSDBStatItem", "TSDBStatItem", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForMemoryInBytesByLabelName += "}"
+	repeatedStringForSeriesCountByLabelValuePair := "[]TSDBStatItem{"
+	for _, f := range this.SeriesCountByLabelValuePair {
+		repeatedStringForSeriesCountByLabelValuePair += strings.Replace(strings.Replace(f.String(), "TSDBStatItem", "TSDBStatItem", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForSeriesCountByLabelValuePair += "}"
+	s := strings.Join([]string{`&TSDBStatusResponse{`,
+		`NumSeries:` + fmt.Sprintf("%v", this.NumSeries) + `,`,
+		`NumLabelPairs:` + fmt.Sprintf("%v", this.NumLabelPairs) + `,`,
+		`ChunkCount:` + fmt.Sprintf("%v", this.ChunkCount) + `,`,
+		`MinTime:` + fmt.Sprintf("%v", this.MinTime) + `,`,
+		`MaxTime:` + fmt.Sprintf("%v", this.MaxTime) + `,`,
+		`SeriesCountByMetricName:` + repeatedStringForSeriesCountByMetricName + `,`,
+		`LabelValueCountByLabelName:` + repeatedStringForLabelValueCountByLabelName + `,`,
+		`MemoryInBytesByLabelName:` + repeatedStringForMemoryInBytesByLabelName + `,`,
+		`SeriesCountByLabelValuePair:` + repeatedStringForSeriesCountByLabelValuePair + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TSDBStatItem) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TSDBStatItem{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForQueries := "[]*QueryRequest{"
+	for _, f := range this.Queries {
+		repeatedStringForQueries += strings.Replace(f.String(), "QueryRequest", "QueryRequest", 1) + ","
+	}
+	repeatedStringForQueries += "}"
+	s := strings.Join([]string{`&ReadRequest{`,
+		`Queries:` + repeatedStringForQueries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResults := "[]*QueryResponse{"
+	for _, f := range this.Results {
+		repeatedStringForResults += strings.Replace(f.String(), "QueryResponse", "QueryResponse", 1) + ","
+	}
+	repeatedStringForResults += "}"
+	s := strings.Join([]string{`&ReadResponse{`,
+		`Results:` + repeatedStringForResults + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&QueryRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExemplarQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatchers{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&ExemplarQueryRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&QueryResponse{`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryStreamResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChunkseries := "[]TimeSeriesChunk{"
+	for _, f := range this.Chunkseries {
+		repeatedStringForChunkseries += strings.Replace(strings.Replace(f.String(), "TimeSeriesChunk", "TimeSeriesChunk", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForChunkseries += "}"
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&QueryStreamResponse{`,
+		`Chunkseries:` + repeatedStringForChunkseries + `,`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`Stats:` + strings.Replace(this.Stats.String(), "QueryStreamStats", "QueryStreamStats", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryStreamStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QueryStreamStats{`,
+		`WallTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.WallTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`SelectTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.SelectTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`SamplesCount:` + fmt.Sprintf("%v", this.SamplesCount) + `,`,
+		`ChunksCount:` + fmt.Sprintf("%v", this.ChunksCount) + `,`,
+		`ChunkBytes:` + fmt.Sprintf("%v", this.ChunkBytes) + `,`,
+		`HeadChunksCount:` + fmt.Sprintf("%v", this.HeadChunksCount) + `,`,
+		`BlockChunksCount:` + fmt.Sprintf("%v", this.BlockChunksCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExemplarQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&ExemplarQueryResponse{`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesRequest{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesResponse{`,
+		`LabelValues:` + fmt.Sprintf("%v", this.LabelValues) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNamesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelNamesRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNamesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelNamesResponse{`,
+		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UserStatsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UserStatsRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UserStatsResponse) String() string {
+	if this == nil {
 		return "nil"
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	s := strings.Join([]string{`&UserStatsResponse{`,
+		`IngestionRate:` + fmt.Sprintf("%v", this.IngestionRate) + `,`,
+		`NumSeries:` + fmt.Sprintf("%v", this.NumSeries) + `,`,
+		`ApiIngestionRate:` + fmt.Sprintf("%v", this.ApiIngestionRate) + `,`,
+		`RuleIngestionRate:` + fmt.Sprintf("%v", this.RuleIngestionRate) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UserIDStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UserIDStatsResponse{`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Data:` + strings.Replace(this.Data.String(), "UserStatsResponse", "UserStatsResponse", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UsersStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForStats := "[]*UserIDStatsResponse{"
+	for _, f := range this.Stats {
+		repeatedStringForStats += strings.Replace(f.String(), "UserIDStatsResponse", "UserIDStatsResponse", 1) + ","
+	}
+	repeatedStringForStats += "}"
+	s := strings.Join([]string{`&UsersStatsResponse{`,
+		`Stats:` + repeatedStringForStats + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsForLabelMatchersRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchersSet := "[]*LabelMatchers{"
+	for _, f := range this.MatchersSet {
+		repeatedStringForMatchersSet += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchersSet += "}"
+	s := strings.Join([]string{`&MetricsForLabelMatchersRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`MatchersSet:` + repeatedStringForMatchersSet + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsForLabelMatchersResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetric := "[]*Metric{"
+	for _, f := range this.Metric {
+		repeatedStringForMetric += strings.Replace(fmt.Sprintf("%v", f), "Metric", "mimirpb.Metric", 1) + ","
+	}
+	repeatedStringForMetric += "}"
+	s := strings.Join([]string{`&MetricsForLabelMatchersResponse{`,
+		`Metric:` + repeatedStringForMetric + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsMetadataRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MetricsMetadataRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsMetadataResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*MetricMetadata{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "MetricMetadata", "mimirpb.MetricMetadata", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&MetricsMetadataResponse{`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TimeSeriesChunk) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChunks := "[]Chunk{"
+	for _, f := range this.Chunks {
+		repeatedStringForChunks += strings.Replace(strings.Replace(f.String(), "Chunk", "Chunk", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForChunks += "}"
+	s := strings.Join([]string{`&TimeSeriesChunk{`,
+		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Chunks:` + repeatedStringForChunks + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Chunk) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Chunk{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Encoding:` + fmt.Sprintf("%v", this.Encoding) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelMatchers) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&LabelMatchers{`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelMatcher) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelMatcher{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TimeSeriesFile) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TimeSeriesFile{`,
+		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Filename:` + fmt.Sprintf("%v", this.Filename) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringIngester(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelNamesAndValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelNamesAndValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelNamesAndValuesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelNamesAndValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &LabelValues{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValues) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValues: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValues: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &LabelValueSeriesCount{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValueSeriesCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValueSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueSeries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueSeries == nil {
+				m.LabelValueSeries = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueSeries[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActiveSeriesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActiveSeriesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActiveSeriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActiveSeriesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActiveSeriesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActiveSeriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metric", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metric = append(m.Metric, &mimirpb.Metric{})
+			if err := m.Metric[len(m.Metric)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
+func (m *SeriesChurnRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5815,17 +8611,17 @@ func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesAndValuesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeriesChurnRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesAndValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeriesChurnRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowMs", wireType)
 			}
-			var msglen int
+			m.WindowMs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -5835,26 +8631,11 @@ func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.WindowMs |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthIngester
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Matchers = append(m.Matchers, &LabelMatcher{})
-			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -5879,7 +8660,7 @@ func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
+func (m *SeriesChurnResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5902,10 +8683,10 @@ func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesAndValuesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeriesChurnResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesAndValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeriesChurnResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5937,7 +8718,7 @@ func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, &LabelValues{})
+			m.Items = append(m.Items, &MetricSeriesChurn{})
 			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -5966,7 +8747,7 @@ func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValues) Unmarshal(dAtA []byte) error {
+func (m *MetricSeriesChurn) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5989,15 +8770,15 @@ func (m *LabelValues) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValues: wiretype end group for non-group")
+			return fmt.Errorf("proto: MetricSeriesChurn: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValues: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MetricSeriesChurn: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6025,13 +8806,252 @@ func (m *LabelValues) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelName = string(dAtA[iNdEx:postIndex])
+			m.MetricName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedSeries", wireType)
+			}
+			m.CreatedSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedSeries |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedSeries", wireType)
+			}
+			m.RemovedSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RemovedSeries |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TSDBStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TSDBStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TSDBStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TSDBStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TSDBStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TSDBStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumSeries", wireType)
+			}
+			m.NumSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumSeries |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumLabelPairs", wireType)
+			}
+			m.NumLabelPairs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumLabelPairs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkCount", wireType)
+			}
+			m.ChunkCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinTime", wireType)
+			}
+			m.MinTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTime", wireType)
+			}
+			m.MaxTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCountByMetricName", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -6041,82 +9061,31 @@ func (m *LabelValues) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngester
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipIngester(dAtA[iNdEx:])
-			if err != nil {
+			m.SeriesCountByMetricName = append(m.SeriesCountByMetricName, TSDBStatItem{})
+			if err := m.SeriesCountByMetricName[len(m.SeriesCountByMetricName)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowIngester
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesCardinalityRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueCountByLabelName", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -6126,27 +9095,29 @@ func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngester
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			m.LabelValueCountByLabelName = append(m.LabelValueCountByLabelName, TSDBStatItem{})
+			if err := m.LabelValueCountByLabelName[len(m.LabelValueCountByLabelName)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryInBytesByLabelName", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6173,67 +9144,14 @@ func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Matchers = append(m.Matchers, &LabelMatcher{})
-			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.MemoryInBytesByLabelName = append(m.MemoryInBytesByLabelName, TSDBStatItem{})
+			if err := m.MemoryInBytesByLabelName[len(m.MemoryInBytesByLabelName)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipIngester(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowIngester
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesCardinalityResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCountByLabelValuePair", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6260,8 +9178,8 @@ func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, &LabelValueSeriesCount{})
-			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SeriesCountByLabelValuePair = append(m.SeriesCountByLabelValuePair, TSDBStatItem{})
+			if err := m.SeriesCountByLabelValuePair[len(m.SeriesCountByLabelValuePair)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6289,7 +9207,7 @@ func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
+func (m *TSDBStatItem) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6312,15 +9230,15 @@ func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValueSeriesCount: wiretype end group for non-group")
+			return fmt.Errorf("proto: TSDBStatItem: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValueSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TSDBStatItem: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6348,121 +9266,27 @@ func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueSeries", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIngester
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthIngester
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.LabelValueSeries == nil {
-				m.LabelValueSeries = make(map[string]uint64)
-			}
-			var mapkey string
-			var mapvalue uint64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowIngester
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowIngester
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthIngester
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthIngester
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowIngester
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipIngester(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthIngester
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.LabelValueSeries[mapkey] = mapvalue
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -7095,6 +9919,275 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &QueryStreamStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStreamStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStreamStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStreamStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WallTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.WallTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SelectTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.SelectTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SamplesCount", wireType)
+			}
+			m.SamplesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SamplesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunksCount", wireType)
+			}
+			m.ChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkBytes", wireType)
+			}
+			m.ChunkBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeadChunksCount", wireType)
+			}
+			m.HeadChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HeadChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockChunksCount", wireType)
+			}
+			m.BlockChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
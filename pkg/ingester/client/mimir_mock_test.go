@@ -76,3 +76,18 @@ func (m *IngesterServerMock) LabelValuesCardinality(req *LabelValuesCardinalityR
 	args := m.Called(req, srv)
 	return args.Error(0)
 }
+
+func (m *IngesterServerMock) ActiveSeries(req *ActiveSeriesRequest, srv Ingester_ActiveSeriesServer) error {
+	args := m.Called(req, srv)
+	return args.Error(0)
+}
+
+func (m *IngesterServerMock) SeriesChurn(ctx context.Context, r *SeriesChurnRequest) (*SeriesChurnResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*SeriesChurnResponse), args.Error(1)
+}
+
+func (m *IngesterServerMock) TSDBStatus(ctx context.Context, r *TSDBStatusRequest) (*TSDBStatusResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*TSDBStatusResponse), args.Error(1)
+}
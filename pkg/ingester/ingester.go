@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -56,6 +57,7 @@ import (
 	"github.com/grafana/mimir/pkg/storage/chunk"
 	"github.com/grafana/mimir/pkg/storage/sharding"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	util_log "github.com/grafana/mimir/pkg/util/log"
 	util_math "github.com/grafana/mimir/pkg/util/math"
@@ -74,6 +76,27 @@ const (
 	// Period at which to attempt purging metadata from memory.
 	metadataPurgePeriod = 5 * time.Minute
 
+	// How long the upload of the metric metadata snapshots on shutdown can take at most.
+	metadataUploadOnStopTimeout = 30 * time.Second
+
+	// Period at which to purge the stale series churn and update the series churn metrics.
+	seriesChurnUpdatePeriod = 1 * time.Minute
+
+	// Period at which to check the number of in-memory series for the early head compaction.
+	earlyHeadCompactionCheckPeriod = 10 * time.Second
+
+	// Ratio of -ingester.instance-limits.max-series used as early head compaction threshold, if not configured.
+	earlyHeadCompactionMaxSeriesRatio = 0.9
+
+	// Min interval between two early head compactions of the same tenant. The interval is doubled, up to
+	// the max backoff, each time the early head compaction of the tenant doesn't release any series.
+	earlyHeadCompactionMinInterval = 5 * time.Minute
+	earlyHeadCompactionMaxBackoff  = time.Hour
+
+	// Min time range of samples a tenant's head must hold before the early head compaction
+	// time range, to get compacted early. It avoids cutting a lot of tiny blocks.
+	earlyHeadCompactionMinBlockSpan = 10 * time.Minute
+
 	// IngesterRingKey is the key under which we store the ingesters ring in the KVStore.
 	IngesterRingKey = "ring"
 
@@ -92,7 +115,10 @@ const (
 )
 
 var (
-	errExemplarRef = errors.New("exemplars not ingested because series not already present")
+	errExemplarRef                  = errors.New("exemplars not ingested because series not already present")
+	errActiveSeriesTrackingDisabled = errors.New("active series tracking is disabled")
+	errSeriesChurnTrackingDisabled  = errors.New("series churn tracking is disabled")
+	errIngesterNotRunning           = errors.New("ingester not running")
 )
 
 // Shipper interface is used to have an easy way to mock it in tests.
@@ -121,6 +147,8 @@ type Config struct {
 	// Config for metadata purging.
 	MetadataRetainPeriod time.Duration `yaml:"metadata_retain_period" category:"advanced"`
 
+	MetadataUploadInterval time.Duration `yaml:"metadata_upload_interval" category:"experimental"`
+
 	RateUpdatePeriod time.Duration `yaml:"rate_update_period" category:"advanced"`
 
 	ActiveSeriesMetricsEnabled      bool                              `yaml:"active_series_metrics_enabled" category:"advanced"`
@@ -128,6 +156,9 @@ type Config struct {
 	ActiveSeriesMetricsIdleTimeout  time.Duration                     `yaml:"active_series_metrics_idle_timeout" category:"advanced"`
 	ActiveSeriesCustomTrackers      activeseries.CustomTrackersConfig `yaml:"active_series_custom_trackers" doc:"description=[Deprecated] This config has been moved to the limits config, please set it there. Additional custom trackers for active metrics. If there are active series matching a provided matcher (map value), the count will be exposed in the custom trackers metric labeled using the tracker name (map key). Zero valued counts are not exposed (and removed when they go back to zero)." category:"advanced"`
 
+	SeriesChurnTrackingEnabled bool `yaml:"series_churn_tracking_enabled" category:"experimental"`
+	SeriesChurnMetricsTopN     int  `yaml:"series_churn_metrics_top_n" category:"experimental"`
+
 	ExemplarsUpdatePeriod time.Duration `yaml:"exemplars_update_period" category:"experimental"`
 
 	BlocksStorageConfig         mimir_tsdb.BlocksStorageConfig `yaml:"-"`
@@ -138,6 +169,11 @@ type Config struct {
 	DefaultLimits    InstanceLimits         `yaml:"instance_limits"`
 	InstanceLimitsFn func() *InstanceLimits `yaml:"-"`
 
+	PushCircuitBreaker PushCircuitBreakerConfig `yaml:"push_circuit_breaker"`
+
+	// Tracks the tenants usage, if usage metering is enabled.
+	UsageRecorder *usagemetering.Recorder `yaml:"-"`
+
 	IgnoreSeriesLimitForMetricNames string `yaml:"ignore_series_limit_for_metric_names" category:"advanced"`
 
 	// For testing, you can override the address and ID of this ingester.
@@ -149,12 +185,16 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	cfg.IngesterRing.RegisterFlags(f, logger)
 
 	f.DurationVar(&cfg.MetadataRetainPeriod, "ingester.metadata-retain-period", 10*time.Minute, "Period at which metadata we have not seen will remain in memory before being deleted.")
+	f.DurationVar(&cfg.MetadataUploadInterval, "ingester.metadata-upload-interval", 0, "How frequently a snapshot of the in-memory metric metadata of each tenant is uploaded to the long-term storage, where it's merged by the compactor and used by the querier as fallback for the metadata not held by the ingesters. A snapshot is uploaded on shutdown too. 0 to disable.")
 
 	f.DurationVar(&cfg.RateUpdatePeriod, "ingester.rate-update-period", 15*time.Second, "Period with which to update the per-tenant ingestion rates.")
 	f.BoolVar(&cfg.ActiveSeriesMetricsEnabled, "ingester.active-series-metrics-enabled", true, "Enable tracking of active series and export them as metrics.")
 	f.DurationVar(&cfg.ActiveSeriesMetricsUpdatePeriod, "ingester.active-series-metrics-update-period", 1*time.Minute, "How often to update active series metrics.")
 	f.DurationVar(&cfg.ActiveSeriesMetricsIdleTimeout, "ingester.active-series-metrics-idle-timeout", 10*time.Minute, "After what time a series is considered to be inactive.")
 
+	f.BoolVar(&cfg.SeriesChurnTrackingEnabled, "ingester.series-churn-tracking-enabled", false, "Enable tracking of the number of series created and removed per tenant and metric name, over the last hour.")
+	f.IntVar(&cfg.SeriesChurnMetricsTopN, "ingester.series-churn-metrics-top-n", 0, "Number of metric names with the highest series churn over the last hour, per tenant, exposed as metrics. Requires -ingester.series-churn-tracking-enabled. 0 to disable.")
+
 	f.BoolVar(&cfg.StreamChunksWhenUsingBlocks, "ingester.stream-chunks-when-using-blocks", true, "Stream chunks from ingesters to queriers.")
 	f.DurationVar(&cfg.ExemplarsUpdatePeriod, "ingester.exemplars-update-period", 15*time.Second, "Period with which to update per-tenant max exemplar limit.")
 
@@ -164,6 +204,12 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	f.Int64Var(&cfg.DefaultLimits.MaxInflightPushRequests, "ingester.instance-limits.max-inflight-push-requests", 30000, "Max inflight push requests that this ingester can handle (across all tenants). Additional requests will be rejected. 0 = unlimited.")
 
 	f.StringVar(&cfg.IgnoreSeriesLimitForMetricNames, "ingester.ignore-series-limit-for-metric-names", "", "Comma-separated list of metric names, for which the -ingester.max-global-series-per-metric limit will be ignored. Does not affect the -ingester.max-global-series-per-user limit.")
+
+	cfg.PushCircuitBreaker.RegisterFlags(f)
+}
+
+func (cfg *Config) Validate() error {
+	return cfg.PushCircuitBreaker.Validate()
 }
 
 func (cfg *Config) getIgnoreSeriesLimitForMetricNamesMap() map[string]struct{} {
@@ -235,6 +281,16 @@ type Ingester struct {
 	// Rate of pushed samples. Used to limit global samples push rate.
 	ingestionRate        *util_math.EwmaRate
 	inflightPushRequests atomic.Int64
+
+	// Preparation for downscale. Pushes are rejected while readOnly is true.
+	downscale downscaleState
+	readOnly  atomic.Bool
+
+	// Sheds push requests under pressure. Nil if disabled.
+	pushCircuitBreaker *pushCircuitBreaker
+
+	// Progress of opening the existing TSDBs on startup.
+	startupProgress *startupProgress
 }
 
 func newIngester(cfg Config, limits *validation.Overrides, registerer prometheus.Registerer, logger log.Logger) (*Ingester, error) {
@@ -259,6 +315,7 @@ func newIngester(cfg Config, limits *validation.Overrides, registerer prometheus
 		forceCompactTrigger: make(chan requestWithUsersAndCallback),
 		shipTrigger:         make(chan requestWithUsersAndCallback),
 		seriesHashCache:     hashcache.NewSeriesHashCache(cfg.BlocksStorageConfig.TSDB.SeriesHashCacheMaxBytes),
+		startupProgress:     newStartupProgress(registerer),
 	}, nil
 }
 
@@ -276,7 +333,11 @@ func New(cfg Config, clientConfig client.Config, limits *validation.Overrides, r
 	}
 	i.clientConfig = clientConfig
 	i.ingestionRate = util_math.NewEWMARate(0.2, instanceIngestionRateTickInterval)
-	i.metrics = newIngesterMetrics(registerer, cfg.ActiveSeriesMetricsEnabled, i.getInstanceLimits, i.ingestionRate, &i.inflightPushRequests)
+	i.metrics = newIngesterMetrics(registerer, cfg.ActiveSeriesMetricsEnabled, cfg.SeriesChurnTrackingEnabled && cfg.SeriesChurnMetricsTopN > 0, i.getInstanceLimits, i.ingestionRate, &i.inflightPushRequests)
+
+	if cfg.PushCircuitBreaker.Enabled {
+		i.pushCircuitBreaker = newPushCircuitBreaker(cfg.PushCircuitBreaker, logger, registerer)
+	}
 
 	// Replace specific metrics which we can't directly track but we need to read
 	// them from the underlying system (ie. TSDB).
@@ -324,7 +385,7 @@ func NewForFlusher(cfg Config, limits *validation.Overrides, registerer promethe
 	if err != nil {
 		return nil, err
 	}
-	i.metrics = newIngesterMetrics(registerer, false, i.getInstanceLimits, nil, &i.inflightPushRequests)
+	i.metrics = newIngesterMetrics(registerer, false, false, i.getInstanceLimits, nil, &i.inflightPushRequests)
 
 	i.shipperIngesterID = "flusher"
 
@@ -382,6 +443,11 @@ func (i *Ingester) starting(ctx context.Context) error {
 		servs = append(servs, closeIdleService)
 	}
 
+	if i.cfg.MetadataUploadInterval > 0 {
+		metadataUploadService := services.NewTimerService(i.cfg.MetadataUploadInterval, nil, i.uploadUsersMetricsMetadata, i.stoppingMetadataUpload)
+		servs = append(servs, metadataUploadService)
+	}
+
 	var err error
 	i.subservices, err = services.NewManager(servs...)
 	if err == nil {
@@ -434,6 +500,20 @@ func (i *Ingester) updateLoop(ctx context.Context) error {
 		defer t.Stop()
 	}
 
+	var seriesChurnTickerChan <-chan time.Time
+	if i.cfg.SeriesChurnTrackingEnabled {
+		t := time.NewTicker(seriesChurnUpdatePeriod)
+		seriesChurnTickerChan = t.C
+		defer t.Stop()
+	}
+
+	var pushCircuitBreakerTickerChan <-chan time.Time
+	if i.pushCircuitBreaker != nil {
+		t := time.NewTicker(pushCircuitBreakerEvaluationPeriod)
+		pushCircuitBreakerTickerChan = t.C
+		defer t.Stop()
+	}
+
 	// Similarly to the above, this is a hardcoded value.
 	metadataPurgeTicker := time.NewTicker(metadataPurgePeriod)
 	defer metadataPurgeTicker.Stop()
@@ -458,6 +538,12 @@ func (i *Ingester) updateLoop(ctx context.Context) error {
 		case <-activeSeriesTickerChan:
 			i.updateActiveSeries(time.Now())
 
+		case <-seriesChurnTickerChan:
+			i.updateSeriesChurn(time.Now())
+
+		case <-pushCircuitBreakerTickerChan:
+			i.pushCircuitBreaker.evaluate(time.Now())
+
 		case <-ctx.Done():
 			return nil
 		case err := <-i.subservicesWatcher.Chan():
@@ -494,6 +580,9 @@ func (i *Ingester) updateActiveSeries(now time.Time) {
 				i.metrics.activeSeriesPerUser.DeleteLabelValues(userID)
 			}
 
+			// Each series is active in as many ingesters as the replication factor.
+			i.cfg.UsageRecorder.AddActiveSeries(userID, float64(allActive)/float64(i.cfg.IngesterRing.ReplicationFactor), i.cfg.ActiveSeriesMetricsUpdatePeriod)
+
 			for idx, name := range userDB.activeSeries.CurrentMatcherNames() {
 				// We only set the metrics for matchers that actually exist, to avoid increasing cardinality with zero valued metrics.
 				if activeMatching[idx] > 0 {
@@ -506,6 +595,48 @@ func (i *Ingester) updateActiveSeries(now time.Time) {
 	}
 }
 
+// updateSeriesChurn purges the stale series churn of each tenant and, if enabled, updates the
+// series churn metrics of the metric names with the highest churn.
+func (i *Ingester) updateSeriesChurn(now time.Time) {
+	for _, userID := range i.getTSDBUsers() {
+		userDB := i.getTSDB(userID)
+		if userDB == nil || userDB.seriesChurn == nil {
+			continue
+		}
+
+		userDB.seriesChurn.purge(now)
+		if i.cfg.SeriesChurnMetricsTopN <= 0 {
+			continue
+		}
+
+		top := userDB.seriesChurn.top(now, i.cfg.SeriesChurnMetricsTopN)
+		names := make([]string, 0, len(top))
+		for _, item := range top {
+			i.metrics.seriesChurnCreated.WithLabelValues(userID, item.MetricName).Set(float64(item.CreatedSeries))
+			i.metrics.seriesChurnRemoved.WithLabelValues(userID, item.MetricName).Set(float64(item.RemovedSeries))
+			names = append(names, item.MetricName)
+		}
+
+		// Remove the metrics of the metric names which are not in the top anymore.
+		current := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			current[name] = struct{}{}
+		}
+		for _, name := range userDB.seriesChurn.swapExposedMetricNames(names) {
+			if _, ok := current[name]; !ok {
+				i.metrics.deletePerUserSeriesChurnMetrics(userID, []string{name})
+			}
+		}
+	}
+}
+
+func (i *Ingester) deletePerUserSeriesChurnMetrics(userDB *userTSDB) {
+	if userDB.seriesChurn == nil {
+		return
+	}
+	i.metrics.deletePerUserSeriesChurnMetrics(userDB.userID, userDB.seriesChurn.swapExposedMetricNames(nil))
+}
+
 // Go through all tenants and apply the current max-exemplars setting.
 // If it changed, tsdb will resize the buffer; if it didn't change tsdb will return quickly.
 func (i *Ingester) applyExemplarsSettings() {
@@ -551,6 +682,18 @@ func (i *Ingester) PushWithCleanup(ctx context.Context, req *mimirpb.WriteReques
 		return nil, err
 	}
 
+	if i.readOnly.Load() {
+		return nil, errIngesterReadOnly
+	}
+
+	var pushStart time.Time
+	if i.pushCircuitBreaker != nil {
+		if err := i.pushCircuitBreaker.allow(); err != nil {
+			return nil, err
+		}
+		pushStart = time.Now()
+	}
+
 	// We will report *this* request in the error too.
 	inflight := i.inflightPushRequests.Inc()
 	defer i.inflightPushRequests.Dec()
@@ -595,6 +738,14 @@ func (i *Ingester) PushWithCleanup(ctx context.Context, req *mimirpb.WriteReques
 	}
 	defer db.releaseAppendLock()
 
+	// The push circuit breaker only observes the latency of the appends, because the requests quickly
+	// rejected before appending (eg. by the instance limits) would lower the observed latency percentile.
+	if i.pushCircuitBreaker != nil {
+		defer func() {
+			i.pushCircuitBreaker.observe(time.Since(pushStart))
+		}()
+	}
+
 	span := opentracing.SpanFromContext(ctx)
 	if span != nil {
 		span.LogFields(otlog.String("event", "acquired append lock"))
@@ -1123,6 +1274,57 @@ func (i *Ingester) LabelValuesCardinality(req *client.LabelValuesCardinalityRequ
 	)
 }
 
+// activeSeriesTargetSizeBytes is the maximum allowed size in bytes for active series response.
+// We arbitrarily set it to 1mb to avoid reaching the actual gRPC default limit (4mb).
+const activeSeriesTargetSizeBytes = 1 * 1024 * 1024
+
+// ActiveSeries implements the client.IngesterServer interface.
+func (i *Ingester) ActiveSeries(req *client.ActiveSeriesRequest, srv client.Ingester_ActiveSeriesServer) error {
+	if err := i.checkRunning(); err != nil {
+		return err
+	}
+	if !i.cfg.ActiveSeriesMetricsEnabled {
+		return errActiveSeriesTrackingDisabled
+	}
+	userID, err := tenant.TenantID(srv.Context())
+	if err != nil {
+		return err
+	}
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return nil
+	}
+
+	matchers, err := client.FromLabelMatchers(req.GetMatchers())
+	if err != nil {
+		return err
+	}
+	return activeSeries(db.activeSeries, time.Now(), matchers, activeSeriesTargetSizeBytes, srv)
+}
+
+// SeriesChurn returns the number of series created and removed for each metric name over the requested window.
+func (i *Ingester) SeriesChurn(ctx context.Context, req *client.SeriesChurnRequest) (*client.SeriesChurnResponse, error) {
+	if err := i.checkRunning(); err != nil {
+		return nil, err
+	}
+	if !i.cfg.SeriesChurnTrackingEnabled {
+		return nil, errSeriesChurnTrackingDisabled
+	}
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := i.getTSDB(userID)
+	if db == nil || db.seriesChurn == nil {
+		return &client.SeriesChurnResponse{}, nil
+	}
+
+	window := time.Duration(req.WindowMs) * time.Millisecond
+	return &client.SeriesChurnResponse{Items: db.seriesChurn.churn(time.Now(), window)}, nil
+}
+
 func createUserStats(db *userTSDB) *client.UserStatsResponse {
 	apiRate := db.ingestedAPISamples.Rate()
 	ruleRate := db.ingestedRuleSamples.Rate()
@@ -1169,8 +1371,8 @@ func (i *Ingester) QueryStream(req *client.QueryRequest, stream client.Ingester_
 		return nil
 	}
 
-	numSamples := 0
-	numSeries := 0
+	start := time.Now()
+	stats := &client.QueryStreamStats{}
 
 	streamType := QueryStreamSamples
 	if i.cfg.StreamChunksWhenUsingBlocks {
@@ -1191,25 +1393,29 @@ func (i *Ingester) QueryStream(req *client.QueryRequest, stream client.Ingester_
 
 	if streamType == QueryStreamChunks {
 		level.Debug(spanlog).Log("msg", "using queryStreamChunks")
-		numSeries, numSamples, err = i.queryStreamChunks(ctx, db, int64(from), int64(through), matchers, shard, stream)
+		err = i.queryStreamChunks(ctx, db, int64(from), int64(through), matchers, shard, stream, stats)
 	} else {
 		level.Debug(spanlog).Log("msg", "using queryStreamSamples")
-		numSeries, numSamples, err = i.queryStreamSamples(ctx, db, int64(from), int64(through), matchers, shard, stream)
+		err = i.queryStreamSamples(ctx, db, int64(from), int64(through), matchers, shard, stream, stats)
 	}
 	if err != nil {
 		return err
 	}
 
-	i.metrics.queriedSeries.Observe(float64(numSeries))
-	i.metrics.queriedSamples.Observe(float64(numSamples))
-	level.Debug(spanlog).Log("series", numSeries, "samples", numSamples)
-	return nil
+	i.metrics.queriedSeries.Observe(float64(stats.SeriesCount))
+	i.metrics.queriedSamples.Observe(float64(stats.SamplesCount))
+	level.Debug(spanlog).Log("series", stats.SeriesCount, "samples", stats.SamplesCount, "chunks", stats.ChunksCount, "select_time", stats.SelectTime)
+
+	// Send the query statistics as the last message of the stream, so that the querier can track them.
+	stats.WallTime = time.Since(start)
+	return client.SendQueryStream(stream, &client.QueryStreamResponse{Stats: stats})
 }
 
-func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, through int64, matchers []*labels.Matcher, shard *sharding.ShardSelector, stream client.Ingester_QueryStreamServer) (numSeries, numSamples int, _ error) {
+// queryStreamSamples streams the samples of the series matching the input matchers, tracking the work done in the input stats.
+func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, through int64, matchers []*labels.Matcher, shard *sharding.ShardSelector, stream client.Ingester_QueryStreamServer, stats *client.QueryStreamStats) error {
 	q, err := db.Querier(ctx, from, through)
 	if err != nil {
-		return 0, 0, err
+		return err
 	}
 	defer q.Close()
 
@@ -1219,9 +1425,12 @@ func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, t
 	}
 
 	// It's not required to return sorted series because series are sorted by the Mimir querier.
+	// Selecting the series looks up the postings matching the matchers, while the chunks are read when iterating the series.
+	selectStart := time.Now()
 	ss := q.Select(false, hints, matchers...)
+	stats.SelectTime = time.Since(selectStart)
 	if ss.Err() != nil {
-		return 0, 0, ss.Err()
+		return ss.Err()
 	}
 
 	timeseries := make([]mimirpb.TimeSeries, 0, queryStreamBatchSize)
@@ -1239,8 +1448,8 @@ func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, t
 			t, v := it.At()
 			ts.Samples = append(ts.Samples, mimirpb.Sample{Value: v, TimestampMs: t})
 		}
-		numSamples += len(ts.Samples)
-		numSeries++
+		stats.SamplesCount += uint64(len(ts.Samples))
+		stats.SeriesCount++
 		tsSize := ts.Size()
 
 		if (batchSizeBytes > 0 && batchSizeBytes+tsSize > queryStreamBatchMessageSize) || len(timeseries) >= queryStreamBatchSize {
@@ -1250,7 +1459,7 @@ func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, t
 				Timeseries: timeseries,
 			})
 			if err != nil {
-				return 0, 0, err
+				return err
 			}
 
 			batchSizeBytes = 0
@@ -1263,7 +1472,7 @@ func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, t
 
 	// Ensure no error occurred while iterating the series set.
 	if err := ss.Err(); err != nil {
-		return 0, 0, err
+		return err
 	}
 
 	// Final flush any existing metrics
@@ -1272,21 +1481,26 @@ func (i *Ingester) queryStreamSamples(ctx context.Context, db *userTSDB, from, t
 			Timeseries: timeseries,
 		})
 		if err != nil {
-			return 0, 0, err
+			return err
 		}
 	}
 
-	return numSeries, numSamples, nil
+	return nil
 }
 
-// queryStreamChunks streams metrics from a TSDB. This implements the client.IngesterServer interface
-func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, through int64, matchers []*labels.Matcher, shard *sharding.ShardSelector, stream client.Ingester_QueryStreamServer) (numSeries, numSamples int, _ error) {
+// queryStreamChunks streams metrics from a TSDB. This implements the client.IngesterServer interface.
+// The work done is tracked in the input stats.
+func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, through int64, matchers []*labels.Matcher, shard *sharding.ShardSelector, stream client.Ingester_QueryStreamServer, stats *client.QueryStreamStats) error {
 	q, err := db.ChunkQuerier(ctx, from, through)
 	if err != nil {
-		return 0, 0, err
+		return err
 	}
 	defer q.Close()
 
+	// The blocks on disk don't overlap with the head, so the chunks starting
+	// at or after the head min time are considered to be read from the head.
+	headMinTime := db.Head().MinTime()
+
 	// Disable chunks trimming, so that we don't have to rewrite chunks which have samples outside
 	// the requested from/through range. PromQL engine can handle it.
 	hints := initSelectHints(from, through)
@@ -1294,9 +1508,12 @@ func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, th
 	hints = configSelectHintsWithDisabledTrimming(hints)
 
 	// It's not required to return sorted series because series are sorted by the Mimir querier.
+	// Selecting the series looks up the postings matching the matchers, while the chunks are read when iterating the series.
+	selectStart := time.Now()
 	ss := q.Select(false, hints, matchers...)
+	stats.SelectTime = time.Since(selectStart)
 	if ss.Err() != nil {
-		return 0, 0, ss.Err()
+		return ss.Err()
 	}
 
 	chunkSeries := make([]client.TimeSeriesChunk, 0, queryStreamBatchSize)
@@ -1317,7 +1534,7 @@ func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, th
 			// It is not guaranteed that chunk returned by iterator is populated.
 			// For now just return error. We could also try to figure out how to read the chunk.
 			if meta.Chunk == nil {
-				return 0, 0, errors.Errorf("unfilled chunk returned from TSDB chunk querier")
+				return errors.Errorf("unfilled chunk returned from TSDB chunk querier")
 			}
 
 			ch := client.Chunk{
@@ -1330,13 +1547,20 @@ func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, th
 			case chunkenc.EncXOR:
 				ch.Encoding = int32(chunk.PrometheusXorChunk)
 			default:
-				return 0, 0, errors.Errorf("unknown chunk encoding from TSDB chunk querier: %v", meta.Chunk.Encoding())
+				return errors.Errorf("unknown chunk encoding from TSDB chunk querier: %v", meta.Chunk.Encoding())
 			}
 
 			ts.Chunks = append(ts.Chunks, ch)
-			numSamples += meta.Chunk.NumSamples()
+			stats.SamplesCount += uint64(meta.Chunk.NumSamples())
+			stats.ChunksCount++
+			stats.ChunkBytes += uint64(len(ch.Data))
+			if meta.MinTime >= headMinTime {
+				stats.HeadChunksCount++
+			} else {
+				stats.BlockChunksCount++
+			}
 		}
-		numSeries++
+		stats.SeriesCount++
 		tsSize := ts.Size()
 
 		if (batchSizeBytes > 0 && batchSizeBytes+tsSize > queryStreamBatchMessageSize) || len(chunkSeries) >= queryStreamBatchSize {
@@ -1346,7 +1570,7 @@ func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, th
 				Chunkseries: chunkSeries,
 			})
 			if err != nil {
-				return 0, 0, err
+				return err
 			}
 
 			batchSizeBytes = 0
@@ -1359,7 +1583,7 @@ func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, th
 
 	// Ensure no error occurred while iterating the series set.
 	if err := ss.Err(); err != nil {
-		return 0, 0, err
+		return err
 	}
 
 	// Final flush any existing metrics
@@ -1368,11 +1592,11 @@ func (i *Ingester) queryStreamChunks(ctx context.Context, db *userTSDB, from, th
 			Chunkseries: chunkSeries,
 		})
 		if err != nil {
-			return 0, 0, err
+			return err
 		}
 	}
 
-	return numSeries, numSamples, nil
+	return nil
 }
 
 func (i *Ingester) getTSDB(userID string) *userTSDB {
@@ -1430,7 +1654,7 @@ func (i *Ingester) getOrCreateTSDB(userID string, force bool) (*userTSDB, error)
 	}
 
 	// Create the database and a shipper for a user
-	db, err := i.createTSDB(userID)
+	db, err := i.createTSDB(userID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1443,7 +1667,8 @@ func (i *Ingester) getOrCreateTSDB(userID string, force bool) (*userTSDB, error)
 }
 
 // createTSDB creates a TSDB for a given userID, and returns the created db.
-func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
+// If startupProgress is not nil, the progress of opening the TSDB on startup is tracked.
+func (i *Ingester) createTSDB(userID string, startupProgress *tenantStartupProgress) (*userTSDB, error) {
 	tsdbPromReg := prometheus.NewRegistry()
 	udir := i.cfg.BlocksStorageConfig.TSDB.BlocksDir(userID)
 	userLogger := util_log.WithUserID(userID, i.logger)
@@ -1460,11 +1685,20 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 
 		instanceLimitsFn:    i.getInstanceLimits,
 		instanceSeriesCount: &i.seriesCount,
+
+		tsdbMetricsGatherer: tsdbPromReg,
+	}
+
+	// The TSDB only reports the WAL replay progress in its logs.
+	tsdbLogger := userLogger
+	if startupProgress != nil {
+		tsdbLogger = walReplayProgressLogger{Logger: userLogger, progress: startupProgress}
+		userDB.startupProgress = startupProgress
 	}
 
 	maxExemplars := i.limiter.convertGlobalToLocalLimit(userID, i.limits.MaxGlobalExemplarsPerUser(userID))
 	// Create a new user database
-	db, err := tsdb.Open(udir, userLogger, tsdbPromReg, &tsdb.Options{
+	db, err := tsdb.Open(udir, tsdbLogger, tsdbPromReg, &tsdb.Options{
 		RetentionDuration:              i.cfg.BlocksStorageConfig.TSDB.Retention.Milliseconds(),
 		MinBlockDuration:               blockRanges[0],
 		MaxBlockDuration:               blockRanges[len(blockRanges)-1],
@@ -1488,6 +1722,12 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 	}
 	db.DisableCompactions() // we will compact on our own schedule
 
+	// Series created from now on are not loaded from the WAL.
+	userDB.startupProgress = nil
+	if startupProgress != nil {
+		startupProgress.setPhase(tsdbStartupCompacting, time.Now())
+	}
+
 	// Run compaction before using this TSDB. If there is data in head that needs to be put into blocks,
 	// this will actually create the blocks. If there is no data (empty TSDB), this is a no-op, although
 	// local blocks compaction may still take place if configured.
@@ -1501,6 +1741,11 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 	// We set the limiter here because we don't want to limit
 	// series during WAL replay.
 	userDB.limiter = i.limiter
+	// Similarly, the series churn tracker is set after the WAL replay because
+	// replayed series shouldn't be tracked as created.
+	if i.cfg.SeriesChurnTrackingEnabled {
+		userDB.seriesChurn = newSeriesChurnTracker()
+	}
 
 	if db.Head().NumSeries() > 0 {
 		// If there are series in the head, use max time from head. If this time is too old,
@@ -1576,6 +1821,7 @@ func (i *Ingester) closeAllTSDB() {
 
 			i.metrics.memUsers.Dec()
 			i.metrics.deletePerUserCustomTrackerMetrics(userID, db.activeSeries.CurrentMatcherNames())
+			i.deletePerUserSeriesChurnMetrics(db)
 		}(userDB)
 	}
 
@@ -1589,6 +1835,11 @@ func (i *Ingester) closeAllTSDB() {
 func (i *Ingester) openExistingTSDB(ctx context.Context) error {
 	level.Info(i.logger).Log("msg", "opening existing TSDBs")
 
+	i.startupProgress.start(time.Now())
+	defer func() {
+		i.startupProgress.finish(time.Now())
+	}()
+
 	queue := make(chan string)
 	group, groupCtx := errgroup.WithContext(ctx)
 
@@ -1597,12 +1848,15 @@ func (i *Ingester) openExistingTSDB(ctx context.Context) error {
 		group.Go(func() error {
 			for userID := range queue {
 				startTime := time.Now()
+				progress := i.startupProgress.getTenant(userID)
 
-				db, err := i.createTSDB(userID)
+				db, err := i.createTSDB(userID, progress)
 				if err != nil {
+					progress.setPhase(tsdbStartupFailed, time.Now())
 					level.Error(i.logger).Log("msg", "unable to open TSDB", "err", err, "user", userID)
 					return errors.Wrapf(err, "unable to open TSDB for user %s", userID)
 				}
+				progress.setPhase(tsdbStartupDone, time.Now())
 
 				// Add the database to the map of user databases
 				i.tsdbsMtx.Lock()
@@ -1658,6 +1912,7 @@ func (i *Ingester) openExistingTSDB(ctx context.Context) error {
 			}
 
 			// Enqueue the user to be processed.
+			i.startupProgress.addTenant(userID, path)
 			select {
 			case queue <- userID:
 				// Nothing to do.
@@ -1794,6 +2049,13 @@ func (i *Ingester) shipBlocks(ctx context.Context, allowed *util.AllowedTenants)
 		}
 		defer userDB.casState(activeShipping, active)
 
+		// Blocks are not shipped until their exemplars have been uploaded, otherwise the exemplars
+		// would be missing from the long-term storage.
+		if err := i.uploadBlocksExemplars(ctx, userID, userDB); err != nil {
+			level.Warn(i.logger).Log("msg", "failed to upload the exemplars of the TSDB blocks to ship, skipping shipping", "user", userID, "err", err)
+			return nil
+		}
+
 		uploaded, err := userDB.shipper.Sync(ctx)
 		if err != nil {
 			level.Warn(i.logger).Log("msg", "shipper failed to synchronize TSDB blocks with the storage", "user", userID, "uploaded", uploaded, "err", err)
@@ -1816,15 +2078,119 @@ func (i *Ingester) shipBlocks(ctx context.Context, allowed *util.AllowedTenants)
 	})
 }
 
+// uploadBlocksExemplars uploads the exemplars of the local blocks which haven't been shipped yet, if the
+// tenant has exemplars retention in the long-term storage enabled. The exemplars persisted in the block
+// directory at head compaction are uploaded, falling back to the in-memory exemplars if not found.
+func (i *Ingester) uploadBlocksExemplars(ctx context.Context, userID string, userDB *userTSDB) error {
+	if i.limits.ExemplarsRetentionPeriod(userID) <= 0 {
+		return nil
+	}
+
+	var (
+		shippedBlocks = userDB.getCachedShippedBlocks()
+		userBucket    = bucket.NewUserBucketClient(userID, i.bucket, i.limits)
+	)
+
+	for _, b := range userDB.Blocks() {
+		meta := b.Meta()
+		if _, ok := shippedBlocks[meta.ULID]; ok {
+			continue
+		}
+
+		exemplars, err := mimir_tsdb.ReadBlockExemplarsFromDir(b.Dir(), i.logger)
+		if errors.Is(err, mimir_tsdb.ErrBlockExemplarsNotFound) {
+			exemplars, err = queryBlockExemplars(ctx, userDB, meta)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(exemplars.Series) == 0 {
+			continue
+		}
+
+		if err := mimir_tsdb.WriteBlockExemplars(ctx, userBucket, meta.ULID, exemplars); err != nil {
+			return err
+		}
+		level.Debug(i.logger).Log("msg", "uploaded block exemplars", "user", userID, "block", meta.ULID, "exemplars", exemplars.NumExemplars())
+	}
+
+	return nil
+}
+
+// persistBlocksExemplars stores the in-memory exemplars of the local blocks which haven't been shipped yet
+// in the block directory, if the tenant has exemplars retention in the long-term storage enabled. It's run
+// right after the head compaction, so that the exemplars of a block are not lost if they're evicted from the
+// in-memory exemplars storage, or the ingester restarts, before the block is shipped. Exemplars evicted from
+// the in-memory exemplars storage before the head compaction are still lost.
+func (i *Ingester) persistBlocksExemplars(ctx context.Context, userID string, userDB *userTSDB) error {
+	if userDB.shipper == nil || i.limits.ExemplarsRetentionPeriod(userID) <= 0 {
+		return nil
+	}
+
+	shippedBlocks := userDB.getCachedShippedBlocks()
+
+	for _, b := range userDB.Blocks() {
+		meta := b.Meta()
+		if _, ok := shippedBlocks[meta.ULID]; ok {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(b.Dir(), mimir_tsdb.BlockExemplarsFilename)); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		exemplars, err := queryBlockExemplars(ctx, userDB, meta)
+		if err != nil {
+			return err
+		}
+
+		// The exemplars are stored even if empty, so that the block is not queried again.
+		if err := mimir_tsdb.WriteBlockExemplarsToDir(b.Dir(), exemplars); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryBlockExemplars returns the in-memory exemplars within the time range of the block.
+func queryBlockExemplars(ctx context.Context, userDB *userTSDB, meta tsdb.BlockMeta) (*mimir_tsdb.BlockExemplars, error) {
+	q, err := userDB.ExemplarQuerier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The block max time is exclusive, while the exemplars query end time is inclusive.
+	res, err := q.Select(meta.MinTime, meta.MaxTime-1, []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+")})
+	if err != nil {
+		return nil, errors.Wrapf(err, "query exemplars of block %s", meta.ULID)
+	}
+
+	return mimir_tsdb.NewBlockExemplars(res), nil
+}
+
 func (i *Ingester) compactionLoop(ctx context.Context) error {
 	ticker := time.NewTicker(i.cfg.BlocksStorageConfig.TSDB.HeadCompactionInterval)
 	defer ticker.Stop()
 
+	var earlyCompactionTickerChan <-chan time.Time
+	if i.cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionEnabled {
+		t := time.NewTicker(earlyHeadCompactionCheckPeriod)
+		earlyCompactionTickerChan = t.C
+		defer t.Stop()
+	}
+
 	for ctx.Err() == nil {
 		select {
 		case <-ticker.C:
 			i.compactBlocks(ctx, false, nil)
 
+		case <-earlyCompactionTickerChan:
+			i.compactBlocksUnderMemoryPressure(ctx, time.Now())
+
 		case req := <-i.forceCompactTrigger:
 			i.compactBlocks(ctx, true, req.users)
 			close(req.callback) // Notify back.
@@ -1890,10 +2256,97 @@ func (i *Ingester) compactBlocks(ctx context.Context, force bool, allowed *util.
 			level.Debug(i.logger).Log("msg", "TSDB blocks compaction completed successfully", "user", userID, "compactReason", reason)
 		}
 
+		if err := i.persistBlocksExemplars(ctx, userID, userDB); err != nil {
+			level.Warn(i.logger).Log("msg", "failed to persist the exemplars of the TSDB blocks", "user", userID, "err", err)
+		}
+
 		return nil
 	})
 }
 
+// compactBlocksUnderMemoryPressure compacts the head of the tenants with the most in-memory series, one tenant
+// at a time, while the number of in-memory series in the ingester exceeds the early head compaction threshold.
+// The most recent samples, within the configured min in-memory duration, are kept in the head, while the series
+// which have no samples in that time range are released from memory. A tenant is skipped if it has been early
+// compacted within the last earlyHeadCompactionMinInterval (backed off while its early compactions don't release
+// any series), or if its head holds less than earlyHeadCompactionMinBlockSpan of samples to compact.
+func (i *Ingester) compactBlocksUnderMemoryPressure(ctx context.Context, now time.Time) {
+	threshold := i.getEarlyHeadCompactionSeriesThreshold()
+	if threshold <= 0 || i.seriesCount.Load() <= threshold {
+		return
+	}
+
+	// Don't compact TSDB blocks while JOINING as there may be ongoing blocks transfers.
+	if i.lifecycler != nil && i.lifecycler.GetState() == ring.JOINING {
+		return
+	}
+
+	type tenantSeries struct {
+		userID    string
+		db        *userTSDB
+		numSeries uint64
+	}
+
+	var tenants []tenantSeries
+	for _, userID := range i.getTSDBUsers() {
+		if db := i.getTSDB(userID); db != nil {
+			tenants = append(tenants, tenantSeries{userID: userID, db: db, numSeries: db.Head().NumSeries()})
+		}
+	}
+
+	// Compact the tenants with the most in-memory series first.
+	sort.Slice(tenants, func(a, b int) bool {
+		return tenants[a].numSeries > tenants[b].numSeries
+	})
+
+	level.Info(i.logger).Log("msg", "number of in-memory series exceeds the early head compaction threshold, compacting TSDB head of the largest tenants", "series", i.seriesCount.Load(), "threshold", threshold)
+
+	upToTime := now.Add(-i.cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionMinInMemoryDuration).UnixMilli()
+	for _, tenant := range tenants {
+		if ctx.Err() != nil || i.seriesCount.Load() <= threshold {
+			return
+		}
+
+		if now.Before(tenant.db.earlyCompactionNextAllowed) {
+			level.Debug(i.logger).Log("msg", "skipping early TSDB head compaction because the tenant has been early compacted recently", "user", tenant.userID, "nextAllowed", tenant.db.earlyCompactionNextAllowed)
+			continue
+		}
+		if tenant.db.Head().MinTime() > upToTime-earlyHeadCompactionMinBlockSpan.Milliseconds() {
+			level.Debug(i.logger).Log("msg", "skipping early TSDB head compaction because the tenant head holds too few samples to compact", "user", tenant.userID)
+			continue
+		}
+
+		i.metrics.compactionsTriggered.Inc()
+		if err := tenant.db.compactHeadUpTo(i.cfg.BlocksStorageConfig.TSDB.BlockRanges[0].Milliseconds(), upToTime); err != nil {
+			i.metrics.compactionsFailed.Inc()
+			tenant.db.updateEarlyCompactionBackoff(now, false)
+			level.Warn(i.logger).Log("msg", "TSDB blocks compaction for user has failed", "user", tenant.userID, "err", err, "compactReason", "early")
+			continue
+		}
+
+		if err := i.persistBlocksExemplars(ctx, tenant.userID, tenant.db); err != nil {
+			level.Warn(i.logger).Log("msg", "failed to persist the exemplars of the TSDB blocks", "user", tenant.userID, "err", err)
+		}
+
+		seriesAfter := tenant.db.Head().NumSeries()
+		tenant.db.updateEarlyCompactionBackoff(now, seriesAfter < tenant.numSeries)
+
+		level.Info(i.logger).Log("msg", "TSDB blocks compaction completed successfully", "user", tenant.userID, "compactReason", "early", "seriesBefore", tenant.numSeries, "seriesAfter", seriesAfter, "nextAllowed", tenant.db.earlyCompactionNextAllowed)
+	}
+}
+
+// getEarlyHeadCompactionSeriesThreshold returns the number of in-memory series above which the early head
+// compaction is triggered, or 0 if the threshold is not configured and there's no max series instance limit.
+func (i *Ingester) getEarlyHeadCompactionSeriesThreshold() int64 {
+	if threshold := i.cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionSeriesThreshold; threshold > 0 {
+		return threshold
+	}
+	if il := i.getInstanceLimits(); il != nil && il.MaxInMemorySeries > 0 {
+		return int64(float64(il.MaxInMemorySeries) * earlyHeadCompactionMaxSeriesRatio)
+	}
+	return 0
+}
+
 func (i *Ingester) closeAndDeleteIdleUserTSDBs(ctx context.Context) error {
 	for _, userID := range i.getTSDBUsers() {
 		if ctx.Err() != nil {
@@ -1973,6 +2426,7 @@ func (i *Ingester) closeAndDeleteUserTSDBIfIdle(userID string) tsdbCloseCheckRes
 	i.deleteUserMetadata(userID)
 	i.metrics.deletePerUserMetrics(userID)
 	i.metrics.deletePerUserCustomTrackerMetrics(userID, userDB.activeSeries.CurrentMatcherNames())
+	i.deletePerUserSeriesChurnMetrics(userDB)
 
 	validation.DeletePerUserValidationMetrics(userID, i.logger)
 
@@ -2033,66 +2487,73 @@ func (i *Ingester) FlushHandler(w http.ResponseWriter, r *http.Request) {
 
 	allowedUsers := util.NewAllowedTenants(tenants, nil)
 	run := func() {
-		ingCtx := i.BasicService.ServiceContext()
-		if ingCtx == nil || ingCtx.Err() != nil {
-			level.Info(i.logger).Log("msg", "flushing TSDB blocks: ingester not running, ignoring flush request")
-			return
-		}
+		_ = i.flushBlocks(allowedUsers)
+	}
 
-		compactionCallbackCh := make(chan struct{})
+	if len(r.Form[waitParam]) > 0 && r.Form[waitParam][0] == "true" {
+		// Run synchronously. This simplifies and speeds up tests.
+		run()
+	} else {
+		go run()
+	}
 
-		level.Info(i.logger).Log("msg", "flushing TSDB blocks: triggering compaction")
-		select {
-		case i.forceCompactTrigger <- requestWithUsersAndCallback{users: allowedUsers, callback: compactionCallbackCh}:
-			// Compacting now.
-		case <-ingCtx.Done():
-			level.Warn(i.logger).Log("msg", "failed to compact TSDB blocks, ingester not running anymore")
-			return
-		}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-		// Wait until notified about compaction being finished.
-		select {
-		case <-compactionCallbackCh:
-			level.Info(i.logger).Log("msg", "finished compacting TSDB blocks")
-		case <-ingCtx.Done():
-			level.Warn(i.logger).Log("msg", "failed to compact TSDB blocks, ingester not running anymore")
-			return
-		}
+// flushBlocks forces the compaction of the TSDB head of the allowed users and, if enabled, ships
+// the blocks to the storage. It returns once the blocks have been shipped.
+func (i *Ingester) flushBlocks(allowedUsers *util.AllowedTenants) error {
+	ingCtx := i.BasicService.ServiceContext()
+	if ingCtx == nil || ingCtx.Err() != nil {
+		level.Info(i.logger).Log("msg", "flushing TSDB blocks: ingester not running, ignoring flush request")
+		return errIngesterNotRunning
+	}
 
-		if i.cfg.BlocksStorageConfig.TSDB.IsBlocksShippingEnabled() {
-			shippingCallbackCh := make(chan struct{}) // must be new channel, as compactionCallbackCh is closed now.
+	compactionCallbackCh := make(chan struct{})
 
-			level.Info(i.logger).Log("msg", "flushing TSDB blocks: triggering shipping")
+	level.Info(i.logger).Log("msg", "flushing TSDB blocks: triggering compaction")
+	select {
+	case i.forceCompactTrigger <- requestWithUsersAndCallback{users: allowedUsers, callback: compactionCallbackCh}:
+		// Compacting now.
+	case <-ingCtx.Done():
+		level.Warn(i.logger).Log("msg", "failed to compact TSDB blocks, ingester not running anymore")
+		return errIngesterNotRunning
+	}
 
-			select {
-			case i.shipTrigger <- requestWithUsersAndCallback{users: allowedUsers, callback: shippingCallbackCh}:
-				// shipping now
-			case <-ingCtx.Done():
-				level.Warn(i.logger).Log("msg", "failed to ship TSDB blocks, ingester not running anymore")
-				return
-			}
+	// Wait until notified about compaction being finished.
+	select {
+	case <-compactionCallbackCh:
+		level.Info(i.logger).Log("msg", "finished compacting TSDB blocks")
+	case <-ingCtx.Done():
+		level.Warn(i.logger).Log("msg", "failed to compact TSDB blocks, ingester not running anymore")
+		return errIngesterNotRunning
+	}
 
-			// Wait until shipping finished.
-			select {
-			case <-shippingCallbackCh:
-				level.Info(i.logger).Log("msg", "shipping of TSDB blocks finished")
-			case <-ingCtx.Done():
-				level.Warn(i.logger).Log("msg", "failed to ship TSDB blocks, ingester not running anymore")
-				return
-			}
-		}
+	if i.cfg.BlocksStorageConfig.TSDB.IsBlocksShippingEnabled() {
+		shippingCallbackCh := make(chan struct{}) // must be new channel, as compactionCallbackCh is closed now.
 
-		level.Info(i.logger).Log("msg", "flushing TSDB blocks: finished")
-	}
+		level.Info(i.logger).Log("msg", "flushing TSDB blocks: triggering shipping")
 
-	if len(r.Form[waitParam]) > 0 && r.Form[waitParam][0] == "true" {
-		// Run synchronously. This simplifies and speeds up tests.
-		run()
-	} else {
-		go run()
+		select {
+		case i.shipTrigger <- requestWithUsersAndCallback{users: allowedUsers, callback: shippingCallbackCh}:
+			// shipping now
+		case <-ingCtx.Done():
+			level.Warn(i.logger).Log("msg", "failed to ship TSDB blocks, ingester not running anymore")
+			return errIngesterNotRunning
+		}
+
+		// Wait until shipping finished.
+		select {
+		case <-shippingCallbackCh:
+			level.Info(i.logger).Log("msg", "shipping of TSDB blocks finished")
+		case <-ingCtx.Done():
+			level.Warn(i.logger).Log("msg", "failed to ship TSDB blocks, ingester not running anymore")
+			return errIngesterNotRunning
+		}
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	level.Info(i.logger).Log("msg", "flushing TSDB blocks: finished")
+	return nil
 }
 
 func wrappedTSDBIngestErr(ingestErr error, timestamp model.Time, labels []mimirpb.LabelAdapter) error {
@@ -2266,6 +2727,46 @@ func (i *Ingester) purgeUserMetricsMetadata() {
 	}
 }
 
+// uploadUsersMetricsMetadata uploads a snapshot of the in-memory metric metadata of each tenant to the
+// long-term storage. Errors are logged and never returned, because metadata is a best effort.
+func (i *Ingester) uploadUsersMetricsMetadata(ctx context.Context) error {
+	now := time.Now()
+
+	for _, userID := range i.getUsersWithMetadata() {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		userMetadata := i.getUserMetadata(userID)
+		if userMetadata == nil {
+			continue
+		}
+
+		snapshot := userMetadata.toMetricsMetadata()
+		if len(snapshot.Metadata) == 0 {
+			continue
+		}
+
+		userBucket := bucket.NewUserBucketClient(userID, i.bucket, i.limits)
+		if err := mimir_tsdb.WriteMetricsMetadataObject(ctx, userBucket, mimir_tsdb.MetricsMetadataSnapshotPath(i.cfg.IngesterRing.InstanceID, now), snapshot); err != nil {
+			level.Warn(i.logger).Log("msg", "failed to upload the metrics metadata snapshot", "user", userID, "err", err)
+			continue
+		}
+
+		level.Debug(i.logger).Log("msg", "uploaded the metrics metadata snapshot", "user", userID, "metadata", len(snapshot.Metadata))
+	}
+
+	return nil
+}
+
+// stoppingMetadataUpload uploads a last snapshot of the metric metadata, so that it survives the ingester restart.
+func (i *Ingester) stoppingMetadataUpload(_ error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), metadataUploadOnStopTimeout)
+	defer cancel()
+
+	return i.uploadUsersMetricsMetadata(ctx)
+}
+
 // MetricsMetadata returns all the metric metadata of a user.
 func (i *Ingester) MetricsMetadata(ctx context.Context, req *client.MetricsMetadataRequest) (*client.MetricsMetadataResponse, error) {
 	if err := i.checkRunning(); err != nil {
@@ -2290,6 +2791,9 @@ func (i *Ingester) MetricsMetadata(ctx context.Context, req *client.MetricsMetad
 // are ready for the addition or removal of another ingester.
 func (i *Ingester) CheckReady(ctx context.Context) error {
 	if err := i.checkRunning(); err != nil {
+		if progress := i.StartupStatus(); progress != "" {
+			return fmt.Errorf("ingester not ready: %s", progress)
+		}
 		return fmt.Errorf("ingester not ready: %v", err)
 	}
 	return i.lifecycler.CheckReady(ctx)
@@ -129,6 +129,33 @@ func (i *ActivityTrackerWrapper) LabelValuesCardinality(request *client.LabelVal
 	return i.ing.LabelValuesCardinality(request, server)
 }
 
+func (i *ActivityTrackerWrapper) ActiveSeries(request *client.ActiveSeriesRequest, server client.Ingester_ActiveSeriesServer) error {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(server.Context(), "Ingester/ActiveSeries", request)
+	})
+	defer i.tracker.Delete(ix)
+
+	return i.ing.ActiveSeries(request, server)
+}
+
+func (i *ActivityTrackerWrapper) SeriesChurn(ctx context.Context, request *client.SeriesChurnRequest) (*client.SeriesChurnResponse, error) {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(ctx, "Ingester/SeriesChurn", request)
+	})
+	defer i.tracker.Delete(ix)
+
+	return i.ing.SeriesChurn(ctx, request)
+}
+
+func (i *ActivityTrackerWrapper) TSDBStatus(ctx context.Context, request *client.TSDBStatusRequest) (*client.TSDBStatusResponse, error) {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(ctx, "Ingester/TSDBStatus", request)
+	})
+	defer i.tracker.Delete(ix)
+
+	return i.ing.TSDBStatus(ctx, request)
+}
+
 func (i *ActivityTrackerWrapper) FlushHandler(w http.ResponseWriter, r *http.Request) {
 	ix := i.tracker.Insert(func() string {
 		return requestActivity(r.Context(), "Ingester/FlushHandler", nil)
@@ -138,6 +165,24 @@ func (i *ActivityTrackerWrapper) FlushHandler(w http.ResponseWriter, r *http.Req
 	i.ing.FlushHandler(w, r)
 }
 
+func (i *ActivityTrackerWrapper) PrepareDownscaleHandler(w http.ResponseWriter, r *http.Request) {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(r.Context(), "Ingester/PrepareDownscaleHandler", nil)
+	})
+	defer i.tracker.Delete(ix)
+
+	i.ing.PrepareDownscaleHandler(w, r)
+}
+
+func (i *ActivityTrackerWrapper) StartupProgressHandler(w http.ResponseWriter, r *http.Request) {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(r.Context(), "Ingester/StartupProgressHandler", nil)
+	})
+	defer i.tracker.Delete(ix)
+
+	i.ing.StartupProgressHandler(w, r)
+}
+
 func (i *ActivityTrackerWrapper) ShutdownHandler(w http.ResponseWriter, r *http.Request) {
 	ix := i.tracker.Insert(func() string {
 		return requestActivity(r.Context(), "Ingester/ShutdownHandler", nil)
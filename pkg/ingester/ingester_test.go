@@ -55,6 +55,7 @@ import (
 	"github.com/grafana/mimir/pkg/ingester/activeseries"
 	"github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/mimirpb"
+	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/chunk"
 	"github.com/grafana/mimir/pkg/storage/sharding"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
@@ -2460,6 +2461,7 @@ func TestIngester_QueryStreamManySamples(t *testing.T) {
 	recvMsgs := 0
 	series := 0
 	totalSamples := 0
+	var stats *client.QueryStreamStats
 
 	for {
 		resp, err := s.Recv()
@@ -2467,6 +2469,15 @@ func TestIngester_QueryStreamManySamples(t *testing.T) {
 			break
 		}
 		require.NoError(t, err)
+
+		// The query statistics are sent in the last message.
+		if resp.Stats != nil {
+			require.Nil(t, stats)
+			require.Empty(t, resp.Timeseries)
+			stats = resp.Stats
+			continue
+		}
+		require.Nil(t, stats)
 		require.True(t, len(resp.Timeseries) > 0) // No empty messages.
 
 		recvMsgs++
@@ -2483,6 +2494,46 @@ func TestIngester_QueryStreamManySamples(t *testing.T) {
 	require.True(t, 2 <= recvMsgs && recvMsgs <= 3)
 	require.Equal(t, 3, series)
 	require.Equal(t, 10000+50000+samplesCount, totalSamples)
+
+	require.NotNil(t, stats)
+	require.Equal(t, uint64(3), stats.SeriesCount)
+	require.Equal(t, uint64(10000+50000+samplesCount), stats.SamplesCount)
+	require.Zero(t, stats.ChunksCount)
+}
+
+func TestIngester_QueryStream_Stats(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.StreamChunksWhenUsingBlocks = true
+
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+
+	// Push a sample and compact it to a block, then push a more recent sample to the head.
+	pushSingleSampleAtTime(t, i, time.Minute.Milliseconds())
+	i.compactBlocks(context.Background(), true, nil)
+	pushSingleSampleAtTime(t, i, 3*time.Hour.Milliseconds())
+
+	ctx := user.InjectOrgID(context.Background(), userID)
+	req, err := client.ToQueryRequest(0, model.Time(4*time.Hour.Milliseconds()), []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")})
+	require.NoError(t, err)
+
+	s := stream{ctx: ctx}
+	require.NoError(t, i.QueryStream(req, &s))
+
+	// The query statistics are sent in the last message.
+	require.Len(t, s.responses, 2)
+	require.Len(t, s.responses[0].Chunkseries, 1)
+	require.Len(t, s.responses[0].Chunkseries[0].Chunks, 2)
+
+	stats := s.responses[1].Stats
+	require.NotNil(t, stats)
+	assert.Empty(t, s.responses[1].Chunkseries)
+	assert.Equal(t, uint64(1), stats.SeriesCount)
+	assert.Equal(t, uint64(2), stats.SamplesCount)
+	assert.Equal(t, uint64(2), stats.ChunksCount)
+	assert.Equal(t, uint64(1), stats.HeadChunksCount)
+	assert.Equal(t, uint64(1), stats.BlockChunksCount)
+	assert.Equal(t, uint64(len(s.responses[0].Chunkseries[0].Chunks[0].Data)+len(s.responses[0].Chunkseries[0].Chunks[1].Data)), stats.ChunkBytes)
+	assert.Greater(t, stats.WallTime, time.Duration(0))
 }
 
 func TestIngester_QueryStreamManySamplesChunks(t *testing.T) {
@@ -2557,6 +2608,7 @@ func TestIngester_QueryStreamManySamplesChunks(t *testing.T) {
 	recvMsgs := 0
 	series := 0
 	totalSamples := 0
+	var stats *client.QueryStreamStats
 
 	for {
 		resp, err := s.Recv()
@@ -2564,6 +2616,15 @@ func TestIngester_QueryStreamManySamplesChunks(t *testing.T) {
 			break
 		}
 		require.NoError(t, err)
+
+		// The query statistics are sent in the last message.
+		if resp.Stats != nil {
+			require.Nil(t, stats)
+			require.Empty(t, resp.Chunkseries)
+			stats = resp.Stats
+			continue
+		}
+		require.Nil(t, stats)
 		require.True(t, len(resp.Chunkseries) > 0) // No empty messages.
 
 		recvMsgs++
@@ -2586,6 +2647,14 @@ func TestIngester_QueryStreamManySamplesChunks(t *testing.T) {
 	require.True(t, 2 <= recvMsgs && recvMsgs <= 3)
 	require.Equal(t, 3, series)
 	require.Equal(t, 100000+500000+samplesCount, totalSamples)
+
+	require.NotNil(t, stats)
+	require.Equal(t, uint64(3), stats.SeriesCount)
+	require.Equal(t, uint64(100000+500000+samplesCount), stats.SamplesCount)
+	require.NotZero(t, stats.ChunksCount)
+	require.Equal(t, stats.ChunksCount, stats.HeadChunksCount)
+	require.Zero(t, stats.BlockChunksCount)
+	require.NotZero(t, stats.ChunkBytes)
 }
 
 func writeRequestSingleSeries(lbls labels.Labels, samples []mimirpb.Sample) *mimirpb.WriteRequest {
@@ -3115,6 +3184,72 @@ func TestIngester_dontShipBlocksWhenTenantDeletionMarkerIsPresent(t *testing.T)
 	require.Equal(t, tsdbTenantMarkedForDeletion, i.closeAndDeleteUserTSDBIfIdle(userID))
 }
 
+func TestIngester_shipBlocks_ShouldUploadBlocksExemplars(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.IngesterRing.JoinAfter = 0
+
+	limits := defaultLimitsTestConfig()
+	limits.MaxGlobalExemplarsPerUser = 10
+	limits.ExemplarsRetentionPeriod = model.Duration(24 * time.Hour)
+
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, cfg, limits, "", nil)
+	require.NoError(t, err)
+
+	// Use in-memory bucket.
+	bkt := objstore.NewInMemBucket()
+
+	i.bucket = bkt
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's healthy
+	test.Poll(t, 1*time.Second, 1, func() interface{} {
+		return i.lifecycler.HealthyInstancesCount()
+	})
+
+	now := util.TimeToMillis(time.Now())
+	series := labels.FromStrings(labels.MetricName, "test")
+	ctx := user.InjectOrgID(context.Background(), userID)
+	_, err = i.Push(ctx, mimirpb.ToWriteRequest([]labels.Labels{series}, []mimirpb.Sample{{Value: 1, TimestampMs: now}}, nil, nil, mimirpb.API))
+	require.NoError(t, err)
+	_, err = i.Push(ctx, mimirpb.ToWriteRequest([]labels.Labels{series}, []mimirpb.Sample{{Value: 2, TimestampMs: now + 1}}, []*mimirpb.Exemplar{
+		{Labels: []mimirpb.LabelAdapter{{Name: "traceID", Value: "123"}}, TimestampMs: now + 1, Value: 2},
+	}, nil, mimirpb.API))
+	require.NoError(t, err)
+
+	i.compactBlocks(context.Background(), true, nil)
+
+	db := i.getTSDB(userID)
+	require.NotNil(t, db)
+	require.Len(t, db.Blocks(), 1)
+	blockID := db.Blocks()[0].Meta().ULID
+	blockDir := db.Blocks()[0].Dir()
+
+	expectedSeries := []mimir_tsdb.BlockExemplarsSeries{
+		{Labels: series, Exemplars: []mimir_tsdb.BlockExemplar{{Labels: labels.FromStrings("traceID", "123"), Value: 2, Timestamp: now + 1}}},
+	}
+
+	// The exemplars are persisted in the block directory at head compaction.
+	exemplars, err := mimir_tsdb.ReadBlockExemplarsFromDir(blockDir, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expectedSeries, exemplars.Series)
+
+	// The persisted exemplars are uploaded, instead of the in-memory ones.
+	expectedSeries[0].Exemplars = append(expectedSeries[0].Exemplars, mimir_tsdb.BlockExemplar{Labels: labels.FromStrings("traceID", "456"), Value: 3, Timestamp: now + 2})
+	require.NoError(t, mimir_tsdb.WriteBlockExemplarsToDir(blockDir, &mimir_tsdb.BlockExemplars{Version: mimir_tsdb.BlockExemplarsVersion1, Series: expectedSeries}))
+
+	i.shipBlocks(context.Background(), nil)
+
+	// The exemplars are uploaded alongside the shipped block.
+	exemplars, err = mimir_tsdb.ReadBlockExemplars(context.Background(), bucket.NewUserBucketClient(userID, bkt, nil), blockID, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expectedSeries, exemplars.Series)
+
+	exists, err := bkt.Exists(context.Background(), fmt.Sprintf("%s/%s/meta.json", userID, blockID))
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
 func TestIngester_seriesCountIsCorrectAfterClosingTSDBForDeletedTenant(t *testing.T) {
 	cfg := defaultIngesterTestConfig(t)
 	cfg.IngesterRing.JoinAfter = 0
@@ -3942,6 +4077,152 @@ func TestIngesterCompactAndCloseIdleTSDB(t *testing.T) {
     `), metricsToCheck...))
 }
 
+func TestIngesterCompactBlocksUnderMemoryPressure(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionEnabled = true
+	cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionSeriesThreshold = 2
+	cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionMinInMemoryDuration = 15 * time.Minute
+
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+
+	now := time.Now()
+	push := func(userID string, metricName string, ts time.Time) {
+		ctx := user.InjectOrgID(context.Background(), userID)
+		req, _, _, _ := mockWriteRequest(t, labels.FromStrings(labels.MetricName, metricName), 0, util.TimeToMillis(ts))
+		_, err := i.Push(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// The tenant "user-1" has the most in-memory series, some of them inactive since 30 minutes.
+	push("user-1", "inactive_1", now.Add(-30*time.Minute))
+	push("user-1", "inactive_2", now.Add(-30*time.Minute))
+	push("user-1", "active", now.Add(-30*time.Minute))
+	push("user-1", "active", now)
+	push("user-2", "inactive", now.Add(-30*time.Minute))
+	require.Equal(t, int64(4), i.seriesCount.Load())
+
+	i.compactBlocksUnderMemoryPressure(context.Background(), now)
+
+	// Only the head of the tenant with the most series has been compacted, releasing the inactive series,
+	// because the number of in-memory series is below the threshold afterwards.
+	assert.Equal(t, int64(2), i.seriesCount.Load())
+	assert.Equal(t, uint64(1), i.getTSDB("user-1").Head().NumSeries())
+	assert.Equal(t, uint64(1), i.getTSDB("user-2").Head().NumSeries())
+	assert.Len(t, i.getTSDB("user-1").Blocks(), 1)
+	assert.Len(t, i.getTSDB("user-2").Blocks(), 0)
+
+	// The most recent samples are still queryable from the head.
+	assert.GreaterOrEqual(t, i.getTSDB("user-1").Head().MinTime(), util.TimeToMillis(now.Add(-15*time.Minute)))
+
+	// Nothing happens when the number of in-memory series is below the threshold.
+	i.compactBlocksUnderMemoryPressure(context.Background(), now)
+	assert.Equal(t, int64(2), i.seriesCount.Load())
+	assert.Len(t, i.getTSDB("user-2").Blocks(), 0)
+}
+
+func TestIngesterCompactBlocksUnderMemoryPressure_ShouldSkipRecentlyCompactedAndShortHeads(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionEnabled = true
+	cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionSeriesThreshold = 1
+	cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionMinInMemoryDuration = 15 * time.Minute
+
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+
+	// The samples of "user-1" must span a block range boundary, so that the chunks before the early head
+	// compaction time range are released from the head once compacted, and the head min time moves forward.
+	now := time.Now().Truncate(2 * time.Hour).Add(15 * time.Minute)
+	push := func(userID string, metricName string, ts time.Time) {
+		ctx := user.InjectOrgID(context.Background(), userID)
+		req, _, _, _ := mockWriteRequest(t, labels.FromStrings(labels.MetricName, metricName), 0, util.TimeToMillis(ts))
+		_, err := i.Push(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// The series of "user-1" are all active, so the early head compaction can't release any of them.
+	push("user-1", "active_1", now.Add(-30*time.Minute))
+	push("user-1", "active_2", now.Add(-30*time.Minute))
+	push("user-1", "active_1", now.Add(time.Hour))
+	push("user-1", "active_2", now.Add(time.Hour))
+
+	// The head of "user-2" holds less than the min block span of samples before the early head compaction time range.
+	push("user-2", "inactive", now.Add(-17*time.Minute))
+	require.Equal(t, int64(3), i.seriesCount.Load())
+
+	i.compactBlocksUnderMemoryPressure(context.Background(), now)
+	assert.Equal(t, float64(1), testutil.ToFloat64(i.metrics.compactionsTriggered))
+	assert.Equal(t, int64(3), i.seriesCount.Load())
+	assert.Len(t, i.getTSDB("user-2").Blocks(), 0)
+
+	// The early compaction of "user-1" released nothing, so it's backed off for twice the min interval.
+	assert.Equal(t, now.Add(2*earlyHeadCompactionMinInterval), i.getTSDB("user-1").earlyCompactionNextAllowed)
+
+	i.compactBlocksUnderMemoryPressure(context.Background(), now.Add(earlyHeadCompactionMinInterval))
+	assert.Equal(t, float64(1), testutil.ToFloat64(i.metrics.compactionsTriggered))
+
+	// Once the backoff expires, "user-1" is still skipped because its head only holds samples after the early
+	// head compaction time range, while the head of "user-2" now holds enough samples to be compacted,
+	// releasing its inactive series.
+	i.compactBlocksUnderMemoryPressure(context.Background(), now.Add(20*time.Minute))
+	assert.Equal(t, float64(2), testutil.ToFloat64(i.metrics.compactionsTriggered))
+	assert.Equal(t, int64(2), i.seriesCount.Load())
+	assert.Len(t, i.getTSDB("user-2").Blocks(), 1)
+	assert.Equal(t, now.Add(2*earlyHeadCompactionMinInterval), i.getTSDB("user-1").earlyCompactionNextAllowed)
+	assert.Equal(t, now.Add(20*time.Minute).Add(earlyHeadCompactionMinInterval), i.getTSDB("user-2").earlyCompactionNextAllowed)
+}
+
+func TestUserTSDB_updateEarlyCompactionBackoff(t *testing.T) {
+	now := time.Now()
+	db := &userTSDB{}
+
+	// The backoff is doubled, up to the max backoff, while no series are released.
+	expectedBackoffs := []time.Duration{10 * time.Minute, 20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour}
+	for _, expected := range expectedBackoffs {
+		db.updateEarlyCompactionBackoff(now, false)
+		assert.Equal(t, expected, db.earlyCompactionBackoff)
+		assert.Equal(t, now.Add(expected), db.earlyCompactionNextAllowed)
+	}
+
+	// The backoff is reset once some series are released.
+	db.updateEarlyCompactionBackoff(now, true)
+	assert.Equal(t, earlyHeadCompactionMinInterval, db.earlyCompactionBackoff)
+	assert.Equal(t, now.Add(earlyHeadCompactionMinInterval), db.earlyCompactionNextAllowed)
+}
+
+func TestIngester_getEarlyHeadCompactionSeriesThreshold(t *testing.T) {
+	tests := map[string]struct {
+		threshold int64
+		maxSeries int64
+		expected  int64
+	}{
+		"configured threshold": {
+			threshold: 100,
+			maxSeries: 1000,
+			expected:  100,
+		},
+		"threshold defaults to a ratio of the max series instance limit": {
+			maxSeries: 1000,
+			expected:  900,
+		},
+		"no threshold and no max series instance limit": {
+			expected: 0,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := defaultIngesterTestConfig(t)
+			cfg.BlocksStorageConfig.TSDB.EarlyHeadCompactionSeriesThreshold = testData.threshold
+			cfg.InstanceLimitsFn = func() *InstanceLimits {
+				return &InstanceLimits{MaxInMemorySeries: testData.maxSeries}
+			}
+
+			i, err := prepareIngesterWithBlocksStorage(t, cfg, nil)
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, i.getEarlyHeadCompactionSeriesThreshold())
+		})
+	}
+}
+
 func verifyCompactedHead(t *testing.T, i *Ingester, expected bool) {
 	db := i.getTSDB(userID)
 	require.NotNil(t, db)
@@ -4711,6 +4992,44 @@ func TestIngesterPurgeMetadata(t *testing.T) {
 	}
 }
 
+func TestIngesterUploadMetadata(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.MetadataUploadInterval = time.Hour
+
+	ing, err := prepareIngesterWithBlocksStorageAndLimits(t, cfg, defaultLimitsTestConfig(), "", nil)
+	require.NoError(t, err)
+
+	bkt := objstore.NewInMemBucket()
+	ing.bucket = bkt
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), ing))
+
+	// Wait until the ingester is healthy
+	test.Poll(t, 100*time.Millisecond, 1, func() interface{} {
+		return ing.lifecycler.HealthyInstancesCount()
+	})
+
+	userIDs, _ := pushTestMetadata(t, ing, 2, 2)
+
+	// A snapshot of the metadata is uploaded on shutdown.
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), ing))
+
+	for _, userID := range userIDs {
+		userBucket := bucket.NewUserBucketClient(userID, bkt, nil)
+		snapshots, err := mimir_tsdb.ListMetricsMetadataSnapshots(context.Background(), userBucket)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.True(t, strings.HasPrefix(snapshots[0], "metrics-metadata/snapshots/"+cfg.IngesterRing.InstanceID+"-"))
+
+		snapshot, err := mimir_tsdb.ReadMetricsMetadataObject(context.Background(), userBucket, snapshots[0], log.NewNopLogger())
+		require.NoError(t, err)
+		require.Len(t, snapshot.Metadata, 4)
+		assert.Equal(t, "testmetric_0", snapshot.Metadata[0].Metric)
+		assert.Equal(t, "counter", snapshot.Metadata[0].Type)
+		assert.Equal(t, "a help for 0", snapshot.Metadata[0].Help)
+		assert.NotZero(t, snapshot.Metadata[0].LastSeen)
+	}
+}
+
 func TestIngesterMetadataMetrics(t *testing.T) {
 	reg := prometheus.NewPedanticRegistry()
 	cfg := defaultIngesterTestConfig(t)
@@ -4793,8 +5112,11 @@ func TestIngesterSendsOnlySeriesWithData(t *testing.T) {
 		err = ing.QueryStream(req, &s)
 		require.NoError(t, err)
 
-		// Nothing should be selected.
-		require.Equal(t, 0, len(s.responses))
+		// Nothing should be selected, only the query statistics are sent.
+		require.Equal(t, 1, len(s.responses))
+		require.Empty(t, s.responses[0].Timeseries)
+		require.Empty(t, s.responses[0].Chunkseries)
+		require.Zero(t, s.responses[0].Stats.SeriesCount)
 	}
 
 	// Read samples back via chunk store.
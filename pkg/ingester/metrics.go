@@ -34,6 +34,9 @@ type ingesterMetrics struct {
 	activeSeriesPerUser               *prometheus.GaugeVec
 	activeSeriesCustomTrackersPerUser *prometheus.GaugeVec
 
+	seriesChurnCreated *prometheus.GaugeVec
+	seriesChurnRemoved *prometheus.GaugeVec
+
 	// Global limit metrics
 	maxUsersGauge           prometheus.GaugeFunc
 	maxSeriesGauge          prometheus.GaugeFunc
@@ -54,6 +57,7 @@ type ingesterMetrics struct {
 func newIngesterMetrics(
 	r prometheus.Registerer,
 	activeSeriesEnabled bool,
+	seriesChurnMetricsEnabled bool,
 	instanceLimitsFn func() *InstanceLimits,
 	ingestionRate *util_math.EwmaRate,
 	inflightRequests *atomic.Int64,
@@ -226,6 +230,18 @@ func newIngesterMetrics(
 			Help: "Number of currently active series matching a pre-configured label matchers per user.",
 		}, []string{"user", "name"}),
 
+		// Not registered automatically, but only if seriesChurnMetricsEnabled is true.
+		seriesChurnCreated: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cortex_ingester_series_churn_created_series",
+			Help: "Number of series created over the last hour, for the metric names with the highest series churn per user.",
+		}, []string{"user", "metric"}),
+
+		// Not registered automatically, but only if seriesChurnMetricsEnabled is true.
+		seriesChurnRemoved: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cortex_ingester_series_churn_removed_series",
+			Help: "Number of series removed over the last hour, for the metric names with the highest series churn per user.",
+		}, []string{"user", "metric"}),
+
 		compactionsTriggered: promauto.With(r).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_ingester_tsdb_compactions_triggered_total",
 			Help: "Total number of triggered compactions.",
@@ -260,6 +276,11 @@ func newIngesterMetrics(
 		r.MustRegister(m.activeSeriesCustomTrackersPerUser)
 	}
 
+	if seriesChurnMetricsEnabled && r != nil {
+		r.MustRegister(m.seriesChurnCreated)
+		r.MustRegister(m.seriesChurnRemoved)
+	}
+
 	return m
 }
 
@@ -278,6 +299,13 @@ func (m *ingesterMetrics) deletePerUserCustomTrackerMetrics(userID string, custo
 	}
 }
 
+func (m *ingesterMetrics) deletePerUserSeriesChurnMetrics(userID string, metricNames []string) {
+	for _, name := range metricNames {
+		m.seriesChurnCreated.DeleteLabelValues(userID, name)
+		m.seriesChurnRemoved.DeleteLabelValues(userID, name)
+	}
+}
+
 // TSDB metrics collector. Each tenant has its own registry, that TSDB code uses.
 type tsdbMetrics struct {
 	// Metrics aggregated from Thanos shipper.
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/ring"
+	"github.com/pkg/errors"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+var errIngesterReadOnly = errors.New("ingester is read-only because it's being prepared for downscale")
+
+// downscaleState tracks the progress of the preparation for downscale.
+type downscaleState struct {
+	mtx sync.Mutex
+	// Whether the ingester has been switched to read-only. Once read-only, the ingester
+	// stays read-only until it's restarted.
+	readOnly bool
+	// Whether the head of all TSDBs is being compacted and the blocks shipped to the storage.
+	flushing bool
+	// Whether the flush has completed at least once since the ingester switched to read-only.
+	flushed bool
+	// The error of the last flush, if any.
+	flushErr error
+}
+
+// PrepareDownscaleStatus is the status of the preparation for downscale, returned by PrepareDownscaleHandler.
+type PrepareDownscaleStatus struct {
+	ReadOnly          bool     `json:"read_only"`
+	RingState         string   `json:"ring_state"`
+	FlushInProgress   bool     `json:"flush_in_progress"`
+	FlushError        string   `json:"flush_error,omitempty"`
+	TenantsNotFlushed []string `json:"tenants_not_flushed,omitempty"`
+	SafeToTerminate   bool     `json:"safe_to_terminate"`
+}
+
+// PrepareDownscaleHandler prepares the ingester to be safely terminated without losing data.
+//
+// A POST request switches the ingester into a read-only state, in which it leaves the write path of
+// the ring (switching to the LEAVING state) and rejects pushes while still serving queries, and then
+// compacts the head of all TSDBs and ships the blocks to the storage. A POST request on an ingester
+// which is already read-only retries the flush if the previous one failed or left some data behind.
+//
+// Both POST and GET requests return the current status, which reports whether the ingester is safe to terminate.
+// The read-only state can't be reverted, except by restarting the ingester.
+func (i *Ingester) PrepareDownscaleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		if !i.cfg.BlocksStorageConfig.TSDB.IsBlocksShippingEnabled() {
+			http.Error(w, "blocks shipping is disabled, so the ingester can't be terminated without losing data", http.StatusBadRequest)
+			return
+		}
+
+		if err := i.prepareDownscale(r); err != nil {
+			level.Error(i.logger).Log("msg", "failed to prepare the ingester for downscale", "err", err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	util.WriteJSONResponse(w, i.prepareDownscaleStatus())
+}
+
+// prepareDownscale switches the ingester into the read-only state, if not already done,
+// and starts flushing all TSDBs in background, unless a flush is already in progress.
+func (i *Ingester) prepareDownscale(r *http.Request) error {
+	if err := i.checkRunning(); err != nil {
+		return err
+	}
+
+	i.downscale.mtx.Lock()
+	defer i.downscale.mtx.Unlock()
+
+	if !i.downscale.readOnly {
+		// Reject pushes before leaving the write path of the ring, so that no data is ingested
+		// after the flush even by distributors which haven't seen the ring change yet. Pushes
+		// rejected by this ingester are successfully written to the other replicas.
+		i.readOnly.Store(true)
+
+		if state := i.lifecycler.GetState(); state != ring.LEAVING {
+			if err := i.lifecycler.ChangeState(r.Context(), ring.LEAVING); err != nil {
+				i.readOnly.Store(false)
+				return errors.Wrap(err, "failed to switch the ingester to the LEAVING state")
+			}
+		}
+
+		level.Info(i.logger).Log("msg", "ingester switched to read-only, preparing for downscale")
+		i.downscale.readOnly = true
+	}
+
+	if i.downscale.flushing {
+		return nil
+	}
+	i.downscale.flushing = true
+
+	go func() {
+		err := i.flushBlocks(nil)
+
+		i.downscale.mtx.Lock()
+		defer i.downscale.mtx.Unlock()
+		i.downscale.flushing = false
+		i.downscale.flushed = true
+		i.downscale.flushErr = err
+	}()
+
+	return nil
+}
+
+func (i *Ingester) prepareDownscaleStatus() PrepareDownscaleStatus {
+	i.downscale.mtx.Lock()
+	status := PrepareDownscaleStatus{
+		ReadOnly:        i.downscale.readOnly,
+		RingState:       i.lifecycler.GetState().String(),
+		FlushInProgress: i.downscale.flushing,
+	}
+	flushed := i.downscale.flushed
+	if i.downscale.flushErr != nil {
+		status.FlushError = i.downscale.flushErr.Error()
+	}
+	i.downscale.mtx.Unlock()
+
+	if !status.ReadOnly {
+		return status
+	}
+
+	// Even once the flush has completed, check the TSDBs to make sure that
+	// no data has been left in the head or in unshipped blocks.
+	for _, userID := range i.getTSDBUsers() {
+		db := i.getTSDB(userID)
+		if db == nil {
+			continue
+		}
+		if db.Head().NumSeries() > 0 || db.getOldestUnshippedBlockTime() > 0 {
+			status.TenantsNotFlushed = append(status.TenantsNotFlushed, userID)
+		}
+	}
+	sort.Strings(status.TenantsNotFlushed)
+
+	status.SafeToTerminate = flushed && !status.FlushInProgress && status.FlushError == "" && len(status.TenantsNotFlushed) == 0
+	return status
+}
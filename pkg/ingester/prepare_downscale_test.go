@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/dskit/ring"
+	"github.com/grafana/dskit/services"
+	"github.com/grafana/dskit/test"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+func TestIngester_PrepareDownscaleHandler(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.IngesterRing.JoinAfter = 0
+	cfg.BlocksStorageConfig.TSDB.ShipConcurrency = 1
+	cfg.BlocksStorageConfig.TSDB.ShipInterval = 1 * time.Minute // Long enough to not be reached during the test.
+
+	reg := prometheus.NewPedanticRegistry()
+	i, err := prepareIngesterWithBlocksStorage(t, cfg, reg)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	t.Cleanup(func() {
+		_ = services.StopAndAwaitTerminated(context.Background(), i)
+	})
+
+	// Wait until it's healthy.
+	test.Poll(t, 1*time.Second, 1, func() interface{} {
+		return i.lifecycler.HealthyInstancesCount()
+	})
+
+	pushSingleSampleWithMetadata(t, i)
+
+	// The ingester is not read-only until requested.
+	status := prepareDownscaleRequest(t, i, http.MethodGet)
+	assert.Equal(t, PrepareDownscaleStatus{RingState: ring.ACTIVE.String()}, status)
+
+	status = prepareDownscaleRequest(t, i, http.MethodPost)
+	assert.True(t, status.ReadOnly)
+	assert.Equal(t, ring.LEAVING.String(), status.RingState)
+
+	// Wait until the head has been compacted and the block shipped.
+	test.Poll(t, 5*time.Second, true, func() interface{} {
+		return prepareDownscaleRequest(t, i, http.MethodGet).SafeToTerminate
+	})
+
+	verifyCompactedHead(t, i, true)
+	require.NoError(t, testutil.GatherAndCompare(reg, bytes.NewBufferString(`
+		# HELP cortex_ingester_shipper_uploads_total Total number of uploaded TSDB blocks
+		# TYPE cortex_ingester_shipper_uploads_total counter
+		cortex_ingester_shipper_uploads_total 1
+	`), "cortex_ingester_shipper_uploads_total"))
+
+	// Pushes are rejected while read-only.
+	ctx := user.InjectOrgID(context.Background(), userID)
+	req, _, _, _ := mockWriteRequest(t, labels.Labels{{Name: labels.MetricName, Value: "test"}}, 0, util.TimeToMillis(time.Now()))
+	_, err = i.Push(ctx, req)
+	assert.Equal(t, errIngesterReadOnly, err)
+
+	// Requesting it again is a no-op.
+	status = prepareDownscaleRequest(t, i, http.MethodPost)
+	assert.True(t, status.ReadOnly)
+	assert.Equal(t, ring.LEAVING.String(), i.lifecycler.GetState().String())
+}
+
+func TestIngester_PrepareDownscaleHandler_ShippingDisabled(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.BlocksStorageConfig.TSDB.ShipInterval = 0
+
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+
+	res := httptest.NewRecorder()
+	i.PrepareDownscaleHandler(res, httptest.NewRequest(http.MethodPost, "/ingester/prepare-downscale", nil))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	assert.False(t, i.readOnly.Load())
+	assert.Equal(t, ring.ACTIVE, i.lifecycler.GetState())
+}
+
+func prepareDownscaleRequest(t *testing.T, i *Ingester, method string) PrepareDownscaleStatus {
+	res := httptest.NewRecorder()
+	i.PrepareDownscaleHandler(res, httptest.NewRequest(method, "/ingester/prepare-downscale", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+
+	status := PrepareDownscaleStatus{}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &status))
+	return status
+}
@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"flag"
+	"math"
+	"net/http"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/weaveworks/common/httpgrpc"
+	"go.uber.org/atomic"
+)
+
+const (
+	// pushCircuitBreakerEvaluationPeriod is how frequently the push latency and the memory
+	// pressure are evaluated to decide whether the circuit breaker should change state.
+	pushCircuitBreakerEvaluationPeriod = time.Second
+
+	// pushCircuitBreakerMinObservations is the minimum number of push requests observed in an
+	// evaluation period to consider the observed latency percentile significant.
+	pushCircuitBreakerMinObservations = 10
+
+	// The push latency is tracked in exponential buckets, starting from 1ms and doubling up to ~65s.
+	pushCircuitBreakerLatencyBuckets   = 17
+	pushCircuitBreakerMinLatencyBucket = time.Millisecond
+
+	heapObjectsBytesMetric = "/memory/classes/heap/objects:bytes"
+)
+
+var (
+	// ErrPushCircuitBreakerOpen is the retryable error returned to the distributors while the push circuit breaker
+	// is open. The distributors count it as a failure of a single replica, so the write still succeeds if the quorum
+	// is reached. We don't include values in the message to avoid leaking Mimir cluster configuration to users.
+	ErrPushCircuitBreakerOpen = httpgrpc.Errorf(http.StatusServiceUnavailable, "cannot push: ingester is overloaded and temporarily rejects push requests, please retry")
+
+	errInvalidPushCircuitBreakerLatencyPercentile = errors.New("the push circuit breaker latency percentile must be greater than 0 and lower or equal than 1")
+	errInvalidPushCircuitBreakerRecoveryRatio     = errors.New("the push circuit breaker recovery ratio must be greater than 0 and lower than 1")
+	errInvalidPushCircuitBreakerThresholds        = errors.New("the push circuit breaker requires at least one of the max latency and max heap bytes thresholds to be configured")
+)
+
+type pushCircuitBreakerState int
+
+const (
+	// pushCircuitBreakerClosed is the state in which push requests are accepted.
+	pushCircuitBreakerClosed pushCircuitBreakerState = iota
+	// pushCircuitBreakerOpen is the state in which push requests are rejected.
+	pushCircuitBreakerOpen
+	// pushCircuitBreakerHalfOpen is the state in which push requests are accepted again after
+	// the cooldown period, but the circuit breaker opens as soon as the ingester is under pressure.
+	pushCircuitBreakerHalfOpen
+)
+
+var pushCircuitBreakerStates = []pushCircuitBreakerState{pushCircuitBreakerClosed, pushCircuitBreakerOpen, pushCircuitBreakerHalfOpen}
+
+func (s pushCircuitBreakerState) String() string {
+	switch s {
+	case pushCircuitBreakerClosed:
+		return "closed"
+	case pushCircuitBreakerOpen:
+		return "open"
+	case pushCircuitBreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// PushCircuitBreakerConfig configures the circuit breaker shedding push requests when the ingester is under pressure.
+type PushCircuitBreakerConfig struct {
+	Enabled           bool          `yaml:"enabled" category:"experimental"`
+	LatencyPercentile float64       `yaml:"latency_percentile" category:"experimental"`
+	MaxLatency        time.Duration `yaml:"max_latency" category:"experimental"`
+	MaxHeapBytes      uint64        `yaml:"max_heap_bytes" category:"experimental"`
+	CooldownPeriod    time.Duration `yaml:"cooldown_period" category:"experimental"`
+	RecoveryRatio     float64       `yaml:"recovery_ratio" category:"experimental"`
+}
+
+func (cfg *PushCircuitBreakerConfig) RegisterFlags(f *flag.FlagSet) {
+	f.BoolVar(&cfg.Enabled, "ingester.push-circuit-breaker.enabled", false, "Enable the circuit breaker rejecting push requests with a retryable error when the push latency or the Go heap usage exceed the configured thresholds.")
+	f.Float64Var(&cfg.LatencyPercentile, "ingester.push-circuit-breaker.latency-percentile", 0.99, "The percentile of the push requests latency, observed every second, which is compared with -ingester.push-circuit-breaker.max-latency.")
+	f.DurationVar(&cfg.MaxLatency, "ingester.push-circuit-breaker.max-latency", 2*time.Second, "The circuit breaker opens when the push latency percentile reaches this value. 0 to disable the latency threshold.")
+	f.Uint64Var(&cfg.MaxHeapBytes, "ingester.push-circuit-breaker.max-heap-bytes", 0, "The circuit breaker opens when the Go heap objects size reaches this value. 0 to disable the memory threshold.")
+	f.DurationVar(&cfg.CooldownPeriod, "ingester.push-circuit-breaker.cooldown-period", 10*time.Second, "How long the circuit breaker stays open before accepting push requests again.")
+	f.Float64Var(&cfg.RecoveryRatio, "ingester.push-circuit-breaker.recovery-ratio", 0.8, "The circuit breaker closes only when the push latency and the Go heap objects size are below their thresholds multiplied by this ratio.")
+}
+
+func (cfg *PushCircuitBreakerConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.LatencyPercentile <= 0 || cfg.LatencyPercentile > 1 {
+		return errInvalidPushCircuitBreakerLatencyPercentile
+	}
+	if cfg.RecoveryRatio <= 0 || cfg.RecoveryRatio >= 1 {
+		return errInvalidPushCircuitBreakerRecoveryRatio
+	}
+	if cfg.MaxLatency <= 0 && cfg.MaxHeapBytes == 0 {
+		return errInvalidPushCircuitBreakerThresholds
+	}
+	return nil
+}
+
+// pushCircuitBreaker rejects push requests when the ingester is under pressure, based on the
+// observed push latency percentile and the Go heap usage.
+//
+// The circuit breaker opens when any of the thresholds is reached, and stays open for the cooldown
+// period. Then it switches to half-open and accepts push requests again. While half-open, it opens
+// again as soon as any of the thresholds is reached, and closes only once both the latency and the heap
+// usage are below the thresholds multiplied by the recovery ratio, so that it doesn't flap when the
+// ingester is close to the thresholds.
+type pushCircuitBreaker struct {
+	cfg    PushCircuitBreakerConfig
+	logger log.Logger
+
+	// Number of push requests observed in the current evaluation period, by latency bucket.
+	latencyBuckets [pushCircuitBreakerLatencyBuckets]atomic.Uint64
+
+	// Whether push requests are currently rejected.
+	rejecting atomic.Bool
+
+	// Returns the current Go heap objects size. Overridden in tests.
+	heapBytes func() uint64
+
+	mtx      sync.Mutex
+	state    pushCircuitBreakerState
+	openedAt time.Time
+
+	stateGauge      *prometheus.GaugeVec
+	transitions     *prometheus.CounterVec
+	rejected        prometheus.Counter
+	observedLatency prometheus.Gauge
+}
+
+func newPushCircuitBreaker(cfg PushCircuitBreakerConfig, logger log.Logger, reg prometheus.Registerer) *pushCircuitBreaker {
+	cb := &pushCircuitBreaker{
+		cfg:       cfg,
+		logger:    logger,
+		heapBytes: readHeapObjectsBytes,
+		state:     pushCircuitBreakerClosed,
+
+		stateGauge: promauto.With(reg).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cortex_ingester_push_circuit_breaker_state",
+			Help: "The current state of the ingester push circuit breaker. 1 for the current state, 0 for the other ones.",
+		}, []string{"state"}),
+		transitions: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_ingester_push_circuit_breaker_transitions_total",
+			Help: "Total number of times the ingester push circuit breaker switched to each state.",
+		}, []string{"state"}),
+		rejected: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_push_circuit_breaker_rejected_requests_total",
+			Help: "Total number of push requests rejected by the ingester push circuit breaker.",
+		}),
+		observedLatency: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "cortex_ingester_push_circuit_breaker_observed_latency_seconds",
+			Help: "The push latency percentile observed in the last evaluation of the ingester push circuit breaker.",
+		}),
+	}
+
+	for _, s := range pushCircuitBreakerStates {
+		cb.transitions.WithLabelValues(s.String())
+	}
+	cb.updateStateGauge()
+
+	return cb
+}
+
+// allow returns an error if the push request should be rejected.
+func (cb *pushCircuitBreaker) allow() error {
+	if !cb.rejecting.Load() {
+		return nil
+	}
+
+	cb.rejected.Inc()
+	return ErrPushCircuitBreakerOpen
+}
+
+// observe tracks the latency of a push request accepted by the circuit breaker, whose samples have been appended.
+func (cb *pushCircuitBreaker) observe(latency time.Duration) {
+	cb.latencyBuckets[pushCircuitBreakerLatencyBucket(latency)].Inc()
+}
+
+// evaluate computes the push latency percentile observed since the previous evaluation and the
+// current heap usage, and updates the circuit breaker state accordingly.
+func (cb *pushCircuitBreaker) evaluate(now time.Time) {
+	latency, latencyValid := cb.latencyPercentile()
+	heap := cb.heapBytes()
+
+	cb.observedLatency.Set(latency.Seconds())
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	switch cb.state {
+	case pushCircuitBreakerClosed:
+		if cb.underPressure(latency, latencyValid, heap, 1) {
+			cb.transition(pushCircuitBreakerOpen, now, latency, heap)
+		}
+
+	case pushCircuitBreakerOpen:
+		// No push request is accepted while open, so only the memory pressure can be checked.
+		if now.Sub(cb.openedAt) >= cb.cfg.CooldownPeriod && !cb.underPressure(0, false, heap, cb.cfg.RecoveryRatio) {
+			cb.transition(pushCircuitBreakerHalfOpen, now, latency, heap)
+		}
+
+	case pushCircuitBreakerHalfOpen:
+		if cb.underPressure(latency, latencyValid, heap, 1) {
+			cb.transition(pushCircuitBreakerOpen, now, latency, heap)
+		} else if latencyValid && !cb.underPressure(latency, latencyValid, heap, cb.cfg.RecoveryRatio) {
+			cb.transition(pushCircuitBreakerClosed, now, latency, heap)
+		}
+	}
+}
+
+// underPressure returns whether the latency or the heap usage reached their thresholds multiplied by ratio.
+func (cb *pushCircuitBreaker) underPressure(latency time.Duration, latencyValid bool, heap uint64, ratio float64) bool {
+	if latencyValid && cb.cfg.MaxLatency > 0 && latency >= time.Duration(float64(cb.cfg.MaxLatency)*ratio) {
+		return true
+	}
+	if cb.cfg.MaxHeapBytes > 0 && heap >= uint64(float64(cb.cfg.MaxHeapBytes)*ratio) {
+		return true
+	}
+	return false
+}
+
+// transition must be called with the mutex held.
+func (cb *pushCircuitBreaker) transition(state pushCircuitBreakerState, now time.Time, latency time.Duration, heap uint64) {
+	level.Warn(cb.logger).Log("msg", "ingester push circuit breaker changed state", "from", cb.state, "to", state, "latency", latency, "heap_bytes", heap)
+
+	cb.state = state
+	if state == pushCircuitBreakerOpen {
+		cb.openedAt = now
+	}
+	cb.rejecting.Store(state == pushCircuitBreakerOpen)
+	cb.transitions.WithLabelValues(state.String()).Inc()
+	cb.updateStateGauge()
+}
+
+func (cb *pushCircuitBreaker) updateStateGauge() {
+	for _, s := range pushCircuitBreakerStates {
+		value := 0.0
+		if s == cb.state {
+			value = 1
+		}
+		cb.stateGauge.WithLabelValues(s.String()).Set(value)
+	}
+}
+
+// latencyPercentile returns the configured percentile of the push latency observed since the previous
+// call, and resets the observations. The returned latency is the upper bound of the bucket containing the
+// percentile. The returned latency is not valid if not enough push requests have been observed.
+func (cb *pushCircuitBreaker) latencyPercentile() (time.Duration, bool) {
+	var counts [pushCircuitBreakerLatencyBuckets]uint64
+	total := uint64(0)
+	for i := range cb.latencyBuckets {
+		counts[i] = cb.latencyBuckets[i].Swap(0)
+		total += counts[i]
+	}
+
+	if total < pushCircuitBreakerMinObservations {
+		return 0, false
+	}
+
+	rank := uint64(math.Ceil(cb.cfg.LatencyPercentile * float64(total)))
+	cumulative := uint64(0)
+	for i, count := range counts {
+		cumulative += count
+		if cumulative >= rank {
+			return pushCircuitBreakerLatencyBucketUpperBound(i), true
+		}
+	}
+	return pushCircuitBreakerLatencyBucketUpperBound(pushCircuitBreakerLatencyBuckets - 1), true
+}
+
+// pushCircuitBreakerLatencyBucket returns the index of the exponential bucket for the input latency.
+func pushCircuitBreakerLatencyBucket(latency time.Duration) int {
+	for i := 0; i < pushCircuitBreakerLatencyBuckets-1; i++ {
+		if latency <= pushCircuitBreakerLatencyBucketUpperBound(i) {
+			return i
+		}
+	}
+	return pushCircuitBreakerLatencyBuckets - 1
+}
+
+func pushCircuitBreakerLatencyBucketUpperBound(i int) time.Duration {
+	return pushCircuitBreakerMinLatencyBucket << i
+}
+
+func readHeapObjectsBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsBytesMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/services"
+	"github.com/grafana/dskit/test"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+func TestPushCircuitBreakerConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		setup    func(cfg *PushCircuitBreakerConfig)
+		expected error
+	}{
+		"should pass with default config": {
+			setup: func(cfg *PushCircuitBreakerConfig) {},
+		},
+		"should pass when enabled with default config": {
+			setup: func(cfg *PushCircuitBreakerConfig) {
+				cfg.Enabled = true
+			},
+		},
+		"should fail when enabled with invalid latency percentile": {
+			setup: func(cfg *PushCircuitBreakerConfig) {
+				cfg.Enabled = true
+				cfg.LatencyPercentile = 1.5
+			},
+			expected: errInvalidPushCircuitBreakerLatencyPercentile,
+		},
+		"should fail when enabled with invalid recovery ratio": {
+			setup: func(cfg *PushCircuitBreakerConfig) {
+				cfg.Enabled = true
+				cfg.RecoveryRatio = 1
+			},
+			expected: errInvalidPushCircuitBreakerRecoveryRatio,
+		},
+		"should fail when enabled without thresholds": {
+			setup: func(cfg *PushCircuitBreakerConfig) {
+				cfg.Enabled = true
+				cfg.MaxLatency = 0
+				cfg.MaxHeapBytes = 0
+			},
+			expected: errInvalidPushCircuitBreakerThresholds,
+		},
+		"should pass when disabled with invalid config": {
+			setup: func(cfg *PushCircuitBreakerConfig) {
+				cfg.RecoveryRatio = 1
+			},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			cfg := defaultIngesterTestConfig(t).PushCircuitBreaker
+			testData.setup(&cfg)
+			assert.Equal(t, testData.expected, cfg.Validate())
+		})
+	}
+}
+
+func TestPushCircuitBreaker_Latency(t *testing.T) {
+	cfg := PushCircuitBreakerConfig{
+		Enabled:           true,
+		LatencyPercentile: 0.9,
+		MaxLatency:        time.Second,
+		CooldownPeriod:    10 * time.Second,
+		RecoveryRatio:     0.5,
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	cb := newPushCircuitBreaker(cfg, log.NewNopLogger(), reg)
+	cb.heapBytes = func() uint64 { return 0 }
+	now := time.Now()
+
+	observe := func(count int, latency time.Duration) {
+		for i := 0; i < count; i++ {
+			cb.observe(latency)
+		}
+	}
+
+	// The latency percentile is below the threshold.
+	observe(95, 10*time.Millisecond)
+	observe(5, 5*time.Second)
+	cb.evaluate(now)
+	require.Equal(t, pushCircuitBreakerClosed, cb.state)
+	require.NoError(t, cb.allow())
+
+	// Not enough observations to consider the latency percentile significant.
+	observe(pushCircuitBreakerMinObservations-1, 5*time.Second)
+	cb.evaluate(now)
+	require.Equal(t, pushCircuitBreakerClosed, cb.state)
+
+	// The latency percentile reaches the threshold.
+	observe(80, 10*time.Millisecond)
+	observe(20, 5*time.Second)
+	cb.evaluate(now)
+	require.Equal(t, pushCircuitBreakerOpen, cb.state)
+
+	err := cb.allow()
+	resp, ok := httpgrpc.HTTPResponseFromError(err)
+	require.True(t, ok)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), resp.Code)
+
+	// The circuit breaker stays open until the cooldown period has elapsed.
+	cb.evaluate(now.Add(cfg.CooldownPeriod / 2))
+	require.Equal(t, pushCircuitBreakerOpen, cb.state)
+
+	cb.evaluate(now.Add(cfg.CooldownPeriod))
+	require.Equal(t, pushCircuitBreakerHalfOpen, cb.state)
+	require.NoError(t, cb.allow())
+
+	// The latency percentile is below the threshold but above the recovery one, so the circuit breaker stays half-open.
+	observe(100, 300*time.Millisecond)
+	cb.evaluate(now.Add(cfg.CooldownPeriod))
+	require.Equal(t, pushCircuitBreakerHalfOpen, cb.state)
+
+	// The latency percentile is below the recovery threshold.
+	observe(100, 10*time.Millisecond)
+	cb.evaluate(now.Add(cfg.CooldownPeriod))
+	require.Equal(t, pushCircuitBreakerClosed, cb.state)
+	require.NoError(t, cb.allow())
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_ingester_push_circuit_breaker_rejected_requests_total Total number of push requests rejected by the ingester push circuit breaker.
+		# TYPE cortex_ingester_push_circuit_breaker_rejected_requests_total counter
+		cortex_ingester_push_circuit_breaker_rejected_requests_total 1
+
+		# HELP cortex_ingester_push_circuit_breaker_state The current state of the ingester push circuit breaker. 1 for the current state, 0 for the other ones.
+		# TYPE cortex_ingester_push_circuit_breaker_state gauge
+		cortex_ingester_push_circuit_breaker_state{state="closed"} 1
+		cortex_ingester_push_circuit_breaker_state{state="half-open"} 0
+		cortex_ingester_push_circuit_breaker_state{state="open"} 0
+
+		# HELP cortex_ingester_push_circuit_breaker_transitions_total Total number of times the ingester push circuit breaker switched to each state.
+		# TYPE cortex_ingester_push_circuit_breaker_transitions_total counter
+		cortex_ingester_push_circuit_breaker_transitions_total{state="closed"} 1
+		cortex_ingester_push_circuit_breaker_transitions_total{state="half-open"} 1
+		cortex_ingester_push_circuit_breaker_transitions_total{state="open"} 1
+	`),
+		"cortex_ingester_push_circuit_breaker_rejected_requests_total",
+		"cortex_ingester_push_circuit_breaker_state",
+		"cortex_ingester_push_circuit_breaker_transitions_total",
+	))
+}
+
+func TestPushCircuitBreaker_HeapBytes(t *testing.T) {
+	cfg := PushCircuitBreakerConfig{
+		Enabled:           true,
+		LatencyPercentile: 0.99,
+		MaxHeapBytes:      1000,
+		CooldownPeriod:    10 * time.Second,
+		RecoveryRatio:     0.8,
+	}
+
+	cb := newPushCircuitBreaker(cfg, log.NewNopLogger(), nil)
+	heap := uint64(999)
+	cb.heapBytes = func() uint64 { return heap }
+	now := time.Now()
+
+	cb.evaluate(now)
+	require.Equal(t, pushCircuitBreakerClosed, cb.state)
+
+	heap = 1000
+	cb.evaluate(now)
+	require.Equal(t, pushCircuitBreakerOpen, cb.state)
+
+	// The heap usage is below the threshold but above the recovery one, so the circuit breaker stays open.
+	heap = 900
+	cb.evaluate(now.Add(cfg.CooldownPeriod))
+	require.Equal(t, pushCircuitBreakerOpen, cb.state)
+
+	heap = 700
+	cb.evaluate(now.Add(cfg.CooldownPeriod))
+	require.Equal(t, pushCircuitBreakerHalfOpen, cb.state)
+
+	// The circuit breaker opens again as soon as the heap usage reaches the threshold.
+	heap = 1000
+	cb.evaluate(now.Add(cfg.CooldownPeriod))
+	require.Equal(t, pushCircuitBreakerOpen, cb.state)
+	require.Error(t, cb.allow())
+}
+
+func TestPushCircuitBreakerLatencyBucket(t *testing.T) {
+	assert.Equal(t, 0, pushCircuitBreakerLatencyBucket(0))
+	assert.Equal(t, 0, pushCircuitBreakerLatencyBucket(time.Millisecond))
+	assert.Equal(t, 1, pushCircuitBreakerLatencyBucket(1500*time.Microsecond))
+	assert.Equal(t, 10, pushCircuitBreakerLatencyBucket(time.Second))
+	assert.Equal(t, pushCircuitBreakerLatencyBuckets-1, pushCircuitBreakerLatencyBucket(time.Hour))
+}
+
+func TestIngester_Push_CircuitBreaker(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.PushCircuitBreaker.Enabled = true
+	cfg.PushCircuitBreaker.MaxHeapBytes = 1000
+
+	i, err := prepareIngesterWithBlocksStorage(t, cfg, nil)
+	require.NoError(t, err)
+
+	// The circuit breaker is also periodically evaluated in background, so the heap usage must be set atomically.
+	heap := atomic.NewUint64(0)
+	i.pushCircuitBreaker.heapBytes = heap.Load
+
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(context.Background(), i))
+	})
+	test.Poll(t, 100*time.Millisecond, 1, func() interface{} {
+		return i.lifecycler.HealthyInstancesCount()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), userID)
+	req, _, _, _ := mockWriteRequest(t, labels.Labels{{Name: labels.MetricName, Value: "test"}}, 0, util.TimeToMillis(time.Now()))
+	_, err = i.Push(ctx, req)
+	require.NoError(t, err)
+
+	// Pushes are rejected with a retryable error while the circuit breaker is open.
+	heap.Store(1000)
+	i.pushCircuitBreaker.evaluate(time.Now())
+
+	_, err = i.Push(ctx, req)
+	resp, ok := httpgrpc.HTTPResponseFromError(err)
+	require.True(t, ok)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), resp.Code)
+
+	// Pushes are accepted again once the pressure is gone and the cooldown period has elapsed.
+	heap.Store(0)
+	i.pushCircuitBreaker.evaluate(time.Now().Add(cfg.PushCircuitBreaker.CooldownPeriod))
+
+	_, err = i.Push(ctx, req)
+	require.NoError(t, err)
+}
+
+func TestIngester_Push_CircuitBreakerShouldNotObserveRequestsRejectedByInstanceLimits(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.PushCircuitBreaker.Enabled = true
+	cfg.PushCircuitBreaker.MaxLatency = time.Millisecond
+	cfg.InstanceLimitsFn = func() *InstanceLimits {
+		return &InstanceLimits{MaxInMemoryTenants: 1}
+	}
+
+	i, err := prepareIngesterWithBlocksStorage(t, cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(context.Background(), i))
+	})
+	test.Poll(t, 100*time.Millisecond, 1, func() interface{} {
+		return i.lifecycler.HealthyInstancesCount()
+	})
+
+	req, _, _, _ := mockWriteRequest(t, labels.Labels{{Name: labels.MetricName, Value: "test"}}, 0, util.TimeToMillis(time.Now()))
+	_, err = i.Push(user.InjectOrgID(context.Background(), userID), req)
+	require.NoError(t, err)
+
+	// Each of the other tenants exceeds the max in-memory tenants instance limit. Even if rejected requests are
+	// likely to be faster than the latency threshold, they must not be observed by the circuit breaker.
+	for n := 0; n < 2*pushCircuitBreakerMinObservations; n++ {
+		_, err = i.Push(user.InjectOrgID(context.Background(), fmt.Sprintf("other-%d", n)), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errMaxUsersLimitReached.Error())
+	}
+
+	i.pushCircuitBreaker.evaluate(time.Now())
+	require.NoError(t, i.pushCircuitBreaker.allow())
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/segmentio/fasthash/fnv1a"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+const (
+	// seriesChurnBucketDuration is the granularity of the series churn sliding window.
+	seriesChurnBucketDuration = 5 * time.Minute
+	seriesChurnBuckets        = 12
+
+	// seriesChurnMaxWindow is the longest window over which the series churn is tracked.
+	seriesChurnMaxWindow = seriesChurnBucketDuration * seriesChurnBuckets
+
+	numSeriesChurnShards = 128
+)
+
+type seriesChurnBucket struct {
+	// id is the number of bucket durations elapsed since the epoch, used to detect stale buckets.
+	id      int64
+	created uint32
+	removed uint32
+}
+
+type seriesChurnCounters struct {
+	buckets [seriesChurnBuckets]seriesChurnBucket
+}
+
+// bucket returns the bucket for the input bucket ID, resetting it if it holds stale counts.
+func (c *seriesChurnCounters) bucket(id int64) *seriesChurnBucket {
+	b := &c.buckets[id%seriesChurnBuckets]
+	if b.id != id {
+		*b = seriesChurnBucket{id: id}
+	}
+	return b
+}
+
+// sum returns the number of series created and removed in the buckets newer than minID.
+func (c *seriesChurnCounters) sum(minID int64) (created, removed uint64) {
+	for _, b := range c.buckets {
+		if b.id > minID {
+			created += uint64(b.created)
+			removed += uint64(b.removed)
+		}
+	}
+	return
+}
+
+type seriesChurnShard struct {
+	mtx sync.Mutex
+	m   map[string]*seriesChurnCounters
+}
+
+// seriesChurnTracker tracks the number of series created and removed for each metric name,
+// over a sliding window made of fixed-size time buckets.
+type seriesChurnTracker struct {
+	shards []seriesChurnShard
+
+	// Metric names currently exposed in the series churn metrics.
+	exposedMtx         sync.Mutex
+	exposedMetricNames []string
+}
+
+func newSeriesChurnTracker() *seriesChurnTracker {
+	shards := make([]seriesChurnShard, 0, numSeriesChurnShards)
+	for i := 0; i < numSeriesChurnShards; i++ {
+		shards = append(shards, seriesChurnShard{
+			m: map[string]*seriesChurnCounters{},
+		})
+	}
+	return &seriesChurnTracker{shards: shards}
+}
+
+func seriesChurnBucketID(t time.Time) int64 {
+	return t.UnixNano() / int64(seriesChurnBucketDuration)
+}
+
+func (t *seriesChurnTracker) getShard(metricName string) *seriesChurnShard {
+	return &t.shards[hashFP(model.Fingerprint(fnv1a.HashString64(metricName)))%numSeriesChurnShards]
+}
+
+func (t *seriesChurnTracker) seriesCreated(metricName string, now time.Time) {
+	shard := t.getShard(metricName)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	c, ok := shard.m[metricName]
+	if !ok {
+		c = &seriesChurnCounters{}
+		shard.m[metricName] = c
+	}
+	c.bucket(seriesChurnBucketID(now)).created++
+}
+
+func (t *seriesChurnTracker) seriesRemoved(metricName string, now time.Time) {
+	shard := t.getShard(metricName)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	c, ok := shard.m[metricName]
+	if !ok {
+		c = &seriesChurnCounters{}
+		shard.m[metricName] = c
+	}
+	c.bucket(seriesChurnBucketID(now)).removed++
+}
+
+// churn returns the number of series created and removed for each metric name over the input
+// window, rounded up to the bucket duration and capped to seriesChurnMaxWindow. Metric names
+// without any series created or removed in the window are not returned.
+func (t *seriesChurnTracker) churn(now time.Time, window time.Duration) []*client.MetricSeriesChurn {
+	minID := seriesChurnBucketID(now) - seriesChurnWindowBuckets(window)
+
+	var result []*client.MetricSeriesChurn
+	for i := range t.shards {
+		shard := &t.shards[i]
+		shard.mtx.Lock()
+		for name, c := range shard.m {
+			created, removed := c.sum(minID)
+			if created == 0 && removed == 0 {
+				continue
+			}
+			result = append(result, &client.MetricSeriesChurn{MetricName: name, CreatedSeries: created, RemovedSeries: removed})
+		}
+		shard.mtx.Unlock()
+	}
+	return result
+}
+
+// purge removes the metric names without any series created or removed over seriesChurnMaxWindow.
+func (t *seriesChurnTracker) purge(now time.Time) {
+	minID := seriesChurnBucketID(now) - seriesChurnBuckets
+
+	for i := range t.shards {
+		shard := &t.shards[i]
+		shard.mtx.Lock()
+		for name, c := range shard.m {
+			if created, removed := c.sum(minID); created == 0 && removed == 0 {
+				delete(shard.m, name)
+			}
+		}
+		shard.mtx.Unlock()
+	}
+}
+
+// top returns the topN metric names with the highest series churn over seriesChurnMaxWindow.
+func (t *seriesChurnTracker) top(now time.Time, topN int) []*client.MetricSeriesChurn {
+	items := t.churn(now, seriesChurnMaxWindow)
+	sortMetricSeriesChurn(items)
+	if len(items) > topN {
+		items = items[:topN]
+	}
+	return items
+}
+
+// swapExposedMetricNames stores the metric names currently exposed in the series churn metrics
+// and returns the previously exposed ones.
+func (t *seriesChurnTracker) swapExposedMetricNames(names []string) []string {
+	t.exposedMtx.Lock()
+	defer t.exposedMtx.Unlock()
+
+	prev := t.exposedMetricNames
+	t.exposedMetricNames = names
+	return prev
+}
+
+// seriesChurnWindowBuckets returns the number of buckets covering the input window.
+func seriesChurnWindowBuckets(window time.Duration) int64 {
+	buckets := int64((window + seriesChurnBucketDuration - 1) / seriesChurnBucketDuration)
+	if buckets < 1 {
+		return 1
+	}
+	if buckets > seriesChurnBuckets {
+		return seriesChurnBuckets
+	}
+	return buckets
+}
+
+// sortMetricSeriesChurn sorts the input items by the sum of created and removed series in
+// descending order, and by metric name in ascending order.
+func sortMetricSeriesChurn(items []*client.MetricSeriesChurn) {
+	sort.Slice(items, func(i, j int) bool {
+		ci := items[i].CreatedSeries + items[i].RemovedSeries
+		cj := items[j].CreatedSeries + items[j].RemovedSeries
+		if ci != cj {
+			return ci > cj
+		}
+		return items[i].MetricName < items[j].MetricName
+	})
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+func TestSeriesChurnTracker(t *testing.T) {
+	now := time.Unix(0, 0).Add(100 * seriesChurnBucketDuration)
+	tracker := newSeriesChurnTracker()
+
+	// 2 buckets ago.
+	tracker.seriesCreated("metric_a", now.Add(-2*seriesChurnBucketDuration))
+	tracker.seriesCreated("metric_b", now.Add(-2*seriesChurnBucketDuration))
+	// Current bucket.
+	tracker.seriesCreated("metric_a", now)
+	tracker.seriesCreated("metric_a", now)
+	tracker.seriesRemoved("metric_b", now)
+
+	assert.ElementsMatch(t, []*client.MetricSeriesChurn{
+		{MetricName: "metric_a", CreatedSeries: 2},
+		{MetricName: "metric_b", RemovedSeries: 1},
+	}, tracker.churn(now, seriesChurnBucketDuration))
+
+	assert.ElementsMatch(t, []*client.MetricSeriesChurn{
+		{MetricName: "metric_a", CreatedSeries: 3},
+		{MetricName: "metric_b", CreatedSeries: 1, RemovedSeries: 1},
+	}, tracker.churn(now, time.Hour))
+
+	// The window is capped to the max window.
+	assert.ElementsMatch(t, tracker.churn(now, time.Hour), tracker.churn(now, 24*time.Hour))
+
+	assert.Equal(t, []*client.MetricSeriesChurn{
+		{MetricName: "metric_a", CreatedSeries: 3},
+	}, tracker.top(now, 1))
+
+	// When the bucket slot is reused, the stale counts are reset.
+	later := now.Add(seriesChurnMaxWindow)
+	tracker.seriesCreated("metric_a", later)
+	assert.ElementsMatch(t, []*client.MetricSeriesChurn{
+		{MetricName: "metric_a", CreatedSeries: 1},
+	}, tracker.churn(later, time.Hour))
+
+	// The metric names without churn in the max window are purged.
+	tracker.purge(later)
+	assert.Len(t, tracker.getShard("metric_a").m, 1)
+	assert.Len(t, tracker.getShard("metric_b").m, 0)
+}
+
+func TestIngester_SeriesChurn(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.SeriesChurnTrackingEnabled = true
+	cfg.SeriesChurnMetricsTopN = 1
+
+	registry := prometheus.NewRegistry()
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, registry)
+
+	ctx := pushSeriesToIngester(t, []series{
+		{lbls: labels.FromStrings(labels.MetricName, "metric_0", "pod", "a"), value: 1, timestamp: 100000},
+		{lbls: labels.FromStrings(labels.MetricName, "metric_0", "pod", "b"), value: 1, timestamp: 100000},
+		{lbls: labels.FromStrings(labels.MetricName, "metric_1"), value: 1, timestamp: 100000},
+	}, i)
+
+	res, err := i.SeriesChurn(ctx, &client.SeriesChurnRequest{WindowMs: time.Hour.Milliseconds()})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*client.MetricSeriesChurn{
+		{MetricName: "metric_0", CreatedSeries: 2},
+		{MetricName: "metric_1", CreatedSeries: 1},
+	}, res.Items)
+
+	// Compacting the head removes the series from memory.
+	i.compactBlocks(context.Background(), true, nil)
+
+	res, err = i.SeriesChurn(ctx, &client.SeriesChurnRequest{WindowMs: time.Hour.Milliseconds()})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*client.MetricSeriesChurn{
+		{MetricName: "metric_0", CreatedSeries: 2, RemovedSeries: 2},
+		{MetricName: "metric_1", CreatedSeries: 1, RemovedSeries: 1},
+	}, res.Items)
+
+	// Only the metric name with the highest churn is exposed in the metrics.
+	i.updateSeriesChurn(time.Now())
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+		# HELP cortex_ingester_series_churn_created_series Number of series created over the last hour, for the metric names with the highest series churn per user.
+		# TYPE cortex_ingester_series_churn_created_series gauge
+		cortex_ingester_series_churn_created_series{metric="metric_0",user="test"} 2
+		# HELP cortex_ingester_series_churn_removed_series Number of series removed over the last hour, for the metric names with the highest series churn per user.
+		# TYPE cortex_ingester_series_churn_removed_series gauge
+		cortex_ingester_series_churn_removed_series{metric="metric_0",user="test"} 2
+	`), "cortex_ingester_series_churn_created_series", "cortex_ingester_series_churn_removed_series"))
+}
+
+func TestIngester_SeriesChurn_Disabled(t *testing.T) {
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+
+	_, err := i.SeriesChurn(context.Background(), &client.SeriesChurnRequest{})
+	require.Equal(t, errSeriesChurnTrackingDisabled, err)
+}
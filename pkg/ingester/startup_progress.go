@@ -0,0 +1,379 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	_ "embed" // Used to embed html template
+	"fmt"
+	"html/template"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/tsdb/wal"
+	"go.uber.org/atomic"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+// Phases of a tenant's TSDB while the existing TSDBs are opened on startup.
+const (
+	tsdbStartupWaiting       = "waiting"
+	tsdbStartupLoadingChunks = "loading chunks"
+	tsdbStartupReplayingWAL  = "replaying WAL"
+	tsdbStartupCompacting    = "compacting"
+	tsdbStartupDone          = "done"
+	tsdbStartupFailed        = "failed"
+)
+
+var tsdbStartupPhases = []string{tsdbStartupWaiting, tsdbStartupLoadingChunks, tsdbStartupReplayingWAL, tsdbStartupCompacting, tsdbStartupDone, tsdbStartupFailed}
+
+//go:embed startup_progress.gohtml
+var startupProgressPageHTML string
+var startupProgressTemplate = template.Must(template.New("webpage").Parse(startupProgressPageHTML))
+
+// StartupProgress is the progress of opening the existing TSDBs on startup, returned by StartupProgressHandler.
+type StartupProgress struct {
+	Now               time.Time               `json:"now"`
+	InProgress        bool                    `json:"in_progress"`
+	Duration          time.Duration           `json:"duration"`
+	WALReplayProgress float64                 `json:"wal_replay_progress"`
+	Tenants           []TenantStartupProgress `json:"tenants"`
+}
+
+// TenantStartupProgress is the progress of opening the TSDB of a single tenant on startup.
+type TenantStartupProgress struct {
+	UserID                 string        `json:"user"`
+	Phase                  string        `json:"phase"`
+	Duration               time.Duration `json:"duration"`
+	WALSegmentsReplayed    int           `json:"wal_segments_replayed"`
+	WALSegmentsTotal       int           `json:"wal_segments_total"`
+	SeriesLoaded           uint64        `json:"series_loaded"`
+	CheckpointLoadDuration time.Duration `json:"checkpoint_load_duration"`
+}
+
+// WALReplayPercentage returns the percentage of WAL segments replayed across all tenants.
+func (s StartupProgress) WALReplayPercentage() int {
+	return int(s.WALReplayProgress * 100)
+}
+
+// StartupProgressHandler shows the progress of opening the existing TSDBs on startup, per tenant.
+func (i *Ingester) StartupProgressHandler(w http.ResponseWriter, r *http.Request) {
+	util.RenderHTTPResponse(w, i.startupProgress.status(time.Now()), startupProgressTemplate, r)
+}
+
+// StartupStatus returns the WAL replay progress while the existing TSDBs are opened on startup,
+// or an empty string otherwise.
+func (i *Ingester) StartupStatus() string {
+	return i.startupProgress.readinessMessage()
+}
+
+// startupProgress tracks the progress of opening the existing TSDBs on startup.
+type startupProgress struct {
+	mtx        sync.Mutex
+	inProgress bool
+	startedAt  time.Time
+	finishedAt time.Time
+	tenants    map[string]*tenantStartupProgress
+
+	walReplayProgressDesc       *prometheus.Desc
+	tenantsDesc                 *prometheus.Desc
+	tenantWALReplayProgressDesc *prometheus.Desc
+	tenantSeriesLoadedDesc      *prometheus.Desc
+}
+
+func newStartupProgress(reg prometheus.Registerer) *startupProgress {
+	p := &startupProgress{
+		tenants: map[string]*tenantStartupProgress{},
+
+		walReplayProgressDesc: prometheus.NewDesc(
+			"cortex_ingester_startup_wal_replay_progress_ratio",
+			"The ratio of WAL segments replayed across all tenants while opening the existing TSDBs on startup.",
+			nil, nil),
+		tenantsDesc: prometheus.NewDesc(
+			"cortex_ingester_startup_tenants",
+			"Number of tenants by phase while opening the existing TSDBs on startup.",
+			[]string{"phase"}, nil),
+		tenantWALReplayProgressDesc: prometheus.NewDesc(
+			"cortex_ingester_startup_tenant_wal_replay_progress_ratio",
+			"The ratio of WAL segments replayed per tenant while opening the existing TSDBs on startup. Only exposed during startup.",
+			[]string{"user"}, nil),
+		tenantSeriesLoadedDesc: prometheus.NewDesc(
+			"cortex_ingester_startup_tenant_series_loaded",
+			"Number of series loaded per tenant while opening the existing TSDBs on startup. Only exposed during startup.",
+			[]string{"user"}, nil),
+	}
+
+	if reg != nil {
+		reg.MustRegister(p)
+	}
+	return p
+}
+
+func (p *startupProgress) start(now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.inProgress = true
+	p.startedAt = now
+	p.tenants = map[string]*tenantStartupProgress{}
+}
+
+func (p *startupProgress) finish(now time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.inProgress = false
+	p.finishedAt = now
+}
+
+// addTenant starts tracking the TSDB of a tenant, stored in the input directory, which is waiting to be opened.
+func (p *startupProgress) addTenant(userID, dir string) *tenantStartupProgress {
+	first, last := walSegmentsToReplay(filepath.Join(dir, "wal"))
+	t := &tenantStartupProgress{
+		phase:         tsdbStartupWaiting,
+		firstSegment:  first,
+		segmentsTotal: last - first + 1,
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.tenants[userID] = t
+	return t
+}
+
+func (p *startupProgress) getTenant(userID string) *tenantStartupProgress {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.tenants[userID]
+}
+
+func (p *startupProgress) status(now time.Time) StartupProgress {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	res := StartupProgress{
+		Now:        now,
+		InProgress: p.inProgress,
+		Tenants:    make([]TenantStartupProgress, 0, len(p.tenants)),
+	}
+	if !p.startedAt.IsZero() {
+		if p.inProgress {
+			res.Duration = now.Sub(p.startedAt)
+		} else {
+			res.Duration = p.finishedAt.Sub(p.startedAt)
+		}
+	}
+
+	for userID, t := range p.tenants {
+		res.Tenants = append(res.Tenants, t.status(userID, now))
+	}
+	sort.Slice(res.Tenants, func(i, j int) bool {
+		return res.Tenants[i].UserID < res.Tenants[j].UserID
+	})
+
+	res.WALReplayProgress = p.walReplayProgress(res.Tenants)
+	return res
+}
+
+// walReplayProgress must be called with the mutex held.
+func (p *startupProgress) walReplayProgress(tenants []TenantStartupProgress) float64 {
+	replayed, total := 0, 0
+	for _, t := range tenants {
+		replayed += t.WALSegmentsReplayed
+		total += t.WALSegmentsTotal
+	}
+
+	if total == 0 {
+		if p.inProgress {
+			return 0
+		}
+		return 1
+	}
+	return float64(replayed) / float64(total)
+}
+
+// readinessMessage returns the WAL replay progress to report in the readiness check,
+// or an empty string if the existing TSDBs are not being opened.
+func (p *startupProgress) readinessMessage() string {
+	s := p.status(time.Now())
+	if !s.InProgress {
+		return ""
+	}
+	return fmt.Sprintf("replaying WAL: %d%%", s.WALReplayPercentage())
+}
+
+func (p *startupProgress) Describe(out chan<- *prometheus.Desc) {
+	out <- p.walReplayProgressDesc
+	out <- p.tenantsDesc
+	out <- p.tenantWALReplayProgressDesc
+	out <- p.tenantSeriesLoadedDesc
+}
+
+func (p *startupProgress) Collect(out chan<- prometheus.Metric) {
+	s := p.status(time.Now())
+
+	out <- prometheus.MustNewConstMetric(p.walReplayProgressDesc, prometheus.GaugeValue, s.WALReplayProgress)
+
+	byPhase := make(map[string]int, len(tsdbStartupPhases))
+	for _, t := range s.Tenants {
+		byPhase[t.Phase]++
+	}
+	for _, phase := range tsdbStartupPhases {
+		out <- prometheus.MustNewConstMetric(p.tenantsDesc, prometheus.GaugeValue, float64(byPhase[phase]), phase)
+	}
+
+	// The per-tenant progress is only exposed during startup, to not keep exposing
+	// a series for each tenant for the whole lifetime of the ingester.
+	if !s.InProgress {
+		return
+	}
+	for _, t := range s.Tenants {
+		ratio := 1.0
+		if t.WALSegmentsTotal > 0 {
+			ratio = float64(t.WALSegmentsReplayed) / float64(t.WALSegmentsTotal)
+		}
+		out <- prometheus.MustNewConstMetric(p.tenantWALReplayProgressDesc, prometheus.GaugeValue, ratio, t.UserID)
+		out <- prometheus.MustNewConstMetric(p.tenantSeriesLoadedDesc, prometheus.GaugeValue, float64(t.SeriesLoaded), t.UserID)
+	}
+}
+
+// tenantStartupProgress tracks the progress of opening the TSDB of a single tenant on startup.
+type tenantStartupProgress struct {
+	seriesLoaded atomic.Uint64
+
+	mtx                    sync.Mutex
+	phase                  string
+	startedAt              time.Time
+	finishedAt             time.Time
+	firstSegment           int
+	segmentsReplayed       int
+	segmentsTotal          int
+	walReplayStartedAt     time.Time
+	checkpointLoadDuration time.Duration
+}
+
+func (t *tenantStartupProgress) setPhase(phase string, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	switch phase {
+	case tsdbStartupLoadingChunks:
+		t.startedAt = now
+	case tsdbStartupReplayingWAL:
+		t.walReplayStartedAt = now
+	case tsdbStartupCompacting, tsdbStartupDone:
+		// The WAL has been fully replayed, even if some segments were skipped (eg. replayed from a snapshot).
+		t.segmentsReplayed = t.segmentsTotal
+	}
+	if phase == tsdbStartupDone || phase == tsdbStartupFailed {
+		t.finishedAt = now
+	}
+	t.phase = phase
+}
+
+func (t *tenantStartupProgress) segmentLoaded(segment, maxSegment int) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	// The WAL may have been written between the listing of segments and the replay.
+	if total := maxSegment - t.firstSegment + 1; total > t.segmentsTotal {
+		t.segmentsTotal = total
+	}
+	if replayed := segment - t.firstSegment + 1; replayed > t.segmentsReplayed {
+		t.segmentsReplayed = replayed
+	}
+}
+
+func (t *tenantStartupProgress) checkpointLoaded(now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.checkpointLoadDuration = now.Sub(t.walReplayStartedAt)
+}
+
+func (t *tenantStartupProgress) isFinished() bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.phase == tsdbStartupDone || t.phase == tsdbStartupFailed
+}
+
+func (t *tenantStartupProgress) status(userID string, now time.Time) TenantStartupProgress {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	res := TenantStartupProgress{
+		UserID:                 userID,
+		Phase:                  t.phase,
+		WALSegmentsReplayed:    t.segmentsReplayed,
+		WALSegmentsTotal:       t.segmentsTotal,
+		SeriesLoaded:           t.seriesLoaded.Load(),
+		CheckpointLoadDuration: t.checkpointLoadDuration,
+	}
+	if !t.startedAt.IsZero() {
+		if t.finishedAt.IsZero() {
+			res.Duration = now.Sub(t.startedAt)
+		} else {
+			res.Duration = t.finishedAt.Sub(t.startedAt)
+		}
+	}
+	return res
+}
+
+// walReplayProgressLogger tracks the progress of a TSDB's WAL replay from the logs of the TSDB,
+// which doesn't expose it otherwise.
+type walReplayProgressLogger struct {
+	log.Logger
+	progress *tenantStartupProgress
+}
+
+func (l walReplayProgressLogger) Log(keyvals ...interface{}) error {
+	if !l.progress.isFinished() {
+		l.observe(keyvals)
+	}
+	return l.Logger.Log(keyvals...)
+}
+
+func (l walReplayProgressLogger) observe(keyvals []interface{}) {
+	switch logValue(keyvals, "msg") {
+	case "Replaying on-disk memory mappable chunks if any":
+		l.progress.setPhase(tsdbStartupLoadingChunks, time.Now())
+	case "Replaying WAL, this may take a while":
+		l.progress.setPhase(tsdbStartupReplayingWAL, time.Now())
+	case "WAL checkpoint loaded":
+		l.progress.checkpointLoaded(time.Now())
+	case "WAL segment loaded":
+		segment, segmentOK := logValue(keyvals, "segment").(int)
+		maxSegment, maxSegmentOK := logValue(keyvals, "maxSegment").(int)
+		if segmentOK && maxSegmentOK {
+			l.progress.segmentLoaded(segment, maxSegment)
+		}
+	}
+}
+
+// logValue returns the value of the input key in the log keyvals, or nil if not found.
+func logValue(keyvals []interface{}, key string) interface{} {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			return keyvals[i+1]
+		}
+	}
+	return nil
+}
+
+// walSegmentsToReplay returns the range of WAL segments replayed when the TSDB is opened, which are
+// the segments following the last checkpoint. If there are no segments, last is lower than first.
+func walSegmentsToReplay(walDir string) (first, last int) {
+	first, last, err := wal.Segments(walDir)
+	if err != nil || first < 0 {
+		return 0, -1
+	}
+
+	if _, checkpoint, err := wal.LastCheckpoint(walDir); err == nil && checkpoint+1 > first {
+		first = checkpoint + 1
+	}
+	return first, last
+}
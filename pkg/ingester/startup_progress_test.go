@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/services"
+	"github.com/grafana/dskit/test"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupProgress(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	p := newStartupProgress(reg)
+	now := time.Now()
+
+	assert.Equal(t, "", p.readinessMessage())
+
+	p.start(now)
+	assert.Equal(t, "replaying WAL: 0%", p.readinessMessage())
+
+	// The TSDB of user-1 has 4 WAL segments after the checkpoint, while the TSDB of user-2 has no WAL.
+	dir := t.TempDir()
+	createWALSegments(t, filepath.Join(dir, "user-1", "wal"), 3, 8)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "user-1", "wal", "checkpoint.00000004"), os.ModePerm))
+
+	user1 := p.addTenant("user-1", filepath.Join(dir, "user-1"))
+	p.addTenant("user-2", filepath.Join(dir, "user-2"))
+
+	// Replay the WAL of user-1 through the TSDB logs.
+	logger := level.Info(walReplayProgressLogger{Logger: log.NewNopLogger(), progress: user1})
+	require.NoError(t, logger.Log("msg", "Replaying on-disk memory mappable chunks if any"))
+	require.NoError(t, logger.Log("msg", "Replaying WAL, this may take a while"))
+	user1.seriesLoaded.Add(10)
+	require.NoError(t, logger.Log("msg", "WAL checkpoint loaded"))
+	require.NoError(t, logger.Log("msg", "WAL segment loaded", "segment", 5, "maxSegment", 8))
+	require.NoError(t, logger.Log("msg", "WAL segment loaded", "segment", 6, "maxSegment", 8))
+
+	status := p.status(now)
+	require.True(t, status.InProgress)
+	require.Len(t, status.Tenants, 2)
+	assert.Equal(t, "user-1", status.Tenants[0].UserID)
+	assert.Equal(t, tsdbStartupReplayingWAL, status.Tenants[0].Phase)
+	assert.Equal(t, 2, status.Tenants[0].WALSegmentsReplayed)
+	assert.Equal(t, 4, status.Tenants[0].WALSegmentsTotal)
+	assert.Equal(t, uint64(10), status.Tenants[0].SeriesLoaded)
+	assert.Equal(t, TenantStartupProgress{UserID: "user-2", Phase: tsdbStartupWaiting}, status.Tenants[1])
+	assert.Equal(t, "replaying WAL: 50%", p.readinessMessage())
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_ingester_startup_tenant_series_loaded Number of series loaded per tenant while opening the existing TSDBs on startup. Only exposed during startup.
+		# TYPE cortex_ingester_startup_tenant_series_loaded gauge
+		cortex_ingester_startup_tenant_series_loaded{user="user-1"} 10
+		cortex_ingester_startup_tenant_series_loaded{user="user-2"} 0
+
+		# HELP cortex_ingester_startup_tenant_wal_replay_progress_ratio The ratio of WAL segments replayed per tenant while opening the existing TSDBs on startup. Only exposed during startup.
+		# TYPE cortex_ingester_startup_tenant_wal_replay_progress_ratio gauge
+		cortex_ingester_startup_tenant_wal_replay_progress_ratio{user="user-1"} 0.5
+		cortex_ingester_startup_tenant_wal_replay_progress_ratio{user="user-2"} 1
+
+		# HELP cortex_ingester_startup_wal_replay_progress_ratio The ratio of WAL segments replayed across all tenants while opening the existing TSDBs on startup.
+		# TYPE cortex_ingester_startup_wal_replay_progress_ratio gauge
+		cortex_ingester_startup_wal_replay_progress_ratio 0.5
+	`), "cortex_ingester_startup_tenant_series_loaded", "cortex_ingester_startup_tenant_wal_replay_progress_ratio", "cortex_ingester_startup_wal_replay_progress_ratio"))
+
+	// Once the TSDB has been opened, its WAL is fully replayed.
+	user1.setPhase(tsdbStartupDone, now)
+	assert.Equal(t, "replaying WAL: 100%", p.readinessMessage())
+
+	// The series loaded after the TSDB has been opened are not tracked.
+	require.NoError(t, logger.Log("msg", "Replaying WAL, this may take a while"))
+	assert.Equal(t, tsdbStartupDone, p.status(now).Tenants[0].Phase)
+
+	// The per-tenant metrics are not exposed once done.
+	p.finish(now)
+	assert.Equal(t, "", p.readinessMessage())
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_ingester_startup_wal_replay_progress_ratio The ratio of WAL segments replayed across all tenants while opening the existing TSDBs on startup.
+		# TYPE cortex_ingester_startup_wal_replay_progress_ratio gauge
+		cortex_ingester_startup_wal_replay_progress_ratio 1
+	`), "cortex_ingester_startup_tenant_series_loaded", "cortex_ingester_startup_tenant_wal_replay_progress_ratio", "cortex_ingester_startup_wal_replay_progress_ratio"))
+}
+
+func TestWALReplayProgressLogger_MultipleSegments(t *testing.T) {
+	dir := t.TempDir()
+
+	// Write a WAL spanning multiple segments, using the smallest allowed segment size.
+	opts := tsdb.DefaultOptions()
+	opts.WALSegmentSize = 32 * 1024
+
+	db, err := tsdb.Open(dir, log.NewNopLogger(), nil, opts, nil)
+	require.NoError(t, err)
+
+	const numSeries = 2000
+	app := db.Appender(context.Background())
+	for s := 0; s < numSeries; s++ {
+		_, err := app.Append(0, labels.FromStrings(labels.MetricName, "metric", "series", fmt.Sprintf("series-with-a-long-label-value-%d", s)), 1000, 1)
+		require.NoError(t, err)
+	}
+	require.NoError(t, app.Commit())
+	require.NoError(t, db.Close())
+
+	first, last := walSegmentsToReplay(filepath.Join(dir, "wal"))
+	require.GreaterOrEqual(t, last-first+1, 3)
+
+	p := newStartupProgress(prometheus.NewPedanticRegistry())
+	p.start(time.Now())
+	progress := p.addTenant("user-1", dir)
+
+	// Record the number of WAL segments replayed, as tracked when each segment is loaded.
+	var replayed []int
+	recorder := log.LoggerFunc(func(keyvals ...interface{}) error {
+		if logValue(keyvals, "msg") == "WAL segment loaded" {
+			replayed = append(replayed, progress.status("user-1", time.Now()).WALSegmentsReplayed)
+		}
+		return nil
+	})
+
+	db, err = tsdb.Open(dir, walReplayProgressLogger{Logger: recorder, progress: progress}, nil, opts, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	assert.Equal(t, uint64(numSeries), db.Head().NumSeries())
+
+	// The progress is tracked segment by segment. The TSDB cuts a new empty segment when opening the WAL,
+	// before the replay, so the segments replayed are one more than the segments listed before opening it.
+	segmentsTotal := last - first + 2
+	expected := make([]int, 0, segmentsTotal)
+	for s := 1; s <= segmentsTotal; s++ {
+		expected = append(expected, s)
+	}
+	assert.Equal(t, expected, replayed)
+
+	status := progress.status("user-1", time.Now())
+	assert.Equal(t, tsdbStartupReplayingWAL, status.Phase)
+	assert.Equal(t, segmentsTotal, status.WALSegmentsTotal)
+	assert.Equal(t, segmentsTotal, status.WALSegmentsReplayed)
+}
+
+func TestWALSegmentsToReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	first, last := walSegmentsToReplay(filepath.Join(dir, "not-existing"))
+	assert.Equal(t, 0, last-first+1)
+
+	walDir := filepath.Join(dir, "wal")
+	createWALSegments(t, walDir, 2, 5)
+	first, last = walSegmentsToReplay(walDir)
+	assert.Equal(t, 2, first)
+	assert.Equal(t, 5, last)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(walDir, "checkpoint.00000003"), os.ModePerm))
+	first, last = walSegmentsToReplay(walDir)
+	assert.Equal(t, 4, first)
+	assert.Equal(t, 5, last)
+}
+
+func TestIngester_StartupProgress(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	dataDir := t.TempDir()
+
+	newIngester := func(reg prometheus.Registerer) *Ingester {
+		i, err := prepareIngesterWithBlocksStorageAndLimits(t, cfg, defaultLimitsTestConfig(), dataDir, reg)
+		require.NoError(t, err)
+		require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+		test.Poll(t, time.Second, 1, func() interface{} {
+			return i.lifecycler.HealthyInstancesCount()
+		})
+		return i
+	}
+
+	i := newIngester(nil)
+	pushSeriesToIngester(t, []series{
+		{lbls: labels.FromStrings(labels.MetricName, "metric", "pod", "a"), value: 1, timestamp: time.Now().UnixMilli()},
+		{lbls: labels.FromStrings(labels.MetricName, "metric", "pod", "b"), value: 1, timestamp: time.Now().UnixMilli()},
+	}, i)
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), i))
+
+	// The series are loaded from the WAL on restart.
+	i = newIngester(prometheus.NewPedanticRegistry())
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(context.Background(), i))
+	})
+	assert.Equal(t, "", i.StartupStatus())
+
+	req := httptest.NewRequest(http.MethodGet, "/ingester/startup-progress", nil)
+	req.Header.Set("Accept", "application/json")
+	res := httptest.NewRecorder()
+	i.StartupProgressHandler(res, req)
+	require.Equal(t, http.StatusOK, res.Code)
+
+	status := StartupProgress{}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &status))
+	assert.False(t, status.InProgress)
+	assert.Equal(t, 1.0, status.WALReplayProgress)
+	require.Len(t, status.Tenants, 1)
+	assert.Equal(t, "test", status.Tenants[0].UserID)
+	assert.Equal(t, tsdbStartupDone, status.Tenants[0].Phase)
+	assert.Equal(t, uint64(2), status.Tenants[0].SeriesLoaded)
+	assert.Equal(t, status.Tenants[0].WALSegmentsTotal, status.Tenants[0].WALSegmentsReplayed)
+	assert.NotZero(t, status.Tenants[0].WALSegmentsTotal)
+
+	// The HTML page is rendered by default.
+	res = httptest.NewRecorder()
+	i.StartupProgressHandler(res, httptest.NewRequest(http.MethodGet, "/ingester/startup-progress", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), "Existing TSDBs opened in")
+}
+
+func createWALSegments(t *testing.T, walDir string, first, last int) {
+	require.NoError(t, os.MkdirAll(walDir, os.ModePerm))
+	for s := first; s <= last; s++ {
+		require.NoError(t, os.WriteFile(filepath.Join(walDir, fmt.Sprintf("%08d", s)), nil, os.ModePerm))
+	}
+}
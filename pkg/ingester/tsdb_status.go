@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+
+	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb/index"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/util"
+)
+
+// TSDBStatus returns the statistics of the tenant's TSDB head, like the Prometheus /api/v1/status/tsdb endpoint.
+func (i *Ingester) TSDBStatus(ctx context.Context, _ *client.TSDBStatusRequest) (*client.TSDBStatusResponse, error) {
+	if err := i.checkRunning(); err != nil {
+		return nil, err
+	}
+
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return &client.TSDBStatusResponse{}, nil
+	}
+
+	chunkCount, err := db.headChunksCount()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := db.Head().Stats(labels.MetricName)
+	return &client.TSDBStatusResponse{
+		NumSeries:                   stats.NumSeries,
+		NumLabelPairs:               uint64(stats.IndexPostingStats.NumLabelPairs),
+		ChunkCount:                  chunkCount,
+		MinTime:                     stats.MinTime,
+		MaxTime:                     stats.MaxTime,
+		SeriesCountByMetricName:     toTSDBStatItems(stats.IndexPostingStats.CardinalityMetricsStats),
+		LabelValueCountByLabelName:  toTSDBStatItems(stats.IndexPostingStats.CardinalityLabelStats),
+		MemoryInBytesByLabelName:    toTSDBStatItems(stats.IndexPostingStats.LabelValueStats),
+		SeriesCountByLabelValuePair: toTSDBStatItems(stats.IndexPostingStats.LabelValuePairsStats),
+	}, nil
+}
+
+func toTSDBStatItems(stats []index.Stat) []client.TSDBStatItem {
+	items := make([]client.TSDBStatItem, 0, len(stats))
+	for _, s := range stats {
+		items = append(items, client.TSDBStatItem{Name: s.Name, Value: s.Count})
+	}
+	return items
+}
+
+// headChunksCount returns the number of chunks in the TSDB head, which is only exposed by the TSDB metrics.
+func (u *userTSDB) headChunksCount() (uint64, error) {
+	families, err := u.tsdbMetricsGatherer.Gather()
+	if err != nil {
+		return 0, err
+	}
+
+	mfm, err := util.NewMetricFamilyMap(families)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(mfm.SumGauges("prometheus_tsdb_head_chunks")), nil
+}
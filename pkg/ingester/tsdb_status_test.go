@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+func TestIngester_TSDBStatus(t *testing.T) {
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+
+	// The TSDB of the tenant doesn't exist yet.
+	res, err := i.TSDBStatus(user.InjectOrgID(context.Background(), "test"), &client.TSDBStatusRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &client.TSDBStatusResponse{}, res)
+
+	ctx := pushSeriesToIngester(t, []series{
+		{lbls: labels.FromStrings(labels.MetricName, "metric_0", "pod", "a"), value: 1, timestamp: 100000},
+		{lbls: labels.FromStrings(labels.MetricName, "metric_0", "pod", "b"), value: 1, timestamp: 200000},
+		{lbls: labels.FromStrings(labels.MetricName, "metric_1"), value: 1, timestamp: 300000},
+	}, i)
+
+	res, err = i.TSDBStatus(ctx, &client.TSDBStatusRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &client.TSDBStatusResponse{
+		NumSeries:     3,
+		NumLabelPairs: 4,
+		ChunkCount:    3,
+		MinTime:       100000,
+		MaxTime:       300000,
+		SeriesCountByMetricName: []client.TSDBStatItem{
+			{Name: "metric_0", Value: 2},
+			{Name: "metric_1", Value: 1},
+		},
+		LabelValueCountByLabelName: []client.TSDBStatItem{
+			{Name: labels.MetricName, Value: 2},
+			{Name: "pod", Value: 2},
+		},
+		MemoryInBytesByLabelName: []client.TSDBStatItem{
+			{Name: labels.MetricName, Value: 16},
+			{Name: "pod", Value: 2},
+		},
+		SeriesCountByLabelValuePair: []client.TSDBStatItem{
+			{Name: labels.MetricName + "=metric_0", Value: 2},
+			{Name: labels.MetricName + "=metric_1", Value: 1},
+			{Name: "pod=a", Value: 1},
+			{Name: "pod=b", Value: 1},
+		},
+	}, sortTSDBStatusItems(res))
+}
+
+// sortTSDBStatusItems sorts the items with the same value by name, because the TSDB doesn't guarantee their order.
+func sortTSDBStatusItems(res *client.TSDBStatusResponse) *client.TSDBStatusResponse {
+	for _, items := range [][]client.TSDBStatItem{res.SeriesCountByMetricName, res.LabelValueCountByLabelName, res.MemoryInBytesByLabelName, res.SeriesCountByLabelValuePair} {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Value != items[j].Value {
+				return items[i].Value > items[j].Value
+			}
+			return items[i].Name < items[j].Name
+		})
+	}
+	return res
+}
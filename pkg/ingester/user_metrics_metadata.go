@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 
 	"github.com/grafana/mimir/pkg/mimirpb"
+	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/util/validation"
 )
 
@@ -99,6 +100,25 @@ func (mm *userMetricsMetadata) toClientMetadata() []*mimirpb.MetricMetadata {
 	return r
 }
 
+// toMetricsMetadata returns a snapshot of the metadata, to be stored in the long-term storage.
+func (mm *userMetricsMetadata) toMetricsMetadata() *mimir_tsdb.MetricsMetadata {
+	mm.mtx.RLock()
+	defer mm.mtx.RUnlock()
+	r := &mimir_tsdb.MetricsMetadata{Version: mimir_tsdb.MetricsMetadataVersion1}
+	for _, set := range mm.metricToMetadata {
+		for m, lastSeen := range set {
+			r.Metadata = append(r.Metadata, mimir_tsdb.MetricMetadata{
+				Metric:   m.MetricFamilyName,
+				Type:     string(mimirpb.MetricMetadataMetricTypeToMetricType(m.GetType())),
+				Help:     m.Help,
+				Unit:     m.Unit,
+				LastSeen: lastSeen.UnixMilli(),
+			})
+		}
+	}
+	return mimir_tsdb.MergeMetricsMetadata(r)
+}
+
 type metricMetadataSet map[mimirpb.MetricMetadata]time.Time
 
 // If deadline is zero time, all metrics are purged.
@@ -7,12 +7,14 @@ package ingester
 
 import (
 	"context"
+	"math"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/oklog/ulid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb"
@@ -60,8 +62,15 @@ type userTSDB struct {
 	userID         string
 	activeSeries   *activeseries.ActiveSeries
 	seriesInMetric *metricCounter
+	seriesChurn    *seriesChurnTracker // nil if series churn tracking is disabled.
 	limiter        *Limiter
 
+	// Registry of the TSDB metrics, used to read the metrics not exposed by the TSDB API.
+	tsdbMetricsGatherer prometheus.Gatherer
+
+	// Progress of opening the TSDB on startup, set only while the WAL is replayed.
+	startupProgress *tenantStartupProgress
+
 	instanceSeriesCount *atomic.Int64 // Shared across all userTSDB instances created by ingester.
 	instanceLimitsFn    func() *InstanceLimits
 
@@ -89,6 +98,10 @@ type userTSDB struct {
 	// Cached shipped blocks.
 	shippedBlocksMtx sync.Mutex
 	shippedBlocks    map[ulid.ULID]struct{}
+
+	// Early head compaction backoff, only accessed by the ingester compaction loop.
+	earlyCompactionNextAllowed time.Time
+	earlyCompactionBackoff     time.Duration
 }
 
 // Explicitly wrapping the tsdb.DB functions that we use.
@@ -142,6 +155,12 @@ func (u *userTSDB) casState(from, to tsdbState) bool {
 
 // compactHead compacts the Head block at specified block durations avoiding a single huge block.
 func (u *userTSDB) compactHead(blockDuration int64) error {
+	return u.compactHeadUpTo(blockDuration, math.MaxInt64)
+}
+
+// compactHeadUpTo compacts the Head block at specified block durations avoiding a single huge block,
+// up to the specified max time (inclusive). The samples after the max time are kept in the Head.
+func (u *userTSDB) compactHeadUpTo(blockDuration, upToTime int64) error {
 	if !u.casState(active, forceCompacting) {
 		return errors.New("TSDB head cannot be compacted because it is not in active state (possibly being closed or blocks shipping in progress)")
 	}
@@ -155,7 +174,11 @@ func (u *userTSDB) compactHead(blockDuration int64) error {
 
 	h := u.Head()
 
-	minTime, maxTime := h.MinTime(), h.MaxTime()
+	minTime, maxTime := h.MinTime(), util_math.Min64(h.MaxTime(), upToTime)
+	if minTime > maxTime {
+		// Nothing to compact.
+		return nil
+	}
 
 	for (minTime/blockDuration)*blockDuration != (maxTime/blockDuration)*blockDuration {
 		// Data in Head spans across multiple block ranges, so we break it into blocks here.
@@ -166,12 +189,34 @@ func (u *userTSDB) compactHead(blockDuration int64) error {
 		}
 
 		// Get current min/max times after compaction.
-		minTime, maxTime = h.MinTime(), h.MaxTime()
+		minTime, maxTime = h.MinTime(), util_math.Min64(h.MaxTime(), upToTime)
+		if minTime > maxTime {
+			return nil
+		}
 	}
 
 	return u.db.CompactHead(tsdb.NewRangeHead(h, minTime, maxTime))
 }
 
+// updateEarlyCompactionBackoff sets the time after which the next early head compaction of the tenant
+// is allowed. If the last early head compaction has released some series, the tenant can be compacted
+// again after the min interval, otherwise the interval is doubled up to the max backoff.
+func (u *userTSDB) updateEarlyCompactionBackoff(now time.Time, releasedSeries bool) {
+	if releasedSeries {
+		u.earlyCompactionBackoff = earlyHeadCompactionMinInterval
+	} else {
+		u.earlyCompactionBackoff = 2 * u.earlyCompactionBackoff
+		if u.earlyCompactionBackoff < 2*earlyHeadCompactionMinInterval {
+			u.earlyCompactionBackoff = 2 * earlyHeadCompactionMinInterval
+		}
+		if u.earlyCompactionBackoff > earlyHeadCompactionMaxBackoff {
+			u.earlyCompactionBackoff = earlyHeadCompactionMaxBackoff
+		}
+	}
+
+	u.earlyCompactionNextAllowed = now.Add(u.earlyCompactionBackoff)
+}
+
 // PreCreation implements SeriesLifecycleCallback interface.
 func (u *userTSDB) PreCreation(metric labels.Labels) error {
 	if u.limiter == nil {
@@ -206,6 +251,9 @@ func (u *userTSDB) PreCreation(metric labels.Labels) error {
 // PostCreation implements SeriesLifecycleCallback interface.
 func (u *userTSDB) PostCreation(metric labels.Labels) {
 	u.instanceSeriesCount.Inc()
+	if u.startupProgress != nil {
+		u.startupProgress.seriesLoaded.Inc()
+	}
 
 	metricName, err := extract.MetricNameFromLabels(metric)
 	if err != nil {
@@ -213,12 +261,17 @@ func (u *userTSDB) PostCreation(metric labels.Labels) {
 		return
 	}
 	u.seriesInMetric.increaseSeriesForMetric(metricName)
+	if u.seriesChurn != nil {
+		u.seriesChurn.seriesCreated(metricName, time.Now())
+	}
 }
 
 // PostDeletion implements SeriesLifecycleCallback interface.
 func (u *userTSDB) PostDeletion(metrics ...labels.Labels) {
 	u.instanceSeriesCount.Sub(int64(len(metrics)))
 
+	now := time.Now()
+
 	for _, metric := range metrics {
 		metricName, err := extract.MetricNameFromLabels(metric)
 		if err != nil {
@@ -226,6 +279,9 @@ func (u *userTSDB) PostDeletion(metrics ...labels.Labels) {
 			continue
 		}
 		u.seriesInMetric.decreaseSeriesForMetric(metricName)
+		if u.seriesChurn != nil {
+			u.seriesChurn.seriesRemoved(metricName, now)
+		}
 	}
 }
 
@@ -44,6 +44,7 @@ import (
 	"github.com/grafana/mimir/pkg/flusher"
 	"github.com/grafana/mimir/pkg/frontend"
 	"github.com/grafana/mimir/pkg/frontend/querymiddleware"
+	"github.com/grafana/mimir/pkg/frontend/transport"
 	frontendv1 "github.com/grafana/mimir/pkg/frontend/v1"
 	"github.com/grafana/mimir/pkg/ingester"
 	"github.com/grafana/mimir/pkg/ingester/client"
@@ -57,6 +58,7 @@ import (
 	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storegateway"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/activitytracker"
 	util_log "github.com/grafana/mimir/pkg/util/log"
@@ -115,6 +117,7 @@ type Config struct {
 	RuntimeConfig       runtimeconfig.Config                       `yaml:"runtime_config"`
 	MemberlistKV        memberlist.KVConfig                        `yaml:"memberlist"`
 	QueryScheduler      scheduler.Config                           `yaml:"query_scheduler"`
+	UsageMetering       usagemetering.Config                       `yaml:"usage_metering"`
 }
 
 // RegisterFlags registers flag.
@@ -157,6 +160,7 @@ func (c *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	c.MemberlistKV.RegisterFlags(f)
 	c.ActivityTracker.RegisterFlags(f)
 	c.QueryScheduler.RegisterFlags(f)
+	c.UsageMetering.RegisterFlags(f, logger)
 }
 
 // Validate the mimir config and return an error if the validation
@@ -184,12 +188,18 @@ func (c *Config) Validate(log log.Logger) error {
 	if err := c.Querier.Validate(); err != nil {
 		return errors.Wrap(err, "invalid querier config")
 	}
+	if err := c.Ingester.Validate(); err != nil {
+		return errors.Wrap(err, "invalid ingester config")
+	}
 	if err := c.IngesterClient.Validate(log); err != nil {
 		return errors.Wrap(err, "invalid ingester_client config")
 	}
 	if err := c.Worker.Validate(log); err != nil {
 		return errors.Wrap(err, "invalid frontend_worker config")
 	}
+	if err := c.Frontend.Handler.Validate(); err != nil {
+		return errors.Wrap(err, "invalid query-frontend config")
+	}
 	if err := c.Frontend.QueryMiddleware.Validate(); err != nil {
 		return errors.Wrap(err, "invalid query-frontend middleware config")
 	}
@@ -205,6 +215,9 @@ func (c *Config) Validate(log log.Logger) error {
 	if err := c.Alertmanager.Validate(c.AlertmanagerStorage); err != nil {
 		return errors.Wrap(err, "invalid alertmanager config")
 	}
+	if err := c.UsageMetering.Validate(); err != nil {
+		return errors.Wrap(err, "invalid usage metering config")
+	}
 	return nil
 }
 
@@ -262,6 +275,26 @@ func (c *Config) validateBucketConfigs() error {
 		errs.Add(errors.Wrap(validateBucketConfig(c.RulerStorage.Config, c.BlocksStorage.Bucket), "ruler storage"))
 	}
 
+	// Validate the query-frontend results cache generations bucket config. The generations must be
+	// seen by all query-frontends, so the local filesystem can be used only when running a single binary.
+	if c.isAnyModuleEnabled(All, QueryFrontend) && c.Frontend.QueryMiddleware.ResultsCacheInvalidationEnabled {
+		errs.Add(errors.Wrap(validateBucketConfig(c.Frontend.QueryMiddleware.ResultsCacheGenerationsStorage, c.BlocksStorage.Bucket), "results cache generations storage"))
+
+		if c.Frontend.QueryMiddleware.ResultsCacheGenerationsStorage.Backend == bucket.Filesystem && !c.isModuleEnabled(All) {
+			errs.Add(errors.New("results cache generations storage: the filesystem backend is supported only when running Mimir as a single binary (target=all)"))
+		}
+	}
+
+	// Validate usage metering bucket config. The partial usage reports uploaded by distributors and ingesters
+	// must be seen by the compactors, so the local filesystem can be used only when running a single binary.
+	if c.UsageMetering.Enabled {
+		errs.Add(errors.Wrap(validateBucketConfig(c.UsageMetering.Storage, c.BlocksStorage.Bucket), "usage metering storage"))
+
+		if c.UsageMetering.Storage.Backend == bucket.Filesystem && !c.isModuleEnabled(All) {
+			errs.Add(errors.New("usage metering storage: the filesystem backend is supported only when running Mimir as a single binary (target=all)"))
+		}
+	}
+
 	return errs.Err()
 }
 
@@ -344,6 +377,8 @@ type Mimir struct {
 	ExemplarQueryable        prom_storage.ExemplarQueryable
 	QuerierEngine            *promql.Engine
 	QueryFrontendTripperware querymiddleware.Tripperware
+	QueryFrontendLifecycler  *ring.Lifecycler
+	QueryFrontendQueryLogger *transport.QueryLogger
 	Ruler                    *ruler.Ruler
 	RulerStorage             rulestore.RuleStore
 	Alertmanager             *alertmanager.MultitenantAlertmanager
@@ -355,6 +390,18 @@ type Mimir struct {
 
 	// Queryables that the querier should use to query the long term storage.
 	StoreQueryables []querier.QueryableWithFilter
+
+	// Queryable used to run the cardinality analysis on the long term storage.
+	BlocksCardinalityQueryable querier.BlocksCardinalityQueryable
+
+	// Queryable used to query the exemplars retained in the long term storage.
+	BlocksExemplarQueryable querier.BlocksExemplarQueryable
+
+	// Queryable used to query the metric metadata stored in the long term storage.
+	BlocksMetadataQueryable querier.BlocksMetadataQueryable
+
+	// Tracks the tenants usage, if usage metering is enabled.
+	UsageRecorder *usagemetering.Recorder
 }
 
 // New makes a new Mimir.
@@ -522,6 +569,13 @@ func (t *Mimir) readyHandler(sm *services.Manager) http.HandlerFunc {
 				msg.WriteString(fmt.Sprintf("%v: %d\n", st, len(ls)))
 			}
 
+			// Opening the existing TSDBs on startup may take a long time, so the ingester reports its progress.
+			if t.Ingester != nil {
+				if progress := t.Ingester.StartupStatus(); progress != "" {
+					msg.WriteString(fmt.Sprintf("Ingester: %s\n", progress))
+				}
+			}
+
 			http.Error(w, msg.String(), http.StatusServiceUnavailable)
 			return
 		}
@@ -41,6 +41,7 @@ import (
 	"github.com/grafana/mimir/pkg/cache"
 	"github.com/grafana/mimir/pkg/compactor"
 	"github.com/grafana/mimir/pkg/distributor"
+	"github.com/grafana/mimir/pkg/frontend"
 	"github.com/grafana/mimir/pkg/frontend/v1/frontendv1pb"
 	"github.com/grafana/mimir/pkg/ingester"
 	"github.com/grafana/mimir/pkg/ingester/activeseries"
@@ -142,6 +143,14 @@ func TestMimir(t *testing.T) {
 			ReplicationFactor:      1,
 			InstanceInterfaceNames: []string{"en0", "eth0", "lo0", "lo"},
 		}},
+		Frontend: frontend.CombinedFrontendConfig{
+			QueryFrontendRing: frontend.RingConfig{
+				KVStore: kv.Config{
+					Store: "inmemory",
+				},
+				InstanceInterfaceNames: []string{"en0", "eth0", "lo0", "lo"},
+			},
+		},
 
 		Target: []string{All, AlertManager},
 	}
@@ -321,6 +330,56 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "results cache invalidation: should pass with the filesystem backend when running a single binary",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all")
+				cfg.Frontend.QueryMiddleware.CacheResults = true
+				cfg.Frontend.QueryMiddleware.ResultsCacheConfig.Backend = cache.BackendMemcached
+				cfg.Frontend.QueryMiddleware.ResultsCacheConfig.Memcached.Addresses = "localhost"
+				cfg.Frontend.QueryMiddleware.ResultsCacheInvalidationEnabled = true
+				cfg.Frontend.QueryMiddleware.ResultsCacheGenerationsStorage.Backend = bucket.Filesystem
+				return cfg
+			},
+			expectedError: nil,
+		},
+		{
+			name: "results cache invalidation: should fail with the filesystem backend when running microservices",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("query-frontend")
+				cfg.Frontend.QueryMiddleware.CacheResults = true
+				cfg.Frontend.QueryMiddleware.ResultsCacheConfig.Backend = cache.BackendMemcached
+				cfg.Frontend.QueryMiddleware.ResultsCacheConfig.Memcached.Addresses = "localhost"
+				cfg.Frontend.QueryMiddleware.ResultsCacheInvalidationEnabled = true
+				cfg.Frontend.QueryMiddleware.ResultsCacheGenerationsStorage.Backend = bucket.Filesystem
+				return cfg
+			},
+			expectedError: errInvalidBucketConfig,
+		},
+		{
+			name: "usage metering: should pass with the filesystem backend when running a single binary",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all")
+				cfg.UsageMetering.Enabled = true
+				cfg.UsageMetering.Storage.Backend = bucket.Filesystem
+				return cfg
+			},
+			expectedError: nil,
+		},
+		{
+			name: "usage metering: should fail with the filesystem backend when running microservices",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("distributor")
+				cfg.UsageMetering.Enabled = true
+				cfg.UsageMetering.Storage.Backend = bucket.Filesystem
+				return cfg
+			},
+			expectedError: errInvalidBucketConfig,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := tc.getTestConfig().Validate(nil)
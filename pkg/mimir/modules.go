@@ -28,12 +28,14 @@ import (
 	"github.com/prometheus/prometheus/rules"
 	prom_storage "github.com/prometheus/prometheus/storage"
 	"github.com/thanos-io/thanos/pkg/discovery/dns"
+	"github.com/thanos-io/thanos/pkg/objstore"
 	httpgrpc_server "github.com/weaveworks/common/httpgrpc/server"
 	"github.com/weaveworks/common/server"
 
 	"github.com/grafana/mimir/pkg/alertmanager"
 	"github.com/grafana/mimir/pkg/alertmanager/alertstore"
 	"github.com/grafana/mimir/pkg/api"
+	"github.com/grafana/mimir/pkg/cache"
 	"github.com/grafana/mimir/pkg/compactor"
 	"github.com/grafana/mimir/pkg/distributor"
 	"github.com/grafana/mimir/pkg/flusher"
@@ -48,7 +50,9 @@ import (
 	querier_worker "github.com/grafana/mimir/pkg/querier/worker"
 	"github.com/grafana/mimir/pkg/ruler"
 	"github.com/grafana/mimir/pkg/scheduler"
+	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storegateway"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/activitytracker"
 	util_log "github.com/grafana/mimir/pkg/util/log"
@@ -76,6 +80,8 @@ const (
 	StoreQueryable           string = "store-queryable"
 	QueryFrontend            string = "query-frontend"
 	QueryFrontendTripperware string = "query-frontend-tripperware"
+	QueryFrontendRing        string = "query-frontend-ring"
+	QueryFrontendQueryLog    string = "query-frontend-query-log"
 	RulerStorage             string = "ruler-storage"
 	Ruler                    string = "ruler"
 	AlertManager             string = "alertmanager"
@@ -86,6 +92,7 @@ const (
 	Purger                   string = "purger"
 	QueryScheduler           string = "query-scheduler"
 	TenantFederation         string = "tenant-federation"
+	UsageMetering            string = "usage-metering"
 	All                      string = "all"
 )
 
@@ -266,6 +273,7 @@ func (t *Mimir) initDistributorService() (serv services.Service, err error) {
 	t.Cfg.Distributor.DistributorRing.KVStore.Multi.ConfigProvider = multiClientRuntimeConfigChannel(t.RuntimeConfig)
 	t.Cfg.Distributor.DistributorRing.ListenPort = t.Cfg.Server.GRPCListenPort
 	t.Cfg.Distributor.ShuffleShardingLookbackPeriod = t.Cfg.Querier.ShuffleShardingIngestersLookbackPeriod
+	t.Cfg.Distributor.UsageRecorder = t.UsageRecorder
 
 	// Check whether the distributor can join the distributors ring, which is
 	// whenever it's not running as an internal dependency (ie. querier or
@@ -294,6 +302,11 @@ func (t *Mimir) initQueryable() (serv services.Service, err error) {
 	// Create a querier queryable and PromQL engine
 	t.QuerierQueryable, t.ExemplarQueryable, t.QuerierEngine = querier.New(t.Cfg.Querier, t.Overrides, t.Distributor, t.StoreQueryables, querierRegisterer, util_log.Logger, t.ActivityTracker)
 
+	// Query the exemplars retained in the long-term storage too.
+	if t.BlocksExemplarQueryable != nil {
+		t.ExemplarQueryable = querier.NewExemplarQueryable(t.ExemplarQueryable, t.BlocksExemplarQueryable, t.Overrides, util_log.Logger)
+	}
+
 	// Register the default endpoints that are always enabled for the querier module
 	t.API.RegisterQueryable(t.QuerierQueryable, t.Distributor)
 
@@ -372,6 +385,8 @@ func (t *Mimir) initQuerier() (serv services.Service, err error) {
 		t.ExemplarQueryable,
 		t.QuerierEngine,
 		t.Distributor,
+		t.BlocksCardinalityQueryable,
+		t.BlocksMetadataQueryable,
 		prometheus.DefaultRegisterer,
 		util_log.Logger,
 		t.Overrides,
@@ -425,9 +440,19 @@ func (t *Mimir) initStoreQueryables() (services.Service, error) {
 		return nil, fmt.Errorf("failed to initialize querier: %v", err)
 	} else {
 		t.StoreQueryables = append(t.StoreQueryables, querier.UseAlwaysQueryable(q))
+		t.BlocksCardinalityQueryable = q
+		t.BlocksExemplarQueryable = q
 		servs = append(servs, q)
 	}
 
+	if t.Cfg.Querier.QueryStoredMetricsMetadata {
+		bkt, err := bucket.NewClient(context.Background(), t.Cfg.BlocksStorage.Bucket, "querier-metadata", util_log.Logger, prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the metrics metadata bucket client")
+		}
+		t.BlocksMetadataQueryable = querier.NewBucketMetadataQueryable(bkt, t.Overrides, t.Cfg.Querier.StoredMetricsMetadataCacheTTL, util_log.Logger)
+	}
+
 	// Return service, if any.
 	switch len(servs) {
 	case 0:
@@ -451,6 +476,7 @@ func (t *Mimir) initIngesterService() (serv services.Service, err error) {
 	t.Cfg.Ingester.IngesterRing.ListenPort = t.Cfg.Server.GRPCListenPort
 	t.Cfg.Ingester.StreamTypeFn = ingesterChunkStreaming(t.RuntimeConfig)
 	t.Cfg.Ingester.InstanceLimitsFn = ingesterInstanceLimits(t.RuntimeConfig)
+	t.Cfg.Ingester.UsageRecorder = t.UsageRecorder
 	t.tsdbIngesterConfig()
 
 	t.Ingester, err = ingester.New(t.Cfg.Ingester, t.Cfg.IngesterClient, t.Overrides, prometheus.DefaultRegisterer, util_log.Logger)
@@ -492,13 +518,42 @@ func (t *Mimir) initFlusher() (serv services.Service, err error) {
 // initQueryFrontendTripperware instantiates the tripperware used by the query frontend
 // to optimize Prometheus query requests.
 func (t *Mimir) initQueryFrontendTripperware() (serv services.Service, err error) {
+	// The PromQL engine used to execute sharded and split queries registers the same metrics of
+	// the querier and ruler ones, so we differentiate them by label when running Mimir as a single binary.
+	queryFrontendRegisterer := prometheus.WrapRegistererWith(prometheus.Labels{"engine": "query-frontend"}, prometheus.DefaultRegisterer)
+
+	var (
+		resultsCache            cache.Cache
+		resultsCacheGenerations *querymiddleware.ResultsCacheGenerations
+	)
+	if t.Cfg.Frontend.QueryMiddleware.CacheResults {
+		resultsCache, err = querymiddleware.NewResultsCache(t.Cfg.Frontend.QueryMiddleware.ResultsCacheConfig, util_log.Logger, prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if t.Cfg.Frontend.QueryMiddleware.ResultsCacheInvalidationEnabled {
+		// The results cache generations are stored in the object storage, so that they're
+		// shared by all the query-frontend replicas and never evicted like cached results.
+		bkt, err := bucket.NewClient(context.Background(), t.Cfg.Frontend.QueryMiddleware.ResultsCacheGenerationsStorage, "query-frontend-results-cache-generations", util_log.Logger, prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the results cache generations bucket client")
+		}
+
+		resultsCacheGenerations = querymiddleware.NewResultsCacheGenerations(bkt)
+		t.API.RegisterQueryFrontendResultsCacheInvalidation(querymiddleware.NewResultsCacheInvalidationHandler(resultsCacheGenerations, util_log.Logger))
+	}
+
 	tripperware, err := querymiddleware.NewTripperware(
 		t.Cfg.Frontend.QueryMiddleware,
 		util_log.Logger,
 		t.Overrides,
-		querymiddleware.PrometheusCodec,
+		querymiddleware.NewPrometheusCodec(t.Cfg.Frontend.QueryMiddleware.QueryResultResponseFormat),
+		resultsCache,
+		resultsCacheGenerations,
 		querymiddleware.PrometheusResponseExtractor{},
-		engine.NewPromQLEngineOptions(t.Cfg.Querier.EngineConfig, t.ActivityTracker, util_log.Logger, prometheus.DefaultRegisterer),
+		engine.NewPromQLEngineOptions(t.Cfg.Querier.EngineConfig, t.ActivityTracker, util_log.Logger, queryFrontendRegisterer),
 		prometheus.DefaultRegisterer,
 	)
 	if err != nil {
@@ -509,6 +564,48 @@ func (t *Mimir) initQueryFrontendTripperware() (serv services.Service, err error
 	return nil, nil
 }
 
+// initQueryFrontendRing instantiates the lifecycler used by the query-frontend to join the
+// query-frontends ring, which is used to share the per-tenant query rate limits.
+func (t *Mimir) initQueryFrontendRing() (serv services.Service, err error) {
+	if !t.Cfg.Frontend.Handler.QueryRateLimitsEnabled {
+		return nil, nil
+	}
+
+	t.Cfg.Frontend.QueryFrontendRing.KVStore.Multi.ConfigProvider = multiClientRuntimeConfigChannel(t.RuntimeConfig)
+	t.Cfg.Frontend.QueryFrontendRing.ListenPort = t.Cfg.Server.GRPCListenPort
+
+	t.QueryFrontendLifecycler, err = ring.NewLifecycler(t.Cfg.Frontend.QueryFrontendRing.ToLifecyclerConfig(), nil, frontend.RingName, frontend.RingKey, true, util_log.Logger, prometheus.WrapRegistererWithPrefix("cortex_", prometheus.DefaultRegisterer))
+	if err != nil {
+		return nil, err
+	}
+
+	return t.QueryFrontendLifecycler, nil
+}
+
+// initQueryFrontendQueryLog instantiates the query logger used by the query-frontend to write
+// the received queries to the query log, if enabled.
+func (t *Mimir) initQueryFrontendQueryLog() (serv services.Service, err error) {
+	cfg := t.Cfg.Frontend.Handler.QueryLog
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	var bkt objstore.Bucket
+	if cfg.Backend == transport.QueryLogBackendObjectStorage {
+		bkt, err = bucket.NewClient(context.Background(), t.Cfg.BlocksStorage.Bucket, "query-log", util_log.Logger, prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create the query log bucket client")
+		}
+	}
+
+	t.QueryFrontendQueryLogger, err = transport.NewQueryLogger(cfg, t.Overrides, bkt, util_log.Logger, prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.QueryFrontendQueryLogger, nil
+}
+
 func (t *Mimir) initQueryFrontend() (serv services.Service, err error) {
 	roundTripper, frontendV1, frontendV2, err := frontend.InitFrontend(t.Cfg.Frontend, t.Overrides, t.Cfg.Server.GRPCListenPort, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
@@ -518,7 +615,11 @@ func (t *Mimir) initQueryFrontend() (serv services.Service, err error) {
 	// Wrap roundtripper into Tripperware.
 	roundTripper = t.QueryFrontendTripperware(roundTripper)
 
-	handler := transport.NewHandler(t.Cfg.Frontend.Handler, roundTripper, util_log.Logger, prometheus.DefaultRegisterer)
+	var rateLimiter *transport.QueryRateLimiter
+	if t.Cfg.Frontend.Handler.QueryRateLimitsEnabled {
+		rateLimiter = transport.NewQueryRateLimiter(t.Overrides, t.QueryFrontendLifecycler, prometheus.DefaultRegisterer)
+	}
+	handler := transport.NewHandler(t.Cfg.Frontend.Handler, roundTripper, rateLimiter, t.QueryFrontendQueryLogger, util_log.Logger, prometheus.DefaultRegisterer)
 	t.API.RegisterQueryFrontendHandler(handler, t.BuildInfoHandler)
 
 	if frontendV1 != nil {
@@ -634,6 +735,7 @@ func (t *Mimir) initAlertManager() (serv services.Service, err error) {
 func (t *Mimir) initCompactor() (serv services.Service, err error) {
 	t.Cfg.Compactor.ShardingRing.KVStore.Multi.ConfigProvider = multiClientRuntimeConfigChannel(t.RuntimeConfig)
 	t.Cfg.Compactor.ShardingRing.ListenPort = t.Cfg.Server.GRPCListenPort
+	t.Cfg.Compactor.UsageRecorder = t.UsageRecorder
 
 	t.Compactor, err = compactor.NewMultitenantCompactor(t.Cfg.Compactor, t.Cfg.BlocksStorage, t.Overrides, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
@@ -660,6 +762,24 @@ func (t *Mimir) initStoreGateway() (serv services.Service, err error) {
 	return t.StoreGateway, nil
 }
 
+func (t *Mimir) initUsageMetering() (services.Service, error) {
+	if !t.Cfg.UsageMetering.Enabled {
+		return nil, nil
+	}
+
+	bkt, err := bucket.NewClient(context.Background(), t.Cfg.UsageMetering.Storage, "usage-metering", util_log.Logger, prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the usage metering bucket client")
+	}
+
+	t.UsageRecorder = usagemetering.NewRecorder(t.Cfg.UsageMetering, bkt, util_log.Logger, prometheus.DefaultRegisterer)
+
+	// Expose HTTP endpoints.
+	t.API.RegisterUsageMetering(t.UsageRecorder)
+
+	return t.UsageRecorder, nil
+}
+
 func (t *Mimir) initMemberlistKV() (services.Service, error) {
 	reg := prometheus.DefaultRegisterer
 	t.Cfg.MemberlistKV.MetricsRegisterer = reg
@@ -684,6 +804,7 @@ func (t *Mimir) initMemberlistKV() (services.Service, error) {
 	t.Cfg.Compactor.ShardingRing.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
 	t.Cfg.Ruler.Ring.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
 	t.Cfg.Alertmanager.ShardingRing.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
+	t.Cfg.Frontend.QueryFrontendRing.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
 
 	return t.MemberlistKV, nil
 }
@@ -732,6 +853,8 @@ func (t *Mimir) setupModuleManager() error {
 	mm.RegisterModule(Querier, t.initQuerier)
 	mm.RegisterModule(StoreQueryable, t.initStoreQueryables, modules.UserInvisibleModule)
 	mm.RegisterModule(QueryFrontendTripperware, t.initQueryFrontendTripperware, modules.UserInvisibleModule)
+	mm.RegisterModule(QueryFrontendRing, t.initQueryFrontendRing, modules.UserInvisibleModule)
+	mm.RegisterModule(QueryFrontendQueryLog, t.initQueryFrontendQueryLog, modules.UserInvisibleModule)
 	mm.RegisterModule(QueryFrontend, t.initQueryFrontend)
 	mm.RegisterModule(RulerStorage, t.initRulerStorage, modules.UserInvisibleModule)
 	mm.RegisterModule(Ruler, t.initRuler)
@@ -742,6 +865,7 @@ func (t *Mimir) setupModuleManager() error {
 	mm.RegisterModule(Purger, nil)
 	mm.RegisterModule(QueryScheduler, t.initQueryScheduler)
 	mm.RegisterModule(TenantFederation, t.initTenantFederation, modules.UserInvisibleModule)
+	mm.RegisterModule(UsageMetering, t.initUsageMetering, modules.UserInvisibleModule)
 	mm.RegisterModule(All, nil)
 
 	// Add dependencies
@@ -754,24 +878,27 @@ func (t *Mimir) setupModuleManager() error {
 		Overrides:                {RuntimeConfig},
 		OverridesExporter:        {Overrides},
 		Distributor:              {DistributorService, API},
-		DistributorService:       {Ring, Overrides},
+		DistributorService:       {Ring, Overrides, UsageMetering},
 		Ingester:                 {IngesterService, API},
-		IngesterService:          {Overrides, RuntimeConfig, MemberlistKV},
+		IngesterService:          {Overrides, RuntimeConfig, MemberlistKV, UsageMetering},
 		Flusher:                  {API},
 		Queryable:                {Overrides, DistributorService, Ring, API, StoreQueryable, MemberlistKV},
 		Querier:                  {TenantFederation},
 		StoreQueryable:           {Overrides, MemberlistKV},
 		QueryFrontendTripperware: {API, Overrides},
-		QueryFrontend:            {QueryFrontendTripperware},
+		QueryFrontendRing:        {API, RuntimeConfig, MemberlistKV},
+		QueryFrontendQueryLog:    {Overrides},
+		QueryFrontend:            {QueryFrontendTripperware, QueryFrontendRing, QueryFrontendQueryLog},
 		QueryScheduler:           {API, Overrides},
 		Ruler:                    {DistributorService, StoreQueryable, RulerStorage},
 		RulerStorage:             {Overrides},
 		AlertManager:             {API, MemberlistKV, Overrides},
-		Compactor:                {API, MemberlistKV, Overrides},
+		Compactor:                {API, MemberlistKV, Overrides, UsageMetering},
 		StoreGateway:             {API, Overrides, MemberlistKV},
 		TenantDeletion:           {API, Overrides},
 		Purger:                   {TenantDeletion},
 		TenantFederation:         {Queryable},
+		UsageMetering:            {API},
 		All:                      {QueryFrontend, Querier, Ingester, Distributor, Purger, StoreGateway, Ruler, Compactor},
 	}
 	for mod, targets := range deps {
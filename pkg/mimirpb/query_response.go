@@ -0,0 +1,6 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package mimirpb
+
+// QueryResponseMimeType is the media type of a QueryResponse encoded as protobuf.
+const QueryResponseMimeType = "application/vnd.mimir.queryresponse+protobuf"
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+
+	ingester_client "github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
+	util_math "github.com/grafana/mimir/pkg/util/math"
+)
+
+// BlocksCardinalityQueryable is the interface used to run the cardinality analysis on the blocks
+// in the long-term storage.
+type BlocksCardinalityQueryable interface {
+	// LabelValuesCardinality returns the estimated total number of series and the estimated number
+	// of series for each value of the requested label names, in the blocks overlapping the input time range.
+	LabelValuesCardinality(ctx context.Context, minT, maxT int64, labelNames []model.LabelName, matchers []*labels.Matcher) (uint64, *ingester_client.LabelValuesCardinalityResponse, error)
+
+	// LabelNamesAndValues returns the values of each label name, in the blocks overlapping the input time range.
+	LabelNamesAndValues(ctx context.Context, minT, maxT int64, matchers []*labels.Matcher) (*ingester_client.LabelNamesAndValuesResponse, error)
+}
+
+type blockTimeRange struct {
+	minT, maxT int64
+}
+
+// blocksLabelValuesCardinalityMerger merges the per-block label values cardinality received from
+// the store-gateways. The same series is usually stored in multiple blocks, so the number of series
+// can't be summed across blocks. It's estimated as follows:
+// - Blocks covering the same time range and having a compactor shard ID hold different series, so their counts are summed.
+// - Blocks covering the same time range without a compactor shard ID (eg. uploaded by different ingesters) may hold the same series, so the highest count is taken.
+// - Blocks covering different time ranges may hold the same series, so the highest count is taken.
+type blocksLabelValuesCardinalityMerger struct {
+	mtx    sync.Mutex
+	blocks map[string]*storegatewaypb.BlockLabelValuesCardinality
+}
+
+func newBlocksLabelValuesCardinalityMerger() *blocksLabelValuesCardinalityMerger {
+	return &blocksLabelValuesCardinalityMerger{
+		blocks: map[string]*storegatewaypb.BlockLabelValuesCardinality{},
+	}
+}
+
+func (m *blocksLabelValuesCardinalityMerger) add(blocks []*storegatewaypb.BlockLabelValuesCardinality) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	// The same block may be received from multiple store-gateways, so we deduplicate them by ID.
+	for _, b := range blocks {
+		m.blocks[b.BlockId] = b
+	}
+}
+
+func (m *blocksLabelValuesCardinalityMerger) merge() (uint64, *ingester_client.LabelValuesCardinalityResponse) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	type rangeCardinality struct {
+		shardedSeries, unshardedSeries uint64
+		sharded, unsharded             map[string]map[string]uint64
+	}
+
+	ranges := map[blockTimeRange]*rangeCardinality{}
+	for _, b := range m.blocks {
+		key := blockTimeRange{minT: b.MinTime, maxT: b.MaxTime}
+		r, ok := ranges[key]
+		if !ok {
+			r = &rangeCardinality{sharded: map[string]map[string]uint64{}, unsharded: map[string]map[string]uint64{}}
+			ranges[key] = r
+		}
+
+		if b.CompactorShardId != "" {
+			r.shardedSeries += b.SeriesCount
+			for _, item := range b.Items {
+				mergeLabelValueSeries(r.sharded, item.LabelName, item.LabelValueSeries, true)
+			}
+		} else {
+			r.unshardedSeries = util_math.MaxUint64(r.unshardedSeries, b.SeriesCount)
+			for _, item := range b.Items {
+				mergeLabelValueSeries(r.unsharded, item.LabelName, item.LabelValueSeries, false)
+			}
+		}
+	}
+
+	seriesCountTotal := uint64(0)
+	merged := map[string]map[string]uint64{}
+	for _, r := range ranges {
+		seriesCountTotal = util_math.MaxUint64(seriesCountTotal, util_math.MaxUint64(r.shardedSeries, r.unshardedSeries))
+		for name, values := range r.sharded {
+			mergeLabelValueSeries(merged, name, values, false)
+		}
+		for name, values := range r.unsharded {
+			mergeLabelValueSeries(merged, name, values, false)
+		}
+	}
+
+	items := make([]*ingester_client.LabelValueSeriesCount, 0, len(merged))
+	for name, values := range merged {
+		items = append(items, &ingester_client.LabelValueSeriesCount{LabelName: name, LabelValueSeries: values})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LabelName < items[j].LabelName
+	})
+
+	return seriesCountTotal, &ingester_client.LabelValuesCardinalityResponse{Items: items}
+}
+
+// mergeLabelValueSeries merges the series count of each label value into dst, either summing
+// the counts or taking the highest one.
+func mergeLabelValueSeries(dst map[string]map[string]uint64, labelName string, values map[string]uint64, sum bool) {
+	dstValues, ok := dst[labelName]
+	if !ok {
+		dstValues = make(map[string]uint64, len(values))
+		dst[labelName] = dstValues
+	}
+
+	for value, count := range values {
+		if sum {
+			dstValues[value] += count
+		} else {
+			dstValues[value] = util_math.MaxUint64(dstValues[value], count)
+		}
+	}
+}
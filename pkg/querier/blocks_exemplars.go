@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/tenant"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"golang.org/x/sync/errgroup"
+
+	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
+	"github.com/grafana/mimir/pkg/util"
+	"github.com/grafana/mimir/pkg/util/spanlogger"
+)
+
+// BlocksExemplarQueryable is the interface used to query the exemplars stored in the long-term storage.
+type BlocksExemplarQueryable interface {
+	// Exemplars returns the exemplars stored alongside the blocks, within the input time range
+	// (both inclusive) and of the series matching any of the matcher sets.
+	Exemplars(ctx context.Context, minT, maxT int64, matchers ...[]*labels.Matcher) ([]exemplar.QueryResult, error)
+}
+
+// ExemplarsRetentionLimits is the interface used to get the per-tenant exemplars retention in the long-term storage.
+type ExemplarsRetentionLimits interface {
+	// ExemplarsRetentionPeriod returns how long exemplars are retained in the long-term storage. 0 when disabled.
+	ExemplarsRetentionPeriod(userID string) time.Duration
+}
+
+// NewExemplarQueryable returns an exemplar queryable which queries the exemplars from both the ingesters
+// and the long-term storage, for the tenants retaining the exemplars in the long-term storage.
+func NewExemplarQueryable(ingesters storage.ExemplarQueryable, blocks BlocksExemplarQueryable, limits ExemplarsRetentionLimits, logger log.Logger) storage.ExemplarQueryable {
+	return &exemplarQueryable{
+		ingesters: ingesters,
+		blocks:    blocks,
+		limits:    limits,
+		logger:    logger,
+	}
+}
+
+type exemplarQueryable struct {
+	ingesters storage.ExemplarQueryable
+	blocks    BlocksExemplarQueryable
+	limits    ExemplarsRetentionLimits
+	logger    log.Logger
+}
+
+func (q *exemplarQueryable) ExemplarQuerier(ctx context.Context) (storage.ExemplarQuerier, error) {
+	ingesters, err := q.ingesters.ExemplarQuerier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exemplarQuerier{
+		ctx:       ctx,
+		ingesters: ingesters,
+		blocks:    q.blocks,
+		limits:    q.limits,
+		logger:    q.logger,
+	}, nil
+}
+
+type exemplarQuerier struct {
+	ctx       context.Context
+	ingesters storage.ExemplarQuerier
+	blocks    BlocksExemplarQueryable
+	limits    ExemplarsRetentionLimits
+	logger    log.Logger
+}
+
+// Select implements storage.ExemplarQuerier. The exemplars found both in the ingesters and in the
+// long-term storage are deduplicated.
+func (q *exemplarQuerier) Select(start, end int64, matchers ...[]*labels.Matcher) ([]exemplar.QueryResult, error) {
+	userID, err := tenant.TenantID(q.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := q.limits.ExemplarsRetentionPeriod(userID)
+	if retention <= 0 {
+		return q.ingesters.Select(start, end, matchers...)
+	}
+
+	spanLog, ctx := spanlogger.NewWithLogger(q.ctx, q.logger, "exemplarQuerier.Select")
+	defer spanLog.Finish()
+
+	// The exemplars older than the retention period may not have been deleted from the long-term storage yet.
+	blocksStart := start
+	if minT := util.TimeToMillis(time.Now().Add(-retention)); blocksStart < minT {
+		blocksStart = minT
+	}
+
+	var ingestersRes, blocksRes []exemplar.QueryResult
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		ingestersRes, err = q.ingesters.Select(start, end, matchers...)
+		return err
+	})
+	if blocksStart <= end {
+		g.Go(func() error {
+			var err error
+			blocksRes, err = q.blocks.Exemplars(gCtx, blocksStart, end, matchers...)
+			return err
+		})
+	} else {
+		level.Debug(spanLog).Log("msg", "the query time range is outside the exemplars retention period, not querying the long-term storage", "retention", retention)
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// The ingesters results are already sorted and deduplicated, so there's no need to merge them
+	// if no exemplar has been found in the long-term storage.
+	if len(blocksRes) == 0 {
+		return ingestersRes, nil
+	}
+
+	return mimir_tsdb.NewBlockExemplars(append(ingestersRes, blocksRes...)).QueryResults(), nil
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+func TestExemplarQuerier_Select(t *testing.T) {
+	var (
+		series1 = labels.FromStrings(labels.MetricName, "metric", "pod", "a")
+		series2 = labels.FromStrings(labels.MetricName, "metric", "pod", "b")
+		traceA  = labels.FromStrings("trace_id", "a")
+		traceB  = labels.FromStrings("trace_id", "b")
+		now     = time.Now()
+		start   = util.TimeToMillis(now.Add(-48 * time.Hour))
+		end     = util.TimeToMillis(now)
+
+		ingestersRes = []exemplar.QueryResult{
+			{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceB, Value: 2, Ts: end - 10, HasTs: true}}},
+		}
+		blocksRes = []exemplar.QueryResult{
+			{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 1, Ts: end - 20, HasTs: true}, {Labels: traceB, Value: 2, Ts: end - 10, HasTs: true}}},
+			{SeriesLabels: series2, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 3, Ts: end - 30, HasTs: true}}},
+		}
+	)
+
+	tests := map[string]struct {
+		retention       time.Duration
+		expectedBlocks  bool
+		expectedResults []exemplar.QueryResult
+	}{
+		"should query only the ingesters if exemplars are not retained in the long-term storage": {
+			retention:       0,
+			expectedResults: ingestersRes,
+		},
+		"should query the long-term storage within the retention period": {
+			retention:      24 * time.Hour,
+			expectedBlocks: true,
+			expectedResults: []exemplar.QueryResult{
+				{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 1, Ts: end - 20, HasTs: true}, {Labels: traceB, Value: 2, Ts: end - 10, HasTs: true}}},
+				{SeriesLabels: series2, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 3, Ts: end - 30, HasTs: true}}},
+			},
+		},
+		"should not query the long-term storage if the query time range is outside the retention period": {
+			retention:       time.Hour,
+			expectedResults: ingestersRes,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// The query time range ends before the retention period if no exemplars are expected from the long-term storage.
+			queryEnd := end
+			if testData.retention > 0 && !testData.expectedBlocks {
+				queryEnd = util.TimeToMillis(now.Add(-2 * testData.retention))
+			}
+
+			blocks := &blocksExemplarQueryableMock{results: blocksRes}
+			limits := &exemplarsRetentionLimitsMock{retention: testData.retention}
+			queryable := NewExemplarQueryable(&exemplarQueryableMock{results: ingestersRes}, blocks, limits, log.NewNopLogger())
+
+			q, err := queryable.ExemplarQuerier(user.InjectOrgID(context.Background(), "user-1"))
+			require.NoError(t, err)
+
+			actual, err := q.Select(start, queryEnd, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "metric")})
+			require.NoError(t, err)
+			assert.Equal(t, testData.expectedResults, actual)
+
+			if testData.expectedBlocks {
+				require.Len(t, blocks.calls, 1)
+				// The start time is clamped to the retention period.
+				assert.InDelta(t, util.TimeToMillis(now.Add(-testData.retention)), blocks.calls[0][0], float64(time.Minute.Milliseconds()))
+				assert.Equal(t, queryEnd, blocks.calls[0][1])
+			} else {
+				assert.Empty(t, blocks.calls)
+			}
+		})
+	}
+}
+
+type exemplarQueryableMock struct {
+	results []exemplar.QueryResult
+}
+
+func (m *exemplarQueryableMock) ExemplarQuerier(context.Context) (storage.ExemplarQuerier, error) {
+	return m, nil
+}
+
+func (m *exemplarQueryableMock) Select(_, _ int64, _ ...[]*labels.Matcher) ([]exemplar.QueryResult, error) {
+	return m.results, nil
+}
+
+type blocksExemplarQueryableMock struct {
+	results []exemplar.QueryResult
+	calls   [][2]int64
+}
+
+func (m *blocksExemplarQueryableMock) Exemplars(_ context.Context, minT, maxT int64, _ ...[]*labels.Matcher) ([]exemplar.QueryResult, error) {
+	m.calls = append(m.calls, [2]int64{minT, maxT})
+	return m.results, nil
+}
+
+type exemplarsRetentionLimitsMock struct {
+	retention time.Duration
+}
+
+func (m *exemplarsRetentionLimitsMock) ExemplarsRetentionPeriod(string) time.Duration {
+	return m.retention
+}
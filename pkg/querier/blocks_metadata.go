@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/tenant"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/textparse"
+	"github.com/prometheus/prometheus/scrape"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/grafana/mimir/pkg/storage/bucket"
+	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
+)
+
+// BlocksMetadataQueryable is the interface used to query the metric metadata stored in the long-term storage.
+type BlocksMetadataQueryable interface {
+	// MetricsMetadata returns the metric metadata of the tenant stored in the long-term storage.
+	MetricsMetadata(ctx context.Context) ([]scrape.MetricMetadata, error)
+}
+
+// BucketMetadataQueryable reads the metric metadata merged by the compactor from the bucket. The metadata
+// read is cached in memory for the cache TTL, given it's updated by the compactor once per cleanup interval.
+type BucketMetadataQueryable struct {
+	bkt         objstore.Bucket
+	cfgProvider bucket.TenantConfigProvider
+	cacheTTL    time.Duration
+	logger      log.Logger
+
+	cacheMx sync.Mutex
+	cache   map[string]cachedMetricsMetadata
+}
+
+type cachedMetricsMetadata struct {
+	metadata  []scrape.MetricMetadata
+	expiresAt time.Time
+}
+
+func NewBucketMetadataQueryable(bkt objstore.Bucket, cfgProvider bucket.TenantConfigProvider, cacheTTL time.Duration, logger log.Logger) *BucketMetadataQueryable {
+	return &BucketMetadataQueryable{
+		bkt:         bkt,
+		cfgProvider: cfgProvider,
+		cacheTTL:    cacheTTL,
+		logger:      logger,
+		cache:       map[string]cachedMetricsMetadata{},
+	}
+}
+
+// MetricsMetadata implements BlocksMetadataQueryable.
+func (q *BucketMetadataQueryable) MetricsMetadata(ctx context.Context) ([]scrape.MetricMetadata, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if metadata, ok := q.getCachedMetricsMetadata(userID, now); ok {
+		return metadata, nil
+	}
+
+	stored, err := mimir_tsdb.ReadMetricsMetadata(ctx, bucket.NewUserBucketClient(userID, q.bkt, q.cfgProvider), q.logger)
+	if errors.Is(err, mimir_tsdb.ErrMetricsMetadataNotFound) {
+		q.cacheMetricsMetadata(userID, nil, now)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]scrape.MetricMetadata, 0, len(stored.Metadata))
+	for _, m := range stored.Metadata {
+		result = append(result, scrape.MetricMetadata{
+			Metric: m.Metric,
+			Type:   textparse.MetricType(m.Type),
+			Help:   m.Help,
+			Unit:   m.Unit,
+		})
+	}
+
+	q.cacheMetricsMetadata(userID, result, now)
+	return result, nil
+}
+
+func (q *BucketMetadataQueryable) getCachedMetricsMetadata(userID string, now time.Time) ([]scrape.MetricMetadata, bool) {
+	q.cacheMx.Lock()
+	defer q.cacheMx.Unlock()
+
+	entry, ok := q.cache[userID]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.metadata, true
+}
+
+func (q *BucketMetadataQueryable) cacheMetricsMetadata(userID string, metadata []scrape.MetricMetadata, now time.Time) {
+	if q.cacheTTL <= 0 {
+		return
+	}
+
+	q.cacheMx.Lock()
+	defer q.cacheMx.Unlock()
+
+	// Remove the expired entries, so that the metadata of the tenants not queried anymore is not kept in memory.
+	for cachedUserID, entry := range q.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(q.cache, cachedUserID)
+		}
+	}
+
+	q.cache[userID] = cachedMetricsMetadata{metadata: metadata, expiresAt: now.Add(q.cacheTTL)}
+}
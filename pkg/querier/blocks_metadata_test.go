@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/scrape"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+	"github.com/weaveworks/common/user"
+
+	"github.com/grafana/mimir/pkg/storage/bucket"
+	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
+)
+
+func TestBucketMetadataQueryable_MetricsMetadata(t *testing.T) {
+	bkt := objstore.NewInMemBucket()
+	q := NewBucketMetadataQueryable(bkt, nil, 0, log.NewNopLogger())
+	ctx := user.InjectOrgID(context.Background(), "user-1")
+
+	// No metadata has been stored yet.
+	actual, err := q.MetricsMetadata(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+
+	require.NoError(t, mimir_tsdb.WriteMetricsMetadata(context.Background(), bucket.NewUserBucketClient("user-1", bkt, nil), &mimir_tsdb.MetricsMetadata{
+		Version:  mimir_tsdb.MetricsMetadataVersion1,
+		Metadata: []mimir_tsdb.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Help.", Unit: "seconds", LastSeen: 10}},
+	}))
+
+	actual, err = q.MetricsMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []scrape.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Help.", Unit: "seconds"}}, actual)
+
+	// The metadata of other tenants is not returned.
+	actual, err = q.MetricsMetadata(user.InjectOrgID(context.Background(), "user-2"))
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestBucketMetadataQueryable_MetricsMetadata_ShouldCacheTheMetadata(t *testing.T) {
+	bkt := objstore.NewInMemBucket()
+	userBucket := bucket.NewUserBucketClient("user-1", bkt, nil)
+	q := NewBucketMetadataQueryable(bkt, nil, time.Hour, log.NewNopLogger())
+	ctx := user.InjectOrgID(context.Background(), "user-1")
+
+	require.NoError(t, mimir_tsdb.WriteMetricsMetadata(context.Background(), userBucket, &mimir_tsdb.MetricsMetadata{
+		Version:  mimir_tsdb.MetricsMetadataVersion1,
+		Metadata: []mimir_tsdb.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Help.", LastSeen: 10}},
+	}))
+
+	actual, err := q.MetricsMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []scrape.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Help."}}, actual)
+
+	// The metadata updated in the bucket is not read until the cached one expires.
+	require.NoError(t, mimir_tsdb.WriteMetricsMetadata(context.Background(), userBucket, &mimir_tsdb.MetricsMetadata{
+		Version:  mimir_tsdb.MetricsMetadataVersion1,
+		Metadata: []mimir_tsdb.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Updated help.", LastSeen: 20}},
+	}))
+
+	actual, err = q.MetricsMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []scrape.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Help."}}, actual)
+
+	q.cacheMx.Lock()
+	entry := q.cache["user-1"]
+	entry.expiresAt = time.Now().Add(-time.Second)
+	q.cache["user-1"] = entry
+	q.cacheMx.Unlock()
+
+	actual, err = q.MetricsMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []scrape.MetricMetadata{{Metric: "metric", Type: "counter", Help: "Updated help."}}, actual)
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"sort"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/gogo/protobuf/types"
+	"github.com/grafana/dskit/concurrency"
 	"github.com/grafana/dskit/kv"
 	"github.com/grafana/dskit/ring"
 	"github.com/grafana/dskit/services"
@@ -25,6 +27,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/thanos-io/thanos/pkg/block"
@@ -32,12 +35,14 @@ import (
 	"github.com/thanos-io/thanos/pkg/store/hintspb"
 	"github.com/thanos-io/thanos/pkg/store/storepb"
 	"github.com/thanos-io/thanos/pkg/strutil"
+	"github.com/weaveworks/common/httpgrpc"
 	"go.uber.org/atomic"
 	"golang.org/x/sync/errgroup"
 	grpc_metadata "google.golang.org/grpc/metadata"
 
 	"github.com/grafana/dskit/tenant"
 
+	ingester_client "github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/mimirpb"
 	"github.com/grafana/mimir/pkg/querier/stats"
 	"github.com/grafana/mimir/pkg/storage/bucket"
@@ -60,10 +65,15 @@ const (
 	// store-gateways. If no more store-gateways are left (ie. due to lower replication
 	// factor) than we'll end the retries earlier.
 	maxFetchSeriesAttempts = 3
+
+	// The maximum number of label names whose values are concurrently fetched
+	// from the store-gateways by a single LabelNamesAndValues() call.
+	labelNamesAndValuesConcurrency = 8
 )
 
 var (
-	errMaxChunksPerQueryLimit = "the query hit the max number of chunks limit while fetching chunks from store-gateways for %s (limit: %d)"
+	errMaxChunksPerQueryLimit   = "the query hit the max number of chunks limit while fetching chunks from store-gateways for %s (limit: %d)"
+	errMaxBlocksLabelNamesLimit = "label names cardinality request label names limit (limit: %d actual: %d) exceeded, use matchers to reduce the number of label names"
 )
 
 // BlocksStoreSet is the interface used to get the clients to query series on a set of blocks.
@@ -98,10 +108,12 @@ type BlocksStoreClient interface {
 // BlocksStoreLimits is the interface that should be implemented by the limits provider.
 type BlocksStoreLimits interface {
 	bucket.TenantConfigProvider
+	PartialResultsLimits
 
 	MaxLabelsQueryLength(userID string) time.Duration
 	MaxChunksPerQuery(userID string) int
 	StoreGatewayTenantShardSize(userID string) int
+	CardinalityAnalysisMaxBlocksLabelNames(userID string) int
 }
 
 type blocksStoreQueryableMetrics struct {
@@ -293,6 +305,40 @@ func (q *BlocksStoreQueryable) stopping(_ error) error {
 
 // Querier returns a new Querier on the storage.
 func (q *BlocksStoreQueryable) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
+	return q.newBlocksStoreQuerier(ctx, mint, maxt)
+}
+
+// LabelValuesCardinality implements BlocksCardinalityQueryable.
+func (q *BlocksStoreQueryable) LabelValuesCardinality(ctx context.Context, minT, maxT int64, labelNames []model.LabelName, matchers []*labels.Matcher) (uint64, *ingester_client.LabelValuesCardinalityResponse, error) {
+	querier, err := q.newBlocksStoreQuerier(ctx, minT, maxT)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return querier.labelValuesCardinality(labelNames, matchers)
+}
+
+// LabelNamesAndValues implements BlocksCardinalityQueryable.
+func (q *BlocksStoreQueryable) LabelNamesAndValues(ctx context.Context, minT, maxT int64, matchers []*labels.Matcher) (*ingester_client.LabelNamesAndValuesResponse, error) {
+	querier, err := q.newBlocksStoreQuerier(ctx, minT, maxT)
+	if err != nil {
+		return nil, err
+	}
+
+	return querier.labelNamesAndValues(matchers)
+}
+
+// Exemplars implements BlocksExemplarQueryable.
+func (q *BlocksStoreQueryable) Exemplars(ctx context.Context, minT, maxT int64, matchers ...[]*labels.Matcher) ([]exemplar.QueryResult, error) {
+	querier, err := q.newBlocksStoreQuerier(ctx, minT, maxT)
+	if err != nil {
+		return nil, err
+	}
+
+	return querier.exemplars(matchers)
+}
+
+func (q *BlocksStoreQueryable) newBlocksStoreQuerier(ctx context.Context, mint, maxt int64) (*blocksStoreQuerier, error) {
 	if s := q.State(); s != services.Running {
 		return nil, errors.Errorf("BlocksStoreQueryable is not running: %v", s)
 	}
@@ -376,10 +422,11 @@ func (q *blocksStoreQuerier) LabelNames(matchers ...*labels.Matcher) ([]string,
 		return queriedBlocks, nil
 	}
 
-	err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, nil, queryFunc)
+	consistencyWarnings, err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, nil, queryFunc)
 	if err != nil {
 		return nil, nil, err
 	}
+	resWarnings = append(resWarnings, consistencyWarnings...)
 
 	return strutil.MergeSlices(resNameSets...), resWarnings, nil
 }
@@ -421,14 +468,139 @@ func (q *blocksStoreQuerier) LabelValues(name string, matchers ...*labels.Matche
 		return queriedBlocks, nil
 	}
 
-	err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, nil, queryFunc)
+	consistencyWarnings, err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, nil, queryFunc)
 	if err != nil {
 		return nil, nil, err
 	}
+	resWarnings = append(resWarnings, consistencyWarnings...)
 
 	return strutil.MergeSlices(resValueSets...), resWarnings, nil
 }
 
+// labelNamesAndValues returns the values of each label name in the querier time range. The label names
+// are fetched first, and then the values of each label name, with a bounded concurrency. Each label name
+// is a separate store-gateways request, so the number of label names is limited per-tenant.
+func (q *blocksStoreQuerier) labelNamesAndValues(matchers []*labels.Matcher) (*ingester_client.LabelNamesAndValuesResponse, error) {
+	spanLog, spanCtx := spanlogger.NewWithLogger(q.ctx, q.logger, "blocksStoreQuerier.labelNamesAndValues")
+	defer spanLog.Span.Finish()
+
+	names, warnings, err := q.LabelNames(matchers...)
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		level.Warn(spanLog).Log("msg", "warning while fetching label names", "warning", warning)
+	}
+
+	if maxNames := q.limits.CardinalityAnalysisMaxBlocksLabelNames(q.userID); maxNames > 0 && len(names) > maxNames {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, errMaxBlocksLabelNamesLimit, maxNames, len(names))
+	}
+
+	items := make([]*ingester_client.LabelValues, len(names))
+	err = concurrency.ForEachJob(spanCtx, len(names), labelNamesAndValuesConcurrency, func(_ context.Context, idx int) error {
+		values, warnings, err := q.LabelValues(names[idx], matchers...)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			level.Warn(spanLog).Log("msg", "warning while fetching label values", "label_name", names[idx], "warning", warning)
+		}
+
+		items[idx] = &ingester_client.LabelValues{LabelName: names[idx], Values: values}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingester_client.LabelNamesAndValuesResponse{Items: items}, nil
+}
+
+// labelValuesCardinality returns the number of series for each value of the requested label names,
+// estimated from the blocks in the querier time range. See blocksLabelValuesCardinalityMerger for details.
+func (q *blocksStoreQuerier) labelValuesCardinality(labelNames []model.LabelName, matchers []*labels.Matcher) (uint64, *ingester_client.LabelValuesCardinalityResponse, error) {
+	spanLog, spanCtx := spanlogger.NewWithLogger(q.ctx, q.logger, "blocksStoreQuerier.labelValuesCardinality")
+	defer spanLog.Span.Finish()
+
+	minT, maxT := q.minT, q.maxT
+
+	level.Debug(spanLog).Log("start", util.TimeFromMillis(minT).UTC().String(), "end",
+		util.TimeFromMillis(maxT).UTC().String(), "matchers", util.MatchersStringer(matchers))
+
+	{
+		// Clamp max time range.
+		startTime, endTime := model.Time(minT), model.Time(maxT)
+		maxQueryLength := q.limits.MaxLabelsQueryLength(q.userID)
+		minT = int64(clampTime(spanCtx, startTime, maxQueryLength, endTime.Add(-maxQueryLength), true, "start", "max label query length", spanLog))
+	}
+
+	merger := newBlocksLabelValuesCardinalityMerger()
+
+	queryFunc := func(clients map[BlocksStoreClient][]ulid.ULID, minT, maxT int64) ([]ulid.ULID, error) {
+		blocks, queriedBlocks, err := q.fetchLabelValuesCardinalityFromStore(spanCtx, labelNames, clients, minT, maxT, matchers...)
+		if err != nil {
+			return nil, err
+		}
+
+		merger.add(blocks)
+
+		return queriedBlocks, nil
+	}
+
+	// The cardinality response has no way to carry warnings, so they're only logged.
+	warnings, err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, nil, queryFunc)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, w := range warnings {
+		level.Warn(spanLog).Log("msg", "label values cardinality from store-gateways may be incomplete", "warning", w)
+	}
+
+	seriesCountTotal, response := merger.merge()
+	return seriesCountTotal, response, nil
+}
+
+// exemplars returns the exemplars stored alongside the blocks in the querier time range, of the series
+// matching any of the matcher sets.
+func (q *blocksStoreQuerier) exemplars(matchers [][]*labels.Matcher) ([]exemplar.QueryResult, error) {
+	spanLog, spanCtx := spanlogger.NewWithLogger(q.ctx, q.logger, "blocksStoreQuerier.exemplars")
+	defer spanLog.Span.Finish()
+
+	minT, maxT := q.minT, q.maxT
+
+	level.Debug(spanLog).Log("start", util.TimeFromMillis(minT).UTC().String(), "end",
+		util.TimeFromMillis(maxT).UTC().String(), "matchers", util.MultiMatchersStringer(matchers))
+
+	var (
+		resMtx       sync.Mutex
+		resExemplars []*mimir_tsdb.BlockExemplars
+	)
+
+	queryFunc := func(clients map[BlocksStoreClient][]ulid.ULID, minT, maxT int64) ([]ulid.ULID, error) {
+		exemplars, queriedBlocks, err := q.fetchExemplarsFromStore(spanCtx, clients, minT, maxT, matchers)
+		if err != nil {
+			return nil, err
+		}
+
+		resMtx.Lock()
+		resExemplars = append(resExemplars, exemplars...)
+		resMtx.Unlock()
+
+		return queriedBlocks, nil
+	}
+
+	// The exemplar query API has no way to return warnings, so they're only logged.
+	warnings, err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, nil, queryFunc)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		level.Warn(spanLog).Log("msg", "exemplars from store-gateways may be incomplete", "warning", w)
+	}
+
+	return mimir_tsdb.MergeBlockExemplars(resExemplars...).QueryResults(), nil
+}
+
 func (q *blocksStoreQuerier) Close() error {
 	return nil
 }
@@ -476,10 +648,11 @@ func (q *blocksStoreQuerier) selectSorted(sp *storage.SelectHints, matchers ...*
 		return queriedBlocks, nil
 	}
 
-	err = q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, shard, queryFunc)
+	consistencyWarnings, err := q.queryWithConsistencyCheck(spanCtx, spanLog, minT, maxT, shard, queryFunc)
 	if err != nil {
 		return storage.ErrSeriesSet(err)
 	}
+	resWarnings = append(resWarnings, consistencyWarnings...)
 
 	if len(resSeriesSets) == 0 {
 		storage.EmptySeriesSet()
@@ -491,7 +664,7 @@ func (q *blocksStoreQuerier) selectSorted(sp *storage.SelectHints, matchers ...*
 }
 
 func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logger log.Logger, minT, maxT int64, shard *sharding.ShardSelector,
-	queryFunc func(clients map[BlocksStoreClient][]ulid.ULID, minT, maxT int64) ([]ulid.ULID, error)) error {
+	queryFunc func(clients map[BlocksStoreClient][]ulid.ULID, minT, maxT int64) ([]ulid.ULID, error)) (storage.Warnings, error) {
 	// If queryStoreAfter is enabled, we do manipulate the query maxt to query samples up until
 	// now - queryStoreAfter, because the most recent time range is covered by ingesters. This
 	// optimization is particularly important for the blocks storage because can be used to skip
@@ -508,20 +681,20 @@ func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logg
 		if maxT < minT {
 			q.metrics.storesHit.Observe(0)
 			level.Debug(logger).Log("msg", "empty query time range after max time manipulation")
-			return nil
+			return nil, nil
 		}
 	}
 
 	// Find the list of blocks we need to query given the time range.
 	knownBlocks, knownDeletionMarks, err := q.finder.GetBlocks(ctx, q.userID, minT, maxT)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(knownBlocks) == 0 {
 		q.metrics.storesHit.Observe(0)
 		level.Debug(logger).Log("msg", "no blocks found")
-		return nil
+		return nil, nil
 	}
 
 	q.metrics.blocksFound.Add(float64(len(knownBlocks)))
@@ -548,6 +721,10 @@ func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logg
 		touchedStores   = map[string]struct{}{}
 
 		resQueriedBlocks = []ulid.ULID(nil)
+
+		// If partial results are enabled, the blocks which can't be queried are reported
+		// as warnings instead of failing the query.
+		partialResults = isPartialResultsEnabled(ctx, q.limits, q.userID)
 	)
 
 	for attempt := 1; attempt <= maxFetchSeriesAttempts; attempt++ {
@@ -562,7 +739,12 @@ func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logg
 				break
 			}
 
-			return err
+			if partialResults && isPartialResultsTolerableError(err) {
+				level.Warn(util_log.WithContext(ctx, logger)).Log("msg", "unable to get store-gateway clients, returning partial results", "err", err)
+				return storage.Warnings{newMissingBlocksWarning(remainingBlocks, knownBlocks, err)}, nil
+			}
+
+			return nil, err
 		}
 		level.Debug(logger).Log("msg", "found store-gateway instances to query", "num instances", len(clients), "attempt", attempt)
 
@@ -570,7 +752,7 @@ func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logg
 		// are only meant to cover missing blocks.
 		queriedBlocks, err := queryFunc(clients, minT, maxT)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		level.Debug(logger).Log("msg", "received series from all store-gateways", "queried blocks", strings.Join(convertULIDsToString(queriedBlocks), " "))
 
@@ -591,7 +773,7 @@ func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logg
 			q.metrics.storesHit.Observe(float64(len(touchedStores)))
 			q.metrics.refetches.Observe(float64(attempt - 1))
 
-			return nil
+			return nil, nil
 		}
 
 		level.Debug(logger).Log("msg", "consistency check failed", "attempt", attempt, "missing blocks", strings.Join(convertULIDsToString(missingBlocks), " "))
@@ -601,8 +783,13 @@ func (q *blocksStoreQuerier) queryWithConsistencyCheck(ctx context.Context, logg
 	}
 
 	// We've not been able to query all expected blocks after all retries.
+	if partialResults {
+		level.Warn(util_log.WithContext(ctx, logger)).Log("msg", "failed consistency check, returning partial results", "missing blocks", strings.Join(convertULIDsToString(remainingBlocks), " "))
+		return storage.Warnings{newMissingBlocksWarning(remainingBlocks, knownBlocks, nil)}, nil
+	}
+
 	level.Warn(util_log.WithContext(ctx, logger)).Log("msg", "failed consistency check", "err", err)
-	return fmt.Errorf("consistency check failed because some blocks were not queried: %s", strings.Join(convertULIDsToString(remainingBlocks), " "))
+	return nil, fmt.Errorf("consistency check failed because some blocks were not queried: %s", strings.Join(convertULIDsToString(remainingBlocks), " "))
 }
 
 // filterBlocksByShard removes blocks that can be safely ignored when using query sharding. We know that block can be safely
@@ -722,6 +909,10 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 
 			stream, err := c.Series(gCtx, req)
 			if err != nil {
+				if limitErr := storeGatewayLimitError(err); limitErr != nil {
+					return limitErr
+				}
+
 				level.Warn(spanLog).Log("msg", "failed to fetch series", "remote", c.RemoteAddress(), "err", err)
 				return nil
 			}
@@ -742,6 +933,10 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 					break
 				}
 				if err != nil {
+					if limitErr := storeGatewayLimitError(err); limitErr != nil {
+						return limitErr
+					}
+
 					level.Warn(spanLog).Log("msg", "failed to receive series", "remote", c.RemoteAddress(), "err", err)
 					return nil
 				}
@@ -826,6 +1021,19 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 	return seriesSets, queriedBlocks, warnings, int(numChunks.Load()), nil
 }
 
+// storeGatewayLimitError returns a validation.LimitError if the store-gateway has rejected the request
+// because the tenant has exceeded a per-tenant limit, or nil otherwise. The request would be rejected
+// by any other store-gateway too, so the query fails instead of retrying the blocks on other replicas.
+// The requests rejected because of the per-instance concurrency limit (429) are retried instead,
+// because other store-gateways may not be at their limit.
+func storeGatewayLimitError(err error) error {
+	resp, ok := httpgrpc.HTTPResponseFromError(err)
+	if !ok || resp.Code != http.StatusUnprocessableEntity {
+		return nil
+	}
+	return validation.LimitError(resp.Body)
+}
+
 func (q *blocksStoreQuerier) fetchLabelNamesFromStore(
 	ctx context.Context,
 	clients map[BlocksStoreClient][]ulid.ULID,
@@ -984,6 +1192,160 @@ func (q *blocksStoreQuerier) fetchLabelValuesFromStore(
 	return valueSets, warnings, queriedBlocks, nil
 }
 
+func (q *blocksStoreQuerier) fetchLabelValuesCardinalityFromStore(
+	ctx context.Context,
+	labelNames []model.LabelName,
+	clients map[BlocksStoreClient][]ulid.ULID,
+	minT int64,
+	maxT int64,
+	matchers ...*labels.Matcher,
+) ([]*storegatewaypb.BlockLabelValuesCardinality, []ulid.ULID, error) {
+	var (
+		reqCtx        = grpc_metadata.AppendToOutgoingContext(ctx, mimir_tsdb.TenantIDExternalLabel, q.userID)
+		g, gCtx       = errgroup.WithContext(reqCtx)
+		mtx           = sync.Mutex{}
+		blocks        = []*storegatewaypb.BlockLabelValuesCardinality(nil)
+		queriedBlocks = []ulid.ULID(nil)
+		spanLog       = spanlogger.FromContext(ctx, q.logger)
+	)
+
+	// Concurrently fetch the cardinality from all clients.
+	for c, blockIDs := range clients {
+		// Change variables scope since it will be used in a goroutine.
+		c := c
+		blockIDs := blockIDs
+
+		g.Go(func() error {
+			req, err := createLabelValuesCardinalityRequest(minT, maxT, labelNames, blockIDs, matchers...)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create label values cardinality request")
+			}
+
+			cardinalityResp, err := c.LabelValuesCardinality(gCtx, req)
+			if err != nil {
+				level.Warn(spanLog).Log("msg", "failed to fetch label values cardinality", "remote", c.RemoteAddress(), "err", err)
+				return nil
+			}
+
+			myQueriedBlocks := []ulid.ULID(nil)
+			if cardinalityResp.Hints != nil {
+				hints := hintspb.LabelValuesResponseHints{}
+				if err := types.UnmarshalAny(cardinalityResp.Hints, &hints); err != nil {
+					return errors.Wrapf(err, "failed to unmarshal label values cardinality hints from %s", c.RemoteAddress())
+				}
+
+				ids, err := convertBlockHintsToULIDs(hints.QueriedBlocks)
+				if err != nil {
+					return errors.Wrapf(err, "failed to parse queried block IDs from received hints")
+				}
+
+				myQueriedBlocks = ids
+			}
+
+			level.Debug(spanLog).Log("msg", "received label values cardinality from store-gateway",
+				"instance", c.RemoteAddress(),
+				"requested blocks", strings.Join(convertULIDsToString(blockIDs), " "),
+				"queried blocks", strings.Join(convertULIDsToString(myQueriedBlocks), " "))
+
+			// Store the result.
+			mtx.Lock()
+			blocks = append(blocks, cardinalityResp.Blocks...)
+			queriedBlocks = append(queriedBlocks, myQueriedBlocks...)
+			mtx.Unlock()
+
+			return nil
+		})
+	}
+
+	// Wait until all client requests complete.
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return blocks, queriedBlocks, nil
+}
+
+func (q *blocksStoreQuerier) fetchExemplarsFromStore(
+	ctx context.Context,
+	clients map[BlocksStoreClient][]ulid.ULID,
+	minT int64,
+	maxT int64,
+	matchers [][]*labels.Matcher,
+) ([]*mimir_tsdb.BlockExemplars, []ulid.ULID, error) {
+	var (
+		reqCtx        = grpc_metadata.AppendToOutgoingContext(ctx, mimir_tsdb.TenantIDExternalLabel, q.userID)
+		g, gCtx       = errgroup.WithContext(reqCtx)
+		mtx           = sync.Mutex{}
+		exemplars     = []*mimir_tsdb.BlockExemplars(nil)
+		queriedBlocks = []ulid.ULID(nil)
+		spanLog       = spanlogger.FromContext(ctx, q.logger)
+	)
+
+	// Concurrently fetch the exemplars from all clients.
+	for c, blockIDs := range clients {
+		// Change variables scope since it will be used in a goroutine.
+		c := c
+		blockIDs := blockIDs
+
+		g.Go(func() error {
+			req, err := createExemplarsRequest(minT, maxT, blockIDs, matchers)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create exemplars request")
+			}
+
+			exemplarsResp, err := c.Exemplars(gCtx, req)
+			if err != nil {
+				level.Warn(spanLog).Log("msg", "failed to fetch exemplars", "remote", c.RemoteAddress(), "err", err)
+				return nil
+			}
+
+			myQueriedBlocks := []ulid.ULID(nil)
+			if exemplarsResp.Hints != nil {
+				hints := hintspb.LabelValuesResponseHints{}
+				if err := types.UnmarshalAny(exemplarsResp.Hints, &hints); err != nil {
+					return errors.Wrapf(err, "failed to unmarshal exemplars hints from %s", c.RemoteAddress())
+				}
+
+				ids, err := convertBlockHintsToULIDs(hints.QueriedBlocks)
+				if err != nil {
+					return errors.Wrapf(err, "failed to parse queried block IDs from received hints")
+				}
+
+				myQueriedBlocks = ids
+			}
+
+			results := make([]exemplar.QueryResult, 0, len(exemplarsResp.Timeseries))
+			for _, ts := range exemplarsResp.Timeseries {
+				results = append(results, exemplar.QueryResult{
+					SeriesLabels: mimirpb.FromLabelAdaptersToLabels(ts.Labels),
+					Exemplars:    mimirpb.FromExemplarProtosToExemplars(ts.Exemplars),
+				})
+			}
+
+			level.Debug(spanLog).Log("msg", "received exemplars from store-gateway",
+				"instance", c.RemoteAddress(),
+				"num series", len(results),
+				"requested blocks", strings.Join(convertULIDsToString(blockIDs), " "),
+				"queried blocks", strings.Join(convertULIDsToString(myQueriedBlocks), " "))
+
+			// Store the result.
+			mtx.Lock()
+			exemplars = append(exemplars, mimir_tsdb.NewBlockExemplars(results))
+			queriedBlocks = append(queriedBlocks, myQueriedBlocks...)
+			mtx.Unlock()
+
+			return nil
+		})
+	}
+
+	// Wait until all client requests complete.
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return exemplars, queriedBlocks, nil
+}
+
 func createSeriesRequest(minT, maxT int64, matchers []storepb.LabelMatcher, skipChunks bool, blockIDs []ulid.ULID) (*storepb.SeriesRequest, error) {
 	// Selectively query only specific blocks.
 	hints := &hintspb.SeriesRequestHints{
@@ -1068,6 +1430,67 @@ func createLabelValuesRequest(minT, maxT int64, label string, blockIDs []ulid.UL
 	return req, nil
 }
 
+func createLabelValuesCardinalityRequest(minT, maxT int64, labelNames []model.LabelName, blockIDs []ulid.ULID, matchers ...*labels.Matcher) (*storegatewaypb.LabelValuesCardinalityRequest, error) {
+	req := &storegatewaypb.LabelValuesCardinalityRequest{
+		Start:    minT,
+		End:      maxT,
+		Matchers: convertMatchersToLabelMatcher(matchers),
+	}
+	for _, name := range labelNames {
+		req.LabelNames = append(req.LabelNames, string(name))
+	}
+
+	// Selectively query only specific blocks.
+	hints := &hintspb.LabelValuesRequestHints{
+		BlockMatchers: []storepb.LabelMatcher{
+			{
+				Type:  storepb.LabelMatcher_RE,
+				Name:  block.BlockIDLabel,
+				Value: strings.Join(convertULIDsToString(blockIDs), "|"),
+			},
+		},
+	}
+
+	anyHints, err := types.MarshalAny(hints)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal label values cardinality request hints")
+	}
+
+	req.Hints = anyHints
+
+	return req, nil
+}
+
+func createExemplarsRequest(minT, maxT int64, blockIDs []ulid.ULID, matchers [][]*labels.Matcher) (*storegatewaypb.ExemplarsRequest, error) {
+	req := &storegatewaypb.ExemplarsRequest{
+		Start: minT,
+		End:   maxT,
+	}
+	for _, m := range matchers {
+		req.Matchers = append(req.Matchers, storegatewaypb.ExemplarsMatchers{Matchers: convertMatchersToLabelMatcher(m)})
+	}
+
+	// Selectively query only specific blocks.
+	hints := &hintspb.LabelValuesRequestHints{
+		BlockMatchers: []storepb.LabelMatcher{
+			{
+				Type:  storepb.LabelMatcher_RE,
+				Name:  block.BlockIDLabel,
+				Value: strings.Join(convertULIDsToString(blockIDs), "|"),
+			},
+		},
+	}
+
+	anyHints, err := types.MarshalAny(hints)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal exemplars request hints")
+	}
+
+	req.Hints = anyHints
+
+	return req, nil
+}
+
 func convertULIDsToString(ids []ulid.ULID) []string {
 	res := make([]string, len(ids))
 	for idx, id := range ids {
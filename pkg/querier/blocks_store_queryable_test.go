@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net/http"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,6 +25,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage"
@@ -32,9 +36,12 @@ import (
 	"github.com/thanos-io/thanos/pkg/store/hintspb"
 	"github.com/thanos-io/thanos/pkg/store/labelpb"
 	"github.com/thanos-io/thanos/pkg/store/storepb"
+	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
 	"google.golang.org/grpc"
 
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/mimirpb"
 	"github.com/grafana/mimir/pkg/storage/sharding"
 	"github.com/grafana/mimir/pkg/storage/tsdb/bucketindex"
 	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
@@ -612,6 +619,78 @@ func TestBlocksStoreQuerier_Select(t *testing.T) {
 			queryLimiter: limiter.NewQueryLimiter(0, 8, 0),
 			expectedErr:  validation.LimitError(fmt.Sprintf(limiter.ErrMaxChunkBytesHit, 8)),
 		},
+		"store-gateway rejects the series request because exceeding a per-tenant limit": {
+			finderResult: bucketindex.Blocks{
+				{ID: block1},
+			},
+			storeSetResponses: []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{
+						remoteAddr:      "1.1.1.1",
+						mockedSeriesErr: httpgrpc.Errorf(http.StatusUnprocessableEntity, "the request has exceeded the limit of series per request"),
+					}: {block1},
+				},
+				// The blocks are not queried from other store-gateways.
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{remoteAddr: "2.2.2.2", mockedSeriesResponses: []*storepb.SeriesResponse{
+						mockSeriesResponse(labels.Labels{metricNameLabel}, minT, 1),
+						mockHintsResponse(block1),
+					}}: {block1},
+				},
+			},
+			limits:       &blocksStoreLimitsMock{},
+			queryLimiter: noOpQueryLimiter,
+			expectedErr:  validation.LimitError("the request has exceeded the limit of series per request"),
+		},
+		"store-gateway rejects the series request while streaming because exceeding a per-tenant limit": {
+			finderResult: bucketindex.Blocks{
+				{ID: block1},
+			},
+			storeSetResponses: []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{
+						remoteAddr: "1.1.1.1",
+						mockedSeriesResponses: []*storepb.SeriesResponse{
+							mockSeriesResponse(labels.Labels{metricNameLabel}, minT, 1),
+						},
+						mockedSeriesStreamErr: httpgrpc.Errorf(http.StatusUnprocessableEntity, "the request has exceeded the limit of chunks per query"),
+					}: {block1},
+				},
+			},
+			limits:       &blocksStoreLimitsMock{},
+			queryLimiter: noOpQueryLimiter,
+			expectedErr:  validation.LimitError("the request has exceeded the limit of chunks per query"),
+		},
+		"store-gateway rejects the series request because exceeding the per-instance concurrency limit": {
+			finderResult: bucketindex.Blocks{
+				{ID: block1},
+			},
+			storeSetResponses: []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{
+						remoteAddr:      "1.1.1.1",
+						mockedSeriesErr: httpgrpc.Errorf(http.StatusTooManyRequests, "the tenant has reached the limit of 1 concurrent series requests"),
+					}: {block1},
+				},
+				// The blocks are queried from another store-gateway, which is not at its concurrency limit.
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{remoteAddr: "2.2.2.2", mockedSeriesResponses: []*storepb.SeriesResponse{
+						mockSeriesResponse(labels.Labels{metricNameLabel}, minT, 1),
+						mockHintsResponse(block1),
+					}}: {block1},
+				},
+			},
+			limits:       &blocksStoreLimitsMock{},
+			queryLimiter: noOpQueryLimiter,
+			expectedSeries: []seriesResult{
+				{
+					lbls: labels.New(metricNameLabel),
+					values: []valueResult{
+						{t: minT, v: 1},
+					},
+				},
+			},
+		},
 		"blocks with non-matching shard are filtered out": {
 			finderResult: bucketindex.Blocks{
 				{ID: block1, CompactorShardID: "1_of_4"},
@@ -890,6 +969,337 @@ func TestBlocksStoreQuerier_Select(t *testing.T) {
 	}
 }
 
+func TestBlocksStoreQuerier_SelectPartialResults(t *testing.T) {
+	const (
+		metricName = "test_metric"
+		minT       = int64(10)
+		maxT       = int64(20)
+	)
+
+	var (
+		block1          = ulid.MustNew(1, nil)
+		block2          = ulid.MustNew(2, nil)
+		metricNameLabel = labels.Label{Name: labels.MetricName, Value: metricName}
+		series1Label    = labels.Label{Name: "series", Value: "1"}
+		finderResult    = bucketindex.Blocks{
+			{ID: block1, MinTime: 0, MaxTime: 7200000},
+			{ID: block2, MinTime: 7200000, MaxTime: 14400000},
+		}
+	)
+
+	// Returns the store set responses where block2 can't be queried from any store-gateway.
+	missingBlockResponses := func() []interface{} {
+		return []interface{}{
+			map[BlocksStoreClient][]ulid.ULID{
+				&storeGatewayClientMock{remoteAddr: "1.1.1.1", mockedSeriesResponses: []*storepb.SeriesResponse{
+					mockSeriesResponse(labels.Labels{metricNameLabel, series1Label}, minT, 1),
+					mockHintsResponse(block1),
+				}}: {block1, block2},
+			},
+			errors.New("no store-gateway remaining after exclude"),
+		}
+	}
+
+	tests := map[string]struct {
+		storeSetResponses []interface{}
+		limits            BlocksStoreLimits
+		requested         bool
+		expectedSeries    int
+		expectedErr       string
+		expectedWarning   string
+	}{
+		"should fail the query on consistency check failure if partial results are disabled": {
+			storeSetResponses: missingBlockResponses(),
+			limits:            &blocksStoreLimitsMock{},
+			expectedErr:       fmt.Sprintf("consistency check failed because some blocks were not queried: %s", block2.String()),
+		},
+		"should return partial results on consistency check failure if partial results are enabled for the tenant": {
+			storeSetResponses: missingBlockResponses(),
+			limits:            &blocksStoreLimitsMock{partialResultsEnabled: true},
+			expectedSeries:    1,
+			expectedWarning:   fmt.Sprintf("partial results: some blocks could not be queried from the store-gateways and their data is missing from the results: %s from 1970-01-01T02:00:00Z to 1970-01-01T04:00:00Z", block2.String()),
+		},
+		"should return partial results on consistency check failure if partial results are requested": {
+			storeSetResponses: missingBlockResponses(),
+			limits:            &blocksStoreLimitsMock{},
+			requested:         true,
+			expectedSeries:    1,
+			expectedWarning:   fmt.Sprintf("partial results: some blocks could not be queried from the store-gateways and their data is missing from the results: %s from 1970-01-01T02:00:00Z to 1970-01-01T04:00:00Z", block2.String()),
+		},
+		"should return partial results if store-gateway clients can't be found and partial results are enabled": {
+			storeSetResponses: []interface{}{errors.New("no client found")},
+			limits:            &blocksStoreLimitsMock{partialResultsEnabled: true},
+			expectedSeries:    0,
+			expectedWarning: fmt.Sprintf("partial results: some blocks could not be queried from the store-gateways and their data is missing from the results: "+
+				"%s from 1970-01-01T00:00:00Z to 1970-01-01T02:00:00Z, %s from 1970-01-01T02:00:00Z to 1970-01-01T04:00:00Z (no client found)", block1.String(), block2.String()),
+		},
+		"should fail the query on limit errors even if partial results are enabled": {
+			storeSetResponses: []interface{}{validation.LimitError("limit reached")},
+			limits:            &blocksStoreLimitsMock{partialResultsEnabled: true},
+			expectedErr:       "limit reached",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ctx := limiter.AddQueryLimiterToContext(context.Background(), limiter.NewQueryLimiter(0, 0, 0))
+			if testData.requested {
+				ctx = ContextWithPartialResultsRequested(ctx)
+			}
+
+			finder := &blocksFinderMock{}
+			finder.On("GetBlocks", mock.Anything, "user-1", minT, maxT).Return(finderResult, map[ulid.ULID]*bucketindex.BlockDeletionMark(nil), nil)
+
+			q := &blocksStoreQuerier{
+				ctx:         ctx,
+				minT:        minT,
+				maxT:        maxT,
+				userID:      "user-1",
+				finder:      finder,
+				stores:      &blocksStoreSetMock{mockedResponses: testData.storeSetResponses},
+				consistency: NewBlocksConsistencyChecker(0, 0, log.NewNopLogger(), nil),
+				logger:      log.NewNopLogger(),
+				metrics:     newBlocksStoreQueryableMetrics(nil),
+				limits:      testData.limits,
+			}
+
+			set := q.Select(true, &storage.SelectHints{Start: minT, End: maxT}, labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, metricName))
+			if testData.expectedErr != "" {
+				assert.EqualError(t, set.Err(), testData.expectedErr)
+				return
+			}
+
+			actualSeries := 0
+			for set.Next() {
+				actualSeries++
+			}
+			require.NoError(t, set.Err())
+			assert.Equal(t, testData.expectedSeries, actualSeries)
+
+			require.Len(t, set.Warnings(), 1)
+			assert.EqualError(t, set.Warnings()[0], testData.expectedWarning)
+		})
+	}
+}
+
+func TestBlocksStoreQuerier_LabelValuesCardinality(t *testing.T) {
+	const (
+		minT = int64(10)
+		maxT = int64(20)
+	)
+
+	var (
+		block1       = ulid.MustNew(1, nil)
+		block2       = ulid.MustNew(2, nil)
+		block3       = ulid.MustNew(3, nil)
+		finderResult = bucketindex.Blocks{
+			{ID: block1, MinTime: 0, MaxTime: 7200000},
+			{ID: block2, MinTime: 0, MaxTime: 7200000},
+			{ID: block3, MinTime: 7200000, MaxTime: 14400000},
+		}
+	)
+
+	// Block 1 and 2 are split by the compactor, so their series are summed, while
+	// block 3 covers a different time range, so the highest number of series is taken.
+	storeSetResponses := []interface{}{
+		map[BlocksStoreClient][]ulid.ULID{
+			&storeGatewayClientMock{remoteAddr: "1.1.1.1", mockedLabelValuesCardinalityResponse: &storegatewaypb.LabelValuesCardinalityResponse{
+				Blocks: []*storegatewaypb.BlockLabelValuesCardinality{
+					{BlockId: block1.String(), MinTime: 0, MaxTime: 7200000, CompactorShardId: "1_of_2", SeriesCount: 3, Items: []*storegatewaypb.LabelValueSeriesCount{
+						{LabelName: "job", LabelValueSeries: map[string]uint64{"a": 2, "b": 1}},
+					}},
+					{BlockId: block2.String(), MinTime: 0, MaxTime: 7200000, CompactorShardId: "2_of_2", SeriesCount: 2, Items: []*storegatewaypb.LabelValueSeriesCount{
+						{LabelName: "job", LabelValueSeries: map[string]uint64{"a": 2}},
+					}},
+				},
+				Hints: mockValuesHints(block1, block2),
+			}}: {block1, block2},
+			&storeGatewayClientMock{remoteAddr: "2.2.2.2", mockedLabelValuesCardinalityResponse: &storegatewaypb.LabelValuesCardinalityResponse{
+				Blocks: []*storegatewaypb.BlockLabelValuesCardinality{
+					{BlockId: block3.String(), MinTime: 7200000, MaxTime: 14400000, SeriesCount: 6, Items: []*storegatewaypb.LabelValueSeriesCount{
+						{LabelName: "job", LabelValueSeries: map[string]uint64{"a": 3, "b": 3}},
+					}},
+				},
+				Hints: mockValuesHints(block3),
+			}}: {block3},
+		},
+	}
+
+	finder := &blocksFinderMock{}
+	finder.On("GetBlocks", mock.Anything, "user-1", minT, maxT).Return(finderResult, map[ulid.ULID]*bucketindex.BlockDeletionMark(nil), nil)
+
+	q := &blocksStoreQuerier{
+		ctx:         context.Background(),
+		minT:        minT,
+		maxT:        maxT,
+		userID:      "user-1",
+		finder:      finder,
+		stores:      &blocksStoreSetMock{mockedResponses: storeSetResponses},
+		consistency: NewBlocksConsistencyChecker(0, 0, log.NewNopLogger(), nil),
+		logger:      log.NewNopLogger(),
+		metrics:     newBlocksStoreQueryableMetrics(nil),
+		limits:      &blocksStoreLimitsMock{},
+	}
+
+	seriesCountTotal, response, err := q.labelValuesCardinality([]model.LabelName{"job"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), seriesCountTotal)
+	assert.Equal(t, &client.LabelValuesCardinalityResponse{Items: []*client.LabelValueSeriesCount{
+		{LabelName: "job", LabelValueSeries: map[string]uint64{"a": 4, "b": 3}},
+	}}, response)
+}
+
+func TestBlocksStoreQuerier_LabelNamesAndValues(t *testing.T) {
+	const (
+		minT = int64(10)
+		maxT = int64(20)
+	)
+
+	var (
+		block1       = ulid.MustNew(1, nil)
+		finderResult = bucketindex.Blocks{
+			{ID: block1},
+		}
+	)
+
+	tests := map[string]struct {
+		maxLabelNames    int
+		expectedResponse *client.LabelNamesAndValuesResponse
+		expectedErr      error
+	}{
+		"should fetch the values of each label name": {
+			expectedResponse: &client.LabelNamesAndValuesResponse{Items: []*client.LabelValues{
+				{LabelName: "job", Values: []string{"a", "b"}},
+				{LabelName: "zone", Values: []string{"a", "b"}},
+			}},
+		},
+		"should fetch the values of each label name if the number of label names is within the limit": {
+			maxLabelNames: 2,
+			expectedResponse: &client.LabelNamesAndValuesResponse{Items: []*client.LabelValues{
+				{LabelName: "job", Values: []string{"a", "b"}},
+				{LabelName: "zone", Values: []string{"a", "b"}},
+			}},
+		},
+		"should fail without fetching the values if the number of label names exceeds the limit": {
+			maxLabelNames: 1,
+			expectedErr:   httpgrpc.Errorf(http.StatusBadRequest, errMaxBlocksLabelNamesLimit, 1, 2),
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// The mocked store-gateway returns the same values for any label name.
+			storeClient := &storeGatewayClientMock{
+				remoteAddr: "1.1.1.1",
+				mockedLabelNamesResponse: &storepb.LabelNamesResponse{
+					Names: []string{"job", "zone"},
+					Hints: mockNamesHints(block1),
+				},
+				mockedLabelValuesResponse: &storepb.LabelValuesResponse{
+					Values: []string{"a", "b"},
+					Hints:  mockValuesHints(block1),
+				},
+			}
+
+			// The label names are queried first, and then the values of each label name.
+			storeSetResponses := []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{storeClient: {block1}},
+				map[BlocksStoreClient][]ulid.ULID{storeClient: {block1}},
+				map[BlocksStoreClient][]ulid.ULID{storeClient: {block1}},
+			}
+			stores := &blocksStoreSetMock{mockedResponses: storeSetResponses}
+
+			finder := &blocksFinderMock{}
+			finder.On("GetBlocks", mock.Anything, "user-1", minT, maxT).Return(finderResult, map[ulid.ULID]*bucketindex.BlockDeletionMark(nil), nil)
+
+			q := &blocksStoreQuerier{
+				ctx:         context.Background(),
+				minT:        minT,
+				maxT:        maxT,
+				userID:      "user-1",
+				finder:      finder,
+				stores:      stores,
+				consistency: NewBlocksConsistencyChecker(0, 0, log.NewNopLogger(), nil),
+				logger:      log.NewNopLogger(),
+				metrics:     newBlocksStoreQueryableMetrics(nil),
+				limits:      &blocksStoreLimitsMock{cardinalityAnalysisMaxBlocksLabelNames: testData.maxLabelNames},
+			}
+
+			response, err := q.labelNamesAndValues(nil)
+			if testData.expectedErr != nil {
+				require.Equal(t, testData.expectedErr, err)
+
+				// Only the label names should have been fetched.
+				assert.Equal(t, 1, stores.nextResult)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expectedResponse, response)
+		})
+	}
+}
+
+func TestBlocksStoreQuerier_Exemplars(t *testing.T) {
+	const (
+		minT = int64(10)
+		maxT = int64(20)
+	)
+
+	var (
+		block1       = ulid.MustNew(1, nil)
+		block2       = ulid.MustNew(2, nil)
+		series1      = labels.FromStrings(labels.MetricName, "metric", "pod", "a")
+		series2      = labels.FromStrings(labels.MetricName, "metric", "pod", "b")
+		finderResult = bucketindex.Blocks{
+			{ID: block1, MinTime: 0, MaxTime: 7200000},
+			{ID: block2, MinTime: 0, MaxTime: 7200000},
+		}
+	)
+
+	// The same exemplar is returned by both store-gateways (eg. uploaded by different ingesters).
+	storeSetResponses := []interface{}{
+		map[BlocksStoreClient][]ulid.ULID{
+			&storeGatewayClientMock{remoteAddr: "1.1.1.1", mockedExemplarsResponse: &storegatewaypb.ExemplarsResponse{
+				Timeseries: []mimirpb.TimeSeries{
+					{Labels: mimirpb.FromLabelsToLabelAdapters(series1), Exemplars: []mimirpb.Exemplar{{Labels: []mimirpb.LabelAdapter{{Name: "trace_id", Value: "a"}}, Value: 1, TimestampMs: 15}}},
+				},
+				Hints: mockValuesHints(block1),
+			}}: {block1},
+			&storeGatewayClientMock{remoteAddr: "2.2.2.2", mockedExemplarsResponse: &storegatewaypb.ExemplarsResponse{
+				Timeseries: []mimirpb.TimeSeries{
+					{Labels: mimirpb.FromLabelsToLabelAdapters(series1), Exemplars: []mimirpb.Exemplar{{Labels: []mimirpb.LabelAdapter{{Name: "trace_id", Value: "a"}}, Value: 1, TimestampMs: 15}}},
+					{Labels: mimirpb.FromLabelsToLabelAdapters(series2), Exemplars: []mimirpb.Exemplar{{Labels: []mimirpb.LabelAdapter{{Name: "trace_id", Value: "b"}}, Value: 2, TimestampMs: 12}}},
+				},
+				Hints: mockValuesHints(block2),
+			}}: {block2},
+		},
+	}
+
+	finder := &blocksFinderMock{}
+	finder.On("GetBlocks", mock.Anything, "user-1", minT, maxT).Return(finderResult, map[ulid.ULID]*bucketindex.BlockDeletionMark(nil), nil)
+
+	q := &blocksStoreQuerier{
+		ctx:         context.Background(),
+		minT:        minT,
+		maxT:        maxT,
+		userID:      "user-1",
+		finder:      finder,
+		stores:      &blocksStoreSetMock{mockedResponses: storeSetResponses},
+		consistency: NewBlocksConsistencyChecker(0, 0, log.NewNopLogger(), nil),
+		logger:      log.NewNopLogger(),
+		metrics:     newBlocksStoreQueryableMetrics(nil),
+		limits:      &blocksStoreLimitsMock{},
+	}
+
+	actual, err := q.exemplars([][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "metric")}})
+	require.NoError(t, err)
+	assert.Equal(t, []exemplar.QueryResult{
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: labels.FromStrings("trace_id", "a"), Value: 1, Ts: 15, HasTs: true}}},
+		{SeriesLabels: series2, Exemplars: []exemplar.Exemplar{{Labels: labels.FromStrings("trace_id", "b"), Value: 2, Ts: 12, HasTs: true}}},
+	}, actual)
+}
+
 func TestBlocksStoreQuerier_Labels(t *testing.T) {
 	const (
 		metricName = "test_metric"
@@ -1800,9 +2210,13 @@ type blocksStoreSetMock struct {
 
 	mockedResponses []interface{}
 	nextResult      int
+	nextResultMtx   sync.Mutex
 }
 
 func (m *blocksStoreSetMock) GetClientsFor(_ string, _ []ulid.ULID, _ map[ulid.ULID][]string) (map[BlocksStoreClient][]ulid.ULID, error) {
+	m.nextResultMtx.Lock()
+	defer m.nextResultMtx.Unlock()
+
 	if m.nextResult >= len(m.mockedResponses) {
 		panic("not enough mocked results")
 	}
@@ -1834,15 +2248,23 @@ type storeGatewayClientMock struct {
 	remoteAddr                string
 	mockedSeriesResponses     []*storepb.SeriesResponse
 	mockedSeriesErr           error
+	mockedSeriesStreamErr     error // Returned once all the mocked series responses have been received.
 	mockedLabelNamesResponse  *storepb.LabelNamesResponse
 	mockedLabelNamesErr       error
 	mockedLabelValuesResponse *storepb.LabelValuesResponse
 	mockedLabelValuesErr      error
+
+	mockedLabelValuesCardinalityResponse *storegatewaypb.LabelValuesCardinalityResponse
+	mockedLabelValuesCardinalityErr      error
+
+	mockedExemplarsResponse *storegatewaypb.ExemplarsResponse
+	mockedExemplarsErr      error
 }
 
 func (m *storeGatewayClientMock) Series(ctx context.Context, in *storepb.SeriesRequest, opts ...grpc.CallOption) (storegatewaypb.StoreGateway_SeriesClient, error) {
 	seriesClient := &storeGatewaySeriesClientMock{
 		mockedResponses: m.mockedSeriesResponses,
+		mockedErr:       m.mockedSeriesStreamErr,
 	}
 
 	return seriesClient, m.mockedSeriesErr
@@ -1856,6 +2278,14 @@ func (m *storeGatewayClientMock) LabelValues(context.Context, *storepb.LabelValu
 	return m.mockedLabelValuesResponse, m.mockedLabelValuesErr
 }
 
+func (m *storeGatewayClientMock) LabelValuesCardinality(context.Context, *storegatewaypb.LabelValuesCardinalityRequest, ...grpc.CallOption) (*storegatewaypb.LabelValuesCardinalityResponse, error) {
+	return m.mockedLabelValuesCardinalityResponse, m.mockedLabelValuesCardinalityErr
+}
+
+func (m *storeGatewayClientMock) Exemplars(context.Context, *storegatewaypb.ExemplarsRequest, ...grpc.CallOption) (*storegatewaypb.ExemplarsResponse, error) {
+	return m.mockedExemplarsResponse, m.mockedExemplarsErr
+}
+
 func (m *storeGatewayClientMock) RemoteAddress() string {
 	return m.remoteAddr
 }
@@ -1864,6 +2294,7 @@ type storeGatewaySeriesClientMock struct {
 	grpc.ClientStream
 
 	mockedResponses []*storepb.SeriesResponse
+	mockedErr       error
 }
 
 func (m *storeGatewaySeriesClientMock) Recv() (*storepb.SeriesResponse, error) {
@@ -1871,6 +2302,9 @@ func (m *storeGatewaySeriesClientMock) Recv() (*storepb.SeriesResponse, error) {
 	time.Sleep(10 * time.Millisecond)
 
 	if len(m.mockedResponses) == 0 {
+		if m.mockedErr != nil {
+			return nil, m.mockedErr
+		}
 		return nil, io.EOF
 	}
 
@@ -1880,9 +2314,11 @@ func (m *storeGatewaySeriesClientMock) Recv() (*storepb.SeriesResponse, error) {
 }
 
 type blocksStoreLimitsMock struct {
-	maxLabelsQueryLength        time.Duration
-	maxChunksPerQuery           int
-	storeGatewayTenantShardSize int
+	maxLabelsQueryLength                   time.Duration
+	maxChunksPerQuery                      int
+	storeGatewayTenantShardSize            int
+	partialResultsEnabled                  bool
+	cardinalityAnalysisMaxBlocksLabelNames int
 }
 
 func (m *blocksStoreLimitsMock) MaxLabelsQueryLength(_ string) time.Duration {
@@ -1897,6 +2333,14 @@ func (m *blocksStoreLimitsMock) StoreGatewayTenantShardSize(_ string) int {
 	return m.storeGatewayTenantShardSize
 }
 
+func (m *blocksStoreLimitsMock) CardinalityAnalysisMaxBlocksLabelNames(_ string) int {
+	return m.cardinalityAnalysisMaxBlocksLabelNames
+}
+
+func (m *blocksStoreLimitsMock) PartialResultsEnabled(_ string) bool {
+	return m.partialResultsEnabled
+}
+
 func (m *blocksStoreLimitsMock) S3SSEType(_ string) string {
 	return ""
 }
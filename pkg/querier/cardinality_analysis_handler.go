@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
@@ -26,10 +27,21 @@ const (
 	minLimit     = 0
 	maxLimit     = 500
 	defaultLimit = 20
+
+	sourceIngesters = "ingesters"
+	sourceBlocks    = "blocks"
+
+	defaultBlocksCardinalityTimeRange = 24 * time.Hour
+
+	// The ingesters track the series churn over the last hour.
+	defaultSeriesChurnWindow = time.Hour
+	maxSeriesChurnWindow     = time.Hour
 )
 
 // LabelNamesCardinalityHandler creates handler for label names cardinality endpoint.
-func LabelNamesCardinalityHandler(d Distributor, limits *validation.Overrides) http.Handler {
+// The cardinality is computed from the ingesters, or from the blocks in the long-term storage
+// if the request has the source=blocks param.
+func LabelNamesCardinalityHandler(d Distributor, blocks BlocksCardinalityQueryable, limits *validation.Overrides) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		tenantID, err := tenant.TenantID(ctx)
@@ -46,7 +58,18 @@ func LabelNamesCardinalityHandler(d Distributor, limits *validation.Overrides) h
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		response, err := d.LabelNamesAndValues(ctx, matchers)
+		source, err := extractSourceRequestParams(r, blocks)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var response *ingester_client.LabelNamesAndValuesResponse
+		if source.fromBlocks {
+			response, err = blocks.LabelNamesAndValues(ctx, source.start, source.end, matchers)
+		} else {
+			response, err = d.LabelNamesAndValues(ctx, matchers)
+		}
 		if err != nil {
 			respondFromError(err, w)
 			return
@@ -57,7 +80,9 @@ func LabelNamesCardinalityHandler(d Distributor, limits *validation.Overrides) h
 }
 
 // LabelValuesCardinalityHandler creates handler for label values cardinality endpoint.
-func LabelValuesCardinalityHandler(distributor Distributor, limits *validation.Overrides) http.Handler {
+// The cardinality is computed from the ingesters, or from the blocks in the long-term storage
+// if the request has the source=blocks param.
+func LabelValuesCardinalityHandler(distributor Distributor, blocks BlocksCardinalityQueryable, limits *validation.Overrides) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		// Guarantee request's context is for a single tenant id
@@ -76,8 +101,26 @@ func LabelValuesCardinalityHandler(distributor Distributor, limits *validation.O
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		source, err := extractSourceRequestParams(r, blocks)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		seriesCountTotal, cardinalityResponse, err := distributor.LabelValuesCardinality(ctx, labelNames, matchers)
+		var (
+			seriesCountTotal    uint64
+			cardinalityResponse *ingester_client.LabelValuesCardinalityResponse
+		)
+		if source.fromBlocks {
+			// The distributor enforces the label names limit when querying the ingesters.
+			if lbNamesLimit := limits.LabelValuesMaxCardinalityLabelNamesPerRequest(tenantID); len(labelNames) > lbNamesLimit {
+				http.Error(w, fmt.Sprintf("label values cardinality request label names limit (limit: %d actual: %d) exceeded", lbNamesLimit, len(labelNames)), http.StatusBadRequest)
+				return
+			}
+			seriesCountTotal, cardinalityResponse, err = blocks.LabelValuesCardinality(ctx, source.start, source.end, labelNames, matchers)
+		} else {
+			seriesCountTotal, cardinalityResponse, err = distributor.LabelValuesCardinality(ctx, labelNames, matchers)
+		}
 		if err != nil {
 			respondFromError(err, w)
 			return
@@ -87,6 +130,171 @@ func LabelValuesCardinalityHandler(distributor Distributor, limits *validation.O
 	})
 }
 
+// ActiveSeriesHandler creates handler for active series endpoint.
+// It returns the series which are currently active in the ingesters and match the selector,
+// or the number of active series for each value of the group_by label.
+func ActiveSeriesHandler(d Distributor, limits *validation.Overrides) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tenantID, err := tenant.TenantID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !limits.CardinalityAnalysisEnabled(tenantID) {
+			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
+			return
+		}
+
+		matchers, groupBy, limit, err := extractActiveSeriesRequestParams(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		series, err := d.ActiveSeries(ctx, matchers)
+		if err != nil {
+			respondFromError(err, w)
+			return
+		}
+
+		util.WriteJSONResponse(w, toActiveSeriesResponse(series, groupBy, limit))
+	})
+}
+
+// extractActiveSeriesRequestParams parses query params from GET requests and parses request body from POST requests
+func extractActiveSeriesRequestParams(r *http.Request) (matchers []*labels.Matcher, groupBy string, limit int, err error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, "", 0, err
+	}
+
+	matchers, err = extractSelector(r)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if len(matchers) == 0 {
+		return nil, "", 0, fmt.Errorf("'selector' param is required")
+	}
+
+	groupByParams := r.Form["group_by"]
+	if len(groupByParams) > 1 {
+		return nil, "", 0, fmt.Errorf("multiple 'group_by' params are not allowed")
+	}
+	if len(groupByParams) == 1 {
+		groupBy = groupByParams[0]
+		if !model.LabelName(groupBy).IsValid() {
+			return nil, "", 0, fmt.Errorf("invalid 'group_by' param '%v'", groupBy)
+		}
+	}
+
+	limit, err = extractLimit(r)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	return matchers, groupBy, limit, nil
+}
+
+// SeriesChurnHandler creates handler for series churn endpoint.
+func SeriesChurnHandler(d Distributor, limits *validation.Overrides) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tenantID, err := tenant.TenantID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !limits.CardinalityAnalysisEnabled(tenantID) {
+			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
+			return
+		}
+
+		window, limit, err := extractSeriesChurnRequestParams(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		items, err := d.SeriesChurn(ctx, window)
+		if err != nil {
+			respondFromError(err, w)
+			return
+		}
+
+		util.WriteJSONResponse(w, toSeriesChurnResponse(items, window, limit))
+	})
+}
+
+// extractSeriesChurnRequestParams parses query params from GET requests and parses request body from POST requests
+func extractSeriesChurnRequestParams(r *http.Request) (window time.Duration, limit int, err error) {
+	if err := r.ParseForm(); err != nil {
+		return 0, 0, err
+	}
+
+	window = defaultSeriesChurnWindow
+	if windowParam := r.FormValue("window"); windowParam != "" {
+		parsed, err := model.ParseDuration(windowParam)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid 'window' param '%v'", windowParam)
+		}
+		window = time.Duration(parsed)
+		if window <= 0 || window > maxSeriesChurnWindow {
+			return 0, 0, fmt.Errorf("'window' param must be greater than 0 and not greater than '%v'", model.Duration(maxSeriesChurnWindow))
+		}
+	}
+
+	limit, err = extractLimit(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return window, limit, nil
+}
+
+type cardinalitySource struct {
+	fromBlocks bool
+	start, end int64
+}
+
+// extractSourceRequestParams parses the `source` request param and, if the cardinality is computed from the blocks,
+// the `start` and `end` request params. If not set, the time range defaults to the last 24 hours.
+func extractSourceRequestParams(r *http.Request, blocks BlocksCardinalityQueryable) (cardinalitySource, error) {
+	switch source := r.Form.Get("source"); source {
+	case "", sourceIngesters:
+		return cardinalitySource{}, nil
+	case sourceBlocks:
+		if blocks == nil {
+			return cardinalitySource{}, fmt.Errorf("'source' param '%v' is not supported", source)
+		}
+	default:
+		return cardinalitySource{}, fmt.Errorf("invalid 'source' param '%v', supported values are '%v' and '%v'", source, sourceIngesters, sourceBlocks)
+	}
+
+	end := time.Now()
+	if param := r.Form.Get("end"); param != "" {
+		t, err := util.ParseTime(param)
+		if err != nil {
+			return cardinalitySource{}, fmt.Errorf("invalid 'end' param '%v'", param)
+		}
+		end = util.TimeFromMillis(t)
+	}
+
+	start := end.Add(-defaultBlocksCardinalityTimeRange)
+	if param := r.Form.Get("start"); param != "" {
+		t, err := util.ParseTime(param)
+		if err != nil {
+			return cardinalitySource{}, fmt.Errorf("invalid 'start' param '%v'", param)
+		}
+		start = util.TimeFromMillis(t)
+	}
+
+	if end.Before(start) {
+		return cardinalitySource{}, fmt.Errorf("'end' param must not be before 'start' param")
+	}
+
+	return cardinalitySource{fromBlocks: true, start: util.TimeToMillis(start), end: util.TimeToMillis(end)}, nil
+}
+
 func extractLabelNamesRequestParams(r *http.Request) ([]*labels.Matcher, int, error) {
 	err := r.ParseForm()
 	if err != nil {
@@ -307,3 +515,86 @@ type labelValuesCardinalityResponse struct {
 	SeriesCountTotal uint64                  `json:"series_count_total"`
 	Labels           []labelNamesCardinality `json:"labels"`
 }
+
+// toActiveSeriesResponse converts the active series to activeSeriesResponse, either listing the series
+// sorted by labels or, if groupBy is set, counting them for each value of the groupBy label.
+func toActiveSeriesResponse(series []labels.Labels, groupBy string, limit int) *activeSeriesResponse {
+	seriesCountTotal := uint64(len(series))
+
+	if groupBy == "" {
+		sort.Slice(series, func(i, j int) bool {
+			return labels.Compare(series[i], series[j]) < 0
+		})
+		if len(series) > limit {
+			series = series[:limit]
+		}
+		return &activeSeriesResponse{
+			SeriesCountTotal: seriesCountTotal,
+			Series:           series,
+		}
+	}
+
+	counts := map[string]uint64{}
+	for _, s := range series {
+		counts[s.Get(groupBy)]++
+	}
+	cardinality := make([]labelValuesCardinality, 0, len(counts))
+	for value, count := range counts {
+		cardinality = append(cardinality, labelValuesCardinality{LabelValue: value, SeriesCount: count})
+	}
+
+	return &activeSeriesResponse{
+		SeriesCountTotal: seriesCountTotal,
+		LabelName:        groupBy,
+		Cardinality:      limitLabelValuesCardinality(sortBySeriesCountAndLabelValue(cardinality), limit),
+	}
+}
+
+// toSeriesChurnResponse converts the series churn received from the distributor to seriesChurnResponse,
+// keeping the limit metric names with the highest churn.
+func toSeriesChurnResponse(items []*ingester_client.MetricSeriesChurn, window time.Duration, limit int) *seriesChurnResponse {
+	res := &seriesChurnResponse{
+		Window:  model.Duration(window).String(),
+		Metrics: make([]metricSeriesChurn, 0, len(items)),
+	}
+	for _, item := range items {
+		res.SeriesCreatedTotal += item.CreatedSeries
+		res.SeriesRemovedTotal += item.RemovedSeries
+		res.Metrics = append(res.Metrics, metricSeriesChurn{
+			MetricName:    item.MetricName,
+			SeriesCreated: item.CreatedSeries,
+			SeriesRemoved: item.RemovedSeries,
+		})
+	}
+
+	// Sort by churn in DESC order and by metric name in ASC order.
+	sort.Slice(res.Metrics, func(i, j int) bool {
+		ci := res.Metrics[i].SeriesCreated + res.Metrics[i].SeriesRemoved
+		cj := res.Metrics[j].SeriesCreated + res.Metrics[j].SeriesRemoved
+		return ci > cj || (ci == cj && res.Metrics[i].MetricName < res.Metrics[j].MetricName)
+	})
+	if len(res.Metrics) > limit {
+		res.Metrics = res.Metrics[:limit]
+	}
+	return res
+}
+
+type metricSeriesChurn struct {
+	MetricName    string `json:"metric_name"`
+	SeriesCreated uint64 `json:"series_created"`
+	SeriesRemoved uint64 `json:"series_removed"`
+}
+
+type seriesChurnResponse struct {
+	Window             string              `json:"window"`
+	SeriesCreatedTotal uint64              `json:"series_created_total"`
+	SeriesRemovedTotal uint64              `json:"series_removed_total"`
+	Metrics            []metricSeriesChurn `json:"metrics"`
+}
+
+type activeSeriesResponse struct {
+	SeriesCountTotal uint64                   `json:"series_count_total"`
+	Series           []labels.Labels          `json:"series,omitempty"`
+	LabelName        string                   `json:"label_name,omitempty"`
+	Cardinality      []labelValuesCardinality `json:"cardinality,omitempty"`
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/grafana/dskit/flagext"
 	"github.com/prometheus/common/model"
@@ -209,7 +210,7 @@ func TestLabelNamesCardinalityHandler_DistributorError(t *testing.T) {
 			limits.CardinalityAnalysisEnabled = true
 			overrides, err := validation.NewOverrides(limits, nil)
 			require.NoError(t, err)
-			handler := LabelNamesCardinalityHandler(distributor, overrides)
+			handler := LabelNamesCardinalityHandler(distributor, nil, overrides)
 			ctx := user.InjectOrgID(context.Background(), "test")
 
 			request, err := http.NewRequestWithContext(ctx, "GET", labelNamesURL, http.NoBody)
@@ -274,7 +275,7 @@ func TestLabelNamesCardinalityHandler_NegativeTests(t *testing.T) {
 			}
 			overrides, err := validation.NewOverrides(limits, nil)
 			require.NoError(t, err)
-			handler := LabelNamesCardinalityHandler(mockDistributorLabelNamesAndValues([]*client.LabelValues{}, nil), overrides)
+			handler := LabelNamesCardinalityHandler(mockDistributorLabelNamesAndValues([]*client.LabelValues{}, nil), nil, overrides)
 
 			recorder := httptest.NewRecorder()
 
@@ -631,7 +632,7 @@ func TestLabelValuesCardinalityHandler_FeatureFlag(t *testing.T) {
 			limits := validation.Limits{CardinalityAnalysisEnabled: testData.cardinalityAnalysisEnabled}
 			overrides, err := validation.NewOverrides(limits, nil)
 			require.NoError(t, err)
-			handler := LabelValuesCardinalityHandler(distributor, overrides)
+			handler := LabelValuesCardinalityHandler(distributor, nil, overrides)
 
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, testData.request)
@@ -773,12 +774,300 @@ func TestLabelValuesCardinalityHandler_DistributorError(t *testing.T) {
 }
 
 // createEnabledHandler creates a cardinalityHandler that can be either a LabelNamesCardinalityHandler or a LabelValuesCardinalityHandler
-func createEnabledHandler(t *testing.T, cardinalityHandler func(Distributor, *validation.Overrides) http.Handler, distributor *mockDistributor) http.Handler {
+func TestCardinalityHandlers_BlocksSource(t *testing.T) {
+	cardinalityResponse := &client.LabelValuesCardinalityResponse{Items: []*client.LabelValueSeriesCount{
+		{LabelName: "__name__", LabelValueSeries: map[string]uint64{"test_1": 10, "test_2": 5}},
+		{LabelName: "job", LabelValueSeries: map[string]uint64{"a": 15}},
+	}}
+
+	limits := validation.Limits{}
+	flagext.DefaultValues(&limits)
+	limits.CardinalityAnalysisEnabled = true
+	overrides, err := validation.NewOverrides(limits, nil)
+	require.NoError(t, err)
+
+	readResponse := func(t *testing.T, recorder *httptest.ResponseRecorder, v interface{}) {
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		body := recorder.Result().Body
+		defer func() { _ = body.Close() }()
+		require.NoError(t, json.NewDecoder(body).Decode(v))
+	}
+
+	t.Run("label names", func(t *testing.T) {
+		blocks := &blocksCardinalityQueryableMock{}
+		blocks.On("LabelNamesAndValues", mock.Anything, int64(10000), int64(20000), []*labels.Matcher(nil)).Return(&client.LabelNamesAndValuesResponse{Items: []*client.LabelValues{
+			{LabelName: "job", Values: []string{"a"}},
+			{LabelName: "__name__", Values: []string{"test_1", "test_2"}},
+		}}, nil)
+
+		recorder := httptest.NewRecorder()
+		LabelNamesCardinalityHandler(&mockDistributor{}, blocks, overrides).ServeHTTP(recorder, createRequest("/label_names?source=blocks&start=10&end=20", "team-a"))
+
+		responseBody := LabelNamesCardinalityResponse{}
+		readResponse(t, recorder, &responseBody)
+		require.Equal(t, LabelNamesCardinalityResponse{
+			LabelValuesCountTotal: 3,
+			LabelNamesCount:       2,
+			Cardinality: []*LabelNamesCardinalityItem{
+				{LabelName: "__name__", LabelValuesCount: 2},
+				{LabelName: "job", LabelValuesCount: 1},
+			},
+		}, responseBody)
+	})
+
+	t.Run("label values", func(t *testing.T) {
+		blocks := &blocksCardinalityQueryableMock{}
+		blocks.On("LabelValuesCardinality", mock.Anything, int64(10000), int64(20000), []model.LabelName{"__name__"}, []*labels.Matcher(nil)).Return(uint64(15), cardinalityResponse, nil)
+
+		recorder := httptest.NewRecorder()
+		LabelValuesCardinalityHandler(&mockDistributor{}, blocks, overrides).ServeHTTP(recorder, createRequest("/label_values?source=blocks&start=10&end=20&label_names[]=__name__", "team-a"))
+
+		responseBody := labelValuesCardinalityResponse{}
+		readResponse(t, recorder, &responseBody)
+		require.Equal(t, uint64(15), responseBody.SeriesCountTotal)
+		require.Len(t, responseBody.Labels, 2)
+		require.Equal(t, labelNamesCardinality{
+			LabelName:        "__name__",
+			LabelValuesCount: 2,
+			SeriesCount:      15,
+			Cardinality: []labelValuesCardinality{
+				{LabelValue: "test_1", SeriesCount: 10},
+				{LabelValue: "test_2", SeriesCount: 5},
+			},
+		}, responseBody.Labels[0])
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		tests := map[string]struct {
+			url                  string
+			blocks               BlocksCardinalityQueryable
+			expectedErrorMessage string
+		}{
+			"unknown source": {
+				url:                  "/label_names?source=unknown",
+				blocks:               &blocksCardinalityQueryableMock{},
+				expectedErrorMessage: "invalid 'source' param 'unknown', supported values are 'ingesters' and 'blocks'\n",
+			},
+			"blocks source not available": {
+				url:                  "/label_names?source=blocks",
+				expectedErrorMessage: "'source' param 'blocks' is not supported\n",
+			},
+			"invalid start": {
+				url:                  "/label_names?source=blocks&start=foo",
+				blocks:               &blocksCardinalityQueryableMock{},
+				expectedErrorMessage: "invalid 'start' param 'foo'\n",
+			},
+			"end before start": {
+				url:                  "/label_names?source=blocks&start=20&end=10",
+				blocks:               &blocksCardinalityQueryableMock{},
+				expectedErrorMessage: "'end' param must not be before 'start' param\n",
+			},
+		}
+
+		for testName, testData := range tests {
+			t.Run(testName, func(t *testing.T) {
+				recorder := httptest.NewRecorder()
+				LabelNamesCardinalityHandler(&mockDistributor{}, testData.blocks, overrides).ServeHTTP(recorder, createRequest(testData.url, "team-a"))
+
+				require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+				body := recorder.Result().Body
+				defer func() { _ = body.Close() }()
+				bodyContent, err := ioutil.ReadAll(body)
+				require.NoError(t, err)
+				require.Equal(t, testData.expectedErrorMessage, string(bodyContent))
+			})
+		}
+	})
+}
+
+func TestActiveSeriesHandler(t *testing.T) {
+	series := []labels.Labels{
+		labels.FromStrings("__name__", "metric", "job", "b"),
+		labels.FromStrings("__name__", "metric", "job", "a"),
+		labels.FromStrings("__name__", "metric", "job", "a", "pod", "1"),
+		labels.FromStrings("__name__", "metric"),
+	}
+	nameMatcher := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "__name__", "metric")}
+
+	tests := map[string]struct {
+		url                  string
+		expectedStatusCode   int
+		expectedResponse     activeSeriesResponse
+		expectedErrorMessage string
+	}{
+		"should list the active series sorted by labels": {
+			url:                "/active_series?selector=metric&limit=3",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: activeSeriesResponse{
+				SeriesCountTotal: 4,
+				Series: []labels.Labels{
+					labels.FromStrings("__name__", "metric"),
+					labels.FromStrings("__name__", "metric", "job", "a"),
+					labels.FromStrings("__name__", "metric", "job", "a", "pod", "1"),
+				},
+			},
+		},
+		"should count the active series grouped by label": {
+			url:                "/active_series?selector=metric&group_by=job",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: activeSeriesResponse{
+				SeriesCountTotal: 4,
+				LabelName:        "job",
+				Cardinality: []labelValuesCardinality{
+					{LabelValue: "a", SeriesCount: 2},
+					{LabelValue: "", SeriesCount: 1},
+					{LabelValue: "b", SeriesCount: 1},
+				},
+			},
+		},
+		"should fail if the selector is missing": {
+			url:                  "/active_series",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "'selector' param is required\n",
+		},
+		"should fail if the group_by label is invalid": {
+			url:                  "/active_series?selector=metric&group_by=1abc",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid 'group_by' param '1abc'\n",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			distributor := &mockDistributor{}
+			// Copy the series because the handler sorts them.
+			distributor.On("ActiveSeries", mock.Anything, nameMatcher).Return(append([]labels.Labels(nil), series...), nil)
+
+			handler := createEnabledHandler(t, func(d Distributor, _ BlocksCardinalityQueryable, limits *validation.Overrides) http.Handler {
+				return ActiveSeriesHandler(d, limits)
+			}, distributor)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, createRequest(testData.url, "team-a"))
+			require.Equal(t, testData.expectedStatusCode, recorder.Result().StatusCode)
+
+			body := recorder.Result().Body
+			defer func() { _ = body.Close() }()
+			bodyContent, err := ioutil.ReadAll(body)
+			require.NoError(t, err)
+
+			if testData.expectedErrorMessage != "" {
+				require.Equal(t, testData.expectedErrorMessage, string(bodyContent))
+				return
+			}
+
+			actual := activeSeriesResponse{}
+			require.NoError(t, json.Unmarshal(bodyContent, &actual))
+			require.Equal(t, testData.expectedResponse, actual)
+		})
+	}
+}
+
+func TestSeriesChurnHandler(t *testing.T) {
+	items := []*client.MetricSeriesChurn{
+		{MetricName: "metric_a", CreatedSeries: 10, RemovedSeries: 5},
+		{MetricName: "metric_b", CreatedSeries: 1},
+		{MetricName: "metric_c", CreatedSeries: 20},
+	}
+
+	tests := map[string]struct {
+		url                  string
+		expectedWindow       time.Duration
+		expectedStatusCode   int
+		expectedResponse     seriesChurnResponse
+		expectedErrorMessage string
+	}{
+		"should return the metric names with the highest churn over the default window": {
+			url:                "/series_churn?limit=2",
+			expectedWindow:     time.Hour,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: seriesChurnResponse{
+				Window:             "1h",
+				SeriesCreatedTotal: 31,
+				SeriesRemovedTotal: 5,
+				Metrics: []metricSeriesChurn{
+					{MetricName: "metric_c", SeriesCreated: 20},
+					{MetricName: "metric_a", SeriesCreated: 10, SeriesRemoved: 5},
+				},
+			},
+		},
+		"should query the series churn over the requested window": {
+			url:                "/series_churn?window=10m",
+			expectedWindow:     10 * time.Minute,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: seriesChurnResponse{
+				Window:             "10m",
+				SeriesCreatedTotal: 31,
+				SeriesRemovedTotal: 5,
+				Metrics: []metricSeriesChurn{
+					{MetricName: "metric_c", SeriesCreated: 20},
+					{MetricName: "metric_a", SeriesCreated: 10, SeriesRemoved: 5},
+					{MetricName: "metric_b", SeriesCreated: 1},
+				},
+			},
+		},
+		"should fail if the window is invalid": {
+			url:                  "/series_churn?window=abc",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid 'window' param 'abc'\n",
+		},
+		"should fail if the window is greater than the max window": {
+			url:                  "/series_churn?window=2h",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "'window' param must be greater than 0 and not greater than '1h'\n",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			distributor := &mockDistributor{}
+			distributor.On("SeriesChurn", mock.Anything, testData.expectedWindow).Return(items, nil)
+
+			handler := createEnabledHandler(t, func(d Distributor, _ BlocksCardinalityQueryable, limits *validation.Overrides) http.Handler {
+				return SeriesChurnHandler(d, limits)
+			}, distributor)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, createRequest(testData.url, "team-a"))
+			require.Equal(t, testData.expectedStatusCode, recorder.Result().StatusCode)
+
+			body := recorder.Result().Body
+			defer func() { _ = body.Close() }()
+			bodyContent, err := ioutil.ReadAll(body)
+			require.NoError(t, err)
+
+			if testData.expectedErrorMessage != "" {
+				require.Equal(t, testData.expectedErrorMessage, string(bodyContent))
+				return
+			}
+
+			actual := seriesChurnResponse{}
+			require.NoError(t, json.Unmarshal(bodyContent, &actual))
+			require.Equal(t, testData.expectedResponse, actual)
+		})
+	}
+}
+
+type blocksCardinalityQueryableMock struct {
+	mock.Mock
+}
+
+func (m *blocksCardinalityQueryableMock) LabelValuesCardinality(ctx context.Context, minT, maxT int64, labelNames []model.LabelName, matchers []*labels.Matcher) (uint64, *client.LabelValuesCardinalityResponse, error) {
+	args := m.Called(ctx, minT, maxT, labelNames, matchers)
+	return args.Get(0).(uint64), args.Get(1).(*client.LabelValuesCardinalityResponse), args.Error(2)
+}
+
+func (m *blocksCardinalityQueryableMock) LabelNamesAndValues(ctx context.Context, minT, maxT int64, matchers []*labels.Matcher) (*client.LabelNamesAndValuesResponse, error) {
+	args := m.Called(ctx, minT, maxT, matchers)
+	return args.Get(0).(*client.LabelNamesAndValuesResponse), args.Error(1)
+}
+
+func createEnabledHandler(t *testing.T, cardinalityHandler func(Distributor, BlocksCardinalityQueryable, *validation.Overrides) http.Handler, distributor *mockDistributor) http.Handler {
 	limits := validation.Limits{CardinalityAnalysisEnabled: true}
 	overrides, err := validation.NewOverrides(limits, nil)
 	require.NoError(t, err)
 
-	handler := cardinalityHandler(distributor, overrides)
+	handler := cardinalityHandler(distributor, nil, overrides)
 	return handler
 }
 
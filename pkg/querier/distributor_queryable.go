@@ -17,6 +17,8 @@ import (
 	"github.com/prometheus/prometheus/scrape"
 	"github.com/prometheus/prometheus/storage"
 
+	"github.com/grafana/dskit/tenant"
+
 	"github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/mimirpb"
 	"github.com/grafana/mimir/pkg/storage/series"
@@ -36,14 +38,18 @@ type Distributor interface {
 	MetricsMetadata(ctx context.Context) ([]scrape.MetricMetadata, error)
 	LabelNamesAndValues(ctx context.Context, matchers []*labels.Matcher) (*client.LabelNamesAndValuesResponse, error)
 	LabelValuesCardinality(ctx context.Context, labelNames []model.LabelName, matchers []*labels.Matcher) (uint64, *client.LabelValuesCardinalityResponse, error)
+	ActiveSeries(ctx context.Context, matchers []*labels.Matcher) ([]labels.Labels, error)
+	SeriesChurn(ctx context.Context, window time.Duration) ([]*client.MetricSeriesChurn, error)
+	TSDBStatus(ctx context.Context) (*client.TSDBStatusResponse, error)
 }
 
-func newDistributorQueryable(distributor Distributor, iteratorFn chunkIteratorFunc, queryIngestersWithin time.Duration, logger log.Logger) QueryableWithFilter {
+func newDistributorQueryable(distributor Distributor, iteratorFn chunkIteratorFunc, queryIngestersWithin time.Duration, limits PartialResultsLimits, logger log.Logger) QueryableWithFilter {
 	return distributorQueryable{
 		logger:               logger,
 		distributor:          distributor,
 		iteratorFn:           iteratorFn,
 		queryIngestersWithin: queryIngestersWithin,
+		limits:               limits,
 	}
 }
 
@@ -52,6 +58,7 @@ type distributorQueryable struct {
 	distributor          Distributor
 	iteratorFn           chunkIteratorFunc
 	queryIngestersWithin time.Duration
+	limits               PartialResultsLimits
 }
 
 func (d distributorQueryable) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
@@ -63,6 +70,7 @@ func (d distributorQueryable) Querier(ctx context.Context, mint, maxt int64) (st
 		maxt:                 maxt,
 		chunkIterFn:          d.iteratorFn,
 		queryIngestersWithin: d.queryIngestersWithin,
+		limits:               d.limits,
 	}, nil
 }
 
@@ -78,6 +86,7 @@ type distributorQuerier struct {
 	mint, maxt           int64
 	chunkIterFn          chunkIteratorFunc
 	queryIngestersWithin time.Duration
+	limits               PartialResultsLimits
 }
 
 // Select implements storage.Querier interface.
@@ -102,7 +111,7 @@ func (q *distributorQuerier) Select(_ bool, sp *storage.SelectHints, matchers ..
 	if sp.Func == "series" {
 		ms, err := q.distributor.MetricsForLabelMatchers(ctx, model.Time(minT), model.Time(maxT), matchers...)
 		if err != nil {
-			return storage.ErrSeriesSet(err)
+			return q.partialResultsSeriesSet(spanlog, minT, maxT, err)
 		}
 		return series.MetricsToSeriesSet(ms)
 	}
@@ -113,7 +122,7 @@ func (q *distributorQuerier) Select(_ bool, sp *storage.SelectHints, matchers ..
 func (q *distributorQuerier) streamingSelect(ctx context.Context, minT, maxT int64, matchers []*labels.Matcher) storage.SeriesSet {
 	results, err := q.distributor.QueryStream(ctx, model.Time(minT), model.Time(maxT), matchers...)
 	if err != nil {
-		return storage.ErrSeriesSet(err)
+		return q.partialResultsSeriesSet(spanlogger.FromContext(ctx, q.logger), minT, maxT, err)
 	}
 
 	sets := []storage.SeriesSet(nil)
@@ -167,8 +176,12 @@ func (q *distributorQuerier) LabelValues(name string, matchers ...*labels.Matche
 	}
 
 	lvs, err := q.distributor.LabelValuesForLabelName(q.ctx, minT, model.Time(q.maxt), model.LabelName(name), matchers...)
+	if err != nil {
+		warnings, err := q.partialResultsWarnings(q.logger, int64(minT), q.maxt, err)
+		return nil, warnings, err
+	}
 
-	return lvs, nil, err
+	return lvs, nil, nil
 }
 
 func (q *distributorQuerier) LabelNames(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
@@ -183,7 +196,33 @@ func (q *distributorQuerier) LabelNames(matchers ...*labels.Matcher) ([]string,
 	}
 
 	ln, err := q.distributor.LabelNames(ctx, minT, model.Time(q.maxt), matchers...)
-	return ln, nil, err
+	if err != nil {
+		warnings, err := q.partialResultsWarnings(log, int64(minT), q.maxt, err)
+		return nil, warnings, err
+	}
+
+	return ln, nil, nil
+}
+
+// partialResultsWarnings returns the warnings describing the data which couldn't be fetched from ingesters
+// if partial results are enabled for the query and the error can be tolerated, otherwise returns the error.
+func (q *distributorQuerier) partialResultsWarnings(logger log.Logger, minT, maxT int64, err error) (storage.Warnings, error) {
+	userID, tenantErr := tenant.TenantID(q.ctx)
+	if tenantErr != nil || !isPartialResultsEnabled(q.ctx, q.limits, userID) || !isPartialResultsTolerableError(err) {
+		return nil, err
+	}
+
+	level.Warn(logger).Log("msg", "failed to query ingesters, returning partial results", "err", err)
+	return storage.Warnings{newIngestersUnavailableWarning(minT, maxT, err)}, nil
+}
+
+// partialResultsSeriesSet is like partialResultsWarnings but returns a storage.SeriesSet.
+func (q *distributorQuerier) partialResultsSeriesSet(logger log.Logger, minT, maxT int64, err error) storage.SeriesSet {
+	warnings, err := q.partialResultsWarnings(logger, minT, maxT, err)
+	if err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+	return series.NewSeriesSetWithWarnings(storage.EmptySeriesSet(), warnings)
 }
 
 func (q *distributorQuerier) Close() error {
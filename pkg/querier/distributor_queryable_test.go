@@ -7,6 +7,7 @@ package querier
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	"github.com/grafana/mimir/pkg/storage/chunk"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/chunkcompat"
+	"github.com/grafana/mimir/pkg/util/validation"
 )
 
 func TestDistributorQuerier_SelectShouldHonorQueryIngestersWithin(t *testing.T) {
@@ -87,7 +89,7 @@ func TestDistributorQuerier_SelectShouldHonorQueryIngestersWithin(t *testing.T)
 			distributor.On("MetricsForLabelMatchers", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.Metric{}, nil)
 
 			ctx := user.InjectOrgID(context.Background(), "test")
-			queryable := newDistributorQueryable(distributor, nil, testData.queryIngestersWithin, log.NewNopLogger())
+			queryable := newDistributorQueryable(distributor, nil, testData.queryIngestersWithin, nil, log.NewNopLogger())
 			querier, err := queryable.Querier(ctx, testData.queryMinT, testData.queryMaxT)
 			require.NoError(t, err)
 
@@ -112,7 +114,7 @@ func TestDistributorQuerier_SelectShouldHonorQueryIngestersWithin(t *testing.T)
 
 func TestDistributorQueryableFilter(t *testing.T) {
 	d := &mockDistributor{}
-	dq := newDistributorQueryable(d, nil, 1*time.Hour, log.NewNopLogger())
+	dq := newDistributorQueryable(d, nil, 1*time.Hour, nil, log.NewNopLogger())
 
 	now := time.Now()
 
@@ -126,6 +128,77 @@ func TestDistributorQueryableFilter(t *testing.T) {
 	require.False(t, dq.UseQueryable(now.Add(time.Hour).Add(1*time.Millisecond), queryMinT, queryMaxT))
 }
 
+func TestDistributorQuerier_PartialResults(t *testing.T) {
+	const mint, maxt = 0, 7200000
+
+	tests := map[string]struct {
+		queryErr        error
+		limits          PartialResultsLimits
+		requested       bool
+		expectedErr     string
+		expectedWarning string
+	}{
+		"should fail the query if partial results are disabled": {
+			queryErr:    errors.New("at least 2 live replicas required"),
+			limits:      partialResultsLimitsMock(false),
+			expectedErr: "at least 2 live replicas required",
+		},
+		"should return partial results if enabled for the tenant": {
+			queryErr:        errors.New("at least 2 live replicas required"),
+			limits:          partialResultsLimitsMock(true),
+			expectedWarning: "partial results: ingesters could not be queried and data from 1970-01-01T00:00:00Z to 1970-01-01T02:00:00Z may be missing from the results (at least 2 live replicas required)",
+		},
+		"should return partial results if requested": {
+			queryErr:        errors.New("at least 2 live replicas required"),
+			limits:          partialResultsLimitsMock(false),
+			requested:       true,
+			expectedWarning: "partial results: ingesters could not be queried and data from 1970-01-01T00:00:00Z to 1970-01-01T02:00:00Z may be missing from the results (at least 2 live replicas required)",
+		},
+		"should fail the query on limit errors even if partial results are enabled": {
+			queryErr:    validation.LimitError("limit reached"),
+			limits:      partialResultsLimitsMock(true),
+			expectedErr: "limit reached",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			d := &mockDistributor{}
+			d.On("QueryStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return((*client.QueryStreamResponse)(nil), testData.queryErr)
+			d.On("LabelNames", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]string(nil), testData.queryErr)
+
+			ctx := user.InjectOrgID(context.Background(), "user-1")
+			if testData.requested {
+				ctx = ContextWithPartialResultsRequested(ctx)
+			}
+
+			queryable := newDistributorQueryable(d, nil, 0, testData.limits, log.NewNopLogger())
+			querier, err := queryable.Querier(ctx, mint, maxt)
+			require.NoError(t, err)
+
+			set := querier.Select(true, &storage.SelectHints{Start: mint, End: maxt})
+			assert.False(t, set.Next())
+
+			names, warnings, err := querier.LabelNames()
+			assert.Empty(t, names)
+
+			if testData.expectedErr != "" {
+				assert.EqualError(t, set.Err(), testData.expectedErr)
+				assert.EqualError(t, err, testData.expectedErr)
+				return
+			}
+
+			require.NoError(t, set.Err())
+			require.Len(t, set.Warnings(), 1)
+			assert.EqualError(t, set.Warnings()[0], testData.expectedWarning)
+
+			require.NoError(t, err)
+			require.Len(t, warnings, 1)
+			assert.EqualError(t, warnings[0], testData.expectedWarning)
+		})
+	}
+}
+
 func TestIngesterStreaming(t *testing.T) {
 	const mint, maxt = 0, 10
 
@@ -164,7 +237,7 @@ func TestIngesterStreaming(t *testing.T) {
 		nil)
 
 	ctx := user.InjectOrgID(context.Background(), "0")
-	queryable := newDistributorQueryable(d, mergeChunks, 0, log.NewNopLogger())
+	queryable := newDistributorQueryable(d, mergeChunks, 0, nil, log.NewNopLogger())
 	querier, err := queryable.Querier(ctx, mint, maxt)
 	require.NoError(t, err)
 
@@ -240,7 +313,7 @@ func TestIngesterStreamingMixedResults(t *testing.T) {
 		nil)
 
 	ctx := user.InjectOrgID(context.Background(), "0")
-	queryable := newDistributorQueryable(d, mergeChunks, 0, log.NewNopLogger())
+	queryable := newDistributorQueryable(d, mergeChunks, 0, nil, log.NewNopLogger())
 	querier, err := queryable.Querier(ctx, mint, maxt)
 	require.NoError(t, err)
 
@@ -272,7 +345,7 @@ func TestDistributorQuerier_LabelNames(t *testing.T) {
 			d.On("LabelNames", mock.Anything, model.Time(mint), model.Time(maxt), someMatchers).
 				Return(labelNames, nil)
 
-			queryable := newDistributorQueryable(d, nil, 0, log.NewNopLogger())
+			queryable := newDistributorQueryable(d, nil, 0, nil, log.NewNopLogger())
 			querier, err := queryable.Querier(context.Background(), mint, maxt)
 			require.NoError(t, err)
 
@@ -322,7 +395,7 @@ func BenchmarkDistributorQueryable_Select(b *testing.B) {
 	d.On("QueryStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(response, nil)
 
 	ctx := user.InjectOrgID(context.Background(), "0")
-	queryable := newDistributorQueryable(d, mergeChunks, 0, log.NewNopLogger())
+	queryable := newDistributorQueryable(d, mergeChunks, 0, nil, log.NewNopLogger())
 	querier, err := queryable.Querier(ctx, math.MinInt64, math.MaxInt64)
 	require.NoError(b, err)
 
@@ -410,3 +483,24 @@ func (m *mockDistributor) LabelValuesCardinality(ctx context.Context, labelNames
 	args := m.Called(ctx, labelNames, matchers)
 	return args.Get(0).(uint64), args.Get(1).(*client.LabelValuesCardinalityResponse), args.Error(2)
 }
+
+func (m *mockDistributor) ActiveSeries(ctx context.Context, matchers []*labels.Matcher) ([]labels.Labels, error) {
+	args := m.Called(ctx, matchers)
+	return args.Get(0).([]labels.Labels), args.Error(1)
+}
+
+func (m *mockDistributor) SeriesChurn(ctx context.Context, window time.Duration) ([]*client.MetricSeriesChurn, error) {
+	args := m.Called(ctx, window)
+	return args.Get(0).([]*client.MetricSeriesChurn), args.Error(1)
+}
+
+func (m *mockDistributor) TSDBStatus(ctx context.Context) (*client.TSDBStatusResponse, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*client.TSDBStatusResponse), args.Error(1)
+}
+
+type partialResultsLimitsMock bool
+
+func (m partialResultsLimitsMock) PartialResultsEnabled(_ string) bool {
+	return bool(m)
+}
@@ -8,7 +8,12 @@ package querier
 import (
 	"net/http"
 
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/prometheus/scrape"
+
 	"github.com/grafana/mimir/pkg/util"
+	util_log "github.com/grafana/mimir/pkg/util/log"
 )
 
 type metricMetadata struct {
@@ -29,8 +34,10 @@ type metadataResult struct {
 }
 
 // MetadataHandler returns metric metadata held by Mimir for a given tenant.
-// It is kept and returned as a set.
-func MetadataHandler(d Distributor) http.Handler {
+// It is kept and returned as a set. If blocks is not nil, the metadata stored in the
+// long-term storage is returned for the metrics whose metadata is not held by the ingesters. If the
+// stored metadata can't be read, the error is logged and the metadata held by the ingesters is returned.
+func MetadataHandler(d Distributor, blocks BlocksMetadataQueryable, logger log.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp, err := d.MetricsMetadata(r.Context())
 		if err != nil {
@@ -39,6 +46,15 @@ func MetadataHandler(d Distributor) http.Handler {
 			return
 		}
 
+		if blocks != nil {
+			stored, err := blocks.MetricsMetadata(r.Context())
+			if err != nil {
+				level.Warn(util_log.WithContext(r.Context(), logger)).Log("msg", "failed to read the metric metadata stored in the long-term storage, returning the metadata held by the ingesters only", "err", err)
+			} else {
+				resp = mergeStoredMetricsMetadata(resp, stored)
+			}
+		}
+
 		// Put all the elements of the pseudo-set into a map of slices for marshalling.
 		metrics := map[string][]metricMetadata{}
 		for _, m := range resp {
@@ -54,3 +70,20 @@ func MetadataHandler(d Distributor) http.Handler {
 		util.WriteJSONResponse(w, metadataResult{Status: statusSuccess, Data: metrics})
 	})
 }
+
+// mergeStoredMetricsMetadata adds the stored metadata of the metrics not found in the ingesters metadata,
+// which is more recent than the stored one.
+func mergeStoredMetricsMetadata(ingesters, stored []scrape.MetricMetadata) []scrape.MetricMetadata {
+	found := make(map[string]struct{}, len(ingesters))
+	for _, m := range ingesters {
+		found[m.Metric] = struct{}{}
+	}
+
+	for _, m := range stored {
+		if _, ok := found[m.Metric]; !ok {
+			ingesters = append(ingesters, m)
+		}
+	}
+
+	return ingesters
+}
@@ -6,12 +6,14 @@
 package querier
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-kit/log"
 	"github.com/prometheus/prometheus/scrape"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -25,7 +27,99 @@ func TestMetadataHandler_Success(t *testing.T) {
 		},
 		nil)
 
-	handler := MetadataHandler(d)
+	handler := MetadataHandler(d, nil, log.NewNopLogger())
+
+	request, err := http.NewRequest("GET", "/metadata", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+
+	expectedJSON := `
+	{
+		"status": "success",
+		"data": {
+			"alertmanager_dispatcher_aggregation_groups": [
+				{
+					"help": "Number of active aggregation groups",
+					"type": "gauge",
+					"unit": ""
+				}
+			]
+		}
+	}
+	`
+
+	require.JSONEq(t, expectedJSON, string(responseBody))
+}
+
+func TestMetadataHandler_StoredMetadataFallback(t *testing.T) {
+	d := &mockDistributor{}
+	d.On("MetricsMetadata", mock.Anything).Return(
+		[]scrape.MetricMetadata{
+			{Metric: "alertmanager_dispatcher_aggregation_groups", Help: "Number of active aggregation groups", Type: "gauge", Unit: ""},
+		},
+		nil)
+
+	// The stored metadata is returned only for the metrics not held by the ingesters.
+	blocks := &blocksMetadataQueryableMock{metadata: []scrape.MetricMetadata{
+		{Metric: "alertmanager_dispatcher_aggregation_groups", Help: "Outdated help", Type: "gauge", Unit: ""},
+		{Metric: "alertmanager_alerts", Help: "How many alerts by state", Type: "gauge", Unit: ""},
+	}}
+
+	handler := MetadataHandler(d, blocks, log.NewNopLogger())
+
+	request, err := http.NewRequest("GET", "/metadata", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+
+	expectedJSON := `
+	{
+		"status": "success",
+		"data": {
+			"alertmanager_dispatcher_aggregation_groups": [
+				{
+					"help": "Number of active aggregation groups",
+					"type": "gauge",
+					"unit": ""
+				}
+			],
+			"alertmanager_alerts": [
+				{
+					"help": "How many alerts by state",
+					"type": "gauge",
+					"unit": ""
+				}
+			]
+		}
+	}
+	`
+
+	require.JSONEq(t, expectedJSON, string(responseBody))
+}
+
+func TestMetadataHandler_StoredMetadataError(t *testing.T) {
+	d := &mockDistributor{}
+	d.On("MetricsMetadata", mock.Anything).Return(
+		[]scrape.MetricMetadata{
+			{Metric: "alertmanager_dispatcher_aggregation_groups", Help: "Number of active aggregation groups", Type: "gauge", Unit: ""},
+		},
+		nil)
+
+	// The metadata held by the ingesters is returned if the stored metadata can't be read.
+	blocks := &blocksMetadataQueryableMock{err: fmt.Errorf("bucket unavailable")}
+
+	handler := MetadataHandler(d, blocks, log.NewNopLogger())
 
 	request, err := http.NewRequest("GET", "/metadata", nil)
 	require.NoError(t, err)
@@ -59,7 +153,7 @@ func TestMetadataHandler_Error(t *testing.T) {
 	d := &mockDistributor{}
 	d.On("MetricsMetadata", mock.Anything).Return([]scrape.MetricMetadata{}, fmt.Errorf("no user id"))
 
-	handler := MetadataHandler(d)
+	handler := MetadataHandler(d, nil, log.NewNopLogger())
 
 	request, err := http.NewRequest("GET", "/metadata", nil)
 	require.NoError(t, err)
@@ -80,3 +174,12 @@ func TestMetadataHandler_Error(t *testing.T) {
 
 	require.JSONEq(t, expectedJSON, string(responseBody))
 }
+
+type blocksMetadataQueryableMock struct {
+	metadata []scrape.MetricMetadata
+	err      error
+}
+
+func (m *blocksMetadataQueryableMock) MetricsMetadata(context.Context) ([]scrape.MetricMetadata, error) {
+	return m.metadata, m.err
+}
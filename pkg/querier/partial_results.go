@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+
+	"github.com/grafana/mimir/pkg/storage/tsdb/bucketindex"
+	"github.com/grafana/mimir/pkg/util"
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+const (
+	// PartialResponseParam is the request parameter used to request partial results for a single query.
+	PartialResponseParam = "partial_response"
+)
+
+type partialResultsContextKey int
+
+const partialResultsRequestedKey partialResultsContextKey = 0
+
+// PartialResultsLimits is the interface that should be implemented by the limits provider
+// to enable partial results per-tenant.
+type PartialResultsLimits interface {
+	PartialResultsEnabled(userID string) bool
+}
+
+// ContextWithPartialResultsRequested returns a new context marking partial results as requested
+// by the client for the query run with it.
+func ContextWithPartialResultsRequested(ctx context.Context) context.Context {
+	return context.WithValue(ctx, partialResultsRequestedKey, true)
+}
+
+// IsPartialResultsRequested returns whether partial results have been requested by the client
+// for the query run with the input context.
+func IsPartialResultsRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(partialResultsRequestedKey).(bool)
+	return requested
+}
+
+// PartialResultsMiddleware marks partial results as requested in the request context
+// if the request has the partial_response=true parameter.
+func PartialResultsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Invalid values are ignored, like Prometheus does for the unknown parameters.
+		if requested, err := strconv.ParseBool(r.FormValue(PartialResponseParam)); err == nil && requested {
+			r = r.WithContext(ContextWithPartialResultsRequested(r.Context()))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isPartialResultsEnabled returns whether partial results should be returned for the query run
+// with the input context, either because they've been requested by the client or enabled for the tenant.
+func isPartialResultsEnabled(ctx context.Context, limits PartialResultsLimits, userID string) bool {
+	return IsPartialResultsRequested(ctx) || (limits != nil && limits.PartialResultsEnabled(userID))
+}
+
+// isPartialResultsTolerableError returns whether the input error can be turned into a warning
+// when partial results are enabled. Limit errors and canceled queries always fail the query.
+func isPartialResultsTolerableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var limitErr validation.LimitError
+	return !errors.As(err, &limitErr)
+}
+
+// newMissingBlocksWarning returns the warning describing the blocks which couldn't be queried.
+func newMissingBlocksWarning(missing []ulid.ULID, knownBlocks bucketindex.Blocks, cause error) error {
+	ranges := make(map[ulid.ULID]string, len(knownBlocks))
+	for _, b := range knownBlocks {
+		ranges[b.ID] = fmt.Sprintf("%s from %s to %s", b.ID.String(), util.TimeFromMillis(b.MinTime).UTC().Format(time.RFC3339), util.TimeFromMillis(b.MaxTime).UTC().Format(time.RFC3339))
+	}
+
+	descs := make([]string, 0, len(missing))
+	for _, id := range missing {
+		if desc, ok := ranges[id]; ok {
+			descs = append(descs, desc)
+		} else {
+			descs = append(descs, id.String())
+		}
+	}
+
+	msg := fmt.Sprintf("partial results: some blocks could not be queried from the store-gateways and their data is missing from the results: %s", strings.Join(descs, ", "))
+	if cause != nil {
+		msg = fmt.Sprintf("%s (%s)", msg, cause.Error())
+	}
+	return errors.New(msg)
+}
+
+// newIngestersUnavailableWarning returns the warning describing the time range which couldn't be
+// queried from the ingesters.
+func newIngestersUnavailableWarning(minT, maxT int64, cause error) error {
+	return fmt.Errorf("partial results: ingesters could not be queried and data from %s to %s may be missing from the results (%s)",
+		util.TimeFromMillis(minT).UTC().Format(time.RFC3339), util.TimeFromMillis(maxT).UTC().Format(time.RFC3339), cause.Error())
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+func TestPartialResultsMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		expected bool
+	}{
+		"parameter not set": {
+			url:      "/api/v1/query?query=up",
+			expected: false,
+		},
+		"parameter set to true": {
+			url:      "/api/v1/query?query=up&partial_response=true",
+			expected: true,
+		},
+		"parameter set to false": {
+			url:      "/api/v1/query?query=up&partial_response=false",
+			expected: false,
+		},
+		"parameter set to an invalid value": {
+			url:      "/api/v1/query?query=up&partial_response=maybe",
+			expected: false,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var actual bool
+			handler := PartialResultsMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				actual = IsPartialResultsRequested(r.Context())
+			}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", testData.url, nil))
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestIsPartialResultsTolerableError(t *testing.T) {
+	assert.True(t, isPartialResultsTolerableError(fmt.Errorf("at least 2 live replicas required")))
+	assert.False(t, isPartialResultsTolerableError(context.Canceled))
+	assert.False(t, isPartialResultsTolerableError(fmt.Errorf("query failed: %w", context.DeadlineExceeded)))
+	assert.False(t, isPartialResultsTolerableError(validation.LimitError("limit reached")))
+}
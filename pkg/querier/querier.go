@@ -30,6 +30,7 @@ import (
 	"github.com/grafana/mimir/pkg/querier/iterators"
 	"github.com/grafana/mimir/pkg/storage/chunk"
 	"github.com/grafana/mimir/pkg/storage/lazyquery"
+	"github.com/grafana/mimir/pkg/storage/series"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/activitytracker"
 	"github.com/grafana/mimir/pkg/util/limiter"
@@ -51,6 +52,9 @@ type Config struct {
 
 	ShuffleShardingIngestersLookbackPeriod time.Duration `yaml:"shuffle_sharding_ingesters_lookback_period" category:"advanced"`
 
+	QueryStoredMetricsMetadata    bool          `yaml:"query_stored_metrics_metadata" category:"experimental"`
+	StoredMetricsMetadataCacheTTL time.Duration `yaml:"stored_metrics_metadata_cache_ttl" category:"experimental"`
+
 	// PromQL engine config.
 	EngineConfig engine.Config `yaml:",inline"`
 }
@@ -71,6 +75,9 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.QueryStoreAfter, "querier.query-store-after", 0, "The time after which a metric should be queried from storage and not just ingesters. 0 means all queries are sent to store. If this option is enabled, the time range of the query sent to the store-gateway will be manipulated to ensure the query end is not more recent than 'now - query-store-after'.")
 	f.DurationVar(&cfg.ShuffleShardingIngestersLookbackPeriod, "querier.shuffle-sharding-ingesters-lookback-period", 0, "When distributor's sharding strategy is shuffle-sharding and this setting is > 0, queriers fetch in-memory series from the minimum set of required ingesters, selecting only ingesters which may have received series since 'now - lookback period'. The lookback period should be greater or equal than the configured -querier.query-store-after and -querier.query-ingesters-within. If this setting is 0, queriers always query all ingesters (ingesters shuffle sharding on read path is disabled).")
 
+	f.BoolVar(&cfg.QueryStoredMetricsMetadata, "querier.query-stored-metrics-metadata", false, "True to fall back to the metric metadata stored in the long-term storage for the metrics whose metadata is not held by the ingesters. The metadata is stored in the long-term storage when -ingester.metadata-upload-interval is set.")
+	f.DurationVar(&cfg.StoredMetricsMetadataCacheTTL, "querier.stored-metrics-metadata-cache-ttl", 5*time.Minute, "How long the metric metadata read from the long-term storage is cached in memory by each querier. 0 to disable the cache.")
+
 	cfg.EngineConfig.RegisterFlags(f)
 }
 
@@ -105,7 +112,7 @@ func getChunksIteratorFunction(cfg Config) chunkIteratorFunc {
 func New(cfg Config, limits *validation.Overrides, distributor Distributor, stores []QueryableWithFilter, reg prometheus.Registerer, logger log.Logger, tracker *activitytracker.ActivityTracker) (storage.SampleAndChunkQueryable, storage.ExemplarQueryable, *promql.Engine) {
 	iteratorFunc := getChunksIteratorFunction(cfg)
 
-	distributorQueryable := newDistributorQueryable(distributor, iteratorFunc, cfg.QueryIngestersWithin, logger)
+	distributorQueryable := newDistributorQueryable(distributor, iteratorFunc, cfg.QueryIngestersWithin, limits, logger)
 
 	ns := make([]QueryableWithFilter, len(stores))
 	for ix, s := range stores {
@@ -391,6 +398,7 @@ func (q querier) mergeSeriesSets(sets []storage.SeriesSet) storage.SeriesSet {
 
 	otherSets := []storage.SeriesSet(nil)
 	chunks := []chunk.Chunk(nil)
+	warnings := storage.Warnings(nil)
 
 	for _, set := range sets {
 		nonChunkSeries := []storage.Series(nil)
@@ -411,21 +419,32 @@ func (q querier) mergeSeriesSets(sets []storage.SeriesSet) storage.SeriesSet {
 		} else if len(nonChunkSeries) > 0 {
 			otherSets = append(otherSets, &sliceSeriesSet{series: nonChunkSeries, ix: -1})
 		}
+
+		// Keep track of the warnings (eg. partial results), since the sets are consumed here.
+		warnings = append(warnings, set.Warnings()...)
 	}
 
 	if len(chunks) == 0 {
-		return storage.NewMergeSeriesSet(otherSets, storage.ChainedSeriesMerge)
+		return withWarnings(storage.NewMergeSeriesSet(otherSets, storage.ChainedSeriesMerge), warnings)
 	}
 
 	// partitionChunks returns set with sorted series, so it can be used by NewMergeSeriesSet
 	chunksSet := partitionChunks(chunks, q.mint, q.maxt, q.chunkIterFn)
 
 	if len(otherSets) == 0 {
-		return chunksSet
+		return withWarnings(chunksSet, warnings)
 	}
 
 	otherSets = append(otherSets, chunksSet)
-	return storage.NewMergeSeriesSet(otherSets, storage.ChainedSeriesMerge)
+	return withWarnings(storage.NewMergeSeriesSet(otherSets, storage.ChainedSeriesMerge), warnings)
+}
+
+// withWarnings returns the input set with the additional warnings, if any.
+func withWarnings(set storage.SeriesSet, warnings storage.Warnings) storage.SeriesSet {
+	if len(warnings) == 0 {
+		return set
+	}
+	return series.NewSeriesSetWithWarnings(set, warnings)
 }
 
 type sliceSeriesSet struct {
@@ -868,6 +868,18 @@ func (m *errDistributor) LabelValuesCardinality(ctx context.Context, labelNames
 	return 0, nil, errDistributorError
 }
 
+func (m *errDistributor) ActiveSeries(ctx context.Context, matchers []*labels.Matcher) ([]labels.Labels, error) {
+	return nil, errDistributorError
+}
+
+func (m *errDistributor) SeriesChurn(ctx context.Context, window time.Duration) ([]*client.MetricSeriesChurn, error) {
+	return nil, errDistributorError
+}
+
+func (m *errDistributor) TSDBStatus(ctx context.Context) (*client.TSDBStatusResponse, error) {
+	return nil, errDistributorError
+}
+
 type emptyDistributor struct{}
 
 func (d *emptyDistributor) LabelNamesAndValues(_ context.Context, _ []*labels.Matcher) (*client.LabelNamesAndValuesResponse, error) {
@@ -906,6 +918,18 @@ func (d *emptyDistributor) LabelValuesCardinality(ctx context.Context, labelName
 	return 0, nil, nil
 }
 
+func (d *emptyDistributor) ActiveSeries(ctx context.Context, matchers []*labels.Matcher) ([]labels.Labels, error) {
+	return nil, nil
+}
+
+func (d *emptyDistributor) SeriesChurn(ctx context.Context, window time.Duration) ([]*client.MetricSeriesChurn, error) {
+	return nil, nil
+}
+
+func (d *emptyDistributor) TSDBStatus(ctx context.Context) (*client.TSDBStatusResponse, error) {
+	return nil, nil
+}
+
 func TestQuerier_QueryStoreAfterConfig(t *testing.T) {
 	testCases := []struct {
 		name                 string
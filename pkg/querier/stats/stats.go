@@ -123,6 +123,172 @@ func (s *Stats) LoadShardedQueries() uint32 {
 	return atomic.LoadUint32(&s.ShardedQueries)
 }
 
+// AddQueueTime adds some time to the queue time counter.
+func (s *Stats) AddQueueTime(t time.Duration) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddInt64((*int64)(&s.QueueTime), int64(t))
+}
+
+// LoadQueueTime returns current queue time.
+func (s *Stats) LoadQueueTime() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	return time.Duration(atomic.LoadInt64((*int64)(&s.QueueTime)))
+}
+
+func (s *Stats) AddSplitQueries(num uint32) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint32(&s.SplitQueries, num)
+}
+
+func (s *Stats) LoadSplitQueries() uint32 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint32(&s.SplitQueries)
+}
+
+func (s *Stats) AddResultsCacheHits(num uint32) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint32(&s.ResultsCacheHits, num)
+}
+
+func (s *Stats) LoadResultsCacheHits() uint32 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint32(&s.ResultsCacheHits)
+}
+
+// AddIngesterWallTime adds some time to the ingester wall time counter.
+func (s *Stats) AddIngesterWallTime(t time.Duration) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddInt64((*int64)(&s.IngesterWallTime), int64(t))
+}
+
+// LoadIngesterWallTime returns current ingester wall time.
+func (s *Stats) LoadIngesterWallTime() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	return time.Duration(atomic.LoadInt64((*int64)(&s.IngesterWallTime)))
+}
+
+// AddIngesterSelectTime adds some time to the ingester select time counter.
+func (s *Stats) AddIngesterSelectTime(t time.Duration) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddInt64((*int64)(&s.IngesterSelectTime), int64(t))
+}
+
+// LoadIngesterSelectTime returns current ingester select time.
+func (s *Stats) LoadIngesterSelectTime() time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	return time.Duration(atomic.LoadInt64((*int64)(&s.IngesterSelectTime)))
+}
+
+func (s *Stats) AddIngesterSeries(series uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterSeriesCount, series)
+}
+
+func (s *Stats) LoadIngesterSeries() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterSeriesCount)
+}
+
+func (s *Stats) AddIngesterChunks(chunks uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterChunksCount, chunks)
+}
+
+func (s *Stats) LoadIngesterChunks() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterChunksCount)
+}
+
+func (s *Stats) AddIngesterChunkBytes(bytes uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterChunkBytes, bytes)
+}
+
+func (s *Stats) LoadIngesterChunkBytes() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterChunkBytes)
+}
+
+func (s *Stats) AddIngesterHeadChunks(chunks uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterHeadChunksCount, chunks)
+}
+
+func (s *Stats) LoadIngesterHeadChunks() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterHeadChunksCount)
+}
+
+func (s *Stats) AddIngesterBlockChunks(chunks uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterBlockChunksCount, chunks)
+}
+
+func (s *Stats) LoadIngesterBlockChunks() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterBlockChunksCount)
+}
+
 // Merge the provided Stats into this one.
 func (s *Stats) Merge(other *Stats) {
 	if s == nil || other == nil {
@@ -134,6 +300,16 @@ func (s *Stats) Merge(other *Stats) {
 	s.AddFetchedChunkBytes(other.LoadFetchedChunkBytes())
 	s.AddFetchedChunks(other.LoadFetchedChunks())
 	s.AddShardedQueries(other.LoadShardedQueries())
+	s.AddQueueTime(other.LoadQueueTime())
+	s.AddSplitQueries(other.LoadSplitQueries())
+	s.AddResultsCacheHits(other.LoadResultsCacheHits())
+	s.AddIngesterWallTime(other.LoadIngesterWallTime())
+	s.AddIngesterSelectTime(other.LoadIngesterSelectTime())
+	s.AddIngesterSeries(other.LoadIngesterSeries())
+	s.AddIngesterChunks(other.LoadIngesterChunks())
+	s.AddIngesterChunkBytes(other.LoadIngesterChunkBytes())
+	s.AddIngesterHeadChunks(other.LoadIngesterHeadChunks())
+	s.AddIngesterBlockChunks(other.LoadIngesterBlockChunks())
 }
 
 func ShouldTrackHTTPGRPCResponse(r *httpgrpc.HTTPResponse) bool {
@@ -40,6 +40,26 @@ type Stats struct {
 	FetchedChunksCount uint64 `protobuf:"varint,4,opt,name=fetched_chunks_count,json=fetchedChunksCount,proto3" json:"fetched_chunks_count,omitempty"`
 	// The number of sharded queries executed. 0 if sharding is disabled or the query can't be sharded.
 	ShardedQueries uint32 `protobuf:"varint,5,opt,name=sharded_queries,json=shardedQueries,proto3" json:"sharded_queries,omitempty"`
+	// The sum of all the time spent by the query requests in the query-frontend or query-scheduler queue.
+	QueueTime time.Duration `protobuf:"bytes,6,opt,name=queue_time,json=queueTime,proto3,stdduration" json:"queue_time"`
+	// The number of split queries executed. 0 if splitting is disabled or the query can't be split.
+	SplitQueries uint32 `protobuf:"varint,7,opt,name=split_queries,json=splitQueries,proto3" json:"split_queries,omitempty"`
+	// The number of split queries whose results have been, fully or partially, fetched from the results cache.
+	ResultsCacheHits uint32 `protobuf:"varint,8,opt,name=results_cache_hits,json=resultsCacheHits,proto3" json:"results_cache_hits,omitempty"`
+	// The sum of all the time spent by the ingesters to execute the query requests.
+	IngesterWallTime time.Duration `protobuf:"bytes,9,opt,name=ingester_wall_time,json=ingesterWallTime,proto3,stdduration" json:"ingester_wall_time"`
+	// The sum of all the time spent by the ingesters selecting the series matching the query.
+	IngesterSelectTime time.Duration `protobuf:"bytes,10,opt,name=ingester_select_time,json=ingesterSelectTime,proto3,stdduration" json:"ingester_select_time"`
+	// The number of series touched by the ingesters, including the replicas.
+	IngesterSeriesCount uint64 `protobuf:"varint,11,opt,name=ingester_series_count,json=ingesterSeriesCount,proto3" json:"ingester_series_count,omitempty"`
+	// The number of chunks fetched from the ingesters, including the replicas.
+	IngesterChunksCount uint64 `protobuf:"varint,12,opt,name=ingester_chunks_count,json=ingesterChunksCount,proto3" json:"ingester_chunks_count,omitempty"`
+	// The number of bytes of the chunks fetched from the ingesters, including the replicas.
+	IngesterChunkBytes uint64 `protobuf:"varint,13,opt,name=ingester_chunk_bytes,json=ingesterChunkBytes,proto3" json:"ingester_chunk_bytes,omitempty"`
+	// The number of chunks fetched from the ingesters TSDB head.
+	IngesterHeadChunksCount uint64 `protobuf:"varint,14,opt,name=ingester_head_chunks_count,json=ingesterHeadChunksCount,proto3" json:"ingester_head_chunks_count,omitempty"`
+	// The number of chunks fetched from the ingesters TSDB blocks.
+	IngesterBlockChunksCount uint64 `protobuf:"varint,15,opt,name=ingester_block_chunks_count,json=ingesterBlockChunksCount,proto3" json:"ingester_block_chunks_count,omitempty"`
 }
 
 func (m *Stats) Reset()      { *m = Stats{} }
@@ -109,6 +129,76 @@ func (m *Stats) GetShardedQueries() uint32 {
 	return 0
 }
 
+func (m *Stats) GetQueueTime() time.Duration {
+	if m != nil {
+		return m.QueueTime
+	}
+	return 0
+}
+
+func (m *Stats) GetSplitQueries() uint32 {
+	if m != nil {
+		return m.SplitQueries
+	}
+	return 0
+}
+
+func (m *Stats) GetResultsCacheHits() uint32 {
+	if m != nil {
+		return m.ResultsCacheHits
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterWallTime() time.Duration {
+	if m != nil {
+		return m.IngesterWallTime
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterSelectTime() time.Duration {
+	if m != nil {
+		return m.IngesterSelectTime
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterSeriesCount() uint64 {
+	if m != nil {
+		return m.IngesterSeriesCount
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterChunksCount() uint64 {
+	if m != nil {
+		return m.IngesterChunksCount
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterChunkBytes() uint64 {
+	if m != nil {
+		return m.IngesterChunkBytes
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterHeadChunksCount() uint64 {
+	if m != nil {
+		return m.IngesterHeadChunksCount
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterBlockChunksCount() uint64 {
+	if m != nil {
+		return m.IngesterBlockChunksCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Stats)(nil), "stats.Stats")
 }
@@ -116,27 +206,39 @@ func init() {
 func init() { proto.RegisterFile("stats.proto", fileDescriptor_b4756a0aec8b9d44) }
 
 var fileDescriptor_b4756a0aec8b9d44 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xbd, 0x4e, 0xc3, 0x30,
-	0x14, 0x85, 0x7d, 0x4b, 0x8b, 0x4a, 0x2a, 0x40, 0x04, 0x86, 0xd0, 0xe1, 0xb6, 0x62, 0xa1, 0x0b,
-	0x2e, 0x82, 0x91, 0x05, 0xb5, 0xbc, 0x00, 0x2d, 0x13, 0x4b, 0x94, 0x1f, 0x37, 0x89, 0x68, 0x6b,
-	0x88, 0x6d, 0x21, 0x36, 0x26, 0x66, 0x46, 0x1e, 0x81, 0x47, 0xe9, 0xd8, 0xb1, 0x13, 0x50, 0x77,
-	0x61, 0xec, 0x23, 0xa0, 0x38, 0x89, 0x80, 0xcd, 0xe7, 0x7e, 0xf7, 0xf3, 0x91, 0x6c, 0xab, 0x21,
-	0xa4, 0x27, 0x05, 0xbd, 0x4f, 0xb9, 0xe4, 0x76, 0xcd, 0x84, 0xe6, 0x49, 0x94, 0xc8, 0x58, 0xf9,
-	0x34, 0xe0, 0x93, 0x6e, 0xc4, 0x23, 0xde, 0x35, 0xd4, 0x57, 0x23, 0x93, 0x4c, 0x30, 0xa7, 0xdc,
-	0x6a, 0x62, 0xc4, 0x79, 0x34, 0x66, 0xbf, 0x5b, 0xa1, 0x4a, 0x3d, 0x99, 0xf0, 0x69, 0xce, 0x8f,
-	0x5e, 0x2a, 0x56, 0x6d, 0x98, 0x5d, 0x6c, 0x5f, 0x5a, 0x5b, 0x8f, 0xde, 0x78, 0xec, 0xca, 0x64,
-	0xc2, 0x1c, 0x68, 0x43, 0xa7, 0x71, 0x76, 0x48, 0x73, 0x9b, 0x96, 0x36, 0xbd, 0x2a, 0xec, 0x5e,
-	0x7d, 0xf6, 0xd1, 0x22, 0x6f, 0x9f, 0x2d, 0x18, 0xd4, 0x33, 0xeb, 0x26, 0x99, 0x30, 0xfb, 0xd4,
-	0x3a, 0x18, 0x31, 0x19, 0xc4, 0x2c, 0x74, 0x05, 0x4b, 0x13, 0x26, 0xdc, 0x80, 0xab, 0xa9, 0x74,
-	0x2a, 0x6d, 0xe8, 0x54, 0x07, 0x76, 0xc1, 0x86, 0x06, 0xf5, 0x33, 0x62, 0x53, 0x6b, 0xbf, 0x34,
-	0x82, 0x58, 0x4d, 0xef, 0x5c, 0xff, 0x49, 0x32, 0xe1, 0x6c, 0x18, 0x61, 0xaf, 0x40, 0xfd, 0x8c,
-	0xf4, 0x32, 0xf0, 0xb7, 0xc1, 0xec, 0x97, 0x0d, 0xd5, 0x7f, 0x0d, 0x46, 0x28, 0x1a, 0x8e, 0xad,
-	0x5d, 0x11, 0x7b, 0x69, 0xc8, 0x42, 0xf7, 0x41, 0x99, 0x66, 0xa7, 0xd6, 0x86, 0xce, 0xf6, 0x60,
-	0xa7, 0x18, 0x5f, 0xe7, 0xd3, 0xde, 0xc5, 0x7c, 0x89, 0x64, 0xb1, 0x44, 0xb2, 0x5e, 0x22, 0x3c,
-	0x6b, 0x84, 0x77, 0x8d, 0x30, 0xd3, 0x08, 0x73, 0x8d, 0xf0, 0xa5, 0x11, 0xbe, 0x35, 0x92, 0xb5,
-	0x46, 0x78, 0x5d, 0x21, 0x99, 0xaf, 0x90, 0x2c, 0x56, 0x48, 0x6e, 0xf3, 0x4f, 0xf1, 0x37, 0xcd,
-	0x03, 0x9d, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x28, 0xab, 0xfa, 0xea, 0xb1, 0x01, 0x00, 0x00,
+	// 505 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xbd, 0xd0, 0x84, 0x64, 0xd3, 0xb4, 0xc5, 0x0d, 0xc2, 0x04, 0x69, 0x1b, 0xc1, 0x81,
+	0x1c, 0xc0, 0xad, 0xca, 0xb1, 0x42, 0x42, 0x0e, 0x87, 0x5e, 0x9b, 0x80, 0x90, 0xb8, 0x58, 0xb6,
+	0x33, 0xb5, 0xad, 0x3a, 0xd9, 0xd6, 0xbb, 0x16, 0xe2, 0xc6, 0x23, 0x70, 0xe4, 0x11, 0x78, 0x94,
+	0x1e, 0x73, 0xec, 0x85, 0x3f, 0x71, 0x2e, 0x1c, 0xf3, 0x08, 0xc8, 0xb3, 0xb6, 0x63, 0x73, 0xca,
+	0x2d, 0x3b, 0xdf, 0xfc, 0xe6, 0xdb, 0xcd, 0x37, 0xa6, 0x1d, 0x21, 0x1d, 0x29, 0xcc, 0xeb, 0x98,
+	0x4b, 0xae, 0x37, 0xf0, 0xd0, 0x7f, 0xe5, 0x87, 0x32, 0x48, 0x5c, 0xd3, 0xe3, 0xb3, 0x63, 0x9f,
+	0xfb, 0xfc, 0x18, 0x55, 0x37, 0xb9, 0xc4, 0x13, 0x1e, 0xf0, 0x97, 0xa2, 0xfa, 0xcc, 0xe7, 0xdc,
+	0x8f, 0x60, 0xd3, 0x35, 0x4d, 0x62, 0x47, 0x86, 0x7c, 0xae, 0xf4, 0x67, 0x3f, 0x9b, 0xb4, 0x31,
+	0xc9, 0x06, 0xeb, 0x6f, 0x69, 0xfb, 0xb3, 0x13, 0x45, 0xb6, 0x0c, 0x67, 0x60, 0x90, 0x01, 0x19,
+	0x76, 0x4e, 0x9f, 0x98, 0x8a, 0x36, 0x0b, 0xda, 0x7c, 0x97, 0xd3, 0x56, 0xeb, 0xf6, 0xd7, 0x91,
+	0xf6, 0xfd, 0xf7, 0x11, 0x19, 0xb7, 0x32, 0xea, 0x7d, 0x38, 0x03, 0xfd, 0x84, 0xf6, 0x2e, 0x41,
+	0x7a, 0x01, 0x4c, 0x6d, 0x01, 0x71, 0x08, 0xc2, 0xf6, 0x78, 0x32, 0x97, 0xc6, 0xbd, 0x01, 0x19,
+	0xee, 0x8c, 0xf5, 0x5c, 0x9b, 0xa0, 0x34, 0xca, 0x14, 0xdd, 0xa4, 0x87, 0x05, 0xe1, 0x05, 0xc9,
+	0xfc, 0xca, 0x76, 0xbf, 0x48, 0x10, 0xc6, 0x7d, 0x04, 0x1e, 0xe6, 0xd2, 0x28, 0x53, 0xac, 0x4c,
+	0xa8, 0x3a, 0x60, 0x7f, 0xe1, 0xb0, 0x53, 0x73, 0x40, 0x20, 0x77, 0x78, 0x41, 0xf7, 0x45, 0xe0,
+	0xc4, 0x53, 0x98, 0xda, 0x37, 0x09, 0x3a, 0x1b, 0x8d, 0x01, 0x19, 0x76, 0xc7, 0x7b, 0x79, 0xf9,
+	0x42, 0x55, 0x75, 0x8b, 0xd2, 0x9b, 0x04, 0x12, 0x50, 0xef, 0x6f, 0x6e, 0xff, 0xfe, 0x36, 0x62,
+	0xf8, 0x07, 0x3c, 0xa7, 0x5d, 0x71, 0x1d, 0x85, 0xb2, 0xb4, 0x7a, 0x80, 0x56, 0xbb, 0x58, 0x2c,
+	0x8c, 0x5e, 0x52, 0x3d, 0x06, 0x91, 0x44, 0x52, 0xd8, 0x9e, 0xe3, 0x05, 0x60, 0x07, 0xa1, 0x14,
+	0x46, 0x0b, 0x3b, 0x0f, 0x72, 0x65, 0x94, 0x09, 0xe7, 0xa1, 0x14, 0xfa, 0x05, 0xd5, 0xc3, 0xb9,
+	0x0f, 0x42, 0x42, 0x6c, 0x6f, 0xe2, 0x69, 0x6f, 0x7f, 0xbd, 0x83, 0x02, 0xff, 0x58, 0xc4, 0xf4,
+	0x81, 0xf6, 0xca, 0x91, 0x02, 0x22, 0xf0, 0xa4, 0x1a, 0x4a, 0xb7, 0x1f, 0x5a, 0xde, 0x69, 0x82,
+	0x3c, 0x8e, 0x3d, 0xa5, 0x8f, 0x2a, 0x63, 0x2b, 0xf1, 0x77, 0x30, 0x9c, 0xc3, 0x0d, 0xb2, 0xc9,
+	0xbf, 0xca, 0xd4, 0x02, 0xdd, 0xad, 0x33, 0xd5, 0x44, 0x4f, 0x68, 0xaf, 0xce, 0xe4, 0x4b, 0xd3,
+	0x55, 0x3b, 0x50, 0x43, 0xd4, 0xd6, 0x9c, 0xd1, 0x7e, 0x49, 0x04, 0xe0, 0xfc, 0xb7, 0x3b, 0x7b,
+	0xc8, 0x3d, 0x2e, 0x3a, 0xce, 0xc1, 0xa9, 0x2d, 0xd0, 0x1b, 0xfa, 0xb4, 0x84, 0xdd, 0x88, 0x7b,
+	0x57, 0x75, 0x7a, 0x1f, 0x69, 0xa3, 0x68, 0xb1, 0xb2, 0x8e, 0x0a, 0x6e, 0x9d, 0x2d, 0x96, 0x4c,
+	0xbb, 0x5b, 0x32, 0x6d, 0xbd, 0x64, 0xe4, 0x6b, 0xca, 0xc8, 0x8f, 0x94, 0x91, 0xdb, 0x94, 0x91,
+	0x45, 0xca, 0xc8, 0x9f, 0x94, 0x91, 0xbf, 0x29, 0xd3, 0xd6, 0x29, 0x23, 0xdf, 0x56, 0x4c, 0x5b,
+	0xac, 0x98, 0x76, 0xb7, 0x62, 0xda, 0x27, 0xf5, 0xad, 0xbb, 0x4d, 0xcc, 0xe0, 0xf5, 0xbf, 0x01,
+	0x00, 0xa6, 0x95, 0x7c, 0xc3, 0x08, 0x04, 0x00, 0x00,
 }
 
 func (this *Stats) Equal(that interface{}) bool {
@@ -173,19 +275,59 @@ func (this *Stats) Equal(that interface{}) bool {
 	if this.ShardedQueries != that1.ShardedQueries {
 		return false
 	}
+	if this.QueueTime != that1.QueueTime {
+		return false
+	}
+	if this.SplitQueries != that1.SplitQueries {
+		return false
+	}
+	if this.ResultsCacheHits != that1.ResultsCacheHits {
+		return false
+	}
+	if this.IngesterWallTime != that1.IngesterWallTime {
+		return false
+	}
+	if this.IngesterSelectTime != that1.IngesterSelectTime {
+		return false
+	}
+	if this.IngesterSeriesCount != that1.IngesterSeriesCount {
+		return false
+	}
+	if this.IngesterChunksCount != that1.IngesterChunksCount {
+		return false
+	}
+	if this.IngesterChunkBytes != that1.IngesterChunkBytes {
+		return false
+	}
+	if this.IngesterHeadChunksCount != that1.IngesterHeadChunksCount {
+		return false
+	}
+	if this.IngesterBlockChunksCount != that1.IngesterBlockChunksCount {
+		return false
+	}
 	return true
 }
 func (this *Stats) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 19)
 	s = append(s, "&stats.Stats{")
 	s = append(s, "WallTime: "+fmt.Sprintf("%#v", this.WallTime)+",\n")
 	s = append(s, "FetchedSeriesCount: "+fmt.Sprintf("%#v", this.FetchedSeriesCount)+",\n")
 	s = append(s, "FetchedChunkBytes: "+fmt.Sprintf("%#v", this.FetchedChunkBytes)+",\n")
 	s = append(s, "FetchedChunksCount: "+fmt.Sprintf("%#v", this.FetchedChunksCount)+",\n")
 	s = append(s, "ShardedQueries: "+fmt.Sprintf("%#v", this.ShardedQueries)+",\n")
+	s = append(s, "QueueTime: "+fmt.Sprintf("%#v", this.QueueTime)+",\n")
+	s = append(s, "SplitQueries: "+fmt.Sprintf("%#v", this.SplitQueries)+",\n")
+	s = append(s, "ResultsCacheHits: "+fmt.Sprintf("%#v", this.ResultsCacheHits)+",\n")
+	s = append(s, "IngesterWallTime: "+fmt.Sprintf("%#v", this.IngesterWallTime)+",\n")
+	s = append(s, "IngesterSelectTime: "+fmt.Sprintf("%#v", this.IngesterSelectTime)+",\n")
+	s = append(s, "IngesterSeriesCount: "+fmt.Sprintf("%#v", this.IngesterSeriesCount)+",\n")
+	s = append(s, "IngesterChunksCount: "+fmt.Sprintf("%#v", this.IngesterChunksCount)+",\n")
+	s = append(s, "IngesterChunkBytes: "+fmt.Sprintf("%#v", this.IngesterChunkBytes)+",\n")
+	s = append(s, "IngesterHeadChunksCount: "+fmt.Sprintf("%#v", this.IngesterHeadChunksCount)+",\n")
+	s = append(s, "IngesterBlockChunksCount: "+fmt.Sprintf("%#v", this.IngesterBlockChunksCount)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -217,6 +359,65 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IngesterBlockChunksCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterBlockChunksCount))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.IngesterHeadChunksCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterHeadChunksCount))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.IngesterChunkBytes != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterChunkBytes))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.IngesterChunksCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterChunksCount))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.IngesterSeriesCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterSeriesCount))
+		i--
+		dAtA[i] = 0x58
+	}
+	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.IngesterSelectTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.IngesterSelectTime):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintStats(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x52
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.IngesterWallTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.IngesterWallTime):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintStats(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x4a
+	if m.ResultsCacheHits != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.ResultsCacheHits))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.SplitQueries != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.SplitQueries))
+		i--
+		dAtA[i] = 0x38
+	}
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.QueueTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.QueueTime):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintStats(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x32
 	if m.ShardedQueries != 0 {
 		i = encodeVarintStats(dAtA, i, uint64(m.ShardedQueries))
 		i--
@@ -237,12 +438,12 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x10
 	}
-	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.WallTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime):])
-	if err1 != nil {
-		return 0, err1
+	n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.WallTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime):])
+	if err4 != nil {
+		return 0, err4
 	}
-	i -= n1
-	i = encodeVarintStats(dAtA, i, uint64(n1))
+	i -= n4
+	i = encodeVarintStats(dAtA, i, uint64(n4))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -279,6 +480,33 @@ func (m *Stats) Size() (n int) {
 	if m.ShardedQueries != 0 {
 		n += 1 + sovStats(uint64(m.ShardedQueries))
 	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.QueueTime)
+	n += 1 + l + sovStats(uint64(l))
+	if m.SplitQueries != 0 {
+		n += 1 + sovStats(uint64(m.SplitQueries))
+	}
+	if m.ResultsCacheHits != 0 {
+		n += 1 + sovStats(uint64(m.ResultsCacheHits))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.IngesterWallTime)
+	n += 1 + l + sovStats(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.IngesterSelectTime)
+	n += 1 + l + sovStats(uint64(l))
+	if m.IngesterSeriesCount != 0 {
+		n += 1 + sovStats(uint64(m.IngesterSeriesCount))
+	}
+	if m.IngesterChunksCount != 0 {
+		n += 1 + sovStats(uint64(m.IngesterChunksCount))
+	}
+	if m.IngesterChunkBytes != 0 {
+		n += 1 + sovStats(uint64(m.IngesterChunkBytes))
+	}
+	if m.IngesterHeadChunksCount != 0 {
+		n += 1 + sovStats(uint64(m.IngesterHeadChunksCount))
+	}
+	if m.IngesterBlockChunksCount != 0 {
+		n += 1 + sovStats(uint64(m.IngesterBlockChunksCount))
+	}
 	return n
 }
 
@@ -298,6 +526,16 @@ func (this *Stats) String() string {
 		`FetchedChunkBytes:` + fmt.Sprintf("%v", this.FetchedChunkBytes) + `,`,
 		`FetchedChunksCount:` + fmt.Sprintf("%v", this.FetchedChunksCount) + `,`,
 		`ShardedQueries:` + fmt.Sprintf("%v", this.ShardedQueries) + `,`,
+		`QueueTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.QueueTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`SplitQueries:` + fmt.Sprintf("%v", this.SplitQueries) + `,`,
+		`ResultsCacheHits:` + fmt.Sprintf("%v", this.ResultsCacheHits) + `,`,
+		`IngesterWallTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.IngesterWallTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`IngesterSelectTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.IngesterSelectTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`IngesterSeriesCount:` + fmt.Sprintf("%v", this.IngesterSeriesCount) + `,`,
+		`IngesterChunksCount:` + fmt.Sprintf("%v", this.IngesterChunksCount) + `,`,
+		`IngesterChunkBytes:` + fmt.Sprintf("%v", this.IngesterChunkBytes) + `,`,
+		`IngesterHeadChunksCount:` + fmt.Sprintf("%v", this.IngesterHeadChunksCount) + `,`,
+		`IngesterBlockChunksCount:` + fmt.Sprintf("%v", this.IngesterBlockChunksCount) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -448,6 +686,238 @@ func (m *Stats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStats
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.QueueTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitQueries", wireType)
+			}
+			m.SplitQueries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SplitQueries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultsCacheHits", wireType)
+			}
+			m.ResultsCacheHits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultsCacheHits |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterWallTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStats
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.IngesterWallTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterSelectTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStats
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.IngesterSelectTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterSeriesCount", wireType)
+			}
+			m.IngesterSeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterSeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterChunksCount", wireType)
+			}
+			m.IngesterChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterChunkBytes", wireType)
+			}
+			m.IngesterChunkBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterChunkBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterHeadChunksCount", wireType)
+			}
+			m.IngesterHeadChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterHeadChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterBlockChunksCount", wireType)
+			}
+			m.IngesterBlockChunksCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterBlockChunksCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStats(dAtA[iNdEx:])
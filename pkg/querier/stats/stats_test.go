@@ -98,6 +98,57 @@ func TestStats_AddShardedQueries(t *testing.T) {
 	})
 }
 
+func TestStats_QueueTime(t *testing.T) {
+	t.Run("add and load queue time", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddQueueTime(time.Second)
+		stats.AddQueueTime(time.Second)
+
+		assert.Equal(t, 2*time.Second, stats.LoadQueueTime())
+	})
+
+	t.Run("add and load queue time nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddQueueTime(time.Second)
+
+		assert.Equal(t, time.Duration(0), stats.LoadQueueTime())
+	})
+}
+
+func TestStats_AddSplitQueries(t *testing.T) {
+	t.Run("add and load split queries", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddSplitQueries(20)
+		stats.AddSplitQueries(22)
+
+		assert.Equal(t, uint32(42), stats.LoadSplitQueries())
+	})
+
+	t.Run("add and load split queries nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddSplitQueries(3)
+
+		assert.Equal(t, uint32(0), stats.LoadSplitQueries())
+	})
+}
+
+func TestStats_AddResultsCacheHits(t *testing.T) {
+	t.Run("add and load results cache hits", func(t *testing.T) {
+		stats, _ := ContextWithEmptyStats(context.Background())
+		stats.AddResultsCacheHits(20)
+		stats.AddResultsCacheHits(22)
+
+		assert.Equal(t, uint32(42), stats.LoadResultsCacheHits())
+	})
+
+	t.Run("add and load results cache hits nil receiver", func(t *testing.T) {
+		var stats *Stats
+		stats.AddResultsCacheHits(3)
+
+		assert.Equal(t, uint32(0), stats.LoadResultsCacheHits())
+	})
+}
+
 func TestStats_Merge(t *testing.T) {
 	t.Run("merge two stats objects", func(t *testing.T) {
 		stats1 := &Stats{}
@@ -106,6 +157,16 @@ func TestStats_Merge(t *testing.T) {
 		stats1.AddFetchedChunkBytes(42)
 		stats1.AddFetchedChunks(10)
 		stats1.AddShardedQueries(20)
+		stats1.AddQueueTime(time.Millisecond)
+		stats1.AddSplitQueries(3)
+		stats1.AddResultsCacheHits(1)
+		stats1.AddIngesterWallTime(time.Millisecond)
+		stats1.AddIngesterSelectTime(time.Microsecond)
+		stats1.AddIngesterSeries(50)
+		stats1.AddIngesterChunks(10)
+		stats1.AddIngesterChunkBytes(42)
+		stats1.AddIngesterHeadChunks(8)
+		stats1.AddIngesterBlockChunks(2)
 
 		stats2 := &Stats{}
 		stats2.AddWallTime(time.Second)
@@ -113,6 +174,16 @@ func TestStats_Merge(t *testing.T) {
 		stats2.AddFetchedChunkBytes(100)
 		stats2.AddFetchedChunks(11)
 		stats2.AddShardedQueries(21)
+		stats2.AddQueueTime(2 * time.Millisecond)
+		stats2.AddSplitQueries(4)
+		stats2.AddResultsCacheHits(2)
+		stats2.AddIngesterWallTime(2 * time.Millisecond)
+		stats2.AddIngesterSelectTime(2 * time.Microsecond)
+		stats2.AddIngesterSeries(60)
+		stats2.AddIngesterChunks(11)
+		stats2.AddIngesterChunkBytes(100)
+		stats2.AddIngesterHeadChunks(1)
+		stats2.AddIngesterBlockChunks(10)
 
 		stats1.Merge(stats2)
 
@@ -121,6 +192,16 @@ func TestStats_Merge(t *testing.T) {
 		assert.Equal(t, uint64(142), stats1.LoadFetchedChunkBytes())
 		assert.Equal(t, uint64(21), stats1.LoadFetchedChunks())
 		assert.Equal(t, uint32(41), stats1.LoadShardedQueries())
+		assert.Equal(t, 3*time.Millisecond, stats1.LoadQueueTime())
+		assert.Equal(t, uint32(7), stats1.LoadSplitQueries())
+		assert.Equal(t, uint32(3), stats1.LoadResultsCacheHits())
+		assert.Equal(t, 3*time.Millisecond, stats1.LoadIngesterWallTime())
+		assert.Equal(t, 3*time.Microsecond, stats1.LoadIngesterSelectTime())
+		assert.Equal(t, uint64(110), stats1.LoadIngesterSeries())
+		assert.Equal(t, uint64(21), stats1.LoadIngesterChunks())
+		assert.Equal(t, uint64(142), stats1.LoadIngesterChunkBytes())
+		assert.Equal(t, uint64(9), stats1.LoadIngesterHeadChunks())
+		assert.Equal(t, uint64(12), stats1.LoadIngesterBlockChunks())
 	})
 
 	t.Run("merge two nil stats objects", func(t *testing.T) {
@@ -134,5 +215,10 @@ func TestStats_Merge(t *testing.T) {
 		assert.Equal(t, uint64(0), stats1.LoadFetchedChunkBytes())
 		assert.Equal(t, uint64(0), stats1.LoadFetchedChunks())
 		assert.Equal(t, uint32(0), stats1.LoadShardedQueries())
+		assert.Equal(t, time.Duration(0), stats1.LoadQueueTime())
+		assert.Equal(t, uint32(0), stats1.LoadSplitQueries())
+		assert.Equal(t, uint32(0), stats1.LoadResultsCacheHits())
+		assert.Equal(t, time.Duration(0), stats1.LoadIngesterWallTime())
+		assert.Equal(t, uint64(0), stats1.LoadIngesterSeries())
 	})
 }
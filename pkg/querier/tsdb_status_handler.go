@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/dskit/tenant"
+
+	ingester_client "github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/util"
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+type tsdbHeadStats struct {
+	NumSeries     uint64 `json:"numSeries"`
+	NumLabelPairs uint64 `json:"numLabelPairs"`
+	ChunkCount    uint64 `json:"chunkCount"`
+	MinTime       int64  `json:"minTime"`
+	MaxTime       int64  `json:"maxTime"`
+}
+
+type tsdbStat struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+}
+
+// tsdbStatus has the same format of the Prometheus /api/v1/status/tsdb response data.
+type tsdbStatus struct {
+	HeadStats                   tsdbHeadStats `json:"headStats"`
+	SeriesCountByMetricName     []tsdbStat    `json:"seriesCountByMetricName"`
+	LabelValueCountByLabelName  []tsdbStat    `json:"labelValueCountByLabelName"`
+	MemoryInBytesByLabelName    []tsdbStat    `json:"memoryInBytesByLabelName"`
+	SeriesCountByLabelValuePair []tsdbStat    `json:"seriesCountByLabelValuePair"`
+}
+
+type tsdbStatusResult struct {
+	Status string      `json:"status"`
+	Data   *tsdbStatus `json:"data,omitempty"`
+}
+
+// TSDBStatusHandler returns the statistics of the tenant's TSDB head, merged across the ingesters.
+func TSDBStatusHandler(d Distributor, limits *validation.Overrides) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tenantID, err := tenant.TenantID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !limits.CardinalityAnalysisEnabled(tenantID) {
+			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := d.TSDBStatus(ctx)
+		if err != nil {
+			respondFromError(err, w)
+			return
+		}
+
+		util.WriteJSONResponse(w, tsdbStatusResult{Status: statusSuccess, Data: toTSDBStatus(resp)})
+	})
+}
+
+func toTSDBStatus(resp *ingester_client.TSDBStatusResponse) *tsdbStatus {
+	return &tsdbStatus{
+		HeadStats: tsdbHeadStats{
+			NumSeries:     resp.NumSeries,
+			NumLabelPairs: resp.NumLabelPairs,
+			ChunkCount:    resp.ChunkCount,
+			MinTime:       resp.MinTime,
+			MaxTime:       resp.MaxTime,
+		},
+		SeriesCountByMetricName:     toTSDBStats(resp.SeriesCountByMetricName),
+		LabelValueCountByLabelName:  toTSDBStats(resp.LabelValueCountByLabelName),
+		MemoryInBytesByLabelName:    toTSDBStats(resp.MemoryInBytesByLabelName),
+		SeriesCountByLabelValuePair: toTSDBStats(resp.SeriesCountByLabelValuePair),
+	}
+}
+
+func toTSDBStats(items []ingester_client.TSDBStatItem) []tsdbStat {
+	stats := make([]tsdbStat, 0, len(items))
+	for _, item := range items {
+		stats = append(stats, tsdbStat{Name: item.Name, Value: item.Value})
+	}
+	return stats
+}
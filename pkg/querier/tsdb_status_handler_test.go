@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package querier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/util/validation"
+)
+
+func TestTSDBStatusHandler(t *testing.T) {
+	distributor := &mockDistributor{}
+	distributor.On("TSDBStatus", mock.Anything).Return(&client.TSDBStatusResponse{
+		NumSeries:     3,
+		NumLabelPairs: 4,
+		ChunkCount:    5,
+		MinTime:       1000,
+		MaxTime:       2000,
+		SeriesCountByMetricName: []client.TSDBStatItem{
+			{Name: "metric_0", Value: 2},
+			{Name: "metric_1", Value: 1},
+		},
+		LabelValueCountByLabelName: []client.TSDBStatItem{
+			{Name: "__name__", Value: 2},
+		},
+		SeriesCountByLabelValuePair: []client.TSDBStatItem{
+			{Name: "__name__=metric_0", Value: 2},
+		},
+	}, nil)
+
+	handler := createEnabledHandler(t, func(d Distributor, _ BlocksCardinalityQueryable, limits *validation.Overrides) http.Handler {
+		return TSDBStatusHandler(d, limits)
+	}, distributor)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, createRequest("/api/v1/status/tsdb", "team-a"))
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	// The response has the same format of the Prometheus TSDB status API.
+	assert.JSONEq(t, `{
+		"status": "success",
+		"data": {
+			"headStats": {"numSeries": 3, "numLabelPairs": 4, "chunkCount": 5, "minTime": 1000, "maxTime": 2000},
+			"seriesCountByMetricName": [{"name": "metric_0", "value": 2}, {"name": "metric_1", "value": 1}],
+			"labelValueCountByLabelName": [{"name": "__name__", "value": 2}],
+			"memoryInBytesByLabelName": [],
+			"seriesCountByLabelValuePair": [{"name": "__name__=metric_0", "value": 2}]
+		}
+	}`, recorder.Body.String())
+}
+
+func TestTSDBStatusHandler_CardinalityAnalysisDisabled(t *testing.T) {
+	overrides, err := validation.NewOverrides(validation.Limits{CardinalityAnalysisEnabled: false}, nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	TSDBStatusHandler(&mockDistributor{}, overrides).ServeHTTP(recorder, createRequest("/api/v1/status/tsdb", "team-a"))
+	require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	assert.Equal(t, "cardinality analysis is disabled for the tenant: team-a\n", recorder.Body.String())
+}
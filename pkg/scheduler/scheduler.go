@@ -421,6 +421,7 @@ func (s *Scheduler) forwardRequestToQuerier(querier schedulerpb.SchedulerForQuer
 			FrontendAddress: req.frontendAddress,
 			HttpRequest:     req.request,
 			StatsEnabled:    req.statsEnabled,
+			QueueTime:       time.Since(req.enqueueTime),
 		})
 		if err != nil {
 			errCh <- err
@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tsdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/runutil"
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+const (
+	// BlockExemplarsFilename is the name of the object, stored in the block prefix, holding the exemplars
+	// of the series in the block. It's not a block file, so it's not listed in the block meta.json.
+	BlockExemplarsFilename = "exemplars.json.gz"
+
+	// BlockExemplarsVersion1 is the only supported block exemplars version.
+	BlockExemplarsVersion1 = 1
+)
+
+var (
+	ErrBlockExemplarsNotFound  = errors.New("block exemplars not found")
+	ErrBlockExemplarsCorrupted = errors.New("block exemplars corrupted")
+)
+
+// BlockExemplars holds the exemplars of the series in a block.
+type BlockExemplars struct {
+	Version int `json:"version"`
+
+	// Series sorted by labels.
+	Series []BlockExemplarsSeries `json:"series"`
+}
+
+type BlockExemplarsSeries struct {
+	Labels labels.Labels `json:"labels"`
+
+	// Exemplars sorted by timestamp.
+	Exemplars []BlockExemplar `json:"exemplars"`
+}
+
+type BlockExemplar struct {
+	Labels labels.Labels `json:"labels"`
+
+	// The value is encoded as a string, in order to support non-finite values.
+	Value     model.SampleValue `json:"value"`
+	Timestamp int64             `json:"timestamp"`
+}
+
+// NewBlockExemplars builds the block exemplars from the exemplars queried from a TSDB.
+func NewBlockExemplars(results []exemplar.QueryResult) *BlockExemplars {
+	e := &BlockExemplars{Version: BlockExemplarsVersion1}
+
+	for _, res := range results {
+		if len(res.Exemplars) == 0 {
+			continue
+		}
+
+		series := BlockExemplarsSeries{Labels: res.SeriesLabels}
+		for _, ex := range res.Exemplars {
+			series.Exemplars = append(series.Exemplars, BlockExemplar{Labels: ex.Labels, Value: model.SampleValue(ex.Value), Timestamp: ex.Ts})
+		}
+		e.Series = append(e.Series, series)
+	}
+
+	return MergeBlockExemplars(e)
+}
+
+// MergeBlockExemplars merges the exemplars of multiple blocks, deduplicating the exemplars
+// found in more than one block.
+func MergeBlockExemplars(blocks ...*BlockExemplars) *BlockExemplars {
+	type exemplarKey struct {
+		timestamp int64
+		labels    string
+	}
+
+	series := map[string]*BlockExemplarsSeries{}
+	seen := map[string]map[exemplarKey]struct{}{}
+
+	for _, b := range blocks {
+		for _, s := range b.Series {
+			key := s.Labels.String()
+			merged, ok := series[key]
+			if !ok {
+				merged = &BlockExemplarsSeries{Labels: s.Labels}
+				series[key] = merged
+				seen[key] = map[exemplarKey]struct{}{}
+			}
+
+			for _, ex := range s.Exemplars {
+				exKey := exemplarKey{timestamp: ex.Timestamp, labels: ex.Labels.String()}
+				if _, ok := seen[key][exKey]; ok {
+					continue
+				}
+				seen[key][exKey] = struct{}{}
+				merged.Exemplars = append(merged.Exemplars, ex)
+			}
+		}
+	}
+
+	res := &BlockExemplars{Version: BlockExemplarsVersion1, Series: make([]BlockExemplarsSeries, 0, len(series))}
+	for _, s := range series {
+		sort.SliceStable(s.Exemplars, func(i, j int) bool {
+			return s.Exemplars[i].Timestamp < s.Exemplars[j].Timestamp
+		})
+		res.Series = append(res.Series, *s)
+	}
+	sort.Slice(res.Series, func(i, j int) bool {
+		return labels.Compare(res.Series[i].Labels, res.Series[j].Labels) < 0
+	})
+
+	return res
+}
+
+// Filter returns the exemplars for which keep returns true. Series left without exemplars are removed.
+func (e *BlockExemplars) Filter(keep func(series labels.Labels, ex BlockExemplar) bool) *BlockExemplars {
+	res := &BlockExemplars{Version: e.Version}
+
+	for _, s := range e.Series {
+		filtered := BlockExemplarsSeries{Labels: s.Labels}
+		for _, ex := range s.Exemplars {
+			if keep(s.Labels, ex) {
+				filtered.Exemplars = append(filtered.Exemplars, ex)
+			}
+		}
+
+		if len(filtered.Exemplars) > 0 {
+			res.Series = append(res.Series, filtered)
+		}
+	}
+
+	return res
+}
+
+// Select returns the exemplars within the start and end timestamps (both inclusive) of the series
+// matching any of the matcher sets, like the Prometheus storage.ExemplarQuerier.
+func (e *BlockExemplars) Select(start, end int64, matchers ...[]*labels.Matcher) *BlockExemplars {
+	return e.Filter(func(series labels.Labels, ex BlockExemplar) bool {
+		return ex.Timestamp >= start && ex.Timestamp <= end && matchesAnyMatcherSet(series, matchers)
+	})
+}
+
+// QueryResults converts the exemplars to the Prometheus exemplar query results.
+func (e *BlockExemplars) QueryResults() []exemplar.QueryResult {
+	res := make([]exemplar.QueryResult, 0, len(e.Series))
+
+	for _, s := range e.Series {
+		result := exemplar.QueryResult{SeriesLabels: s.Labels}
+		for _, ex := range s.Exemplars {
+			result.Exemplars = append(result.Exemplars, exemplar.Exemplar{Labels: ex.Labels, Value: float64(ex.Value), Ts: ex.Timestamp, HasTs: true})
+		}
+		res = append(res, result)
+	}
+
+	return res
+}
+
+// NumExemplars returns the total number of exemplars.
+func (e *BlockExemplars) NumExemplars() int {
+	count := 0
+	for _, s := range e.Series {
+		count += len(s.Exemplars)
+	}
+	return count
+}
+
+func matchesAnyMatcherSet(series labels.Labels, matcherSets [][]*labels.Matcher) bool {
+	for _, matchers := range matcherSets {
+		matches := true
+		for _, m := range matchers {
+			if !m.Matches(series.Get(m.Name)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadBlockExemplars reads the exemplars of the block from the bucket, which is expected to be a
+// tenant bucket client. Returns ErrBlockExemplarsNotFound if the block has no exemplars.
+func ReadBlockExemplars(ctx context.Context, bkt objstore.InstrumentedBucketReader, blockID ulid.ULID, logger log.Logger) (*BlockExemplars, error) {
+	reader, err := bkt.ReaderWithExpectedErrs(bkt.IsObjNotFoundErr).Get(ctx, path.Join(blockID.String(), BlockExemplarsFilename))
+	if err != nil {
+		if bkt.IsObjNotFoundErr(err) {
+			return nil, ErrBlockExemplarsNotFound
+		}
+		return nil, errors.Wrapf(err, "read exemplars of block %s", blockID)
+	}
+	defer runutil.CloseWithLogOnErr(logger, reader, "close block exemplars reader")
+
+	return decodeBlockExemplars(reader, logger)
+}
+
+// ReadBlockExemplarsFromDir reads the exemplars of the block stored in the local directory.
+// Returns ErrBlockExemplarsNotFound if the block has no exemplars.
+func ReadBlockExemplarsFromDir(dir string, logger log.Logger) (*BlockExemplars, error) {
+	f, err := os.Open(filepath.Join(dir, BlockExemplarsFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrBlockExemplarsNotFound
+		}
+		return nil, errors.Wrapf(err, "read exemplars of block %s", dir)
+	}
+	defer runutil.CloseWithLogOnErr(logger, f, "close block exemplars file")
+
+	return decodeBlockExemplars(f, logger)
+}
+
+// WriteBlockExemplars uploads the exemplars of the block to the bucket, which is expected to be a
+// tenant bucket client. The exemplars should be uploaded before the block meta.json, so that they're
+// available as soon as the block is.
+func WriteBlockExemplars(ctx context.Context, bkt objstore.Bucket, blockID ulid.ULID, e *BlockExemplars) error {
+	content, err := encodeBlockExemplars(e)
+	if err != nil {
+		return err
+	}
+
+	if err := bkt.Upload(ctx, path.Join(blockID.String(), BlockExemplarsFilename), bytes.NewReader(content)); err != nil {
+		return errors.Wrapf(err, "upload exemplars of block %s", blockID)
+	}
+
+	return nil
+}
+
+// WriteBlockExemplarsToDir stores the exemplars of the block in the local block directory. The file is
+// written to a temporary path and then renamed, so that a partially written file is never read.
+func WriteBlockExemplarsToDir(dir string, e *BlockExemplars) error {
+	content, err := encodeBlockExemplars(e)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(dir, BlockExemplarsFilename)
+	if err := os.WriteFile(filename+".tmp", content, 0o666); err != nil {
+		return errors.Wrapf(err, "write exemplars of block %s", dir)
+	}
+	if err := os.Rename(filename+".tmp", filename); err != nil {
+		return errors.Wrapf(err, "rename exemplars of block %s", dir)
+	}
+
+	return nil
+}
+
+func encodeBlockExemplars(e *BlockExemplars) ([]byte, error) {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal block exemplars")
+	}
+
+	var gzipContent bytes.Buffer
+	gzip := gzip.NewWriter(&gzipContent)
+	if _, err := gzip.Write(content); err != nil {
+		return nil, errors.Wrap(err, "gzip block exemplars")
+	}
+	if err := gzip.Close(); err != nil {
+		return nil, errors.Wrap(err, "close gzip block exemplars")
+	}
+
+	return gzipContent.Bytes(), nil
+}
+
+func decodeBlockExemplars(r io.Reader, logger log.Logger) (*BlockExemplars, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrBlockExemplarsCorrupted
+	}
+	defer runutil.CloseWithLogOnErr(logger, gzipReader, "close block exemplars gzip reader")
+
+	e := &BlockExemplars{}
+	if err := json.NewDecoder(gzipReader).Decode(e); err != nil {
+		return nil, ErrBlockExemplarsCorrupted
+	}
+	if e.Version != BlockExemplarsVersion1 {
+		return nil, errors.Errorf("unsupported block exemplars version %d", e.Version)
+	}
+
+	return e, nil
+}
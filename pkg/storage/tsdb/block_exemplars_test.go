@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/oklog/ulid"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestBlockExemplars_MergeAndSelect(t *testing.T) {
+	series1 := labels.FromStrings(labels.MetricName, "metric", "pod", "a")
+	series2 := labels.FromStrings(labels.MetricName, "metric", "pod", "b")
+	traceA := labels.FromStrings("trace_id", "a")
+	traceB := labels.FromStrings("trace_id", "b")
+
+	block1 := NewBlockExemplars([]exemplar.QueryResult{
+		{SeriesLabels: series2, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 1, Ts: 10}}},
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 2, Ts: 20}, {Labels: traceB, Value: 1, Ts: 10}}},
+		{SeriesLabels: labels.FromStrings(labels.MetricName, "no_exemplars")},
+	})
+	block2 := NewBlockExemplars([]exemplar.QueryResult{
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceB, Value: 1, Ts: 10}, {Labels: traceB, Value: 3, Ts: 30}}},
+	})
+
+	merged := MergeBlockExemplars(block1, block2)
+	assert.Equal(t, &BlockExemplars{Version: BlockExemplarsVersion1, Series: []BlockExemplarsSeries{
+		{Labels: series1, Exemplars: []BlockExemplar{{Labels: traceB, Value: 1, Timestamp: 10}, {Labels: traceA, Value: 2, Timestamp: 20}, {Labels: traceB, Value: 3, Timestamp: 30}}},
+		{Labels: series2, Exemplars: []BlockExemplar{{Labels: traceA, Value: 1, Timestamp: 10}}},
+	}}, merged)
+	assert.Equal(t, 4, merged.NumExemplars())
+
+	// The time range is inclusive.
+	actual := merged.Select(20, 30, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "pod", "b")}, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "pod", "a")})
+	assert.Equal(t, []exemplar.QueryResult{
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 2, Ts: 20, HasTs: true}, {Labels: traceB, Value: 3, Ts: 30, HasTs: true}}},
+	}, actual.QueryResults())
+
+	// No series is selected without matchers.
+	assert.Empty(t, merged.Select(0, 30).Series)
+}
+
+func TestBlockExemplars_ReadWrite(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.WithNoopInstr(objstore.NewInMemBucket())
+	blockID := ulid.MustNew(1, nil)
+
+	_, err := ReadBlockExemplars(ctx, bkt, blockID, log.NewNopLogger())
+	require.Equal(t, ErrBlockExemplarsNotFound, err)
+
+	// Non-finite values are supported.
+	expected := NewBlockExemplars([]exemplar.QueryResult{
+		{SeriesLabels: labels.FromStrings(labels.MetricName, "metric"), Exemplars: []exemplar.Exemplar{
+			{Labels: labels.FromStrings("trace_id", "a"), Value: 1.5, Ts: 10},
+			{Labels: labels.FromStrings("trace_id", "b"), Value: math.Inf(1), Ts: 20},
+		}},
+	})
+	require.NoError(t, WriteBlockExemplars(ctx, bkt, blockID, expected))
+
+	actual, err := ReadBlockExemplars(ctx, bkt, blockID, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// The exemplars can be read from a downloaded block too.
+	dir := t.TempDir()
+	_, err = ReadBlockExemplarsFromDir(dir, log.NewNopLogger())
+	require.Equal(t, ErrBlockExemplarsNotFound, err)
+
+	reader, err := bkt.Get(ctx, blockID.String()+"/"+BlockExemplarsFilename)
+	require.NoError(t, err)
+	content := bytes.Buffer{}
+	_, err = content.ReadFrom(reader)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, BlockExemplarsFilename), content.Bytes(), os.ModePerm))
+
+	actual, err = ReadBlockExemplarsFromDir(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// The exemplars can be stored in a local block too.
+	dir = t.TempDir()
+	require.NoError(t, WriteBlockExemplarsToDir(dir, expected))
+
+	actual, err = ReadBlockExemplarsFromDir(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// Corrupted exemplars.
+	require.NoError(t, bkt.Upload(ctx, blockID.String()+"/"+BlockExemplarsFilename, strings.NewReader("invalid!}")))
+	_, err = ReadBlockExemplars(ctx, bkt, blockID, log.NewNopLogger())
+	require.Equal(t, ErrBlockExemplarsCorrupted, err)
+}
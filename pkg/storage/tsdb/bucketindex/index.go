@@ -52,6 +52,15 @@ func (idx *Index) GetUpdatedAt() time.Time {
 	return time.Unix(idx.UpdatedAt, 0)
 }
 
+// SizeBytes returns the total size of the blocks in the index, whose size is known.
+func (idx *Index) SizeBytes() int64 {
+	size := int64(0)
+	for _, b := range idx.Blocks {
+		size += b.SizeBytes
+	}
+	return size
+}
+
 // RemoveBlock removes block and its deletion mark (if any) from index.
 func (idx *Index) RemoveBlock(id ulid.ULID) {
 	for i := 0; i < len(idx.Blocks); i++ {
@@ -91,6 +100,10 @@ type Block struct {
 
 	// Block's compactor shard ID, copied from tsdb.CompactorShardIDExternalLabel label.
 	CompactorShardID string `json:"compactor_shard_id,omitempty"`
+
+	// SizeBytes is the total size of the block files, as listed in the block meta.json. It's zero
+	// if the size is unknown, like for blocks added to the index before the size was tracked.
+	SizeBytes int64 `json:"size_bytes,omitempty"`
 }
 
 // Within returns whether the block contains samples within the provided range.
@@ -157,7 +170,16 @@ func BlockFromThanosMeta(meta metadata.Meta) *Block {
 		SegmentsFormat:   segmentsFormat,
 		SegmentsNum:      segmentsNum,
 		CompactorShardID: meta.Thanos.Labels[mimir_tsdb.CompactorShardIDExternalLabel],
+		SizeBytes:        blockSizeBytes(meta),
+	}
+}
+
+func blockSizeBytes(meta metadata.Meta) int64 {
+	size := int64(0)
+	for _, f := range meta.Thanos.Files {
+		size += f.SizeBytes
 	}
+	return size
 }
 
 func detectBlockSegmentsFormat(meta metadata.Meta) (string, int) {
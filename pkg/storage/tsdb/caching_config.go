@@ -26,8 +26,11 @@ import (
 	"github.com/grafana/mimir/pkg/storage/tsdb/bucketcache"
 )
 
+var supportedCacheBackends = []string{cache.BackendMemcached, cache.BackendRedis}
+
 type ChunksCacheConfig struct {
 	cache.BackendConfig `yaml:",inline"`
+	LocalCache          cache.LocalCacheConfig `yaml:"local_cache"`
 
 	SubrangeSize               int64         `yaml:"subrange_size" category:"advanced"`
 	MaxGetRangeRequests        int           `yaml:"max_get_range_requests" category:"advanced"`
@@ -37,9 +40,11 @@ type ChunksCacheConfig struct {
 }
 
 func (cfg *ChunksCacheConfig) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix string) {
-	f.StringVar(&cfg.Backend, prefix+"backend", "", fmt.Sprintf("Backend for chunks cache, if not empty. Supported values: %s.", cache.BackendMemcached))
+	f.StringVar(&cfg.Backend, prefix+"backend", "", fmt.Sprintf("Backend for chunks cache, if not empty. Supported values: %s.", strings.Join(supportedCacheBackends, ", ")))
 
 	cfg.Memcached.RegisterFlagsWithPrefix(f, prefix+"memcached.")
+	cfg.Redis.RegisterFlagsWithPrefix(f, prefix+"redis.")
+	cfg.LocalCache.RegisterFlagsWithPrefix(f, prefix+"local-cache.")
 
 	f.Int64Var(&cfg.SubrangeSize, prefix+"subrange-size", 16000, "Size of each subrange that bucket object is split into for better caching.")
 	f.IntVar(&cfg.MaxGetRangeRequests, prefix+"max-get-range-requests", 3, "Maximum number of sub-GetRange requests that a single GetRange request can be split into when fetching chunks. Zero or negative value = unlimited number of sub-requests.")
@@ -49,6 +54,10 @@ func (cfg *ChunksCacheConfig) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix st
 }
 
 func (cfg *ChunksCacheConfig) Validate() error {
+	if err := cfg.LocalCache.Validate(); err != nil {
+		return err
+	}
+
 	return cfg.BackendConfig.Validate()
 }
 
@@ -69,9 +78,10 @@ type MetadataCacheConfig struct {
 }
 
 func (cfg *MetadataCacheConfig) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix string) {
-	f.StringVar(&cfg.Backend, prefix+"backend", "", fmt.Sprintf("Backend for metadata cache, if not empty. Supported values: %s.", cache.BackendMemcached))
+	f.StringVar(&cfg.Backend, prefix+"backend", "", fmt.Sprintf("Backend for metadata cache, if not empty. Supported values: %s.", strings.Join(supportedCacheBackends, ", ")))
 
 	cfg.Memcached.RegisterFlagsWithPrefix(f, prefix+"memcached.")
+	cfg.Redis.RegisterFlagsWithPrefix(f, prefix+"redis.")
 
 	f.DurationVar(&cfg.TenantsListTTL, prefix+"tenants-list-ttl", 15*time.Minute, "How long to cache list of tenants in the bucket.")
 	f.DurationVar(&cfg.TenantBlocksListTTL, prefix+"tenant-blocks-list-ttl", 5*time.Minute, "How long to cache list of blocks for each tenant.")
@@ -121,6 +131,11 @@ func CreateCachingBucket(chunksConfig ChunksCacheConfig, metadataConfig Metadata
 
 	if chunksCache != nil {
 		cachingConfigured = true
+
+		chunksCache, err = cache.WrapWithLocalCache(chunksCache, "chunks-cache", chunksConfig.LocalCache, reg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "wrap chunks cache with in-memory cache")
+		}
 		chunksCache = cache.NewSpanlessTracingCache(chunksCache, logger)
 
 		// Use the metadata cache for attributes if configured, otherwise fallback to chunks cache.
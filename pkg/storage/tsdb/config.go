@@ -63,13 +63,14 @@ const (
 
 // Validation errors
 var (
-	errInvalidShipConcurrency       = errors.New("invalid TSDB ship concurrency")
-	errInvalidOpeningConcurrency    = errors.New("invalid TSDB opening concurrency")
-	errInvalidCompactionInterval    = errors.New("invalid TSDB compaction interval")
-	errInvalidCompactionConcurrency = errors.New("invalid TSDB compaction concurrency")
-	errInvalidWALSegmentSizeBytes   = errors.New("invalid TSDB WAL segment size bytes")
-	errInvalidStripeSize            = errors.New("invalid TSDB stripe size")
-	errEmptyBlockranges             = errors.New("empty block ranges for TSDB")
+	errInvalidShipConcurrency                        = errors.New("invalid TSDB ship concurrency")
+	errInvalidOpeningConcurrency                     = errors.New("invalid TSDB opening concurrency")
+	errInvalidCompactionInterval                     = errors.New("invalid TSDB compaction interval")
+	errInvalidCompactionConcurrency                  = errors.New("invalid TSDB compaction concurrency")
+	errInvalidEarlyHeadCompactionMinInMemoryDuration = errors.New("invalid TSDB early head compaction min in-memory duration, must be greater than 0")
+	errInvalidWALSegmentSizeBytes                    = errors.New("invalid TSDB WAL segment size bytes")
+	errInvalidStripeSize                             = errors.New("invalid TSDB stripe size")
+	errEmptyBlockranges                              = errors.New("empty block ranges for TSDB")
 )
 
 // BlocksStorageConfig holds the config information for the blocks storage.
@@ -148,6 +149,11 @@ type TSDBConfig struct {
 	HeadCompactionInterval    time.Duration `yaml:"head_compaction_interval" category:"advanced"`
 	HeadCompactionConcurrency int           `yaml:"head_compaction_concurrency" category:"advanced"`
 	HeadCompactionIdleTimeout time.Duration `yaml:"head_compaction_idle_timeout" category:"advanced"`
+
+	EarlyHeadCompactionEnabled             bool          `yaml:"early_head_compaction_enabled" category:"experimental"`
+	EarlyHeadCompactionSeriesThreshold     int64         `yaml:"early_head_compaction_series_threshold" category:"experimental"`
+	EarlyHeadCompactionMinInMemoryDuration time.Duration `yaml:"early_head_compaction_min_in_memory_duration" category:"experimental"`
+
 	HeadChunksWriteBufferSize int           `yaml:"head_chunks_write_buffer_size_bytes" category:"advanced"`
 	HeadChunksEndTimeVariance float64       `yaml:"head_chunks_end_time_variance" category:"experimental"`
 	StripeSize                int           `yaml:"stripe_size" category:"advanced"`
@@ -189,6 +195,9 @@ func (cfg *TSDBConfig) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.HeadCompactionInterval, "blocks-storage.tsdb.head-compaction-interval", 1*time.Minute, "How frequently ingesters try to compact TSDB head. Block is only created if data covers smallest block range. Must be greater than 0 and max 5 minutes.")
 	f.IntVar(&cfg.HeadCompactionConcurrency, "blocks-storage.tsdb.head-compaction-concurrency", 5, "Maximum number of tenants concurrently compacting TSDB head into a new block")
 	f.DurationVar(&cfg.HeadCompactionIdleTimeout, "blocks-storage.tsdb.head-compaction-idle-timeout", 1*time.Hour, "If TSDB head is idle for this duration, it is compacted. Note that up to 25% jitter is added to the value to avoid ingesters compacting concurrently. 0 means disabled.")
+	f.BoolVar(&cfg.EarlyHeadCompactionEnabled, "blocks-storage.tsdb.early-head-compaction-enabled", false, "True to compact the TSDB head of the tenants with the most in-memory series before the regular head compaction, when the number of in-memory series in the ingester exceeds the early head compaction threshold, so that series which are no longer active get released from memory.")
+	f.Int64Var(&cfg.EarlyHeadCompactionSeriesThreshold, "blocks-storage.tsdb.early-head-compaction-series-threshold", 0, "Number of in-memory series in the ingester, across all tenants, above which the early head compaction is triggered. 0 to use 90% of -ingester.instance-limits.max-series.")
+	f.DurationVar(&cfg.EarlyHeadCompactionMinInMemoryDuration, "blocks-storage.tsdb.early-head-compaction-min-in-memory-duration", 15*time.Minute, "The most recent time range of samples kept in the TSDB head by the early head compaction. After the early head compaction of a tenant, samples older than this time range can't be ingested for the tenant anymore.")
 	f.IntVar(&cfg.HeadChunksWriteBufferSize, "blocks-storage.tsdb.head-chunks-write-buffer-size-bytes", chunks.DefaultWriteBufferSize, "The write buffer size used by the head chunks mapper. Lower values reduce memory utilisation on clusters with a large number of tenants at the cost of increased disk I/O operations.")
 	f.Float64Var(&cfg.HeadChunksEndTimeVariance, "blocks-storage.tsdb.head-chunks-end-time-variance", 0, "How much variance (as percentage between 0 and 1) should be applied to the chunk end time, to spread chunks writing across time. Doesn't apply to the last chunk of the chunk range. 0 means no variance.")
 	f.IntVar(&cfg.StripeSize, "blocks-storage.tsdb.stripe-size", 16384, "The number of shards of series to use in TSDB (must be a power of 2). Reducing this will decrease memory footprint, but can negatively impact performance.")
@@ -219,6 +228,10 @@ func (cfg *TSDBConfig) Validate() error {
 		return errInvalidCompactionConcurrency
 	}
 
+	if cfg.EarlyHeadCompactionEnabled && cfg.EarlyHeadCompactionMinInMemoryDuration <= 0 {
+		return errInvalidEarlyHeadCompactionMinInMemoryDuration
+	}
+
 	if cfg.HeadChunksWriteBufferSize < chunks.MinWriteBufferSize || cfg.HeadChunksWriteBufferSize > chunks.MaxWriteBufferSize || cfg.HeadChunksWriteBufferSize%1024 != 0 {
 		return errors.Errorf("head chunks write buffer size must be a multiple of 1024 between %d and %d", chunks.MinWriteBufferSize, chunks.MaxWriteBufferSize)
 	}
@@ -273,6 +286,9 @@ type BucketStoreConfig struct {
 	// Series hash cache.
 	SeriesHashCacheMaxBytes uint64 `yaml:"series_hash_cache_max_size_bytes" category:"advanced"`
 
+	// Blocks exemplars cache.
+	ExemplarsCacheMaxBytes uint64 `yaml:"exemplars_cache_max_size_bytes" category:"experimental"`
+
 	// Controls whether index-header lazy loading is enabled.
 	IndexHeaderLazyLoadingEnabled     bool          `yaml:"index_header_lazy_loading_enabled" category:"advanced"`
 	IndexHeaderLazyLoadingIdleTimeout time.Duration `yaml:"index_header_lazy_loading_idle_timeout" category:"advanced"`
@@ -301,6 +317,7 @@ func (cfg *BucketStoreConfig) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&cfg.ChunkPoolMinBucketSizeBytes, "blocks-storage.bucket-store.chunk-pool-min-bucket-size-bytes", ChunkPoolDefaultMinBucketSize, "Size - in bytes - of the smallest chunks pool bucket.")
 	f.IntVar(&cfg.ChunkPoolMaxBucketSizeBytes, "blocks-storage.bucket-store.chunk-pool-max-bucket-size-bytes", ChunkPoolDefaultMaxBucketSize, "Size - in bytes - of the largest chunks pool bucket.")
 	f.Uint64Var(&cfg.SeriesHashCacheMaxBytes, "blocks-storage.bucket-store.series-hash-cache-max-size-bytes", uint64(1*units.Gibibyte), "Max size - in bytes - of the in-memory series hash cache. The cache is shared across all tenants and it's used only when query sharding is enabled.")
+	f.Uint64Var(&cfg.ExemplarsCacheMaxBytes, "blocks-storage.bucket-store.exemplars-cache-max-size-bytes", uint64(256*units.Mebibyte), "Max size - in bytes - of the in-memory cache of the exemplars read from the blocks. The cache is shared across all tenants. 0 to disable the cache.")
 	f.IntVar(&cfg.MaxConcurrent, "blocks-storage.bucket-store.max-concurrent", 100, "Max number of concurrent queries to execute against the long-term storage. The limit is shared across all tenants.")
 	f.IntVar(&cfg.TenantSyncConcurrency, "blocks-storage.bucket-store.tenant-sync-concurrency", 10, "Maximum number of concurrent tenants synching blocks.")
 	f.IntVar(&cfg.BlockSyncConcurrency, "blocks-storage.bucket-store.block-sync-concurrency", 20, "Maximum number of concurrent blocks synching per tenant.")
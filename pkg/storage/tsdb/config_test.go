@@ -84,6 +84,20 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		"should fail on invalid early head compaction min in-memory duration": {
+			setup: func(cfg *BlocksStorageConfig) {
+				cfg.TSDB.EarlyHeadCompactionEnabled = true
+				cfg.TSDB.EarlyHeadCompactionMinInMemoryDuration = 0
+			},
+			expectedErr: errInvalidEarlyHeadCompactionMinInMemoryDuration,
+		},
+		"should pass on disabled early head compaction with invalid min in-memory duration": {
+			setup: func(cfg *BlocksStorageConfig) {
+				cfg.TSDB.EarlyHeadCompactionEnabled = false
+				cfg.TSDB.EarlyHeadCompactionMinInMemoryDuration = 0
+			},
+			expectedErr: nil,
+		},
 		"should fail on negative stripe size": {
 			setup: func(cfg *BlocksStorageConfig) {
 				cfg.TSDB.StripeSize = -2
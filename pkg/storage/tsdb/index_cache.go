@@ -29,6 +29,9 @@ const (
 	// IndexCacheBackendMemcached is the value for the memcached index cache backend.
 	IndexCacheBackendMemcached = cache.BackendMemcached
 
+	// IndexCacheBackendRedis is the value for the redis index cache backend.
+	IndexCacheBackendRedis = cache.BackendRedis
+
 	// IndexCacheBackendDefault is the value for the default index cache backend.
 	IndexCacheBackendDefault = IndexCacheBackendInMemory
 
@@ -36,7 +39,7 @@ const (
 )
 
 var (
-	supportedIndexCacheBackends = []string{IndexCacheBackendInMemory, IndexCacheBackendMemcached}
+	supportedIndexCacheBackends = []string{IndexCacheBackendInMemory, IndexCacheBackendMemcached, IndexCacheBackendRedis}
 
 	errUnsupportedIndexCacheBackend = errors.New("unsupported index cache backend")
 )
@@ -44,6 +47,7 @@ var (
 type IndexCacheConfig struct {
 	cache.BackendConfig `yaml:",inline"`
 	InMemory            InMemoryIndexCacheConfig `yaml:"inmemory"`
+	LocalCache          cache.LocalCacheConfig   `yaml:"local_cache"`
 }
 
 func (cfg *IndexCacheConfig) RegisterFlags(f *flag.FlagSet) {
@@ -55,6 +59,8 @@ func (cfg *IndexCacheConfig) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix str
 
 	cfg.InMemory.RegisterFlagsWithPrefix(f, prefix+"inmemory.")
 	cfg.Memcached.RegisterFlagsWithPrefix(f, prefix+"memcached.")
+	cfg.Redis.RegisterFlagsWithPrefix(f, prefix+"redis.")
+	cfg.LocalCache.RegisterFlagsWithPrefix(f, prefix+"local-cache.")
 }
 
 // Validate the config.
@@ -69,6 +75,16 @@ func (cfg *IndexCacheConfig) Validate() error {
 		}
 	}
 
+	if cfg.Backend == IndexCacheBackendRedis {
+		if err := cfg.Redis.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.LocalCache.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -86,7 +102,9 @@ func NewIndexCache(cfg IndexCacheConfig, logger log.Logger, registerer prometheu
 	case IndexCacheBackendInMemory:
 		return newInMemoryIndexCache(cfg.InMemory, logger, registerer)
 	case IndexCacheBackendMemcached:
-		return newMemcachedIndexCache(cfg.Memcached, logger, registerer)
+		return newMemcachedIndexCache(cfg.Memcached, cfg.LocalCache, logger, registerer)
+	case IndexCacheBackendRedis:
+		return newRedisIndexCache(cfg.Redis, cfg.LocalCache, logger, registerer)
 	default:
 		return nil, errUnsupportedIndexCacheBackend
 	}
@@ -107,15 +125,34 @@ func newInMemoryIndexCache(cfg InMemoryIndexCacheConfig, logger log.Logger, regi
 	})
 }
 
-func newMemcachedIndexCache(cfg cache.MemcachedConfig, logger log.Logger, registerer prometheus.Registerer) (indexcache.IndexCache, error) {
+func newMemcachedIndexCache(cfg cache.MemcachedConfig, localCfg cache.LocalCacheConfig, logger log.Logger, registerer prometheus.Registerer) (indexcache.IndexCache, error) {
 	client, err := cacheutil.NewMemcachedClientWithConfig(logger, "index-cache", cfg.ToMemcachedClientConfig(), registerer)
 	if err != nil {
 		return nil, errors.Wrap(err, "create index cache memcached client")
 	}
 
+	return newRemoteIndexCache(client, localCfg, logger, registerer)
+}
+
+func newRedisIndexCache(cfg cache.RedisConfig, localCfg cache.LocalCacheConfig, logger log.Logger, registerer prometheus.Registerer) (indexcache.IndexCache, error) {
+	client, err := cache.NewRedisClient(logger, "index-cache", cfg, registerer)
+	if err != nil {
+		return nil, errors.Wrap(err, "create index cache redis client")
+	}
+
+	return newRemoteIndexCache(client, localCfg, logger, registerer)
+}
+
+func newRemoteIndexCache(client cacheutil.RemoteCacheClient, localCfg cache.LocalCacheConfig, logger log.Logger, registerer prometheus.Registerer) (indexcache.IndexCache, error) {
+	client, err := cache.WrapRemoteCacheClientWithLocalCache(client, "index-cache", localCfg, registerer)
+	if err != nil {
+		return nil, errors.Wrap(err, "wrap index cache client with in-memory cache")
+	}
+
+	// The remote index cache implementation is agnostic of the underlying remote cache client.
 	cache, err := indexcache.NewMemcachedIndexCache(logger, client, registerer)
 	if err != nil {
-		return nil, errors.Wrap(err, "create memcached-based index cache")
+		return nil, errors.Wrap(err, "create remote index cache")
 	}
 
 	return indexcache.NewTracingIndexCache(cache, logger), nil
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tsdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/runutil"
+	"github.com/pkg/errors"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+const (
+	// MetricsMetadataPathname is the name of the tenant prefix holding the metric metadata.
+	MetricsMetadataPathname = "metrics-metadata"
+
+	// MetricsMetadataFilename is the name of the object holding the metric metadata snapshots merged by the compactor.
+	MetricsMetadataFilename = "metadata.json.gz"
+
+	// MetricsMetadataSnapshotsPathname is the name of the prefix holding the metric metadata snapshots uploaded
+	// by the ingesters, which haven't been merged by the compactor yet.
+	MetricsMetadataSnapshotsPathname = "snapshots"
+
+	// MetricsMetadataVersion1 is the only supported metric metadata version.
+	MetricsMetadataVersion1 = 1
+)
+
+var (
+	ErrMetricsMetadataNotFound  = errors.New("metrics metadata not found")
+	ErrMetricsMetadataCorrupted = errors.New("metrics metadata corrupted")
+)
+
+// MetricsMetadata holds the metric metadata of a tenant.
+type MetricsMetadata struct {
+	Version int `json:"version"`
+
+	// Metadata sorted by metric family name, type, help and unit.
+	Metadata []MetricMetadata `json:"metadata"`
+}
+
+type MetricMetadata struct {
+	Metric string `json:"metric"`
+	Type   string `json:"type"`
+	Help   string `json:"help"`
+	Unit   string `json:"unit"`
+
+	// LastSeen is the unix timestamp, in milliseconds, of the last time the metadata has been ingested.
+	LastSeen int64 `json:"last_seen"`
+}
+
+func (m MetricMetadata) key() MetricMetadata {
+	m.LastSeen = 0
+	return m
+}
+
+// MergeMetricsMetadata merges multiple metric metadata snapshots. The same metadata found in more than one
+// snapshot is deduplicated, keeping the most recent last seen timestamp.
+func MergeMetricsMetadata(snapshots ...*MetricsMetadata) *MetricsMetadata {
+	merged := map[MetricMetadata]int64{}
+	for _, s := range snapshots {
+		for _, m := range s.Metadata {
+			if lastSeen, ok := merged[m.key()]; !ok || m.LastSeen > lastSeen {
+				merged[m.key()] = m.LastSeen
+			}
+		}
+	}
+
+	res := &MetricsMetadata{Version: MetricsMetadataVersion1, Metadata: make([]MetricMetadata, 0, len(merged))}
+	for m, lastSeen := range merged {
+		m.LastSeen = lastSeen
+		res.Metadata = append(res.Metadata, m)
+	}
+	sort.Slice(res.Metadata, func(i, j int) bool {
+		a, b := res.Metadata[i], res.Metadata[j]
+		if a.Metric != b.Metric {
+			return a.Metric < b.Metric
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Help != b.Help {
+			return a.Help < b.Help
+		}
+		return a.Unit < b.Unit
+	})
+
+	return res
+}
+
+// RemoveOlderThan removes the metadata which has not been seen since the input time.
+func (m *MetricsMetadata) RemoveOlderThan(t time.Time) {
+	minLastSeen := t.UnixMilli()
+	kept := m.Metadata[:0]
+	for _, md := range m.Metadata {
+		if md.LastSeen >= minLastSeen {
+			kept = append(kept, md)
+		}
+	}
+	m.Metadata = kept
+}
+
+// MetricsMetadataSnapshotPath returns the path, relative to the tenant prefix, of the metric metadata
+// snapshot uploaded by the input instance at the input time.
+func MetricsMetadataSnapshotPath(instanceID string, t time.Time) string {
+	return path.Join(MetricsMetadataPathname, MetricsMetadataSnapshotsPathname, fmt.Sprintf("%s-%d.json.gz", instanceID, t.UnixMilli()))
+}
+
+// ListMetricsMetadataSnapshots returns the paths, relative to the tenant prefix, of the metric metadata
+// snapshots uploaded by the ingesters and not merged yet. The bucket is expected to be a tenant bucket client.
+func ListMetricsMetadataSnapshots(ctx context.Context, bkt objstore.BucketReader) ([]string, error) {
+	var snapshots []string
+	err := bkt.Iter(ctx, path.Join(MetricsMetadataPathname, MetricsMetadataSnapshotsPathname), func(name string) error {
+		if strings.HasSuffix(name, ".json.gz") {
+			snapshots = append(snapshots, name)
+		}
+		return nil
+	})
+	return snapshots, errors.Wrap(err, "list metrics metadata snapshots")
+}
+
+// ReadMetricsMetadata reads the merged metric metadata of the tenant. The bucket is expected to be a tenant
+// bucket client. Returns ErrMetricsMetadataNotFound if the tenant has no metric metadata.
+func ReadMetricsMetadata(ctx context.Context, bkt objstore.InstrumentedBucketReader, logger log.Logger) (*MetricsMetadata, error) {
+	return ReadMetricsMetadataObject(ctx, bkt, path.Join(MetricsMetadataPathname, MetricsMetadataFilename), logger)
+}
+
+// ReadMetricsMetadataObject reads the metric metadata stored at the input path, relative to the tenant prefix.
+// Returns ErrMetricsMetadataNotFound if the object does not exist.
+func ReadMetricsMetadataObject(ctx context.Context, bkt objstore.InstrumentedBucketReader, name string, logger log.Logger) (*MetricsMetadata, error) {
+	reader, err := bkt.ReaderWithExpectedErrs(bkt.IsObjNotFoundErr).Get(ctx, name)
+	if err != nil {
+		if bkt.IsObjNotFoundErr(err) {
+			return nil, ErrMetricsMetadataNotFound
+		}
+		return nil, errors.Wrapf(err, "read metrics metadata %s", name)
+	}
+	defer runutil.CloseWithLogOnErr(logger, reader, "close metrics metadata reader")
+
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, ErrMetricsMetadataCorrupted
+	}
+	defer runutil.CloseWithLogOnErr(logger, gzipReader, "close metrics metadata gzip reader")
+
+	m := &MetricsMetadata{}
+	if err := json.NewDecoder(gzipReader).Decode(m); err != nil {
+		return nil, ErrMetricsMetadataCorrupted
+	}
+	if m.Version != MetricsMetadataVersion1 {
+		return nil, errors.Errorf("unsupported metrics metadata version %d", m.Version)
+	}
+
+	return m, nil
+}
+
+// WriteMetricsMetadata uploads the merged metric metadata of the tenant. The bucket is expected to be a tenant bucket client.
+func WriteMetricsMetadata(ctx context.Context, bkt objstore.Bucket, m *MetricsMetadata) error {
+	return WriteMetricsMetadataObject(ctx, bkt, path.Join(MetricsMetadataPathname, MetricsMetadataFilename), m)
+}
+
+// WriteMetricsMetadataObject uploads the metric metadata at the input path, relative to the tenant prefix.
+func WriteMetricsMetadataObject(ctx context.Context, bkt objstore.Bucket, name string, m *MetricsMetadata) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "marshal metrics metadata")
+	}
+
+	var gzipContent bytes.Buffer
+	gzip := gzip.NewWriter(&gzipContent)
+	if _, err := gzip.Write(content); err != nil {
+		return errors.Wrap(err, "gzip metrics metadata")
+	}
+	if err := gzip.Close(); err != nil {
+		return errors.Wrap(err, "close gzip metrics metadata")
+	}
+
+	return errors.Wrapf(bkt.Upload(ctx, name, &gzipContent), "upload metrics metadata %s", name)
+}
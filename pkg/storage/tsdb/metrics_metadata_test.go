@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package tsdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestMergeMetricsMetadata(t *testing.T) {
+	snapshot1 := &MetricsMetadata{Version: MetricsMetadataVersion1, Metadata: []MetricMetadata{
+		{Metric: "metric_b", Type: "gauge", Help: "B", LastSeen: 10},
+		{Metric: "metric_a", Type: "counter", Help: "A", LastSeen: 20},
+	}}
+	snapshot2 := &MetricsMetadata{Version: MetricsMetadataVersion1, Metadata: []MetricMetadata{
+		{Metric: "metric_a", Type: "counter", Help: "A", LastSeen: 15},
+		{Metric: "metric_a", Type: "counter", Help: "Another A", LastSeen: 30},
+		{Metric: "metric_b", Type: "gauge", Help: "B", LastSeen: 40},
+	}}
+
+	merged := MergeMetricsMetadata(snapshot1, snapshot2)
+	assert.Equal(t, &MetricsMetadata{Version: MetricsMetadataVersion1, Metadata: []MetricMetadata{
+		{Metric: "metric_a", Type: "counter", Help: "A", LastSeen: 20},
+		{Metric: "metric_a", Type: "counter", Help: "Another A", LastSeen: 30},
+		{Metric: "metric_b", Type: "gauge", Help: "B", LastSeen: 40},
+	}}, merged)
+
+	merged.RemoveOlderThan(time.UnixMilli(30))
+	assert.Equal(t, []MetricMetadata{
+		{Metric: "metric_a", Type: "counter", Help: "Another A", LastSeen: 30},
+		{Metric: "metric_b", Type: "gauge", Help: "B", LastSeen: 40},
+	}, merged.Metadata)
+}
+
+func TestMetricsMetadata_ReadWrite(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.WithNoopInstr(objstore.NewInMemBucket())
+
+	_, err := ReadMetricsMetadata(ctx, bkt, log.NewNopLogger())
+	require.Equal(t, ErrMetricsMetadataNotFound, err)
+
+	expected := &MetricsMetadata{Version: MetricsMetadataVersion1, Metadata: []MetricMetadata{
+		{Metric: "metric", Type: "counter", Help: "Help.", Unit: "seconds", LastSeen: 10},
+	}}
+	require.NoError(t, WriteMetricsMetadata(ctx, bkt, expected))
+
+	actual, err := ReadMetricsMetadata(ctx, bkt, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// Snapshots are listed separately from the merged metadata.
+	snapshots, err := ListMetricsMetadataSnapshots(ctx, bkt)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	snapshot := MetricsMetadataSnapshotPath("ingester-1", time.UnixMilli(1000))
+	assert.Equal(t, "metrics-metadata/snapshots/ingester-1-1000.json.gz", snapshot)
+	require.NoError(t, WriteMetricsMetadataObject(ctx, bkt, snapshot, expected))
+
+	snapshots, err = ListMetricsMetadataSnapshots(ctx, bkt)
+	require.NoError(t, err)
+	assert.Equal(t, []string{snapshot}, snapshots)
+
+	actual, err = ReadMetricsMetadataObject(ctx, bkt, snapshot, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// Corrupted metadata.
+	require.NoError(t, bkt.Upload(ctx, snapshot, strings.NewReader("invalid!}")))
+	_, err = ReadMetricsMetadataObject(ctx, bkt, snapshot, log.NewNopLogger())
+	require.Equal(t, ErrMetricsMetadataCorrupted, err)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/gogo/protobuf/types"
+	"github.com/grafana/dskit/concurrency"
 	"github.com/grafana/dskit/runutil"
 	"github.com/oklog/ulid"
 	"github.com/opentracing/opentracing-go"
@@ -53,13 +54,16 @@ import (
 	"github.com/thanos-io/thanos/pkg/store/storepb"
 	"github.com/thanos-io/thanos/pkg/strutil"
 	"github.com/thanos-io/thanos/pkg/tracing"
+	"go.uber.org/atomic"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/grafana/mimir/pkg/mimirpb"
 	"github.com/grafana/mimir/pkg/storage/sharding"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storegateway/indexcache"
+	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
 	util_math "github.com/grafana/mimir/pkg/util/math"
 	"github.com/grafana/mimir/pkg/util/spanlogger"
 )
@@ -92,6 +96,24 @@ const (
 	// Labels for metrics.
 	labelEncode = "encode"
 	labelDecode = "decode"
+
+	// labelValuesCardinalityBlocksConcurrency is the max number of blocks concurrently queried
+	// by a single LabelValuesCardinality() call.
+	labelValuesCardinalityBlocksConcurrency = 4
+
+	// exemplarsBlocksConcurrency is the max number of blocks concurrently queried by a single Exemplars() call.
+	exemplarsBlocksConcurrency = 4
+)
+
+var (
+	// errNoLabelValuesCardinalityLabelNames is returned when a LabelValuesCardinality() call has no label names.
+	errNoLabelValuesCardinalityLabelNames = errors.New("at least one label name is required")
+
+	// errMaxLabelValuesPerLabelNameExceeded is returned when a label name has more values than the limit in a block.
+	errMaxLabelValuesPerLabelNameExceeded = errors.New("the label values cardinality request exceeded the max number of label values per label name")
+
+	// errMaxExemplarsPerRequestExceeded is returned when an Exemplars() call reads more exemplars than the limit.
+	errMaxExemplarsPerRequestExceeded = errors.New("the exemplars request exceeded the max number of exemplars per request")
 )
 
 // FilterConfig is a configuration, which Store uses for filtering metrics based on time.
@@ -121,6 +143,7 @@ type BucketStore struct {
 	indexReaderPool *indexheader.ReaderPool
 	chunkPool       pool.Bytes
 	seriesHashCache *hashcache.SeriesHashCache
+	exemplarsCache  *exemplarsCache
 
 	// Sets of blocks that have the same labels. They are indexed by a hash over their label set.
 	mtx       sync.RWMutex
@@ -221,6 +244,14 @@ func WithChunkPool(chunkPool pool.Bytes) BucketStoreOption {
 	}
 }
 
+// WithExemplarsCache sets the cache of the blocks exemplars. If not set, the exemplars are read
+// from the bucket on each request.
+func WithExemplarsCache(cache *exemplarsCache) BucketStoreOption {
+	return func(s *BucketStore) {
+		s.exemplarsCache = cache
+	}
+}
+
 // WithFilterConfig sets a filter which Store uses for filtering metrics based on time.
 func WithFilterConfig(filter *FilterConfig) BucketStoreOption {
 	return func(s *BucketStore) {
@@ -516,6 +547,8 @@ func (s *BucketStore) removeBlock(id ulid.ULID) error {
 		return nil
 	}
 
+	s.exemplarsCache.remove(s.userID, id)
+
 	if err := b.Close(); err != nil {
 		return errors.Wrap(err, "close block")
 	}
@@ -1542,6 +1575,290 @@ func storeCachedLabelValues(ctx context.Context, indexCache indexcache.IndexCach
 	indexCache.StoreLabelValues(ctx, userID, blockID, labelName, entry.MatchersKey, data)
 }
 
+// LabelValuesCardinality returns, for each queried block, the number of series for each value
+// of the requested label names, optionally restricting the count to the series matching the matchers provided.
+// The request is rejected if any requested label name has more than maxValuesPerLabelName values in a block.
+// 0 disables the limit.
+func (s *BucketStore) LabelValuesCardinality(ctx context.Context, req *storegatewaypb.LabelValuesCardinalityRequest, maxValuesPerLabelName int) (_ *storegatewaypb.LabelValuesCardinalityResponse, err error) {
+	if len(req.LabelNames) == 0 {
+		return nil, status.Error(codes.InvalidArgument, errNoLabelValuesCardinalityLabelNames.Error())
+	}
+
+	reqSeriesMatchers, err := storepb.MatchersToPromMatchers(req.Matchers...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "translate request labels matchers").Error())
+	}
+
+	var reqBlockMatchers []*labels.Matcher
+	if req.Hints != nil {
+		reqHints := &hintspb.LabelValuesRequestHints{}
+		err := types.UnmarshalAny(req.Hints, reqHints)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "unmarshal label values cardinality request hints").Error())
+		}
+
+		reqBlockMatchers, err = storepb.MatchersToPromMatchers(reqHints.BlockMatchers...)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "translate request hints labels matchers").Error())
+		}
+	}
+
+	// The cardinality is computed from the postings of each block, so the request is subject
+	// to the same concurrency limit of the series requests.
+	if s.queryGate != nil {
+		tracing.DoInSpan(ctx, "store_query_gate_ismyturn", func(ctx context.Context) {
+			err = s.queryGate.Start(ctx)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to wait for turn")
+		}
+
+		defer s.queryGate.Done()
+	}
+
+	resHints := &hintspb.LabelValuesResponseHints{}
+
+	s.mtx.RLock()
+
+	var queriedBlocks []*bucketBlock
+	for _, b := range s.blocks {
+		if !b.overlapsClosedInterval(req.Start, req.End) {
+			continue
+		}
+		if len(reqBlockMatchers) > 0 && !b.matchRelabelLabels(reqBlockMatchers) {
+			continue
+		}
+
+		resHints.AddQueriedBlock(b.meta.ULID)
+		queriedBlocks = append(queriedBlocks, b)
+	}
+
+	// The index readers are acquired while holding the lock, so that the blocks can't be closed meanwhile.
+	indexReaders := make([]*bucketIndexReader, 0, len(queriedBlocks))
+	for _, b := range queriedBlocks {
+		indexReaders = append(indexReaders, b.indexReader())
+	}
+
+	s.mtx.RUnlock()
+
+	defer func() {
+		for _, indexr := range indexReaders {
+			runutil.CloseWithLogOnErr(s.logger, indexr, "label values cardinality")
+		}
+	}()
+
+	blocks := make([]*storegatewaypb.BlockLabelValuesCardinality, len(queriedBlocks))
+	err = concurrency.ForEachJob(ctx, len(queriedBlocks), labelValuesCardinalityBlocksConcurrency, func(ctx context.Context, idx int) error {
+		b := queriedBlocks[idx]
+
+		items, err := blockLabelValuesCardinality(ctx, indexReaders[idx], req.LabelNames, reqSeriesMatchers, maxValuesPerLabelName)
+		if err != nil {
+			return errors.Wrapf(err, "block %s", b.meta.ULID)
+		}
+
+		blocks[idx] = &storegatewaypb.BlockLabelValuesCardinality{
+			BlockId:          b.meta.ULID.String(),
+			Items:            items,
+			MinTime:          b.meta.MinTime,
+			MaxTime:          b.meta.MaxTime,
+			CompactorShardId: b.meta.Thanos.Labels[mimir_tsdb.CompactorShardIDExternalLabel],
+			SeriesCount:      b.meta.Stats.NumSeries,
+		}
+		return nil
+	})
+	if errors.Is(err, errMaxLabelValuesPerLabelNameExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	anyHints, err := types.MarshalAny(resHints)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, errors.Wrap(err, "marshal label values cardinality response hints").Error())
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].BlockId < blocks[j].BlockId
+	})
+
+	return &storegatewaypb.LabelValuesCardinalityResponse{
+		Blocks: blocks,
+		Hints:  anyHints,
+	}, nil
+}
+
+// Exemplars returns the exemplars stored alongside the queried blocks, within the requested time range
+// and matching any of the requested matcher sets. The request is rejected if more than maxExemplars
+// exemplars are read, counting an exemplar once for each block it's read from. 0 disables the limit.
+func (s *BucketStore) Exemplars(ctx context.Context, req *storegatewaypb.ExemplarsRequest, maxExemplars int) (_ *storegatewaypb.ExemplarsResponse, err error) {
+	reqMatcherSets := make([][]*labels.Matcher, 0, len(req.Matchers))
+	for _, m := range req.Matchers {
+		matchers, err := storepb.MatchersToPromMatchers(m.Matchers...)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "translate request labels matchers").Error())
+		}
+		reqMatcherSets = append(reqMatcherSets, matchers)
+	}
+
+	var reqBlockMatchers []*labels.Matcher
+	if req.Hints != nil {
+		reqHints := &hintspb.LabelValuesRequestHints{}
+		err := types.UnmarshalAny(req.Hints, reqHints)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "unmarshal exemplars request hints").Error())
+		}
+
+		reqBlockMatchers, err = storepb.MatchersToPromMatchers(reqHints.BlockMatchers...)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, errors.Wrap(err, "translate request hints labels matchers").Error())
+		}
+	}
+
+	// Reading and decoding the exemplars of a block the first time is expensive, so the request is
+	// subject to the same concurrency limit of the series requests.
+	if s.queryGate != nil {
+		tracing.DoInSpan(ctx, "store_query_gate_ismyturn", func(ctx context.Context) {
+			err = s.queryGate.Start(ctx)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to wait for turn")
+		}
+
+		defer s.queryGate.Done()
+	}
+
+	resHints := &hintspb.LabelValuesResponseHints{}
+
+	s.mtx.RLock()
+
+	var queriedBlocks []*bucketBlock
+	for _, b := range s.blocks {
+		if !b.overlapsClosedInterval(req.Start, req.End) {
+			continue
+		}
+		if len(reqBlockMatchers) > 0 && !b.matchRelabelLabels(reqBlockMatchers) {
+			continue
+		}
+
+		resHints.AddQueriedBlock(b.meta.ULID)
+		queriedBlocks = append(queriedBlocks, b)
+	}
+
+	s.mtx.RUnlock()
+
+	var numExemplars atomic.Int64
+	blocksExemplars := make([]*mimir_tsdb.BlockExemplars, len(queriedBlocks))
+	err = concurrency.ForEachJob(ctx, len(queriedBlocks), exemplarsBlocksConcurrency, func(ctx context.Context, idx int) error {
+		b := queriedBlocks[idx]
+
+		exemplars, err := b.readExemplars(ctx, s.bkt, s.exemplarsCache)
+		if err != nil {
+			return errors.Wrapf(err, "block %s", b.meta.ULID)
+		}
+
+		exemplars = exemplars.Select(req.Start, req.End, reqMatcherSets...)
+		if total := numExemplars.Add(int64(exemplars.NumExemplars())); maxExemplars > 0 && total > int64(maxExemplars) {
+			return errors.Wrapf(errMaxExemplarsPerRequestExceeded, "read %d exemplars (limit: %d)", total, maxExemplars)
+		}
+
+		blocksExemplars[idx] = exemplars
+		return nil
+	})
+	if errors.Is(err, errMaxExemplarsPerRequestExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	anyHints, err := types.MarshalAny(resHints)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, errors.Wrap(err, "marshal exemplars response hints").Error())
+	}
+
+	// The same exemplars may be stored in multiple blocks (eg. uploaded by different ingesters).
+	merged := mimir_tsdb.MergeBlockExemplars(blocksExemplars...)
+
+	res := &storegatewaypb.ExemplarsResponse{Hints: anyHints}
+	for _, series := range merged.QueryResults() {
+		res.Timeseries = append(res.Timeseries, mimirpb.TimeSeries{
+			Labels:    mimirpb.FromLabelsToLabelAdapters(series.SeriesLabels),
+			Exemplars: mimirpb.FromExemplarsToExemplarProtos(series.Exemplars),
+		})
+	}
+
+	return res, nil
+}
+
+// blockLabelValuesCardinality returns the number of series for each value of the requested label names
+// in the block. If matchers are provided, only the series matching them are counted, and the label values
+// without any matching series are not returned. If a label name has more than maxValuesPerLabelName values
+// in the block, errMaxLabelValuesPerLabelNameExceeded is returned. 0 disables the limit.
+func blockLabelValuesCardinality(ctx context.Context, indexr *bucketIndexReader, labelNames []string, matchers []*labels.Matcher, maxValuesPerLabelName int) ([]*storegatewaypb.LabelValueSeriesCount, error) {
+	var matchedPostings []storage.SeriesRef
+	if len(matchers) > 0 {
+		var err error
+		matchedPostings, err = indexr.ExpandedPostings(ctx, matchers)
+		if err != nil {
+			return nil, errors.Wrap(err, "expanded postings")
+		}
+	}
+
+	items := make([]*storegatewaypb.LabelValueSeriesCount, 0, len(labelNames))
+	for _, labelName := range labelNames {
+		values, err := indexr.block.indexHeaderReader.LabelValues(labelName)
+		if err != nil {
+			return nil, errors.Wrap(err, "index header label values")
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if maxValuesPerLabelName > 0 && len(values) > maxValuesPerLabelName {
+			return nil, errors.Wrapf(errMaxLabelValuesPerLabelNameExceeded, "label name %q has %d values (limit: %d)", labelName, len(values), maxValuesPerLabelName)
+		}
+
+		keys := make([]labels.Label, len(values))
+		for i, value := range values {
+			keys[i] = labels.Label{Name: labelName, Value: value}
+		}
+
+		fetchedPostings, err := indexr.FetchPostings(ctx, keys)
+		if err != nil {
+			return nil, errors.Wrap(err, "get postings")
+		}
+
+		counts := make(map[string]uint64, len(values))
+		for i, value := range values {
+			p := fetchedPostings[i]
+			if len(matchers) > 0 {
+				p = index.Intersect(index.NewListPostings(matchedPostings), p)
+			}
+
+			count := uint64(0)
+			for p.Next() {
+				count++
+			}
+			if err := p.Err(); err != nil {
+				return nil, errors.Wrapf(err, "counting value %q postings", value)
+			}
+
+			if count > 0 {
+				counts[value] = count
+			}
+		}
+
+		if len(counts) > 0 {
+			items = append(items, &storegatewaypb.LabelValueSeriesCount{
+				LabelName:        labelName,
+				LabelValueSeries: counts,
+			})
+		}
+	}
+
+	return items, nil
+}
+
 // them up by downsampling resolution and allows querying.
 // bucketBlockSet holds all blocks of an equal label set. It internally splits
 type bucketBlockSet struct {
@@ -1702,6 +2019,9 @@ type bucketBlock struct {
 	relabelLabels labels.Labels
 
 	expandedPostingsPromises sync.Map
+
+	// Serializes the reads of the block exemplars, so that they're read once by concurrent requests.
+	exemplarsMtx sync.Mutex
 }
 
 func newBucketBlock(
@@ -1813,6 +2133,32 @@ func (b *bucketBlock) chunkRangeReader(ctx context.Context, seq int, off, length
 	return b.bkt.GetRange(ctx, b.chunkObjs[seq], off, length)
 }
 
+// readExemplars returns the exemplars of the block, reading them from the bucket unless they're cached.
+// A block uploaded without exemplars, or whose exemplars are corrupted, has no exemplars.
+func (b *bucketBlock) readExemplars(ctx context.Context, bkt objstore.InstrumentedBucketReader, cache *exemplarsCache) (*mimir_tsdb.BlockExemplars, error) {
+	b.exemplarsMtx.Lock()
+	defer b.exemplarsMtx.Unlock()
+
+	if exemplars, ok := cache.get(b.userID, b.meta.ULID); ok {
+		return exemplars, nil
+	}
+
+	exemplars, err := mimir_tsdb.ReadBlockExemplars(ctx, bkt, b.meta.ULID, b.logger)
+	if errors.Is(err, mimir_tsdb.ErrBlockExemplarsNotFound) {
+		// The exemplars are uploaded before the block, so they won't show up later.
+		exemplars = &mimir_tsdb.BlockExemplars{Version: mimir_tsdb.BlockExemplarsVersion1}
+	} else if errors.Is(err, mimir_tsdb.ErrBlockExemplarsCorrupted) {
+		// The exemplars are not essential to the block, so we don't want to fail the request.
+		level.Warn(b.logger).Log("msg", "skipping corrupted block exemplars", "block", b.meta.ULID)
+		exemplars = &mimir_tsdb.BlockExemplars{Version: mimir_tsdb.BlockExemplarsVersion1}
+	} else if err != nil {
+		return nil, err
+	}
+
+	cache.set(b.userID, b.meta.ULID, exemplars)
+	return exemplars, nil
+}
+
 func (b *bucketBlock) indexReader() *bucketIndexReader {
 	b.pendingReaders.Add(1)
 	return newBucketIndexReader(b)
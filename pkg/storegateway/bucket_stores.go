@@ -33,16 +33,30 @@ import (
 	"github.com/thanos-io/thanos/pkg/store/storepb"
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/logging"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storegateway/indexcache"
+	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
 	util_log "github.com/grafana/mimir/pkg/util/log"
 	"github.com/grafana/mimir/pkg/util/spanlogger"
 	"github.com/grafana/mimir/pkg/util/validation"
 )
 
+const (
+	// Reasons of the requests rejected because exceeding the per-tenant limits.
+	rejectReasonMaxConcurrentSeriesRequests = "max-concurrent-series-requests"
+	rejectReasonMaxSeriesPerRequest         = "max-series-per-request"
+	rejectReasonMaxChunksPerQuery           = "max-fetched-chunks-per-query"
+	rejectReasonMaxLabelValuesPerLabelName  = "max-label-values-per-label-name"
+	rejectReasonMaxExemplarsPerRequest      = "max-exemplars-per-request"
+)
+
+var rejectReasons = []string{rejectReasonMaxConcurrentSeriesRequests, rejectReasonMaxSeriesPerRequest, rejectReasonMaxChunksPerQuery, rejectReasonMaxLabelValuesPerLabelName, rejectReasonMaxExemplarsPerRequest}
+
 // BucketStores is a multi-tenant wrapper of Thanos BucketStore.
 type BucketStores struct {
 	logger             log.Logger
@@ -60,6 +74,9 @@ type BucketStores struct {
 	// Series hash cache shared across all tenants.
 	seriesHashCache *hashcache.SeriesHashCache
 
+	// Blocks exemplars cache shared across all tenants.
+	exemplarsCache *exemplarsCache
+
 	// Chunks bytes pool shared across all tenants.
 	chunksPool pool.Bytes
 
@@ -73,12 +90,17 @@ type BucketStores struct {
 	storesMu sync.RWMutex
 	stores   map[string]*BucketStore
 
+	// Keeps the number of in-flight series requests for each tenant.
+	inflightSeriesMu sync.Mutex
+	inflightSeries   map[string]int
+
 	// Metrics.
 	syncTimes         prometheus.Histogram
 	syncLastSuccess   prometheus.Gauge
 	tenantsDiscovered prometheus.Gauge
 	tenantsSynced     prometheus.Gauge
 	blocksLoaded      prometheus.GaugeFunc
+	requestsRejected  *prometheus.CounterVec
 }
 
 // NewBucketStores makes a new BucketStores.
@@ -96,6 +118,11 @@ func NewBucketStores(cfg tsdb.BlocksStorageConfig, shardingStrategy ShardingStra
 		Help: "Number of maximum concurrent queries allowed.",
 	}).Set(float64(cfg.BucketStore.MaxConcurrent))
 
+	exemplarsCache, err := newExemplarsCache(cfg.BucketStore.ExemplarsCacheMaxBytes, reg)
+	if err != nil {
+		return nil, errors.Wrap(err, "create exemplars cache")
+	}
+
 	u := &BucketStores{
 		logger:             logger,
 		cfg:                cfg,
@@ -103,12 +130,14 @@ func NewBucketStores(cfg tsdb.BlocksStorageConfig, shardingStrategy ShardingStra
 		bucket:             cachingBucket,
 		shardingStrategy:   shardingStrategy,
 		stores:             map[string]*BucketStore{},
+		inflightSeries:     map[string]int{},
 		logLevel:           logLevel,
 		bucketStoreMetrics: NewBucketStoreMetrics(reg),
 		metaFetcherMetrics: NewMetadataFetcherMetrics(),
 		queryGate:          queryGate,
 		partitioner:        newGapBasedPartitioner(cfg.BucketStore.PartitionerMaxGapBytes, reg),
 		seriesHashCache:    hashcache.NewSeriesHashCache(cfg.BucketStore.SeriesHashCacheMaxBytes),
+		exemplarsCache:     exemplarsCache,
 	}
 
 	// Register metrics.
@@ -133,6 +162,10 @@ func NewBucketStores(cfg tsdb.BlocksStorageConfig, shardingStrategy ShardingStra
 		Name: "cortex_bucket_store_blocks_loaded",
 		Help: "Number of currently loaded blocks.",
 	}, u.getBlocksLoadedMetric)
+	u.requestsRejected = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "cortex_bucket_stores_requests_rejected_total",
+		Help: "Total number of requests rejected because exceeding the per-tenant limits.",
+	}, []string{"user", "reason"})
 
 	// Init the index cache.
 	if u.indexCache, err = tsdb.NewIndexCache(cfg.BucketStore.IndexCache, logger, reg); err != nil {
@@ -307,12 +340,40 @@ func (u *BucketStores) Series(req *storepb.SeriesRequest, srv storepb.Store_Seri
 		return nil
 	}
 
+	if limit := u.limits.StoreGatewayMaxConcurrentSeriesRequests(userID); !u.acquireSeriesRequest(userID, limit) {
+		u.requestsRejected.WithLabelValues(userID, rejectReasonMaxConcurrentSeriesRequests).Inc()
+		return httpgrpc.Errorf(http.StatusTooManyRequests, "the tenant has reached the limit of %d concurrent series requests", limit)
+	}
+	defer u.releaseSeriesRequest(userID)
+
 	return store.Series(req, spanSeriesServer{
 		Store_SeriesServer: srv,
 		ctx:                spanCtx,
 	})
 }
 
+// acquireSeriesRequest tracks a new in-flight series request for the tenant, unless the tenant has
+// already reached the input limit of concurrent series requests. 0 disables the limit.
+func (u *BucketStores) acquireSeriesRequest(userID string, limit int) bool {
+	u.inflightSeriesMu.Lock()
+	defer u.inflightSeriesMu.Unlock()
+
+	if limit > 0 && u.inflightSeries[userID] >= limit {
+		return false
+	}
+	u.inflightSeries[userID]++
+	return true
+}
+
+func (u *BucketStores) releaseSeriesRequest(userID string) {
+	u.inflightSeriesMu.Lock()
+	defer u.inflightSeriesMu.Unlock()
+
+	if u.inflightSeries[userID]--; u.inflightSeries[userID] <= 0 {
+		delete(u.inflightSeries, userID)
+	}
+}
+
 // LabelNames implements the Storegateway proto service.
 func (u *BucketStores) LabelNames(ctx context.Context, req *storepb.LabelNamesRequest) (*storepb.LabelNamesResponse, error) {
 	spanLog, spanCtx := spanlogger.NewWithLogger(ctx, u.logger, "BucketStores.LabelNames")
@@ -349,6 +410,56 @@ func (u *BucketStores) LabelValues(ctx context.Context, req *storepb.LabelValues
 	return store.LabelValues(ctx, req)
 }
 
+// LabelValuesCardinality implements the Storegateway proto service.
+func (u *BucketStores) LabelValuesCardinality(ctx context.Context, req *storegatewaypb.LabelValuesCardinalityRequest) (*storegatewaypb.LabelValuesCardinalityResponse, error) {
+	spanLog, spanCtx := spanlogger.NewWithLogger(ctx, u.logger, "BucketStores.LabelValuesCardinality")
+	defer spanLog.Span.Finish()
+
+	userID := getUserIDFromGRPCContext(spanCtx)
+	if userID == "" {
+		return nil, fmt.Errorf("no userID")
+	}
+
+	if maxLabelNames := u.limits.LabelValuesMaxCardinalityLabelNamesPerRequest(userID); len(req.LabelNames) > maxLabelNames {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("label values cardinality request has %d label names (limit: %d)", len(req.LabelNames), maxLabelNames))
+	}
+
+	store := u.getStore(userID)
+	if store == nil {
+		return &storegatewaypb.LabelValuesCardinalityResponse{}, nil
+	}
+
+	resp, err := store.LabelValuesCardinality(ctx, req, u.limits.StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName(userID))
+	if errors.Is(err, errMaxLabelValuesPerLabelNameExceeded) {
+		u.requestsRejected.WithLabelValues(userID, rejectReasonMaxLabelValuesPerLabelName).Inc()
+		return nil, httpgrpc.Errorf(http.StatusUnprocessableEntity, err.Error())
+	}
+	return resp, err
+}
+
+// Exemplars implements the Storegateway proto service.
+func (u *BucketStores) Exemplars(ctx context.Context, req *storegatewaypb.ExemplarsRequest) (*storegatewaypb.ExemplarsResponse, error) {
+	spanLog, spanCtx := spanlogger.NewWithLogger(ctx, u.logger, "BucketStores.Exemplars")
+	defer spanLog.Span.Finish()
+
+	userID := getUserIDFromGRPCContext(spanCtx)
+	if userID == "" {
+		return nil, fmt.Errorf("no userID")
+	}
+
+	store := u.getStore(userID)
+	if store == nil {
+		return &storegatewaypb.ExemplarsResponse{}, nil
+	}
+
+	resp, err := store.Exemplars(ctx, req, u.limits.StoreGatewayMaxExemplarsPerRequest(userID))
+	if errors.Is(err, errMaxExemplarsPerRequestExceeded) {
+		u.requestsRejected.WithLabelValues(userID, rejectReasonMaxExemplarsPerRequest).Inc()
+		return nil, httpgrpc.Errorf(http.StatusUnprocessableEntity, err.Error())
+	}
+	return resp, err
+}
+
 // scanUsers in the bucket and return the list of found users. If an error occurs while
 // iterating the bucket, it may return both an error and a subset of the users in the bucket.
 func (u *BucketStores) scanUsers(ctx context.Context) ([]string, error) {
@@ -404,6 +515,9 @@ func (u *BucketStores) closeEmptyBucketStore(userID string) error {
 	u.storesMu.Unlock()
 
 	u.metaFetcherMetrics.RemoveUserRegistry(userID)
+	for _, reason := range rejectReasons {
+		u.requestsRejected.DeleteLabelValues(userID, reason)
+	}
 	return bs.Close()
 }
 
@@ -491,6 +605,7 @@ func (u *BucketStores) getOrCreateStore(userID string) (*BucketStore, error) {
 		WithIndexCache(u.indexCache),
 		WithQueryGate(u.queryGate),
 		WithChunkPool(u.chunksPool),
+		WithExemplarsCache(u.exemplarsCache),
 	}
 	if u.logLevel.String() == "debug" {
 		bucketStoreOpts = append(bucketStoreOpts, WithDebugLogging())
@@ -501,8 +616,8 @@ func (u *BucketStores) getOrCreateStore(userID string) (*BucketStore, error) {
 		userBkt,
 		fetcher,
 		u.syncDirForUser(userID),
-		newChunksLimiterFactory(u.limits, userID),
-		NewSeriesLimiterFactory(0), // No series limiter.
+		newChunksLimiterFactory(u.limits, userID, u.requestsRejected),
+		newSeriesLimiterFactory(u.limits, userID, u.requestsRejected),
 		u.partitioner,
 		u.cfg.BucketStore.BlockSyncConcurrency,
 		false, // No need to enable backward compatibility with Thanos pre 0.8.0 queriers
@@ -602,25 +717,50 @@ func (s spanSeriesServer) Context() context.Context {
 	return s.ctx
 }
 
-type chunkLimiter struct {
+// tenantLimiter enforces a per-tenant limit, tracking the rejected request in the per-tenant rejected counter.
+type tenantLimiter struct {
 	limiter *Limiter
+
+	rejectedCounter *prometheus.CounterVec
+	rejectedOnce    sync.Once
+	userID          string
+	reason          string
 }
 
-func (c *chunkLimiter) Reserve(num uint64) error {
+func (c *tenantLimiter) Reserve(num uint64) error {
 	err := c.limiter.Reserve(num)
 	if err != nil {
+		c.rejectedOnce.Do(func() {
+			c.rejectedCounter.WithLabelValues(c.userID, c.reason).Inc()
+		})
 		return httpgrpc.Errorf(http.StatusUnprocessableEntity, err.Error())
 	}
 
 	return nil
 }
 
-func newChunksLimiterFactory(limits *validation.Overrides, userID string) ChunksLimiterFactory {
+func newChunksLimiterFactory(limits *validation.Overrides, userID string, rejectedCounter *prometheus.CounterVec) ChunksLimiterFactory {
 	return func(failedCounter prometheus.Counter) ChunksLimiter {
 		// Since limit overrides could be live reloaded, we have to get the current user's limit
 		// each time a new limiter is instantiated.
-		return &chunkLimiter{
-			limiter: NewLimiter(uint64(limits.MaxChunksPerQuery(userID)), failedCounter),
+		return &tenantLimiter{
+			limiter:         NewLimiter(uint64(limits.MaxChunksPerQuery(userID)), failedCounter),
+			rejectedCounter: rejectedCounter,
+			userID:          userID,
+			reason:          rejectReasonMaxChunksPerQuery,
+		}
+	}
+}
+
+func newSeriesLimiterFactory(limits *validation.Overrides, userID string, rejectedCounter *prometheus.CounterVec) SeriesLimiterFactory {
+	return func(failedCounter prometheus.Counter) SeriesLimiter {
+		// Since limit overrides could be live reloaded, we have to get the current user's limit
+		// each time a new limiter is instantiated.
+		return &tenantLimiter{
+			limiter:         NewLimiter(uint64(limits.StoreGatewayMaxSeriesPerRequest(userID)), failedCounter),
+			rejectedCounter: rejectedCounter,
+			userID:          userID,
+			reason:          rejectReasonMaxSeriesPerRequest,
 		}
 	}
 }
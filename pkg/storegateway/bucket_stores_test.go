@@ -12,6 +12,7 @@ import (
 	"io"
 	"io/ioutil"
 	"math"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
@@ -40,17 +41,22 @@ import (
 	filesystemstore "github.com/thanos-io/thanos/pkg/objstore/filesystem"
 	"github.com/thanos-io/thanos/pkg/store/labelpb"
 	"github.com/thanos-io/thanos/pkg/store/storepb"
+	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/logging"
 	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/bucket/filesystem"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	mimir_testutil "github.com/grafana/mimir/pkg/storage/tsdb/testutil"
 	"github.com/grafana/mimir/pkg/storegateway/indexcache"
+	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
 	"github.com/grafana/mimir/pkg/util"
 	"github.com/grafana/mimir/pkg/util/test"
+	"github.com/grafana/mimir/pkg/util/validation"
 )
 
 func TestBucketStores_InitialSync(t *testing.T) {
@@ -389,6 +395,144 @@ func testBucketStoresSeriesShouldCorrectlyQuerySeriesSpanningMultipleChunks(t *t
 	}
 }
 
+func TestBucketStores_Series_ShouldEnforceMaxConcurrentSeriesRequests(t *testing.T) {
+	const (
+		userID     = "user-1"
+		metricName = "series_1"
+	)
+
+	ctx := context.Background()
+	cfg := prepareStorageConfig(t)
+
+	storageDir := t.TempDir()
+	generateStorageBlock(t, storageDir, userID, metricName, 10, 100, 15)
+	generateStorageBlock(t, storageDir, "user-2", metricName, 10, 100, 15)
+
+	bucket, err := filesystem.NewBucketClient(filesystem.Config{Directory: storageDir})
+	require.NoError(t, err)
+
+	limits := defaultLimitsConfig()
+	limits.StoreGatewayMaxConcurrentSeriesRequests = 2
+	overrides, err := validation.NewOverrides(limits, nil)
+	require.NoError(t, err)
+
+	reg := prometheus.NewPedanticRegistry()
+	stores, err := NewBucketStores(cfg, newNoShardingStrategy(), bucket, overrides, mockLoggingLevel(), log.NewNopLogger(), reg)
+	require.NoError(t, err)
+	require.NoError(t, stores.InitialSync(ctx))
+
+	// Simulate the tenant running the max number of concurrent series requests.
+	require.True(t, stores.acquireSeriesRequest(userID, 2))
+	require.True(t, stores.acquireSeriesRequest(userID, 2))
+
+	_, _, err = querySeries(stores, userID, metricName, 10, 100)
+	require.Error(t, err)
+	resp, ok := httpgrpc.HTTPResponseFromError(err)
+	require.True(t, ok)
+	assert.Equal(t, int32(http.StatusTooManyRequests), resp.Code)
+
+	// Other tenants are not affected.
+	seriesSet, _, err := querySeries(stores, "user-2", metricName, 10, 100)
+	require.NoError(t, err)
+	assert.Len(t, seriesSet, 1)
+
+	// Once an in-flight request completes, the tenant can run a new one.
+	stores.releaseSeriesRequest(userID)
+	seriesSet, _, err = querySeries(stores, userID, metricName, 10, 100)
+	require.NoError(t, err)
+	assert.Len(t, seriesSet, 1)
+
+	stores.releaseSeriesRequest(userID)
+	assert.Empty(t, stores.inflightSeries)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_bucket_stores_requests_rejected_total Total number of requests rejected because exceeding the per-tenant limits.
+		# TYPE cortex_bucket_stores_requests_rejected_total counter
+		cortex_bucket_stores_requests_rejected_total{reason="max-concurrent-series-requests",user="user-1"} 1
+	`), "cortex_bucket_stores_requests_rejected_total"))
+}
+
+func TestBucketStores_LabelValuesCardinality_ShouldEnforceLimits(t *testing.T) {
+	const (
+		userID     = "user-1"
+		metricName = "series_1"
+	)
+
+	ctx := context.Background()
+	cfg := prepareStorageConfig(t)
+
+	// Generate a single block with 1 metric name and 2 values for the "case" label.
+	specs := []*mimir_testutil.BlockSeriesSpec{
+		{
+			Labels: labels.Labels{labels.Label{Name: labels.MetricName, Value: metricName}, labels.Label{Name: "case", Value: "first"}},
+			Chunks: []chunks.Meta{tsdbutil.ChunkFromSamples([]tsdbutil.Sample{sample{t: 10, v: 10}})},
+		},
+		{
+			Labels: labels.Labels{labels.Label{Name: labels.MetricName, Value: metricName}, labels.Label{Name: "case", Value: "second"}},
+			Chunks: []chunks.Meta{tsdbutil.ChunkFromSamples([]tsdbutil.Sample{sample{t: 10, v: 10}})},
+		},
+	}
+
+	storageDir := t.TempDir()
+	_, err := mimir_testutil.GenerateBlockFromSpec(userID, filepath.Join(storageDir, userID), specs)
+	require.NoError(t, err)
+
+	bucket, err := filesystem.NewBucketClient(filesystem.Config{Directory: storageDir})
+	require.NoError(t, err)
+
+	limits := defaultLimitsConfig()
+	limits.LabelValuesMaxCardinalityLabelNamesPerRequest = 2
+	limits.StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName = 1
+	overrides, err := validation.NewOverrides(limits, nil)
+	require.NoError(t, err)
+
+	reg := prometheus.NewPedanticRegistry()
+	stores, err := NewBucketStores(cfg, newNoShardingStrategy(), bucket, overrides, mockLoggingLevel(), log.NewNopLogger(), reg)
+	require.NoError(t, err)
+	require.NoError(t, stores.InitialSync(ctx))
+
+	queryCardinality := func(labelNames ...string) (*storegatewaypb.LabelValuesCardinalityResponse, error) {
+		return stores.LabelValuesCardinality(setUserIDToGRPCContext(ctx, userID), &storegatewaypb.LabelValuesCardinalityRequest{
+			LabelNames: labelNames,
+			Start:      math.MinInt64,
+			End:        math.MaxInt64,
+		})
+	}
+
+	t.Run("should succeed if no limit is exceeded", func(t *testing.T) {
+		resp, err := queryCardinality(labels.MetricName)
+		require.NoError(t, err)
+		require.Len(t, resp.Blocks, 1)
+		assert.Equal(t, []*storegatewaypb.LabelValueSeriesCount{
+			{LabelName: labels.MetricName, LabelValueSeries: map[string]uint64{metricName: 2}},
+		}, resp.Blocks[0].Items)
+	})
+
+	t.Run("should reject a request without label names", func(t *testing.T) {
+		_, err := queryCardinality()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("should reject a request with too many label names", func(t *testing.T) {
+		_, err := queryCardinality(labels.MetricName, "case", "other")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("should reject a request for a label name with too many values", func(t *testing.T) {
+		_, err := queryCardinality(labels.MetricName, "case")
+		require.Error(t, err)
+		resp, ok := httpgrpc.HTTPResponseFromError(err)
+		require.True(t, ok)
+		assert.Equal(t, int32(http.StatusUnprocessableEntity), resp.Code)
+	})
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_bucket_stores_requests_rejected_total Total number of requests rejected because exceeding the per-tenant limits.
+		# TYPE cortex_bucket_stores_requests_rejected_total counter
+		cortex_bucket_stores_requests_rejected_total{reason="max-label-values-per-label-name",user="user-1"} 1
+	`), "cortex_bucket_stores_requests_rejected_total"))
+}
+
 func TestBucketStore_Series_ShouldQueryBlockWithOutOfOrderChunks(t *testing.T) {
 	const (
 		userID     = "user-1"
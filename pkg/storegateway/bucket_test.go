@@ -23,6 +23,7 @@ import (
 	"runtime"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -36,6 +37,7 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/prometheus/client_golang/prometheus"
 	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/exemplar"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/prometheus/prometheus/storage"
@@ -61,9 +63,11 @@ import (
 	"go.uber.org/atomic"
 
 	"github.com/grafana/mimir/pkg/compactor"
+	"github.com/grafana/mimir/pkg/mimirpb"
 	"github.com/grafana/mimir/pkg/storage/sharding"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storegateway/indexcache"
+	"github.com/grafana/mimir/pkg/storegateway/storegatewaypb"
 	"github.com/grafana/mimir/pkg/util/test"
 )
 
@@ -1141,6 +1145,163 @@ func (c cacheNotExpectingToStoreLabelValues) StoreLabelValues(ctx context.Contex
 	c.t.Fatalf("StoreLabelValues should not be called")
 }
 
+func TestBlockLabelValuesCardinality(t *testing.T) {
+	const series = 500
+
+	newTestBucketBlock := prepareTestBlock(test.NewTB(t), series)
+
+	t.Run("happy case with no matchers", func(t *testing.T) {
+		b := newTestBucketBlock()
+		items, err := blockLabelValuesCardinality(context.Background(), b.indexReader(), []string{"j", "p"}, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, []*storegatewaypb.LabelValueSeriesCount{
+			{LabelName: "j", LabelValueSeries: map[string]uint64{"bar": 300, "foo": 200}},
+			{LabelName: "p", LabelValueSeries: map[string]uint64{"foo": 100}},
+		}, items)
+	})
+
+	t.Run("happy case with matchers", func(t *testing.T) {
+		b := newTestBucketBlock()
+		matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "p", "foo")}
+		items, err := blockLabelValuesCardinality(context.Background(), b.indexReader(), []string{"j", "q"}, matchers, 0)
+		require.NoError(t, err)
+		require.Equal(t, []*storegatewaypb.LabelValueSeriesCount{
+			{LabelName: "j", LabelValueSeries: map[string]uint64{"foo": 100}},
+		}, items)
+	})
+
+	t.Run("max values per label name limit not exceeded", func(t *testing.T) {
+		b := newTestBucketBlock()
+		items, err := blockLabelValuesCardinality(context.Background(), b.indexReader(), []string{"j"}, nil, 2)
+		require.NoError(t, err)
+		require.Equal(t, []*storegatewaypb.LabelValueSeriesCount{
+			{LabelName: "j", LabelValueSeries: map[string]uint64{"bar": 300, "foo": 200}},
+		}, items)
+	})
+
+	t.Run("max values per label name limit exceeded", func(t *testing.T) {
+		b := newTestBucketBlock()
+		_, err := blockLabelValuesCardinality(context.Background(), b.indexReader(), []string{"p", "j"}, nil, 1)
+		require.ErrorIs(t, err, errMaxLabelValuesPerLabelNameExceeded)
+	})
+
+	t.Run("index reader error", func(t *testing.T) {
+		b := newTestBucketBlock()
+		b.indexHeaderReader = &interceptedIndexReader{
+			Reader:              b.indexHeaderReader,
+			onLabelValuesCalled: func(name string) error { return context.DeadlineExceeded },
+		}
+
+		_, err := blockLabelValuesCardinality(context.Background(), b.indexReader(), []string{"j"}, nil, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestBucketStore_Exemplars(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+
+	series1 := labels.FromStrings(labels.MetricName, "metric", "pod", "a")
+	series2 := labels.FromStrings(labels.MetricName, "metric", "pod", "b")
+	traceA := labels.FromStrings("trace_id", "a")
+	traceB := labels.FromStrings("trace_id", "b")
+
+	newBlock := func(id ulid.ULID, minT, maxT int64, exemplars []exemplar.QueryResult) *bucketBlock {
+		if exemplars != nil {
+			require.NoError(t, mimir_tsdb.WriteBlockExemplars(ctx, bkt, id, mimir_tsdb.NewBlockExemplars(exemplars)))
+		}
+
+		return &bucketBlock{
+			logger:        log.NewNopLogger(),
+			meta:          &metadata.Meta{BlockMeta: tsdb.BlockMeta{ULID: id, MinTime: minT, MaxTime: maxT}},
+			relabelLabels: labels.FromStrings(block.BlockIDLabel, id.String()),
+		}
+	}
+
+	// Block 1 and 2 hold the same exemplar (eg. uploaded by different ingesters), block 3 has no exemplars
+	// and block 4 is outside the query time range.
+	block1 := newBlock(ulid.MustNew(1, nil), 0, 100, []exemplar.QueryResult{
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 1, Ts: 10}, {Labels: traceB, Value: 2, Ts: 20}}},
+		{SeriesLabels: series2, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 3, Ts: 10}}},
+	})
+	block2 := newBlock(ulid.MustNew(2, nil), 0, 100, []exemplar.QueryResult{
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 1, Ts: 10}, {Labels: traceB, Value: 4, Ts: 90}}},
+	})
+	block3 := newBlock(ulid.MustNew(3, nil), 0, 100, nil)
+	block4 := newBlock(ulid.MustNew(4, nil), 100, 200, []exemplar.QueryResult{
+		{SeriesLabels: series1, Exemplars: []exemplar.Exemplar{{Labels: traceA, Value: 5, Ts: 110}}},
+	})
+
+	// Block 5 has corrupted exemplars, which are skipped.
+	block5 := newBlock(ulid.MustNew(5, nil), 0, 100, nil)
+	require.NoError(t, bkt.Upload(ctx, path.Join(block5.meta.ULID.String(), mimir_tsdb.BlockExemplarsFilename), strings.NewReader("corrupted")))
+
+	exemplarsCache, err := newExemplarsCache(1024*1024, nil)
+	require.NoError(t, err)
+
+	store := &BucketStore{
+		userID:         "test",
+		bkt:            objstore.WithNoopInstr(bkt),
+		logger:         log.NewNopLogger(),
+		exemplarsCache: exemplarsCache,
+		blocks: map[ulid.ULID]*bucketBlock{
+			block1.meta.ULID: block1,
+			block2.meta.ULID: block2,
+			block3.meta.ULID: block3,
+			block4.meta.ULID: block4,
+			block5.meta.ULID: block5,
+		},
+	}
+
+	hints, err := types.MarshalAny(&hintspb.LabelValuesRequestHints{
+		BlockMatchers: []storepb.LabelMatcher{
+			{Type: storepb.LabelMatcher_RE, Name: block.BlockIDLabel, Value: strings.Join([]string{block1.meta.ULID.String(), block2.meta.ULID.String(), block3.meta.ULID.String(), block4.meta.ULID.String(), block5.meta.ULID.String()}, "|")},
+		},
+	})
+	require.NoError(t, err)
+
+	req := &storegatewaypb.ExemplarsRequest{
+		Start: 10,
+		End:   90,
+		Matchers: []storegatewaypb.ExemplarsMatchers{
+			{Matchers: []storepb.LabelMatcher{{Type: storepb.LabelMatcher_EQ, Name: "pod", Value: "a"}}},
+		},
+		Hints: hints,
+	}
+
+	expectedTimeseries := []mimirpb.TimeSeries{
+		{Labels: mimirpb.FromLabelsToLabelAdapters(series1), Exemplars: []mimirpb.Exemplar{
+			{Labels: mimirpb.FromLabelsToLabelAdapters(traceA), Value: 1, TimestampMs: 10},
+			{Labels: mimirpb.FromLabelsToLabelAdapters(traceB), Value: 2, TimestampMs: 20},
+			{Labels: mimirpb.FromLabelsToLabelAdapters(traceB), Value: 4, TimestampMs: 90},
+		}},
+	}
+
+	res, err := store.Exemplars(ctx, req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, expectedTimeseries, res.Timeseries)
+
+	resHints := hintspb.LabelValuesResponseHints{}
+	require.NoError(t, types.UnmarshalAny(res.Hints, &resHints))
+	assert.ElementsMatch(t, []hintspb.Block{{Id: block1.meta.ULID.String()}, {Id: block2.meta.ULID.String()}, {Id: block3.meta.ULID.String()}, {Id: block5.meta.ULID.String()}}, resHints.QueriedBlocks)
+
+	// The exemplars of each block are read from the bucket only once, and then cached.
+	require.NoError(t, bkt.Iter(ctx, "", func(name string) error {
+		return bkt.Delete(ctx, path.Join(name, mimir_tsdb.BlockExemplarsFilename))
+	}))
+
+	res, err = store.Exemplars(ctx, req, 0)
+	require.NoError(t, err)
+	assert.Equal(t, expectedTimeseries, res.Timeseries)
+
+	// The limit counts an exemplar once for each block it's read from.
+	_, err = store.Exemplars(ctx, req, 4)
+	require.NoError(t, err)
+
+	_, err = store.Exemplars(ctx, req, 3)
+	require.ErrorIs(t, err, errMaxExemplarsPerRequestExceeded)
+}
+
 func TestBucketIndexReader_ExpandedPostings(t *testing.T) {
 	tb := test.NewTB(t)
 	const series = 500
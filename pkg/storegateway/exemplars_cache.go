@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storegateway
+
+import (
+	"math"
+	"sync"
+	"unsafe"
+
+	lru "github.com/hashicorp/golang-lru/simplelru"
+	"github.com/oklog/ulid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/model/labels"
+
+	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
+)
+
+// exemplarsCache is an in-memory LRU cache of the decoded exemplars of the blocks. It's shared
+// across all tenants and it's bounded by the estimated size of the cached exemplars. A nil
+// exemplarsCache caches nothing.
+type exemplarsCache struct {
+	maxSizeBytes uint64
+
+	mtx       sync.Mutex
+	lru       *lru.LRU
+	sizeBytes uint64
+
+	requests prometheus.Counter
+	hits     prometheus.Counter
+}
+
+type exemplarsCacheItem struct {
+	exemplars *mimir_tsdb.BlockExemplars
+	sizeBytes uint64
+}
+
+// newExemplarsCache makes a new exemplarsCache whose cached exemplars take up to maxSizeBytes.
+// Returns nil if maxSizeBytes is 0.
+func newExemplarsCache(maxSizeBytes uint64, reg prometheus.Registerer) (*exemplarsCache, error) {
+	if maxSizeBytes == 0 {
+		return nil, nil
+	}
+
+	c := &exemplarsCache{maxSizeBytes: maxSizeBytes}
+
+	var err error
+	c.lru, err = lru.NewLRU(math.MaxInt32, c.onEvict)
+	if err != nil {
+		return nil, err
+	}
+
+	c.requests = promauto.With(reg).NewCounter(prometheus.CounterOpts{
+		Name: "cortex_bucket_store_exemplars_cache_requests_total",
+		Help: "Total number of requests to the in-memory cache of the blocks exemplars.",
+	})
+	c.hits = promauto.With(reg).NewCounter(prometheus.CounterOpts{
+		Name: "cortex_bucket_store_exemplars_cache_hits_total",
+		Help: "Total number of requests to the in-memory cache of the blocks exemplars that were a hit.",
+	})
+	promauto.With(reg).NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "cortex_bucket_store_exemplars_cache_items",
+		Help: "Number of blocks whose exemplars are in the in-memory cache.",
+	}, func() float64 {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		return float64(c.lru.Len())
+	})
+	promauto.With(reg).NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "cortex_bucket_store_exemplars_cache_size_bytes",
+		Help: "Estimated size in bytes of the blocks exemplars in the in-memory cache.",
+	}, func() float64 {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+
+		return float64(c.sizeBytes)
+	})
+
+	return c, nil
+}
+
+// get returns the cached exemplars of the input block, if any.
+func (c *exemplarsCache) get(userID string, blockID ulid.ULID) (*mimir_tsdb.BlockExemplars, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.requests.Inc()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	item, ok := c.lru.Get(exemplarsCacheKey(userID, blockID))
+	if !ok {
+		return nil, false
+	}
+
+	c.hits.Inc()
+	return item.(*exemplarsCacheItem).exemplars, true
+}
+
+// set caches the exemplars of the input block, evicting the least recently used ones if the cache
+// is full. Exemplars bigger than the cache max size are not cached.
+func (c *exemplarsCache) set(userID string, blockID ulid.ULID, exemplars *mimir_tsdb.BlockExemplars) {
+	if c == nil {
+		return
+	}
+
+	item := &exemplarsCacheItem{exemplars: exemplars, sizeBytes: blockExemplarsSize(exemplars)}
+	if item.sizeBytes > c.maxSizeBytes {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := exemplarsCacheKey(userID, blockID)
+	c.lru.Remove(key)
+
+	for c.sizeBytes+item.sizeBytes > c.maxSizeBytes {
+		if _, _, ok := c.lru.RemoveOldest(); !ok {
+			break
+		}
+	}
+
+	c.lru.Add(key, item)
+	c.sizeBytes += item.sizeBytes
+}
+
+// remove removes the cached exemplars of the input block, if any.
+func (c *exemplarsCache) remove(userID string, blockID ulid.ULID) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.lru.Remove(exemplarsCacheKey(userID, blockID))
+}
+
+// onEvict is called by the LRU, with the lock held, whenever an item is removed.
+func (c *exemplarsCache) onEvict(_, value interface{}) {
+	c.sizeBytes -= value.(*exemplarsCacheItem).sizeBytes
+}
+
+func exemplarsCacheKey(userID string, blockID ulid.ULID) string {
+	return userID + "/" + blockID.String()
+}
+
+// blockExemplarsSize returns the estimated size in bytes of the decoded exemplars in memory.
+func blockExemplarsSize(e *mimir_tsdb.BlockExemplars) uint64 {
+	size := uint64(unsafe.Sizeof(*e))
+	for _, series := range e.Series {
+		size += uint64(unsafe.Sizeof(series)) + labelsSize(series.Labels)
+		for _, ex := range series.Exemplars {
+			size += uint64(unsafe.Sizeof(ex)) + labelsSize(ex.Labels)
+		}
+	}
+	return size
+}
+
+func labelsSize(lbls labels.Labels) uint64 {
+	size := uint64(0)
+	for _, l := range lbls {
+		size += uint64(unsafe.Sizeof(l)) + uint64(len(l.Name)) + uint64(len(l.Value))
+	}
+	return size
+}
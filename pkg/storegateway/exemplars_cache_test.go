@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package storegateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/oklog/ulid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
+)
+
+func TestExemplarsCache(t *testing.T) {
+	newExemplars := func(series string) *mimir_tsdb.BlockExemplars {
+		return &mimir_tsdb.BlockExemplars{
+			Version: mimir_tsdb.BlockExemplarsVersion1,
+			Series: []mimir_tsdb.BlockExemplarsSeries{{
+				Labels:    labels.FromStrings(labels.MetricName, series),
+				Exemplars: []mimir_tsdb.BlockExemplar{{Labels: labels.FromStrings("trace_id", "a"), Value: 1, Timestamp: 10}},
+			}},
+		}
+	}
+
+	block1, block2, block3 := ulid.MustNew(1, nil), ulid.MustNew(2, nil), ulid.MustNew(3, nil)
+	exemplars1, exemplars2, exemplars3 := newExemplars("series_1"), newExemplars("series_2"), newExemplars("series_3")
+
+	// The cache fits two of the exemplars.
+	reg := prometheus.NewPedanticRegistry()
+	cache, err := newExemplarsCache(2*blockExemplarsSize(exemplars1), reg)
+	require.NoError(t, err)
+
+	cache.set("user-1", block1, exemplars1)
+	cache.set("user-1", block2, exemplars2)
+
+	actual, ok := cache.get("user-1", block1)
+	require.True(t, ok)
+	assert.Equal(t, exemplars1, actual)
+
+	// Caching the third exemplars evicts the least recently used ones.
+	cache.set("user-1", block3, exemplars3)
+
+	_, ok = cache.get("user-1", block2)
+	assert.False(t, ok)
+	_, ok = cache.get("user-1", block1)
+	assert.True(t, ok)
+	_, ok = cache.get("user-1", block3)
+	assert.True(t, ok)
+
+	// The same block of another tenant is not cached.
+	_, ok = cache.get("user-2", block1)
+	assert.False(t, ok)
+
+	// Removed exemplars are not cached anymore.
+	cache.remove("user-1", block1)
+	_, ok = cache.get("user-1", block1)
+	assert.False(t, ok)
+
+	// Exemplars bigger than the cache are not cached.
+	big := &mimir_tsdb.BlockExemplars{Series: append(append(exemplars1.Series, exemplars2.Series...), exemplars3.Series...)}
+	cache.set("user-1", block1, big)
+	_, ok = cache.get("user-1", block1)
+	assert.False(t, ok)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_bucket_store_exemplars_cache_hits_total Total number of requests to the in-memory cache of the blocks exemplars that were a hit.
+		# TYPE cortex_bucket_store_exemplars_cache_hits_total counter
+		cortex_bucket_store_exemplars_cache_hits_total 3
+		# HELP cortex_bucket_store_exemplars_cache_items Number of blocks whose exemplars are in the in-memory cache.
+		# TYPE cortex_bucket_store_exemplars_cache_items gauge
+		cortex_bucket_store_exemplars_cache_items 1
+		# HELP cortex_bucket_store_exemplars_cache_requests_total Total number of requests to the in-memory cache of the blocks exemplars.
+		# TYPE cortex_bucket_store_exemplars_cache_requests_total counter
+		cortex_bucket_store_exemplars_cache_requests_total 7
+	`), "cortex_bucket_store_exemplars_cache_hits_total", "cortex_bucket_store_exemplars_cache_items", "cortex_bucket_store_exemplars_cache_requests_total"))
+}
+
+func TestExemplarsCache_ShouldCacheNothingIfDisabled(t *testing.T) {
+	cache, err := newExemplarsCache(0, nil)
+	require.NoError(t, err)
+
+	cache.set("user-1", ulid.MustNew(1, nil), &mimir_tsdb.BlockExemplars{})
+	_, ok := cache.get("user-1", ulid.MustNew(1, nil))
+	assert.False(t, ok)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/tsdb"
 	"github.com/prometheus/prometheus/tsdb/chunkenc"
@@ -1096,26 +1097,41 @@ func TestStoreGateway_Series_QueryShardingConcurrency(t *testing.T) {
 	assert.Equal(t, numSeries, totalSeries)
 }
 
-func TestStoreGateway_SeriesQueryingShouldEnforceMaxChunksPerQueryLimit(t *testing.T) {
+func TestStoreGateway_SeriesQueryingShouldEnforceMaxChunksPerQueryAndMaxSeriesPerRequestLimits(t *testing.T) {
 	test.VerifyNoLeak(t)
 
-	const chunksQueried = 10
+	// Each mocked series contains only 1 sample, so it will also only have 1 chunk.
+	const (
+		seriesQueried = 10
+		chunksQueried = seriesQueried
+	)
 
 	tests := map[string]struct {
-		limit       int
-		expectedErr error
+		maxChunksPerQuery    int
+		maxSeriesPerRequest  int
+		expectedErr          error
+		expectedRejectReason string
 	}{
 		"no limit enforced if zero": {
-			limit:       0,
 			expectedErr: nil,
 		},
 		"should return NO error if the actual number of queried chunks is <= limit": {
-			limit:       chunksQueried,
-			expectedErr: nil,
+			maxChunksPerQuery: chunksQueried,
+			expectedErr:       nil,
 		},
 		"should return error if the actual number of queried chunks is > limit": {
-			limit:       chunksQueried - 1,
-			expectedErr: status.Error(http.StatusUnprocessableEntity, fmt.Sprintf("exceeded chunks limit: rpc error: code = Code(422) desc = limit %d violated (got %d)", chunksQueried-1, chunksQueried)),
+			maxChunksPerQuery:    chunksQueried - 1,
+			expectedErr:          status.Error(http.StatusUnprocessableEntity, fmt.Sprintf("exceeded chunks limit: rpc error: code = Code(422) desc = limit %d violated (got %d)", chunksQueried-1, chunksQueried)),
+			expectedRejectReason: rejectReasonMaxChunksPerQuery,
+		},
+		"should return NO error if the actual number of queried series is <= limit": {
+			maxSeriesPerRequest: seriesQueried,
+			expectedErr:         nil,
+		},
+		"should return error if the actual number of queried series is > limit": {
+			maxSeriesPerRequest:  seriesQueried - 1,
+			expectedErr:          status.Error(http.StatusUnprocessableEntity, fmt.Sprintf("exceeded series limit: rpc error: code = Code(422) desc = limit %d violated (got %d)", seriesQueried-1, seriesQueried)),
+			expectedRejectReason: rejectReasonMaxSeriesPerRequest,
 		},
 	}
 
@@ -1125,12 +1141,11 @@ func TestStoreGateway_SeriesQueryingShouldEnforceMaxChunksPerQueryLimit(t *testi
 
 	storageDir := t.TempDir()
 
-	// Generate 1 TSDB block with chunksQueried series. Since each mocked series contains only 1 sample,
-	// it will also only have 1 chunk.
+	// Generate 1 TSDB block with seriesQueried series.
 	now := time.Now()
 	minT := now.Add(-1*time.Hour).Unix() * 1000
 	maxT := now.Unix() * 1000
-	mockTSDB(t, path.Join(storageDir, userID), chunksQueried, 0, minT, maxT)
+	mockTSDB(t, path.Join(storageDir, userID), seriesQueried, 0, minT, maxT)
 
 	bucketClient, err := filesystem.NewBucketClient(filesystem.Config{Directory: storageDir})
 	require.NoError(t, err)
@@ -1148,7 +1163,8 @@ func TestStoreGateway_SeriesQueryingShouldEnforceMaxChunksPerQueryLimit(t *testi
 		t.Run(testName, func(t *testing.T) {
 			// Customise the limits.
 			limits := defaultLimitsConfig()
-			limits.MaxChunksPerQuery = testData.limit
+			limits.MaxChunksPerQuery = testData.maxChunksPerQuery
+			limits.StoreGatewayMaxSeriesPerRequest = testData.maxSeriesPerRequest
 			overrides, err := validation.NewOverrides(limits, nil)
 			require.NoError(t, err)
 
@@ -1159,7 +1175,8 @@ func TestStoreGateway_SeriesQueryingShouldEnforceMaxChunksPerQueryLimit(t *testi
 			ringStore, closer := consul.NewInMemoryClient(ring.GetCodec(), log.NewNopLogger(), nil)
 			t.Cleanup(func() { assert.NoError(t, closer.Close()) })
 
-			g, err := newStoreGateway(gatewayCfg, storageCfg, bucketClient, ringStore, overrides, mockLoggingLevel(), logger, nil, nil)
+			reg := prometheus.NewPedanticRegistry()
+			g, err := newStoreGateway(gatewayCfg, storageCfg, bucketClient, ringStore, overrides, mockLoggingLevel(), logger, reg, nil)
 			require.NoError(t, err)
 			require.NoError(t, services.StartAndAwaitRunning(ctx, g))
 			t.Cleanup(func() { assert.NoError(t, services.StopAndAwaitTerminated(ctx, g)) })
@@ -1177,10 +1194,16 @@ func TestStoreGateway_SeriesQueryingShouldEnforceMaxChunksPerQueryLimit(t *testi
 				assert.True(t, ok)
 				assert.True(t, strings.Contains(s1.Message(), s2.Message()))
 				assert.Equal(t, s1.Code(), s2.Code())
+
+				assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(fmt.Sprintf(`
+					# HELP cortex_bucket_stores_requests_rejected_total Total number of requests rejected because exceeding the per-tenant limits.
+					# TYPE cortex_bucket_stores_requests_rejected_total counter
+					cortex_bucket_stores_requests_rejected_total{component="store-gateway",reason=%q,user="user-1"} 1
+				`, testData.expectedRejectReason)), "cortex_bucket_stores_requests_rejected_total"))
 			} else {
 				require.NoError(t, err)
 				assert.Empty(t, srv.Warnings)
-				assert.Len(t, srv.SeriesSet, chunksQueried)
+				assert.Len(t, srv.SeriesSet, seriesQueried)
 			}
 		})
 	}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/grafana/mimir/pkg/storage/bucket"
+)
+
+var (
+	errInvalidFlushInterval = errors.New("the usage metering flush interval must be greater than 0")
+	errInvalidReportDelay   = errors.New("the usage metering report delay must be greater than the flush interval")
+)
+
+// Config holds the usage metering configuration.
+type Config struct {
+	Enabled       bool          `yaml:"enabled" category:"experimental"`
+	InstanceID    string        `yaml:"instance_id" category:"experimental" doc:"default=<hostname>"`
+	FlushInterval time.Duration `yaml:"flush_interval" category:"experimental"`
+	ReportDelay   time.Duration `yaml:"report_delay" category:"experimental"`
+	Storage       bucket.Config `yaml:"storage" category:"experimental"`
+}
+
+// RegisterFlags registers the usage metering flags.
+func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to get hostname", "err", err)
+		os.Exit(1)
+	}
+
+	f.BoolVar(&cfg.Enabled, "usage-metering.enabled", false, "True to enable the usage metering. Distributors, ingesters and compactors track the per-tenant usage per hour, and compactors write the hourly usage reports to the usage metering storage. The filesystem storage backend is supported only when running Mimir as a single binary.")
+	f.StringVar(&cfg.InstanceID, "usage-metering.instance-id", hostname, "Instance ID used to name the partial usage reports uploaded by this instance. Must be unique across the cluster.")
+	f.DurationVar(&cfg.FlushInterval, "usage-metering.flush-interval", 5*time.Minute, "How frequently the usage tracked in memory is uploaded to the usage metering storage as partial usage report.")
+	f.DurationVar(&cfg.ReportDelay, "usage-metering.report-delay", 15*time.Minute, "How long to wait after the end of an hour before writing its usage report, so that all instances have uploaded their partial usage reports. Must be greater than the flush interval.")
+
+	cfg.Storage.RegisterFlagsWithPrefixAndDefaultDirectory("usage-metering.storage.", "usage-metering", f)
+}
+
+// Validate the config.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.FlushInterval <= 0 {
+		return errInvalidFlushInterval
+	}
+	if cfg.ReportDelay <= cfg.FlushInterval {
+		return errInvalidReportDelay
+	}
+	return cfg.Storage.Validate()
+}
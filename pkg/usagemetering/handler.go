@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+const (
+	// maxReportsRange is the maximum time range of the usage reports which can be requested at once.
+	maxReportsRange = 31 * 24 * time.Hour
+
+	defaultReportsRange = 24 * time.Hour
+)
+
+type reportsResponse struct {
+	Reports []*Report `json:"reports"`
+}
+
+// ReportsHandler serves the hourly usage reports within the requested time range, in JSON or CSV format.
+// The usage reports of the hours not completed yet, or not reported yet, are not returned.
+func (r *Recorder) ReportsHandler(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start, end, err := parseReportsRange(req, r.now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := req.Form.Get("user")
+	format := req.Form.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	reports := []*Report{}
+	for hour := start; hour.Before(end); hour = hour.Add(time.Hour) {
+		report, err := ReadReport(req.Context(), r.bkt, hour, r.logger)
+		if errors.Is(err, ErrReportNotFound) {
+			continue
+		}
+		if err != nil {
+			level.Error(r.logger).Log("msg", "failed to read the usage report", "hour", hourPath(hour), "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if userID != "" {
+			report = filterReport(report, userID)
+		}
+		report.Partials = nil
+		reports = append(reports, report)
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		if err := WriteCSV(w, reports...); err != nil {
+			level.Error(r.logger).Log("msg", "failed to write the usage reports", "err", err)
+		}
+		return
+	}
+
+	util.WriteJSONResponse(w, reportsResponse{Reports: reports})
+}
+
+// parseReportsRange returns the hours of the requested time range, truncating the start to the hour.
+// The end is exclusive.
+func parseReportsRange(req *http.Request, now time.Time) (start, end time.Time, _ error) {
+	end = now
+	if v := req.Form.Get("end"); v != "" {
+		ms, err := util.ParseTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrap(err, "invalid end")
+		}
+		end = util.TimeFromMillis(ms)
+	}
+
+	start = end.Add(-defaultReportsRange)
+	if v := req.Form.Get("start"); v != "" {
+		ms, err := util.ParseTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrap(err, "invalid start")
+		}
+		start = util.TimeFromMillis(ms)
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("the end must be greater than or equal to the start")
+	}
+	if end.Sub(start) > maxReportsRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("the time range must not exceed %s", maxReportsRange)
+	}
+
+	return start.UTC().Truncate(time.Hour), end.UTC(), nil
+}
+
+func filterReport(r *Report, userID string) *Report {
+	filtered := &Report{Version: r.Version, Hour: r.Hour, Revision: r.Revision, Tenants: []TenantUsage{}}
+	for _, t := range r.Tenants {
+		if t.UserID == userID {
+			filtered.Tenants = append(filtered.Tenants, t)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestRecorder_ReportsHandler(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+	r := NewRecorder(Config{Enabled: true, InstanceID: "instance-1", FlushInterval: time.Minute, ReportDelay: 5 * time.Minute}, bkt, log.NewNopLogger(), nil)
+
+	hour1 := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	hour2 := hour1.Add(time.Hour)
+	report1 := &Report{Version: ReportVersion1, Hour: hour1, Tenants: []TenantUsage{
+		{UserID: "user-1", Usage: Usage{SamplesIngested: 10, BytesReceived: 100, ActiveSeriesHours: 1.5, StorageBytes: 1000}},
+		{UserID: "user-2", Usage: Usage{SamplesIngested: 20}},
+	}}
+	report2 := &Report{Version: ReportVersion1, Hour: hour2, Tenants: []TenantUsage{
+		{UserID: "user-1", Usage: Usage{SamplesIngested: 30}},
+	}}
+	require.NoError(t, writeReport(ctx, bkt, report1))
+	require.NoError(t, writeReport(ctx, bkt, report2))
+
+	tests := map[string]struct {
+		query            string
+		expectedStatus   int
+		expectedReports  []*Report
+		expectedCSV      string
+		expectedErrorMsg string
+	}{
+		"all reports within the range": {
+			query:           fmt.Sprintf("start=%d&end=%d", hour1.Unix(), hour2.Add(time.Hour).Unix()),
+			expectedStatus:  http.StatusOK,
+			expectedReports: []*Report{report1, report2},
+		},
+		"start within the hour": {
+			query:           fmt.Sprintf("start=%d&end=%d", hour1.Add(30*time.Minute).Unix(), hour2.Unix()),
+			expectedStatus:  http.StatusOK,
+			expectedReports: []*Report{report1},
+		},
+		"no reports within the range": {
+			query:           fmt.Sprintf("start=%d&end=%d", hour1.Add(-5*time.Hour).Unix(), hour1.Unix()),
+			expectedStatus:  http.StatusOK,
+			expectedReports: []*Report{},
+		},
+		"filtered by tenant": {
+			query:          fmt.Sprintf("start=%d&end=%d&user=user-2", hour1.Unix(), hour2.Add(time.Hour).Unix()),
+			expectedStatus: http.StatusOK,
+			expectedReports: []*Report{
+				{Version: ReportVersion1, Hour: hour1, Tenants: []TenantUsage{{UserID: "user-2", Usage: Usage{SamplesIngested: 20}}}},
+				{Version: ReportVersion1, Hour: hour2, Tenants: []TenantUsage{}},
+			},
+		},
+		"CSV format": {
+			query:          fmt.Sprintf("start=%d&end=%d&format=csv", hour1.Unix(), hour2.Add(time.Hour).Unix()),
+			expectedStatus: http.StatusOK,
+			expectedCSV: "hour,user,samples_ingested,bytes_received,active_series_hours,storage_bytes\n" +
+				"2022-03-01T10:00:00Z,user-1,10,100,1.5,1000\n" +
+				"2022-03-01T10:00:00Z,user-2,20,0,0,0\n" +
+				"2022-03-01T11:00:00Z,user-1,30,0,0,0\n",
+		},
+		"unsupported format": {
+			query:            "format=xml",
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "unsupported format \"xml\"",
+		},
+		"end before start": {
+			query:            fmt.Sprintf("start=%d&end=%d", hour2.Unix(), hour1.Unix()),
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "the end must be greater than or equal to the start",
+		},
+		"range too long": {
+			query:            fmt.Sprintf("start=%d&end=%d", hour1.Add(-32*24*time.Hour).Unix(), hour1.Unix()),
+			expectedStatus:   http.StatusBadRequest,
+			expectedErrorMsg: "the time range must not exceed",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/usage-metering/reports?"+testData.query, nil)
+			resp := httptest.NewRecorder()
+			r.ReportsHandler(resp, req)
+
+			require.Equal(t, testData.expectedStatus, resp.Code)
+
+			switch {
+			case testData.expectedErrorMsg != "":
+				assert.Contains(t, resp.Body.String(), testData.expectedErrorMsg)
+			case testData.expectedCSV != "":
+				assert.Equal(t, "text/csv", resp.Header().Get("Content-Type"))
+				assert.Equal(t, testData.expectedCSV, resp.Body.String())
+			default:
+				actual := reportsResponse{}
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &actual))
+				assert.Equal(t, testData.expectedReports, actual.Reports)
+			}
+		})
+	}
+}
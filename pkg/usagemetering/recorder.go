@@ -0,0 +1,360 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/services"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+const (
+	// maxFailedFlushUsageAge is how long the usage failed to be uploaded is kept in memory, to be uploaded
+	// at the next flush. The usage of older hours is dropped, so that the memory used is bounded.
+	maxFailedFlushUsageAge = 24 * time.Hour
+)
+
+// Recorder tracks the per-tenant usage per hour in memory, and periodically uploads it to the usage
+// metering storage as partial usage report. The partial usage reports uploaded by all instances are
+// merged into the hourly usage report by the instance owning the reports, if any.
+//
+// All the methods tracking the usage can be safely called on a nil Recorder, in which case they're no-op.
+type Recorder struct {
+	services.Service
+
+	cfg    Config
+	bkt    objstore.Bucket
+	logger log.Logger
+
+	mtx sync.Mutex
+	// Usage tracked since the last flush, by hour and tenant.
+	usage map[time.Time]map[string]*Usage
+	// Returns whether this instance writes the usage reports. Nil if it never does.
+	ownReports func() (bool, error)
+
+	// Returns the current time. Overridden in tests.
+	now func() time.Time
+
+	flushesFailed      prometheus.Counter
+	flushesDropped     prometheus.Counter
+	reportsWritten     prometheus.Counter
+	reportsFailed      prometheus.Counter
+	latePartialsMerged prometheus.Counter
+}
+
+// NewRecorder makes a new Recorder.
+func NewRecorder(cfg Config, bkt objstore.Bucket, logger log.Logger, reg prometheus.Registerer) *Recorder {
+	r := &Recorder{
+		cfg:    cfg,
+		bkt:    bkt,
+		logger: log.With(logger, "component", "usage-metering"),
+		usage:  map[time.Time]map[string]*Usage{},
+		now:    time.Now,
+		flushesFailed: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_usage_metering_flushes_failed_total",
+			Help: "Total number of failed uploads of the usage tracked in memory.",
+		}),
+		flushesDropped: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_usage_metering_flushes_dropped_total",
+			Help: "Total number of partial usage reports dropped because they've failed to be uploaded for too long.",
+		}),
+		reportsWritten: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_usage_metering_reports_written_total",
+			Help: "Total number of hourly usage reports written to the usage metering storage.",
+		}),
+		reportsFailed: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_usage_metering_reports_failed_total",
+			Help: "Total number of hourly usage reports failed to be written to the usage metering storage.",
+		}),
+		latePartialsMerged: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_usage_metering_late_partial_reports_merged_total",
+			Help: "Total number of partial usage reports uploaded after the hourly usage report has been written, and merged into a new revision of it.",
+		}),
+	}
+
+	r.Service = services.NewTimerService(cfg.FlushInterval, nil, r.iteration, r.stopping)
+	return r
+}
+
+// AddSamplesIngested tracks the samples accepted for the tenant.
+func (r *Recorder) AddSamplesIngested(userID string, samples int) {
+	r.record(userID, func(u *Usage) {
+		u.SamplesIngested += int64(samples)
+	})
+}
+
+// AddBytesReceived tracks the size of the write requests received for the tenant.
+func (r *Recorder) AddBytesReceived(userID string, bytes int) {
+	r.record(userID, func(u *Usage) {
+		u.BytesReceived += int64(bytes)
+	})
+}
+
+// AddActiveSeries tracks the series active for the tenant over the input period.
+func (r *Recorder) AddActiveSeries(userID string, series float64, period time.Duration) {
+	r.record(userID, func(u *Usage) {
+		u.ActiveSeriesHours += series * period.Hours()
+	})
+}
+
+// SetStorageBytes tracks the size of the blocks of the tenant in the long-term storage.
+func (r *Recorder) SetStorageBytes(userID string, bytes int64) {
+	r.record(userID, func(u *Usage) {
+		u.StorageBytes = bytes
+	})
+}
+
+func (r *Recorder) record(userID string, update func(u *Usage)) {
+	if r == nil {
+		return
+	}
+
+	hour := r.now().UTC().Truncate(time.Hour)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	tenants, ok := r.usage[hour]
+	if !ok {
+		tenants = map[string]*Usage{}
+		r.usage[hour] = tenants
+	}
+	u, ok := tenants[userID]
+	if !ok {
+		u = &Usage{}
+		tenants[userID] = u
+	}
+	update(u)
+}
+
+// EnableReports makes this instance write the hourly usage reports whenever owns returns true.
+func (r *Recorder) EnableReports(owns func() (bool, error)) {
+	if r == nil {
+		return
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.ownReports = owns
+}
+
+func (r *Recorder) iteration(ctx context.Context) error {
+	now := r.now()
+
+	if err := r.flush(ctx, now); err != nil {
+		level.Warn(r.logger).Log("msg", "failed to upload the partial usage reports", "err", err)
+	}
+
+	r.mtx.Lock()
+	owns := r.ownReports
+	r.mtx.Unlock()
+
+	if owns == nil {
+		return nil
+	}
+	if ok, err := owns(); err != nil {
+		level.Warn(r.logger).Log("msg", "failed to check whether this instance writes the usage reports", "err", err)
+	} else if ok {
+		r.writeReports(ctx, now)
+	}
+
+	return nil
+}
+
+func (r *Recorder) stopping(_ error) error {
+	// Upload the usage tracked since the last flush.
+	if err := r.flush(context.Background(), r.now()); err != nil {
+		level.Warn(r.logger).Log("msg", "failed to upload the partial usage reports on shutdown", "err", err)
+	}
+	return nil
+}
+
+// flush uploads the usage tracked since the last flush as partial usage reports, one per hour. Each partial
+// usage report holds the usage tracked between two flushes, so that it's never overwritten. The usage failed
+// to be uploaded is kept in memory, to be uploaded at the next flush, unless its hour has ended more than
+// maxFailedFlushUsageAge ago.
+func (r *Recorder) flush(ctx context.Context, now time.Time) error {
+	r.mtx.Lock()
+	usage := r.usage
+	r.usage = map[time.Time]map[string]*Usage{}
+	r.mtx.Unlock()
+
+	var firstErr error
+	for hour, tenants := range usage {
+		name := path.Join(partialsHourPrefix(hour), fmt.Sprintf("%s-%d.json", r.cfg.InstanceID, now.UnixNano()))
+		if err := writeReportObject(ctx, r.bkt, name, newReport(hour, tenants)); err != nil {
+			r.flushesFailed.Inc()
+			if now.Sub(hour.Add(time.Hour)) > maxFailedFlushUsageAge {
+				r.flushesDropped.Inc()
+				level.Error(r.logger).Log("msg", "dropping the usage failed to be uploaded for too long", "hour", hourPath(hour), "tenants", len(tenants), "err", err)
+			} else {
+				r.restore(hour, tenants)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+func (r *Recorder) restore(hour time.Time, tenants map[string]*Usage) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	current, ok := r.usage[hour]
+	if !ok {
+		r.usage[hour] = tenants
+		return
+	}
+	for userID, u := range tenants {
+		if c, ok := current[userID]; ok {
+			// The storage size tracked after the failed flush is the most recent one.
+			storageBytes := c.StorageBytes
+			c.merge(*u)
+			if storageBytes > 0 {
+				c.StorageBytes = storageBytes
+			}
+		} else {
+			current[userID] = u
+		}
+	}
+}
+
+// writeReports writes the usage report of each hour ended more than the report delay ago, and
+// having partial usage reports.
+func (r *Recorder) writeReports(ctx context.Context, now time.Time) {
+	var hours []time.Time
+	err := r.bkt.Iter(ctx, partialsPrefix, func(name string) error {
+		hour, err := time.Parse(hourLayout, path.Base(strings.TrimSuffix(name, "/")))
+		if err == nil {
+			hours = append(hours, hour)
+		}
+		return nil
+	})
+	if err != nil {
+		level.Warn(r.logger).Log("msg", "failed to list the partial usage reports", "err", err)
+		return
+	}
+
+	for _, hour := range hours {
+		if now.Before(hour.Add(time.Hour + r.cfg.ReportDelay)) {
+			continue
+		}
+
+		if err := r.writeHourReport(ctx, hour); err != nil {
+			r.reportsFailed.Inc()
+			level.Warn(r.logger).Log("msg", "failed to write the usage report", "hour", hourPath(hour), "err", err)
+		}
+	}
+}
+
+// writeHourReport merges the partial usage reports of the hour into its usage report, and then deletes them.
+// If the usage report has already been written, the partial usage reports not merged yet, which have been
+// uploaded too late, are merged into a new revision of it. The usage report is not written if another instance
+// has written it in the meantime, so that a report is never overwritten by a report with less usage.
+func (r *Recorder) writeHourReport(ctx context.Context, hour time.Time) error {
+	var partials []string
+	err := r.bkt.Iter(ctx, partialsHourPrefix(hour), func(name string) error {
+		if strings.HasSuffix(name, ".json") {
+			partials = append(partials, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "list partial usage reports")
+	}
+
+	existing, err := ReadReport(ctx, r.bkt, hour, r.logger)
+	if err != nil && !errors.Is(err, ErrReportNotFound) {
+		return err
+	}
+
+	reports := make([]*Report, 0, len(partials)+1)
+	merged := map[string]struct{}{}
+	if existing != nil {
+		reports = append(reports, existing)
+		for _, name := range existing.Partials {
+			merged[name] = struct{}{}
+		}
+	}
+
+	var newPartials []string
+	for _, name := range partials {
+		// The partial usage report could have been merged but failed to be deleted.
+		if _, ok := merged[name]; ok {
+			continue
+		}
+
+		partial, err := readReportObject(ctx, r.bkt, name, r.logger)
+		if errors.Is(err, ErrReportNotFound) {
+			continue
+		}
+		if errors.Is(err, ErrReportCorrupted) {
+			level.Warn(r.logger).Log("msg", "dropping corrupted partial usage report", "partial", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		reports = append(reports, partial)
+		newPartials = append(newPartials, name)
+	}
+
+	if len(newPartials) == 0 {
+		return r.deletePartials(ctx, partials)
+	}
+
+	report := MergeReports(hour, reports...)
+	if existing != nil {
+		report.Revision = existing.Revision + 1
+		report.Partials = append(report.Partials, existing.Partials...)
+	}
+	report.Partials = append(report.Partials, newPartials...)
+	sort.Strings(report.Partials)
+
+	// Check that the report hasn't been written by another instance in the meantime, right before writing it.
+	current, err := ReadReport(ctx, r.bkt, hour, r.logger)
+	if err != nil && !errors.Is(err, ErrReportNotFound) {
+		return err
+	}
+	if (current == nil) != (existing == nil) || (current != nil && current.Revision != existing.Revision) {
+		return errors.New("the usage report has been written by another instance in the meantime")
+	}
+
+	if err := writeReport(ctx, r.bkt, report); err != nil {
+		return err
+	}
+
+	r.reportsWritten.Inc()
+	if existing != nil {
+		r.latePartialsMerged.Add(float64(len(newPartials)))
+		level.Warn(r.logger).Log("msg", "merged the partial usage reports uploaded after the usage report has been written into a new revision of it", "hour", hourPath(hour), "revision", report.Revision, "partials", len(newPartials))
+	} else {
+		level.Info(r.logger).Log("msg", "written usage report", "hour", hourPath(hour), "partials", len(newPartials), "tenants", len(report.Tenants))
+	}
+
+	return r.deletePartials(ctx, partials)
+}
+
+func (r *Recorder) deletePartials(ctx context.Context, partials []string) error {
+	for _, name := range partials {
+		if err := r.bkt.Delete(ctx, name); err != nil && !r.bkt.IsObjNotFoundErr(err) {
+			return errors.Wrapf(err, "delete partial usage report %s", name)
+		}
+	}
+	return nil
+}
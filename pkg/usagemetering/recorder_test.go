@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestRecorder_ShouldBeNoopWhenDisabled(t *testing.T) {
+	var r *Recorder
+
+	r.AddSamplesIngested("user-1", 10)
+	r.AddBytesReceived("user-1", 100)
+	r.AddActiveSeries("user-1", 5, time.Minute)
+	r.SetStorageBytes("user-1", 1000)
+	r.EnableReports(func() (bool, error) { return true, nil })
+}
+
+func TestRecorder_FlushAndWriteReports(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+	cfg := Config{Enabled: true, FlushInterval: time.Minute, ReportDelay: 5 * time.Minute}
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := hour.Add(10 * time.Minute)
+
+	// Two instances track the usage of the same tenants.
+	cfg.InstanceID = "instance-1"
+	reg := prometheus.NewPedanticRegistry()
+	r1 := NewRecorder(cfg, bkt, log.NewNopLogger(), reg)
+	r1.now = func() time.Time { return now }
+	r1.AddSamplesIngested("user-1", 10)
+	r1.AddBytesReceived("user-1", 100)
+	r1.SetStorageBytes("user-2", 1000)
+	require.NoError(t, r1.flush(ctx, now))
+
+	// The usage tracked after a flush is uploaded in a new partial usage report.
+	r1.AddSamplesIngested("user-1", 5)
+	require.NoError(t, r1.flush(ctx, now.Add(time.Minute)))
+
+	cfg.InstanceID = "instance-2"
+	r2 := NewRecorder(cfg, bkt, log.NewNopLogger(), nil)
+	r2.now = func() time.Time { return now }
+	r2.AddActiveSeries("user-1", 120, 30*time.Minute)
+	r2.SetStorageBytes("user-2", 2000)
+	require.NoError(t, r2.flush(ctx, now))
+
+	// Nothing is uploaded if no usage has been tracked since the last flush.
+	require.NoError(t, r2.flush(ctx, now.Add(time.Minute)))
+	partials := listObjects(t, bkt, partialsHourPrefix(hour))
+	assert.Equal(t, []string{
+		partialPath(hour, "instance-1", now),
+		partialPath(hour, "instance-1", now.Add(time.Minute)),
+		partialPath(hour, "instance-2", now),
+	}, partials)
+
+	// The report is not written until the hour has ended since more than the report delay.
+	r1.writeReports(ctx, hour.Add(time.Hour+time.Minute))
+	_, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.Equal(t, ErrReportNotFound, err)
+
+	r1.writeReports(ctx, hour.Add(time.Hour+cfg.ReportDelay))
+	report, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Version: ReportVersion1, Hour: hour, Tenants: []TenantUsage{
+		{UserID: "user-1", Usage: Usage{SamplesIngested: 15, BytesReceived: 100, ActiveSeriesHours: 60}},
+		{UserID: "user-2", Usage: Usage{StorageBytes: 2000}},
+	}, Partials: partials}, report)
+
+	// The report is written in CSV format too.
+	reader, err := bkt.Get(ctx, reportPath(hour, ".csv"))
+	require.NoError(t, err)
+	csv := bytes.Buffer{}
+	_, err = csv.ReadFrom(reader)
+	require.NoError(t, err)
+	hourStr := hour.Format(time.RFC3339)
+	assert.Equal(t, "hour,user,samples_ingested,bytes_received,active_series_hours,storage_bytes\n"+
+		hourStr+",user-1,15,100,60,0\n"+
+		hourStr+",user-2,0,0,0,2000\n", csv.String())
+
+	// The merged partial usage reports are deleted.
+	assert.Empty(t, listObjects(t, bkt, partialsHourPrefix(hour)))
+
+	// The partial usage reports uploaded after the report has been written are merged into a new revision of it.
+	r2.AddSamplesIngested("user-1", 1)
+	require.NoError(t, r2.flush(ctx, hour.Add(30*time.Minute)))
+	r1.writeReports(ctx, hour.Add(3*time.Hour))
+	assert.Empty(t, listObjects(t, bkt, partialsHourPrefix(hour)))
+
+	report, err = ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Revision)
+	assert.Equal(t, int64(16), report.Tenants[0].SamplesIngested)
+	assert.Equal(t, append(partials, partialPath(hour, "instance-2", hour.Add(30*time.Minute))), report.Partials)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_usage_metering_reports_written_total Total number of hourly usage reports written to the usage metering storage.
+		# TYPE cortex_usage_metering_reports_written_total counter
+		cortex_usage_metering_reports_written_total 2
+
+		# HELP cortex_usage_metering_late_partial_reports_merged_total Total number of partial usage reports uploaded after the hourly usage report has been written, and merged into a new revision of it.
+		# TYPE cortex_usage_metering_late_partial_reports_merged_total counter
+		cortex_usage_metering_late_partial_reports_merged_total 1
+	`), "cortex_usage_metering_reports_written_total", "cortex_usage_metering_late_partial_reports_merged_total"))
+}
+
+func TestRecorder_ShouldNotMergePartialReportsTwice(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+	cfg := Config{Enabled: true, InstanceID: "instance-1", FlushInterval: time.Minute, ReportDelay: 5 * time.Minute}
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := hour.Add(10 * time.Minute)
+
+	r := NewRecorder(cfg, bkt, log.NewNopLogger(), nil)
+	r.now = func() time.Time { return now }
+	r.AddSamplesIngested("user-1", 10)
+	require.NoError(t, r.flush(ctx, now))
+
+	// Keep a copy of the partial usage report, to upload it again once merged.
+	name := partialPath(hour, "instance-1", now)
+	reader, err := bkt.Get(ctx, name)
+	require.NoError(t, err)
+	content := bytes.Buffer{}
+	_, err = content.ReadFrom(reader)
+	require.NoError(t, err)
+
+	r.writeReports(ctx, hour.Add(2*time.Hour))
+
+	// The partial usage report merged but failed to be deleted is deleted at the next run, without merging it again.
+	require.NoError(t, bkt.Upload(ctx, name, bytes.NewReader(content.Bytes())))
+	r.writeReports(ctx, hour.Add(3*time.Hour))
+	assert.Empty(t, listObjects(t, bkt, partialsHourPrefix(hour)))
+
+	report, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Revision)
+	assert.Equal(t, []TenantUsage{{UserID: "user-1", Usage: Usage{SamplesIngested: 10}}}, report.Tenants)
+}
+
+func TestRecorder_ShouldNotOverwriteReportWrittenConcurrently(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{Enabled: true, InstanceID: "instance-1", FlushInterval: time.Minute, ReportDelay: 5 * time.Minute}
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := hour.Add(10 * time.Minute)
+
+	// Another instance writes the report after this instance has read the partial usage reports.
+	concurrent := &Report{Version: ReportVersion1, Hour: hour, Tenants: []TenantUsage{{UserID: "user-1", Usage: Usage{SamplesIngested: 100}}}}
+	bkt := &failingBucket{Bucket: objstore.NewInMemBucket()}
+	bkt.getHook = func(name string) {
+		if strings.HasPrefix(name, partialsPrefix) {
+			require.NoError(t, writeReport(ctx, bkt.Bucket, concurrent))
+		}
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	r := NewRecorder(cfg, bkt, log.NewNopLogger(), reg)
+	r.now = func() time.Time { return now }
+	r.AddSamplesIngested("user-1", 10)
+	require.NoError(t, r.flush(ctx, now))
+
+	r.writeReports(ctx, hour.Add(2*time.Hour))
+
+	// The report written by the other instance is not overwritten, and the partial usage reports are kept.
+	report, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, concurrent, report)
+	assert.Len(t, listObjects(t, bkt, partialsHourPrefix(hour)), 1)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_usage_metering_reports_failed_total Total number of hourly usage reports failed to be written to the usage metering storage.
+		# TYPE cortex_usage_metering_reports_failed_total counter
+		cortex_usage_metering_reports_failed_total 1
+	`), "cortex_usage_metering_reports_failed_total"))
+}
+
+func TestRecorder_ShouldKeepUsageInMemoryOnFlushFailure(t *testing.T) {
+	ctx := context.Background()
+	bkt := &failingBucket{Bucket: objstore.NewInMemBucket(), uploadErr: errors.New("mocked error")}
+	cfg := Config{Enabled: true, InstanceID: "instance-1", FlushInterval: time.Minute, ReportDelay: 5 * time.Minute}
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := hour.Add(10 * time.Minute)
+
+	r := NewRecorder(cfg, bkt, log.NewNopLogger(), nil)
+	r.now = func() time.Time { return now }
+	r.AddSamplesIngested("user-1", 10)
+	r.SetStorageBytes("user-1", 1000)
+	require.Error(t, r.flush(ctx, now))
+
+	// The usage tracked after the failed flush is merged with the one failed to be uploaded.
+	r.AddSamplesIngested("user-1", 5)
+	r.SetStorageBytes("user-1", 500)
+	bkt.uploadErr = nil
+	require.NoError(t, r.flush(ctx, now.Add(time.Minute)))
+
+	r.writeReports(ctx, hour.Add(2*time.Hour))
+	report, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, []TenantUsage{{UserID: "user-1", Usage: Usage{SamplesIngested: 15, StorageBytes: 500}}}, report.Tenants)
+}
+
+func TestRecorder_ShouldDropUsageFailedToBeUploadedForTooLong(t *testing.T) {
+	ctx := context.Background()
+	bkt := &failingBucket{Bucket: objstore.NewInMemBucket(), uploadErr: errors.New("mocked error")}
+	cfg := Config{Enabled: true, InstanceID: "instance-1", FlushInterval: time.Minute, ReportDelay: 5 * time.Minute}
+	hour := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	now := hour.Add(10 * time.Minute)
+
+	reg := prometheus.NewPedanticRegistry()
+	r := NewRecorder(cfg, bkt, log.NewNopLogger(), reg)
+	r.now = func() time.Time { return now }
+	r.AddSamplesIngested("user-1", 10)
+
+	// The usage is kept in memory until its hour has ended since more than the max age.
+	require.Error(t, r.flush(ctx, hour.Add(time.Hour+maxFailedFlushUsageAge)))
+	assert.Len(t, r.usage, 1)
+
+	require.Error(t, r.flush(ctx, hour.Add(time.Hour+maxFailedFlushUsageAge+time.Minute)))
+	assert.Empty(t, r.usage)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_usage_metering_flushes_dropped_total Total number of partial usage reports dropped because they've failed to be uploaded for too long.
+		# TYPE cortex_usage_metering_flushes_dropped_total counter
+		cortex_usage_metering_flushes_dropped_total 1
+
+		# HELP cortex_usage_metering_flushes_failed_total Total number of failed uploads of the usage tracked in memory.
+		# TYPE cortex_usage_metering_flushes_failed_total counter
+		cortex_usage_metering_flushes_failed_total 2
+	`), "cortex_usage_metering_flushes_dropped_total", "cortex_usage_metering_flushes_failed_total"))
+}
+
+func partialPath(hour time.Time, instanceID string, flushTime time.Time) string {
+	return path.Join(partialsHourPrefix(hour), fmt.Sprintf("%s-%d.json", instanceID, flushTime.UnixNano()))
+}
+
+func listObjects(t *testing.T, bkt objstore.Bucket, prefix string) []string {
+	var names []string
+	require.NoError(t, bkt.Iter(context.Background(), prefix, func(name string) error {
+		names = append(names, name)
+		return nil
+	}))
+	return names
+}
+
+type failingBucket struct {
+	objstore.Bucket
+	uploadErr error
+	getHook   func(name string)
+}
+
+func (b *failingBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if b.getHook != nil {
+		b.getHook(name)
+	}
+	return b.Bucket.Get(ctx, name)
+}
+
+func (b *failingBucket) Upload(ctx context.Context, name string, r io.Reader) error {
+	if b.uploadErr != nil {
+		return b.uploadErr
+	}
+	return b.Bucket.Upload(ctx, name, r)
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/runutil"
+	"github.com/pkg/errors"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+const (
+	// ReportVersion1 is the only supported usage report version.
+	ReportVersion1 = 1
+
+	// partialsPrefix is the storage prefix holding the partial usage reports uploaded by each instance,
+	// in a sub-prefix per hour. Partial usage reports are deleted once merged into the usage report.
+	partialsPrefix = "partials"
+
+	// reportsPrefix is the storage prefix holding the usage reports, in JSON and CSV formats.
+	reportsPrefix = "reports"
+
+	// hourLayout is the layout used to format the hours in the storage paths.
+	hourLayout = "2006-01-02T15"
+)
+
+var (
+	ErrReportNotFound  = errors.New("usage report not found")
+	ErrReportCorrupted = errors.New("usage report corrupted")
+
+	csvHeader = []string{"hour", "user", "samples_ingested", "bytes_received", "active_series_hours", "storage_bytes"}
+)
+
+// Usage is the usage of a tenant within an hour.
+type Usage struct {
+	// SamplesIngested is the number of samples accepted by the distributors.
+	SamplesIngested int64 `json:"samples_ingested"`
+
+	// BytesReceived is the uncompressed size of the write requests received by the distributors.
+	BytesReceived int64 `json:"bytes_received"`
+
+	// ActiveSeriesHours is the number of active series over time, excluding the replication.
+	ActiveSeriesHours float64 `json:"active_series_hours"`
+
+	// StorageBytes is the size of the blocks in the long-term storage, as last observed within the hour.
+	StorageBytes int64 `json:"storage_bytes"`
+}
+
+func (u *Usage) merge(other Usage) {
+	u.SamplesIngested += other.SamplesIngested
+	u.BytesReceived += other.BytesReceived
+	u.ActiveSeriesHours += other.ActiveSeriesHours
+
+	// The storage size of a tenant is observed by a single compactor at a time, but the
+	// tenant could have been moved to another compactor within the hour.
+	if other.StorageBytes > u.StorageBytes {
+		u.StorageBytes = other.StorageBytes
+	}
+}
+
+// TenantUsage is the usage of a tenant.
+type TenantUsage struct {
+	UserID string `json:"user"`
+	Usage
+}
+
+// Report is the usage of all tenants within an hour.
+type Report struct {
+	Version int `json:"version"`
+
+	// Hour is the start time of the hour.
+	Hour time.Time `json:"hour"`
+
+	// Revision is incremented each time the report is updated with the partial usage reports
+	// uploaded after it has been written.
+	Revision int `json:"revision"`
+
+	// Tenants sorted by user ID.
+	Tenants []TenantUsage `json:"tenants"`
+
+	// Partials are the sorted names of the partial usage reports merged into the report, so that
+	// they're never merged twice. Not set in the reports served by the API.
+	Partials []string `json:"partials,omitempty"`
+}
+
+func newReport(hour time.Time, usage map[string]*Usage) *Report {
+	r := &Report{Version: ReportVersion1, Hour: hour, Tenants: make([]TenantUsage, 0, len(usage))}
+	for userID, u := range usage {
+		r.Tenants = append(r.Tenants, TenantUsage{UserID: userID, Usage: *u})
+	}
+	sort.Slice(r.Tenants, func(i, j int) bool {
+		return r.Tenants[i].UserID < r.Tenants[j].UserID
+	})
+	return r
+}
+
+// MergeReports merges the reports of the same hour.
+func MergeReports(hour time.Time, reports ...*Report) *Report {
+	usage := map[string]*Usage{}
+	for _, r := range reports {
+		for _, t := range r.Tenants {
+			u, ok := usage[t.UserID]
+			if !ok {
+				u = &Usage{}
+				usage[t.UserID] = u
+			}
+			u.merge(t.Usage)
+		}
+	}
+	return newReport(hour, usage)
+}
+
+// WriteCSV writes the reports in CSV format, with a row per hour and tenant.
+func WriteCSV(w io.Writer, reports ...*Report) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		hour := r.Hour.UTC().Format(time.RFC3339)
+		for _, t := range r.Tenants {
+			row := []string{
+				hour,
+				t.UserID,
+				strconv.FormatInt(t.SamplesIngested, 10),
+				strconv.FormatInt(t.BytesReceived, 10),
+				strconv.FormatFloat(t.ActiveSeriesHours, 'f', -1, 64),
+				strconv.FormatInt(t.StorageBytes, 10),
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func hourPath(hour time.Time) string {
+	return hour.UTC().Format(hourLayout)
+}
+
+func partialsHourPrefix(hour time.Time) string {
+	return path.Join(partialsPrefix, hourPath(hour))
+}
+
+func reportPath(hour time.Time, ext string) string {
+	return path.Join(reportsPrefix, hourPath(hour)+ext)
+}
+
+// ReadReport reads the usage report of the input hour. Returns ErrReportNotFound if the report does not exist.
+func ReadReport(ctx context.Context, bkt objstore.BucketReader, hour time.Time, logger log.Logger) (*Report, error) {
+	return readReportObject(ctx, bkt, reportPath(hour, ".json"), logger)
+}
+
+func readReportObject(ctx context.Context, bkt objstore.BucketReader, name string, logger log.Logger) (*Report, error) {
+	reader, err := bkt.Get(ctx, name)
+	if err != nil {
+		if bkt.IsObjNotFoundErr(err) {
+			return nil, ErrReportNotFound
+		}
+		return nil, errors.Wrapf(err, "read usage report %s", name)
+	}
+	defer runutil.CloseWithLogOnErr(logger, reader, "close usage report reader")
+
+	r := &Report{}
+	if err := json.NewDecoder(reader).Decode(r); err != nil {
+		return nil, ErrReportCorrupted
+	}
+	if r.Version != ReportVersion1 {
+		return nil, errors.Errorf("unsupported usage report version %d", r.Version)
+	}
+
+	return r, nil
+}
+
+func writeReportObject(ctx context.Context, bkt objstore.Bucket, name string, r *Report) error {
+	content, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "marshal usage report")
+	}
+
+	return errors.Wrapf(bkt.Upload(ctx, name, bytes.NewReader(content)), "upload usage report %s", name)
+}
+
+// writeReport writes the usage report in both JSON and CSV formats. The JSON one is written last,
+// because its existence signals that the report has been completed.
+func writeReport(ctx context.Context, bkt objstore.Bucket, r *Report) error {
+	var content bytes.Buffer
+	if err := WriteCSV(&content, r); err != nil {
+		return errors.Wrap(err, "encode usage report to CSV")
+	}
+
+	name := reportPath(r.Hour, ".csv")
+	if err := bkt.Upload(ctx, name, &content); err != nil {
+		return errors.Wrapf(err, "upload usage report %s", name)
+	}
+
+	return writeReportObject(ctx, bkt, reportPath(r.Hour, ".json"), r)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package usagemetering
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestMergeReports(t *testing.T) {
+	hour := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	actual := MergeReports(hour,
+		&Report{Version: ReportVersion1, Hour: hour, Tenants: []TenantUsage{
+			{UserID: "user-2", Usage: Usage{SamplesIngested: 10, BytesReceived: 100, StorageBytes: 2000}},
+			{UserID: "user-1", Usage: Usage{ActiveSeriesHours: 1.5}},
+		}},
+		&Report{Version: ReportVersion1, Hour: hour, Tenants: []TenantUsage{
+			{UserID: "user-2", Usage: Usage{SamplesIngested: 5, BytesReceived: 50, StorageBytes: 1000}},
+			{UserID: "user-1", Usage: Usage{ActiveSeriesHours: 2}},
+		}},
+	)
+
+	assert.Equal(t, &Report{Version: ReportVersion1, Hour: hour, Tenants: []TenantUsage{
+		{UserID: "user-1", Usage: Usage{ActiveSeriesHours: 3.5}},
+		{UserID: "user-2", Usage: Usage{SamplesIngested: 15, BytesReceived: 150, StorageBytes: 2000}},
+	}}, actual)
+}
+
+func TestReadReport(t *testing.T) {
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+	hour := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	_, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.Equal(t, ErrReportNotFound, err)
+
+	require.NoError(t, bkt.Upload(ctx, reportPath(hour, ".json"), strings.NewReader("invalid")))
+	_, err = ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.Equal(t, ErrReportCorrupted, err)
+
+	require.NoError(t, bkt.Upload(ctx, reportPath(hour, ".json"), strings.NewReader(`{"version":2}`)))
+	_, err = ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.EqualError(t, err, "unsupported usage report version 2")
+
+	expected := &Report{Version: ReportVersion1, Hour: hour, Tenants: []TenantUsage{{UserID: "user-1", Usage: Usage{SamplesIngested: 10}}}}
+	require.NoError(t, writeReport(ctx, bkt, expected))
+	actual, err := ReadReport(ctx, bkt, hour, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -29,6 +29,14 @@ func Max64(a, b int64) int64 {
 	return b
 }
 
+// MaxUint64 returns the maximum of two uint64s
+func MaxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // Min64 returns the minimum of two int64s
 func Min64(a, b int64) int64 {
 	if a < b {
@@ -10,10 +10,12 @@ import (
 	"encoding/json"
 	"flag"
 	"math"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/grafana/dskit/flagext"
+	"github.com/pkg/errors"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/relabel"
 	"golang.org/x/time/rate"
@@ -39,6 +41,65 @@ type ForwardingRule struct {
 // ForwardingRules are keyed by metric names, excluding labels.
 type ForwardingRules map[string]ForwardingRule
 
+// BlockedQuery is a query pattern blocked by the query-frontend for a tenant.
+type BlockedQuery struct {
+	// Pattern is matched against the normalized PromQL query.
+	Pattern string `yaml:"pattern" json:"pattern" doc:"nocli|description=The query to block. If regex is false, the pattern is matched exactly against the query, once both have been normalized."`
+
+	// Regex defines whether the pattern is a regular expression.
+	Regex bool `yaml:"regex" json:"regex" doc:"nocli|description=If true, the pattern is a regular expression, fully anchored, matched against the normalized query."`
+
+	// re is the compiled regular expression, set when the blocked query is loaded.
+	re *regexp.Regexp
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, compiling the regular expression
+// pattern so that invalid patterns are rejected when the limits are loaded.
+func (b *BlockedQuery) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain BlockedQuery
+	if err := unmarshal((*plain)(b)); err != nil {
+		return err
+	}
+	return b.compile()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, compiling the regular expression
+// pattern so that invalid patterns are rejected when the limits are loaded.
+func (b *BlockedQuery) UnmarshalJSON(data []byte) error {
+	type plain BlockedQuery
+	if err := json.Unmarshal(data, (*plain)(b)); err != nil {
+		return err
+	}
+	return b.compile()
+}
+
+func (b *BlockedQuery) compile() error {
+	b.re = nil
+	if !b.Regex || b.Pattern == "" {
+		return nil
+	}
+
+	re, err := compileBlockedQueryPattern(b.Pattern)
+	if err != nil {
+		return errors.Wrapf(err, "invalid blocked query pattern %q", b.Pattern)
+	}
+	b.re = re
+	return nil
+}
+
+// Regexp returns the fully anchored regular expression of a regex blocked query. The regular
+// expression is compiled once when the blocked query is loaded, and compiled on demand otherwise.
+func (b BlockedQuery) Regexp() (*regexp.Regexp, error) {
+	if b.re != nil {
+		return b.re, nil
+	}
+	return compileBlockedQueryPattern(b.Pattern)
+}
+
+func compileBlockedQueryPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
 // Limits describe all the limits for users; can be used to describe global default
 // limits via flags, or per-user limits via yaml config.
 type Limits struct {
@@ -67,26 +128,43 @@ type Limits struct {
 	MaxGlobalMetricsWithMetadataPerUser int `yaml:"max_global_metadata_per_user" json:"max_global_metadata_per_user"`
 	MaxGlobalMetadataPerMetric          int `yaml:"max_global_metadata_per_metric" json:"max_global_metadata_per_metric"`
 	// Exemplars
-	MaxGlobalExemplarsPerUser int `yaml:"max_global_exemplars_per_user" json:"max_global_exemplars_per_user" category:"experimental"`
+	MaxGlobalExemplarsPerUser int            `yaml:"max_global_exemplars_per_user" json:"max_global_exemplars_per_user" category:"experimental"`
+	ExemplarsRetentionPeriod  model.Duration `yaml:"exemplars_retention_period" json:"exemplars_retention_period" category:"experimental"`
 	// Active series custom trackers
 	ActiveSeriesCustomTrackersConfig activeseries.CustomTrackersConfig `yaml:"active_series_custom_trackers_config" json:"active_series_custom_trackers_config" doc:"description=Additional custom trackers for active metrics. If there are active series matching a provided matcher (map value), the count will be exposed in the custom trackers metric labeled using the tracker name (map key). Zero valued counts are not exposed (and removed when they go back to zero)." category:"advanced"`
 
 	// Querier enforced limits.
-	MaxChunksPerQuery              int            `yaml:"max_fetched_chunks_per_query" json:"max_fetched_chunks_per_query"`
-	MaxFetchedSeriesPerQuery       int            `yaml:"max_fetched_series_per_query" json:"max_fetched_series_per_query"`
-	MaxFetchedChunkBytesPerQuery   int            `yaml:"max_fetched_chunk_bytes_per_query" json:"max_fetched_chunk_bytes_per_query"`
-	MaxQueryLookback               model.Duration `yaml:"max_query_lookback" json:"max_query_lookback"`
-	MaxQueryLength                 model.Duration `yaml:"max_query_length" json:"max_query_length"`
-	MaxQueryParallelism            int            `yaml:"max_query_parallelism" json:"max_query_parallelism"`
-	MaxLabelsQueryLength           model.Duration `yaml:"max_labels_query_length" json:"max_labels_query_length"`
-	MaxCacheFreshness              model.Duration `yaml:"max_cache_freshness" json:"max_cache_freshness" category:"advanced"`
-	MaxQueriersPerTenant           int            `yaml:"max_queriers_per_tenant" json:"max_queriers_per_tenant"`
-	QueryShardingTotalShards       int            `yaml:"query_sharding_total_shards" json:"query_sharding_total_shards"`
-	QueryShardingMaxShardedQueries int            `yaml:"query_sharding_max_sharded_queries" json:"query_sharding_max_sharded_queries"`
+	MaxChunksPerQuery                       int            `yaml:"max_fetched_chunks_per_query" json:"max_fetched_chunks_per_query"`
+	MaxFetchedSeriesPerQuery                int            `yaml:"max_fetched_series_per_query" json:"max_fetched_series_per_query"`
+	MaxFetchedChunkBytesPerQuery            int            `yaml:"max_fetched_chunk_bytes_per_query" json:"max_fetched_chunk_bytes_per_query"`
+	MaxQueryLookback                        model.Duration `yaml:"max_query_lookback" json:"max_query_lookback"`
+	MaxQueryLength                          model.Duration `yaml:"max_query_length" json:"max_query_length"`
+	MaxQueryParallelism                     int            `yaml:"max_query_parallelism" json:"max_query_parallelism"`
+	MaxLabelsQueryLength                    model.Duration `yaml:"max_labels_query_length" json:"max_labels_query_length"`
+	MaxCacheFreshness                       model.Duration `yaml:"max_cache_freshness" json:"max_cache_freshness" category:"advanced"`
+	MaxQueriersPerTenant                    int            `yaml:"max_queriers_per_tenant" json:"max_queriers_per_tenant"`
+	QueryShardingTotalShards                int            `yaml:"query_sharding_total_shards" json:"query_sharding_total_shards"`
+	QueryShardingMaxShardedQueries          int            `yaml:"query_sharding_max_sharded_queries" json:"query_sharding_max_sharded_queries"`
+	QueryShardingApproximateQuantileEnabled bool           `yaml:"query_sharding_approximate_quantile_enabled" json:"query_sharding_approximate_quantile_enabled" category:"experimental"`
+	SplitInstantQueriesByInterval           model.Duration `yaml:"split_instant_queries_by_interval" json:"split_instant_queries_by_interval" category:"experimental"`
+	PartialResultsEnabled                   bool           `yaml:"partial_results_enabled" json:"partial_results_enabled" category:"experimental"`
+	// Query-frontend rate limits
+	RangeQueryRateLimit    float64 `yaml:"range_query_rate_limit" json:"range_query_rate_limit" category:"experimental"`
+	RangeQueryBurstSize    int     `yaml:"range_query_burst_size" json:"range_query_burst_size" category:"experimental"`
+	InstantQueryRateLimit  float64 `yaml:"instant_query_rate_limit" json:"instant_query_rate_limit" category:"experimental"`
+	InstantQueryBurstSize  int     `yaml:"instant_query_burst_size" json:"instant_query_burst_size" category:"experimental"`
+	MetadataQueryRateLimit float64 `yaml:"metadata_query_rate_limit" json:"metadata_query_rate_limit" category:"experimental"`
+	MetadataQueryBurstSize int     `yaml:"metadata_query_burst_size" json:"metadata_query_burst_size" category:"experimental"`
+	// Blocked queries
+	BlockedQueries []BlockedQuery `yaml:"blocked_queries,omitempty" json:"blocked_queries,omitempty" doc:"nocli|description=List of queries to block. Blocked queries are rejected by the query-frontend before being queued." category:"experimental"`
+	// Query log
+	QueryLogSamplingRatio float64 `yaml:"query_log_sampling_ratio" json:"query_log_sampling_ratio" category:"experimental"`
 	// Cardinality
 	CardinalityAnalysisEnabled                    bool `yaml:"cardinality_analysis_enabled" json:"cardinality_analysis_enabled"`
 	LabelNamesAndValuesResultsMaxSizeBytes        int  `yaml:"label_names_and_values_results_max_size_bytes" json:"label_names_and_values_results_max_size_bytes"`
 	LabelValuesMaxCardinalityLabelNamesPerRequest int  `yaml:"label_values_max_cardinality_label_names_per_request" json:"label_values_max_cardinality_label_names_per_request"`
+	ActiveSeriesResultsMaxSizeBytes               int  `yaml:"active_series_results_max_size_bytes" json:"active_series_results_max_size_bytes" category:"experimental"`
+	CardinalityAnalysisMaxBlocksLabelNames        int  `yaml:"cardinality_analysis_max_blocks_label_names" json:"cardinality_analysis_max_blocks_label_names" category:"experimental"`
 
 	// Ruler defaults and limits.
 	RulerEvaluationDelay        model.Duration `yaml:"ruler_evaluation_delay_duration" json:"ruler_evaluation_delay_duration"`
@@ -95,7 +173,11 @@ type Limits struct {
 	RulerMaxRuleGroupsPerTenant int            `yaml:"ruler_max_rule_groups_per_tenant" json:"ruler_max_rule_groups_per_tenant"`
 
 	// Store-gateway.
-	StoreGatewayTenantShardSize int `yaml:"store_gateway_tenant_shard_size" json:"store_gateway_tenant_shard_size"`
+	StoreGatewayTenantShardSize                             int `yaml:"store_gateway_tenant_shard_size" json:"store_gateway_tenant_shard_size"`
+	StoreGatewayMaxSeriesPerRequest                         int `yaml:"store_gateway_max_series_per_request" json:"store_gateway_max_series_per_request" category:"experimental"`
+	StoreGatewayMaxConcurrentSeriesRequests                 int `yaml:"store_gateway_max_concurrent_series_requests" json:"store_gateway_max_concurrent_series_requests" category:"experimental"`
+	StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName int `yaml:"store_gateway_label_values_cardinality_max_values_per_label_name" json:"store_gateway_label_values_cardinality_max_values_per_label_name" category:"experimental"`
+	StoreGatewayMaxExemplarsPerRequest                      int `yaml:"store_gateway_max_exemplars_per_request" json:"store_gateway_max_exemplars_per_request" category:"experimental"`
 
 	// Compactor.
 	CompactorBlocksRetentionPeriod model.Duration `yaml:"compactor_blocks_retention_period" json:"compactor_blocks_retention_period"`
@@ -150,6 +232,7 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxGlobalMetricsWithMetadataPerUser, "ingester.max-global-metadata-per-user", 0, "The maximum number of active metrics with metadata per tenant, across the cluster. 0 to disable.")
 	f.IntVar(&l.MaxGlobalMetadataPerMetric, "ingester.max-global-metadata-per-metric", 0, "The maximum number of metadata per metric, across the cluster. 0 to disable.")
 	f.IntVar(&l.MaxGlobalExemplarsPerUser, "ingester.max-global-exemplars-per-user", 0, "The maximum number of exemplars in memory, across the cluster. 0 to disable exemplars ingestion.")
+	f.Var(&l.ExemplarsRetentionPeriod, "ingester.exemplars-retention-period", "How long exemplars are retained in the long-term storage. When enabled, ingesters upload the in-memory exemplars alongside each shipped block, compactors carry them over to the compacted blocks and queriers query them from the store-gateways too. 0 to disable storing exemplars in the long-term storage.")
 	f.Var(&l.ActiveSeriesCustomTrackersConfig, "ingester.active-series-custom-trackers", "Additional active series metrics, matching the provided matchers. Matchers should be in form <name>:<matcher>, like 'foobar:{foo=\"bar\"}'. Multiple matchers can be provided either providing the flag multiple times or providing multiple semicolon-separated values to a single flag.")
 
 	f.IntVar(&l.MaxChunksPerQuery, "querier.max-fetched-chunks-per-query", 2e6, "Maximum number of chunks that can be fetched in a single query from ingesters and long-term storage. This limit is enforced in the querier, ruler and store-gateway. 0 to disable.")
@@ -160,13 +243,25 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxQueryParallelism, "querier.max-query-parallelism", 14, "Maximum number of split (by time) or partial (by shard) queries that will be scheduled in parallel by the query-frontend for a single input query. This limit is introduced to have a fairer query scheduling and avoid a single query over a large time range saturating all available queriers.")
 	f.Var(&l.MaxLabelsQueryLength, "store.max-labels-query-length", "Limit the time range (end - start time) of series, label names and values queries. This limit is enforced in the querier. If the requested time range is outside the allowed range, the request will not fail but will be manipulated to only query data within the allowed time range. 0 to disable.")
 	f.IntVar(&l.LabelNamesAndValuesResultsMaxSizeBytes, "querier.label-names-and-values-results-max-size-bytes", 400*1024*1024, "Maximum size in bytes of distinct label names and values. When querier receives response from ingester, it merges the response with responses from other ingesters. This maximum size limit is applied to the merged(distinct) results. If the limit is reached, an error is returned.")
+	f.BoolVar(&l.PartialResultsEnabled, "querier.partial-results-enabled", false, "Return partial results, with warnings describing the data which couldn't be fetched, instead of failing the query when some blocks can't be queried from the store-gateways or ingesters can't be queried. Partial results can also be requested per-query using the partial_response=true parameter.")
 	f.BoolVar(&l.CardinalityAnalysisEnabled, "querier.cardinality-analysis-enabled", false, "Enables endpoints used for cardinality analysis.")
 	f.IntVar(&l.LabelValuesMaxCardinalityLabelNamesPerRequest, "querier.label-values-max-cardinality-label-names-per-request", 100, "Maximum number of label names allowed to be queried in a single /api/v1/cardinality/label_values API call.")
+	f.IntVar(&l.CardinalityAnalysisMaxBlocksLabelNames, "querier.cardinality-analysis-max-blocks-label-names", 500, "Maximum number of label names a single /api/v1/cardinality/label_names API call with source=blocks can fetch the values of from the store-gateways. The values of each label name are fetched with a separate request to the store-gateways. Requests exceeding the limit are rejected. 0 to disable.")
+	f.IntVar(&l.ActiveSeriesResultsMaxSizeBytes, "querier.active-series-results-max-size-bytes", 400*1024*1024, "Maximum size in bytes of distinct active series. When querier receives response from ingester, it merges the response with responses from other ingesters. This maximum size limit is applied to the merged(distinct) results. If the limit is reached, an error is returned.")
 	_ = l.MaxCacheFreshness.Set("1m")
 	f.Var(&l.MaxCacheFreshness, "query-frontend.max-cache-freshness", "Most recent allowed cacheable result per-tenant, to prevent caching very recent results that might still be in flux.")
 	f.IntVar(&l.MaxQueriersPerTenant, "query-frontend.max-queriers-per-tenant", 0, "Maximum number of queriers that can handle requests for a single tenant. If set to 0 or value higher than number of available queriers, *all* queriers will handle requests for the tenant. Each frontend (or query-scheduler, if used) will select the same set of queriers for the same tenant (given that all queriers are connected to all frontends / query-schedulers). This option only works with queriers connecting to the query-frontend / query-scheduler, not when using downstream URL.")
 	f.IntVar(&l.QueryShardingTotalShards, "query-frontend.query-sharding-total-shards", 16, "The amount of shards to use when doing parallelisation via query sharding by tenant. 0 to disable query sharding for tenant. Query sharding implementation will adjust the number of query shards based on compactor shards. This allows querier to not search the blocks which cannot possibly have the series for given query shard.")
 	f.IntVar(&l.QueryShardingMaxShardedQueries, "query-frontend.query-sharding-max-sharded-queries", 128, "The max number of sharded queries that can be run for a given received query. 0 to disable limit.")
+	f.BoolVar(&l.QueryShardingApproximateQuantileEnabled, "query-frontend.query-sharding-approximate-quantile-enabled", false, "True to shard quantile() aggregations. The result of a sharded quantile() is an approximation, computed as the average of the per-shard quantiles weighted by the per-shard number of series.")
+	f.Float64Var(&l.RangeQueryRateLimit, "query-frontend.range-query-rate-limit", 0, "Per-tenant range queries rate limit, in requests per second, enforced by the query-frontend. The limit is shared across all query-frontend replicas in the ring. 0 to disable.")
+	f.IntVar(&l.RangeQueryBurstSize, "query-frontend.range-query-burst-size", 0, "Per-tenant allowed range queries burst size. 0 to use the rate limit, rounded up, as burst size.")
+	f.Float64Var(&l.InstantQueryRateLimit, "query-frontend.instant-query-rate-limit", 0, "Per-tenant instant queries rate limit, in requests per second, enforced by the query-frontend. The limit is shared across all query-frontend replicas in the ring. 0 to disable.")
+	f.IntVar(&l.InstantQueryBurstSize, "query-frontend.instant-query-burst-size", 0, "Per-tenant allowed instant queries burst size. 0 to use the rate limit, rounded up, as burst size.")
+	f.Float64Var(&l.MetadataQueryRateLimit, "query-frontend.metadata-query-rate-limit", 0, "Per-tenant series, label names, label values and metadata queries rate limit, in requests per second, enforced by the query-frontend. The limit is shared across all query-frontend replicas in the ring. 0 to disable.")
+	f.IntVar(&l.MetadataQueryBurstSize, "query-frontend.metadata-query-burst-size", 0, "Per-tenant allowed series, label names, label values and metadata queries burst size. 0 to use the rate limit, rounded up, as burst size.")
+	f.Float64Var(&l.QueryLogSamplingRatio, "query-frontend.query-log-sampling-ratio", 1, "Ratio of the tenant's queries written to the query log, if the query log is enabled. 1 to log all queries, 0 to log none.")
+	f.Var(&l.SplitInstantQueriesByInterval, "query-frontend.split-instant-queries-by-interval", "Split the range vector selectors of instant queries by an interval and execute the partial queries in parallel. Supported functions are sum_over_time, count_over_time, max_over_time, min_over_time, rate and increase. 0 to disable.")
 
 	f.Var(&l.RulerEvaluationDelay, "ruler.evaluation-delay-duration", "Duration to delay the evaluation of rules to ensure the underlying metrics have been pushed.")
 	f.IntVar(&l.RulerTenantShardSize, "ruler.tenant-shard-size", 0, "The tenant's shard size when sharding is used by ruler. Value of 0 disables shuffle sharding for the tenant, and tenant rules will be sharded across all ruler replicas.")
@@ -180,6 +275,10 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 
 	// Store-gateway.
 	f.IntVar(&l.StoreGatewayTenantShardSize, "store-gateway.tenant-shard-size", 0, "The tenant's shard size, used when store-gateway sharding is enabled. Value of 0 disables shuffle sharding for the tenant, that is all tenant blocks are sharded across all store-gateway replicas.")
+	f.IntVar(&l.StoreGatewayMaxSeriesPerRequest, "store-gateway.max-series-per-request", 0, "Maximum number of series a single request can touch in each store-gateway, counting a series once for each block it's fetched from. Requests exceeding the limit are rejected. 0 to disable.")
+	f.IntVar(&l.StoreGatewayMaxConcurrentSeriesRequests, "store-gateway.max-concurrent-series-requests", 0, "Maximum number of series requests a tenant can run concurrently in each store-gateway. Requests exceeding the limit are rejected. 0 to disable.")
+	f.IntVar(&l.StoreGatewayMaxExemplarsPerRequest, "store-gateway.max-exemplars-per-request", 100000, "Maximum number of exemplars a single exemplars request can read in each store-gateway, counting an exemplar once for each block it's read from. Requests exceeding the limit are rejected. 0 to disable.")
+	f.IntVar(&l.StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName, "store-gateway.label-values-cardinality-max-values-per-label-name", 10000, "Maximum number of values a label name can have in a block to be queried by a single label values cardinality request in each store-gateway. Requests exceeding the limit are rejected. 0 to disable.")
 
 	// Alertmanager.
 	f.Var(&l.AlertmanagerReceiversBlockCIDRNetworks, "alertmanager.receivers-firewall-block-cidr-networks", "Comma-separated list of network CIDRs to block in Alertmanager receiver integrations.")
@@ -296,6 +395,17 @@ func (o *Overrides) LabelValuesMaxCardinalityLabelNamesPerRequest(userID string)
 	return o.getOverridesForUser(userID).LabelValuesMaxCardinalityLabelNamesPerRequest
 }
 
+// CardinalityAnalysisMaxBlocksLabelNames returns the maximum number of label names whose values can be
+// fetched from the store-gateways by a single label names cardinality request.
+func (o *Overrides) CardinalityAnalysisMaxBlocksLabelNames(userID string) int {
+	return o.getOverridesForUser(userID).CardinalityAnalysisMaxBlocksLabelNames
+}
+
+// ActiveSeriesResultsMaxSizeBytes returns the maximum size in bytes of distinct active series.
+func (o *Overrides) ActiveSeriesResultsMaxSizeBytes(userID string) int {
+	return o.getOverridesForUser(userID).ActiveSeriesResultsMaxSizeBytes
+}
+
 // IngestionBurstSize returns the burst size for ingestion rate.
 func (o *Overrides) IngestionBurstSize(userID string) int {
 	return o.getOverridesForUser(userID).IngestionBurstSize
@@ -375,6 +485,12 @@ func (o *Overrides) MaxFetchedChunkBytesPerQuery(userID string) int {
 	return o.getOverridesForUser(userID).MaxFetchedChunkBytesPerQuery
 }
 
+// PartialResultsEnabled returns whether partial results are returned, instead of failing the query,
+// when some data can't be fetched from the store-gateways or ingesters.
+func (o *Overrides) PartialResultsEnabled(userID string) bool {
+	return o.getOverridesForUser(userID).PartialResultsEnabled
+}
+
 // MaxQueryLookback returns the max lookback period of queries.
 func (o *Overrides) MaxQueryLookback(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MaxQueryLookback)
@@ -413,6 +529,58 @@ func (o *Overrides) QueryShardingTotalShards(userID string) int {
 	return o.getOverridesForUser(userID).QueryShardingTotalShards
 }
 
+// QueryShardingApproximateQuantileEnabled returns whether quantile() aggregations can be sharded
+// approximating their result.
+func (o *Overrides) QueryShardingApproximateQuantileEnabled(userID string) bool {
+	return o.getOverridesForUser(userID).QueryShardingApproximateQuantileEnabled
+}
+
+// SplitInstantQueriesByInterval returns the interval used to split the range vector
+// selectors of instant queries.
+func (o *Overrides) SplitInstantQueriesByInterval(userID string) time.Duration {
+	return time.Duration(o.getOverridesForUser(userID).SplitInstantQueriesByInterval)
+}
+
+// RangeQueryRateLimit returns the limit on range queries per second enforced by the query-frontend.
+func (o *Overrides) RangeQueryRateLimit(userID string) float64 {
+	return o.getOverridesForUser(userID).RangeQueryRateLimit
+}
+
+// RangeQueryBurstSize returns the burst size for range queries.
+func (o *Overrides) RangeQueryBurstSize(userID string) int {
+	return o.getOverridesForUser(userID).RangeQueryBurstSize
+}
+
+// InstantQueryRateLimit returns the limit on instant queries per second enforced by the query-frontend.
+func (o *Overrides) InstantQueryRateLimit(userID string) float64 {
+	return o.getOverridesForUser(userID).InstantQueryRateLimit
+}
+
+// InstantQueryBurstSize returns the burst size for instant queries.
+func (o *Overrides) InstantQueryBurstSize(userID string) int {
+	return o.getOverridesForUser(userID).InstantQueryBurstSize
+}
+
+// MetadataQueryRateLimit returns the limit on metadata queries per second enforced by the query-frontend.
+func (o *Overrides) MetadataQueryRateLimit(userID string) float64 {
+	return o.getOverridesForUser(userID).MetadataQueryRateLimit
+}
+
+// MetadataQueryBurstSize returns the burst size for metadata queries.
+func (o *Overrides) MetadataQueryBurstSize(userID string) int {
+	return o.getOverridesForUser(userID).MetadataQueryBurstSize
+}
+
+// BlockedQueries returns the queries blocked by the query-frontend for a given user.
+func (o *Overrides) BlockedQueries(userID string) []BlockedQuery {
+	return o.getOverridesForUser(userID).BlockedQueries
+}
+
+// QueryLogSamplingRatio returns the ratio of the user's queries written to the query log.
+func (o *Overrides) QueryLogSamplingRatio(userID string) float64 {
+	return o.getOverridesForUser(userID).QueryLogSamplingRatio
+}
+
 // QueryShardingMaxShardedQueries returns the max number of sharded queries that can
 // be run for a given received query. 0 to disable limit.
 func (o *Overrides) QueryShardingMaxShardedQueries(userID string) int {
@@ -439,6 +607,11 @@ func (o *Overrides) MaxGlobalExemplarsPerUser(userID string) int {
 	return o.getOverridesForUser(userID).MaxGlobalExemplarsPerUser
 }
 
+// ExemplarsRetentionPeriod returns how long exemplars are retained in the long-term storage. 0 when disabled.
+func (o *Overrides) ExemplarsRetentionPeriod(userID string) time.Duration {
+	return time.Duration(o.getOverridesForUser(userID).ExemplarsRetentionPeriod)
+}
+
 func (o *Overrides) ActiveSeriesCustomTrackersConfig(userID string) activeseries.CustomTrackersConfig {
 	return o.getOverridesForUser(userID).ActiveSeriesCustomTrackersConfig
 }
@@ -498,6 +671,28 @@ func (o *Overrides) StoreGatewayTenantShardSize(userID string) int {
 	return o.getOverridesForUser(userID).StoreGatewayTenantShardSize
 }
 
+// StoreGatewayMaxSeriesPerRequest returns the maximum number of series a single request can touch in each store-gateway.
+func (o *Overrides) StoreGatewayMaxSeriesPerRequest(userID string) int {
+	return o.getOverridesForUser(userID).StoreGatewayMaxSeriesPerRequest
+}
+
+// StoreGatewayMaxConcurrentSeriesRequests returns the maximum number of series requests a tenant can run concurrently
+// in each store-gateway.
+func (o *Overrides) StoreGatewayMaxConcurrentSeriesRequests(userID string) int {
+	return o.getOverridesForUser(userID).StoreGatewayMaxConcurrentSeriesRequests
+}
+
+// StoreGatewayMaxExemplarsPerRequest returns the maximum number of exemplars a single exemplars request can read in each store-gateway.
+func (o *Overrides) StoreGatewayMaxExemplarsPerRequest(userID string) int {
+	return o.getOverridesForUser(userID).StoreGatewayMaxExemplarsPerRequest
+}
+
+// StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName returns the maximum number of values a label name can have
+// in a block to be queried by a single label values cardinality request in each store-gateway.
+func (o *Overrides) StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName(userID string) int {
+	return o.getOverridesForUser(userID).StoreGatewayLabelValuesCardinalityMaxValuesPerLabelName
+}
+
 // MaxHAClusters returns maximum number of clusters that HA tracker will track for a user.
 func (o *Overrides) MaxHAClusters(user string) int {
 	return o.getOverridesForUser(user).HAMaxClusters
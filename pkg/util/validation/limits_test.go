@@ -187,6 +187,45 @@ metric_relabel_configs:
 	assert.Equal(t, []*relabel.Config{&exp}, l.MetricRelabelConfigs)
 }
 
+func TestBlockedQueriesLimitsLoading(t *testing.T) {
+	SetDefaultLimitsForYAMLUnmarshalling(Limits{})
+
+	t.Run("yaml", func(t *testing.T) {
+		l := Limits{}
+		require.NoError(t, yaml.UnmarshalStrict([]byte(`
+blocked_queries:
+- pattern: sum(up)
+- pattern: count\(.*\)
+  regex: true
+`), &l))
+
+		require.Len(t, l.BlockedQueries, 2)
+		assert.Nil(t, l.BlockedQueries[0].re)
+		require.NotNil(t, l.BlockedQueries[1].re)
+		assert.True(t, l.BlockedQueries[1].re.MatchString("count(up)"))
+		assert.False(t, l.BlockedQueries[1].re.MatchString("sum(count(up))"))
+
+		err := yaml.UnmarshalStrict([]byte(`
+blocked_queries:
+- pattern: sum(
+  regex: true
+`), &Limits{})
+		assert.EqualError(t, err, "invalid blocked query pattern \"sum(\": error parsing regexp: missing closing ): `^(?:sum()$`")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		l := Limits{}
+		require.NoError(t, json.Unmarshal([]byte(`{"blocked_queries": [{"pattern": "count\\(.*\\)", "regex": true}]}`), &l))
+
+		require.Len(t, l.BlockedQueries, 1)
+		require.NotNil(t, l.BlockedQueries[0].re)
+		assert.True(t, l.BlockedQueries[0].re.MatchString("count(up)"))
+
+		err := json.Unmarshal([]byte(`{"blocked_queries": [{"pattern": "sum(", "regex": true}]}`), &Limits{})
+		assert.Error(t, err)
+	})
+}
+
 func TestSmallestPositiveIntPerTenant(t *testing.T) {
 	tenantLimits := map[string]*Limits{
 		"tenant-a": {
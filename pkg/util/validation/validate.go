@@ -38,6 +38,9 @@ const (
 	// ErrQueryTooLong is used in chunk store, querier and query frontend.
 	ErrQueryTooLong = "the query time range exceeds the limit (query length: %s, limit: %s)"
 
+	// ErrQueryBlocked is used in the query-frontend.
+	ErrQueryBlocked = "the query has been blocked by the cluster administrator because it matches the blocked query pattern %q"
+
 	missingMetricName      = "missing_metric_name"
 	invalidMetricName      = "metric_name_invalid"
 	maxLabelNamesPerSeries = "max_label_names_per_series"
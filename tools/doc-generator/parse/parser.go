@@ -123,10 +123,12 @@ func Flags(cfg flagext.RegistererWithLogger, logger log.Logger) map[uintptr]*fla
 // Config returns a slice of ConfigBlocks. The first ConfigBlock is a recursively expanded cfg.
 // The remaining entries in the slice are all (root or not) ConfigBlocks.
 func Config(cfg interface{}, flags map[uintptr]*flag.Flag, rootBlocks []RootBlock) ([]*ConfigBlock, error) {
-	return config(nil, cfg, flags, rootBlocks)
+	return config(nil, cfg, "", flags, rootBlocks)
 }
 
-func config(block *ConfigBlock, cfg interface{}, flags map[uintptr]*flag.Flag, rootBlocks []RootBlock) ([]*ConfigBlock, error) {
+// config inspects the input cfg and adds its entries to the input block. The fields with no category
+// inherit the input category, which is the category of the parent struct field, if any.
+func config(block *ConfigBlock, cfg interface{}, category string, flags map[uintptr]*flag.Flag, rootBlocks []RootBlock) ([]*ConfigBlock, error) {
 	blocks := []*ConfigBlock{}
 
 	// If the input block is nil it means we're generating the doc for the top-level block
@@ -155,6 +157,10 @@ func config(block *ConfigBlock, cfg interface{}, flags map[uintptr]*flag.Flag, r
 		field := t.Field(i)
 		fieldValue := v.FieldByIndex(field.Index)
 
+		if category != "" && field.Tag.Get("category") == "" {
+			field.Tag = reflect.StructTag(fmt.Sprintf(`%s category:"%s"`, field.Tag, category))
+		}
+
 		// Skip fields explicitly marked as "hidden" in the doc
 		if isFieldHidden(field) {
 			continue
@@ -237,7 +243,7 @@ func config(block *ConfigBlock, cfg interface{}, flags map[uintptr]*flag.Flag, r
 			}
 
 			// Recursively generate the doc for the sub-block
-			otherBlocks, err := config(subBlock, fieldValue.Interface(), flags, rootBlocks)
+			otherBlocks, err := config(subBlock, fieldValue.Interface(), field.Tag.Get("category"), flags, rootBlocks)
 			if err != nil {
 				return nil, err
 			}
@@ -262,7 +268,7 @@ func config(block *ConfigBlock, cfg interface{}, flags map[uintptr]*flag.Flag, r
 				}
 				kind = KindSlice
 
-				_, err = config(element, reflect.New(field.Type.Elem()).Interface(), flags, rootBlocks)
+				_, err = config(element, reflect.New(field.Type.Elem()).Interface(), field.Tag.Get("category"), flags, rootBlocks)
 				if err != nil {
 					return nil, errors.Wrapf(err, "couldn't inspect slice, element_type=%s", field.Type.Elem())
 				}
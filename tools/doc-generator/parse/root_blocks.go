@@ -27,6 +27,7 @@ import (
 	"github.com/grafana/mimir/pkg/storage/bucket/s3"
 	"github.com/grafana/mimir/pkg/storage/tsdb"
 	"github.com/grafana/mimir/pkg/storegateway"
+	"github.com/grafana/mimir/pkg/usagemetering"
 	"github.com/grafana/mimir/pkg/util/validation"
 )
 
@@ -134,6 +135,11 @@ var (
 			StructType: reflect.TypeOf(storegateway.Config{}),
 			Desc:       "The store_gateway block configures the store-gateway component.",
 		},
+		{
+			Name:       "usage_metering",
+			StructType: reflect.TypeOf(usagemetering.Config{}),
+			Desc:       "The usage_metering block configures the per-tenant usage metering and the storage of the usage reports.",
+		},
 		{
 			Name:       "sse",
 			StructType: reflect.TypeOf(s3.SSEConfig{}),